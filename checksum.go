@@ -0,0 +1,37 @@
+package gxpdf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// VerifyChecksum reports whether the file at path still matches the
+// checksum embedded by creator.EmbedChecksum.
+//
+// It returns false if the file was modified after it was written, and
+// ErrNoChecksum if the file has no embedded checksum.
+//
+// Example:
+//
+//	ok, err := gxpdf.VerifyChecksum("audited.pdf")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !ok {
+//	    log.Println("document was altered")
+//	}
+func VerifyChecksum(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("gxpdf: failed to read %s: %w", path, err)
+	}
+
+	ok, err := writer.VerifyChecksum(data)
+	if errors.Is(err, writer.ErrNoChecksum) {
+		return false, ErrNoChecksum
+	}
+	return ok, err
+}
@@ -0,0 +1,92 @@
+package gxpdf_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf"
+	"github.com/coregx/gxpdf/creator"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	c := creator.New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := page.AddText("Audit trail", 100, 700, creator.Helvetica, 12); err != nil {
+		t.Fatal(err)
+	}
+	c.EmbedChecksum()
+
+	path := filepath.Join(t.TempDir(), "audited.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+
+	ok, err := gxpdf.VerifyChecksum(path)
+	if err != nil || !ok {
+		t.Fatalf("VerifyChecksum() = %v, %v; want true, nil", ok, err)
+	}
+
+	// The document must still open normally.
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	_ = doc.Close()
+
+	// Flip one byte of the page content.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := bytes.Index(data, []byte("/MediaBox"))
+	if i < 0 {
+		t.Fatal("no /MediaBox in output")
+	}
+	data[i+1] = 'X'
+	tampered := filepath.Join(t.TempDir(), "tampered.pdf")
+	if err := os.WriteFile(tampered, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err = gxpdf.VerifyChecksum(tampered)
+	if err != nil || ok {
+		t.Errorf("VerifyChecksum(tampered) = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestVerifyChecksum_WriteToMatchesFile(t *testing.T) {
+	c := creator.New()
+	if _, err := c.NewPage(); err != nil {
+		t.Fatal(err)
+	}
+	c.EmbedChecksum()
+
+	data, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bytes.pdf")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := gxpdf.VerifyChecksum(path)
+	if err != nil || !ok {
+		t.Errorf("VerifyChecksum() = %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestVerifyChecksum_NoChecksum(t *testing.T) {
+	path := writeTestPDF(t, "Plain")
+
+	_, err := gxpdf.VerifyChecksum(path)
+	if !errors.Is(err, gxpdf.ErrNoChecksum) {
+		t.Errorf("VerifyChecksum() error = %v, want ErrNoChecksum", err)
+	}
+}
@@ -0,0 +1,44 @@
+package creator
+
+import "github.com/coregx/gxpdf/internal/writer"
+
+// AcroFormOptions configures the document-level form (/AcroForm) entries.
+type AcroFormOptions struct {
+	// NeedAppearances asks viewers to regenerate the appearance of every
+	// field from its value (/NeedAppearances true). Enable it for forms
+	// whose values are filled in programmatically, so that viewers do not
+	// show stale or missing appearances.
+	NeedAppearances bool
+
+	// CalculationOrder lists the names of calculated fields in the order
+	// the viewer recalculates them (/CO), e.g. line totals before the
+	// grand total. Names that match no field are ignored.
+	CalculationOrder []string
+}
+
+// SetAcroFormOptions sets the form options of the document. Pass nil for
+// the defaults: /NeedAppearances true and no calculation order.
+//
+// Example:
+//
+//	c.SetAcroFormOptions(&creator.AcroFormOptions{
+//	    NeedAppearances:  true,
+//	    CalculationOrder: []string{"subtotal", "tax", "total"},
+//	})
+//
+// Reference: PDF 1.7 Specification, Section 12.7.2 (Interactive Form Dictionary).
+func (c *Creator) SetAcroFormOptions(opts *AcroFormOptions) {
+	c.acroFormOpts = opts
+}
+
+// toWriter converts the options to the writer's AcroForm options.
+// Returns nil for nil options.
+func (o *AcroFormOptions) toWriter() *writer.AcroFormOptions {
+	if o == nil {
+		return nil
+	}
+	return &writer.AcroFormOptions{
+		NeedAppearances:  o.NeedAppearances,
+		CalculationOrder: append([]string(nil), o.CalculationOrder...),
+	}
+}
@@ -369,6 +369,7 @@ func (a *Appender) AddPage(size PageSize) (*Page, error) {
 		},
 		textOps:     make([]TextOperation, 0),
 		graphicsOps: make([]GraphicsOperation, 0),
+		nextPage:    func() (*Page, error) { return a.AddPage(size) },
 	}
 
 	// Track new page.
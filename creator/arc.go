@@ -0,0 +1,189 @@
+package creator
+
+import (
+	"errors"
+	"math"
+)
+
+// maxArcSegmentAngle is the largest sweep, in degrees, approximated by one
+// cubic Bézier segment. Up to 90° the radial error stays below 0.03% of
+// the radius.
+const maxArcSegmentAngle = 90.0
+
+// ArcOptions configures arc drawing.
+type ArcOptions struct {
+	// StrokeColor is the arc color (nil = no stroke).
+	// If StrokeColorCMYK is set, this field is ignored.
+	StrokeColor *Color
+
+	// StrokeColorCMYK is the arc color in CMYK (nil = no stroke).
+	// If set, this takes precedence over StrokeColor (RGB).
+	StrokeColorCMYK *ColorCMYK
+
+	// StrokeWidth is the arc width in points (default: 1.0).
+	StrokeWidth float64
+
+	// FillColor is the fill color (nil = no fill).
+	// A filled arc is closed by a chord, or by two radii if Pie is set.
+	// Mutually exclusive with FillGradient and FillColorCMYK.
+	// If FillColorCMYK is set, this field is ignored.
+	FillColor *Color
+
+	// FillColorCMYK is the fill color in CMYK (nil = no fill).
+	// If set, this takes precedence over FillColor (RGB).
+	// Mutually exclusive with FillGradient.
+	FillColorCMYK *ColorCMYK
+
+	// FillGradient is the gradient fill (nil = no gradient fill).
+	// Mutually exclusive with FillColor and FillColorCMYK.
+	FillGradient *Gradient
+
+	// Opacity is the arc opacity (0.0 = transparent, 1.0 = opaque).
+	// Optional. If set, applies transparency via ExtGState.
+	// Affects both fill and stroke.
+	// Range: [0.0, 1.0]
+	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
+
+	// Pie draws a sector: the path runs from the center to the start of
+	// the arc and back from its end, as used for pie charts.
+	Pie bool
+}
+
+// hasFill reports whether the arc is filled.
+func (o *ArcOptions) hasFill() bool {
+	return o.FillColor != nil || o.FillColorCMYK != nil || o.FillGradient != nil
+}
+
+// DrawArc draws a circular arc centered at (cx, cy) from startAngle to
+// endAngle.
+//
+// Angles are in degrees, measured counterclockwise from the positive
+// x-axis. The arc runs counterclockwise when endAngle > startAngle and
+// clockwise otherwise; sweeps beyond 360° are drawn as a full circle.
+// The arc is approximated by cubic Bézier curves of at most 90° each.
+//
+// Parameters:
+//   - cx, cy: Center coordinates
+//   - radius: Arc radius
+//   - startAngle, endAngle: Start and end angles in degrees
+//   - opts: Arc options (stroke, fill, opacity, pie slice)
+//
+// Example (gauge):
+//
+//	opts := &creator.ArcOptions{
+//	    StrokeColor: &creator.Blue,
+//	    StrokeWidth: 8.0,
+//	}
+//	err := page.DrawArc(300, 400, 100, 180, 45, opts)
+//
+// Example (pie slice):
+//
+//	opts := &creator.ArcOptions{
+//	    FillColor: &creator.Red,
+//	    Pie:       true,
+//	}
+//	err := page.DrawArc(300, 400, 100, 0, 120, opts)
+func (p *Page) DrawArc(cx, cy, radius, startAngle, endAngle float64, opts *ArcOptions) error {
+	if opts == nil {
+		return errors.New("arc options cannot be nil")
+	}
+
+	if radius < 0 {
+		return errors.New("arc radius must be non-negative")
+	}
+	if startAngle == endAngle {
+		return errors.New("arc start and end angles must differ")
+	}
+
+	if err := validateArcOptions(opts); err != nil {
+		return err
+	}
+
+	center := Point{X: cx, Y: cy}
+	segments := arcSegments(center, radius, startAngle, endAngle)
+	if opts.Pie {
+		// A degenerate curve is the straight radius to the arc start;
+		// closing the path draws the radius back from the arc end.
+		start := segments[0].Start
+		segments = append([]BezierSegment{{Start: center, C1: center, C2: start, End: start}}, segments...)
+	}
+
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+		Type:       GraphicsOpBezier,
+		BezierSegs: segments,
+		ArcOpts:    opts,
+	})
+
+	return nil
+}
+
+// arcSegments approximates the arc from startAngle to endAngle (degrees)
+// with cubic Bézier segments of at most maxArcSegmentAngle each.
+//
+// A segment spanning angle θ has its control points on the tangents at
+// its ends, at distance 4/3·tan(θ/4)·radius.
+func arcSegments(center Point, radius, startAngle, endAngle float64) []BezierSegment {
+	sweep := math.Max(-360, math.Min(360, endAngle-startAngle))
+	n := int(math.Ceil(math.Abs(sweep)/maxArcSegmentAngle - 1e-9))
+	n = max(n, 1)
+
+	step := sweep / float64(n) * math.Pi / 180
+	k := 4.0 / 3.0 * math.Tan(step/4) * radius
+
+	point := func(a float64) Point {
+		return Point{X: center.X + radius*math.Cos(a), Y: center.Y + radius*math.Sin(a)}
+	}
+
+	segments := make([]BezierSegment, n)
+	a := startAngle * math.Pi / 180
+	for i := range segments {
+		b := a + step
+		p0, p3 := point(a), point(b)
+		segments[i] = BezierSegment{
+			Start: p0,
+			C1:    Point{X: p0.X - k*math.Sin(a), Y: p0.Y + k*math.Cos(a)},
+			C2:    Point{X: p3.X + k*math.Sin(b), Y: p3.Y - k*math.Cos(b)},
+			End:   p3,
+		}
+		a = b
+	}
+	return segments
+}
+
+// validateArcOptions validates arc drawing options.
+func validateArcOptions(opts *ArcOptions) error {
+	if opts.StrokeColor != nil {
+		if err := validateColor(*opts.StrokeColor); err != nil {
+			return errors.New("stroke " + err.Error())
+		}
+	}
+
+	if opts.FillColor != nil {
+		if err := validateColor(*opts.FillColor); err != nil {
+			return errors.New("fill " + err.Error())
+		}
+	}
+
+	if opts.StrokeWidth < 0 {
+		return errors.New("stroke width must be non-negative")
+	}
+
+	if opts.StrokeColor == nil && opts.StrokeColorCMYK == nil && !opts.hasFill() {
+		return errors.New("arc must have at least stroke, fill color, or gradient")
+	}
+
+	if opts.FillColor != nil && opts.FillGradient != nil {
+		return errors.New("cannot use both fill color and fill gradient")
+	}
+
+	if opts.FillGradient != nil {
+		if err := opts.FillGradient.Validate(); err != nil {
+			return errors.New("fill gradient: " + err.Error())
+		}
+	}
+
+	return nil
+}
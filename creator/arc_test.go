@@ -0,0 +1,181 @@
+package creator
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestDrawArcSegmentCounts(t *testing.T) {
+	tests := []struct {
+		name         string
+		start, end   float64
+		pie          bool
+		wantSegments int
+	}{
+		{"quarter", 0, 90, false, 1},
+		{"small", 10, 40, false, 1},
+		{"just over a quarter", 0, 91, false, 2},
+		{"half", 0, 180, false, 2},
+		{"three quarters", 45, 315, false, 3},
+		{"clockwise half", 180, 0, false, 2},
+		{"full circle", 0, 360, false, 4},
+		{"beyond full circle", 0, 720, false, 4},
+		{"pie slice adds a radius", 0, 120, true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			if err != nil {
+				t.Fatalf("failed to create page: %v", err)
+			}
+
+			opts := &ArcOptions{StrokeColor: &Black, Pie: tt.pie}
+			if err := page.DrawArc(200, 300, 50, tt.start, tt.end, opts); err != nil {
+				t.Fatalf("DrawArc() error = %v", err)
+			}
+
+			ops := page.GraphicsOperations()
+			if len(ops) != 1 {
+				t.Fatalf("expected 1 graphics operation, got %d", len(ops))
+			}
+			if ops[0].Type != GraphicsOpBezier {
+				t.Errorf("expected bezier operation, got type %d", ops[0].Type)
+			}
+			if got := len(ops[0].BezierSegs); got != tt.wantSegments {
+				t.Errorf("segments = %d, want %d", got, tt.wantSegments)
+			}
+		})
+	}
+}
+
+func TestDrawArcEndpoints(t *testing.T) {
+	segs := arcSegments(Point{X: 100, Y: 100}, 50, 90, 180)
+	if len(segs) != 1 {
+		t.Fatalf("segments = %d, want 1", len(segs))
+	}
+	assertPointNear(t, "start", segs[0].Start, Point{X: 100, Y: 150})
+	assertPointNear(t, "end", segs[0].End, Point{X: 50, Y: 100})
+
+	// Clockwise: from 0° down to -90°.
+	segs = arcSegments(Point{X: 0, Y: 0}, 10, 0, -90)
+	assertPointNear(t, "clockwise end", segs[0].End, Point{X: 0, Y: -10})
+	assertPointNear(t, "clockwise C1", segs[0].C1, Point{X: 10, Y: -10 * 0.5522847498})
+
+	// Points along the curve stay on the circle.
+	for _, seg := range arcSegments(Point{}, 100, 0, 270) {
+		for _, u := range []float64{0.25, 0.5, 0.75} {
+			p := bezierPoint(seg, u)
+			if r := math.Hypot(p.X, p.Y); math.Abs(r-100) > 0.03 {
+				t.Errorf("point at t=%.2f has radius %.4f, want 100", u, r)
+			}
+		}
+	}
+}
+
+func TestDrawArcFullCircleMatchesCircle(t *testing.T) {
+	const cx, cy, r = 150.0, 200.0, 60.0
+	const kappa = 0.5522847498
+
+	// The four quarters of DrawEllipse/DrawCircle, starting at 3 o'clock.
+	want := []BezierSegment{
+		{Start: Point{cx + r, cy}, C1: Point{cx + r, cy + r*kappa}, C2: Point{cx + r*kappa, cy + r}, End: Point{cx, cy + r}},
+		{Start: Point{cx, cy + r}, C1: Point{cx - r*kappa, cy + r}, C2: Point{cx - r, cy + r*kappa}, End: Point{cx - r, cy}},
+		{Start: Point{cx - r, cy}, C1: Point{cx - r, cy - r*kappa}, C2: Point{cx - r*kappa, cy - r}, End: Point{cx, cy - r}},
+		{Start: Point{cx, cy - r}, C1: Point{cx + r*kappa, cy - r}, C2: Point{cx + r, cy - r*kappa}, End: Point{cx + r, cy}},
+	}
+
+	got := arcSegments(Point{X: cx, Y: cy}, r, 0, 360)
+	if len(got) != len(want) {
+		t.Fatalf("segments = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		assertPointNear(t, "start", got[i].Start, want[i].Start)
+		assertPointNear(t, "C1", got[i].C1, want[i].C1)
+		assertPointNear(t, "C2", got[i].C2, want[i].C2)
+		assertPointNear(t, "end", got[i].End, want[i].End)
+	}
+}
+
+func TestDrawArcPieOutput(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+	if err := page.DrawArc(300, 400, 100, 0, 90, &ArcOptions{FillColor: &Red, Pie: true}); err != nil {
+		t.Fatalf("DrawArc() error = %v", err)
+	}
+
+	gops := convertGraphicsOps(page.GraphicsOperations())
+	if !gops[0].Closed {
+		t.Error("pie slice path should be closed")
+	}
+	if gops[0].StrokeColor != nil {
+		t.Error("fill-only arc should not be stroked")
+	}
+
+	pdf, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		t.Error("output is not a PDF")
+	}
+}
+
+func TestDrawArcValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		radius   float64
+		start    float64
+		end      float64
+		opts     *ArcOptions
+		errorMsg string
+	}{
+		{"nil options", 10, 0, 90, nil, "arc options cannot be nil"},
+		{"negative radius", -1, 0, 90, &ArcOptions{StrokeColor: &Black}, "arc radius must be non-negative"},
+		{"zero sweep", 10, 45, 45, &ArcOptions{StrokeColor: &Black}, "arc start and end angles must differ"},
+		{"no stroke or fill", 10, 0, 90, &ArcOptions{}, "arc must have at least stroke, fill color, or gradient"},
+		{"negative width", 10, 0, 90, &ArcOptions{StrokeColor: &Black, StrokeWidth: -1}, "stroke width must be non-negative"},
+		{"invalid fill", 10, 0, 90, &ArcOptions{FillColor: &Color{R: 2}}, "fill color components must be in range [0.0, 1.0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			if err != nil {
+				t.Fatalf("failed to create page: %v", err)
+			}
+
+			err = page.DrawArc(100, 100, tt.radius, tt.start, tt.end, tt.opts)
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if err.Error() != tt.errorMsg {
+				t.Errorf("expected error %q, got %q", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+// bezierPoint evaluates a cubic Bézier segment at t.
+func bezierPoint(s BezierSegment, t float64) Point {
+	u := 1 - t
+	a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+	return Point{
+		X: a*s.Start.X + b*s.C1.X + c*s.C2.X + d*s.End.X,
+		Y: a*s.Start.Y + b*s.C1.Y + c*s.C2.Y + d*s.End.Y,
+	}
+}
+
+// assertPointNear fails if got is not within 1e-6 of want.
+func assertPointNear(t *testing.T, name string, got, want Point) {
+	t.Helper()
+	if math.Abs(got.X-want.X) > 1e-6 || math.Abs(got.Y-want.Y) > 1e-6 {
+		t.Errorf("%s = (%.6f, %.6f), want (%.6f, %.6f)", name, got.X, got.Y, want.X, want.Y)
+	}
+}
@@ -53,6 +53,18 @@ type BezierOptions struct {
 	// Only used when Dashed is true.
 	DashPhase float64
 
+	// LineCap is the style of the curve ends (default: LineCapButt).
+	LineCap LineCap
+
+	// LineJoin is the style of the corners between segments
+	// (default: LineJoinMiter).
+	LineJoin LineJoin
+
+	// MiterLimit limits the length of miter joins as a ratio of the
+	// curve width; longer miters are beveled. Only used with LineJoinMiter.
+	// Must be at least 1.0 (0 = PDF default of 10).
+	MiterLimit float64
+
 	// Closed determines if the curve path should be closed.
 	// If true, a line is drawn from the last segment's end point
 	// back to the first segment's start point.
@@ -73,6 +85,9 @@ type BezierOptions struct {
 	// Affects both stroke and fill (if Closed is true).
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
 }
 
 // DrawBezierCurve draws a complex curve composed of one or more cubic Bézier segments.
@@ -160,6 +175,10 @@ func validateBezierOptions(opts *BezierOptions) error {
 		return errors.New("curve width must be non-negative")
 	}
 
+	if err := validateLineStyle(opts.LineCap, opts.LineJoin, opts.MiterLimit); err != nil {
+		return err
+	}
+
 	// Validate fill color if provided
 	if opts.FillColor != nil {
 		if err := validateColor(*opts.FillColor); err != nil {
@@ -3,6 +3,8 @@ package creator
 import (
 	"errors"
 	"fmt"
+
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 // Bookmark represents a PDF bookmark (also known as outline item).
@@ -12,11 +14,8 @@ import (
 //
 // Example:
 //
-//	bookmark := Bookmark{
-//	    Title:     "Chapter 1",
-//	    PageIndex: 0,  // First page (0-based)
-//	    Level:     0,  // Top-level bookmark
-//	}
+//	chapter := c.AddBookmark("Chapter 1", 0, 0) // Top of the first page
+//	chapter.AddChild("Section 1.1", 0, 400)     // Same page, 400 points up
 type Bookmark struct {
 	// Title is the text displayed in the bookmark tree.
 	Title string
@@ -28,12 +27,60 @@ type Bookmark struct {
 	// Level is the nesting level in the bookmark hierarchy.
 	// 0 = top-level, 1 = child of top-level, 2 = grandchild, etc.
 	Level int
+
+	// Y is the top of the view on the target page, in points from the
+	// bottom edge. 0 jumps to the top of the page.
+	Y float64
+
+	children []*Bookmark
 }
 
-// AddBookmark adds a bookmark to the document.
+// AddBookmark adds a top-level bookmark to the document and returns it,
+// for adding nested bookmarks with AddChild.
 //
-// Bookmarks create a navigational tree structure (outline) in the PDF.
-// Use Level to create nested bookmarks (chapters → sections → subsections).
+// The bookmark jumps to page (0-based) with y, in points from the bottom
+// edge, at the top of the window; 0 jumps to the top of the page.
+// Bookmarks whose page does not exist when the document is written are
+// left out of the outline, with their children.
+//
+// Example:
+//
+//	c := creator.New()
+//	page1, _ := c.NewPage()
+//	page2, _ := c.NewPage()
+//
+//	ch1 := c.AddBookmark("Chapter 1", 0, 0)  // Top of page 1
+//	ch1.AddChild("Section 1.1", 0, 420)      // Middle of page 1
+//	ch1.AddChild("Section 1.2", 1, 0)        // Top of page 2
+//	c.AddBookmark("Chapter 2", 1, 300)
+//
+//	c.WriteToFile("document.pdf")
+func (c *Creator) AddBookmark(title string, page int, y float64) *Bookmark {
+	b := &Bookmark{Title: title, PageIndex: page, Y: y}
+	c.bookmarks = append(c.bookmarks, b)
+	return b
+}
+
+// AddChild adds a bookmark nested under b and returns it. The arguments
+// are those of Creator.AddBookmark.
+func (b *Bookmark) AddChild(title string, page int, y float64) *Bookmark {
+	child := &Bookmark{Title: title, PageIndex: page, Level: b.Level + 1, Y: y}
+	b.children = append(b.children, child)
+	return child
+}
+
+// Children returns the bookmarks nested directly under b.
+func (b *Bookmark) Children() []*Bookmark {
+	return b.children
+}
+
+// AddBookmarkAtLevel adds a bookmark by nesting level instead of with
+// AddChild, for bookmarks generated from a flat list of headings.
+//
+// The bookmark becomes a child of the last bookmark added with a lower
+// level (at any depth of the outline), or a top-level bookmark if there
+// is none. Each bookmark jumps to the top of its page. Bookmarks that
+// point past the last page are left out of the outline.
 //
 // Parameters:
 //   - title: Text to display in the bookmark tree
@@ -44,21 +91,11 @@ type Bookmark struct {
 //
 // Example:
 //
-//	c := creator.New()
-//	page1, _ := c.NewPage()
-//	page2, _ := c.NewPage()
-//	page3, _ := c.NewPage()
-//
-//	// Add top-level bookmarks
-//	c.AddBookmark("Chapter 1", 0, 0)  // Points to page 1
-//	c.AddBookmark("Chapter 2", 2, 0)  // Points to page 3
-//
-//	// Add nested bookmarks (sections under Chapter 1)
-//	c.AddBookmark("Section 1.1", 0, 1)  // Child of Chapter 1
-//	c.AddBookmark("Section 1.2", 1, 1)  // Child of Chapter 1
-//
-//	c.WriteToFile("document.pdf")
-func (c *Creator) AddBookmark(title string, pageIndex int, level int) error {
+//	c.AddBookmarkAtLevel("Chapter 1", 0, 0)    // Points to page 1
+//	c.AddBookmarkAtLevel("Section 1.1", 0, 1)  // Child of Chapter 1
+//	c.AddBookmarkAtLevel("Section 1.2", 1, 1)  // Child of Chapter 1
+//	c.AddBookmarkAtLevel("Chapter 2", 2, 0)    // Points to page 3
+func (c *Creator) AddBookmarkAtLevel(title string, pageIndex int, level int) error {
 	// Validate title.
 	if title == "" {
 		return ErrEmptyBookmarkTitle
@@ -76,15 +113,22 @@ func (c *Creator) AddBookmark(title string, pageIndex int, level int) error {
 			ErrInvalidBookmarkLevel, level)
 	}
 
-	// Create bookmark.
-	bookmark := Bookmark{
-		Title:     title,
-		PageIndex: pageIndex,
-		Level:     level,
+	// Find the parent along the last branch of the outline.
+	var parent *Bookmark
+	for siblings := c.bookmarks; len(siblings) > 0; {
+		last := siblings[len(siblings)-1]
+		if last.Level >= level {
+			break
+		}
+		parent, siblings = last, last.children
 	}
 
-	// Add to document's bookmark list.
-	c.bookmarks = append(c.bookmarks, bookmark)
+	bookmark := &Bookmark{Title: title, PageIndex: pageIndex, Level: level}
+	if parent == nil {
+		c.bookmarks = append(c.bookmarks, bookmark)
+	} else {
+		parent.children = append(parent.children, bookmark)
+	}
 
 	return nil
 }
@@ -92,22 +136,51 @@ func (c *Creator) AddBookmark(title string, pageIndex int, level int) error {
 // Bookmarks returns a copy of all bookmarks in the document.
 //
 // The returned slice is a copy, so modifications won't affect the document.
-// Bookmarks are returned in the order they were added.
+// Bookmarks are returned in outline order: each bookmark is followed by
+// its children. The copies have no children of their own.
 //
 // Example:
 //
-//	c.AddBookmark("Chapter 1", 0, 0)
-//	c.AddBookmark("Section 1.1", 0, 1)
+//	ch1 := c.AddBookmark("Chapter 1", 0, 0)
+//	ch1.AddChild("Section 1.1", 0, 400)
 //
 //	bookmarks := c.Bookmarks()
 //	fmt.Printf("Document has %d bookmarks\n", len(bookmarks))
 func (c *Creator) Bookmarks() []Bookmark {
-	// Return a copy to prevent external modifications.
-	result := make([]Bookmark, len(c.bookmarks))
-	copy(result, c.bookmarks)
+	// Return copies to prevent external modifications.
+	result := make([]Bookmark, 0, len(c.bookmarks))
+	var walk func(bookmarks []*Bookmark)
+	walk = func(bookmarks []*Bookmark) {
+		for _, b := range bookmarks {
+			copied := *b
+			copied.children = nil
+			result = append(result, copied)
+			walk(b.children)
+		}
+	}
+	walk(c.bookmarks)
 	return result
 }
 
+// outline converts the bookmarks to the writer's outline tree.
+func (c *Creator) outline() []*writer.OutlineItem {
+	return outlineItems(c.bookmarks)
+}
+
+// outlineItems converts bookmarks and their children to outline items.
+func outlineItems(bookmarks []*Bookmark) []*writer.OutlineItem {
+	var items []*writer.OutlineItem
+	for _, b := range bookmarks {
+		items = append(items, &writer.OutlineItem{
+			Title:     b.Title,
+			PageIndex: b.PageIndex,
+			Top:       b.Y,
+			Children:  outlineItems(b.children),
+		})
+	}
+	return items
+}
+
 // Bookmark-related errors.
 var (
 	// ErrEmptyBookmarkTitle is returned when bookmark title is empty.
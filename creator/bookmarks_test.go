@@ -1,7 +1,9 @@
 package creator
 
 import (
+	"bytes"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -18,21 +20,21 @@ func TestAddBookmark_Success(t *testing.T) {
 	}
 
 	// Add top-level bookmark.
-	err := c.AddBookmark("Chapter 1", 0, 0)
+	err := c.AddBookmarkAtLevel("Chapter 1", 0, 0)
 	if err != nil {
-		t.Errorf("AddBookmark failed: %v", err)
+		t.Errorf("AddBookmarkAtLevel failed: %v", err)
 	}
 
 	// Add nested bookmark.
-	err = c.AddBookmark("Section 1.1", 0, 1)
+	err = c.AddBookmarkAtLevel("Section 1.1", 0, 1)
 	if err != nil {
-		t.Errorf("AddBookmark failed for nested bookmark: %v", err)
+		t.Errorf("AddBookmarkAtLevel failed for nested bookmark: %v", err)
 	}
 
 	// Add another top-level bookmark.
-	err = c.AddBookmark("Chapter 2", 1, 0)
+	err = c.AddBookmarkAtLevel("Chapter 2", 1, 0)
 	if err != nil {
-		t.Errorf("AddBookmark failed for second chapter: %v", err)
+		t.Errorf("AddBookmarkAtLevel failed for second chapter: %v", err)
 	}
 
 	// Verify bookmarks were added.
@@ -52,7 +54,7 @@ func TestAddBookmark_ValidateTitle(t *testing.T) {
 	}
 
 	// Empty title should fail.
-	err := c.AddBookmark("", 0, 0)
+	err := c.AddBookmarkAtLevel("", 0, 0)
 	if err == nil {
 		t.Error("Expected error for empty title, got nil")
 	}
@@ -99,9 +101,9 @@ func TestAddBookmark_ValidatePageIndex(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := c.AddBookmark("Test", tt.pageIndex, 0)
+			err := c.AddBookmarkAtLevel("Test", tt.pageIndex, 0)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("AddBookmark() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("AddBookmarkAtLevel() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if err != nil && !errors.Is(err, ErrInvalidBookmarkPage) {
 				t.Errorf("Expected ErrInvalidBookmarkPage, got: %v", err)
@@ -153,9 +155,9 @@ func TestAddBookmark_ValidateLevel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := c.AddBookmark("Test", 0, tt.level)
+			err := c.AddBookmarkAtLevel("Test", 0, tt.level)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("AddBookmark() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("AddBookmarkAtLevel() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if err != nil && !errors.Is(err, ErrInvalidBookmarkLevel) {
 				t.Errorf("Expected ErrInvalidBookmarkLevel, got: %v", err)
@@ -174,7 +176,7 @@ func TestBookmarks_ReturnsCopy(t *testing.T) {
 	}
 
 	// Add a bookmark.
-	if err := c.AddBookmark("Chapter 1", 0, 0); err != nil {
+	if err := c.AddBookmarkAtLevel("Chapter 1", 0, 0); err != nil {
 		t.Fatalf("Failed to add bookmark: %v", err)
 	}
 
@@ -215,7 +217,7 @@ func TestBookmarks_PreservesOrder(t *testing.T) {
 	}
 
 	for i, title := range bookmarkTitles {
-		if err := c.AddBookmark(title, i%5, 0); err != nil {
+		if err := c.AddBookmarkAtLevel(title, i%5, 0); err != nil {
 			t.Fatalf("Failed to add bookmark %q: %v", title, err)
 		}
 	}
@@ -250,7 +252,7 @@ func TestBookmark_Structure(t *testing.T) {
 	pageIndex := 1
 	level := 2
 
-	err := c.AddBookmark(title, pageIndex, level)
+	err := c.AddBookmarkAtLevel(title, pageIndex, level)
 	if err != nil {
 		t.Fatalf("Failed to add bookmark: %v", err)
 	}
@@ -306,7 +308,7 @@ func TestAddBookmark_HierarchicalStructure(t *testing.T) {
 	}
 
 	for _, b := range hierarchy {
-		err := c.AddBookmark(b.title, b.pageIndex, b.level)
+		err := c.AddBookmarkAtLevel(b.title, b.pageIndex, b.level)
 		if err != nil {
 			t.Fatalf("Failed to add bookmark %q: %v", b.title, err)
 		}
@@ -334,6 +336,102 @@ func TestAddBookmark_HierarchicalStructure(t *testing.T) {
 	}
 }
 
+// TestBookmarks_Outline tests that bookmarks are nested by level and
+// written as the document outline.
+func TestBookmarks_Outline(t *testing.T) {
+	c := New()
+	for i := 0; i < 3; i++ {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("Failed to add page %d: %v", i, err)
+		}
+	}
+
+	for _, b := range []Bookmark{
+		{Title: "Chapter 1", PageIndex: 0, Level: 0},
+		{Title: "Subsection 1.0.1", PageIndex: 0, Level: 2}, // Skipped level: child of Chapter 1
+		{Title: "Section 1.1", PageIndex: 1, Level: 1},
+		{Title: "Chapter 2", PageIndex: 2, Level: 0},
+		{Title: "Section 2.1", PageIndex: 2, Level: 1},
+	} {
+		if err := c.AddBookmarkAtLevel(b.Title, b.PageIndex, b.Level); err != nil {
+			t.Fatalf("Failed to add bookmark %q: %v", b.Title, err)
+		}
+	}
+
+	outline := c.outline()
+	if len(outline) != 2 || outline[0].Title != "Chapter 1" || outline[1].Title != "Chapter 2" {
+		t.Fatalf("Expected chapters at the top level, got %+v", outline)
+	}
+	if children := outline[0].Children; len(children) != 2 ||
+		children[0].Title != "Subsection 1.0.1" || children[1].Title != "Section 1.1" || children[1].PageIndex != 1 {
+		t.Errorf("Unexpected children of Chapter 1: %+v", children)
+	}
+	if children := outline[1].Children; len(children) != 1 || children[0].Title != "Section 2.1" {
+		t.Errorf("Unexpected children of Chapter 2: %+v", children)
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	for _, want := range []string{"/Outlines ", "/Type /Outlines", "/Title (Section 2.1)"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	}
+}
+
+// TestBookmarks_AddChild tests the bookmark tree built with AddBookmark
+// and AddChild, and its destinations.
+func TestBookmarks_AddChild(t *testing.T) {
+	c := New()
+	for i := 0; i < 2; i++ {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("Failed to add page %d: %v", i, err)
+		}
+	}
+
+	chapter1 := c.AddBookmark("Chapter 1", 0, 0)
+	section := chapter1.AddChild("Section 1.1", 0, 400)
+	section.AddChild("Detail", 1, 250)
+	chapter1.AddChild("Section 1.2", 1, 0)
+	c.AddBookmark("Chapter 2", 1, 300)
+	// Level-based bookmarks continue the last branch.
+	if err := c.AddBookmarkAtLevel("Section 2.1", 1, 1); err != nil {
+		t.Fatalf("AddBookmarkAtLevel failed: %v", err)
+	}
+
+	if len(chapter1.Children()) != 2 || section.Level != 1 || section.Children()[0].Level != 2 {
+		t.Errorf("Unexpected tree under Chapter 1: %+v", chapter1.Children())
+	}
+
+	var titles []string
+	for _, b := range c.Bookmarks() {
+		titles = append(titles, b.Title)
+	}
+	if got := strings.Join(titles, ", "); got != "Chapter 1, Section 1.1, Detail, Section 1.2, Chapter 2, Section 2.1" {
+		t.Errorf("Bookmarks() = %s", got)
+	}
+
+	outline := c.outline()
+	if len(outline) != 2 || len(outline[0].Children) != 2 || len(outline[1].Children) != 1 {
+		t.Fatalf("Unexpected outline: %+v", outline)
+	}
+	if detail := outline[0].Children[0].Children[0]; detail.Title != "Detail" || detail.PageIndex != 1 || detail.Top != 250 {
+		t.Errorf("Detail = %+v, want page 1 at 250", detail)
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	for _, want := range []string{"/XYZ 0 842 null", "/XYZ 0 400 null", "/XYZ 0 250 null", "/XYZ 0 300 null"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("PDF should contain the destination %q", want)
+		}
+	}
+}
+
 // TestBookmarks_EmptyByDefault tests that new Creator has no bookmarks.
 func TestBookmarks_EmptyByDefault(t *testing.T) {
 	c := New()
@@ -358,7 +456,7 @@ func TestAddBookmark_MultipleCallsSameLevel(t *testing.T) {
 	// Add multiple top-level bookmarks.
 	for i := 0; i < 10; i++ {
 		title := "Chapter " + string(rune('A'+i))
-		if err := c.AddBookmark(title, i, 0); err != nil {
+		if err := c.AddBookmarkAtLevel(title, i, 0); err != nil {
 			t.Fatalf("Failed to add bookmark %d: %v", i, err)
 		}
 	}
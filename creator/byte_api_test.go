@@ -3,6 +3,8 @@ package creator
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
 	"time"
 )
@@ -189,3 +191,54 @@ func TestWriteToWithTimeout(t *testing.T) {
 		t.Error("Buffer is empty after WriteToContext with timeout")
 	}
 }
+
+func TestDataURI(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() failed: %v", err)
+	}
+	page.AddText("Hello, World!", 100, 700, Helvetica, 12)
+
+	uri, err := c.DataURI()
+	if err != nil {
+		t.Fatalf("DataURI() failed: %v", err)
+	}
+
+	const prefix = "data:application/pdf;base64,"
+	if !strings.HasPrefix(uri, prefix) {
+		t.Fatalf("DataURI() = %.40q..., want prefix %q", uri, prefix)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(uri, prefix))
+	if err != nil {
+		t.Fatalf("DataURI() payload is not valid base64: %v", err)
+	}
+	if !bytes.HasPrefix(decoded, []byte("%PDF-")) || !bytes.Contains(decoded, []byte("%%EOF")) {
+		t.Error("DataURI() payload is not a PDF document")
+	}
+}
+
+func TestWriteDataURI(t *testing.T) {
+	c := New()
+	if _, err := c.NewPage(); err != nil {
+		t.Fatalf("NewPage() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := c.WriteDataURI(&buf)
+	if err != nil {
+		t.Fatalf("WriteDataURI() failed: %v", err)
+	}
+	if int64(buf.Len()) != n {
+		t.Errorf("WriteDataURI() returned %d bytes, but buffer has %d bytes", n, buf.Len())
+	}
+
+	uri, err := c.DataURI()
+	if err != nil {
+		t.Fatalf("DataURI() failed: %v", err)
+	}
+	if len(uri) != buf.Len() {
+		t.Errorf("WriteDataURI() wrote %d bytes, DataURI() returned %d", buf.Len(), len(uri))
+	}
+}
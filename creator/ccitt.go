@@ -0,0 +1,185 @@
+package creator
+
+// encodeG4 encodes a bilevel image with CCITT Group 4 (T.6) compression,
+// as read by the PDF CCITTFaxDecode filter with /K -1.
+//
+// samples holds 1-bit pixels, MSB first, each row padded to a whole byte.
+// blackIsOne selects whether 1 bits are black (TIFF WhiteIsZero) or white.
+// The output ends with an end-of-facsimile-block marker.
+//
+// Reference: ITU-T Recommendation T.6, Section 2.2 (Coding procedure).
+func encodeG4(samples []byte, width, height int, blackIsOne bool) []byte {
+	rowBytes := (width + 7) / 8
+	var bw g4BitWriter
+
+	// The reference line of the first row is an imaginary white line.
+	ref := make([]byte, width)
+	cur := make([]byte, width)
+	for y := 0; y < height; y++ {
+		row := samples[y*rowBytes:]
+		for x := range cur {
+			bit := row[x/8] >> (7 - x%8) & 1
+			if blackIsOne {
+				cur[x] = bit
+			} else {
+				cur[x] = bit ^ 1
+			}
+		}
+		encodeG4Row(&bw, ref, cur)
+		ref, cur = cur, ref
+	}
+
+	// EOFB: two EOL codes.
+	bw.writeCode("000000000001")
+	bw.writeCode("000000000001")
+	return bw.bytes()
+}
+
+// encodeG4Row codes one row (0 = white, 1 = black) against the reference
+// row above it.
+func encodeG4Row(bw *g4BitWriter, ref, cur []byte) {
+	width := len(cur)
+	a0 := -1
+	var color byte // Color of a0; the imaginary pixel before a row is white.
+	for a0 < width {
+		a1 := g4NextChange(cur, a0)
+		b1 := g4NextChange(ref, a0)
+		if b1 < width && ref[b1] == color {
+			b1 = g4NextChange(ref, b1)
+		}
+		b2 := g4NextChange(ref, b1)
+
+		switch d := a1 - b1; {
+		case b2 < a1:
+			// Pass mode: b2 lies left of a1.
+			bw.writeCode("0001")
+			a0 = b2
+		case d >= -3 && d <= 3:
+			bw.writeCode(g4VerticalCodes[d+3])
+			a0 = a1
+			color ^= 1
+		default:
+			a2 := g4NextChange(cur, a1)
+			bw.writeCode("001")
+			bw.writeRun(a1-max(a0, 0), color)
+			bw.writeRun(a2-a1, color^1)
+			a0 = a2
+		}
+	}
+}
+
+// g4NextChange returns the first changing element of row after x: the
+// position of the first pixel whose color differs from the pixel at x, or
+// len(row). The pixel before the row (x = -1) is white.
+func g4NextChange(row []byte, x int) int {
+	var color byte
+	if x >= 0 {
+		if x >= len(row) {
+			return len(row)
+		}
+		color = row[x]
+	}
+	for i := x + 1; i < len(row); i++ {
+		if row[i] != color {
+			return i
+		}
+	}
+	return len(row)
+}
+
+// g4VerticalCodes holds the vertical mode codes for a1 - b1 = -3 to 3.
+var g4VerticalCodes = [7]string{"0000010", "000010", "010", "1", "011", "000011", "0000011"}
+
+// g4BitWriter packs variable-length codes MSB first.
+type g4BitWriter struct {
+	buf   []byte
+	acc   byte
+	nbits uint
+}
+
+// writeCode appends a code given as a string of '0' and '1'.
+func (bw *g4BitWriter) writeCode(code string) {
+	for i := 0; i < len(code); i++ {
+		bw.acc = bw.acc<<1 | (code[i] - '0')
+		if bw.nbits++; bw.nbits == 8 {
+			bw.buf = append(bw.buf, bw.acc)
+			bw.acc, bw.nbits = 0, 0
+		}
+	}
+}
+
+// writeRun appends the codes of a run of n pixels of color (0 = white):
+// make-up codes for multiples of 64, then a terminating code.
+func (bw *g4BitWriter) writeRun(n int, color byte) {
+	terminating, makeup := &g4WhiteTerminating, &g4WhiteMakeup
+	if color == 1 {
+		terminating, makeup = &g4BlackTerminating, &g4BlackMakeup
+	}
+	for n >= 2560+64 {
+		bw.writeCode(g4ExtendedMakeup[len(g4ExtendedMakeup)-1])
+		n -= 2560
+	}
+	if n >= 64 {
+		m := n / 64
+		if m <= len(makeup) {
+			bw.writeCode(makeup[m-1])
+		} else {
+			bw.writeCode(g4ExtendedMakeup[m-len(makeup)-1])
+		}
+		n -= m * 64
+	}
+	bw.writeCode(terminating[n])
+}
+
+// bytes returns the written codes, padding the last byte with zero bits.
+func (bw *g4BitWriter) bytes() []byte {
+	if bw.nbits > 0 {
+		return append(bw.buf, bw.acc<<(8-bw.nbits))
+	}
+	return bw.buf
+}
+
+// Run-length codes, indexed by run length (terminating codes) or by run
+// length / 64 - 1 (make-up codes).
+//
+// Reference: ITU-T Recommendation T.4, Tables 2 and 3.
+var (
+	g4WhiteTerminating = [64]string{
+		"00110101", "000111", "0111", "1000", "1011", "1100", "1110", "1111",
+		"10011", "10100", "00111", "01000", "001000", "000011", "110100", "110101",
+		"101010", "101011", "0100111", "0001100", "0001000", "0010111", "0000011", "0000100",
+		"0101000", "0101011", "0010011", "0100100", "0011000", "00000010", "00000011", "00011010",
+		"00011011", "00010010", "00010011", "00010100", "00010101", "00010110", "00010111", "00101000",
+		"00101001", "00101010", "00101011", "00101100", "00101101", "00000100", "00000101", "00001010",
+		"00001011", "01010010", "01010011", "01010100", "01010101", "00100100", "00100101", "01011000",
+		"01011001", "01011010", "01011011", "01001010", "01001011", "00110010", "00110011", "00110100",
+	}
+	g4BlackTerminating = [64]string{
+		"0000110111", "010", "11", "10", "011", "0011", "0010", "00011",
+		"000101", "000100", "0000100", "0000101", "0000111", "00000100", "00000111", "000011000",
+		"0000010111", "0000011000", "0000001000", "00001100111", "00001101000", "00001101100", "00000110111", "00000101000",
+		"00000010111", "00000011000", "000011001010", "000011001011", "000011001100", "000011001101", "000001101000", "000001101001",
+		"000001101010", "000001101011", "000011010010", "000011010011", "000011010100", "000011010101", "000011010110", "000011010111",
+		"000001101100", "000001101101", "000011011010", "000011011011", "000001010100", "000001010101", "000001010110", "000001010111",
+		"000001100100", "000001100101", "000001010010", "000001010011", "000000100100", "000000110111", "000000111000", "000000100111",
+		"000000101000", "000001011000", "000001011001", "000000101011", "000000101100", "000001011010", "000001100110", "000001100111",
+	}
+	g4WhiteMakeup = [27]string{
+		"11011", "10010", "010111", "0110111", "00110110", "00110111", "01100100", "01100101",
+		"01101000", "01100111", "011001100", "011001101", "011010010", "011010011", "011010100", "011010101",
+		"011010110", "011010111", "011011000", "011011001", "011011010", "011011011", "010011000", "010011001",
+		"010011010", "011000", "010011011",
+	}
+	g4BlackMakeup = [27]string{
+		"0000001111", "000011001000", "000011001001", "000001011011", "000000110011", "000000110100", "000000110101", "0000001101100",
+		"0000001101101", "0000001001010", "0000001001011", "0000001001100", "0000001001101", "0000001110010", "0000001110011", "0000001110100",
+		"0000001110101", "0000001110110", "0000001110111", "0000001010010", "0000001010011", "0000001010100", "0000001010101", "0000001011010",
+		"0000001011011", "0000001100100", "0000001100101",
+	}
+	// g4ExtendedMakeup holds the make-up codes for 1792 to 2560, shared by
+	// both colors.
+	g4ExtendedMakeup = [13]string{
+		"00000001000", "00000001100", "00000001101", "000000010010", "000000010011", "000000010100", "000000010101", "000000010110",
+		"000000010111", "000000011100", "000000011101", "000000011110", "000000011111",
+	}
+)
@@ -0,0 +1,72 @@
+package creator
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/ccitt"
+)
+
+func TestEncodeG4_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	runs := func(width, height int) []byte {
+		// Rows of random runs, from single pixels to the whole row.
+		rowBytes := (width + 7) / 8
+		samples := make([]byte, rowBytes*height)
+		for y := 0; y < height; y++ {
+			bit := byte(rng.Intn(2))
+			for x := 0; x < width; {
+				n := 1 + rng.Intn(min(width, 1<<rng.Intn(13)))
+				for ; n > 0 && x < width; n, x = n-1, x+1 {
+					samples[y*rowBytes+x/8] |= bit << (7 - x%8)
+				}
+				bit ^= 1
+			}
+		}
+		return samples
+	}
+
+	tests := []struct {
+		name          string
+		width, height int
+		samples       []byte
+	}{
+		{"one pixel", 1, 1, []byte{0x80}},
+		{"all white", 16, 4, make([]byte, 8)},
+		{"all black", 12, 3, bytes.Repeat([]byte{0xFF, 0xF0}, 3)},
+		{"stripes", 8, 4, []byte{0xAA, 0x55, 0xAA, 0x55}},
+		{"random runs", 301, 40, nil},
+		{"long runs", 5200, 6, nil},
+	}
+	for _, tt := range tests {
+		samples := tt.samples
+		if samples == nil {
+			samples = runs(tt.width, tt.height)
+		}
+		for _, blackIsOne := range []bool{false, true} {
+			data := encodeG4(samples, tt.width, tt.height, blackIsOne)
+			r := ccitt.NewReader(bytes.NewReader(data), ccitt.MSB, ccitt.Group4, tt.width, tt.height, nil)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("%s (blackIsOne %v): decode error = %v", tt.name, blackIsOne, err)
+			}
+
+			// The decoder outputs 1 for white and zero padding bits.
+			want := bytes.Clone(samples)
+			rowBytes := (tt.width + 7) / 8
+			for i := range want {
+				if blackIsOne {
+					want[i] = ^want[i]
+				}
+				if pad := rowBytes*8 - tt.width; i%rowBytes == rowBytes-1 && pad > 0 {
+					want[i] &^= 1<<pad - 1
+				}
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s (blackIsOne %v): round trip differs", tt.name, blackIsOne)
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// EmbedChecksum makes the write methods embed a SHA-256 checksum of the
+// document, for checking later that the file has not been altered.
+//
+// The checksum covers every byte of the file except the checksum value
+// itself and is stored in a custom entry (/GXPDF_Checksum) of the document
+// Info dictionary. Use gxpdf.VerifyChecksum to check it.
+//
+// This is tamper evidence, not a signature: anyone who modifies the file
+// can also recompute the checksum. Because the checksum depends on the
+// complete output, the document is built in memory before being written.
+//
+// Example:
+//
+//	c.EmbedChecksum()
+//	err := c.WriteToFile("audited.pdf")
+//
+//	ok, err := gxpdf.VerifyChecksum("audited.pdf")
+func (c *Creator) EmbedChecksum() {
+	c.checksum = true
+}
+
+// needsBuffering reports whether the output must be built in memory and
+// patched or encrypted before being written (checksum, usage rights byte
+// range, encryption).
+func (c *Creator) needsBuffering() bool {
+	return c.checksum || c.usageRights != nil || c.encryptionOpts != nil
+}
+
+// bufferedBytes writes the document to memory and fills in the parts that
+// depend on the complete output: the usage rights byte range, then the
+// checksum. With encryption, the complete output is encrypted instead.
+func (c *Creator) bufferedBytes(pages []pageContent) ([]byte, error) {
+	if c.encryptionOpts != nil && (c.checksum || c.usageRights != nil) {
+		return nil, errors.New("encryption cannot be combined with a checksum or usage rights")
+	}
+
+	var buf bytes.Buffer
+	pdfWriter := writer.NewPdfWriterFromWriter(&buf)
+	defer pdfWriter.Close()
+
+	c.configureWriter(pdfWriter)
+	pdfWriter.SetChecksum(c.checksum)
+	textContents, graphicsContents := c.convertPageContents(pages)
+	if err := pdfWriter.WriteWithAllContent(c.doc, textContents, graphicsContents); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+
+	data := buf.Bytes()
+	if c.encryptionOpts != nil {
+		encrypted, err := encryptBytes(data, c.encryptionOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt PDF: %w", err)
+		}
+		return encrypted, nil
+	}
+	if c.usageRights != nil {
+		if err := writer.PrepareUsageRights(data); err != nil {
+			return nil, fmt.Errorf("failed to prepare usage rights: %w", err)
+		}
+	}
+	if c.checksum {
+		if err := writer.EmbedChecksum(data); err != nil {
+			return nil, fmt.Errorf("failed to embed checksum: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// writeBuffered writes the document built by bufferedBytes to w.
+func (c *Creator) writeBuffered(w io.Writer, pages []pageContent) (int64, error) {
+	data, err := c.bufferedBytes(pages)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// writeBufferedFile writes the document built by bufferedBytes to path.
+func (c *Creator) writeBufferedFile(path string, pages []pageContent) error {
+	data, err := c.bufferedBytes(pages)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
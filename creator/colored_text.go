@@ -0,0 +1,159 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// ColoredGlyph is a character (or short cluster) drawn in its own color.
+//
+// Glyphs are drawn left-to-right by AddColoredText.
+//
+// Example:
+//
+//	glyphs := []creator.ColoredGlyph{
+//	    {Text: "G", Color: creator.Blue},
+//	    {Text: "o", Color: creator.Red},
+//	}
+type ColoredGlyph struct {
+	// Text is the glyph to draw, usually a single character.
+	Text string
+
+	// Color is the glyph color (RGB, 0.0 to 1.0 range).
+	// If ColorCMYK is set, this field is ignored.
+	Color Color
+
+	// ColorCMYK is the glyph color in CMYK color space (optional).
+	// If set, this takes precedence over Color (RGB).
+	ColorCMYK *ColorCMYK
+}
+
+// sameColor reports whether two glyphs are drawn in the same color.
+func (g ColoredGlyph) sameColor(other ColoredGlyph) bool {
+	if g.ColorCMYK != nil || other.ColorCMYK != nil {
+		return g.ColorCMYK != nil && other.ColorCMYK != nil && *g.ColorCMYK == *other.ColorCMYK
+	}
+	return g.Color == other.Color
+}
+
+// AddColoredText draws text in which each glyph has its own color, such as
+// rainbow headings or legends with colored markers.
+//
+// All glyphs are written as one text object, switching the fill color
+// between them, so each glyph starts at the advance of the previous one
+// exactly as in a single AddText call. Adjacent glyphs of the same color
+// are combined into one run.
+//
+// Parameters:
+//   - glyphs: The glyphs, in drawing order
+//   - x: Horizontal position in points (from left edge)
+//   - y: Baseline position in points (from bottom edge)
+//   - font: Font to use (one of the Standard 14 fonts)
+//   - size: Font size in points
+//
+// Example:
+//
+//	err := page.AddColoredText([]creator.ColoredGlyph{
+//	    {Text: "R", Color: creator.Red},
+//	    {Text: "G", Color: creator.Green},
+//	    {Text: "B", Color: creator.Blue},
+//	}, 100, 700, creator.HelveticaBold, 36)
+func (p *Page) AddColoredText(glyphs []ColoredGlyph, x, y float64, font FontName, size float64) error {
+	if font == "" {
+		return errors.New("font is required")
+	}
+	return p.addColoredText(glyphs, x, y, font, nil, size)
+}
+
+// AddColoredTextCustomFont draws per-glyph colored text using an embedded
+// TrueType/OpenType font.
+//
+// See AddColoredText for details.
+//
+// Example:
+//
+//	font, _ := creator.LoadFont("fonts/OpenSans-Bold.ttf")
+//	err := page.AddColoredTextCustomFont([]creator.ColoredGlyph{
+//	    {Text: "Я", Color: creator.Red},
+//	    {Text: "!", Color: creator.Blue},
+//	}, 100, 700, font, 36)
+func (p *Page) AddColoredTextCustomFont(glyphs []ColoredGlyph, x, y float64, font *CustomFont, size float64) error {
+	if font == nil {
+		return errors.New("font cannot be nil")
+	}
+	return p.addColoredText(glyphs, x, y, "", font, size)
+}
+
+// addColoredText validates the glyphs and stores one continued text
+// operation per color run.
+func (p *Page) addColoredText(glyphs []ColoredGlyph, x, y float64, font FontName, customFont *CustomFont, size float64) error {
+	if len(glyphs) == 0 {
+		return errors.New("colored text must have at least one glyph")
+	}
+	if size <= 0 {
+		return errors.New("font size must be positive")
+	}
+	for i, g := range glyphs {
+		if err := validateColoredGlyph(g); err != nil {
+			return fmt.Errorf("glyph %d: %w", i, err)
+		}
+	}
+
+	cursorX := x
+	for i := 0; i < len(glyphs); {
+		// Collect the run of glyphs sharing this glyph's color.
+		var text strings.Builder
+		j := i
+		for ; j < len(glyphs) && glyphs[j].sameColor(glyphs[i]); j++ {
+			text.WriteString(glyphs[j].Text)
+		}
+		run := text.String()
+
+		var width float64
+		if customFont != nil {
+			customFont.UseString(run)
+			width = customFont.MeasureString(run, size)
+		} else {
+			width = fonts.MeasureString(string(font), run, size)
+		}
+
+		p.textOps = append(p.textOps, TextOperation{
+			Text:       run,
+			X:          cursorX,
+			Y:          y,
+			Font:       font,
+			CustomFont: customFont,
+			Size:       size,
+			Color:      glyphs[i].Color,
+			ColorCMYK:  glyphs[i].ColorCMYK,
+			Continued:  i > 0,
+		})
+
+		cursorX += width
+		i = j
+	}
+
+	return nil
+}
+
+// validateColoredGlyph validates a single colored glyph.
+func validateColoredGlyph(g ColoredGlyph) error {
+	if g.Text == "" {
+		return errors.New("glyph text is required")
+	}
+	if strings.ContainsAny(g.Text, "\r\n") {
+		return errors.New("glyph text cannot contain line breaks")
+	}
+	if err := validateColor(g.Color); err != nil {
+		return err
+	}
+	if c := g.ColorCMYK; c != nil {
+		if c.C < 0 || c.C > 1 || c.M < 0 || c.M > 1 || c.Y < 0 || c.Y > 1 || c.K < 0 || c.K > 1 {
+			return errors.New("CMYK color components must be in range [0.0, 1.0]")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package creator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+	"github.com/coregx/gxpdf/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage_AddColoredText(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	err = page.AddColoredText([]ColoredGlyph{
+		{Text: "R", Color: Red},
+		{Text: "G", Color: Green},
+		{Text: "B", Color: Blue},
+	}, 100, 700, HelveticaBold, 36)
+	require.NoError(t, err)
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 3)
+
+	assert.False(t, ops[0].Continued)
+	assert.True(t, ops[1].Continued)
+	assert.True(t, ops[2].Continued)
+	assert.InDelta(t, 100+fonts.MeasureString("Helvetica-Bold", "R", 36), ops[1].X, 1e-9)
+	assert.InDelta(t, 100+fonts.MeasureString("Helvetica-Bold", "RG", 36), ops[2].X, 1e-9)
+	assert.Equal(t, []Color{Red, Green, Blue}, []Color{ops[0].Color, ops[1].Color, ops[2].Color})
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(ops))
+	require.NoError(t, err)
+	got := string(content)
+	assert.Equal(t, 1, strings.Count(got, "BT"), "glyphs share one text object:\n%s", got)
+	assert.Equal(t, 1, strings.Count(got, " Td"), "only the first glyph is positioned:\n%s", got)
+	assert.Equal(t, 3, strings.Count(got, " rg"), "color switches between glyphs:\n%s", got)
+}
+
+func TestPage_AddColoredText_MergesSameColor(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	cyan := CMYKCyan
+	err = page.AddColoredText([]ColoredGlyph{
+		{Text: "a", Color: Red},
+		{Text: "b", Color: Red},
+		{Text: "c", ColorCMYK: &cyan},
+		{Text: "d", ColorCMYK: &cyan},
+	}, 50, 500, Helvetica, 12)
+	require.NoError(t, err)
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 2)
+	assert.Equal(t, "ab", ops[0].Text)
+	assert.Equal(t, "cd", ops[1].Text)
+	require.NotNil(t, ops[1].ColorCMYK)
+	assert.Equal(t, cyan, *ops[1].ColorCMYK)
+}
+
+func TestPage_AddColoredText_Errors(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		glyphs []ColoredGlyph
+		font   FontName
+		size   float64
+	}{
+		{"no glyphs", nil, Helvetica, 12},
+		{"no font", []ColoredGlyph{{Text: "a"}}, "", 12},
+		{"zero size", []ColoredGlyph{{Text: "a"}}, Helvetica, 0},
+		{"empty glyph", []ColoredGlyph{{Text: ""}}, Helvetica, 12},
+		{"line break", []ColoredGlyph{{Text: "a\nb"}}, Helvetica, 12},
+		{"invalid color", []ColoredGlyph{{Text: "a", Color: Color{R: 2}}}, Helvetica, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, page.AddColoredText(tt.glyphs, 0, 0, tt.font, tt.size))
+		})
+	}
+	assert.Empty(t, page.TextOperations())
+
+	assert.Error(t, page.AddColoredTextCustomFont([]ColoredGlyph{{Text: "a"}}, 0, 0, nil, 12))
+}
+
+func TestPage_AddColoredText_GrayUsesDeviceGray(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	err = page.AddColoredText([]ColoredGlyph{
+		{Text: "g", Color: NewGrayColor(0.5)},
+		{Text: "r", Color: Red},
+	}, 100, 700, Helvetica, 12)
+	require.NoError(t, err)
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+	got := string(content)
+	assert.Contains(t, got, "0.50 g\n", "neutral colors are written in DeviceGray:\n%s", got)
+	assert.Equal(t, 1, strings.Count(got, " rg"), "only the red glyph uses RGB:\n%s", got)
+}
@@ -0,0 +1,195 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Conformance is a PDF standard that the output conforms to.
+type Conformance int
+
+const (
+	// ConformanceNone writes plain PDF (default).
+	ConformanceNone Conformance = iota
+
+	// PDFA1B writes PDF/A-1b (ISO 19005-1, level B), the archival
+	// profile that guarantees the pages render the same in the future.
+	PDFA1B
+)
+
+// String returns the name of the standard, e.g. "PDF/A-1b".
+func (c Conformance) String() string {
+	switch c {
+	case ConformanceNone:
+		return "none"
+	case PDFA1B:
+		return "PDF/A-1b"
+	default:
+		return fmt.Sprintf("Conformance(%d)", int(c))
+	}
+}
+
+// ErrNonConforming is returned by Validate and the write methods when the
+// document uses a feature that the conformance level forbids.
+var ErrNonConforming = errors.New("document does not conform")
+
+// SetConformance makes the output conform to a PDF standard.
+//
+// With PDFA1B the document is written as PDF/A-1b: the catalog gets an
+// sRGB output intent with an embedded ICC profile, an XMP packet with the
+// PDF/A identification (pdfaid:part 1, pdfaid:conformance B) is always
+// embedded, the trailer gets a file identifier, and cross-reference and
+// object streams are not used.
+//
+// The content, including templates drawn on the pages, must also conform,
+// or writing fails with an error wrapping ErrNonConforming that names the
+// offending page and feature:
+//   - All text must use an embedded font (LoadFont); the Standard 14
+//     fonts, including in watermarks, are not embedded.
+//   - Nothing may be transparent: no opacity below 1.0 and no images with
+//     an alpha channel.
+//   - Colors must be RGB or gray; CMYK does not match the sRGB output
+//     intent.
+//   - The document must not be encrypted.
+//
+// A packet set with SetXMP must contain the PDF/A identification itself.
+//
+// Example:
+//
+//	font, _ := creator.LoadFont("fonts/DejaVuSans.ttf")
+//	c.SetConformance(creator.PDFA1B)
+//	page.AddTextCustomFont("Archived", 72, 750, font, 12)
+//	err := c.WriteToFile("archive.pdf")
+func (c *Creator) SetConformance(conformance Conformance) {
+	c.conformance = conformance
+}
+
+// validateConformance checks the document, with the page operations
+// returned by allPageOperations, against the conformance level.
+func (c *Creator) validateConformance(pages []pageContent) error {
+	if err := c.validateDocumentConformance(); err != nil {
+		return err
+	}
+	for i, page := range pages {
+		if err := c.validatePageConformance(i+1, page.text, page.graphics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDocumentConformance checks the document-level settings against
+// the conformance level.
+func (c *Creator) validateDocumentConformance() error {
+	if c.conformance != PDFA1B {
+		return nil
+	}
+
+	if c.encryptionOpts != nil {
+		return c.nonConforming("encryption is not allowed")
+	}
+	if c.xmp != nil && !bytes.Contains(c.xmp, []byte("pdfaid:part")) {
+		return c.nonConforming("the XMP packet set with SetXMP has no PDF/A identification (pdfaid:part)")
+	}
+	return nil
+}
+
+// validatePageConformance checks the operations of page pageNum against
+// the conformance level.
+func (c *Creator) validatePageConformance(pageNum int, textOps []TextOperation, graphicsOps []GraphicsOperation) error {
+	if c.conformance != PDFA1B {
+		return nil
+	}
+
+	for i := range textOps {
+		if err := c.validateTextConformance(pageNum, &textOps[i]); err != nil {
+			return err
+		}
+	}
+	for i := range graphicsOps {
+		if err := c.validateGraphicsConformance(pageNum, &graphicsOps[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTextConformance checks a text operation on page pageNum.
+func (c *Creator) validateTextConformance(pageNum int, op *TextOperation) error {
+	if op.CustomFont == nil {
+		return c.nonConforming("page %d: text %q uses the standard font %s, which is not embedded (use LoadFont)",
+			pageNum, op.Text, op.Font)
+	}
+	if op.Opacity != nil && *op.Opacity < 1.0 {
+		return c.nonConforming("page %d: text %q has opacity %.2f; transparency is not allowed",
+			pageNum, op.Text, *op.Opacity)
+	}
+	if op.ColorCMYK != nil {
+		return c.nonConforming("page %d: text %q uses a CMYK color, which does not match the sRGB output intent",
+			pageNum, op.Text)
+	}
+	return nil
+}
+
+// validateGraphicsConformance checks a graphics operation on page pageNum.
+func (c *Creator) validateGraphicsConformance(pageNum int, op *GraphicsOperation) error {
+	if opacity := shapeOpacity(op); opacity != nil && *opacity < 1.0 {
+		return c.nonConforming("page %d: a shape has opacity %.2f; transparency is not allowed", pageNum, *opacity)
+	}
+
+	switch {
+	case op.Type == GraphicsOpImage && op.Image != nil:
+		if len(op.Image.AlphaMask()) > 0 {
+			return c.nonConforming("page %d: an image has an alpha channel; transparency is not allowed", pageNum)
+		}
+		if op.Image.ColorSpace() == ColorSpaceCMYK {
+			return c.nonConforming("page %d: a CMYK image does not match the sRGB output intent", pageNum)
+		}
+	case op.Type == GraphicsOpWatermark && op.WatermarkOp != nil:
+		wm := op.WatermarkOp
+		if wm.Opacity() < 1.0 {
+			return c.nonConforming("page %d: watermark %q has opacity %.2f; transparency is not allowed",
+				pageNum, wm.Text(), wm.Opacity())
+		}
+		return c.nonConforming("page %d: watermark %q uses the standard font %s, which is not embedded",
+			pageNum, wm.Text(), wm.Font())
+	case op.Type == GraphicsOpTemplate && op.Template != nil:
+		// Template content is drawn on the page, so it must conform too.
+		return c.validatePageConformance(pageNum, op.Template.textOps, op.Template.graphicsOps)
+	}
+
+	gop := convertGraphicsOps([]GraphicsOperation{*op})[0]
+	if gop.StrokeColorCMYK != nil || gop.FillColorCMYK != nil {
+		return c.nonConforming("page %d: a shape uses a CMYK color, which does not match the sRGB output intent", pageNum)
+	}
+	return nil
+}
+
+// nonConforming returns an error wrapping ErrNonConforming.
+func (c *Creator) nonConforming(format string, args ...any) error {
+	return fmt.Errorf("%w to %s: %s", ErrNonConforming, c.conformance, fmt.Sprintf(format, args...))
+}
+
+// shapeOpacity returns the opacity of a shape operation, or nil if unset.
+func shapeOpacity(op *GraphicsOperation) *float64 {
+	switch {
+	case op.LineOpts != nil:
+		return op.LineOpts.Opacity
+	case op.RectOpts != nil:
+		return op.RectOpts.Opacity
+	case op.CircleOpts != nil:
+		return op.CircleOpts.Opacity
+	case op.PolygonOpts != nil:
+		return op.PolygonOpts.Opacity
+	case op.PolylineOpts != nil:
+		return op.PolylineOpts.Opacity
+	case op.EllipseOpts != nil:
+		return op.EllipseOpts.Opacity
+	case op.BezierOpts != nil:
+		return op.BezierOpts.Opacity
+	case op.ArcOpts != nil:
+		return op.ArcOpts.Opacity
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package creator
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// loadTestFont loads the Go Regular TrueType font.
+func loadTestFont(t *testing.T) *CustomFont {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "goregular.ttf")
+	require.NoError(t, os.WriteFile(path, goregular.TTF, 0o600))
+	font, err := LoadFont(path)
+	require.NoError(t, err)
+	return font
+}
+
+func TestCreator_SetConformancePDFA1B(t *testing.T) {
+	c := New()
+	c.SetTitle("Archive")
+	c.SetConformance(PDFA1B)
+	c.SetXRefStreamOutput(true) // Not allowed in PDF/A-1, ignored
+
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddTextCustomFont("Archived", 72, 750, loadTestFont(t), 12))
+	require.NoError(t, page.DrawRect(72, 600, 100, 50, &RectOptions{FillColor: &Black}))
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+
+	// One sRGB output intent pointing at an ICC profile stream.
+	intent := regexp.MustCompile(`/Type /Catalog[^\n]* /OutputIntents \[<< /Type /OutputIntent /S /GTS_PDFA1` +
+		` /OutputConditionIdentifier \(sRGB IEC61966-2\.1\) /RegistryName \(http://www\.color\.org\)` +
+		` /Info \(sRGB IEC61966-2\.1\) /DestOutputProfile (\d+) 0 R >>\]`).FindSubmatch(pdf)
+	require.NotNil(t, intent, "catalog should have an sRGB output intent")
+	profile := regexp.MustCompile(`\n` + string(intent[1]) + ` 0 obj\n<< /N 3 /Filter /FlateDecode /Length \d+ >>\nstream\n`)
+	assert.True(t, profile.Match(pdf), "output intent should reference a three-component ICC profile stream")
+
+	_, packet := metadataStream(t, pdf)
+	assert.Contains(t, string(packet), `xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/"`)
+	assert.Contains(t, string(packet), "<pdfaid:part>1</pdfaid:part>")
+	assert.Contains(t, string(packet), "<pdfaid:conformance>B</pdfaid:conformance>")
+
+	assert.Regexp(t, `trailer\n<<[^\n]* /ID \[<[0-9a-f]{32}> <[0-9a-f]{32}>\] >>`, string(pdf))
+	assert.NotContains(t, string(pdf), "/Type /XRef")
+	assert.NotContains(t, string(pdf), "/Subtype /Type1", "no font may be left unembedded")
+}
+
+func TestCreator_SetConformancePDFA1BRejects(t *testing.T) {
+	opacity := 0.5
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, c *Creator, page *Page)
+		want  string
+	}{
+		{
+			name: "standard font",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				require.NoError(t, page.AddText("Hello", 72, 750, Helvetica, 12))
+			},
+			want: `page 1: text "Hello" uses the standard font Helvetica, which is not embedded`,
+		},
+		{
+			name: "shape opacity",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				require.NoError(t, page.DrawRect(72, 600, 100, 50, &RectOptions{FillColor: &Red, Opacity: &opacity}))
+			},
+			want: "page 1: a shape has opacity 0.50; transparency is not allowed",
+		},
+		{
+			name: "watermark",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				require.NoError(t, page.DrawWatermark(NewTextWatermark("DRAFT")))
+			},
+			want: `page 1: watermark "DRAFT" has opacity 0.50`,
+		},
+		{
+			name: "CMYK color",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				require.NoError(t, page.DrawLine(72, 600, 172, 600, &LineOptions{Width: 1, ColorCMYK: &ColorCMYK{K: 1}}))
+			},
+			want: "page 1: a shape uses a CMYK color",
+		},
+		{
+			name: "standard font in template",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				tpl, err := NewTemplate(100, 40)
+				require.NoError(t, err)
+				require.NoError(t, tpl.AddText("Logo", 10, 14, Helvetica, 12))
+				require.NoError(t, page.DrawTemplate(tpl, 72, 700, 1))
+			},
+			want: `page 1: text "Logo" uses the standard font Helvetica, which is not embedded`,
+		},
+		{
+			name: "opacity in nested template",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				inner, err := NewTemplate(100, 40)
+				require.NoError(t, err)
+				require.NoError(t, inner.DrawRect(0, 0, 100, 40, &RectOptions{FillColor: &Red, Opacity: &opacity}))
+				outer, err := NewTemplate(100, 40)
+				require.NoError(t, err)
+				require.NoError(t, outer.DrawTemplate(inner, 0, 0, 1))
+				require.NoError(t, page.DrawTemplate(outer, 72, 700, 1))
+			},
+			want: "page 1: a shape has opacity 0.50; transparency is not allowed",
+		},
+		{
+			name: "CMYK color in template",
+			setup: func(t *testing.T, _ *Creator, page *Page) {
+				tpl, err := NewTemplate(100, 40)
+				require.NoError(t, err)
+				require.NoError(t, tpl.DrawLine(0, 0, 100, 0, &LineOptions{Width: 1, ColorCMYK: &ColorCMYK{K: 1}}))
+				require.NoError(t, page.DrawTemplate(tpl, 72, 700, 1))
+			},
+			want: "page 1: a shape uses a CMYK color",
+		},
+		{
+			name: "encryption",
+			setup: func(t *testing.T, c *Creator, _ *Page) {
+				require.NoError(t, c.SetEncryption(EncryptionOptions{UserPassword: "secret"}))
+			},
+			want: "encryption is not allowed",
+		},
+		{
+			name: "XMP without identification",
+			setup: func(_ *testing.T, c *Creator, _ *Page) {
+				c.SetXMP([]byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"/>`))
+			},
+			want: "has no PDF/A identification",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			c.SetConformance(PDFA1B)
+			page, err := c.NewPage()
+			require.NoError(t, err)
+			tt.setup(t, c, page)
+
+			_, err = c.Bytes()
+			require.ErrorIs(t, err, ErrNonConforming)
+			assert.Contains(t, err.Error(), "to PDF/A-1b: ")
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestCreator_ConformanceNoneAllowsStandardFonts(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddText("Hello", 72, 750, Helvetica, 12))
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(pdf), "/OutputIntents")
+	assert.NotContains(t, string(pdf), "pdfaid")
+}
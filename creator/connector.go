@@ -0,0 +1,349 @@
+package creator
+
+import (
+	"errors"
+	"math"
+)
+
+// ConnectorStyle selects how DrawConnector routes the path between its endpoints.
+type ConnectorStyle int
+
+const (
+	// ConnectorStraight draws a single straight segment.
+	ConnectorStraight ConnectorStyle = iota
+
+	// ConnectorOrthogonal routes the path with horizontal and vertical
+	// segments only: a Z-shape turning at the midpoint, or an L-shape
+	// when ConnectorOptions.LShape is set.
+	ConnectorOrthogonal
+
+	// ConnectorCurved draws a cubic Bézier curve that leaves and enters
+	// the endpoints along the routing axis.
+	ConnectorCurved
+)
+
+// ConnectorArrows selects which ends of a connector get an arrowhead.
+type ConnectorArrows int
+
+const (
+	// ArrowNone draws no arrowheads.
+	ArrowNone ConnectorArrows = iota
+
+	// ArrowEnd draws an arrowhead at the "to" point.
+	ArrowEnd
+
+	// ArrowStart draws an arrowhead at the "from" point.
+	ArrowStart
+
+	// ArrowBoth draws arrowheads at both ends.
+	ArrowBoth
+)
+
+// ConnectorOptions configures connector drawing.
+type ConnectorOptions struct {
+	// Color is the line and arrowhead color (RGB, 0.0 to 1.0 range).
+	// If ColorCMYK is set, this field is ignored.
+	Color Color
+
+	// ColorCMYK is the line and arrowhead color in CMYK (optional).
+	// If set, this takes precedence over Color (RGB).
+	ColorCMYK *ColorCMYK
+
+	// Width is the line width in points (default: 1.0).
+	Width float64
+
+	// Dashed enables dashed line rendering. Arrowheads are always solid.
+	Dashed bool
+
+	// DashArray defines the dash pattern (e.g., [3, 1] for "3 on, 1 off").
+	// Only used when Dashed is true.
+	DashArray []float64
+
+	// DashPhase is the starting offset into the dash pattern.
+	// Only used when Dashed is true.
+	DashPhase float64
+
+	// Arrows selects the ends that get a filled arrowhead (default: none).
+	Arrows ConnectorArrows
+
+	// ArrowSize is the arrowhead length in points
+	// (default: 4 times the line width, at least 6 points).
+	ArrowSize float64
+
+	// VerticalFirst makes orthogonal and curved connectors leave the
+	// "from" point vertically instead of horizontally.
+	VerticalFirst bool
+
+	// LShape routes orthogonal connectors with a single corner instead
+	// of the default Z-shape.
+	LShape bool
+
+	// Opacity is the connector opacity (0.0 = transparent, 1.0 = opaque).
+	// Optional. If set, applies transparency via ExtGState.
+	// Range: [0.0, 1.0]
+	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
+}
+
+// DrawConnector draws a line between two points routed in the given style,
+// optionally with arrowheads, as used for flowcharts and diagrams.
+//
+// Orthogonal connectors turn at the midpoint between the endpoints
+// (Z-shape) or at a single corner (L-shape); curved connectors bend along
+// the same axis. The path is shortened under each arrowhead so the line
+// does not poke through its tip.
+//
+// Parameters:
+//   - from: Start point
+//   - to: End point
+//   - style: Routing style (straight, orthogonal, curved)
+//   - opts: Connector options (color, width, dash pattern, arrowheads)
+//
+// Example:
+//
+//	opts := &creator.ConnectorOptions{
+//	    Color:  creator.Black,
+//	    Width:  1.0,
+//	    Arrows: creator.ArrowEnd,
+//	}
+//	err := page.DrawConnector(
+//	    creator.Point{X: 150, Y: 700},
+//	    creator.Point{X: 350, Y: 600},
+//	    creator.ConnectorOrthogonal,
+//	    opts,
+//	)
+func (p *Page) DrawConnector(from, to Point, style ConnectorStyle, opts *ConnectorOptions) error {
+	if opts == nil {
+		return errors.New("connector options cannot be nil")
+	}
+
+	if err := validateConnectorOptions(opts); err != nil {
+		return err
+	}
+
+	if from == to {
+		return errors.New("connector endpoints must differ")
+	}
+
+	size := connectorArrowSize(opts)
+	startArrow := opts.Arrows == ArrowStart || opts.Arrows == ArrowBoth
+	endArrow := opts.Arrows == ArrowEnd || opts.Arrows == ArrowBoth
+
+	switch style {
+	case ConnectorStraight, ConnectorOrthogonal:
+		vertices := []Point{from, to}
+		if style == ConnectorOrthogonal {
+			vertices = orthogonalRoute(from, to, opts.VerticalFirst, opts.LShape)
+		}
+		n := len(vertices)
+
+		// Arrowheads follow the direction of the first and last segments.
+		var startTip, endTip Point
+		if startArrow {
+			startTip = vertices[0]
+			vertices[0] = shortenTowards(vertices[0], vertices[1], size)
+		}
+		if endArrow {
+			endTip = vertices[n-1]
+			vertices[n-1] = shortenTowards(vertices[n-1], vertices[n-2], size)
+		}
+
+		if err := p.DrawPolyline(vertices, connectorPolylineOptions(opts)); err != nil {
+			return err
+		}
+		if startArrow {
+			if err := p.drawArrowhead(startTip, vertices[1], size, opts); err != nil {
+				return err
+			}
+		}
+		if endArrow {
+			if err := p.drawArrowhead(endTip, vertices[n-2], size, opts); err != nil {
+				return err
+			}
+		}
+
+	case ConnectorCurved:
+		seg := curvedRoute(from, to, opts.VerticalFirst)
+
+		// Move each shortened endpoint's control point with it so the
+		// curve keeps its tangent under the arrowhead.
+		if startArrow {
+			moved := shortenTowards(seg.Start, seg.C1, size)
+			seg.C1 = Point{X: seg.C1.X + moved.X - seg.Start.X, Y: seg.C1.Y + moved.Y - seg.Start.Y}
+			seg.Start = moved
+		}
+		if endArrow {
+			moved := shortenTowards(seg.End, seg.C2, size)
+			seg.C2 = Point{X: seg.C2.X + moved.X - seg.End.X, Y: seg.C2.Y + moved.Y - seg.End.Y}
+			seg.End = moved
+		}
+
+		if err := p.DrawBezierCurve([]BezierSegment{seg}, connectorBezierOptions(opts)); err != nil {
+			return err
+		}
+		if startArrow {
+			if err := p.drawArrowhead(from, seg.C1, size, opts); err != nil {
+				return err
+			}
+		}
+		if endArrow {
+			if err := p.drawArrowhead(to, seg.C2, size, opts); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return errors.New("invalid connector style")
+	}
+
+	return nil
+}
+
+// orthogonalRoute returns the vertices of a right-angle path from "from"
+// to "to". Collinear endpoints give a single segment.
+func orthogonalRoute(from, to Point, verticalFirst, lShape bool) []Point {
+	if from.X == to.X || from.Y == to.Y {
+		return []Point{from, to}
+	}
+
+	if lShape {
+		corner := Point{X: to.X, Y: from.Y}
+		if verticalFirst {
+			corner = Point{X: from.X, Y: to.Y}
+		}
+		return []Point{from, corner, to}
+	}
+
+	if verticalFirst {
+		midY := (from.Y + to.Y) / 2
+		return []Point{from, {X: from.X, Y: midY}, {X: to.X, Y: midY}, to}
+	}
+	midX := (from.X + to.X) / 2
+	return []Point{from, {X: midX, Y: from.Y}, {X: midX, Y: to.Y}, to}
+}
+
+// curvedRoute returns an S-shaped Bézier segment from "from" to "to" whose
+// control points sit halfway along the routing axis.
+func curvedRoute(from, to Point, verticalFirst bool) BezierSegment {
+	if verticalFirst {
+		midY := (from.Y + to.Y) / 2
+		return BezierSegment{
+			Start: from,
+			C1:    Point{X: from.X, Y: midY},
+			C2:    Point{X: to.X, Y: midY},
+			End:   to,
+		}
+	}
+	midX := (from.X + to.X) / 2
+	return BezierSegment{
+		Start: from,
+		C1:    Point{X: midX, Y: from.Y},
+		C2:    Point{X: midX, Y: to.Y},
+		End:   to,
+	}
+}
+
+// shortenTowards moves p by distance d towards q, stopping at q.
+func shortenTowards(p, q Point, d float64) Point {
+	dx, dy := q.X-p.X, q.Y-p.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return p
+	}
+	if d > length {
+		d = length
+	}
+	return Point{X: p.X + dx/length*d, Y: p.Y + dy/length*d}
+}
+
+// drawArrowhead draws a filled arrowhead with its tip at tip, pointing
+// away from the point "from".
+func (p *Page) drawArrowhead(tip, from Point, size float64, opts *ConnectorOptions) error {
+	dx, dy := tip.X-from.X, tip.Y-from.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil
+	}
+	ux, uy := dx/length, dy/length
+
+	// Base of the arrowhead, with a half-width of 0.4 × its length.
+	bx, by := tip.X-ux*size, tip.Y-uy*size
+	hw := size * 0.4
+
+	vertices := []Point{
+		tip,
+		{X: bx - uy*hw, Y: by + ux*hw},
+		{X: bx + uy*hw, Y: by - ux*hw},
+	}
+
+	color := opts.Color
+	return p.DrawPolygon(vertices, &PolygonOptions{
+		FillColor:     &color,
+		FillColorCMYK: opts.ColorCMYK,
+		Opacity:       opts.Opacity,
+		GraphicsState: opts.GraphicsState,
+	})
+}
+
+// connectorArrowSize returns the arrowhead length for opts.
+func connectorArrowSize(opts *ConnectorOptions) float64 {
+	if opts.ArrowSize > 0 {
+		return opts.ArrowSize
+	}
+	width := opts.Width
+	if width == 0 {
+		width = 1.0
+	}
+	return math.Max(6, 4*width)
+}
+
+// connectorPolylineOptions converts connector options to polyline options.
+func connectorPolylineOptions(opts *ConnectorOptions) *PolylineOptions {
+	return &PolylineOptions{
+		Color:         opts.Color,
+		ColorCMYK:     opts.ColorCMYK,
+		Width:         opts.Width,
+		Dashed:        opts.Dashed,
+		DashArray:     opts.DashArray,
+		DashPhase:     opts.DashPhase,
+		Opacity:       opts.Opacity,
+		GraphicsState: opts.GraphicsState,
+	}
+}
+
+// connectorBezierOptions converts connector options to Bézier options.
+func connectorBezierOptions(opts *ConnectorOptions) *BezierOptions {
+	return &BezierOptions{
+		Color:         opts.Color,
+		ColorCMYK:     opts.ColorCMYK,
+		Width:         opts.Width,
+		Dashed:        opts.Dashed,
+		DashArray:     opts.DashArray,
+		DashPhase:     opts.DashPhase,
+		Opacity:       opts.Opacity,
+		GraphicsState: opts.GraphicsState,
+	}
+}
+
+// validateConnectorOptions validates connector drawing options.
+func validateConnectorOptions(opts *ConnectorOptions) error {
+	if err := validateColor(opts.Color); err != nil {
+		return err
+	}
+
+	if opts.Width < 0 {
+		return errors.New("line width must be non-negative")
+	}
+
+	if opts.ArrowSize < 0 {
+		return errors.New("arrow size must be non-negative")
+	}
+
+	if opts.Arrows < ArrowNone || opts.Arrows > ArrowBoth {
+		return errors.New("invalid connector arrows")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package creator
+
+import (
+	"testing"
+)
+
+func TestDrawConnector(t *testing.T) {
+	from := Point{X: 100, Y: 700}
+	to := Point{X: 300, Y: 600}
+
+	tests := []struct {
+		name      string
+		style     ConnectorStyle
+		opts      *ConnectorOptions
+		wantTypes []GraphicsOpType
+	}{
+		{
+			name:      "straight",
+			style:     ConnectorStraight,
+			opts:      &ConnectorOptions{Color: Black, Width: 1},
+			wantTypes: []GraphicsOpType{GraphicsOpPolyline},
+		},
+		{
+			name:      "orthogonal with end arrow",
+			style:     ConnectorOrthogonal,
+			opts:      &ConnectorOptions{Color: Black, Width: 1, Arrows: ArrowEnd},
+			wantTypes: []GraphicsOpType{GraphicsOpPolyline, GraphicsOpPolygon},
+		},
+		{
+			name:      "curved with both arrows",
+			style:     ConnectorCurved,
+			opts:      &ConnectorOptions{Color: Blue, Width: 2, Arrows: ArrowBoth},
+			wantTypes: []GraphicsOpType{GraphicsOpBezier, GraphicsOpPolygon, GraphicsOpPolygon},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			if err != nil {
+				t.Fatalf("NewPage() error = %v", err)
+			}
+
+			if err := page.DrawConnector(from, to, tt.style, tt.opts); err != nil {
+				t.Fatalf("DrawConnector() error = %v", err)
+			}
+
+			ops := page.GraphicsOperations()
+			if len(ops) != len(tt.wantTypes) {
+				t.Fatalf("len(ops) = %d, want %d", len(ops), len(tt.wantTypes))
+			}
+			for i, want := range tt.wantTypes {
+				if ops[i].Type != want {
+					t.Errorf("ops[%d].Type = %v, want %v", i, ops[i].Type, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawConnector_Errors(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+
+	from := Point{X: 100, Y: 100}
+	to := Point{X: 200, Y: 200}
+
+	if err := page.DrawConnector(from, to, ConnectorStraight, nil); err == nil {
+		t.Error("expected error for nil options")
+	}
+	if err := page.DrawConnector(from, from, ConnectorStraight, &ConnectorOptions{}); err == nil {
+		t.Error("expected error for identical endpoints")
+	}
+	if err := page.DrawConnector(from, to, ConnectorStraight, &ConnectorOptions{Width: -1}); err == nil {
+		t.Error("expected error for negative width")
+	}
+	if err := page.DrawConnector(from, to, ConnectorStyle(99), &ConnectorOptions{}); err == nil {
+		t.Error("expected error for invalid style")
+	}
+}
+
+func TestOrthogonalRoute(t *testing.T) {
+	from := Point{X: 0, Y: 0}
+	to := Point{X: 100, Y: 50}
+
+	tests := []struct {
+		name          string
+		verticalFirst bool
+		lShape        bool
+		want          []Point
+	}{
+		{"Z horizontal", false, false, []Point{from, {X: 50, Y: 0}, {X: 50, Y: 50}, to}},
+		{"Z vertical", true, false, []Point{from, {X: 0, Y: 25}, {X: 100, Y: 25}, to}},
+		{"L horizontal", false, true, []Point{from, {X: 100, Y: 0}, to}},
+		{"L vertical", true, true, []Point{from, {X: 0, Y: 50}, to}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orthogonalRoute(from, to, tt.verticalFirst, tt.lShape)
+			if len(got) != len(tt.want) {
+				t.Fatalf("orthogonalRoute() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("orthogonalRoute()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if got := orthogonalRoute(from, Point{X: 100, Y: 0}, false, false); len(got) != 2 {
+		t.Errorf("collinear route = %v, want a single segment", got)
+	}
+}
+
+func TestDrawConnector_ArrowShortensLine(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+
+	opts := &ConnectorOptions{Color: Black, Width: 1, Arrows: ArrowEnd, ArrowSize: 10}
+	if err := page.DrawConnector(Point{X: 100, Y: 100}, Point{X: 200, Y: 100}, ConnectorStraight, opts); err != nil {
+		t.Fatalf("DrawConnector() error = %v", err)
+	}
+
+	ops := page.GraphicsOperations()
+	line := ops[0].Vertices
+	if end := line[len(line)-1]; end != (Point{X: 190, Y: 100}) {
+		t.Errorf("line end = %v, want {190 100}", end)
+	}
+	if tip := ops[1].Vertices[0]; tip != (Point{X: 200, Y: 100}) {
+		t.Errorf("arrow tip = %v, want {200 100}", tip)
+	}
+}
@@ -17,10 +17,13 @@ package creator
 
 import (
 	"bytes"
+	"compress/flate"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/coregx/gxpdf/internal/document"
 	"github.com/coregx/gxpdf/internal/fonts"
@@ -39,6 +42,9 @@ import (
 // own Creator instance. However, multiple Creator instances can safely be
 // used concurrently without synchronization.
 //
+// To build one document from several goroutines, wrap the Creator in a
+// SafeBuilder, which serializes access.
+//
 // # Example
 //
 //	c := creator.New()
@@ -63,6 +69,8 @@ type Creator struct {
 	// Header and footer configuration
 	headerFunc      HeaderFunc
 	footerFunc      FooterFunc
+	header          PageDecorator
+	footer          PageDecorator
 	headerHeight    float64
 	footerHeight    float64
 	skipHeaderFirst bool
@@ -71,8 +79,51 @@ type Creator struct {
 	// Encryption options (set via SetEncryption)
 	encryptionOpts *EncryptionOptions
 
+	// Resource limits (set via SetLimits)
+	limits Limits
+
+	// Embed a SHA-256 checksum in the Info dictionary (set via EmbedChecksum)
+	checksum bool
+
+	// Content stream layout (set via SetContentStreamStyle)
+	contentStyle ContentStreamStyle
+
+	// Flate compression level (set via SetCompressionLevel)
+	compression writer.CompressionLevel
+
+	// Page-chunked object order (set via SetPageChunkedOutput)
+	pageChunked bool
+
+	// Empty content streams for blank pages (set via SetEmptyContentStreams)
+	emptyContentStreams bool
+
+	// Resource names derived from object numbers (set via SetGlobalResourceNames)
+	globalResourceNames bool
+
+	// Cross-reference stream output (set via SetXRefStreamOutput)
+	xrefStream bool
+
+	// Object stream output (set via SetObjectStreamOutput)
+	objectStreams bool
+
+	// Usage rights signature placeholder (set via SetUsageRights)
+	usageRights *UsageRights
+
+	// AcroForm dictionary options (set via SetAcroFormOptions)
+	acroFormOpts *AcroFormOptions
+
+	// XMP metadata (set via EmbedXMPMetadata and SetXMP)
+	embedXMP bool
+	xmp      []byte
+
+	// Standard the output conforms to (set via SetConformance)
+	conformance Conformance
+
+	// Unicode normalization of page text (set via SetNormalization)
+	normalization Normalization
+
 	// Bookmarks (document outline)
-	bookmarks []Bookmark
+	bookmarks []*Bookmark
 
 	// Table of Contents (TOC)
 	tocEnabled bool
@@ -114,10 +165,11 @@ func New() *Creator {
 		pages:        make([]*Page, 0),
 		headerHeight: DefaultHeaderHeight,
 		footerHeight: DefaultFooterHeight,
-		bookmarks:    make([]Bookmark, 0),
+		bookmarks:    make([]*Bookmark, 0),
 		tocEnabled:   false,
 		toc:          NewTOC(),
 		chapters:     make([]*Chapter, 0),
+		compression:  writer.DefaultCompression,
 	}
 }
 
@@ -133,6 +185,10 @@ func New() *Creator {
 //	page := c.NewPage()
 //	// Add content to page...
 func (c *Creator) NewPage() (*Page, error) {
+	if err := c.checkPageLimit(); err != nil {
+		return nil, err
+	}
+
 	domainPage, err := c.doc.AddPage(c.defaultPageSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add page: %w", err)
@@ -144,6 +200,9 @@ func (c *Creator) NewPage() (*Page, error) {
 		margins:     c.defaultMargins,
 		textOps:     make([]TextOperation, 0),
 		graphicsOps: make([]GraphicsOperation, 0),
+		nextPage:    c.NewPage,
+
+		normalization: &c.normalization,
 	}
 
 	// Track creator page
@@ -160,6 +219,10 @@ func (c *Creator) NewPage() (*Page, error) {
 //
 //	page := c.NewPageWithSize(creator.Letter)
 func (c *Creator) NewPageWithSize(size PageSize) (*Page, error) {
+	if err := c.checkPageLimit(); err != nil {
+		return nil, err
+	}
+
 	domainSize := size.toDomainSize()
 	domainPage, err := c.doc.AddPage(domainSize)
 	if err != nil {
@@ -171,6 +234,9 @@ func (c *Creator) NewPageWithSize(size PageSize) (*Page, error) {
 		margins:     c.defaultMargins,
 		textOps:     make([]TextOperation, 0),
 		graphicsOps: make([]GraphicsOperation, 0),
+		nextPage:    func() (*Page, error) { return c.NewPageWithSize(size) },
+
+		normalization: &c.normalization,
 	}
 
 	// Track creator page
@@ -252,11 +318,234 @@ func (c *Creator) SetMetadata(title, author, subject string) {
 
 // SetKeywords sets document keywords for search/indexing.
 //
+// The keywords are written comma-separated as the Info /Keywords entry.
+// Calling SetKeywords with no keywords clears them, and empty keywords
+// are skipped.
+//
 // Example:
 //
 //	c.SetKeywords("report", "2025", "finance", "annual")
 func (c *Creator) SetKeywords(keywords ...string) {
-	c.doc.SetMetadata("", "", "", keywords...)
+	c.doc.SetKeywords(keywords)
+}
+
+// Trapped indicates whether a document has been trapped for printing
+// (the Info /Trapped entry).
+type Trapped string
+
+// Trapped states.
+const (
+	// TrappedUnset omits /Trapped (default).
+	TrappedUnset Trapped = ""
+
+	// TrappedTrue means the document has been fully trapped.
+	TrappedTrue Trapped = "True"
+
+	// TrappedFalse means the document has not been trapped.
+	TrappedFalse Trapped = "False"
+
+	// TrappedUnknown means the trapping state is unknown or partial.
+	TrappedUnknown Trapped = "Unknown"
+)
+
+// SetTrapped sets the trapping state written as the Info /Trapped entry,
+// which prepress validators expect.
+//
+// Example:
+//
+//	c.SetTrapped(creator.TrappedFalse)
+func (c *Creator) SetTrapped(trapped Trapped) {
+	c.doc.SetTrapped(document.Trapped(trapped))
+}
+
+// SetShowThumbnails sets whether the document opens with the page
+// thumbnail panel visible (/PageMode /UseThumbs).
+//
+// This only controls the initial viewer panel; viewers generate the
+// thumbnails themselves.
+//
+// Example:
+//
+//	c.SetShowThumbnails(true)
+func (c *Creator) SetShowThumbnails(show bool) {
+	if show {
+		c.doc.SetPageMode(document.PageModeUseThumbs)
+	} else if c.doc.PageMode() == document.PageModeUseThumbs {
+		c.doc.SetPageMode(document.PageModeUseNone)
+	}
+}
+
+// SetLanguage sets the natural language of the document's text (/Lang),
+// as a BCP 47 language tag. Screen readers use it to pick a voice.
+//
+// Example:
+//
+//	c.SetLanguage("en-US")
+func (c *Creator) SetLanguage(lang string) {
+	c.doc.SetLanguage(lang)
+}
+
+// SetTagged marks the document as a tagged PDF (/MarkInfo << /Marked true >>).
+//
+// Images drawn with DrawImageOptions.AltText are tagged as /Figure
+// structure elements whether or not the document is marked; marking it
+// declares to readers that the structure tree should be used.
+//
+// Example:
+//
+//	c.SetTagged(true)
+func (c *Creator) SetTagged(tagged bool) {
+	c.doc.SetTagged(tagged)
+}
+
+// ContentStreamStyle controls how page content stream operators are laid out.
+type ContentStreamStyle int
+
+const (
+	// ContentStreamDefault writes one operator per line.
+	ContentStreamDefault ContentStreamStyle = iota
+
+	// ContentStreamPretty writes one operator per line and indents nested
+	// q/Q and BT/ET blocks. Useful for debugging uncompressed output.
+	ContentStreamPretty
+
+	// ContentStreamCompact minimizes whitespace and number lengths to
+	// reduce file size.
+	ContentStreamCompact
+)
+
+// SetContentStreamStyle sets the layout of page content streams.
+//
+// Styles differ only in formatting; the rendered pages are identical.
+//
+// Example:
+//
+//	c.SetContentStreamStyle(creator.ContentStreamPretty)  // Readable output
+//	c.SetContentStreamStyle(creator.ContentStreamCompact) // Smaller files
+func (c *Creator) SetContentStreamStyle(style ContentStreamStyle) {
+	c.contentStyle = style
+}
+
+// SetCompressionLevel sets the Flate compression level of page content
+// streams and PNG image samples: -1 for the default level, 0 for no
+// compression, or 1 (fastest) to 9 (smallest). The constants of
+// compress/flate can be used.
+//
+// Level 0 writes content streams as plain text, which is useful for
+// inspecting and diffing output; combine it with ContentStreamPretty for
+// readable operators. Fonts and other internal streams always use the
+// default level.
+//
+// Example:
+//
+//	c.SetCompressionLevel(flate.BestSpeed)     // Latency-sensitive services
+//	c.SetCompressionLevel(flate.NoCompression) // Debugging
+func (c *Creator) SetCompressionLevel(level int) error {
+	if level < flate.DefaultCompression || level > flate.BestCompression {
+		return fmt.Errorf("invalid compression level %d (must be -1 to 9)", level)
+	}
+	c.compression = writer.CompressionLevel(level)
+	return nil
+}
+
+// SetPageChunkedOutput orders the output for progressive loading.
+//
+// When enabled, the catalog, page tree and other document-level objects
+// are written first, followed by one contiguous chunk per page, in page
+// order, holding the page and every object it uses (content stream,
+// fonts, images, graphics states, annotations). The xref table stays at
+// the end. A client fetching the file with range requests can render
+// page N once it has received the chunks up to and including page N.
+//
+// This is a lightweight alternative to linearization: no hint streams
+// are written, and viewers that require a linearized file for fast web
+// view will not treat the output as such.
+//
+// Example:
+//
+//	c.SetPageChunkedOutput(true)
+//	_, err := c.WriteTo(w) // Page 1 is complete before page 2 begins
+func (c *Creator) SetPageChunkedOutput(chunked bool) {
+	c.pageChunked = chunked
+}
+
+// SetEmptyContentStreams gives pages with nothing drawn on them an empty
+// content stream, so that every page has a /Contents entry.
+//
+// Blank pages are valid without a content stream, but some strict
+// validators warn about them. Enable this when producing deliberately
+// blank pages, such as separator pages, for such tools.
+//
+// Example:
+//
+//	c.SetEmptyContentStreams(true)
+//	_, _ = c.NewPage() // Blank separator page
+func (c *Creator) SetEmptyContentStreams(enabled bool) {
+	c.emptyContentStreams = enabled
+}
+
+// SetGlobalResourceNames names page resources after their PDF object
+// numbers (/F12, /Im30) instead of numbering them per page (/F1, /Im1).
+//
+// Images, fonts and templates shared by several pages are then referenced
+// by the same name on every page, which makes the output easier to debug
+// and diff. The default per-page names are equally valid.
+//
+// Example:
+//
+//	c.SetGlobalResourceNames(true)
+//	err := c.WriteToFile("report.pdf")
+func (c *Creator) SetGlobalResourceNames(enabled bool) {
+	c.globalResourceNames = enabled
+}
+
+// SetXRefStreamOutput writes the cross-reference section as a compressed
+// cross-reference stream (PDF 1.5) instead of a classic xref table.
+//
+// This makes the file smaller, especially for documents with many
+// objects. Readers older than PDF 1.5 cannot open such files.
+//
+// Example:
+//
+//	c.SetXRefStreamOutput(true)
+//	err := c.WriteToFile("compact.pdf")
+func (c *Creator) SetXRefStreamOutput(enabled bool) {
+	c.xrefStream = enabled
+}
+
+// SetObjectStreamOutput packs page, font, annotation and other dictionary
+// objects into compressed object streams (PDF 1.5), with a cross-reference
+// stream to locate them. Content streams and images are unaffected.
+//
+// This roughly halves the size of long text documents, whose page
+// dictionaries would otherwise dominate the file. It implies
+// SetXRefStreamOutput(true). With SetPageChunkedOutput, objects are not
+// packed, so that each page chunk stays self-contained; only the
+// cross-reference stream is used.
+//
+// Example:
+//
+//	c.SetObjectStreamOutput(true)
+//	err := c.WriteToFile("compact.pdf")
+func (c *Creator) SetObjectStreamOutput(enabled bool) {
+	c.objectStreams = enabled
+}
+
+// configureWriter applies the output settings to a PDF writer.
+func (c *Creator) configureWriter(w *writer.PdfWriter) {
+	w.SetContentStyle(writer.ContentStyle(c.contentStyle))
+	_ = w.SetCompression(c.compression) // Validated by SetCompressionLevel
+	w.SetLimits(c.limits.MaxObjects, c.limits.MaxBytes)
+	w.SetPageChunked(c.pageChunked)
+	w.SetEmptyContentStreams(c.emptyContentStreams)
+	w.SetGlobalResourceNames(c.globalResourceNames)
+	w.SetXRefStream(c.xrefStream)
+	w.SetObjectStreams(c.objectStreams)
+	w.SetUsageRights(c.usageRights.toWriter())
+	w.SetAcroFormOptions(c.acroFormOpts.toWriter())
+	w.SetXMPMetadata(c.xmpPacket())
+	w.SetOutline(c.outline())
+	w.SetPDFA(c.conformance == PDFA1B)
 }
 
 // SetHeaderFunc sets the function to render headers on each page.
@@ -292,6 +581,42 @@ func (c *Creator) SetFooterFunc(f FooterFunc) {
 	c.footerFunc = f
 }
 
+// SetHeader sets a function that draws a header on each page.
+//
+// The function is called for every page when the document is written,
+// after all pages exist, so pageCount is final. It draws on a scratch
+// page of the same size and margins whose content is placed over the
+// page's own content; the page itself is not modified. Use it for drawing
+// only: flow content cannot continue on a new page.
+//
+// SetSkipHeaderOnFirstPage also applies to this header.
+//
+// Example:
+//
+//	c.SetHeader(func(page *creator.Page, pageNum, pageCount int) {
+//	    _ = page.DrawImage(logo, 50, page.Height()-60, 80, 30)
+//	    rule := &creator.LineOptions{Color: creator.Gray, Width: 0.5}
+//	    _ = page.DrawLine(50, page.Height()-65, page.Width()-50, page.Height()-65, rule)
+//	})
+func (c *Creator) SetHeader(fn PageDecorator) {
+	c.header = fn
+}
+
+// SetFooter sets a function that draws a footer on each page, like
+// SetHeader.
+//
+// SetSkipFooterOnFirstPage also applies to this footer.
+//
+// Example:
+//
+//	c.SetFooter(func(page *creator.Page, pageNum, pageCount int) {
+//	    text := fmt.Sprintf("Page %d of %d", pageNum, pageCount)
+//	    _ = page.AddText(text, page.Width()/2-25, 30, creator.Helvetica, 9)
+//	})
+func (c *Creator) SetFooter(fn PageDecorator) {
+	c.footer = fn
+}
+
 // SetHeaderHeight sets the height reserved for headers in points.
 //
 // Default: 50 points.
@@ -449,13 +774,21 @@ func (c *Creator) Chapters() []*Chapter {
 // Returns an error if:
 // - Document has no pages
 // - Any page validation fails
+// - The document uses a feature forbidden by the conformance level (see SetConformance)
 //
 // It's recommended to call this before WriteToFile to catch errors early.
 func (c *Creator) Validate() error {
+	return c.validate(c.allPageOperations())
+}
+
+// validate is Validate for the page operations returned by
+// allPageOperations, so that writing does not run header and footer
+// functions a second time.
+func (c *Creator) validate(pages []pageContent) error {
 	if err := c.doc.Validate(); err != nil {
 		return fmt.Errorf("document validation failed: %w", err)
 	}
-	return nil
+	return c.validateConformance(pages)
 }
 
 // WriteToFile writes the PDF document to a file.
@@ -509,7 +842,8 @@ func (c *Creator) WriteToFileContext(ctx context.Context, path string) error {
 	}
 
 	// Validate before writing.
-	if err := c.Validate(); err != nil {
+	pages := c.allPageOperations()
+	if err := c.validate(pages); err != nil {
 		return err
 	}
 
@@ -518,6 +852,10 @@ func (c *Creator) WriteToFileContext(ctx context.Context, path string) error {
 		return fmt.Errorf("context canceled before file write: %w", err)
 	}
 
+	if c.needsBuffering() {
+		return c.writeBufferedFile(path, pages)
+	}
+
 	// Create PDF writer.
 	w, err := writer.NewPdfWriter(path)
 	if err != nil {
@@ -530,7 +868,8 @@ func (c *Creator) WriteToFileContext(ctx context.Context, path string) error {
 	}()
 
 	// Write document with page content (text and graphics).
-	textContents, graphicsContents := c.collectAllPageContents()
+	c.configureWriter(w)
+	textContents, graphicsContents := c.convertPageContents(pages)
 	if err := w.WriteWithAllContent(c.doc, textContents, graphicsContents); err != nil {
 		return fmt.Errorf("failed to write PDF: %w", err)
 	}
@@ -587,7 +926,8 @@ func (c *Creator) WriteToContext(ctx context.Context, w io.Writer) (int64, error
 	}
 
 	// Validate before writing.
-	if err := c.Validate(); err != nil {
+	pages := c.allPageOperations()
+	if err := c.validate(pages); err != nil {
 		return 0, err
 	}
 
@@ -596,6 +936,10 @@ func (c *Creator) WriteToContext(ctx context.Context, w io.Writer) (int64, error
 		return 0, fmt.Errorf("context canceled before write: %w", err)
 	}
 
+	if c.needsBuffering() {
+		return c.writeBuffered(w, pages)
+	}
+
 	// Use counting writer to track bytes written.
 	cw := &countingWriter{w: w}
 
@@ -604,7 +948,8 @@ func (c *Creator) WriteToContext(ctx context.Context, w io.Writer) (int64, error
 	defer pdfWriter.Close()
 
 	// Write document with page content.
-	textContents, graphicsContents := c.collectAllPageContents()
+	c.configureWriter(pdfWriter)
+	textContents, graphicsContents := c.convertPageContents(pages)
 	if err := pdfWriter.WriteWithAllContent(c.doc, textContents, graphicsContents); err != nil {
 		return cw.n, fmt.Errorf("failed to write PDF: %w", err)
 	}
@@ -633,6 +978,56 @@ func (c *Creator) Bytes() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// dataURIPrefix is the header of a base64 PDF data URI.
+const dataURIPrefix = "data:application/pdf;base64,"
+
+// DataURI returns the PDF document as a base64 data URI.
+//
+// This is useful for embedding generated PDFs in HTML (for example in an
+// iframe), email, or JSON APIs.
+// For large documents, consider using WriteDataURI with a streaming writer.
+//
+// Example:
+//
+//	uri, err := c.DataURI()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	html := `<iframe src="` + uri + `"></iframe>`
+func (c *Creator) DataURI() (string, error) {
+	var buf strings.Builder
+	if _, err := c.WriteDataURI(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WriteDataURI writes the PDF document to w as a base64 data URI
+// ("data:application/pdf;base64,...").
+//
+// The document is encoded while it is written, so only the encoded form is
+// held by w. It returns the number of bytes written to w.
+//
+// Example:
+//
+//	n, err := c.WriteDataURI(w)
+func (c *Creator) WriteDataURI(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	if _, err := io.WriteString(cw, dataURIPrefix); err != nil {
+		return cw.n, fmt.Errorf("failed to write data URI: %w", err)
+	}
+
+	enc := base64.NewEncoder(base64.StdEncoding, cw)
+	if _, err := c.WriteTo(enc); err != nil {
+		return cw.n, err
+	}
+	if err := enc.Close(); err != nil {
+		return cw.n, fmt.Errorf("failed to write data URI: %w", err)
+	}
+
+	return cw.n, nil
+}
+
 // countingWriter wraps an io.Writer and counts bytes written.
 type countingWriter struct {
 	w io.Writer
@@ -645,47 +1040,93 @@ func (cw *countingWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// pageContent holds the operations of one page, including its header and
+// footer.
+type pageContent struct {
+	text     []TextOperation
+	graphics []GraphicsOperation
+}
+
+// allPageOperations returns the operations of every page. Header and
+// footer functions run once per page, so the result is computed once per
+// write and shared by validation and output.
+func (c *Creator) allPageOperations() []pageContent {
+	pages := make([]pageContent, len(c.pages))
+	for i := range c.pages {
+		pages[i].text, pages[i].graphics = c.pageOperations(i, len(c.pages))
+	}
+	return pages
+}
+
 // collectAllPageContents converts creator operations to writer operations.
 func (c *Creator) collectAllPageContents() (map[int][]writer.TextOp, map[int][]writer.GraphicsOp) {
+	return c.convertPageContents(c.allPageOperations())
+}
+
+// convertPageContents converts the operations returned by
+// allPageOperations to writer operations.
+func (c *Creator) convertPageContents(pages []pageContent) (map[int][]writer.TextOp, map[int][]writer.GraphicsOp) {
 	textContents := make(map[int][]writer.TextOp)
 	graphicsContents := make(map[int][]writer.GraphicsOp)
-	totalPages := len(c.pages)
-
-	for i, creatorPage := range c.pages {
-		pageNum := i + 1 // 1-based page number
-
-		// Collect page text/graphics operations.
-		var pageTextOps []TextOperation
-		var pageGraphicsOps []GraphicsOperation
-
-		// Add header content.
-		if c.headerFunc != nil && !c.shouldSkipHeader(pageNum) {
-			headerOps := c.renderHeader(creatorPage, pageNum, totalPages)
-			pageTextOps = append(pageTextOps, headerOps...)
-		}
-
-		// Add main page content.
-		pageTextOps = append(pageTextOps, creatorPage.textOps...)
-		pageGraphicsOps = append(pageGraphicsOps, creatorPage.graphicsOps...)
-
-		// Add footer content.
-		if c.footerFunc != nil && !c.shouldSkipFooter(pageNum) {
-			footerOps := c.renderFooter(creatorPage, pageNum, totalPages)
-			pageTextOps = append(pageTextOps, footerOps...)
-		}
 
+	for i, page := range pages {
 		// Convert to writer operations.
-		if len(pageTextOps) > 0 {
-			textContents[i] = convertTextOps(pageTextOps)
+		if len(page.text) > 0 {
+			textContents[i] = convertTextOps(c.normalization.normalizeTextOps(page.text))
 		}
-		if len(pageGraphicsOps) > 0 {
-			graphicsContents[i] = convertGraphicsOps(pageGraphicsOps)
+		if len(page.graphics) > 0 {
+			graphicsContents[i] = convertGraphicsOps(c.normalization.normalizeGraphicsOps(page.graphics))
 		}
 	}
 
 	return textContents, graphicsContents
 }
 
+// pageOperations returns the text and graphics operations of page i
+// (0-based), including its header and footer.
+func (c *Creator) pageOperations(i, totalPages int) ([]TextOperation, []GraphicsOperation) {
+	creatorPage := c.pages[i]
+	pageNum := i + 1 // 1-based page number
+
+	var pageTextOps []TextOperation
+	var pageGraphicsOps []GraphicsOperation
+
+	// Add header content.
+	if c.headerFunc != nil && !c.shouldSkipHeader(pageNum) {
+		headerOps := c.renderHeader(creatorPage, pageNum, totalPages)
+		pageTextOps = append(pageTextOps, headerOps...)
+	}
+
+	// Add main page content.
+	pageTextOps = append(pageTextOps, creatorPage.textOps...)
+	pageGraphicsOps = append(pageGraphicsOps, creatorPage.graphicsOps...)
+
+	// Add footer content.
+	if c.footerFunc != nil && !c.shouldSkipFooter(pageNum) {
+		footerOps := c.renderFooter(creatorPage, pageNum, totalPages)
+		pageTextOps = append(pageTextOps, footerOps...)
+	}
+
+	// Add content drawn by SetHeader and SetFooter, over the page content.
+	for _, d := range []struct {
+		fn   PageDecorator
+		skip bool
+	}{
+		{c.header, c.shouldSkipHeader(pageNum)},
+		{c.footer, c.shouldSkipFooter(pageNum)},
+	} {
+		if d.fn == nil || d.skip {
+			continue
+		}
+		overlay := &Page{page: creatorPage.page, margins: creatorPage.margins, normalization: creatorPage.normalization}
+		d.fn(overlay, pageNum, totalPages)
+		pageTextOps = append(pageTextOps, overlay.textOps...)
+		pageGraphicsOps = append(pageGraphicsOps, overlay.graphicsOps...)
+	}
+
+	return pageTextOps, pageGraphicsOps
+}
+
 // shouldSkipHeader returns true if header should be skipped for the given page.
 func (c *Creator) shouldSkipHeader(pageNum int) bool {
 	return c.skipHeaderFirst && pageNum == 1
@@ -817,12 +1258,25 @@ func convertTextOps(ops []TextOperation) []writer.TextOp {
 	textOps := make([]writer.TextOp, 0, len(ops))
 	for _, op := range ops {
 		textOp := writer.TextOp{
-			Text:  op.Text,
-			X:     op.X,
-			Y:     op.Y,
-			Font:  string(op.Font),
-			Size:  op.Size,
-			Color: writer.RGB{R: op.Color.R, G: op.Color.G, B: op.Color.B},
+			Text:        op.Text,
+			X:           op.X,
+			Y:           op.Y,
+			Font:        string(op.Font),
+			Size:        op.Size,
+			Color:       writer.RGB{R: op.Color.R, G: op.Color.G, B: op.Color.B},
+			Continued:   op.Continued,
+			Rotation:    op.Rotation,
+			Matrix:      op.Matrix,
+			CharSpacing: op.CharSpacing,
+			WordSpacing: op.WordSpacing,
+			Rise:        op.Rise,
+		}
+
+		// Decorations span the measured text, including spacing.
+		if op.Underline || op.Strikethrough {
+			textOp.Underline = op.Underline
+			textOp.Strikethrough = op.Strikethrough
+			textOp.DecorationWidth = op.width() + spacingWidth(op.Text, op.CharSpacing, op.WordSpacing)
 		}
 
 		// Handle custom embedded font.
@@ -865,6 +1319,7 @@ func convertGraphicsOps(ops []GraphicsOperation) []writer.GraphicsOp {
 			Radius: op.Radius,
 			RX:     op.RX,
 			RY:     op.RY,
+			Matrix: op.Matrix,
 		}
 
 		// Convert vertices (polygon/polyline)
@@ -890,6 +1345,7 @@ func convertGraphicsOps(ops []GraphicsOperation) []writer.GraphicsOp {
 
 		// Convert Image fields
 		if op.Type == GraphicsOpImage && op.Image != nil {
+			gop.AltText = op.AltText
 			gop.Image = &writer.ImageData{
 				Data:             op.Image.Data(),
 				AlphaMask:        op.Image.AlphaMask(),
@@ -897,10 +1353,31 @@ func convertGraphicsOps(ops []GraphicsOperation) []writer.GraphicsOp {
 				Height:           op.Image.Height(),
 				ColorSpace:       string(op.Image.ColorSpace()),
 				Format:           op.Image.Format(),
+				Filter:           op.Image.filter,
 				BitsPerComponent: op.Image.BitsPerComponent(),
+				DataRaw:          op.Image.dataRaw,
+				AlphaMaskRaw:     op.Image.alphaRaw,
+				Decode:           op.Image.decode,
+				DecodeParms:      op.Image.decodeParms,
+				Palette:          op.Image.palette,
+			}
+			if op.MaskColor != nil {
+				gop.Image.ImageMask = true
+				gop.Image.Decode = op.Image.maskDecode()
+				gop.FillColor = &writer.RGB{R: op.MaskColor.R, G: op.MaskColor.G, B: op.MaskColor.B}
 			}
 		}
 
+		// Convert path commands
+		if op.Type == GraphicsOpPath && op.Path != nil {
+			gop.Path = op.Path.segments()
+		}
+
+		// Convert Template fields
+		if op.Type == GraphicsOpTemplate && op.Template != nil {
+			gop.Form = op.Template.form()
+		}
+
 		// Convert TextBlock fields
 		if op.Type == GraphicsOpTextBlock && op.TextFont != nil {
 			gop.Text = op.Text
@@ -948,6 +1425,9 @@ func convertGraphicsOptions(gop *writer.GraphicsOp, op *GraphicsOperation) {
 		gop.Dashed = op.LineOpts.Dashed
 		gop.DashArray = op.LineOpts.DashArray
 		gop.DashPhase = op.LineOpts.DashPhase
+		gop.LineCap = int(op.LineOpts.LineCap)
+		gop.LineJoin = int(op.LineOpts.LineJoin)
+		gop.MiterLimit = op.LineOpts.MiterLimit
 	}
 
 	// Rectangle options
@@ -979,6 +1459,47 @@ func convertGraphicsOptions(gop *writer.GraphicsOp, op *GraphicsOperation) {
 	if op.BezierOpts != nil {
 		convertBezierOptions(gop, op.BezierOpts)
 	}
+
+	// Arc options
+	if op.ArcOpts != nil {
+		convertArcOptions(gop, op.ArcOpts)
+	}
+
+	// Path options
+	if op.PathOpts != nil {
+		convertPathOptions(gop, op.PathOpts)
+	}
+
+	// Graphics state flags (ExtGState)
+	if gs := graphicsStateOptions(op); gs != nil {
+		gop.StrokeAdjust = gs.StrokeAdjust
+		gop.Overprint = gs.Overprint
+	}
+}
+
+// graphicsStateOptions returns the graphics state flags of a shape operation.
+func graphicsStateOptions(op *GraphicsOperation) *GraphicsStateOptions {
+	switch {
+	case op.LineOpts != nil:
+		return op.LineOpts.GraphicsState
+	case op.RectOpts != nil:
+		return op.RectOpts.GraphicsState
+	case op.CircleOpts != nil:
+		return op.CircleOpts.GraphicsState
+	case op.PolygonOpts != nil:
+		return op.PolygonOpts.GraphicsState
+	case op.PolylineOpts != nil:
+		return op.PolylineOpts.GraphicsState
+	case op.EllipseOpts != nil:
+		return op.EllipseOpts.GraphicsState
+	case op.BezierOpts != nil:
+		return op.BezierOpts.GraphicsState
+	case op.ArcOpts != nil:
+		return op.ArcOpts.GraphicsState
+	case op.PathOpts != nil:
+		return op.PathOpts.GraphicsState
+	}
+	return nil
 }
 
 // convertRectOptions converts rectangle options.
@@ -1002,6 +1523,7 @@ func convertRectOptions(gop *writer.GraphicsOp, opts *RectOptions) {
 	gop.Dashed = opts.Dashed
 	gop.DashArray = opts.DashArray
 	gop.DashPhase = opts.DashPhase
+	gop.CornerRadii = opts.cornerRadii()
 }
 
 // convertCircleOptions converts circle options.
@@ -1022,6 +1544,9 @@ func convertCircleOptions(gop *writer.GraphicsOp, opts *CircleOptions) {
 		gop.FillGradient = convertGradient(opts.FillGradient)
 	}
 	gop.StrokeWidth = opts.StrokeWidth
+	gop.Dashed = opts.Dashed
+	gop.DashArray = opts.DashArray
+	gop.DashPhase = opts.DashPhase
 }
 
 // convertGradient converts a creator gradient to writer gradient.
@@ -1076,6 +1601,7 @@ func convertPolygonOptions(gop *writer.GraphicsOp, opts *PolygonOptions) {
 	gop.Dashed = opts.Dashed
 	gop.DashArray = opts.DashArray
 	gop.DashPhase = opts.DashPhase
+	gop.EvenOdd = opts.FillRule == FillRuleEvenOdd
 }
 
 // convertPolylineOptions converts polyline options.
@@ -1088,6 +1614,9 @@ func convertPolylineOptions(gop *writer.GraphicsOp, opts *PolylineOptions) {
 	gop.Dashed = opts.Dashed
 	gop.DashArray = opts.DashArray
 	gop.DashPhase = opts.DashPhase
+	gop.LineCap = int(opts.LineCap)
+	gop.LineJoin = int(opts.LineJoin)
+	gop.MiterLimit = opts.MiterLimit
 }
 
 // convertEllipseOptions converts ellipse options.
@@ -1108,6 +1637,9 @@ func convertEllipseOptions(gop *writer.GraphicsOp, opts *EllipseOptions) {
 		gop.FillGradient = convertGradient(opts.FillGradient)
 	}
 	gop.StrokeWidth = opts.StrokeWidth
+	gop.Dashed = opts.Dashed
+	gop.DashArray = opts.DashArray
+	gop.DashPhase = opts.DashPhase
 }
 
 // convertBezierOptions converts bezier options.
@@ -1120,6 +1652,9 @@ func convertBezierOptions(gop *writer.GraphicsOp, opts *BezierOptions) {
 	gop.Dashed = opts.Dashed
 	gop.DashArray = opts.DashArray
 	gop.DashPhase = opts.DashPhase
+	gop.LineCap = int(opts.LineCap)
+	gop.LineJoin = int(opts.LineJoin)
+	gop.MiterLimit = opts.MiterLimit
 	gop.Closed = opts.Closed
 	if opts.FillColor != nil {
 		gop.FillColor = &writer.RGB{R: opts.FillColor.R, G: opts.FillColor.G, B: opts.FillColor.B}
@@ -1129,6 +1664,47 @@ func convertBezierOptions(gop *writer.GraphicsOp, opts *BezierOptions) {
 	}
 }
 
+// convertPathOptions converts path options.
+func convertPathOptions(gop *writer.GraphicsOp, opts *PathOptions) {
+	if opts.StrokeColor != nil {
+		gop.StrokeColor = &writer.RGB{R: opts.StrokeColor.R, G: opts.StrokeColor.G, B: opts.StrokeColor.B}
+	}
+	if opts.StrokeColorCMYK != nil {
+		gop.StrokeColorCMYK = &writer.CMYK{C: opts.StrokeColorCMYK.C, M: opts.StrokeColorCMYK.M, Y: opts.StrokeColorCMYK.Y, K: opts.StrokeColorCMYK.K}
+	}
+	if opts.FillColor != nil {
+		gop.FillColor = &writer.RGB{R: opts.FillColor.R, G: opts.FillColor.G, B: opts.FillColor.B}
+	}
+	if opts.FillColorCMYK != nil {
+		gop.FillColorCMYK = &writer.CMYK{C: opts.FillColorCMYK.C, M: opts.FillColorCMYK.M, Y: opts.FillColorCMYK.Y, K: opts.FillColorCMYK.K}
+	}
+	if opts.FillGradient != nil {
+		gop.FillGradient = convertGradient(opts.FillGradient)
+	}
+	gop.StrokeWidth = opts.StrokeWidth
+	gop.Dashed = opts.Dashed
+	gop.DashArray = opts.DashArray
+	gop.DashPhase = opts.DashPhase
+	gop.LineCap = int(opts.LineCap)
+	gop.LineJoin = int(opts.LineJoin)
+	gop.MiterLimit = opts.MiterLimit
+	gop.EvenOdd = opts.FillRule == FillRuleEvenOdd
+}
+
+// convertArcOptions converts arc options. The arc path is closed when
+// it is filled or drawn as a pie slice.
+func convertArcOptions(gop *writer.GraphicsOp, opts *ArcOptions) {
+	convertEllipseOptions(gop, &EllipseOptions{
+		StrokeColor:     opts.StrokeColor,
+		StrokeColorCMYK: opts.StrokeColorCMYK,
+		StrokeWidth:     opts.StrokeWidth,
+		FillColor:       opts.FillColor,
+		FillColorCMYK:   opts.FillColorCMYK,
+		FillGradient:    opts.FillGradient,
+	})
+	gop.Closed = opts.Pie || opts.hasFill()
+}
+
 // renderTOCAndChapters renders the Table of Contents and all chapters.
 //
 // This is called automatically before writing the PDF.
@@ -1,8 +1,18 @@
 package creator
 
 import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/coregx/gxpdf/creator/forms"
+	"github.com/coregx/gxpdf/internal/document"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,6 +68,54 @@ func TestCreator_SetKeywords(t *testing.T) {
 	assert.Contains(t, keywords, "library")
 }
 
+func TestCreator_KeywordsAndTrappedOutput(t *testing.T) {
+	c := New()
+	c.SetKeywords("pdf", "golang")
+	c.SetTrapped(TrappedUnknown)
+	_, err := c.NewPage()
+	require.NoError(t, err)
+
+	data, err := c.Bytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "/Keywords (pdf, golang)")
+	assert.Contains(t, string(data), "/Trapped /Unknown")
+
+	// No keywords clears them.
+	c.SetKeywords()
+	c.SetTrapped(TrappedUnset)
+	data, err = c.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "/Keywords")
+	assert.NotContains(t, string(data), "/Trapped")
+}
+
+func TestCreator_SetEmptyContentStreams(t *testing.T) {
+	c := New()
+	_, err := c.NewPage()
+	require.NoError(t, err)
+
+	data, err := c.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "/Contents")
+
+	c.SetEmptyContentStreams(true)
+	data, err = c.Bytes()
+	require.NoError(t, err)
+	assert.Regexp(t, `/Resources << >> /Contents \d+ 0 R`, string(data))
+	assert.Contains(t, string(data), "<< /Length 0 >>\nstream\nendstream")
+}
+
+func TestCreator_SetShowThumbnails(t *testing.T) {
+	c := New()
+	assert.Equal(t, document.PageModeUseNone, c.Document().PageMode())
+
+	c.SetShowThumbnails(true)
+	assert.Equal(t, document.PageModeUseThumbs, c.Document().PageMode())
+
+	c.SetShowThumbnails(false)
+	assert.Equal(t, document.PageModeUseNone, c.Document().PageMode())
+}
+
 func TestCreator_NewPage(t *testing.T) {
 	c := New()
 
@@ -180,3 +238,148 @@ func TestCreator_MultiplePages(t *testing.T) {
 
 	assert.Equal(t, 3, c.PageCount())
 }
+
+func TestCreator_SetContentStreamStyle(t *testing.T) {
+	build := func(style ContentStreamStyle) string {
+		c := New()
+		c.SetContentStreamStyle(style)
+		page, err := c.NewPage()
+		require.NoError(t, err)
+		require.NoError(t, page.DrawLine(100, 100, 200.5, 200, &LineOptions{Color: Black, Width: 1}))
+
+		data, err := c.Bytes()
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Contains(t, build(ContentStreamDefault), "q\n")
+	assert.Contains(t, build(ContentStreamPretty), "q\n  ")
+	compact := build(ContentStreamCompact)
+	assert.Contains(t, compact, "100 100 m 200.5 200 l")
+	assert.NotContains(t, compact, "100.00")
+}
+
+func TestCreator_SetCompressionLevel(t *testing.T) {
+	build := func(level int) string {
+		c := New()
+		require.NoError(t, c.SetCompressionLevel(level))
+		page, err := c.NewPage()
+		require.NoError(t, err)
+		for i := 0; i < 60; i++ {
+			line := fmt.Sprintf("Row %d: invoice %07d settled for %d.%02d EUR on day %d", i, i*7919%1000003, i*37%997, i*13%100, i%28+1)
+			require.NoError(t, page.AddText(line, 50, 780-float64(i)*12, Helvetica, 9))
+		}
+
+		data, err := c.Bytes()
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	plain := build(flate.NoCompression)
+	assert.Contains(t, plain, "(Row 59: invoice ")
+	assert.NotContains(t, plain, "/Filter /FlateDecode")
+
+	def := build(flate.DefaultCompression)
+	assert.NotContains(t, def, "(Row 59: invoice ")
+	assert.Less(t, len(build(flate.BestCompression)), len(def))
+	assert.Greater(t, len(build(flate.BestSpeed)), len(build(flate.BestCompression)))
+
+	c := New()
+	assert.Error(t, c.SetCompressionLevel(10))
+	assert.Error(t, c.SetCompressionLevel(-2))
+}
+
+func TestCreator_SetCompressionLevel_Images(t *testing.T) {
+	img, err := LoadImageFromReader(bytes.NewReader(createPNGData(t, 64, 64, color.RGBA{R: 10, G: 200, B: 30, A: 128})))
+	require.NoError(t, err)
+	require.True(t, img.IsCompressed())
+
+	c := New()
+	require.NoError(t, c.SetCompressionLevel(flate.NoCompression))
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.DrawImage(img, 100, 100, 64, 64))
+
+	data, err := c.Bytes()
+	require.NoError(t, err)
+	pdf := string(data)
+	assert.NotContains(t, pdf, "/FlateDecode")
+	assert.Contains(t, pdf, fmt.Sprintf("/Length %d >>", 64*64*3), "RGB samples should be stored raw")
+	assert.Contains(t, pdf, fmt.Sprintf("/Length %d >>", 64*64), "alpha samples should be stored raw")
+	assert.True(t, img.IsCompressed(), "the image itself should be unchanged")
+}
+
+func TestCreator_SetPageChunkedOutput(t *testing.T) {
+	img, err := LoadImage(createTempJPEG(t, 8, 8, color.RGBA{0, 0, 255, 255}))
+	require.NoError(t, err)
+
+	c := New()
+	c.SetPageChunkedOutput(true)
+	for i := 0; i < 3; i++ {
+		page, err := c.NewPage()
+		require.NoError(t, err)
+		require.NoError(t, page.AddText("Page text", 100, 700, Helvetica, 12))
+		require.NoError(t, page.DrawImage(img, 100, 100, 50, 50))
+	}
+	size := forms.NewRadioGroup("size")
+	size.AddOption("s", 100, 650, "Small")
+	page := c.pages[1]
+	require.NoError(t, page.AddField(size))
+
+	data, err := c.Bytes()
+	require.NoError(t, err)
+	pdf := string(data)
+
+	// Index every object's position and its dictionary (before any stream).
+	offsets := map[int]int{}
+	dicts := map[int]string{}
+	var pageNums []int
+	matches := regexp.MustCompile(`(?m)^(\d+) 0 obj`).FindAllStringSubmatchIndex(pdf, -1)
+	for _, m := range matches {
+		num, _ := strconv.Atoi(pdf[m[2]:m[3]])
+		body := pdf[m[1]:]
+		body = body[:strings.Index(body, "endobj")]
+		if i := strings.Index(body, "stream\n"); i >= 0 {
+			body = body[:i]
+		}
+		offsets[num], dicts[num] = m[0], body
+		if strings.Contains(body, "/Type /Page ") {
+			pageNums = append(pageNums, num)
+		}
+	}
+	require.Len(t, pageNums, 3)
+
+	refRe := regexp.MustCompile(`(\d+) 0 R`)
+	for i, pageNum := range pageNums {
+		start := offsets[pageNum]
+		end := len(pdf)
+		if i+1 < len(pageNums) {
+			end = offsets[pageNums[i+1]]
+		}
+
+		// Every object reachable from the page is in its chunk, except the
+		// document-level objects written before the first page.
+		queue := []int{pageNum}
+		seen := map[int]bool{pageNum: true}
+		for len(queue) > 0 {
+			num := queue[0]
+			queue = queue[1:]
+			dict := regexp.MustCompile(`/Parent \d+ 0 R`).ReplaceAllString(dicts[num], "")
+			for _, ref := range refRe.FindAllStringSubmatch(dict, -1) {
+				n, _ := strconv.Atoi(ref[1])
+				if seen[n] || offsets[n] < offsets[pageNums[0]] {
+					continue
+				}
+				seen[n] = true
+				assert.True(t, offsets[n] > start && offsets[n] < end,
+					"object %d used by page %d is outside its chunk", n, i+1)
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	// The radio group parent is document-level and precedes the pages.
+	parent := regexp.MustCompile(`(?m)^(\d+) 0 obj\n<< /FT /Btn`).FindStringIndex(pdf)
+	require.NotNil(t, parent)
+	assert.Less(t, parent[0], offsets[pageNums[0]])
+}
@@ -39,9 +39,12 @@ type CustomFont struct {
 // Supported formats:
 //   - TrueType (.ttf)
 //   - OpenType with TrueType outlines (.otf)
+//   - OpenType with CFF outlines (.otf with PostScript outlines, detected
+//     by the "OTTO" signature); embedded as FontFile3 with only the
+//     charstrings of the used glyphs
 //
 // Not yet supported:
-//   - OpenType with CFF outlines (.otf with PostScript outlines)
+//   - OpenType with CFF2 outlines (variable fonts)
 //   - TrueType Collections (.ttc)
 //
 // Returns an error if the file cannot be read or is not a valid font.
@@ -76,6 +79,28 @@ func (f *CustomFont) UseString(text string) {
 	f.isBuilt = false // Invalidate built subset.
 }
 
+// ForceIncludeGlyphs adds characters to the embedded subset even if the
+// document does not use them.
+//
+// Use this when text will be added after the document is created, such
+// as form field values filled in by a later incremental update: the
+// embedded font (glyphs, widths and ToUnicode map) then already covers
+// those characters, so the font does not need to be re-embedded.
+// Characters the font has no glyph for are ignored.
+//
+// Example:
+//
+//	// Cover all of Latin-1 for a form filled in later.
+//	latin1 := make([]rune, 0, 0xFF-0x20+1)
+//	for r := rune(0x20); r <= 0xFF; r++ {
+//	    latin1 = append(latin1, r)
+//	}
+//	font.ForceIncludeGlyphs(latin1)
+func (f *CustomFont) ForceIncludeGlyphs(runes []rune) {
+	f.subset.ForceInclude(runes)
+	f.isBuilt = false // Invalidate built subset.
+}
+
 // MeasureString returns the width of a string in points at the given size.
 //
 // This is used for layout calculations (word wrapping, alignment, etc.).
@@ -0,0 +1,147 @@
+package creator
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFont_OpenTypeCFF(t *testing.T) {
+	font, err := LoadFont("../testdata/fonts/CFFTest.otf")
+	require.NoError(t, err)
+	assert.True(t, font.GetTTF().IsCFF, "OTTO font should take the CFF path")
+
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddTextCustomFont("01", 72, 750, font, 24))
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+
+	out := string(pdf)
+	assert.Regexp(t, `/FontFile3 \d+ 0 R`, out)
+	assert.Contains(t, out, "/Subtype /OpenType")
+	assert.Contains(t, out, "/Subtype /CIDFontType0\n")
+	assert.NotContains(t, out, "/FontFile2")
+	assert.NotContains(t, out, "/CIDToGIDMap")
+}
+
+func TestLoadFont_TrueType(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddTextCustomFont("Hello", 72, 750, loadTestFont(t), 12))
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+
+	out := string(pdf)
+	assert.Regexp(t, `/FontFile2 \d+ 0 R`, out)
+	assert.Contains(t, out, "/Subtype /CIDFontType2\n")
+	assert.NotContains(t, out, "/FontFile3")
+}
+
+func TestAddTextCustomFont_CompositeUnicode(t *testing.T) {
+	// cmapTest.ttf maps 'A' to glyph 6, 'a' to 8, U+4E2D to 12 and
+	// U+1F0A1 (outside the BMP, format 12 cmap only) to 13.
+	font, err := LoadFont("../testdata/fonts/cmapTest.ttf")
+	require.NoError(t, err)
+
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddTextCustomFont("A中a\U0001F0A1", 72, 750, font, 12))
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+	out := string(pdf)
+
+	// Type 0 font with Identity-H encoding and a CIDFontType2 descendant.
+	type0 := regexp.MustCompile(`/Subtype /Type0\n[^>]*/Encoding /Identity-H\n/DescendantFonts \[\d+ 0 R\]\n/ToUnicode (\d+) 0 R`).
+		FindStringSubmatch(out)
+	require.NotNil(t, type0, "expected a Type0 font with Identity-H encoding")
+	assert.Contains(t, out, "/Subtype /CIDFontType2\n")
+	assert.Contains(t, out, "/CIDToGIDMap /Identity\n")
+
+	// Two-byte glyph IDs in the content stream.
+	contents := regexp.MustCompile(`/Contents (\d+) 0 R`).FindStringSubmatch(out)
+	require.NotNil(t, contents)
+	assert.Contains(t, string(inflatedStream(t, pdf, contents[1])), "<0006000C0008000D> Tj")
+
+	// ToUnicode maps every glyph back, with a surrogate pair beyond the BMP.
+	toUnicode := string(inflatedStream(t, pdf, type0[1]))
+	for _, want := range []string{"<0006> <0041>", "<0008> <0061>", "<000C> <4E2D>", "<000D> <D83CDCA1>"} {
+		assert.Contains(t, toUnicode, want)
+	}
+}
+
+// inflatedStream returns the decompressed data of stream object objNum.
+func inflatedStream(t *testing.T, pdf []byte, objNum string) []byte {
+	t.Helper()
+
+	obj := regexp.MustCompile(`(?s)\n` + objNum + ` 0 obj\n(<<.*?>>)\nstream\n`).FindSubmatchIndex(pdf)
+	require.NotNil(t, obj, "stream object %s not found", objNum)
+	dict := string(pdf[obj[2]:obj[3]])
+	require.Contains(t, dict, "/FlateDecode")
+
+	m := regexp.MustCompile(`/Length (\d+)`).FindStringSubmatch(dict)
+	require.NotNil(t, m)
+	length, err := strconv.Atoi(m[1])
+	require.NoError(t, err)
+
+	zr, err := zlib.NewReader(bytes.NewReader(pdf[obj[1] : obj[1]+length]))
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return data
+}
+
+func TestAddTextCustomFont_ToUnicodeRoundTrip(t *testing.T) {
+	const text = "Grüße, naïve café — 1½"
+
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddTextCustomFont(text, 72, 750, loadTestFont(t), 12))
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+	out := string(pdf)
+
+	toUnicodeRef := regexp.MustCompile(`/ToUnicode (\d+) 0 R`).FindStringSubmatch(out)
+	require.NotNil(t, toUnicodeRef)
+	toUnicode := make(map[string]string)
+	for _, m := range regexp.MustCompile(`<([0-9A-F]{4})> <([0-9A-F]+)>\n`).
+		FindAllStringSubmatch(string(inflatedStream(t, pdf, toUnicodeRef[1])), -1) {
+		units := make([]uint16, 0, len(m[2])/4)
+		for i := 0; i < len(m[2]); i += 4 {
+			u, err := strconv.ParseUint(m[2][i:i+4], 16, 16)
+			require.NoError(t, err)
+			units = append(units, uint16(u))
+		}
+		_, dup := toUnicode[m[1]]
+		require.False(t, dup, "code <%s> mapped twice", m[1])
+		toUnicode[m[1]] = string(utf16.Decode(units))
+	}
+
+	contents := regexp.MustCompile(`/Contents (\d+) 0 R`).FindStringSubmatch(out)
+	require.NotNil(t, contents)
+	shown := regexp.MustCompile(`<([0-9A-F]+)> Tj`).FindStringSubmatch(string(inflatedStream(t, pdf, contents[1])))
+	require.NotNil(t, shown)
+
+	var extracted string
+	for i := 0; i < len(shown[1]); i += 4 {
+		code := shown[1][i : i+4]
+		require.Contains(t, toUnicode, code, "code <%s> has no ToUnicode entry", code)
+		extracted += toUnicode[code]
+	}
+	assert.Equal(t, text, extracted)
+}
@@ -482,6 +482,8 @@ func (d *Division) createInnerContext(ctx *LayoutContext) *LayoutContext {
 		},
 		CursorX: ctx.ContentLeft() + d.margins.Left + d.padding.Left + d.getBorderLeftWidth(),
 		CursorY: ctx.CursorY,
+
+		normalization: ctx.normalization,
 	}
 	return innerCtx
 }
@@ -0,0 +1,292 @@
+package creator
+
+// DrawOption configures a shape drawn with one of the Draw...With methods.
+//
+// Draw options are the functional alternative to the option structs
+// (RectOptions, LineOptions, ...): each method collects its options into the
+// matching struct and draws with the struct-based method, so the same
+// defaults and validation apply. Options that do not apply to a shape
+// (for example, WithFill on a line) are ignored.
+//
+// Example:
+//
+//	err := page.DrawRectWith(100, 600, 200, 100,
+//	    creator.WithFill(creator.Red),
+//	    creator.WithOpacity(0.5),
+//	    creator.WithStroke(creator.Black, 2),
+//	)
+type DrawOption func(*drawStyle)
+
+// drawStyle holds the options collected from DrawOptions.
+type drawStyle struct {
+	strokeColor     *Color
+	strokeColorCMYK *ColorCMYK
+	strokeWidth     float64
+	fillColor       *Color
+	fillColorCMYK   *ColorCMYK
+	fillGradient    *Gradient
+	dashArray       []float64
+	dashPhase       float64
+	opacity         *float64
+	graphicsState   *GraphicsStateOptions
+	closed          bool
+}
+
+// newDrawStyle applies opts to an empty style.
+func newDrawStyle(opts []DrawOption) *drawStyle {
+	s := &drawStyle{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// WithStroke sets the stroke (outline or line) color and width in points.
+func WithStroke(color Color, width float64) DrawOption {
+	return func(s *drawStyle) {
+		s.strokeColor = &color
+		s.strokeWidth = width
+	}
+}
+
+// WithStrokeCMYK sets the stroke color in CMYK and the width in points.
+// It takes precedence over an RGB stroke color.
+func WithStrokeCMYK(color ColorCMYK, width float64) DrawOption {
+	return func(s *drawStyle) {
+		s.strokeColorCMYK = &color
+		s.strokeWidth = width
+	}
+}
+
+// WithFill sets the fill color.
+// Bézier curves are only filled when also drawn WithClosed.
+func WithFill(color Color) DrawOption {
+	return func(s *drawStyle) {
+		s.fillColor = &color
+	}
+}
+
+// WithFillCMYK sets the fill color in CMYK.
+// It takes precedence over an RGB fill color.
+func WithFillCMYK(color ColorCMYK) DrawOption {
+	return func(s *drawStyle) {
+		s.fillColorCMYK = &color
+	}
+}
+
+// WithGradient fills the shape with a gradient instead of a solid color.
+func WithGradient(gradient *Gradient) DrawOption {
+	return func(s *drawStyle) {
+		s.fillGradient = gradient
+	}
+}
+
+// WithDash strokes with a dash pattern (e.g. 3, 1 for "3 on, 1 off").
+func WithDash(pattern ...float64) DrawOption {
+	return func(s *drawStyle) {
+		s.dashArray = pattern
+	}
+}
+
+// WithDashPhase sets the starting offset into the dash pattern.
+func WithDashPhase(phase float64) DrawOption {
+	return func(s *drawStyle) {
+		s.dashPhase = phase
+	}
+}
+
+// WithOpacity sets the opacity of fill and stroke (0.0 = transparent,
+// 1.0 = opaque).
+func WithOpacity(opacity float64) DrawOption {
+	return func(s *drawStyle) {
+		s.opacity = &opacity
+	}
+}
+
+// WithGraphicsState sets stroke adjustment and overprint.
+func WithGraphicsState(gs GraphicsStateOptions) DrawOption {
+	return func(s *drawStyle) {
+		s.graphicsState = &gs
+	}
+}
+
+// WithClosed closes a Bézier curve path back to its start point, which
+// allows it to be filled.
+func WithClosed() DrawOption {
+	return func(s *drawStyle) {
+		s.closed = true
+	}
+}
+
+// dashed reports whether a dash pattern was set.
+func (s *drawStyle) dashed() bool {
+	return len(s.dashArray) > 0
+}
+
+// strokeOrBlack returns the RGB stroke color, defaulting to black.
+func (s *drawStyle) strokeOrBlack() Color {
+	if s.strokeColor != nil {
+		return *s.strokeColor
+	}
+	return Black
+}
+
+// DrawLineWith draws a line from (x1, y1) to (x2, y2) configured with
+// draw options. The line is black unless WithStroke sets its color.
+//
+// Example:
+//
+//	err := page.DrawLineWith(100, 700, 500, 700, creator.WithStroke(creator.Gray, 0.5), creator.WithDash(3, 1))
+func (p *Page) DrawLineWith(x1, y1, x2, y2 float64, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawLine(x1, y1, x2, y2, &LineOptions{
+		Color:         s.strokeOrBlack(),
+		ColorCMYK:     s.strokeColorCMYK,
+		Width:         s.strokeWidth,
+		Dashed:        s.dashed(),
+		DashArray:     s.dashArray,
+		DashPhase:     s.dashPhase,
+		Opacity:       s.opacity,
+		GraphicsState: s.graphicsState,
+	})
+}
+
+// DrawRectWith draws a rectangle with its lower-left corner at (x, y)
+// configured with draw options. At least a stroke, fill or gradient is
+// required.
+//
+// Example:
+//
+//	err := page.DrawRectWith(100, 600, 200, 100, creator.WithFill(creator.LightGray), creator.WithStroke(creator.Black, 1))
+func (p *Page) DrawRectWith(x, y, width, height float64, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawRect(x, y, width, height, &RectOptions{
+		StrokeColor:     s.strokeColor,
+		StrokeColorCMYK: s.strokeColorCMYK,
+		StrokeWidth:     s.strokeWidth,
+		FillColor:       s.fillColor,
+		FillColorCMYK:   s.fillColorCMYK,
+		FillGradient:    s.fillGradient,
+		Dashed:          s.dashed(),
+		DashArray:       s.dashArray,
+		DashPhase:       s.dashPhase,
+		Opacity:         s.opacity,
+		GraphicsState:   s.graphicsState,
+	})
+}
+
+// DrawCircleWith draws a circle centered at (cx, cy) configured with draw
+// options. At least a stroke, fill or gradient is required.
+//
+// Example:
+//
+//	err := page.DrawCircleWith(300, 400, 50, creator.WithFill(creator.Blue), creator.WithOpacity(0.5))
+func (p *Page) DrawCircleWith(cx, cy, radius float64, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawCircle(cx, cy, radius, &CircleOptions{
+		StrokeColor:     s.strokeColor,
+		StrokeColorCMYK: s.strokeColorCMYK,
+		StrokeWidth:     s.strokeWidth,
+		FillColor:       s.fillColor,
+		FillColorCMYK:   s.fillColorCMYK,
+		FillGradient:    s.fillGradient,
+		Dashed:          s.dashed(),
+		DashArray:       s.dashArray,
+		DashPhase:       s.dashPhase,
+		Opacity:         s.opacity,
+		GraphicsState:   s.graphicsState,
+	})
+}
+
+// DrawEllipseWith draws an ellipse centered at (cx, cy) configured with
+// draw options. At least a stroke, fill or gradient is required.
+//
+// Example:
+//
+//	err := page.DrawEllipseWith(300, 400, 100, 50, creator.WithStroke(creator.Red, 2))
+func (p *Page) DrawEllipseWith(cx, cy, rx, ry float64, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawEllipse(cx, cy, rx, ry, &EllipseOptions{
+		StrokeColor:     s.strokeColor,
+		StrokeColorCMYK: s.strokeColorCMYK,
+		StrokeWidth:     s.strokeWidth,
+		FillColor:       s.fillColor,
+		FillColorCMYK:   s.fillColorCMYK,
+		FillGradient:    s.fillGradient,
+		Dashed:          s.dashed(),
+		DashArray:       s.dashArray,
+		DashPhase:       s.dashPhase,
+		Opacity:         s.opacity,
+		GraphicsState:   s.graphicsState,
+	})
+}
+
+// DrawPolygonWith draws a closed polygon configured with draw options.
+// At least a stroke, fill or gradient is required.
+//
+// Example:
+//
+//	triangle := []creator.Point{{X: 100, Y: 100}, {X: 200, Y: 100}, {X: 150, Y: 180}}
+//	err := page.DrawPolygonWith(triangle, creator.WithFill(creator.Green))
+func (p *Page) DrawPolygonWith(vertices []Point, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawPolygon(vertices, &PolygonOptions{
+		StrokeColor:     s.strokeColor,
+		StrokeColorCMYK: s.strokeColorCMYK,
+		StrokeWidth:     s.strokeWidth,
+		FillColor:       s.fillColor,
+		FillColorCMYK:   s.fillColorCMYK,
+		FillGradient:    s.fillGradient,
+		Dashed:          s.dashed(),
+		DashArray:       s.dashArray,
+		DashPhase:       s.dashPhase,
+		Opacity:         s.opacity,
+		GraphicsState:   s.graphicsState,
+	})
+}
+
+// DrawPolylineWith draws an open path through the vertices configured with
+// draw options. The path is black unless WithStroke sets its color.
+//
+// Example:
+//
+//	err := page.DrawPolylineWith(points, creator.WithStroke(creator.Red, 2))
+func (p *Page) DrawPolylineWith(vertices []Point, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawPolyline(vertices, &PolylineOptions{
+		Color:         s.strokeOrBlack(),
+		ColorCMYK:     s.strokeColorCMYK,
+		Width:         s.strokeWidth,
+		Dashed:        s.dashed(),
+		DashArray:     s.dashArray,
+		DashPhase:     s.dashPhase,
+		Opacity:       s.opacity,
+		GraphicsState: s.graphicsState,
+	})
+}
+
+// DrawBezierCurveWith draws a Bézier curve configured with draw options.
+// The curve is black unless WithStroke sets its color; fills require
+// WithClosed.
+//
+// Example:
+//
+//	err := page.DrawBezierCurveWith(segments, creator.WithClosed(), creator.WithFill(creator.Yellow))
+func (p *Page) DrawBezierCurveWith(segments []BezierSegment, opts ...DrawOption) error {
+	s := newDrawStyle(opts)
+	return p.DrawBezierCurve(segments, &BezierOptions{
+		Color:         s.strokeOrBlack(),
+		ColorCMYK:     s.strokeColorCMYK,
+		Width:         s.strokeWidth,
+		Dashed:        s.dashed(),
+		DashArray:     s.dashArray,
+		DashPhase:     s.dashPhase,
+		Closed:        s.closed,
+		FillColor:     s.fillColor,
+		FillGradient:  s.fillGradient,
+		Opacity:       s.opacity,
+		GraphicsState: s.graphicsState,
+	})
+}
@@ -0,0 +1,90 @@
+package creator
+
+import (
+	"testing"
+)
+
+func TestDrawRectWith(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+
+	err = page.DrawRectWith(100, 600, 200, 100,
+		WithFill(Red),
+		WithOpacity(0.5),
+		WithStroke(Black, 2),
+		WithDash(3, 1),
+	)
+	if err != nil {
+		t.Fatalf("DrawRectWith() error: %v", err)
+	}
+
+	ops := page.GraphicsOperations()
+	if len(ops) != 1 || ops[0].Type != GraphicsOpRect {
+		t.Fatalf("expected one rectangle operation, got %d", len(ops))
+	}
+	opts := ops[0].RectOpts
+	if opts.FillColor == nil || *opts.FillColor != Red {
+		t.Errorf("FillColor = %v, want Red", opts.FillColor)
+	}
+	if opts.StrokeColor == nil || *opts.StrokeColor != Black || opts.StrokeWidth != 2 {
+		t.Errorf("stroke = %v/%v, want Black/2", opts.StrokeColor, opts.StrokeWidth)
+	}
+	if opts.Opacity == nil || *opts.Opacity != 0.5 {
+		t.Errorf("Opacity = %v, want 0.5", opts.Opacity)
+	}
+	if !opts.Dashed || len(opts.DashArray) != 2 {
+		t.Errorf("dash = %v/%v, want dashed [3 1]", opts.Dashed, opts.DashArray)
+	}
+}
+
+func TestDrawWith_Validation(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+
+	// Same validation as the struct-based methods.
+	if err := page.DrawRectWith(0, 0, 10, 10); err == nil {
+		t.Error("expected error for rectangle without stroke or fill")
+	}
+	if err := page.DrawCircleWith(50, 50, 10, WithFill(Color{R: 2})); err == nil {
+		t.Error("expected error for invalid fill color")
+	}
+	segs := []BezierSegment{{Start: Point{0, 0}, C1: Point{10, 10}, C2: Point{20, 10}, End: Point{30, 0}}}
+	if err := page.DrawBezierCurveWith(segs, WithFill(Red)); err == nil {
+		t.Error("expected error for filled open curve")
+	}
+	if err := page.DrawBezierCurveWith(segs, WithClosed(), WithFill(Red)); err != nil {
+		t.Errorf("closed filled curve: %v", err)
+	}
+}
+
+func TestDrawLineWith_DefaultsToBlack(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+
+	if err := page.DrawLineWith(0, 0, 100, 100); err != nil {
+		t.Fatalf("DrawLineWith() error: %v", err)
+	}
+	if err := page.DrawPolylineWith([]Point{{0, 0}, {50, 50}, {100, 0}}, WithStroke(Blue, 3)); err != nil {
+		t.Fatalf("DrawPolylineWith() error: %v", err)
+	}
+
+	ops := page.GraphicsOperations()
+	if len(ops) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(ops))
+	}
+	if ops[0].LineOpts.Color != Black {
+		t.Errorf("line color = %v, want Black", ops[0].LineOpts.Color)
+	}
+	if ops[1].PolylineOpts.Color != Blue || ops[1].PolylineOpts.Width != 3 {
+		t.Errorf("polyline = %v/%v, want Blue/3", ops[1].PolylineOpts.Color, ops[1].PolylineOpts.Width)
+	}
+}
@@ -31,11 +31,25 @@ type EllipseOptions struct {
 	// Mutually exclusive with FillColor and FillColorCMYK.
 	FillGradient *Gradient
 
+	// Dashed enables dashed border rendering.
+	Dashed bool
+
+	// DashArray defines the dash pattern for the border.
+	// Only used when Dashed is true.
+	DashArray []float64
+
+	// DashPhase is the starting offset into the dash pattern.
+	// Only used when Dashed is true.
+	DashPhase float64
+
 	// Opacity is the ellipse opacity (0.0 = transparent, 1.0 = opaque).
 	// Optional. If set, applies transparency via ExtGState.
 	// Affects both fill and stroke.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
 }
 
 // DrawEllipse draws an ellipse at center (cx, cy) with horizontal radius rx and vertical radius ry.
@@ -111,6 +125,13 @@ func validateEllipseOptions(opts *EllipseOptions) error {
 		return errors.New("stroke width must be non-negative")
 	}
 
+	// Validate dash pattern
+	if opts.Dashed {
+		if err := validateDashArray(opts.DashArray); err != nil {
+			return err
+		}
+	}
+
 	// At least one of stroke or fill must be set
 	if opts.StrokeColor == nil && opts.FillColor == nil && opts.FillGradient == nil {
 		return errors.New("ellipse must have at least stroke, fill color, or gradient")
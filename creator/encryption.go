@@ -1,24 +1,27 @@
 package creator
 
 import (
+	"os"
+
 	"github.com/coregx/gxpdf/internal/security"
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 // EncryptionAlgorithm specifies the encryption algorithm to use.
-type EncryptionAlgorithm int
+type EncryptionAlgorithm = security.Algorithm
 
 const (
 	// EncryptionRC4_40 uses RC4 with 40-bit keys (PDF 1.1+, legacy).
-	EncryptionRC4_40 EncryptionAlgorithm = iota
+	EncryptionRC4_40 = security.AlgorithmRC4_40
 
 	// EncryptionRC4_128 uses RC4 with 128-bit keys (PDF 1.4+, legacy).
-	EncryptionRC4_128
+	EncryptionRC4_128 = security.AlgorithmRC4_128
 
 	// EncryptionAES128 uses AES-128 encryption (PDF 1.5+, recommended).
-	EncryptionAES128
+	EncryptionAES128 = security.AlgorithmAES128
 
 	// EncryptionAES256 uses AES-256 encryption (PDF 1.7+, most secure).
-	EncryptionAES256
+	EncryptionAES256 = security.AlgorithmAES256
 )
 
 // EncryptionOptions holds the encryption settings for PDF creation.
@@ -116,7 +119,9 @@ const (
 //	})
 //	c.WriteToFile("protected.pdf")
 //
-// Note: Encryption is applied during WriteToFile.
+// Encryption is applied by every write method except WriteStream, which
+// returns ErrStreamingUnsupported. It cannot be combined with EmbedChecksum
+// or SetUsageRights.
 func (c *Creator) SetEncryption(opts EncryptionOptions) error {
 	// If Algorithm is not set but KeyLength is, map KeyLength to Algorithm for backward compatibility.
 	if opts.Algorithm == 0 && opts.KeyLength > 0 {
@@ -163,5 +168,43 @@ func validateAlgorithm(alg EncryptionAlgorithm) error {
 	}
 }
 
+// encryptFile rewrites the PDF file at path encrypted with opts, whose
+// Algorithm is used as given.
+func encryptFile(path string, opts *EncryptionOptions) error {
+	enc, err := writer.NewEncryption(security.StandardEncryptionConfig{
+		UserPassword:  opts.UserPassword,
+		OwnerPassword: opts.OwnerPassword,
+		Permissions:   writer.PDFPermissions(opts.Permissions),
+		Algorithm:     opts.Algorithm,
+	})
+	if err != nil {
+		return err
+	}
+	return writer.EncryptFile(path, enc)
+}
+
+// encryptBytes returns the PDF data encrypted with opts. The reader works
+// on files, so the data goes through a temporary file.
+func encryptBytes(data []byte, opts *EncryptionOptions) ([]byte, error) {
+	tmp, err := os.CreateTemp("", "gxpdf-*.pdf")
+	if err != nil {
+		return nil, err
+	}
+	path := tmp.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := encryptFile(path, opts); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
 // encryptionOpts stores the encryption options.
 // This is added to the Creator struct (see creator.go).
@@ -627,3 +627,18 @@ func ExampleCreator_SetEncryption_aes256() {
 
 	// Document will be encrypted when written to file.
 }
+
+func TestCreator_SetEncryption_WithChecksum(t *testing.T) {
+	c := New()
+	if _, err := c.NewPage(); err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	c.EmbedChecksum()
+	if err := c.SetEncryption(EncryptionOptions{OwnerPassword: "owner"}); err != nil {
+		t.Fatalf("SetEncryption() error = %v", err)
+	}
+
+	if _, err := c.Bytes(); err == nil {
+		t.Error("Bytes() with checksum and encryption should fail")
+	}
+}
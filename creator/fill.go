@@ -81,6 +81,14 @@ func (r FillRule) String() string {
 	}
 }
 
+// validateFillRule validates a fill rule option.
+func validateFillRule(rule FillRule) error {
+	if rule != FillRuleNonZero && rule != FillRuleEvenOdd {
+		return fmt.Errorf("invalid fill rule: %d", int(rule))
+	}
+	return nil
+}
+
 // NewFill creates a new Fill with the specified paint.
 //
 // Default values:
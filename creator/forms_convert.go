@@ -14,12 +14,26 @@ import (
 //
 // Supported field types:
 //   - *forms.TextField -> domain.FormField with type "Tx"
+//   - *forms.Checkbox -> domain.FormField with type "Btn"
+//   - *forms.RadioGroup -> one "Btn" widget per option, sharing a parent field
 //
 // Returns ErrUnsupportedFieldType if the field type is not recognized.
-func convertFieldToDomain(field interface{}) (*document.FormField, error) {
+func convertFieldToDomain(field interface{}) ([]*document.FormField, error) {
 	switch f := field.(type) {
 	case *forms.TextField:
-		return convertTextFieldToDomain(f)
+		domainField, err := convertTextFieldToDomain(f)
+		if err != nil {
+			return nil, err
+		}
+		return []*document.FormField{domainField}, nil
+	case *forms.Checkbox:
+		domainField, err := convertCheckboxToDomain(f)
+		if err != nil {
+			return nil, err
+		}
+		return []*document.FormField{domainField}, nil
+	case *forms.RadioGroup:
+		return convertRadioGroupToDomain(f)
 	default:
 		return nil, fmt.Errorf("%w: %T", ErrUnsupportedFieldType, field)
 	}
@@ -64,6 +78,75 @@ func convertTextFieldToDomain(tf *forms.TextField) (*document.FormField, error)
 	return field, nil
 }
 
+// convertCheckboxToDomain converts a creator Checkbox to a domain FormField.
+//
+// The on-state export value is "Yes"; the writer generates the /Yes and
+// /Off appearance streams.
+func convertCheckboxToDomain(cb *forms.Checkbox) (*document.FormField, error) {
+	if err := cb.Validate(); err != nil {
+		return nil, fmt.Errorf("checkbox validation failed: %w", err)
+	}
+
+	field := document.NewFormField("Btn", cb.Name(), cb.Rect())
+	field.SetExportValue("Yes")
+	field.SetValue(cb.Value().(string))
+	field.SetDefaultValue(cb.DefaultValue().(string))
+	field.SetFlags(cb.Flags())
+	field.SetAppearance("")
+
+	if label := cb.Label(); label != "" {
+		field.SetAlternateText(label)
+	}
+	if bc := cb.BorderColor(); bc != nil {
+		field.SetBorderColor(bc[0], bc[1], bc[2])
+	}
+	if fc := cb.FillColor(); fc != nil {
+		field.SetFillColor(fc[0], fc[1], fc[2])
+	}
+
+	return field, nil
+}
+
+// convertRadioGroupToDomain converts a creator RadioGroup to domain widgets.
+//
+// A radio group is one parent field (name, flags, selected value) with one
+// kid widget per option. Each widget's export value is its option value, so
+// the viewer turns on exactly the widget whose value matches /V.
+func convertRadioGroupToDomain(rg *forms.RadioGroup) ([]*document.FormField, error) {
+	if err := rg.Validate(); err != nil {
+		return nil, fmt.Errorf("radio group validation failed: %w", err)
+	}
+
+	parent := document.NewFormField("Btn", rg.Name(), rg.Rect())
+	parent.SetFlags(rg.Flags())
+	parent.SetValue(rg.Selected())
+	if dv, ok := rg.DefaultValue().(string); ok {
+		parent.SetDefaultValue(dv)
+	}
+
+	widgets := make([]*document.FormField, 0, len(rg.Options()))
+	for _, opt := range rg.Options() {
+		widget := document.NewFormField("Btn", rg.Name(), opt.Rect())
+		widget.SetParent(parent)
+		widget.SetExportValue(opt.Value())
+		widget.SetAppearance("")
+
+		if opt.Label() != "" {
+			widget.SetAlternateText(opt.Label())
+		}
+		if bc := rg.BorderColor(); bc != nil {
+			widget.SetBorderColor(bc[0], bc[1], bc[2])
+		}
+		if fc := rg.FillColor(); fc != nil {
+			widget.SetFillColor(fc[0], fc[1], fc[2])
+		}
+
+		widgets = append(widgets, widget)
+	}
+
+	return widgets, nil
+}
+
 // buildAppearanceString builds the PDF default appearance string (/DA).
 //
 // The default appearance string specifies the font and color for text fields.
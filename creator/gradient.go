@@ -191,7 +191,8 @@ func (g *Gradient) sortColorStops() {
 //   - At least 2 color stops are defined
 //   - Color stops are in range [0, 1]
 //   - For linear gradients: start and end points are different
-//   - For radial gradients: radii are non-negative
+//   - For radial gradients: radii are non-negative and the starting
+//     (inner) radius does not exceed the ending (outer) radius
 //
 // Returns an error if validation fails.
 func (g *Gradient) Validate() error {
@@ -246,5 +247,10 @@ func (g *Gradient) validateRadial() error {
 		return errors.New("radial gradient: at least one radius must be positive")
 	}
 
+	// The starting circle is the inner one
+	if g.R0 > g.R1 {
+		return fmt.Errorf("radial gradient: starting radius %f must not exceed ending radius %f", g.R0, g.R1)
+	}
+
 	return nil
 }
@@ -1,6 +1,8 @@
 package creator
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -217,6 +219,25 @@ func TestGradient_Validate_Radial(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Inner radius larger than outer",
+			setup: func() *Gradient {
+				g := NewRadialGradient(150, 550, 60, 150, 550, 50)
+				g.AddColorStop(0, White)
+				g.AddColorStop(1, Blue)
+				return g
+			},
+			wantErr: true,
+		},
+		{
+			name: "Single color stop",
+			setup: func() *Gradient {
+				g := NewRadialGradient(150, 550, 0, 150, 550, 50)
+				g.AddColorStop(0, White)
+				return g
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -288,3 +309,37 @@ func TestPolygonOptions_Gradient(t *testing.T) {
 		t.Error("FillGradient should be set")
 	}
 }
+
+func TestDrawCircle_RadialGradient(t *testing.T) {
+	grad := NewRadialGradient(200, 400, 0, 200, 400, 50)
+	grad.AddColorStop(0, White)
+	grad.AddColorStop(1, Blue)
+
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawCircle(200, 400, 50, &CircleOptions{FillGradient: grad, StrokeColor: &Black}); err != nil {
+		t.Fatalf("DrawCircle() error = %v", err)
+	}
+
+	// The shading is painted clipped to the circle, then the circle is stroked.
+	content := graphicsContent(t, page)
+	for _, want := range []string{"h\nW\nn\n/Sh1 sh\nQ\n", "h\nS\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in content:\n%s", want, content)
+		}
+	}
+
+	pdf, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	ref := regexp.MustCompile(`/Shading << /Sh1 (\d+) 0 R >>`).FindSubmatch(pdf)
+	if ref == nil {
+		t.Fatal("page resources should reference the shading")
+	}
+	obj := regexp.MustCompile(`\n` + string(ref[1]) + ` 0 obj\n<< /ShadingType 3 /ColorSpace /DeviceRGB /Coords \[200 400 0 200 400 50\]` +
+		` /Function << /FunctionType 2 /Domain \[0 1\] /C0 \[1 1 1\] /C1 \[0 0 1\] /N 1 >> /Extend \[true true\] >>`)
+	if !obj.Match(pdf) {
+		t.Error("expected a ShadingType 3 object from the center to the edge of the circle")
+	}
+}
@@ -33,8 +33,9 @@ const (
 
 	// Reserved 9-19 for future graphics ops.
 
-	// GraphicsOpBeginClip begins a rectangular clipping region.
-	// All subsequent drawing is clipped to the rectangle (X, Y, Width, Height).
+	// GraphicsOpBeginClip begins a clipping region.
+	// All subsequent drawing is clipped to the polygon in Vertices, the closed
+	// curve in BezierSegs, or otherwise the rectangle (X, Y, Width, Height).
 	// Must be followed by GraphicsOpEndClip to restore the previous clipping state.
 	GraphicsOpBeginClip GraphicsOpType = 20
 
@@ -44,8 +45,50 @@ const (
 	// GraphicsOpTextBlock renders text inline with graphics operations.
 	// Used for clipped text where ordering matters.
 	GraphicsOpTextBlock GraphicsOpType = 22
+
+	// GraphicsOpSaveState saves the graphics state (q), starting a group of
+	// transformed operations.
+	GraphicsOpSaveState GraphicsOpType = 23
+
+	// GraphicsOpRestoreState restores the graphics state (Q) saved by
+	// GraphicsOpSaveState, undoing the transforms made since.
+	GraphicsOpRestoreState GraphicsOpType = 24
+
+	// GraphicsOpTransform concatenates Matrix to the current transformation
+	// matrix (cm).
+	GraphicsOpTransform GraphicsOpType = 25
+
+	// GraphicsOpTemplate draws Template, placed by Matrix, as a form XObject.
+	GraphicsOpTemplate GraphicsOpType = 26
+
+	// GraphicsOpPath draws a general path of lines and curves (see DrawPath).
+	GraphicsOpPath GraphicsOpType = 27
 )
 
+// GraphicsStateOptions sets graphics state flags for a shape draw.
+//
+// The flags are written through an ExtGState resource that is shared by
+// all shapes on the page with the same settings.
+//
+// Example:
+//
+//	opts := &creator.RectOptions{
+//	    FillColor:     &creator.Red,
+//	    GraphicsState: &creator.GraphicsStateOptions{Overprint: true},
+//	}
+//
+// Reference: PDF 1.7 Specification, Section 8.4.5 (Graphics State Parameter Dictionaries).
+type GraphicsStateOptions struct {
+	// StrokeAdjust enables automatic stroke adjustment (/SA), which snaps
+	// thin strokes to the device pixel grid for consistent hairlines.
+	StrokeAdjust bool
+
+	// Overprint enables overprinting for fills and strokes (/OP, /op and
+	// /OPM 1). Colorants the shape leaves at zero do not knock out the
+	// layers beneath, as needed for spot colors in print workflows.
+	Overprint bool
+}
+
 // LineOptions configures line drawing.
 type LineOptions struct {
 	// Color is the line color (RGB, 0.0 to 1.0 range).
@@ -70,10 +113,25 @@ type LineOptions struct {
 	// Only used when Dashed is true.
 	DashPhase float64
 
+	// LineCap is the style of the line ends (default: LineCapButt).
+	LineCap LineCap
+
+	// LineJoin is the style of the corners between segments
+	// (default: LineJoinMiter).
+	LineJoin LineJoin
+
+	// MiterLimit limits the length of miter joins as a ratio of the
+	// line width; longer miters are beveled. Only used with LineJoinMiter.
+	// Must be at least 1.0 (0 = PDF default of 10).
+	MiterLimit float64
+
 	// Opacity is the line opacity (0.0 = transparent, 1.0 = opaque).
 	// Optional. If set, applies transparency via ExtGState.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
 }
 
 // RectOptions configures rectangle drawing.
@@ -114,11 +172,34 @@ type RectOptions struct {
 	// Only used when Dashed is true.
 	DashPhase float64
 
+	// CornerRadius rounds all four corners with quarter-circle arcs of
+	// this radius in points (default: 0, sharp corners). Radii larger
+	// than half the smaller side are clamped to it.
+	CornerRadius float64
+
+	// CornerRadii sets per-corner radii in points, in the order
+	// lower-left, lower-right, upper-right, upper-left. If any is
+	// non-zero, CornerRadius is ignored.
+	CornerRadii [4]float64
+
 	// Opacity is the rectangle opacity (0.0 = transparent, 1.0 = opaque).
 	// Optional. If set, applies transparency via ExtGState.
 	// Affects both fill and stroke.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
+}
+
+// cornerRadii returns the corner radii (lower-left, lower-right,
+// upper-right, upper-left).
+func (o *RectOptions) cornerRadii() [4]float64 {
+	if o.CornerRadii != [4]float64{} {
+		return o.CornerRadii
+	}
+	r := o.CornerRadius
+	return [4]float64{r, r, r, r}
 }
 
 // CircleOptions configures circle drawing.
@@ -148,11 +229,25 @@ type CircleOptions struct {
 	// Mutually exclusive with FillColor and FillColorCMYK.
 	FillGradient *Gradient
 
+	// Dashed enables dashed border rendering.
+	Dashed bool
+
+	// DashArray defines the dash pattern for the border.
+	// Only used when Dashed is true.
+	DashArray []float64
+
+	// DashPhase is the starting offset into the dash pattern.
+	// Only used when Dashed is true.
+	DashPhase float64
+
 	// Opacity is the circle opacity (0.0 = transparent, 1.0 = opaque).
 	// Optional. If set, applies transparency via ExtGState.
 	// Affects both fill and stroke.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
 }
 
 // GraphicsOperation represents a graphics drawing operation.
@@ -166,7 +261,10 @@ type CircleOptions struct {
 // - GraphicsOpPolygon: Vertices, PolygonOpts.
 // - GraphicsOpPolyline: Vertices, PolylineOpts.
 // - GraphicsOpEllipse: X, Y, RX, RY, EllipseOpts.
-// - GraphicsOpBezier: BezierSegs, BezierOpts.
+// - GraphicsOpBezier: BezierSegs, BezierOpts (or ArcOpts for arcs).
+// - GraphicsOpTransform: Matrix.
+// - GraphicsOpTemplate: Template, Matrix.
+// - GraphicsOpPath: Path, PathOpts.
 type GraphicsOperation struct {
 	// Type is the graphics operation type.
 	Type GraphicsOpType
@@ -198,12 +296,22 @@ type GraphicsOperation struct {
 	// RY is the vertical radius (only for ellipse).
 	RY float64
 
-	// Vertices is the array of points (only for polygon/polyline).
+	// Vertices is the array of points (only for polygon/polyline/clip).
 	Vertices []Point
 
-	// BezierSegs is the array of Bézier segments (only for bezier).
+	// BezierSegs is the array of Bézier segments (only for bezier/clip).
 	BezierSegs []BezierSegment
 
+	// Matrix is the transformation [a b c d e f] (only for transform and
+	// template placement).
+	Matrix [6]float64
+
+	// Template is the template to draw (only for template).
+	Template *Template
+
+	// Path is the path to draw (only for path).
+	Path *Path
+
 	// LineOpts are line options (only for line).
 	LineOpts *LineOptions
 
@@ -225,9 +333,22 @@ type GraphicsOperation struct {
 	// BezierOpts are Bézier curve options (only for bezier).
 	BezierOpts *BezierOptions
 
+	// ArcOpts are arc options (only for bezier, for arcs drawn with DrawArc).
+	ArcOpts *ArcOptions
+
+	// PathOpts are path options (only for path).
+	PathOpts *PathOptions
+
 	// Image is the image to draw (only for image).
 	Image *Image
 
+	// AltText is the image's alternate text (only for image, see DrawImageOptions).
+	AltText string
+
+	// MaskColor draws a 1-bit image as a stencil mask in this color (only
+	// for image, see DrawImageMask).
+	MaskColor *Color
+
 	// WatermarkOp is the watermark operation (only for watermark).
 	WatermarkOp *TextWatermark
 
@@ -1,7 +1,10 @@
 package creator
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 // TestDrawLine_Valid tests valid DrawLine cases.
@@ -95,6 +98,8 @@ func TestDrawRect_Invalid(t *testing.T) {
 		{"no stroke or fill", 100, 50, 200, 100, &RectOptions{}},
 		{"negative dimensions", 100, 50, -200, 100, &RectOptions{StrokeColor: &Black}},
 		{"invalid stroke color", 100, 50, 200, 100, &RectOptions{StrokeColor: &Color{R: 2.0, G: 0, B: 0}}},
+		{"negative corner radius", 100, 50, 200, 100, &RectOptions{StrokeColor: &Black, CornerRadius: -1}},
+		{"negative per-corner radius", 100, 50, 200, 100, &RectOptions{StrokeColor: &Black, CornerRadii: [4]float64{0, -2, 0, 0}}},
 	}
 
 	for _, tt := range tests {
@@ -108,6 +113,127 @@ func TestDrawRect_Invalid(t *testing.T) {
 	}
 }
 
+// rectContent renders a single DrawRect call and returns the content stream.
+func rectContent(t *testing.T, x, y, w, h float64, opts *RectOptions) string {
+	t.Helper()
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawRect(x, y, w, h, opts); err != nil {
+		t.Fatalf("DrawRect() error = %v", err)
+	}
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	return string(content)
+}
+
+// TestDrawRect_ZeroCornerRadius tests that a zero radius keeps the sharp rectangle.
+func TestDrawRect_ZeroCornerRadius(t *testing.T) {
+	sharp := rectContent(t, 100, 600, 200, 100, &RectOptions{FillColor: &LightGray})
+	zero := rectContent(t, 100, 600, 200, 100, &RectOptions{FillColor: &LightGray, CornerRadius: 0})
+
+	if sharp != zero {
+		t.Errorf("zero radius changed the output:\n%s\nwant:\n%s", zero, sharp)
+	}
+	if !strings.Contains(zero, " re\n") {
+		t.Errorf("expected re operator, got:\n%s", zero)
+	}
+}
+
+// TestDrawRect_RoundedCorners tests rounded rectangle paths.
+func TestDrawRect_RoundedCorners(t *testing.T) {
+	content := rectContent(t, 100, 600, 200, 100, &RectOptions{FillColor: &LightGray, CornerRadius: 10})
+
+	if strings.Contains(content, " re\n") {
+		t.Errorf("rounded rectangle should not use re, got:\n%s", content)
+	}
+	if n := strings.Count(content, " c\n"); n != 4 {
+		t.Errorf("expected 4 corner curves, got %d:\n%s", n, content)
+	}
+	for _, want := range []string{"110 600 m\n", "290 600 l\n", "h\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in content:\n%s", want, content)
+		}
+	}
+}
+
+// TestDrawRect_CornerRadiusClamped tests that radii beyond half the side are clamped.
+func TestDrawRect_CornerRadiusClamped(t *testing.T) {
+	clamped := rectContent(t, 100, 600, 200, 100, &RectOptions{FillColor: &LightGray, CornerRadius: 500})
+	half := rectContent(t, 100, 600, 200, 100, &RectOptions{FillColor: &LightGray, CornerRadius: 50})
+
+	if clamped != half {
+		t.Errorf("radius 500 should be clamped to 50, got:\n%s\nwant:\n%s", clamped, half)
+	}
+}
+
+// TestDrawRect_PerCornerRadii tests that CornerRadii rounds individual corners.
+func TestDrawRect_PerCornerRadii(t *testing.T) {
+	content := rectContent(t, 100, 600, 200, 100, &RectOptions{
+		FillColor:    &LightGray,
+		CornerRadius: 30, // Ignored: CornerRadii is set
+		CornerRadii:  [4]float64{0, 0, 20, 20},
+	})
+
+	if n := strings.Count(content, " c\n"); n != 2 {
+		t.Errorf("expected 2 corner curves, got %d:\n%s", n, content)
+	}
+	if !strings.Contains(content, "100 600 m\n") {
+		t.Errorf("lower-left corner should be sharp:\n%s", content)
+	}
+}
+
+// TestDrawShapes_Dashed tests dashed rectangle, circle and ellipse borders.
+func TestDrawShapes_Dashed(t *testing.T) {
+	draw := map[string]func(p *Page, dashed bool, dash []float64) error{
+		"rect": func(p *Page, dashed bool, dash []float64) error {
+			return p.DrawRect(100, 600, 200, 100, &RectOptions{StrokeColor: &Black, Dashed: dashed, DashArray: dash, DashPhase: 2})
+		},
+		"circle": func(p *Page, dashed bool, dash []float64) error {
+			return p.DrawCircle(300, 400, 50, &CircleOptions{StrokeColor: &Black, Dashed: dashed, DashArray: dash, DashPhase: 2})
+		},
+		"ellipse": func(p *Page, dashed bool, dash []float64) error {
+			return p.DrawEllipse(300, 400, 80, 40, &EllipseOptions{StrokeColor: &Black, Dashed: dashed, DashArray: dash, DashPhase: 2})
+		},
+	}
+
+	for name, fn := range draw {
+		t.Run(name, func(t *testing.T) {
+			content := func(dashed bool, dash []float64) string {
+				c := New()
+				page, _ := c.NewPage()
+				if err := fn(page, dashed, dash); err != nil {
+					t.Fatalf("draw error = %v", err)
+				}
+				out, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+				if err != nil {
+					t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+				}
+				return string(out)
+			}
+
+			if got := content(true, []float64{6, 3}); !strings.Contains(got, "[6 3] 2 d\n") {
+				t.Errorf("expected dash pattern before stroking, got:\n%s", got)
+			}
+			for _, solid := range []string{content(true, nil), content(true, []float64{}), content(false, []float64{6, 3})} {
+				if strings.Contains(solid, " d\n") {
+					t.Errorf("expected solid border, got:\n%s", solid)
+				}
+			}
+
+			c := New()
+			page, _ := c.NewPage()
+			if err := fn(page, true, []float64{3, -1}); err == nil {
+				t.Error("expected error for negative dash entry")
+			}
+			if err := fn(page, true, []float64{0, 0}); err == nil {
+				t.Error("expected error for all-zero dash array")
+			}
+		})
+	}
+}
+
 // TestDrawRectFilled tests the DrawRectFilled convenience method.
 func TestDrawRectFilled(t *testing.T) {
 	c := New()
@@ -216,3 +342,141 @@ func TestMixedTextAndGraphics(t *testing.T) {
 		t.Errorf("Expected 1 text operation, got %d", len(page.TextOperations()))
 	}
 }
+
+// TestGraphicsStateOptions tests that stroke adjustment and overprint flags
+// produce a shared ExtGState object in the written PDF.
+func TestGraphicsStateOptions(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	overprint := &GraphicsStateOptions{Overprint: true}
+	if err := page.DrawRect(100, 600, 100, 100, &RectOptions{FillColor: &Red, GraphicsState: overprint}); err != nil {
+		t.Fatalf("DrawRect failed: %v", err)
+	}
+	if err := page.DrawCircle(300, 300, 50, &CircleOptions{FillColor: &Blue, GraphicsState: overprint}); err != nil {
+		t.Fatalf("DrawCircle failed: %v", err)
+	}
+	_ = page.DrawLine(100, 500, 500, 500, &LineOptions{
+		Color:         Black,
+		Width:         0.1,
+		GraphicsState: &GraphicsStateOptions{StrokeAdjust: true},
+	})
+
+	pdfBytes, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() failed: %v", err)
+	}
+	pdf := string(pdfBytes)
+
+	if n := strings.Count(pdf, "/Type /ExtGState"); n != 2 {
+		t.Errorf("expected 2 ExtGState objects (overprint shared), got %d", n)
+	}
+	if !strings.Contains(pdf, "/OP true /op true /OPM 1") {
+		t.Error("expected overprint entries in ExtGState")
+	}
+	if !strings.Contains(pdf, "/SA true") {
+		t.Error("expected stroke adjustment entry in ExtGState")
+	}
+	if strings.Contains(pdf, "/GS1 0 0 R") {
+		t.Error("ExtGState resource must reference a real object")
+	}
+}
+
+// TestBeginClip_Shapes tests that each clip shape wraps the clipped drawing in q ... W n ... Q.
+func TestBeginClip_Shapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		begin func(p *Page) error
+		path  string
+	}{
+		{"rect", func(p *Page) error { return p.BeginClipRect(100, 500, 200, 100) }, "100 500 200 100 re\n"},
+		{"circle", func(p *Page) error { return p.BeginClipCircle(200, 550, 50) }, "250 550 m\n"},
+		{"ellipse", func(p *Page) error { return p.BeginClipEllipse(200, 550, 80, 40) }, "280 550 m\n"},
+		{"polygon", func(p *Page) error {
+			return p.BeginClipPolygon([]Point{{X: 100, Y: 500}, {X: 300, Y: 500}, {X: 200, Y: 600}})
+		}, "100 500 m\n300 500 l\n200 600 l\nh\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, _ := c.NewPage()
+			if err := tt.begin(page); err != nil {
+				t.Fatalf("begin clip error = %v", err)
+			}
+			if err := page.DrawRect(0, 0, 612, 792, &RectOptions{FillColor: &Red}); err != nil {
+				t.Fatalf("DrawRect() error = %v", err)
+			}
+			if err := page.EndClip(); err != nil {
+				t.Fatalf("EndClip() error = %v", err)
+			}
+
+			content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+			if err != nil {
+				t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+			}
+			got := string(content)
+
+			want := "q\n" + tt.path
+			begin := strings.Index(got, want)
+			clip := strings.Index(got, "W\nn\n")
+			fill := strings.Index(got, "0 0 612 792 re\n")
+			end := strings.LastIndex(got, "Q\n")
+			if begin != 0 || clip < begin || fill < clip || end < fill {
+				t.Errorf("expected q %q W n ... Q around the clipped rectangle, got:\n%s", tt.path, got)
+			}
+		})
+	}
+}
+
+// TestBeginClip_Nested tests that nested clips save and restore state in order.
+func TestBeginClip_Nested(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.BeginClipRect(100, 100, 400, 400); err != nil {
+		t.Fatalf("BeginClipRect() error = %v", err)
+	}
+	if err := page.BeginClipCircle(300, 300, 100); err != nil {
+		t.Fatalf("BeginClipCircle() error = %v", err)
+	}
+	if err := page.DrawRect(0, 0, 612, 792, &RectOptions{FillColor: &Blue}); err != nil {
+		t.Fatalf("DrawRect() error = %v", err)
+	}
+	_ = page.EndClip()
+	_ = page.EndClip()
+
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	got := string(content)
+
+	if n := strings.Count(got, "W\nn\n"); n != 2 {
+		t.Errorf("expected 2 clips, got %d:\n%s", n, got)
+	}
+	if !strings.HasSuffix(got, "Q\nQ\n") {
+		t.Errorf("expected both clip states restored at the end, got:\n%s", got)
+	}
+	if strings.Index(got, "0 0 612 792 re\n") < strings.LastIndex(got, "W\nn\n") {
+		t.Errorf("drawing must follow the inner clip:\n%s", got)
+	}
+}
+
+// TestBeginClip_Invalid tests clip shape validation.
+func TestBeginClip_Invalid(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	if err := page.BeginClipCircle(100, 100, 0); err == nil {
+		t.Error("expected error for zero radius")
+	}
+	if err := page.BeginClipEllipse(100, 100, 10, -1); err == nil {
+		t.Error("expected error for negative radius")
+	}
+	if err := page.BeginClipPolygon([]Point{{X: 0, Y: 0}, {X: 10, Y: 10}}); err == nil {
+		t.Error("expected error for polygon with 2 vertices")
+	}
+	if n := len(page.GraphicsOperations()); n != 0 {
+		t.Errorf("invalid clips must not add operations, got %d", n)
+	}
+}
@@ -93,6 +93,14 @@ type HeaderFunc func(args HeaderFunctionArgs)
 //	}
 type FooterFunc func(args FooterFunctionArgs)
 
+// PageDecorator draws repeated content, such as a header, footer or logo,
+// directly on a page. pageNum is 1-based and pageCount is the number of
+// pages in the document.
+//
+// Unlike HeaderFunc and FooterFunc, which lay out paragraphs in a Block,
+// a PageDecorator can use any Page drawing method at any position.
+type PageDecorator func(page *Page, pageNum, pageCount int)
+
 // Default header and footer heights in points.
 const (
 	// DefaultHeaderHeight is the default height for headers (50 points).
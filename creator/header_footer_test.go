@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/coregx/gxpdf/internal/writer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -336,6 +337,28 @@ func TestCreator_HeaderFooter_SkipFirst(t *testing.T) {
 	assert.Equal(t, []int{2, 3}, footerPages)
 }
 
+func TestCreator_HeaderFooter_RunOncePerWrite(t *testing.T) {
+	c := New()
+	c.EmbedChecksum() // Buffered write path
+	var headerPages, footerPages []int
+	c.SetHeaderFunc(func(args HeaderFunctionArgs) {
+		headerPages = append(headerPages, args.PageNum)
+	})
+	c.SetFooter(func(_ *Page, pageNum, _ int) {
+		footerPages = append(footerPages, pageNum)
+	})
+	for i := 0; i < 2; i++ {
+		_, err := c.NewPage()
+		require.NoError(t, err)
+	}
+
+	// Validation and output share one run of the decorators.
+	_, err := c.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, headerPages)
+	assert.Equal(t, []int{1, 2}, footerPages)
+}
+
 func TestCreator_HeaderFooter_TotalPages(t *testing.T) {
 	c := New()
 
@@ -358,6 +381,55 @@ func TestCreator_HeaderFooter_TotalPages(t *testing.T) {
 	assert.Equal(t, 5, capturedTotalPages)
 }
 
+func TestCreator_SetFooter_PageNumbers(t *testing.T) {
+	c := New()
+	c.SetFooter(func(page *Page, pageNum, pageCount int) {
+		text := fmt.Sprintf("Page %d of %d", pageNum, pageCount)
+		require.NoError(t, page.AddText(text, 280, 30, Helvetica, 9))
+	})
+
+	for i := 0; i < 3; i++ {
+		page, err := c.NewPage()
+		require.NoError(t, err)
+		require.NoError(t, page.AddText(fmt.Sprintf("Body %d", i+1), 72, 700, Helvetica, 12))
+	}
+
+	textContents, _ := c.collectAllPageContents()
+	require.Len(t, textContents, 3)
+	for i := 0; i < 3; i++ {
+		content, _, err := writer.GenerateContentStream(textContents[i])
+		require.NoError(t, err)
+		assert.Contains(t, string(content), fmt.Sprintf("(Page %d of 3) Tj", i+1))
+		assert.Contains(t, string(content), fmt.Sprintf("(Body %d) Tj", i+1))
+	}
+
+	// The footer is drawn at write time and does not change the pages.
+	for _, page := range c.pages {
+		assert.Len(t, page.TextOperations(), 1)
+	}
+}
+
+func TestCreator_SetHeader_Graphics(t *testing.T) {
+	c := New()
+	var pages []int
+	c.SetHeader(func(page *Page, pageNum, pageCount int) {
+		pages = append(pages, pageNum)
+		rule := &LineOptions{Color: Gray, Width: 0.5}
+		require.NoError(t, page.DrawLine(50, page.Height()-65, page.Width()-50, page.Height()-65, rule))
+	})
+	c.SetSkipHeaderOnFirstPage(true)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.NewPage()
+		require.NoError(t, err)
+	}
+
+	_, graphicsContents := c.collectAllPageContents()
+	assert.Equal(t, []int{2}, pages)
+	assert.Empty(t, graphicsContents[0])
+	assert.Len(t, graphicsContents[1], 1)
+}
+
 func TestCreator_HeaderWithAlignment(t *testing.T) {
 	c := New()
 
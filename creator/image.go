@@ -2,6 +2,7 @@ package creator
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"image"
@@ -35,15 +36,29 @@ import (
 //	}
 //	page.DrawImage(img, 100, 500, 200, 150)
 type Image struct {
-	// Image format (jpeg or png).
+	// Image format (jpeg, png, or a registered encoder name).
 	format string
 
-	// Raw image data (JPEG bytes or compressed PNG pixels).
+	// Raw image data (JPEG bytes or PNG pixels, FlateDecode-compressed
+	// unless compression would make them larger).
 	data []byte
 
-	// Alpha mask data for RGBA PNG (compressed with FlateDecode).
+	// Alpha mask data for RGBA PNG (FlateDecode-compressed unless
+	// compression would make it larger).
 	alphaMask []byte
 
+	// Whether data / alphaMask hold uncompressed samples.
+	dataRaw  bool
+	alphaRaw bool
+
+	// Explicit stream filter from a custom ImageEncoder ("" = by format).
+	filter string
+
+	// Optional /Decode array and /DecodeParms dictionary (e.g. for
+	// inverted TIFF samples and CCITT fax data), written verbatim.
+	decode      string
+	decodeParms string
+
 	// Image dimensions.
 	width  int
 	height int
@@ -56,6 +71,12 @@ type Image struct {
 
 	// Bits per component (8 for most images).
 	bitsPerComponent int
+
+	// RGB triplets of the color table of an indexed image.
+	palette []byte
+
+	// Resolution recorded in the file, in pixels per inch (0 = unknown).
+	dpiX, dpiY float64
 }
 
 // ColorSpace represents the image color space.
@@ -70,13 +91,19 @@ const (
 
 	// ColorSpaceGray is grayscale (1 component).
 	ColorSpaceGray ColorSpace = "DeviceGray"
+
+	// ColorSpaceIndexed is a palette of RGB colors (1 component: the
+	// palette index). See Image.Palette.
+	ColorSpaceIndexed ColorSpace = "Indexed"
 )
 
 // LoadImage loads an image from a file.
 //
-// Supported formats: JPEG, PNG.
+// Supported formats: JPEG, PNG, TIFF.
 // For JPEG: RGB and CMYK color spaces.
-// For PNG: RGB, RGBA (with alpha mask), grayscale, paletted.
+// For PNG: RGB, RGBA (with alpha mask), grayscale, paletted. PNGs whose
+// pixels are all gray are stored in DeviceGray with one component.
+// For TIFF: the first page; use LoadTIFF for multi-page files.
 //
 // Example:
 //
@@ -99,7 +126,7 @@ func LoadImage(path string) (*Image, error) {
 
 // LoadImageFromReader loads an image from an io.Reader.
 //
-// Supported formats: JPEG, PNG.
+// Supported formats: JPEG, PNG, TIFF.
 // This allows loading images from various sources (files, HTTP responses, etc.).
 //
 // Example:
@@ -126,6 +153,8 @@ func LoadImageFromReader(r io.Reader) (*Image, error) {
 		return loadJPEG(data)
 	case "png":
 		return loadPNG(data)
+	case "tiff":
+		return loadTIFFFirstPage(data)
 	default:
 		return nil, fmt.Errorf("unsupported image format: %s", format)
 	}
@@ -147,6 +176,11 @@ func detectImageFormat(data []byte) string {
 		return "png"
 	}
 
+	// Check TIFF signature ("II*\0" little-endian, "MM\0*" big-endian).
+	if string(data[:4]) == "II*\x00" || string(data[:4]) == "MM\x00*" {
+		return "tiff"
+	}
+
 	return ""
 }
 
@@ -158,7 +192,7 @@ func loadJPEG(data []byte) (*Image, error) {
 		return nil, fmt.Errorf("failed to decode JPEG: %w", err)
 	}
 
-	return &Image{
+	img := &Image{
 		format:           "jpeg",
 		data:             data,
 		width:            cfg.Width,
@@ -166,7 +200,64 @@ func loadJPEG(data []byte) (*Image, error) {
 		colorSpace:       ColorSpaceRGB, // JPEG defaults to RGB.
 		components:       3,
 		bitsPerComponent: 8,
-	}, nil
+	}
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		img.colorSpace = ColorSpaceGray
+		img.components = 1
+	case color.CMYKModel:
+		img.colorSpace = ColorSpaceCMYK
+		img.components = 4
+		// Adobe applications write CMYK and YCCK JPEGs with inverted
+		// samples, marked by their APP14 segment; undo the inversion.
+		if jpegHasAdobeMarker(data) {
+			img.decode = "[1 0 1 0 1 0 1 0]"
+		}
+	}
+	img.dpiX, img.dpiY = jpegDPI(data)
+	return img, nil
+}
+
+// jpegSegments calls visit with the marker and payload of each JPEG
+// segment up to the image data (SOS). Scanning stops at the first
+// malformed segment; image/jpeg tolerates junk that it cannot parse.
+func jpegSegments(data []byte, visit func(marker byte, payload []byte)) {
+	for pos := 2; pos+2 <= len(data) && data[pos] == 0xFF; {
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF: // Fill byte
+			pos++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // TEM, RSTn: no length
+			pos += 2
+			continue
+		case marker == 0xDA || marker == 0xD9: // SOS, EOI: no more headers
+			return
+		}
+		if pos+4 > len(data) {
+			return
+		}
+		// The length includes its own two bytes.
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return
+		}
+		visit(marker, data[pos+4:end])
+		pos = end
+	}
+}
+
+// jpegHasAdobeMarker reports whether a JPEG file has an Adobe APP14
+// segment.
+func jpegHasAdobeMarker(data []byte) bool {
+	found := false
+	jpegSegments(data, func(marker byte, payload []byte) {
+		if marker == 0xEE && len(payload) >= 12 && string(payload[:5]) == "Adobe" {
+			found = true
+		}
+	})
+	return found
 }
 
 // loadPNG loads a PNG image from raw data.
@@ -177,8 +268,18 @@ func loadPNG(data []byte) (*Image, error) {
 		return nil, err
 	}
 
-	// Convert PNG to raw pixel data.
-	return convertPNGToImage(img)
+	// Convert PNG to raw pixel data, keeping the bit depth of indexed images.
+	var result *Image
+	if paletted, ok := img.(*image.Paletted); ok && len(paletted.Palette) > 0 && len(data) > 24 {
+		result, err = convertIndexedPNG(paletted, int(data[24])) // IHDR bit depth
+	} else {
+		result, err = convertPNGToImage(img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.dpiX, result.dpiY = pngDPI(data)
+	return result, nil
 }
 
 // decodePNGImage decodes PNG data to an image.Image.
@@ -196,6 +297,10 @@ func convertPNGToImage(img image.Image) (*Image, error) {
 	width := bounds.Dx()
 	height := bounds.Dy()
 
+	if paletted, ok := img.(*image.Paletted); ok && len(paletted.Palette) > 0 {
+		return convertIndexedPNG(paletted, indexedBitDepth(len(paletted.Palette)))
+	}
+
 	// Detect color model and convert accordingly.
 	switch img.ColorModel() {
 	case color.RGBAModel:
@@ -205,7 +310,7 @@ func convertPNGToImage(img image.Image) (*Image, error) {
 	case color.GrayModel:
 		return convertGrayPNG(img, width, height)
 	default:
-		// For paletted and other formats, convert to RGB.
+		// For other formats, convert to RGB.
 		return convertGenericPNG(img, width, height)
 	}
 }
@@ -213,17 +318,19 @@ func convertPNGToImage(img image.Image) (*Image, error) {
 // convertRGBAPNG converts an RGBA PNG image (with alpha channel).
 func convertRGBAPNG(img image.Image, width, height int) (*Image, error) {
 	// Extract RGB and alpha channels separately.
-	rgbData, alphaData := extractRGBAndAlpha(img, width, height)
+	samples, alphaData := extractRGBAndAlpha(img, width, height)
+	colorSpace, components := neutralToGray(&samples)
 
-	// Compress both with FlateDecode.
-	compressedRGB, err := compressData(rgbData)
+	// Compress both with FlateDecode (only where it helps).
+	compressedRGB, rgbRaw, err := compressIfSmaller(samples)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compress RGB data: %w", err)
 	}
 
 	var compressedAlpha []byte
+	var alphaRaw bool
 	if alphaData != nil {
-		compressedAlpha, err = compressData(alphaData)
+		compressedAlpha, alphaRaw, err = compressIfSmaller(alphaData)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compress alpha data: %w", err)
 		}
@@ -233,10 +340,12 @@ func convertRGBAPNG(img image.Image, width, height int) (*Image, error) {
 		format:           "png",
 		data:             compressedRGB,
 		alphaMask:        compressedAlpha,
+		dataRaw:          rgbRaw,
+		alphaRaw:         alphaRaw,
 		width:            width,
 		height:           height,
-		colorSpace:       ColorSpaceRGB,
-		components:       3,
+		colorSpace:       colorSpace,
+		components:       components,
 		bitsPerComponent: 8,
 	}, nil
 }
@@ -246,8 +355,8 @@ func convertGrayPNG(img image.Image, width, height int) (*Image, error) {
 	// Extract grayscale data.
 	grayData := extractGrayscale(img, width, height)
 
-	// Compress with FlateDecode.
-	compressed, err := compressData(grayData)
+	// Compress with FlateDecode (only if it helps).
+	compressed, raw, err := compressIfSmaller(grayData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compress grayscale data: %w", err)
 	}
@@ -255,6 +364,7 @@ func convertGrayPNG(img image.Image, width, height int) (*Image, error) {
 	return &Image{
 		format:           "png",
 		data:             compressed,
+		dataRaw:          raw,
 		width:            width,
 		height:           height,
 		colorSpace:       ColorSpaceGray,
@@ -263,13 +373,100 @@ func convertGrayPNG(img image.Image, width, height int) (*Image, error) {
 	}, nil
 }
 
-// convertGenericPNG converts paletted and other PNG formats to RGB.
+// convertIndexedPNG converts a paletted PNG image to an indexed image
+// with bitDepth (1, 2, 4 or 8) bits per sample.
+//
+// Palette entries made transparent by a tRNS chunk become an alpha mask.
+func convertIndexedPNG(img *image.Paletted, bitDepth int) (*Image, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if len(img.Palette) > 256 {
+		return convertGenericPNG(img, width, height)
+	}
+	if minDepth := indexedBitDepth(len(img.Palette)); bitDepth < minDepth || 8%bitDepth != 0 {
+		bitDepth = minDepth
+	}
+
+	palette := make([]byte, 0, len(img.Palette)*3)
+	alphas := make([]byte, len(img.Palette))
+	hasAlpha := false
+	for i, c := range img.Palette {
+		nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+		palette = append(palette, nrgba.R, nrgba.G, nrgba.B)
+		alphas[i] = nrgba.A
+		hasAlpha = hasAlpha || nrgba.A != 255
+	}
+
+	// Pack the indices, most significant bits first, each row starting
+	// on a byte boundary.
+	rowBytes := (width*bitDepth + 7) / 8
+	samples := make([]byte, rowBytes*height)
+	var alphaData []byte
+	if hasAlpha {
+		alphaData = make([]byte, 0, width*height)
+	}
+	for y := 0; y < height; y++ {
+		row := samples[y*rowBytes:]
+		for x := 0; x < width; x++ {
+			index := img.ColorIndexAt(bounds.Min.X+x, bounds.Min.Y+y)
+			bit := x * bitDepth
+			row[bit/8] |= index << (8 - bitDepth - bit%8)
+			if hasAlpha {
+				alpha := byte(0) // Out-of-range indices are transparent
+				if int(index) < len(alphas) {
+					alpha = alphas[index]
+				}
+				alphaData = append(alphaData, alpha)
+			}
+		}
+	}
+
+	compressed, raw, err := compressIfSmaller(samples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress indexed data: %w", err)
+	}
+	result := &Image{
+		format:           "png",
+		data:             compressed,
+		dataRaw:          raw,
+		width:            width,
+		height:           height,
+		colorSpace:       ColorSpaceIndexed,
+		components:       1,
+		bitsPerComponent: bitDepth,
+		palette:          palette,
+	}
+	if hasAlpha {
+		if result.alphaMask, result.alphaRaw, err = compressIfSmaller(alphaData); err != nil {
+			return nil, fmt.Errorf("failed to compress alpha data: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// indexedBitDepth returns the smallest PNG bit depth that holds n palette
+// indices.
+func indexedBitDepth(n int) int {
+	switch {
+	case n <= 2:
+		return 1
+	case n <= 4:
+		return 2
+	case n <= 16:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// convertGenericPNG converts PNG formats without a dedicated converter to RGB.
 func convertGenericPNG(img image.Image, width, height int) (*Image, error) {
 	// Convert to RGB.
-	rgbData := extractRGB(img, width, height)
+	samples := extractRGB(img, width, height)
+	colorSpace, components := neutralToGray(&samples)
 
-	// Compress with FlateDecode.
-	compressed, err := compressData(rgbData)
+	// Compress with FlateDecode (only if it helps).
+	compressed, raw, err := compressIfSmaller(samples)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compress RGB data: %w", err)
 	}
@@ -277,14 +474,34 @@ func convertGenericPNG(img image.Image, width, height int) (*Image, error) {
 	return &Image{
 		format:           "png",
 		data:             compressed,
+		dataRaw:          raw,
 		width:            width,
 		height:           height,
-		colorSpace:       ColorSpaceRGB,
-		components:       3,
+		colorSpace:       colorSpace,
+		components:       components,
 		bitsPerComponent: 8,
 	}, nil
 }
 
+// neutralToGray replaces RGB samples whose pixels are all neutral
+// (R = G = B) with one gray sample per pixel, and returns the color space
+// and components of the result. Grayscale PNGs that Go decodes to RGB
+// (16-bit gray, gray with alpha) and RGB files holding only grays are
+// stored in DeviceGray, a third of the size.
+func neutralToGray(samples *[]byte) (ColorSpace, int) {
+	rgb := *samples
+	gray := make([]byte, len(rgb)/3)
+	for i := range gray {
+		r, g, b := rgb[3*i], rgb[3*i+1], rgb[3*i+2]
+		if r != g || g != b {
+			return ColorSpaceRGB, 3
+		}
+		gray[i] = r
+	}
+	*samples = gray
+	return ColorSpaceGray, 1
+}
+
 // extractRGBAndAlpha extracts RGB and alpha from RGBA image.
 func extractRGBAndAlpha(img image.Image, width, height int) ([]byte, []byte) {
 	rgbData := make([]byte, width*height*3)
@@ -366,6 +583,23 @@ func compressData(data []byte) ([]byte, error) {
 	return compressed, nil
 }
 
+// compressIfSmaller compresses data using FlateDecode, but only keeps the
+// compressed form when it is actually smaller than the input.
+//
+// High-entropy samples (noise, already-dithered photos) can grow under
+// Flate. In that case the raw samples are returned and raw is true, so the
+// writer can omit the /Filter entry.
+func compressIfSmaller(data []byte) (out []byte, raw bool, err error) {
+	compressed, err := compressData(data)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(compressed) >= len(data) {
+		return data, true, nil
+	}
+	return compressed, false, nil
+}
+
 // Width returns the image width in pixels.
 func (img *Image) Width() int {
 	return img.width
@@ -388,11 +622,27 @@ func (img *Image) ColorSpace() ColorSpace {
 	return img.colorSpace
 }
 
-// Format returns the image format (jpeg or png).
+// Format returns the image format (jpeg, png, or a registered encoder name).
 func (img *Image) Format() string {
 	return img.format
 }
 
+// Filter returns the PDF filter of the image data (e.g. "DCTDecode").
+//
+// Returns "" for uncompressed samples.
+func (img *Image) Filter() string {
+	switch {
+	case img.filter != "":
+		return img.filter
+	case img.dataRaw:
+		return ""
+	case img.format == "jpeg":
+		return "DCTDecode"
+	default:
+		return "FlateDecode"
+	}
+}
+
 // AlphaMask returns the alpha mask data (nil if no transparency).
 //
 // For RGBA PNG images with transparency, this contains the compressed
@@ -401,6 +651,14 @@ func (img *Image) AlphaMask() []byte {
 	return img.alphaMask
 }
 
+// IsCompressed reports whether the PNG pixel data is FlateDecode-compressed.
+//
+// PNG samples are stored uncompressed when compression would not reduce
+// their size. JPEG data is always reported as compressed (DCTDecode).
+func (img *Image) IsCompressed() bool {
+	return !img.dataRaw
+}
+
 // HasAlpha returns true if the image has transparency data.
 func (img *Image) HasAlpha() bool {
 	return img.alphaMask != nil
@@ -409,14 +667,21 @@ func (img *Image) HasAlpha() bool {
 // Components returns the number of color components.
 //
 // Returns:
-//   - 1 for grayscale
+//   - 1 for grayscale and indexed
 //   - 3 for RGB
 //   - 4 for CMYK
 func (img *Image) Components() int {
 	return img.components
 }
 
-// BitsPerComponent returns the bits per component (typically 8).
+// Palette returns the color table of an indexed image as RGB triplets,
+// or nil for other images.
+func (img *Image) Palette() []byte {
+	return img.palette
+}
+
+// BitsPerComponent returns the bits per component (typically 8; 1, 2 or 4
+// for some indexed and bilevel images).
 func (img *Image) BitsPerComponent() int {
 	return img.bitsPerComponent
 }
@@ -438,20 +703,53 @@ func (img *Image) BitsPerComponent() int {
 //	img, _ := creator.LoadImage("photo.jpg")
 //	page.DrawImage(img, 100, 500, 200, 150)
 func (p *Page) DrawImage(img *Image, x, y, width, height float64) error {
+	return p.DrawImageWithOptions(img, x, y, width, height, nil)
+}
+
+// DrawImageOptions configures DrawImageWithOptions.
+type DrawImageOptions struct {
+	// AltText is the alternate text read by screen readers in place of
+	// the image. A non-empty AltText tags the image as a /Figure
+	// structure element carrying the text as /Alt.
+	AltText string
+}
+
+// DrawImageWithOptions draws an image like DrawImage, with options.
+//
+// With an AltText, the image's content is marked with a marked-content
+// ID and linked to a /Figure element in the document's structure tree,
+// as accessible (PDF/UA) documents require. Combine with
+// Creator.SetTagged and Creator.SetLanguage for a tagged PDF.
+//
+// Example:
+//
+//	c.SetTagged(true)
+//	c.SetLanguage("en-US")
+//	page.DrawImageWithOptions(img, 100, 500, 200, 150, &creator.DrawImageOptions{
+//	    AltText: "Quarterly revenue chart",
+//	})
+//
+// Reference: PDF 1.7 Specification, Section 14.9.3 (Alternate Descriptions).
+func (p *Page) DrawImageWithOptions(img *Image, x, y, width, height float64, opts *DrawImageOptions) error {
 	// Validate dimensions.
 	if width <= 0 || height <= 0 {
 		return errors.New("image dimensions must be positive")
 	}
 
-	// Store image operation.
-	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+	op := GraphicsOperation{
 		Type:   GraphicsOpImage,
 		X:      x,
 		Y:      y,
 		Width:  width,
 		Height: height,
 		Image:  img,
-	})
+	}
+	if opts != nil {
+		op.AltText = opts.AltText
+	}
+
+	// Store image operation.
+	p.graphicsOps = append(p.graphicsOps, op)
 
 	return nil
 }
@@ -493,6 +791,82 @@ func (p *Page) DrawImageFit(img *Image, x, y, maxWidth, maxHeight float64) error
 	return p.DrawImage(img, centerX, centerY, scaledW, scaledH)
 }
 
+// DrawImageClipped draws an image into rect, clipped to a polygon.
+//
+// The image is scaled to fill rect; only the part inside the closed polygon
+// through the clip vertices is visible. This produces non-rectangular crops
+// such as hexagonal avatars. Clip vertices are in page coordinates.
+//
+// Parameters:
+//   - img: The image to draw
+//   - rect: Position and size of the image in points
+//   - clip: Polygon vertices (at least 3)
+//
+// Example:
+//
+//	// Diamond-shaped crop
+//	rect := creator.Rect{X: 100, Y: 500, Width: 100, Height: 100}
+//	page.DrawImageClipped(img, rect, []creator.Point{
+//	    {X: 150, Y: 500}, {X: 200, Y: 550}, {X: 150, Y: 600}, {X: 100, Y: 550},
+//	})
+func (p *Page) DrawImageClipped(img *Image, rect Rect, clip []Point) error {
+	if img == nil {
+		return errors.New("image cannot be nil")
+	}
+	if rect.Width <= 0 || rect.Height <= 0 {
+		return errors.New("image dimensions must be positive")
+	}
+	if err := p.BeginClipPolygon(clip); err != nil {
+		return err
+	}
+	if err := p.DrawImage(img, rect.X, rect.Y, rect.Width, rect.Height); err != nil {
+		return err
+	}
+	return p.EndClip()
+}
+
+// DrawImageClippedCircle draws an image into rect, clipped to the ellipse
+// inscribed in rect.
+//
+// For a square rect this is a circle, the usual shape for avatar crops.
+// The clip path is built from four Bézier curves.
+//
+// Example:
+//
+//	// Circular avatar
+//	page.DrawImageClippedCircle(img, creator.Rect{X: 100, Y: 500, Width: 64, Height: 64})
+func (p *Page) DrawImageClippedCircle(img *Image, rect Rect) error {
+	if img == nil {
+		return errors.New("image cannot be nil")
+	}
+	if rect.Width <= 0 || rect.Height <= 0 {
+		return errors.New("image dimensions must be positive")
+	}
+
+	if err := p.BeginClipEllipse(rect.X+rect.Width/2, rect.Y+rect.Height/2, rect.Width/2, rect.Height/2); err != nil {
+		return err
+	}
+	if err := p.DrawImage(img, rect.X, rect.Y, rect.Width, rect.Height); err != nil {
+		return err
+	}
+	return p.EndClip()
+}
+
+// ellipseSegments approximates an ellipse with four Bézier segments,
+// counter-clockwise starting at the rightmost point.
+func ellipseSegments(cx, cy, rx, ry float64) []BezierSegment {
+	// kappa = 4/3 * (sqrt(2) - 1) ≈ 0.5522847498
+	const kappa = 0.5522847498
+	kx, ky := rx*kappa, ry*kappa
+
+	return []BezierSegment{
+		{Start: Point{cx + rx, cy}, C1: Point{cx + rx, cy + ky}, C2: Point{cx + kx, cy + ry}, End: Point{cx, cy + ry}},
+		{Start: Point{cx, cy + ry}, C1: Point{cx - kx, cy + ry}, C2: Point{cx - rx, cy + ky}, End: Point{cx - rx, cy}},
+		{Start: Point{cx - rx, cy}, C1: Point{cx - rx, cy - ky}, C2: Point{cx - kx, cy - ry}, End: Point{cx, cy - ry}},
+		{Start: Point{cx, cy - ry}, C1: Point{cx + kx, cy - ry}, C2: Point{cx + rx, cy - ky}, End: Point{cx + rx, cy}},
+	}
+}
+
 // calculateFitDimensions calculates dimensions to fit within max bounds.
 //
 // Maintains aspect ratio by scaling down the larger dimension.
@@ -513,7 +887,7 @@ func calculateFitDimensions(imgW, imgH, maxW, maxH float64) (float64, float64) {
 // Errors.
 var (
 	// ErrUnsupportedImageFormat is returned for unsupported image formats.
-	ErrUnsupportedImageFormat = errors.New("unsupported image format (supported: JPEG, PNG)")
+	ErrUnsupportedImageFormat = errors.New("unsupported image format (supported: JPEG, PNG, TIFF)")
 
 	// ErrInvalidImageDimensions is returned for zero/negative dimensions.
 	ErrInvalidImageDimensions = errors.New("image dimensions must be positive")
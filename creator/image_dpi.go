@@ -0,0 +1,171 @@
+package creator
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Resolution units of the JPEG JFIF and PNG pHYs headers.
+const (
+	jfifUnitsInch       = 1
+	jfifUnitsCentimeter = 2
+	pngUnitMeter        = 1
+)
+
+// TIFF (and EXIF) resolution units.
+const (
+	tiffResolutionUnitInch       = 2
+	tiffResolutionUnitCentimeter = 3
+)
+
+// DPI returns the resolution recorded in the image file, in pixels per
+// inch, horizontally and vertically.
+//
+// It is read from the JFIF or EXIF header of JPEG files, the pHYs chunk
+// of PNG files and the resolution tags of TIFF files. Returns 0, 0 if the
+// file records no physical resolution.
+func (img *Image) DPI() (x, y float64) {
+	return img.dpiX, img.dpiY
+}
+
+// DrawImageAtDPI draws an image at its print size for a resolution.
+//
+// The image covers pixels / dpi inches, so a 640x480 image at 300 DPI is
+// drawn 153.6 x 115.2 points. With dpi 0 the resolution recorded in the
+// file is used (see Image.DPI), or 72 DPI (one point per pixel) if it
+// records none.
+//
+// Parameters:
+//   - img: The image to draw
+//   - x: Horizontal position in points (from left edge)
+//   - y: Vertical position in points (from bottom edge)
+//   - dpi: Resolution in pixels per inch, or 0 for the image's own
+//
+// Example:
+//
+//	img, _ := creator.LoadImage("scan.png")
+//	page.DrawImageAtDPI(img, 72, 400, 300)
+func (p *Page) DrawImageAtDPI(img *Image, x, y, dpi float64) error {
+	if img == nil {
+		return errors.New("image cannot be nil")
+	}
+	if dpi < 0 {
+		return errors.New("dpi must not be negative")
+	}
+
+	dpiX, dpiY := dpi, dpi
+	if dpi == 0 {
+		dpiX, dpiY = img.DPI()
+		if dpiX <= 0 || dpiY <= 0 {
+			dpiX, dpiY = 72, 72
+		}
+	}
+
+	width := float64(img.width) / dpiX * 72
+	height := float64(img.height) / dpiY * 72
+	return p.DrawImage(img, x, y, width, height)
+}
+
+// jpegDPI returns the resolution from the JFIF (APP0) or EXIF (APP1)
+// header of a JPEG file, or 0, 0 if neither records one. JFIF wins when
+// both do.
+func jpegDPI(data []byte) (x, y float64) {
+	var jfifX, jfifY, exifX, exifY float64
+	jpegSegments(data, func(marker byte, payload []byte) {
+		switch {
+		case marker == 0xE0 && len(payload) >= 12 && string(payload[:5]) == "JFIF\x00":
+			units := payload[7]
+			dx := float64(binary.BigEndian.Uint16(payload[8:]))
+			dy := float64(binary.BigEndian.Uint16(payload[10:]))
+			jfifX, jfifY = resolutionToDPI(dx, dy, units == jfifUnitsInch, units == jfifUnitsCentimeter)
+		case marker == 0xE1 && len(payload) >= 6 && string(payload[:6]) == "Exif\x00\x00":
+			exifX, exifY = exifDPI(payload[6:])
+		}
+	})
+	if jfifX > 0 {
+		return jfifX, jfifY
+	}
+	return exifX, exifY
+}
+
+// exifDPI returns the resolution from the first IFD of EXIF data (a
+// TIFF structure), or 0, 0.
+func exifDPI(data []byte) (x, y float64) {
+	if len(data) < 8 {
+		return 0, 0
+	}
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, 0
+	}
+
+	offset := uint64(order.Uint32(data[4:8]))
+	if offset+2 > uint64(len(data)) {
+		return 0, 0
+	}
+	count := uint64(order.Uint16(data[offset:]))
+	if offset+2+count*12 > uint64(len(data)) {
+		return 0, 0
+	}
+
+	unit := tiffResolutionUnitInch
+	for i := uint64(0); i < count; i++ {
+		entry := data[offset+2+i*12:]
+		switch order.Uint16(entry[0:2]) {
+		case tiffTagXResolution:
+			x = tiffRational(data, order, entry)
+		case tiffTagYResolution:
+			y = tiffRational(data, order, entry)
+		case tiffTagResolutionUnit:
+			if values, err := tiffValues(data, order, entry); err == nil && len(values) > 0 {
+				unit = int(values[0])
+			}
+		}
+	}
+	return resolutionToDPI(x, y, unit == tiffResolutionUnitInch, unit == tiffResolutionUnitCentimeter)
+}
+
+// pngDPI returns the resolution from the pHYs chunk of a PNG file, or
+// 0, 0 if it has none or its unit is unknown (aspect ratio only).
+func pngDPI(data []byte) (x, y float64) {
+	for pos := 8; pos+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		typ := string(data[pos+4 : pos+8])
+		if typ == "IDAT" || length < 0 || pos+8+length > len(data) {
+			break
+		}
+		if typ == "pHYs" && length == 9 {
+			chunk := data[pos+8:]
+			if chunk[8] != pngUnitMeter {
+				return 0, 0
+			}
+			const inchesPerMeter = 0.0254
+			return float64(binary.BigEndian.Uint32(chunk)) * inchesPerMeter,
+				float64(binary.BigEndian.Uint32(chunk[4:])) * inchesPerMeter
+		}
+		pos += 12 + length // Length, type, data, CRC
+	}
+	return 0, 0
+}
+
+// resolutionToDPI converts a resolution in pixels per inch or per
+// centimeter to pixels per inch. Returns 0, 0 for other units or
+// non-positive values.
+func resolutionToDPI(x, y float64, perInch, perCentimeter bool) (float64, float64) {
+	if x <= 0 || y <= 0 {
+		return 0, 0
+	}
+	switch {
+	case perInch:
+		return x, y
+	case perCentimeter:
+		return x * 2.54, y * 2.54
+	default:
+		return 0, 0
+	}
+}
@@ -0,0 +1,147 @@
+package creator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withJPEGSegment inserts an APPn segment right after the SOI marker.
+func withJPEGSegment(data []byte, marker byte, payload []byte) []byte {
+	out := append([]byte{}, data[:2]...)
+	out = append(out, 0xFF, marker)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(payload)+2))
+	out = append(out, payload...)
+	return append(out, data[2:]...)
+}
+
+// jfifHeader returns a JFIF APP0 payload.
+func jfifHeader(units byte, x, y uint16) []byte {
+	payload := []byte("JFIF\x00\x01\x02")
+	payload = append(payload, units)
+	payload = binary.BigEndian.AppendUint16(payload, x)
+	payload = binary.BigEndian.AppendUint16(payload, y)
+	return append(payload, 0, 0) // No thumbnail
+}
+
+// exifHeader returns an EXIF APP1 payload with a big-endian IFD holding
+// XResolution, YResolution and ResolutionUnit.
+func exifHeader(x, y uint32, unit uint16) []byte {
+	be := binary.BigEndian
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8}
+	tiff = be.AppendUint16(tiff, 3)
+	rationals := uint32(8 + 2 + 3*12 + 4)
+	for i, tag := range []uint16{tiffTagXResolution, tiffTagYResolution} {
+		tiff = be.AppendUint16(tiff, tag)
+		tiff = be.AppendUint16(tiff, 5) // RATIONAL
+		tiff = be.AppendUint32(tiff, 1)
+		tiff = be.AppendUint32(tiff, rationals+uint32(i)*8)
+	}
+	tiff = be.AppendUint16(tiff, tiffTagResolutionUnit)
+	tiff = be.AppendUint16(tiff, 3) // SHORT
+	tiff = be.AppendUint32(tiff, 1)
+	tiff = be.AppendUint16(tiff, unit)
+	tiff = be.AppendUint16(tiff, 0)
+	tiff = be.AppendUint32(tiff, 0) // No next IFD
+	tiff = be.AppendUint32(tiff, x)
+	tiff = be.AppendUint32(tiff, 1)
+	tiff = be.AppendUint32(tiff, y)
+	tiff = be.AppendUint32(tiff, 1)
+	return append([]byte("Exif\x00\x00"), tiff...)
+}
+
+// withPNGPhys inserts a pHYs chunk after the IHDR chunk.
+func withPNGPhys(data []byte, x, y uint32, unit byte) []byte {
+	chunk := []byte("pHYs")
+	chunk = binary.BigEndian.AppendUint32(chunk, x)
+	chunk = binary.BigEndian.AppendUint32(chunk, y)
+	chunk = append(chunk, unit)
+
+	ihdrEnd := 8 + 8 + 13 + 4
+	out := append([]byte{}, data[:ihdrEnd]...)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(chunk)-4))
+	out = append(out, chunk...)
+	out = binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(chunk))
+	return append(out, data[ihdrEnd:]...)
+}
+
+func TestImage_DPI(t *testing.T) {
+	jpegData := createJPEGData(t, 8, 8, color.White)
+	pngData := createPNGData(t, 8, 8, color.White)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		wantX float64
+		wantY float64
+	}{
+		{"JPEG without header", jpegData, 0, 0},
+		{"JFIF inches", withJPEGSegment(jpegData, 0xE0, jfifHeader(1, 300, 150)), 300, 150},
+		{"JFIF centimeters", withJPEGSegment(jpegData, 0xE0, jfifHeader(2, 100, 100)), 254, 254},
+		{"JFIF aspect ratio only", withJPEGSegment(jpegData, 0xE0, jfifHeader(0, 1, 1)), 0, 0},
+		{"EXIF inches", withJPEGSegment(jpegData, 0xE1, exifHeader(600, 600, 2)), 600, 600},
+		{"EXIF centimeters", withJPEGSegment(jpegData, 0xE1, exifHeader(50, 50, 3)), 127, 127},
+		{"PNG without pHYs", pngData, 0, 0},
+		{"PNG pHYs meters", withPNGPhys(pngData, 11811, 11811, 1), 299.9994, 299.9994},
+		{"PNG pHYs unknown unit", withPNGPhys(pngData, 2, 1, 0), 0, 0},
+		{"TIFF", func() []byte {
+			page := grayPage(4, 4, 0x80)
+			page.dpi = 200
+			return buildTIFF(t, page)
+		}(), 200, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := LoadImageFromReader(bytes.NewReader(tt.data))
+			require.NoError(t, err)
+			x, y := img.DPI()
+			assert.InDelta(t, tt.wantX, x, 1e-9)
+			assert.InDelta(t, tt.wantY, y, 1e-9)
+		})
+	}
+}
+
+func TestDrawImageAtDPI(t *testing.T) {
+	data := withJPEGSegment(createJPEGData(t, 640, 480, color.White), 0xE0, jfifHeader(1, 150, 150))
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 640, img.Width())
+	assert.Equal(t, 480, img.Height())
+
+	tests := []struct {
+		name          string
+		img           *Image
+		dpi           float64
+		width, height float64
+	}{
+		{"300 DPI", img, 300, 153.6, 115.2},
+		{"72 DPI", img, 72, 640, 480},
+		{"file resolution", img, 0, 307.2, 230.4},
+		{"no file resolution", &Image{width: 640, height: 480}, 0, 640, 480},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := createTestPage(t)
+			require.NoError(t, page.DrawImageAtDPI(tt.img, 50, 100, tt.dpi))
+
+			ops := page.GraphicsOperations()
+			require.Len(t, ops, 1)
+			assert.Equal(t, 50.0, ops[0].X)
+			assert.Equal(t, 100.0, ops[0].Y)
+			assert.InDelta(t, tt.width, ops[0].Width, 1e-9)
+			assert.InDelta(t, tt.height, ops[0].Height, 1e-9)
+		})
+	}
+
+	page := createTestPage(t)
+	assert.Error(t, page.DrawImageAtDPI(img, 0, 0, -300))
+	assert.Error(t, page.DrawImageAtDPI(nil, 0, 0, 300))
+	assert.Empty(t, page.GraphicsOperations())
+}
@@ -0,0 +1,167 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"strings"
+	"sync"
+)
+
+// ImageEncoder encodes a Go image for embedding as a PDF image XObject.
+//
+// It returns the stream data together with the PDF filter that decodes it
+// and the color space of the decoded samples. Samples are assumed to use
+// 8 bits per component.
+//
+// The filter must be "" (raw samples), "FlateDecode", "DCTDecode",
+// "JPXDecode", "LZWDecode", "RunLengthDecode", "ASCIIHexDecode" or
+// "ASCII85Decode". The color space must be "DeviceRGB", "DeviceGray" or
+// "DeviceCMYK". Names may have a leading slash.
+//
+// Reference: PDF 1.7 Specification, Section 8.9.5 (Image Dictionaries).
+type ImageEncoder func(img image.Image) (data []byte, filter string, colorSpace string, err error)
+
+// imageEncoders holds the user-registered encoders, keyed by format name.
+var imageEncoders = struct {
+	sync.RWMutex
+	m map[string]ImageEncoder
+}{m: make(map[string]ImageEncoder)}
+
+// imageEncoderFilters are the filters an ImageEncoder may return: those
+// that decode to samples without further parameters.
+var imageEncoderFilters = map[string]bool{
+	"":                true,
+	"FlateDecode":     true,
+	"DCTDecode":       true,
+	"JPXDecode":       true,
+	"LZWDecode":       true,
+	"RunLengthDecode": true,
+	"ASCIIHexDecode":  true,
+	"ASCII85Decode":   true,
+}
+
+// jpegQuality is the quality of the built-in JPEG encoder.
+const jpegQuality = 90
+
+// RegisterImageEncoder registers an encoder for the given format name.
+//
+// ImageFromGo dispatches to the registered encoder for its format. A
+// registered encoder takes precedence over the built-in "jpeg" and "png"
+// encoders, so it can also replace them. Passing a nil encoder removes the
+// registration. The filter and color space the encoder returns are
+// checked by ImageFromGo, which fails for names not listed on
+// ImageEncoder. Safe for concurrent use.
+//
+// Example:
+//
+//	// Plug in an external JPEG encoder.
+//	creator.RegisterImageEncoder("mozjpeg", func(img image.Image) ([]byte, string, string, error) {
+//	    data, err := mozjpeg.Encode(img, 85)
+//	    return data, "DCTDecode", "DeviceRGB", err
+//	})
+//	img, err := creator.ImageFromGo(photo, "mozjpeg")
+func RegisterImageEncoder(format string, enc ImageEncoder) {
+	imageEncoders.Lock()
+	defer imageEncoders.Unlock()
+
+	if enc == nil {
+		delete(imageEncoders.m, format)
+		return
+	}
+	imageEncoders.m[format] = enc
+}
+
+// ImageFromGo converts a Go image to an Image using the encoder for format.
+//
+// Built-in formats:
+//   - "png": lossless samples compressed with FlateDecode
+//   - "jpeg": JPEG (DCTDecode) at quality 90
+//
+// Other formats must be registered with RegisterImageEncoder.
+//
+// Example:
+//
+//	img, err := creator.ImageFromGo(chart, "png")
+//	if err != nil {
+//	    return err
+//	}
+//	page.DrawImage(img, 100, 500, 200, 150)
+func ImageFromGo(src image.Image, format string) (*Image, error) {
+	if src == nil {
+		return nil, errors.New("image cannot be nil")
+	}
+	bounds := src.Bounds()
+	if bounds.Dx() <= 0 || bounds.Dy() <= 0 {
+		return nil, ErrInvalidImageDimensions
+	}
+
+	imageEncoders.RLock()
+	enc, ok := imageEncoders.m[format]
+	imageEncoders.RUnlock()
+	if ok {
+		return encodeCustomImage(src, format, enc)
+	}
+
+	switch format {
+	case "png":
+		return convertPNGToImage(src)
+	case "jpeg":
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode JPEG: %w", err)
+		}
+		return loadJPEG(buf.Bytes())
+	default:
+		return nil, fmt.Errorf("%w: no encoder registered for %q", ErrUnsupportedImageFormat, format)
+	}
+}
+
+// encodeCustomImage runs a registered encoder and wraps its output.
+func encodeCustomImage(src image.Image, format string, enc ImageEncoder) (*Image, error) {
+	data, filter, colorSpace, err := enc(src)
+	if err != nil {
+		return nil, fmt.Errorf("image encoder %q failed: %w", format, err)
+	}
+
+	filter = strings.TrimPrefix(filter, "/")
+	colorSpace = strings.TrimPrefix(colorSpace, "/")
+	if colorSpace == "" {
+		return nil, fmt.Errorf("image encoder %q returned no color space", format)
+	}
+	if !imageEncoderFilters[filter] {
+		return nil, fmt.Errorf("image encoder %q returned unsupported filter %q", format, filter)
+	}
+	switch ColorSpace(colorSpace) {
+	case ColorSpaceRGB, ColorSpaceGray, ColorSpaceCMYK:
+	default:
+		return nil, fmt.Errorf("image encoder %q returned unsupported color space %q", format, colorSpace)
+	}
+
+	return &Image{
+		format:           format,
+		data:             data,
+		filter:           filter,
+		dataRaw:          filter == "",
+		width:            src.Bounds().Dx(),
+		height:           src.Bounds().Dy(),
+		colorSpace:       ColorSpace(colorSpace),
+		components:       colorSpaceComponents(ColorSpace(colorSpace)),
+		bitsPerComponent: 8,
+	}, nil
+}
+
+// colorSpaceComponents returns the number of components of a device color
+// space.
+func colorSpaceComponents(cs ColorSpace) int {
+	switch cs {
+	case ColorSpaceGray:
+		return 1
+	case ColorSpaceCMYK:
+		return 4
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,190 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// newTestGoImage creates a solid-color RGBA image.
+func newTestGoImage(width, height int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// TestImageFromGo_BuiltIn tests the built-in png and jpeg encoders.
+func TestImageFromGo_BuiltIn(t *testing.T) {
+	src := newTestGoImage(8, 4, color.RGBA{10, 20, 30, 255})
+
+	pngImg, err := ImageFromGo(src, "png")
+	if err != nil {
+		t.Fatalf("ImageFromGo(png) failed: %v", err)
+	}
+	if pngImg.Format() != "png" || pngImg.Filter() != "FlateDecode" {
+		t.Errorf("png: format=%q filter=%q", pngImg.Format(), pngImg.Filter())
+	}
+	if pngImg.Width() != 8 || pngImg.Height() != 4 {
+		t.Errorf("png: size %dx%d, want 8x4", pngImg.Width(), pngImg.Height())
+	}
+
+	jpegImg, err := ImageFromGo(src, "jpeg")
+	if err != nil {
+		t.Fatalf("ImageFromGo(jpeg) failed: %v", err)
+	}
+	if jpegImg.Filter() != "DCTDecode" || !bytes.HasPrefix(jpegImg.Data(), []byte{0xFF, 0xD8}) {
+		t.Errorf("jpeg: filter=%q, data is not JPEG", jpegImg.Filter())
+	}
+}
+
+// TestImageFromGo_Unregistered tests that unknown formats are rejected.
+func TestImageFromGo_Unregistered(t *testing.T) {
+	_, err := ImageFromGo(newTestGoImage(1, 1, color.Black), "webp")
+	if !errors.Is(err, ErrUnsupportedImageFormat) {
+		t.Errorf("expected ErrUnsupportedImageFormat, got %v", err)
+	}
+}
+
+// TestRegisterImageEncoder tests dispatch to a custom encoder and that its
+// filter and color space reach the image XObject.
+func TestRegisterImageEncoder(t *testing.T) {
+	RegisterImageEncoder("test-gray", func(img image.Image) ([]byte, string, string, error) {
+		b := img.Bounds()
+		return make([]byte, b.Dx()*b.Dy()), "", "/DeviceGray", nil
+	})
+	defer RegisterImageEncoder("test-gray", nil)
+
+	img, err := ImageFromGo(newTestGoImage(3, 2, color.White), "test-gray")
+	if err != nil {
+		t.Fatalf("ImageFromGo failed: %v", err)
+	}
+	if img.ColorSpace() != ColorSpaceGray || img.Components() != 1 {
+		t.Errorf("color space = %q (%d components), want DeviceGray (1)", img.ColorSpace(), img.Components())
+	}
+	if img.Filter() != "" || img.IsCompressed() {
+		t.Errorf("raw encoder output should have no filter, got %q", img.Filter())
+	}
+
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage failed: %v", err)
+	}
+	if err := page.DrawImage(img, 100, 100, 30, 20); err != nil {
+		t.Fatalf("DrawImage failed: %v", err)
+	}
+	data, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	pdf := string(data)
+	start := strings.Index(pdf, "/Subtype /Image")
+	if start < 0 {
+		t.Fatal("image XObject not found")
+	}
+	dict := pdf[start : start+strings.Index(pdf[start:], ">>")]
+	if !strings.Contains(dict, "/Width 3 /Height 2 /ColorSpace /DeviceGray") {
+		t.Errorf("image XObject does not use the encoder's color space: %s", dict)
+	}
+	if strings.Contains(dict, "/Filter") {
+		t.Errorf("image XObject should not have a /Filter: %s", dict)
+	}
+
+	RegisterImageEncoder("test-gray", nil)
+	if _, err := ImageFromGo(newTestGoImage(1, 1, color.White), "test-gray"); err == nil {
+		t.Error("expected error after unregistering encoder")
+	}
+}
+
+// TestRegisterImageEncoder_RawJPEG tests that a custom encoder replacing
+// "jpeg" with raw samples is not written as DCTDecode.
+func TestRegisterImageEncoder_RawJPEG(t *testing.T) {
+	RegisterImageEncoder("jpeg", func(img image.Image) ([]byte, string, string, error) {
+		b := img.Bounds()
+		return make([]byte, 3*b.Dx()*b.Dy()), "", "DeviceRGB", nil
+	})
+	defer RegisterImageEncoder("jpeg", nil)
+
+	img, err := ImageFromGo(newTestGoImage(3, 2, color.White), "jpeg")
+	if err != nil {
+		t.Fatalf("ImageFromGo failed: %v", err)
+	}
+	if img.Filter() != "" {
+		t.Errorf("Filter() = %q, want none for raw samples", img.Filter())
+	}
+
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage failed: %v", err)
+	}
+	if err := page.DrawImage(img, 100, 100, 30, 20); err != nil {
+		t.Fatalf("DrawImage failed: %v", err)
+	}
+	data, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	pdf := string(data)
+	start := strings.Index(pdf, "/Subtype /Image")
+	if start < 0 {
+		t.Fatal("image XObject not found")
+	}
+	dict := pdf[start : start+strings.Index(pdf[start:], ">>")]
+	if strings.Contains(dict, "/Filter") {
+		t.Errorf("raw samples should not have a /Filter: %s", dict)
+	}
+}
+
+// TestRegisterImageEncoder_Error tests that encoder failures are reported.
+func TestRegisterImageEncoder_Error(t *testing.T) {
+	errEncode := errors.New("boom")
+	RegisterImageEncoder("test-fail", func(image.Image) ([]byte, string, string, error) {
+		return nil, "", "", errEncode
+	})
+	defer RegisterImageEncoder("test-fail", nil)
+
+	if _, err := ImageFromGo(newTestGoImage(1, 1, color.White), "test-fail"); !errors.Is(err, errEncode) {
+		t.Errorf("expected wrapped encoder error, got %v", err)
+	}
+}
+
+// TestRegisterImageEncoder_InvalidNames tests that unknown filter and
+// color space names are rejected.
+func TestRegisterImageEncoder_InvalidNames(t *testing.T) {
+	tests := []struct {
+		name, filter, colorSpace string
+		ok                       bool
+	}{
+		{"flate", "/FlateDecode", "DeviceRGB", true},
+		{"cmyk jpeg", "DCTDecode", "/DeviceCMYK", true},
+		{"misspelled filter", "FlateDecod", "DeviceRGB", false},
+		{"filter needing parameters", "CCITTFaxDecode", "DeviceGray", false},
+		{"unknown color space", "", "RGB", false},
+		{"indexed", "", "Indexed", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterImageEncoder("test-names", func(image.Image) ([]byte, string, string, error) {
+				return []byte{0}, tt.filter, tt.colorSpace, nil
+			})
+			defer RegisterImageEncoder("test-names", nil)
+
+			_, err := ImageFromGo(newTestGoImage(1, 1, color.White), "test-names")
+			if tt.ok && err != nil {
+				t.Errorf("ImageFromGo() error = %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("expected error for filter %q, color space %q", tt.filter, tt.colorSpace)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// createTwoColorPNG returns a 1-bit indexed PNG with a checkerboard of
+// the two palette colors.
+func createTwoColorPNG(t *testing.T, c0, c1 color.Color) *Image {
+	t.Helper()
+	src := image.NewPaletted(image.Rect(0, 0, 16, 16), color.Palette{c0, c1})
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			src.SetColorIndex(x, y, uint8((x+y)%2))
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	img, err := LoadImageFromReader(&buf)
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+	return img
+}
+
+// bilevelTIFF returns a 1-bit grayscale TIFF image.
+func bilevelTIFF(t *testing.T, photometric int) *Image {
+	t.Helper()
+	page := testTIFFPage{
+		width: 8, height: 2,
+		bitsPerSample: 1, samplesPerPixel: 1,
+		compression: tiffCompressionNone, photometric: photometric,
+		rowsPerStrip: 2,
+		strips:       [][]byte{{0xF0, 0x0F}},
+	}
+	img, err := LoadImageFromReader(bytes.NewReader(buildTIFF(t, page)))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+	return img
+}
+
+func TestDrawImageMask(t *testing.T) {
+	tests := []struct {
+		name   string
+		img    *Image
+		decode string
+	}{
+		{"black on white PNG", createTwoColorPNG(t, color.White, color.Black), "[1 0]"},
+		{"white on black PNG", createTwoColorPNG(t, color.Black, color.White), "[0 1]"},
+		{"BlackIsZero TIFF", bilevelTIFF(t, tiffPhotometricBlackIsZero), "[0 1]"},
+		{"WhiteIsZero TIFF", bilevelTIFF(t, tiffPhotometricWhiteIsZero), "[1 0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, _ := c.NewPage()
+			if err := page.DrawImageMask(tt.img, 100, 500, 64, 64, Red); err != nil {
+				t.Fatalf("DrawImageMask() error = %v", err)
+			}
+
+			gop := convertGraphicsOps(page.GraphicsOperations())[0]
+			if !gop.Image.ImageMask || gop.Image.Decode != tt.decode {
+				t.Errorf("mask = %v with /Decode %q, want a mask with %q", gop.Image.ImageMask, gop.Image.Decode, tt.decode)
+			}
+			content, _, err := writer.GenerateContentStreamWithGraphics(nil, []writer.GraphicsOp{gop})
+			if err != nil {
+				t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+			}
+			if !strings.Contains(string(content), "1 0 0 rg\n64 0 0 64 100 500 cm\n/Im1 Do") {
+				t.Errorf("content stream should set the fill color before drawing the mask:\n%s", content)
+			}
+
+			var buf bytes.Buffer
+			if _, err := c.WriteTo(&buf); err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			pdf := buf.String()
+			start := strings.Index(pdf, "/Subtype /Image")
+			if start < 0 {
+				t.Fatal("PDF has no image XObject")
+			}
+			dict := pdf[start : start+strings.Index(pdf[start:], ">>")]
+			if !strings.Contains(dict, "/ImageMask true") || !strings.Contains(dict, "/Decode "+tt.decode) {
+				t.Errorf("image XObject %q should have /ImageMask true and /Decode %s", dict, tt.decode)
+			}
+			if strings.Contains(dict, "/ColorSpace") || strings.Contains(dict, "/SMask") {
+				t.Errorf("image mask %q should have no /ColorSpace or /SMask", dict)
+			}
+		})
+	}
+}
+
+func TestDrawImageMask_Invalid(t *testing.T) {
+	mask := createTwoColorPNG(t, color.White, color.Black)
+	rgb, err := LoadImageFromReader(bytes.NewReader(createPNGData(t, 4, 4, color.RGBA{R: 10, G: 20, B: 30, A: 255})))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+
+	page := createTestPage(t)
+	if err := page.DrawImageMask(rgb, 0, 0, 10, 10, Black); !errors.Is(err, ErrNotBilevelImage) {
+		t.Errorf("RGB image: error = %v, want ErrNotBilevelImage", err)
+	}
+	if err := page.DrawImageMask(nil, 0, 0, 10, 10, Black); err == nil {
+		t.Error("nil image: expected an error")
+	}
+	if err := page.DrawImageMask(mask, 0, 0, 0, 10, Black); err == nil {
+		t.Error("zero width: expected an error")
+	}
+	if err := page.DrawImageMask(mask, 0, 0, 10, 10, Color{R: 2}); err == nil {
+		t.Error("invalid color: expected an error")
+	}
+	if ops := page.GraphicsOperations(); len(ops) != 0 {
+		t.Errorf("got %d operations after errors, want 0", len(ops))
+	}
+}
@@ -2,12 +2,17 @@ package creator
 
 import (
 	"bytes"
+	"encoding/binary"
 	"image"
 	"image/color"
 	"image/jpeg"
 	"image/png"
+	"math/rand"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 const (
@@ -134,6 +139,49 @@ func verifyImageOperation(t *testing.T, page *Page, img *Image) {
 	}
 }
 
+// TestDrawImageWithOptions_AltText tests that alt text is written to a /Figure structure element.
+func TestDrawImageWithOptions_AltText(t *testing.T) {
+	tmpFile := createTempJPEG(t, 10, 10, color.RGBA{0, 128, 0, 255})
+	defer func() {
+		_ = os.Remove(tmpFile)
+	}()
+	img, err := LoadImage(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadImage failed: %v", err)
+	}
+
+	c := New()
+	c.SetTagged(true)
+	c.SetLanguage("en-US")
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage failed: %v", err)
+	}
+	err = page.DrawImageWithOptions(img, 100, 500, 100, 100, &DrawImageOptions{AltText: "Company logo"})
+	if err != nil {
+		t.Fatalf("DrawImageWithOptions failed: %v", err)
+	}
+
+	data, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	pdf := string(data)
+
+	for _, want := range []string{
+		"/Lang (en-US)",
+		"/MarkInfo << /Marked true >>",
+		"/StructTreeRoot ",
+		"/StructParents 0",
+		"/S /Figure",
+		"/Alt (Company logo)",
+	} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("expected PDF to contain %q", want)
+		}
+	}
+}
+
 // TestDrawImageInvalidDimensions tests validation of image dimensions.
 func TestDrawImageInvalidDimensions(t *testing.T) {
 	// Create test image.
@@ -218,6 +266,115 @@ func TestDrawImageFit(t *testing.T) {
 	}
 }
 
+// TestDrawImageClipped tests that the polygon clip precedes the image Do.
+func TestDrawImageClipped(t *testing.T) {
+	data := createJPEGData(t, 40, 40, color.RGBA{0, 0, 255, 255})
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to load test image: %v", err)
+	}
+
+	page := createTestPage(t)
+	hexagon := []Point{
+		{X: 125, Y: 500}, {X: 175, Y: 500}, {X: 200, Y: 550},
+		{X: 175, Y: 600}, {X: 125, Y: 600}, {X: 100, Y: 550},
+	}
+	rect := Rect{X: 100, Y: 500, Width: 100, Height: 100}
+	if err := page.DrawImageClipped(img, rect, hexagon); err != nil {
+		t.Fatalf("DrawImageClipped failed: %v", err)
+	}
+
+	ops := page.GraphicsOperations()
+	if len(ops) != 3 {
+		t.Fatalf("expected 3 graphics operations, got %d", len(ops))
+	}
+	if ops[0].Type != GraphicsOpBeginClip || ops[1].Type != GraphicsOpImage || ops[2].Type != GraphicsOpEndClip {
+		t.Errorf("unexpected operation sequence: %v, %v, %v", ops[0].Type, ops[1].Type, ops[2].Type)
+	}
+
+	content := renderClipContent(t, page)
+	assertClipBeforeDo(t, content)
+	if !strings.Contains(content, "125 500 m") || !strings.Contains(content, "100 550 l") {
+		t.Errorf("polygon clip path missing from content:\n%s", content)
+	}
+	if strings.Contains(content, " re\n") {
+		t.Errorf("polygon clip should not emit a rectangle:\n%s", content)
+	}
+}
+
+// TestDrawImageClippedCircle tests the Bézier circle clip.
+func TestDrawImageClippedCircle(t *testing.T) {
+	data := createJPEGData(t, 40, 40, color.RGBA{0, 255, 0, 255})
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to load test image: %v", err)
+	}
+
+	page := createTestPage(t)
+	if err := page.DrawImageClippedCircle(img, Rect{X: 100, Y: 500, Width: 64, Height: 64}); err != nil {
+		t.Fatalf("DrawImageClippedCircle failed: %v", err)
+	}
+
+	content := renderClipContent(t, page)
+	assertClipBeforeDo(t, content)
+	if n := strings.Count(content, " c\n"); n != 4 {
+		t.Errorf("expected 4 Bézier curves in clip path, got %d:\n%s", n, content)
+	}
+}
+
+// TestDrawImageClippedInvalid tests argument validation.
+func TestDrawImageClippedInvalid(t *testing.T) {
+	data := createJPEGData(t, 10, 10, color.RGBA{255, 0, 0, 255})
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to load test image: %v", err)
+	}
+
+	page := createTestPage(t)
+	rect := Rect{X: 0, Y: 0, Width: 10, Height: 10}
+	line := []Point{{X: 0, Y: 0}, {X: 10, Y: 10}}
+
+	if err := page.DrawImageClipped(img, rect, line); err == nil {
+		t.Error("expected error for clip with fewer than 3 vertices")
+	}
+	if err := page.DrawImageClipped(nil, rect, append(line, Point{X: 0, Y: 10})); err == nil {
+		t.Error("expected error for nil image")
+	}
+	if err := page.DrawImageClippedCircle(img, Rect{Width: 0, Height: 10}); err == nil {
+		t.Error("expected error for empty rect")
+	}
+	if len(page.GraphicsOperations()) != 0 {
+		t.Errorf("failed calls should not add operations, got %d", len(page.GraphicsOperations()))
+	}
+}
+
+// Helper: renderClipContent renders a page's graphics to a content stream.
+func renderClipContent(t *testing.T, page *Page) string {
+	t.Helper()
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics failed: %v", err)
+	}
+	return string(content)
+}
+
+// Helper: assertClipBeforeDo verifies the clip (W n) precedes the image Do
+// and the clip state is restored afterwards.
+func assertClipBeforeDo(t *testing.T, content string) {
+	t.Helper()
+	clip := strings.Index(content, "W\nn\n")
+	do := strings.Index(content, " Do")
+	if clip < 0 || do < 0 {
+		t.Fatalf("expected both W n and Do in content:\n%s", content)
+	}
+	if clip > do {
+		t.Errorf("clip must precede image Do:\n%s", content)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(content), "Q") {
+		t.Errorf("expected clip state to be restored at end:\n%s", content)
+	}
+}
+
 // TestCalculateFitDimensions tests aspect ratio calculations.
 func TestCalculateFitDimensions(t *testing.T) {
 	tests := []struct {
@@ -428,6 +585,64 @@ func TestLoadPNGGrayscale(t *testing.T) {
 	}
 }
 
+// TestLoadPNGNeutralStoredGray tests that PNGs decoded to RGB but holding
+// only grays are stored with one component per pixel.
+func TestLoadPNGNeutralStoredGray(t *testing.T) {
+	gray16 := image.NewGray16(image.Rect(0, 0, 4, 4))
+	for i := range gray16.Pix {
+		gray16.Pix[i] = byte(i * 7)
+	}
+	grayAlpha := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			v := uint8(x * 60)
+			grayAlpha.SetNRGBA(x, y, color.NRGBA{v, v, v, uint8(y * 80)})
+		}
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		colorSpace ColorSpace
+		components int
+		alpha      bool
+	}{
+		{"16-bit gray", encodePNG(t, gray16), ColorSpaceGray, 1, false},
+		{"gray with alpha", encodePNG(t, grayAlpha), ColorSpaceGray, 1, true},
+		{"RGB holding gray", createPNGData(t, 8, 8, color.RGBA{90, 90, 90, 255}), ColorSpaceGray, 1, false},
+		{"RGB with color", createPNGData(t, 8, 8, color.RGBA{90, 90, 91, 255}), ColorSpaceRGB, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := LoadImageFromReader(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("LoadImageFromReader failed: %v", err)
+			}
+			if img.ColorSpace() != tt.colorSpace {
+				t.Errorf("expected %s color space, got %s", tt.colorSpace, img.ColorSpace())
+			}
+			if img.Components() != tt.components {
+				t.Errorf("expected %d components, got %d", tt.components, img.Components())
+			}
+			if img.HasAlpha() != tt.alpha {
+				t.Errorf("expected HasAlpha %v, got %v", tt.alpha, img.HasAlpha())
+			}
+		})
+	}
+}
+
+// Helper: encodePNG encodes img as PNG.
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
 // TestLoadPNGPaletted tests loading a paletted PNG.
 func TestLoadPNGPaletted(t *testing.T) {
 	// Create paletted PNG.
@@ -445,14 +660,90 @@ func TestLoadPNGPaletted(t *testing.T) {
 		t.Errorf("expected format png, got %s", img.Format())
 	}
 
-	// Paletted PNG should be converted to RGB.
-	if img.ColorSpace() != ColorSpaceRGB {
-		t.Errorf("expected RGB color space (converted), got %s", img.ColorSpace())
+	// Paletted PNG should stay indexed, at its 2-bit depth.
+	if img.ColorSpace() != ColorSpaceIndexed {
+		t.Errorf("expected Indexed color space, got %s", img.ColorSpace())
+	}
+	if img.Components() != 1 || img.BitsPerComponent() != 2 {
+		t.Errorf("expected 1 component of 2 bits, got %d of %d", img.Components(), img.BitsPerComponent())
 	}
+	if want := []byte{255, 0, 0, 0, 255, 0, 0, 0, 255}; !bytes.Equal(img.Palette(), want) {
+		t.Errorf("palette = %v, want %v", img.Palette(), want)
+	}
+	if img.HasAlpha() {
+		t.Error("opaque paletted PNG should have no alpha mask")
+	}
+}
 
-	// Verify components.
-	if img.Components() != 3 {
-		t.Errorf("expected 3 components (converted to RGB), got %d", img.Components())
+// TestLoadPNGIndexed4Bit tests a 4-bit indexed PNG with a tRNS chunk.
+func TestLoadPNGIndexed4Bit(t *testing.T) {
+	palette := make(color.Palette, 10)
+	for i := range palette {
+		palette[i] = color.NRGBA{R: uint8(i * 20), G: 100, B: uint8(255 - i*20), A: 255}
+	}
+	palette[9] = color.NRGBA{A: 0} // Transparent (tRNS)
+
+	src := image.NewPaletted(image.Rect(0, 0, 3, 2), palette)
+	//nolint:gosec // G115: indices are below 10.
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i * 3 % 10) // 0 3 6 / 9 2 5
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	if depth := buf.Bytes()[24]; depth != 4 {
+		t.Fatalf("fixture bit depth = %d, want 4", depth)
+	}
+
+	img, err := LoadImageFromReader(&buf)
+	if err != nil {
+		t.Fatalf("LoadImageFromReader failed: %v", err)
+	}
+	if img.ColorSpace() != ColorSpaceIndexed || img.BitsPerComponent() != 4 {
+		t.Fatalf("got %s with %d bits, want Indexed with 4 bits", img.ColorSpace(), img.BitsPerComponent())
+	}
+
+	wantPalette := []byte{
+		0, 100, 255, 20, 100, 235, 40, 100, 215, 60, 100, 195, 80, 100, 175,
+		100, 100, 155, 120, 100, 135, 140, 100, 115, 160, 100, 95, 0, 0, 0,
+	}
+	if !bytes.Equal(img.Palette(), wantPalette) {
+		t.Errorf("palette = %v, want %v", img.Palette(), wantPalette)
+	}
+
+	// Two pixels per byte, rows padded to whole bytes.
+	samples := img.Data()
+	if img.IsCompressed() {
+		samples = decompressFlate(t, samples)
+	}
+	if want := []byte{0x03, 0x60, 0x92, 0x50}; !bytes.Equal(samples, want) {
+		t.Errorf("samples = % X, want % X", samples, want)
+	}
+
+	alpha := img.AlphaMask()
+	if !img.alphaRaw {
+		alpha = decompressFlate(t, alpha)
+	}
+	if want := []byte{255, 255, 255, 0, 255, 255}; !bytes.Equal(alpha, want) {
+		t.Errorf("alpha mask = %v, want %v", alpha, want)
+	}
+
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawImage(img, 100, 500, 30, 20); err != nil {
+		t.Fatalf("DrawImage() error = %v", err)
+	}
+	var out bytes.Buffer
+	if _, err := c.WriteTo(&out); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	want := "/Width 3 /Height 2 /ColorSpace [/Indexed /DeviceRGB 9 <0064FF1464EB2864D73C64C35064AF64649B7864878C6473A0645F000000>] /BitsPerComponent 4"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("image XObject should contain %q", want)
+	}
+	if !strings.Contains(out.String(), "/SMask") {
+		t.Error("image XObject should have an /SMask for the tRNS entry")
 	}
 }
 
@@ -535,6 +826,55 @@ func createPNGData(t *testing.T, width, height int, c color.Color) []byte {
 	return buf.Bytes()
 }
 
+// TestLoadPNGHighEntropyStoredRaw tests that incompressible samples are not Flate-encoded.
+func TestLoadPNGHighEntropyStoredRaw(t *testing.T) {
+	const size = 64
+
+	// Fill the image with random noise (compression cannot shrink it).
+	rng := rand.New(rand.NewSource(1))
+	src := image.NewRGBA(image.Rect(0, 0, size, size))
+	for i := range src.Pix {
+		src.Pix[i] = byte(rng.Intn(256))
+	}
+	for i := 3; i < len(src.Pix); i += 4 {
+		src.Pix[i] = 255
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+
+	img, err := LoadImageFromReader(&buf)
+	if err != nil {
+		t.Fatalf("LoadImageFromReader failed: %v", err)
+	}
+
+	if img.IsCompressed() {
+		t.Error("expected high-entropy image to be stored uncompressed")
+	}
+	if len(img.Data()) != size*size*3 {
+		t.Errorf("expected %d raw bytes, got %d", size*size*3, len(img.Data()))
+	}
+}
+
+// TestLoadPNGUniformCompressed tests that compressible samples are Flate-encoded.
+func TestLoadPNGUniformCompressed(t *testing.T) {
+	data := createPNGData(t, 64, 64, color.RGBA{10, 20, 30, 255})
+
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader failed: %v", err)
+	}
+
+	if !img.IsCompressed() {
+		t.Error("expected uniform image to be compressed")
+	}
+	if len(img.Data()) >= 64*64*3 {
+		t.Errorf("expected compressed data smaller than raw, got %d bytes", len(img.Data()))
+	}
+}
+
 // Helper: createTempPNG creates a temporary PNG file.
 func createTempPNG(t *testing.T, width, height int, c color.Color) string {
 	t.Helper()
@@ -615,3 +955,139 @@ func createPalettedPNGData(t *testing.T, width, height int) []byte {
 
 	return buf.Bytes()
 }
+
+// createCMYKJPEGData builds an 8x8 four-component baseline JPEG whose
+// blocks are all zero. adobeTransform < 0 leaves out the Adobe APP14
+// segment.
+func createCMYKJPEGData(t *testing.T, adobeTransform int) []byte {
+	t.Helper()
+
+	segment := func(data []byte, marker byte, payload ...byte) []byte {
+		data = append(data, 0xFF, marker)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(payload)+2))
+		return append(data, payload...)
+	}
+	// Huffman table with a single 1-bit code for symbol 0.
+	huffman := func(class byte) []byte {
+		table := append([]byte{class << 4, 1}, make([]byte, 15)...)
+		return append(table, 0)
+	}
+
+	data := []byte{0xFF, 0xD8}
+	if adobeTransform >= 0 {
+		data = segment(data, 0xEE, 'A', 'd', 'o', 'b', 'e', 0, 100, 0, 0, 0, 0, byte(adobeTransform))
+	}
+	data = segment(data, 0xDB, append([]byte{0}, bytes.Repeat([]byte{1}, 64)...)...)
+	data = segment(data, 0xC0, 8, 0, 8, 0, 8, 4, 1, 0x11, 0, 2, 0x11, 0, 3, 0x11, 0, 4, 0x11, 0)
+	data = segment(data, 0xC4, huffman(0)...)
+	data = segment(data, 0xC4, huffman(1)...)
+	data = segment(data, 0xDA, 4, 1, 0, 2, 0, 3, 0, 4, 0, 0, 63, 0)
+	data = append(data, 0x00) // Four blocks of DC 0 + EOB, two bits each
+	data = append(data, 0xFF, 0xD9)
+
+	// The standard decoder only decodes 4-component JPEGs with APP14.
+	if _, err := jpeg.Decode(bytes.NewReader(data)); adobeTransform >= 0 && err != nil {
+		t.Fatalf("invalid CMYK JPEG fixture: %v", err)
+	}
+	return data
+}
+
+// TestLoadJPEG_ColorSpaces tests that JPEG components map to the right
+// color space and that Adobe CMYK is inverted with /Decode.
+func TestLoadJPEG_ColorSpaces(t *testing.T) {
+	var gray bytes.Buffer
+	if err := jpeg.Encode(&gray, image.NewGray(image.Rect(0, 0, 8, 8)), nil); err != nil {
+		t.Fatalf("failed to encode JPEG: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		colorSpace ColorSpace
+		components int
+		decode     string
+	}{
+		{"RGB", createJPEGData(t, 8, 8, color.White), ColorSpaceRGB, 3, ""},
+		{"grayscale", gray.Bytes(), ColorSpaceGray, 1, ""},
+		{"CMYK", createCMYKJPEGData(t, -1), ColorSpaceCMYK, 4, ""},
+		{"Adobe CMYK", createCMYKJPEGData(t, 0), ColorSpaceCMYK, 4, "[1 0 1 0 1 0 1 0]"},
+		{"Adobe YCCK", createCMYKJPEGData(t, 2), ColorSpaceCMYK, 4, "[1 0 1 0 1 0 1 0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := LoadImageFromReader(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("LoadImageFromReader() error = %v", err)
+			}
+			if img.ColorSpace() != tt.colorSpace || img.Components() != tt.components {
+				t.Errorf("color space = %s/%d, want %s/%d", img.ColorSpace(), img.Components(), tt.colorSpace, tt.components)
+			}
+			if img.decode != tt.decode {
+				t.Errorf("decode = %q, want %q", img.decode, tt.decode)
+			}
+			if !bytes.Equal(img.Data(), tt.data) {
+				t.Error("JPEG data should be embedded unchanged")
+			}
+		})
+	}
+}
+
+// TestLoadJPEG_MalformedSegment tests that header scanning does not read
+// past a segment whose length is invalid.
+func TestLoadJPEG_MalformedSegment(t *testing.T) {
+	valid := createJPEGData(t, 8, 8, color.White)
+	tests := []struct {
+		name    string
+		header  []byte // Inserted after SOI
+		decodes bool   // Whether image/jpeg accepts the result
+	}{
+		{"RST marker then junk", []byte{0xFF, 0xD0, 0x00, 0x01}, true},
+		{"length below two", []byte{0xFF, 0xE1, 0x00, 0x01}, false},
+		{"length past end of data", []byte{0xFF, 0xE1, 0xFF, 0xFF}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := append(append(append([]byte{}, valid[:2]...), tt.header...), valid[2:]...)
+			img, err := LoadImageFromReader(bytes.NewReader(data))
+			if !tt.decodes {
+				return // Only checking that it does not panic.
+			}
+			if err != nil {
+				t.Fatalf("LoadImageFromReader() error = %v", err)
+			}
+			if img.Width() != 8 || img.Height() != 8 {
+				t.Errorf("size = %dx%d, want 8x8", img.Width(), img.Height())
+			}
+		})
+	}
+}
+
+// TestCMYKJPEG_XObject tests the image XObject written for an Adobe CMYK JPEG.
+func TestCMYKJPEG_XObject(t *testing.T) {
+	data := createCMYKJPEGData(t, 2)
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawImage(img, 100, 500, 80, 80); err != nil {
+		t.Fatalf("DrawImage() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+
+	pdf := buf.String()
+	want := "/Width 8 /Height 8 /ColorSpace /DeviceCMYK /BitsPerComponent 8 /Filter /DCTDecode /Decode [1 0 1 0 1 0 1 0]"
+	if !strings.Contains(pdf, want) {
+		t.Errorf("image XObject should contain %q", want)
+	}
+	if !bytes.Contains(buf.Bytes(), data) {
+		t.Error("image stream should hold the original JPEG data")
+	}
+}
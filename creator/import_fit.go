@@ -0,0 +1,75 @@
+package creator
+
+import "math"
+
+// FitMode controls how an imported page is scaled onto an output page of
+// a different size.
+type FitMode int
+
+const (
+	// FitNone places the source page unscaled at the lower-left corner
+	// of the output page.
+	FitNone FitMode = iota
+
+	// ShrinkToFit scales oversized source pages down to fit the output
+	// page, preserving the aspect ratio, and centers them. Pages that
+	// already fit are centered at their original size.
+	ShrinkToFit
+
+	// FitToPage scales every source page up or down to fill as much of
+	// the output page as the aspect ratio allows, and centers it.
+	FitToPage
+)
+
+// ImportOptions configures how source pages are placed when importing or
+// merging them into a document.
+//
+// Example:
+//
+//	size := creator.A4
+//	opts := &creator.MergeOptions{
+//	    Import: creator.ImportOptions{PageSize: &size, FitMode: creator.ShrinkToFit},
+//	}
+type ImportOptions struct {
+	// PageSize is the uniform output page size.
+	// Default: nil (each output page keeps its source page's size).
+	PageSize *PageSize
+
+	// FitMode selects how source pages are scaled to PageSize.
+	// Default: FitNone.
+	FitMode FitMode
+}
+
+// FitTransform returns the transformation that maps a source page of
+// srcWidth × srcHeight points onto an output page of dstWidth × dstHeight
+// points according to the fit mode.
+//
+// The result is a uniform scale followed by a translation, suitable for a
+// form XObject /Matrix or a cm operator wrapped around the source content.
+//
+// Example:
+//
+//	opts := creator.ImportOptions{FitMode: creator.ShrinkToFit}
+//	t := opts.FitTransform(842, 1191, 595, 842) // A3 onto A4
+func (o ImportOptions) FitTransform(srcWidth, srcHeight, dstWidth, dstHeight float64) Transform {
+	if srcWidth <= 0 || srcHeight <= 0 {
+		return Identity()
+	}
+
+	var scale float64
+	switch o.FitMode {
+	case ShrinkToFit:
+		scale = math.Min(1, math.Min(dstWidth/srcWidth, dstHeight/srcHeight))
+	case FitToPage:
+		scale = math.Min(dstWidth/srcWidth, dstHeight/srcHeight)
+	default:
+		return Identity()
+	}
+
+	return Transform{
+		A: scale, B: 0,
+		C: 0, D: scale,
+		E: (dstWidth - srcWidth*scale) / 2,
+		F: (dstHeight - srcHeight*scale) / 2,
+	}
+}
@@ -0,0 +1,80 @@
+package creator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestImportOptions_FitTransform(t *testing.T) {
+	const a4W, a4H = 595.0, 842.0
+
+	tests := []struct {
+		name     string
+		mode     FitMode
+		srcW     float64
+		srcH     float64
+		want     Transform
+		wantSame bool
+	}{
+		{
+			name:     "none keeps identity",
+			mode:     FitNone,
+			srcW:     842,
+			srcH:     1191,
+			wantSame: true,
+		},
+		{
+			name: "shrink A3 onto A4",
+			mode: ShrinkToFit,
+			srcW: 842,
+			srcH: 1191,
+			want: Transform{A: 595.0 / 842, D: 595.0 / 842, E: 0, F: (a4H - 1191*595.0/842) / 2},
+		},
+		{
+			name: "shrink leaves small page unscaled and centered",
+			mode: ShrinkToFit,
+			srcW: 420,
+			srcH: 595,
+			want: Transform{A: 1, D: 1, E: (a4W - 420) / 2, F: (a4H - 595) / 2},
+		},
+		{
+			name: "fit to page enlarges small page",
+			mode: FitToPage,
+			srcW: 200,
+			srcH: 200,
+			want: Transform{A: a4W / 200, D: a4W / 200, E: 0, F: (a4H - a4W) / 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ImportOptions{FitMode: tt.mode}.FitTransform(tt.srcW, tt.srcH, a4W, a4H)
+			want := tt.want
+			if tt.wantSame {
+				want = Identity()
+			}
+			for i, pair := range [][2]float64{
+				{got.A, want.A}, {got.B, want.B}, {got.C, want.C},
+				{got.D, want.D}, {got.E, want.E}, {got.F, want.F},
+			} {
+				if math.Abs(pair[0]-pair[1]) > 1e-9 {
+					t.Errorf("component %d = %v, want %v (got %+v)", i, pair[0], pair[1], got)
+				}
+			}
+		})
+	}
+}
+
+func TestImportOptions_FitTransformFitsInside(t *testing.T) {
+	opts := ImportOptions{FitMode: ShrinkToFit}
+	tr := opts.FitTransform(1224, 792, 595, 842) // Landscape tabloid onto A4
+
+	llx, lly := tr.TransformPoint(0, 0)
+	urx, ury := tr.TransformPoint(1224, 792)
+	if llx < -1e-9 || lly < -1e-9 || urx > 595+1e-9 || ury > 842+1e-9 {
+		t.Errorf("fitted page [%v %v %v %v] exceeds A4", llx, lly, urx, ury)
+	}
+	if math.Abs((lly+ury)/2-421) > 1e-9 {
+		t.Errorf("fitted page not vertically centered: %v..%v", lly, ury)
+	}
+}
@@ -0,0 +1,159 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// keepTogetherEpsilon absorbs floating-point error when comparing a block
+// height with the remaining space.
+const keepTogetherEpsilon = 1e-6
+
+// KeepTogether draws a block of flow content that must not split across pages.
+//
+// Typical blocks are a figure with its caption or a small table. fn draws
+// the block using the page's layout cursor: while fn runs, the flow
+// cursor is enabled (see SetFlowCursor), so consecutive Draw calls stack.
+// KeepTogether first runs fn on a scratch copy of the page to measure how
+// far the block advances the cursor. If the block fits between the cursor
+// and the bottom margin, fn is replayed on this page. Otherwise a page
+// break is made: a new page is added and fn is replayed at the top of its
+// content area.
+//
+// A block taller than a whole page is not moved when the cursor is already
+// at the top of the content area, since a new page would not help.
+//
+// Blocks may be nested: a KeepTogether call inside fn that needs a page
+// break makes the enclosing block start on a new page too, unless it
+// already starts at the top of one.
+//
+// Because fn runs twice, it should only draw on the page it receives and
+// have no other side effects.
+//
+// Returns the page the block ended on, with the cursor below the block.
+// Subsequent flow content should continue on that page. Pages added by
+// KeepTogether have the flow cursor setting of p.
+//
+// Example:
+//
+//	page, err = page.KeepTogether(func(p *creator.Page) error {
+//	    if err := p.Draw(figure); err != nil {
+//	        return err
+//	    }
+//	    return p.Draw(caption)
+//	})
+func (p *Page) KeepTogether(fn func(p *Page) error) (*Page, error) {
+	if fn == nil {
+		return nil, errors.New("keep-together block function cannot be nil")
+	}
+
+	height, err := p.measureBlock(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := p.GetLayoutContext()
+	target := p
+	if height > ctx.AvailableHeight()+keepTogetherEpsilon && ctx.CursorY > 0 {
+		if p.nextPage == nil {
+			return nil, ErrNoNextPage
+		}
+		target, err = p.nextPage()
+		if err != nil {
+			return nil, fmt.Errorf("keep-together page break: %w", err)
+		}
+		target.flowCursor = p.flowCursor
+	}
+
+	// Follow the pages that nested blocks break onto.
+	last := target
+	var restore []func()
+	var track func(page *Page)
+	track = func(page *Page) {
+		next := page.nextPage
+		if next == nil {
+			return
+		}
+		restore = append(restore, func() { page.nextPage = next })
+		page.nextPage = func() (*Page, error) {
+			added, err := next()
+			if err == nil {
+				added.flowCursor = p.flowCursor
+				last = added
+				track(added)
+			}
+			return added, err
+		}
+	}
+	track(target)
+
+	err = withFlowCursor(target, fn)
+	for _, r := range restore {
+		r()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return last, nil
+}
+
+// withFlowCursor runs fn on page with the flow cursor enabled.
+func withFlowCursor(page *Page, fn func(p *Page) error) error {
+	flow := page.flowCursor
+	page.flowCursor = true
+	defer func() { page.flowCursor = flow }()
+	return fn(page)
+}
+
+// measureBlock runs fn on a scratch page with the same geometry and cursor
+// and returns how far it advanced the flow cursor. If fn breaks onto
+// another page (a nested KeepTogether), the block cannot fit on one page
+// and the height is infinite.
+func (p *Page) measureBlock(fn func(p *Page) error) (float64, error) {
+	scratch, err := p.scratchPage()
+	if err != nil {
+		return 0, err
+	}
+	scratch.cursorX, scratch.cursorY, scratch.cursorSet = p.cursorX, p.cursorY, p.cursorSet
+
+	broke := false
+	var nextScratch func() (*Page, error)
+	nextScratch = func() (*Page, error) {
+		broke = true
+		page, err := p.scratchPage()
+		if err != nil {
+			return nil, err
+		}
+		page.nextPage = nextScratch
+		return page, nil
+	}
+	scratch.nextPage = nextScratch
+
+	if err := fn(scratch); err != nil {
+		return 0, err
+	}
+	if broke {
+		return math.Inf(1), nil
+	}
+
+	_, startY := p.Cursor()
+	_, endY := scratch.Cursor()
+	return endY - startY, nil
+}
+
+// scratchPage returns an empty page with the geometry of p and the flow
+// cursor enabled, for measuring.
+func (p *Page) scratchPage() (*Page, error) {
+	domainPage := document.NewPageWithMediaBox(p.page.Number(), p.page.MediaBox())
+	if err := domainPage.SetRotation(p.Rotation()); err != nil {
+		return nil, err
+	}
+	return &Page{page: domainPage, margins: p.margins, flowCursor: true, normalization: p.normalization}, nil
+}
+
+// ErrNoNextPage is returned by KeepTogether when a page break is needed
+// but the page was not created by a Creator or Appender.
+var ErrNoNextPage = errors.New("page cannot add a following page")
@@ -0,0 +1,183 @@
+package creator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drawBlock returns a keep-together block of n paragraph lines.
+func drawBlock(n int) func(p *Page) error {
+	return func(p *Page) error {
+		for i := 0; i < n; i++ {
+			if err := p.Draw(NewParagraph("Line")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestPage_Draw_Cursor(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// By default every Draw starts at the cursor.
+	require.NoError(t, page.Draw(NewParagraph("First")))
+	require.NoError(t, page.Draw(NewParagraph("Second")))
+	_, y := page.Cursor()
+	assert.Equal(t, 0.0, y)
+	require.Len(t, page.textOps, 2)
+	assert.Equal(t, page.textOps[0].Y, page.textOps[1].Y)
+}
+
+func TestPage_Draw_FlowCursor(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	page.SetFlowCursor(true)
+
+	require.NoError(t, page.Draw(NewParagraph("First")))
+	_, y1 := page.Cursor()
+	require.NoError(t, page.Draw(NewParagraph("Second")))
+	_, y2 := page.Cursor()
+
+	assert.Greater(t, y1, 0.0)
+	assert.InDelta(t, 2*y1, y2, 1e-9, "second paragraph stacks below the first")
+	require.Len(t, page.textOps, 2)
+	assert.Greater(t, page.textOps[0].Y, page.textOps[1].Y)
+}
+
+func TestPage_KeepTogether_Fits(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	got, err := page.KeepTogether(drawBlock(3))
+	require.NoError(t, err)
+
+	assert.Same(t, page, got)
+	assert.Equal(t, 1, c.PageCount())
+	assert.Len(t, page.textOps, 3, "block is drawn once, not once per pass")
+
+	_, y := page.Cursor()
+	assert.Greater(t, y, 0.0)
+}
+
+func TestPage_KeepTogether_BreaksPage(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Leave room for about two lines.
+	lineHeight := NewParagraph("Line").Height(page.GetLayoutContext())
+	page.MoveCursor(page.Margins().Left, page.ContentHeight()-2.5*lineHeight)
+
+	got, err := page.KeepTogether(drawBlock(5))
+	require.NoError(t, err)
+
+	assert.NotSame(t, page, got)
+	assert.Equal(t, 2, c.PageCount())
+	assert.Empty(t, page.textOps, "nothing is split onto the first page")
+	assert.Len(t, got.textOps, 5)
+
+	_, y := got.Cursor()
+	assert.InDelta(t, 5*lineHeight, y, 1e-9, "block starts at the top of the new page")
+}
+
+func TestPage_KeepTogether_TallBlockAtTop(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	lineHeight := NewParagraph("Line").Height(page.GetLayoutContext())
+	lines := int(page.ContentHeight()/lineHeight) + 5
+
+	got, err := page.KeepTogether(drawBlock(lines))
+	require.NoError(t, err)
+
+	assert.Same(t, page, got, "a new page would not help")
+	assert.Equal(t, 1, c.PageCount())
+}
+
+func TestPage_KeepTogether_Nested(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Leave room for about four lines.
+	lineHeight := NewParagraph("Line").Height(page.GetLayoutContext())
+	page.MoveCursor(page.Margins().Left, page.ContentHeight()-4.5*lineHeight)
+
+	// The outer block (2 + 3 lines) does not fit, but measuring it must
+	// not fail when the inner block needs a page break of its own.
+	outer := func(p *Page) error {
+		if err := drawBlock(2)(p); err != nil {
+			return err
+		}
+		_, err := p.KeepTogether(drawBlock(3))
+		return err
+	}
+	got, err := page.KeepTogether(outer)
+	require.NoError(t, err)
+
+	assert.NotSame(t, page, got)
+	assert.Equal(t, 2, c.PageCount())
+	assert.Empty(t, page.textOps, "nothing is split onto the first page")
+	assert.Len(t, got.textOps, 5)
+	_, y := got.Cursor()
+	assert.InDelta(t, 5*lineHeight, y, 1e-9)
+	assert.False(t, got.flowCursor, "the new page keeps the default Draw behavior")
+}
+
+func TestPage_KeepTogether_NestedBreak(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// At the top of the page, a block taller than the page stays; its
+	// inner block breaks onto a new page, which is returned.
+	lineHeight := NewParagraph("Line").Height(page.GetLayoutContext())
+	lines := int(page.ContentHeight()/lineHeight) - 1
+	got, err := page.KeepTogether(func(p *Page) error {
+		if err := drawBlock(lines)(p); err != nil {
+			return err
+		}
+		_, err := p.KeepTogether(drawBlock(3))
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, c.PageCount())
+	assert.Same(t, c.pages[1], got, "flow continues on the page the inner block broke onto")
+	assert.Len(t, page.textOps, lines)
+	assert.Len(t, got.textOps, 3)
+	_, y := got.Cursor()
+	assert.InDelta(t, 3*lineHeight, y, 1e-9)
+}
+
+func TestPage_KeepTogether_NoNextPage(t *testing.T) {
+	page := &Page{page: document.NewPage(0, document.A4), margins: Margins{72, 72, 72, 72}}
+	page.MoveCursor(72, page.ContentHeight()-1)
+
+	_, err := page.KeepTogether(drawBlock(2))
+	assert.ErrorIs(t, err, ErrNoNextPage)
+}
+
+func TestPage_KeepTogether_Errors(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	_, err = page.KeepTogether(nil)
+	assert.Error(t, err)
+
+	errBlock := errors.New("block failed")
+	_, err = page.KeepTogether(func(*Page) error { return errBlock })
+	assert.ErrorIs(t, err, errBlock)
+	assert.Empty(t, page.textOps)
+}
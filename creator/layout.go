@@ -43,6 +43,10 @@ type LayoutContext struct {
 	// 0 = top of content area (below top margin)
 	// Increases downward.
 	CursorY float64
+
+	// normalization is applied to text before it is wrapped (see
+	// Creator.SetNormalization).
+	normalization Normalization
 }
 
 // Drawable is an interface for elements that can be drawn on a page.
@@ -0,0 +1,61 @@
+package creator
+
+import (
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// Limits caps the resources a document may use.
+//
+// Limits protect services that build documents from untrusted input
+// against runaway generation. A zero field means no limit.
+//
+// Example:
+//
+//	c.SetLimits(creator.Limits{
+//	    MaxPages:   500,
+//	    MaxObjects: 100_000,
+//	    MaxBytes:   50 << 20, // 50 MB
+//	})
+type Limits struct {
+	// MaxObjects is the maximum number of indirect objects in the output.
+	MaxObjects int64
+
+	// MaxBytes is the maximum size of the output in bytes. The output is
+	// checked before each object is written, so a failed write may have
+	// produced up to one object more.
+	MaxBytes int64
+
+	// MaxPages is the maximum number of pages. NewPage and
+	// NewPageWithSize fail once it is reached.
+	MaxPages int64
+}
+
+// ErrLimitExceeded is returned when adding a page or writing the document
+// would exceed a limit set with SetLimits.
+var ErrLimitExceeded = writer.ErrLimitExceeded
+
+// SetLimits sets resource limits enforced while building and writing the
+// document. Write methods fail with ErrLimitExceeded if the output would
+// exceed MaxObjects or MaxBytes.
+//
+// Example:
+//
+//	c.SetLimits(creator.Limits{MaxPages: 100})
+//	for i := 0; i < n; i++ {
+//	    if _, err := c.NewPage(); errors.Is(err, creator.ErrLimitExceeded) {
+//	        return err
+//	    }
+//	}
+func (c *Creator) SetLimits(limits Limits) {
+	c.limits = limits
+}
+
+// checkPageLimit returns an error if adding a page would exceed MaxPages.
+func (c *Creator) checkPageLimit() error {
+	if c.limits.MaxPages > 0 && int64(c.doc.PageCount()) >= c.limits.MaxPages {
+		return fmt.Errorf("%w: more than %d pages", ErrLimitExceeded, c.limits.MaxPages)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package creator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreator_SetLimits_MaxPages(t *testing.T) {
+	c := New()
+	c.SetLimits(Limits{MaxPages: 2})
+
+	_, err := c.NewPage()
+	require.NoError(t, err)
+	_, err = c.NewPageWithSize(Letter)
+	require.NoError(t, err)
+
+	_, err = c.NewPage()
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	_, err = c.NewPageWithSize(Letter)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, 2, c.PageCount())
+}
+
+func TestCreator_SetLimits_MaxObjects(t *testing.T) {
+	c := New()
+	for i := 0; i < 5; i++ {
+		page, err := c.NewPage()
+		require.NoError(t, err)
+		require.NoError(t, page.AddText("Hello", 100, 700, Helvetica, 12))
+	}
+
+	c.SetLimits(Limits{MaxObjects: 8})
+	_, err := c.Bytes()
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	c.SetLimits(Limits{MaxObjects: 1000})
+	_, err = c.Bytes()
+	assert.NoError(t, err)
+}
+
+func TestCreator_SetLimits_MaxBytes(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	for i := 0; i < 200; i++ {
+		require.NoError(t, page.AddText("Lorem ipsum dolor sit amet", 72, float64(100+i*3), Helvetica, 10))
+	}
+
+	c.SetLimits(Limits{MaxBytes: 512})
+	var buf bytes.Buffer
+	_, err = c.WriteTo(&buf)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	c.SetLimits(Limits{})
+	_, err = c.Bytes()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,145 @@
+package creator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// lineStyleShapes draws a stroked shape with the given cap, join and miter
+// limit, one entry per option type that supports line styles.
+var lineStyleShapes = []struct {
+	name string
+	draw func(p *Page, lineCap LineCap, lineJoin LineJoin, miterLimit float64) error
+}{
+	{"line", func(p *Page, lineCap LineCap, lineJoin LineJoin, miterLimit float64) error {
+		return p.DrawLine(100, 100, 200, 200, &LineOptions{
+			Color: Black, Width: 4, LineCap: lineCap, LineJoin: lineJoin, MiterLimit: miterLimit,
+		})
+	}},
+	{"polyline", func(p *Page, lineCap LineCap, lineJoin LineJoin, miterLimit float64) error {
+		return p.DrawPolyline([]Point{{100, 100}, {150, 200}, {200, 100}}, &PolylineOptions{
+			Color: Black, Width: 4, LineCap: lineCap, LineJoin: lineJoin, MiterLimit: miterLimit,
+		})
+	}},
+	{"bezier", func(p *Page, lineCap LineCap, lineJoin LineJoin, miterLimit float64) error {
+		segs := []BezierSegment{{Start: Point{100, 100}, C1: Point{120, 200}, C2: Point{180, 200}, End: Point{200, 100}}}
+		return p.DrawBezierCurve(segs, &BezierOptions{
+			Color: Black, Width: 4, LineCap: lineCap, LineJoin: lineJoin, MiterLimit: miterLimit,
+		})
+	}},
+}
+
+// graphicsContent returns the content stream of the page's graphics.
+func graphicsContent(t *testing.T, page *Page) string {
+	t.Helper()
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	return string(content)
+}
+
+// TestLineStyleOperators tests the J, j and M operators for every cap and join.
+func TestLineStyleOperators(t *testing.T) {
+	caps := []struct {
+		cap  LineCap
+		want string // Empty = no J operator
+	}{
+		{LineCapButt, ""},
+		{LineCapRound, "1 J\n"},
+		{LineCapSquare, "2 J\n"},
+	}
+	joins := []struct {
+		join       LineJoin
+		miterLimit float64
+		want       []string
+		notWant    []string
+	}{
+		{LineJoinMiter, 0, nil, []string{" j\n", " M\n"}},
+		{LineJoinMiter, 4, []string{"4 M\n"}, []string{" j\n"}},
+		{LineJoinRound, 0, []string{"1 j\n"}, []string{" M\n"}},
+		{LineJoinRound, 4, []string{"1 j\n"}, []string{" M\n"}}, // Limit only applies to miters
+		{LineJoinBevel, 0, []string{"2 j\n"}, []string{" M\n"}},
+	}
+
+	for _, shape := range lineStyleShapes {
+		for _, c := range caps {
+			for _, j := range joins {
+				name := shape.name + "/" + c.cap.String() + "/" + j.join.String()
+				if j.miterLimit > 0 {
+					name += "/limit"
+				}
+				t.Run(name, func(t *testing.T) {
+					page, _ := New().NewPage()
+					if err := shape.draw(page, c.cap, j.join, j.miterLimit); err != nil {
+						t.Fatalf("draw error = %v", err)
+					}
+					content := graphicsContent(t, page)
+
+					if c.want != "" && !strings.Contains(content, c.want) {
+						t.Errorf("expected %q in content:\n%s", c.want, content)
+					}
+					if c.want == "" && strings.Contains(content, " J\n") {
+						t.Errorf("butt cap should not write J:\n%s", content)
+					}
+					for _, want := range j.want {
+						if !strings.Contains(content, want) {
+							t.Errorf("expected %q in content:\n%s", want, content)
+						}
+					}
+					for _, notWant := range j.notWant {
+						if strings.Contains(content, notWant) {
+							t.Errorf("unexpected %q in content:\n%s", notWant, content)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestLineStyleDefaultsUnchanged tests that default options write no line style operators.
+func TestLineStyleDefaultsUnchanged(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawLine(100, 100, 200, 200, &LineOptions{Color: Black, Width: 1}); err != nil {
+		t.Fatalf("DrawLine() error = %v", err)
+	}
+
+	want := "q\n1 w\n0 G\n100 100 m\n200 200 l\nS\nQ\n"
+	if got := graphicsContent(t, page); got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+}
+
+// TestLineStyleValidation tests that invalid line styles are rejected.
+func TestLineStyleValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		lineCap    LineCap
+		lineJoin   LineJoin
+		miterLimit float64
+		errorMsg   string
+	}{
+		{"invalid cap", LineCap(3), LineJoinMiter, 0, "invalid line cap: 3"},
+		{"invalid join", LineCapButt, LineJoin(-1), 0, "invalid line join: -1"},
+		{"miter limit below 1", LineCapButt, LineJoinMiter, 0.5, "miter limit must be >= 1.0, got: 0.500000"},
+	}
+
+	for _, shape := range lineStyleShapes {
+		for _, tt := range tests {
+			t.Run(shape.name+"/"+tt.name, func(t *testing.T) {
+				page, _ := New().NewPage()
+				err := shape.draw(page, tt.lineCap, tt.lineJoin, tt.miterLimit)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if err.Error() != tt.errorMsg {
+					t.Errorf("expected error %q, got %q", tt.errorMsg, err.Error())
+				}
+			})
+		}
+	}
+}
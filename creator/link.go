@@ -1,5 +1,7 @@
 package creator
 
+import "github.com/coregx/gxpdf/internal/document"
+
 // LinkStyle defines the visual style for a link.
 //
 // This controls how clickable links appear in the PDF.
@@ -17,6 +19,12 @@ package creator
 //	    Color:     Red,
 //	    Underline: false,
 //	}
+//
+//	// Dashed border drawn by the viewer around the clickable area
+//	boxed := DefaultLinkStyle()
+//	boxed.Underline = false
+//	boxed.BorderStyle = LinkBorderDashed
+//	boxed.DashArray = []float64{3, 2}
 type LinkStyle struct {
 	// Font to use for the link text.
 	Font FontName
@@ -30,6 +38,81 @@ type LinkStyle struct {
 	// Underline indicates whether to draw a line under the link text.
 	// Default: true (like web browsers).
 	Underline bool
+
+	// BorderStyle is the style of the border viewers draw around the
+	// clickable area. Default: LinkBorderNone (no visible border).
+	BorderStyle LinkBorderStyle
+
+	// BorderWidth is the border width in points (default: 1 when a
+	// BorderStyle is set).
+	BorderWidth float64
+
+	// BorderColor is the border color (nil = viewer default, usually black).
+	BorderColor *Color
+
+	// DashArray is the dash pattern for LinkBorderDashed, in points
+	// (e.g., []float64{3, 2}). Default: [3].
+	DashArray []float64
+}
+
+// LinkBorderStyle is the border style of a link's clickable area.
+//
+// Unlike LinkStyle.Underline, which draws a line in the page content,
+// link borders are drawn by the viewer as part of the annotation.
+type LinkBorderStyle int
+
+const (
+	// LinkBorderNone draws no border (default).
+	LinkBorderNone LinkBorderStyle = iota
+
+	// LinkBorderSolid draws a solid rectangle around the clickable area.
+	LinkBorderSolid
+
+	// LinkBorderDashed draws a dashed rectangle (see LinkStyle.DashArray).
+	LinkBorderDashed
+
+	// LinkBorderBeveled draws an embossed rectangle.
+	LinkBorderBeveled
+
+	// LinkBorderInset draws an engraved rectangle.
+	LinkBorderInset
+
+	// LinkBorderUnderline draws a single line along the bottom of the
+	// clickable area.
+	LinkBorderUnderline
+)
+
+// documentBorderStyle converts a LinkBorderStyle to the domain border style.
+func (s LinkBorderStyle) documentBorderStyle() document.BorderStyle {
+	switch s {
+	case LinkBorderSolid:
+		return document.BorderStyleSolid
+	case LinkBorderDashed:
+		return document.BorderStyleDashed
+	case LinkBorderBeveled:
+		return document.BorderStyleBeveled
+	case LinkBorderInset:
+		return document.BorderStyleInset
+	case LinkBorderUnderline:
+		return document.BorderStyleUnderline
+	default:
+		return ""
+	}
+}
+
+// applyBorder copies the style's border settings to a link annotation.
+func (style LinkStyle) applyBorder(annot *document.LinkAnnotation) {
+	annot.BorderStyle = style.BorderStyle.documentBorderStyle()
+	if annot.BorderStyle != "" {
+		annot.BorderWidth = style.BorderWidth
+		if annot.BorderWidth == 0 {
+			annot.BorderWidth = 1
+		}
+	}
+	if style.BorderColor != nil {
+		annot.BorderColor = &[3]float64{style.BorderColor.R, style.BorderColor.G, style.BorderColor.B}
+	}
+	annot.DashArray = style.DashArray
 }
 
 // DefaultLinkStyle returns the default link style.
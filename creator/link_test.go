@@ -403,3 +403,63 @@ func TestLinkAnnotation_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestLinkBorderStyle tests that border settings reach the link annotation.
+func TestLinkBorderStyle(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+
+	style := DefaultLinkStyle()
+	style.Underline = false
+	style.BorderStyle = LinkBorderDashed
+	style.BorderColor = &Red
+	style.DashArray = []float64{3, 2}
+
+	if err := page.AddLinkStyled("Docs", "https://example.com", 100, 700, style); err != nil {
+		t.Fatalf("AddLinkStyled failed: %v", err)
+	}
+
+	annot := page.page.Annotations()[0]
+	if annot.BorderStyle != document.BorderStyleDashed {
+		t.Errorf("expected dashed border, got %q", annot.BorderStyle)
+	}
+	if annot.BorderWidth != 1 {
+		t.Errorf("expected default border width 1, got %.2f", annot.BorderWidth)
+	}
+	if annot.BorderColor == nil || *annot.BorderColor != [3]float64{1, 0, 0} {
+		t.Errorf("expected red border color, got %v", annot.BorderColor)
+	}
+
+	// Default style keeps the invisible border.
+	if err := page.AddLink("Plain", "https://example.com", 100, 600, Helvetica, 12); err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+	plain := page.page.Annotations()[1]
+	if plain.BorderStyle != "" || plain.BorderWidth != 0 {
+		t.Errorf("expected no border, got style %q width %.2f", plain.BorderStyle, plain.BorderWidth)
+	}
+}
+
+// TestLinkBorderStyle_InvalidDashArray tests that an invalid dash pattern
+// is rejected before anything is drawn.
+func TestLinkBorderStyle_InvalidDashArray(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+
+	style := DefaultLinkStyle()
+	style.BorderStyle = LinkBorderDashed
+	style.DashArray = []float64{0, 0}
+
+	if err := page.AddLinkStyled("Docs", "https://example.com", 100, 700, style); err == nil {
+		t.Fatal("expected error for all-zero dash array")
+	}
+	if len(page.TextOperations()) != 0 || len(page.page.Annotations()) != 0 {
+		t.Error("invalid link should add nothing to the page")
+	}
+}
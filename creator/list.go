@@ -230,7 +230,7 @@ func (l *List) calculateHeight(ctx *LayoutContext, level int) float64 {
 
 	for _, item := range l.items {
 		// Calculate height for item text.
-		itemHeight := l.calculateItemHeight(item.text, availableWidth, lineHeight)
+		itemHeight := l.calculateItemHeight(ctx.normalization.normalize(item.text), availableWidth, lineHeight)
 		totalHeight += itemHeight
 
 		// Add height for sublist.
@@ -262,7 +262,7 @@ func (l *List) draw(ctx *LayoutContext, page *Page, level int) error {
 
 		// Draw item text.
 		textX := markerX + l.markerIndent
-		lines := l.wrapText(item.text, availableWidth)
+		lines := l.wrapText(ctx.normalization.normalize(item.text), availableWidth)
 
 		for lineIdx, line := range lines {
 			textY := ctx.CurrentPDFY() - l.fontSize
@@ -0,0 +1,41 @@
+package creator
+
+import "github.com/coregx/gxpdf/internal/fonts"
+
+// Font is a font that can measure the rendered width of text: a Standard
+// 14 FontName or an embedded *CustomFont.
+type Font interface {
+	// MeasureString returns the width of text in points at the given size.
+	MeasureString(text string, size float64) float64
+}
+
+// MeasureString returns the width of text in points at the given size.
+//
+// Widths come from the font's AFM metrics (in 1000-unit glyph space) and
+// are scaled by size/1000. Unknown font names measure as 0.
+//
+// Example:
+//
+//	w := creator.Helvetica.MeasureString("Hello", 12) // 27.336
+func (f FontName) MeasureString(text string, size float64) float64 {
+	return fonts.MeasureString(string(f), text, size)
+}
+
+// MeasureText returns the width of text in points when set in font at the
+// given size.
+//
+// Standard 14 fonts are measured with their AFM metrics, embedded fonts
+// with the advance widths of their TrueType hmtx table. Kerning is not
+// applied, matching how the creator writes text.
+//
+// Example:
+//
+//	// Right-align a label against x=540.
+//	w := creator.MeasureText("Total", creator.HelveticaBold, 12)
+//	page.AddText("Total", 540-w, 700, creator.HelveticaBold, 12)
+func MeasureText(text string, font Font, size float64) float64 {
+	if font == nil {
+		return 0
+	}
+	return font.MeasureString(text, size)
+}
@@ -0,0 +1,57 @@
+package creator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasureText_Standard14(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		font  FontName
+		size  float64
+		width float64
+	}{
+		// AFM widths: H 722, e 556, l 222, o 556.
+		{"Helvetica", "Hello", Helvetica, 12, (722 + 556 + 222 + 222 + 556) * 12 / 1000.0},
+		// AFM widths: H 722, e 444, l 278, o 500.
+		{"Times-Roman", "Hello", TimesRoman, 12, (722 + 444 + 278 + 278 + 500) * 12 / 1000.0},
+		{"Courier", "Hello", Courier, 10, 5 * 600 * 10 / 1000.0},
+		{"scales with size", "Hello", Helvetica, 24, 2 * 27.336},
+		{"empty", "", Helvetica, 12, 0},
+		{"unknown font", "Hello", FontName("NoSuchFont"), 12, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.width, MeasureText(tt.text, tt.font, tt.size), 1e-6)
+			assert.InDelta(t, tt.width, tt.font.MeasureString(tt.text, tt.size), 1e-6)
+		})
+	}
+}
+
+func TestMeasureText_CustomFont(t *testing.T) {
+	font := loadTestFont(t)
+	ttf := font.GetTTF()
+
+	// Sum the hmtx advance widths by hand.
+	var units int
+	for _, ch := range "Hello" {
+		units += int(ttf.GlyphWidths[ttf.CharToGlyph[ch]])
+	}
+	want := float64(units) * 12 / float64(ttf.UnitsPerEm)
+	require.Positive(t, want)
+	assert.InDelta(t, want, MeasureText("Hello", font, 12), 1e-6)
+
+	// Long strings must not overflow the width sum.
+	long := strings.Repeat("Hello", 100)
+	assert.InDelta(t, 100*want, MeasureText(long, font, 12), 1e-6)
+}
+
+func TestMeasureText_NilFont(t *testing.T) {
+	assert.Zero(t, MeasureText("Hello", nil, 12))
+}
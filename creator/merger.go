@@ -32,11 +32,62 @@ import (
 //	    log.Fatal(err)
 //	}
 func Merge(output string, inputs ...string) error {
-	return mergeFiles(output, inputs)
+	return mergeFiles(output, inputs, nil)
+}
+
+// MergeWithOptions merges multiple PDF files using the given options.
+//
+// A nil opts behaves like Merge.
+//
+// Example:
+//
+//	opts := &creator.MergeOptions{PageLabels: creator.PageLabelsContinuous}
+//	err := creator.MergeWithOptions("bundle.pdf", opts, "cover.pdf", "exhibit.pdf")
+func MergeWithOptions(output string, opts *MergeOptions, inputs ...string) error {
+	return mergeFiles(output, inputs, opts)
+}
+
+// PageLabelStrategy controls how source /PageLabels are combined when merging.
+type PageLabelStrategy int
+
+const (
+	// PageLabelsPreservePerSource keeps each source's labels, shifted to the
+	// pages' new positions. Sources without labels are numbered 1, 2, 3...
+	// starting over at each source.
+	PageLabelsPreservePerSource PageLabelStrategy = iota
+
+	// PageLabelsContinuous numbers the merged document 1..N, ignoring
+	// source labels.
+	PageLabelsContinuous
+
+	// PageLabelsDrop writes no /PageLabels at all.
+	PageLabelsDrop
+)
+
+// MergeOptions configures a merge.
+type MergeOptions struct {
+	// PageLabels selects the page labelling strategy.
+	// Default: PageLabelsPreservePerSource.
+	PageLabels PageLabelStrategy
+
+	// Import sets a uniform output page size and how source pages are
+	// fitted to it. Default: each page keeps its source size.
+	Import ImportOptions
+
+	// EmptyContentStreams gives every output page an empty content
+	// stream instead of no /Contents entry (see
+	// Creator.SetEmptyContentStreams). Default: false.
+	EmptyContentStreams bool
+
+	// Encryption encrypts the merged file. Algorithm is used as given
+	// (the zero value is EncryptionRC4_40), so the options returned by
+	// gxpdf.EncryptionInfo.Options keep the encryption of a source.
+	// Default: nil (not encrypted).
+	Encryption *EncryptionOptions
 }
 
 // mergeFiles implements the actual merge logic (extracted for linter compliance).
-func mergeFiles(output string, inputs []string) error {
+func mergeFiles(output string, inputs []string, opts *MergeOptions) error {
 	if len(inputs) == 0 {
 		return fmt.Errorf("no input files specified")
 	}
@@ -60,9 +111,9 @@ func mergeFiles(output string, inputs []string) error {
 	}
 
 	// Create merger and add all pages.
-	merger := NewMerger()
-	for _, doc := range docs {
-		if err := merger.addDocument(doc); err != nil {
+	merger := NewMergerWithOptions(opts)
+	for i, doc := range docs {
+		if err := merger.addDocument(doc, readers[i]); err != nil {
 			return fmt.Errorf("failed to add document: %w", err)
 		}
 	}
@@ -75,6 +126,9 @@ func mergeFiles(output string, inputs []string) error {
 //
 // This is useful when you already have documents loaded in memory
 // or when you want to merge specific documents programmatically.
+// Document instances hold the page structure but not the page content,
+// so the merged pages are blank; use Merge or a Merger to copy content
+// from PDF files.
 //
 // Parameters:
 //   - output: Path to the output PDF file
@@ -96,7 +150,7 @@ func MergeDocuments(output string, docs ...*document.Document) error {
 
 	merger := NewMerger()
 	for _, doc := range docs {
-		if err := merger.addDocument(doc); err != nil {
+		if err := merger.addDocument(doc, nil); err != nil {
 			return fmt.Errorf("failed to add document: %w", err)
 		}
 	}
@@ -131,12 +185,16 @@ type Merger struct {
 
 	// Track opened readers for cleanup.
 	readers []*reader.PdfReader
+
+	// Merge options (page label strategy, etc.).
+	opts MergeOptions
 }
 
 // pageInfo tracks a page to be merged.
 type pageInfo struct {
 	doc       *document.Document
-	pageIndex int // 0-based page index
+	reader    *reader.PdfReader // Source of the page content (nil = none)
+	pageIndex int               // 0-based page index
 }
 
 // NewMerger creates a new Merger instance.
@@ -154,6 +212,23 @@ func NewMerger() *Merger {
 	}
 }
 
+// NewMergerWithOptions creates a new Merger with the given options.
+//
+// A nil opts is equivalent to NewMerger.
+//
+// Example:
+//
+//	merger := creator.NewMergerWithOptions(&creator.MergeOptions{
+//	    PageLabels: creator.PageLabelsPreservePerSource,
+//	})
+func NewMergerWithOptions(opts *MergeOptions) *Merger {
+	m := NewMerger()
+	if opts != nil {
+		m.opts = *opts
+	}
+	return m
+}
+
 // AddPages adds specific pages from a PDF file.
 //
 // Page numbers are 1-based (1 = first page, 2 = second page, etc.).
@@ -198,6 +273,7 @@ func (m *Merger) addPagesFromFile(path string, pageNums []int) error {
 		// Convert to 0-based index.
 		m.pageInfos = append(m.pageInfos, pageInfo{
 			doc:       doc,
+			reader:    r,
 			pageIndex: pageNum - 1,
 		})
 	}
@@ -248,6 +324,7 @@ func (m *Merger) AddPageRange(path string, start, end int) error {
 	for pageNum := start; pageNum <= end; pageNum++ {
 		m.pageInfos = append(m.pageInfos, pageInfo{
 			doc:       doc,
+			reader:    r,
 			pageIndex: pageNum - 1,
 		})
 	}
@@ -280,6 +357,7 @@ func (m *Merger) AddAllPages(path string) error {
 	for i := 0; i < pageCount; i++ {
 		m.pageInfos = append(m.pageInfos, pageInfo{
 			doc:       doc,
+			reader:    r,
 			pageIndex: i,
 		})
 	}
@@ -340,7 +418,15 @@ func (m *Merger) WriteContext(ctx context.Context, path string) error {
 	}
 
 	// Write output document.
-	return m.writeOutput(path)
+	if err := m.writeOutput(path); err != nil {
+		return err
+	}
+	if m.opts.Encryption != nil {
+		if err := encryptFile(path, m.opts.Encryption); err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", path, err)
+		}
+	}
+	return nil
 }
 
 // copyPagesToOutput copies selected pages to the output document.
@@ -353,12 +439,8 @@ func (m *Merger) copyPagesToOutput() error {
 		}
 		srcPage := pages[info.pageIndex]
 
-		// Get page size from source MediaBox.
-		mediaBox := srcPage.MediaBox()
-		size := sizeFromMediaBox(mediaBox)
-
 		// Add page to output document.
-		dstPage, err := m.outputDoc.AddPage(size)
+		dstPage, err := m.outputDoc.AddPage(m.outputSize(srcPage))
 		if err != nil {
 			return fmt.Errorf("failed to add page: %w", err)
 		}
@@ -368,12 +450,122 @@ func (m *Merger) copyPagesToOutput() error {
 			return fmt.Errorf("failed to set rotation: %w", err)
 		}
 
-		// Note: Content stream copying is handled by the writer
-		// which will copy the raw content from the source pages.
-		// We just need to maintain the page structure here.
 	}
 
-	return nil
+	return m.outputDoc.SetPageLabels(m.buildPageLabels())
+}
+
+// outputSize returns the size of the output page for srcPage: its own
+// size, unless a uniform output size is requested.
+func (m *Merger) outputSize(srcPage *document.Page) document.PageSize {
+	if m.opts.Import.PageSize != nil {
+		return m.opts.Import.PageSize.toDomainSize()
+	}
+	return sizeFromMediaBox(srcPage.MediaBox())
+}
+
+// importedContents returns the graphics operations that draw the source
+// page content onto each output page.
+//
+// Each source page is drawn as a form XObject holding its content and
+// resources, placed by the ImportOptions fit transform. The form is
+// clipped to the source media box, so content outside it stays hidden.
+func (m *Merger) importedContents() (map[int][]writer.GraphicsOp, error) {
+	contents := make(map[int][]writer.GraphicsOp)
+	for i, info := range m.pageInfos {
+		if info.reader == nil {
+			continue
+		}
+		pageDict, err := info.reader.GetPage(info.pageIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", info.pageIndex+1, err)
+		}
+
+		srcPage := info.doc.Pages()[info.pageIndex]
+		llx, lly := srcPage.MediaBox().LowerLeft()
+		urx, ury := srcPage.MediaBox().UpperRight()
+		t := Identity()
+		if m.opts.Import.PageSize != nil {
+			dstPage, err := m.outputDoc.Page(i)
+			if err != nil {
+				return nil, err
+			}
+			t = m.opts.Import.FitTransform(urx-llx, ury-lly, dstPage.Width(), dstPage.Height())
+		}
+
+		contents[i] = []writer.GraphicsOp{{
+			Type: 26, // Form XObject
+			// Move the media box origin to (0, 0), then fit.
+			Matrix: [6]float64{t.A, t.B, t.C, t.D, t.E - t.A*llx, t.F - t.D*lly},
+			Form: &writer.FormXObject{
+				ID:   fmt.Sprintf("Import%d", i+1),
+				BBox: [4]float64{llx, lly, urx, ury},
+				Imported: &writer.ImportedPage{
+					Reader: info.reader.GetParserReader(),
+					Page:   pageDict,
+				},
+			},
+		}}
+	}
+	return contents, nil
+}
+
+// buildPageLabels computes the merged document's page label ranges
+// according to the configured strategy.
+//
+// For PageLabelsPreservePerSource every output page keeps the label it had
+// in its source document, and consecutive pages are folded back into ranges.
+// This handles arbitrary page selections, not just whole documents.
+func (m *Merger) buildPageLabels() []document.PageLabelRange {
+	switch m.opts.PageLabels {
+	case PageLabelsDrop:
+		return nil
+	case PageLabelsContinuous:
+		return []document.PageLabelRange{{PageIndex: 0, Style: document.PageLabelDecimal}}
+	}
+
+	// Nothing to preserve if no source defines labels.
+	hasLabels := false
+	for _, info := range m.pageInfos {
+		if len(info.doc.PageLabels()) > 0 {
+			hasLabels = true
+			break
+		}
+	}
+	if !hasLabels {
+		return nil
+	}
+
+	var ranges []document.PageLabelRange
+	var prev *document.Document
+	prevNumber := 0
+	for i, info := range m.pageInfos {
+		label, number, ok := info.doc.PageLabelAt(info.pageIndex)
+		if !ok {
+			// Unlabelled pages default to decimal numbering from 1.
+			label = document.PageLabelRange{Style: document.PageLabelDecimal}
+			number = info.pageIndex + 1
+		}
+
+		if len(ranges) > 0 && info.doc == prev {
+			last := ranges[len(ranges)-1]
+			if last.Style == label.Style && last.Prefix == label.Prefix && number == prevNumber+1 {
+				prevNumber = number
+				continue
+			}
+		}
+
+		ranges = append(ranges, document.PageLabelRange{
+			PageIndex: i,
+			Style:     label.Style,
+			Prefix:    label.Prefix,
+			Start:     number,
+		})
+		prev = info.doc
+		prevNumber = number
+	}
+
+	return ranges
 }
 
 // writeOutput writes the output document to a file.
@@ -389,12 +581,14 @@ func (m *Merger) writeOutput(path string) error {
 		}
 	}()
 
-	// Write document (empty content, just structure).
-	// Note: For now, we write empty pages. Full content copying
-	// would require parsing and copying content streams.
+	// Source page content is drawn as form XObjects.
+	graphicsContents, err := m.importedContents()
+	if err != nil {
+		return err
+	}
 	textContents := make(map[int][]writer.TextOp)
-	graphicsContents := make(map[int][]writer.GraphicsOp)
 
+	w.SetEmptyContentStreams(m.opts.EmptyContentStreams)
 	if err := w.WriteWithAllContent(m.outputDoc, textContents, graphicsContents); err != nil {
 		return fmt.Errorf("failed to write PDF: %w", err)
 	}
@@ -402,12 +596,15 @@ func (m *Merger) writeOutput(path string) error {
 	return nil
 }
 
-// addDocument adds all pages from a document (internal helper).
-func (m *Merger) addDocument(doc *document.Document) error {
+// addDocument adds all pages from a document (internal helper). The page
+// content is copied from r; pages of a document without a reader are
+// merged without content.
+func (m *Merger) addDocument(doc *document.Document, r *reader.PdfReader) error {
 	pageCount := doc.PageCount()
 	for i := 0; i < pageCount; i++ {
 		m.pageInfos = append(m.pageInfos, pageInfo{
 			doc:       doc,
+			reader:    r,
 			pageIndex: i,
 		})
 	}
@@ -437,6 +634,12 @@ func openAndReconstruct(path string) (*document.Document, *reader.PdfReader, err
 		return nil, nil, fmt.Errorf("failed to reconstruct document: %w", err)
 	}
 
+	// Carry over page labels so merges can preserve them.
+	if err := doc.SetPageLabels(pdfReader.PageLabels()); err != nil {
+		_ = pdfReader.Close()
+		return nil, nil, fmt.Errorf("failed to read page labels: %w", err)
+	}
+
 	return doc, pdfReader, nil
 }
 
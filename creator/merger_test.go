@@ -1,11 +1,18 @@
 package creator
 
 import (
+	"bytes"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+	"github.com/coregx/gxpdf/internal/reader"
 )
 
 // Note: Many tests are currently skipped due to a known PDF writer xref offset bug
@@ -355,3 +362,342 @@ func verifyPageCount(t *testing.T, path string, expected int) {
 		t.Errorf("Expected %d pages, got %d", expected, actual)
 	}
 }
+
+// TestMerger_BuildPageLabels_PreservePerSource tests label shifting across sources.
+func TestMerger_BuildPageLabels_PreservePerSource(t *testing.T) {
+	// Source 1: i, ii, then 1, 2.
+	doc1 := createTestDocument(t, 4)
+	err := doc1.SetPageLabels([]document.PageLabelRange{
+		{PageIndex: 0, Style: document.PageLabelRomanLower},
+		{PageIndex: 2, Style: document.PageLabelDecimal},
+	})
+	if err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+
+	// Source 2: no labels (3 pages).
+	doc2 := createTestDocument(t, 3)
+
+	merger := NewMerger()
+	_ = merger.addDocument(doc1, nil)
+	_ = merger.addDocument(doc2, nil)
+
+	got := merger.buildPageLabels()
+	want := []document.PageLabelRange{
+		{PageIndex: 0, Style: document.PageLabelRomanLower, Start: 1},
+		{PageIndex: 2, Style: document.PageLabelDecimal, Start: 1},
+		{PageIndex: 4, Style: document.PageLabelDecimal, Start: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d ranges, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("range %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestMerger_BuildPageLabels_PageSubset tests labels for a non-contiguous selection.
+func TestMerger_BuildPageLabels_PageSubset(t *testing.T) {
+	doc := createTestDocument(t, 5)
+	err := doc.SetPageLabels([]document.PageLabelRange{
+		{PageIndex: 0, Style: document.PageLabelDecimal, Prefix: "A-", Start: 10},
+	})
+	if err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+
+	// Pages A-10, A-11, A-13 (skipping A-12).
+	merger := NewMerger()
+	merger.pageInfos = []pageInfo{{doc: doc, pageIndex: 0}, {doc: doc, pageIndex: 1}, {doc: doc, pageIndex: 3}}
+
+	got := merger.buildPageLabels()
+	if len(got) != 2 {
+		t.Fatalf("got %d ranges, want 2: %+v", len(got), got)
+	}
+	if got[1].PageIndex != 2 || got[1].Start != 13 || got[1].Prefix != "A-" {
+		t.Errorf("second range = %+v, want page 2 starting at A-13", got[1])
+	}
+}
+
+// TestMerger_BuildPageLabels_Strategies tests the Continuous and Drop strategies.
+func TestMerger_BuildPageLabels_Strategies(t *testing.T) {
+	doc := createTestDocument(t, 2)
+	_ = doc.SetPageLabels([]document.PageLabelRange{{PageIndex: 0, Style: document.PageLabelRomanUpper}})
+
+	continuous := NewMergerWithOptions(&MergeOptions{PageLabels: PageLabelsContinuous})
+	_ = continuous.addDocument(doc, nil)
+	_ = continuous.addDocument(doc, nil)
+	got := continuous.buildPageLabels()
+	if len(got) != 1 || got[0].Style != document.PageLabelDecimal || got[0].PageIndex != 0 {
+		t.Errorf("Continuous labels = %+v, want single decimal range", got)
+	}
+
+	drop := NewMergerWithOptions(&MergeOptions{PageLabels: PageLabelsDrop})
+	_ = drop.addDocument(doc, nil)
+	if got := drop.buildPageLabels(); got != nil {
+		t.Errorf("Drop labels = %+v, want nil", got)
+	}
+
+	// Unlabelled sources produce no /PageLabels by default.
+	plain := NewMerger()
+	_ = plain.addDocument(createTestDocument(t, 2), nil)
+	if got := plain.buildPageLabels(); got != nil {
+		t.Errorf("unlabelled merge = %+v, want nil", got)
+	}
+}
+
+// TestMergeWithOptions_PageLabelsRoundTrip tests reading and writing labels through files.
+func TestMergeWithOptions_PageLabelsRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Source with roman front matter: i, ii.
+	c := New()
+	for i := 0; i < 2; i++ {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("Failed to create page: %v", err)
+		}
+	}
+	if err := c.doc.SetPageLabels([]document.PageLabelRange{{PageIndex: 0, Style: document.PageLabelRomanLower}}); err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+	file1 := filepath.Join(tmpDir, "a.pdf")
+	if err := c.WriteToFile(file1); err != nil {
+		t.Fatalf("Failed to write PDF: %v", err)
+	}
+
+	file2 := createMergeTestPDF(t, tmpDir, "b.pdf", 2)
+	output := filepath.Join(tmpDir, "merged.pdf")
+
+	if err := MergeWithOptions(output, nil, file1, file2); err != nil {
+		t.Fatalf("MergeWithOptions failed: %v", err)
+	}
+
+	r, err := reader.NewPdfReader(output)
+	if err != nil {
+		t.Fatalf("failed to open merged PDF: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.PageCount() != 4 {
+		t.Errorf("page count = %d, want 4", r.PageCount())
+	}
+
+	labels := r.PageLabels()
+	if len(labels) != 2 {
+		t.Fatalf("PageLabels = %+v, want 2 ranges", labels)
+	}
+	if labels[0].Style != document.PageLabelRomanLower || labels[1].PageIndex != 2 || labels[1].Style != document.PageLabelDecimal {
+		t.Errorf("PageLabels = %+v, want [i, ii] then [1, 2]", labels)
+	}
+}
+
+func TestMergeWithOptions_PageLabelsDuplicateKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	c := New()
+	for i := 0; i < 3; i++ {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("Failed to create page: %v", err)
+		}
+	}
+	if err := c.doc.SetPageLabels([]document.PageLabelRange{
+		{PageIndex: 0, Style: document.PageLabelRomanLower},
+		{PageIndex: 2, Style: document.PageLabelDecimal},
+	}); err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+	data, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+
+	// Give both number tree entries key 0, keeping the file's offsets.
+	nums := []byte("/Nums [0 << /S /r >> 2 << /S /D >>]")
+	if !bytes.Contains(data, nums) {
+		t.Fatalf("expected %q in output", nums)
+	}
+	data = bytes.Replace(data, nums, []byte("/Nums [0 << /S /r >> 0 << /S /D >>]"), 1)
+	input := filepath.Join(tmpDir, "dup.pdf")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(tmpDir, "merged.pdf")
+	if err := MergeWithOptions(output, nil, input); err != nil {
+		t.Fatalf("MergeWithOptions failed: %v", err)
+	}
+	r, err := reader.NewPdfReader(output)
+	if err != nil {
+		t.Fatalf("failed to open merged PDF: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	// The first entry wins instead of all labels being lost.
+	labels := r.PageLabels()
+	if len(labels) != 1 || labels[0].Style != document.PageLabelRomanLower {
+		t.Errorf("PageLabels = %+v, want one roman range", labels)
+	}
+}
+
+func TestMerger_ImportPageSize(t *testing.T) {
+	a3 := document.NewDocument()
+	if _, err := a3.AddPage(document.A3); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	size := A4
+	m := NewMergerWithOptions(&MergeOptions{
+		Import: ImportOptions{PageSize: &size, FitMode: ShrinkToFit},
+	})
+	if err := m.addDocument(a3, nil); err != nil {
+		t.Fatalf("addDocument() error = %v", err)
+	}
+	if err := m.copyPagesToOutput(); err != nil {
+		t.Fatalf("copyPagesToOutput() error = %v", err)
+	}
+
+	page, err := m.outputDoc.Page(0)
+	if err != nil {
+		t.Fatalf("Page(0) error = %v", err)
+	}
+	if w, h := page.Width(), page.Height(); w != 595 || h != 842 {
+		t.Errorf("output page = %vx%v, want 595x842", w, h)
+	}
+}
+
+func TestMergeWithOptions_ShrinkToFit(t *testing.T) {
+	tmpDir := t.TempDir()
+	a3 := createMergeTestPDFWithSize(t, tmpDir, "a3.pdf", 1, A3)
+	output := filepath.Join(tmpDir, "merged.pdf")
+
+	size := A4
+	opts := &MergeOptions{Import: ImportOptions{PageSize: &size, FitMode: ShrinkToFit}}
+	if err := MergeWithOptions(output, opts, a3); err != nil {
+		t.Fatalf("MergeWithOptions failed: %v", err)
+	}
+
+	r, err := reader.NewPdfReader(output)
+	if err != nil {
+		t.Fatalf("failed to open merged PDF: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	pr := r.GetParserReader()
+
+	page, err := r.GetPage(0)
+	if err != nil {
+		t.Fatalf("GetPage(0) error = %v", err)
+	}
+	content := decodedPageContent(t, pr, page.Get("Contents"))
+
+	// The source page is drawn as a form XObject, scaled down and centered.
+	m := regexp.MustCompile(`(\S+) 0 0 (\S+) (\S+) (\S+) cm\s*/(\S+) Do`).FindStringSubmatch(string(content))
+	if m == nil {
+		t.Fatalf("page content %q should draw the imported page through a cm", content)
+	}
+	want := []float64{595.0 / 842, 595.0 / 842, 0, (842 - 1191*595.0/842) / 2}
+	for i, w := range want {
+		got, err := strconv.ParseFloat(m[i+1], 64)
+		if err != nil || math.Abs(got-w) > 0.01 {
+			t.Errorf("cm operand %d = %s, want %.4f", i+1, m[i+1], w)
+		}
+	}
+
+	// The form holds the source content and its font.
+	resources, ok := pr.ResolveReferences(page.Get("Resources")).(*parser.Dictionary)
+	if !ok {
+		t.Fatal("page has no resources")
+	}
+	xobjects, ok := pr.ResolveReferences(resources.Get("XObject")).(*parser.Dictionary)
+	if !ok {
+		t.Fatal("page has no XObject resources")
+	}
+	form, ok := pr.ResolveReferences(xobjects.Get(m[5])).(*parser.Stream)
+	if !ok {
+		t.Fatalf("/%s is not a form XObject", m[5])
+	}
+	if bbox := form.Dictionary().GetArray("BBox"); bbox == nil || bbox.String() != "[0 0 842 1191]" {
+		t.Errorf("form /BBox = %v, want the A3 media box", bbox)
+	}
+	if got := decodedPageContent(t, pr, form); !strings.Contains(string(got), "(Test page) Tj") {
+		t.Errorf("form content %q should hold the source text", got)
+	}
+	formRes, _ := pr.ResolveReferences(form.Dictionary().Get("Resources")).(*parser.Dictionary)
+	if formRes == nil || formRes.Get("Font") == nil {
+		t.Errorf("form resources %v should hold the source font", formRes)
+	}
+}
+
+// decodedPageContent returns the decoded data of a content stream.
+func decodedPageContent(t *testing.T, r *parser.Reader, obj parser.PdfObject) []byte {
+	t.Helper()
+
+	stream, ok := r.ResolveReferences(obj).(*parser.Stream)
+	if !ok {
+		t.Fatalf("content is %T, not a stream", obj)
+	}
+	data, err := r.DecodeStream(stream)
+	if err != nil {
+		t.Fatalf("DecodeStream() error = %v", err)
+	}
+	return data
+}
+
+func TestMergeWithOptions_Encryption(t *testing.T) {
+	tmpDir := t.TempDir()
+	file1 := createMergeTestPDF(t, tmpDir, "file1.pdf", 1)
+	file2 := createMergeTestPDF(t, tmpDir, "file2.pdf", 2)
+	output := filepath.Join(tmpDir, "merged.pdf")
+
+	opts := &MergeOptions{Encryption: &EncryptionOptions{
+		OwnerPassword: "owner",
+		Permissions:   PermissionPrint,
+		Algorithm:     EncryptionAES256,
+	}}
+	if err := MergeWithOptions(output, opts, file1, file2); err != nil {
+		t.Fatalf("MergeWithOptions failed: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("Test page")) {
+		t.Error("merged file should not hold the page text in clear")
+	}
+
+	r, err := reader.NewPdfReader(output)
+	if err != nil {
+		t.Fatalf("failed to open merged PDF: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	pr := r.GetParserReader()
+
+	info := pr.GetEncryptInfo()
+	if info == nil || info.V != 5 || info.CFM != "AESV3" {
+		t.Fatalf("GetEncryptInfo() = %+v, want AES-256", info)
+	}
+	if count, _ := pr.GetPageCount(); count != 3 {
+		t.Errorf("page count = %d, want 3", count)
+	}
+
+	// The empty user password opens the file; the imported page content
+	// decrypts back to the source text.
+	page, err := r.GetPage(0)
+	if err != nil {
+		t.Fatalf("GetPage(0) error = %v", err)
+	}
+	resources, _ := pr.ResolveReferences(page.Get("Resources")).(*parser.Dictionary)
+	if resources == nil {
+		t.Fatal("page has no resources")
+	}
+	xobjects, _ := pr.ResolveReferences(resources.Get("XObject")).(*parser.Dictionary)
+	if xobjects == nil || len(xobjects.Keys()) != 1 {
+		t.Fatalf("page XObjects = %v, want the imported page", xobjects)
+	}
+	form := xobjects.Get(xobjects.Keys()[0])
+	if got := decodedPageContent(t, pr, form); !strings.Contains(string(got), "(Test page) Tj") {
+		t.Errorf("form content %q should decrypt to the source text", got)
+	}
+}
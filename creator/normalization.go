@@ -0,0 +1,113 @@
+package creator
+
+import "golang.org/x/text/unicode/norm"
+
+// Normalization selects the Unicode normalization applied to page text.
+type Normalization int
+
+const (
+	// NormalizationNone leaves text exactly as given (default).
+	NormalizationNone Normalization = iota
+
+	// NFC composes decomposed sequences (for example "e" followed by
+	// U+0301 COMBINING ACUTE ACCENT becomes "é").
+	//
+	// Fonts usually contain precomposed glyphs but cannot position
+	// combining marks, so NFC text renders accents correctly when the
+	// input was copy-pasted or came from a system that stores text
+	// decomposed (such as macOS file names).
+	NFC
+)
+
+// SetNormalization sets the Unicode normalization applied to text before
+// glyphs are mapped.
+//
+// Normalization is applied when text is added to a page of this creator
+// with the AddText family of methods, paragraphs and inline text blocks,
+// so measuring and wrapping see the normalized text. Text added before the
+// call, or to pages and templates not created by this creator, is
+// normalized when the document is written. Embedded font subsets include
+// the glyphs of the normalized text.
+//
+// Example:
+//
+//	c.SetNormalization(creator.NFC)
+//	page.AddTextCustomFont("Café", 100, 700, font, 12) // Renders "Café"
+func (c *Creator) SetNormalization(n Normalization) {
+	c.normalization = n
+}
+
+// normalize returns s normalized.
+func (n Normalization) normalize(s string) string {
+	if n != NFC {
+		return s
+	}
+	return norm.NFC.String(s)
+}
+
+// textNormalization returns the normalization of the page's creator.
+func (p *Page) textNormalization() Normalization {
+	if p.normalization == nil {
+		return NormalizationNone
+	}
+	return *p.normalization
+}
+
+// normalize returns text normalized for this page (see SetNormalization).
+func (p *Page) normalize(text string) string {
+	return p.textNormalization().normalize(text)
+}
+
+// normalizeTextOps returns ops with normalized text.
+//
+// Custom font subsets are updated with the normalized characters. The
+// input slice is not modified.
+func (n Normalization) normalizeTextOps(ops []TextOperation) []TextOperation {
+	if n != NFC || len(ops) == 0 {
+		return ops
+	}
+	out := make([]TextOperation, len(ops))
+	for i, op := range ops {
+		if !norm.NFC.IsNormalString(op.Text) {
+			op.Text = norm.NFC.String(op.Text)
+			if op.CustomFont != nil {
+				op.CustomFont.UseString(op.Text)
+			}
+		}
+		out[i] = op
+	}
+	return out
+}
+
+// normalizeGraphicsOps returns ops with normalized inline text blocks and
+// templates.
+//
+// Templates are shared between pages, so a normalized copy is drawn in
+// their place; it keeps the template's ID and is still written once.
+func (n Normalization) normalizeGraphicsOps(ops []GraphicsOperation) []GraphicsOperation {
+	if n != NFC || len(ops) == 0 {
+		return ops
+	}
+	out := make([]GraphicsOperation, len(ops))
+	for i, op := range ops {
+		switch {
+		case op.Type == GraphicsOpTextBlock && !norm.NFC.IsNormalString(op.Text):
+			op.Text = norm.NFC.String(op.Text)
+			if op.TextFont != nil {
+				op.TextFont.UseString(op.Text)
+			}
+		case op.Type == GraphicsOpTemplate && op.Template != nil:
+			op.Template = n.normalizeTemplate(op.Template)
+		}
+		out[i] = op
+	}
+	return out
+}
+
+// normalizeTemplate returns a copy of tpl with normalized content.
+func (n Normalization) normalizeTemplate(tpl *Template) *Template {
+	page := *tpl.Page
+	page.textOps = n.normalizeTextOps(page.textOps)
+	page.graphicsOps = n.normalizeGraphicsOps(page.graphicsOps)
+	return &Template{Page: &page, id: tpl.id}
+}
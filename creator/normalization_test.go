@@ -0,0 +1,105 @@
+package creator
+
+import (
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	decomposedCafe  = "Cafe\u0301"
+	precomposedCafe = "Caf\u00e9"
+)
+
+func TestCreator_SetNormalization(t *testing.T) {
+	tests := []struct {
+		name string
+		norm Normalization
+		want string
+	}{
+		{"none keeps decomposed text", NormalizationNone, decomposedCafe},
+		{"NFC composes accents", NFC, precomposedCafe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			c.SetNormalization(tt.norm)
+			page, err := c.NewPage()
+			require.NoError(t, err)
+			require.NoError(t, page.AddText(decomposedCafe, 100, 700, Helvetica, 12))
+
+			textContents, _ := c.collectAllPageContents()
+			require.Len(t, textContents[0], 1)
+			assert.Equal(t, tt.want, textContents[0][0].Text)
+
+			// Text is normalized when it is added.
+			assert.Equal(t, tt.want, page.textOps[0].Text)
+		})
+	}
+}
+
+func TestNormalization_OnAdd(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.AddText(decomposedCafe, 100, 750, Helvetica, 12))
+	c.SetNormalization(NFC)
+
+	// Right-aligned text is measured after normalization.
+	require.NoError(t, page.AddTextWithOptions(decomposedCafe, 300, 700, Helvetica, 12,
+		&TextOptions{Align: AlignRight}))
+	runs := []TextRun{{Text: decomposedCafe, Font: Helvetica, Size: 12}}
+	require.NoError(t, page.AddStyledText(runs, 100, 650))
+	para := NewParagraph(decomposedCafe)
+	require.NoError(t, page.Draw(para))
+
+	require.Len(t, page.textOps, 4)
+	assert.Equal(t, decomposedCafe, page.textOps[0].Text, "text added before SetNormalization is kept")
+	for _, op := range page.textOps[1:] {
+		assert.Equal(t, precomposedCafe, op.Text)
+	}
+	width := fonts.MeasureString(string(Helvetica), precomposedCafe, 12)
+	assert.InDelta(t, 300-width, page.textOps[1].X, 0.001)
+	assert.Equal(t, decomposedCafe, runs[0].Text, "runs are not modified")
+	assert.Equal(t, decomposedCafe, para.Text(), "the paragraph is not modified")
+
+	// Text added before SetNormalization is normalized on write.
+	textContents, _ := c.collectAllPageContents()
+	assert.Equal(t, precomposedCafe, textContents[0][0].Text)
+}
+
+func TestNormalization_GraphicsTextBlock(t *testing.T) {
+	ops := []GraphicsOperation{
+		{Type: GraphicsOpTextBlock, Text: decomposedCafe},
+		{Type: GraphicsOpRect, Text: decomposedCafe},
+	}
+
+	out := NFC.normalizeGraphicsOps(ops)
+	assert.Equal(t, precomposedCafe, out[0].Text)
+	assert.Equal(t, decomposedCafe, out[1].Text, "only text blocks carry text")
+	assert.Equal(t, decomposedCafe, ops[0].Text, "input is not modified")
+}
+
+func TestNormalization_Template(t *testing.T) {
+	tpl, err := NewTemplate(100, 40)
+	require.NoError(t, err)
+	require.NoError(t, tpl.AddText(decomposedCafe, 10, 14, Helvetica, 12))
+
+	c := New()
+	c.SetNormalization(NFC)
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.DrawTemplate(tpl, 50, 700, 1))
+
+	_, graphicsContents := c.collectAllPageContents()
+	require.Len(t, graphicsContents[0], 1)
+	form := graphicsContents[0][0].Form
+	require.NotNil(t, form)
+	assert.Equal(t, tpl.id, form.ID, "the normalized template is still one form XObject")
+	require.Len(t, form.TextOps, 1)
+	assert.Equal(t, precomposedCafe, form.TextOps[0].Text)
+	assert.Equal(t, decomposedCafe, tpl.textOps[0].Text, "the template is not modified")
+}
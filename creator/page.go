@@ -26,6 +26,26 @@ type Page struct {
 	// Content operations
 	textOps     []TextOperation     // Text drawing operations
 	graphicsOps []GraphicsOperation // Graphics drawing operations
+	saveDepth   int                 // Open Save calls (see Save)
+
+	// Page background (see SetBackgroundColor). The first backgroundOps
+	// graphics operations are the background.
+	backgroundColor *GraphicsOperation
+	backgroundImage *GraphicsOperation
+	backgroundOps   int
+
+	// Flow layout cursor used by Draw (see GetLayoutContext).
+	cursorX, cursorY float64
+	cursorSet        bool
+	flowCursor       bool // Draw advances the cursor (see SetFlowCursor)
+
+	// nextPage adds the page that flow content continues on (nil if the
+	// page was not created by a Creator or Appender).
+	nextPage func() (*Page, error)
+
+	// normalization points at the creator's text normalization (nil if
+	// the page was not created by a Creator).
+	normalization *Normalization
 }
 
 // SetRotation sets the page rotation.
@@ -63,6 +83,27 @@ func (p *Page) Rotation() int {
 	return p.page.Rotation()
 }
 
+// SetCropBoxOrigin makes drawing coordinates relative to the lower-left
+// corner of the page's crop box instead of the media box.
+//
+// Use this when overlaying content on trimmed or scanned pages whose
+// crop box is offset within the media box, so that (0, 0) is the corner
+// the viewer actually shows. Pages without a crop box are unaffected.
+//
+// Example:
+//
+//	page.SetCropBoxOrigin(true)
+//	page.AddText("DRAFT", 20, 20, creator.Helvetica, 12)
+func (p *Page) SetCropBoxOrigin(enabled bool) {
+	p.page.SetCropBoxOrigin(enabled)
+}
+
+// CropBoxOrigin reports whether drawing coordinates are relative to the
+// crop box.
+func (p *Page) CropBoxOrigin() bool {
+	return p.page.CropBoxOrigin()
+}
+
 // Width returns the page width in points.
 //
 // If the page is rotated 90 or 270 degrees, width and height are swapped.
@@ -155,7 +196,7 @@ func (p *Page) AddTextColor(text string, x, y float64, font FontName, size float
 
 	// Store text operation
 	p.textOps = append(p.textOps, TextOperation{
-		Text:  text,
+		Text:  p.normalize(text),
 		X:     x,
 		Y:     y,
 		Font:  font,
@@ -199,7 +240,7 @@ func (p *Page) AddTextColorCMYK(text string, x, y float64, font FontName, size f
 
 	// Store text operation with CMYK color
 	p.textOps = append(p.textOps, TextOperation{
-		Text:      text,
+		Text:      p.normalize(text),
 		X:         x,
 		Y:         y,
 		Font:      font,
@@ -259,6 +300,7 @@ func (p *Page) AddTextCustomFontColor(text string, x, y float64, font *CustomFon
 	}
 
 	// Mark characters as used for font subsetting.
+	text = p.normalize(text)
 	font.UseString(text)
 
 	// Store text operation with custom font.
@@ -317,6 +359,10 @@ func (p *Page) DrawLine(x1, y1, x2, y2 float64, opts *LineOptions) error {
 		return errors.New("line width must be non-negative")
 	}
 
+	if err := validateLineStyle(opts.LineCap, opts.LineJoin, opts.MiterLimit); err != nil {
+		return err
+	}
+
 	// Store graphics operation.
 	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
 		Type:     GraphicsOpLine,
@@ -333,11 +379,12 @@ func (p *Page) DrawLine(x1, y1, x2, y2 float64, opts *LineOptions) error {
 // DrawRect draws a rectangle at (x,y) with given width and height.
 //
 // The rectangle can be stroked, filled, or both, depending on the options.
+// Set CornerRadius or CornerRadii for rounded corners.
 //
 // Parameters:
 //   - x, y: Lower-left corner coordinates
 //   - width, height: Rectangle dimensions
-//   - opts: Rectangle options (stroke color, fill color, width, dash pattern)
+//   - opts: Rectangle options (stroke color, fill color, width, dash pattern, corner radii)
 //
 // Example:
 //
@@ -347,6 +394,14 @@ func (p *Page) DrawLine(x1, y1, x2, y2 float64, opts *LineOptions) error {
 //	    FillColor:   &creator.LightGray,
 //	}
 //	err := page.DrawRect(100, 600, 200, 100, opts)
+//
+// Example (rounded card):
+//
+//	opts := &creator.RectOptions{
+//	    FillColor:    &creator.LightGray,
+//	    CornerRadius: 8,
+//	}
+//	err := page.DrawRect(100, 600, 200, 100, opts)
 func (p *Page) DrawRect(x, y, width, height float64, opts *RectOptions) error {
 	if opts == nil {
 		return errors.New("rectangle options cannot be nil")
@@ -430,10 +485,70 @@ func (p *Page) BeginClipRect(x, y, width, height float64) error {
 	return nil
 }
 
-// EndClip ends a clipping region started by BeginClipRect.
+// BeginClipCircle begins a circular clipping region centered at (cx, cy).
+//
+// It works like BeginClipRect; the clip path is built from four Bézier
+// curves. Call EndClip() after drawing the clipped content.
+//
+// Example:
+//
+//	// Circular avatar
+//	page.BeginClipCircle(132, 532, 32)
+//	page.DrawImage(img, 100, 500, 64, 64)
+//	page.EndClip()
+func (p *Page) BeginClipCircle(cx, cy, radius float64) error {
+	if radius <= 0 {
+		return errors.New("clipping circle must have a positive radius")
+	}
+	return p.BeginClipEllipse(cx, cy, radius, radius)
+}
+
+// BeginClipEllipse begins an elliptical clipping region centered at
+// (cx, cy) with horizontal radius rx and vertical radius ry.
 //
-// This restores the graphics state to what it was before BeginClipRect was called.
-// Every BeginClipRect MUST have a matching EndClip.
+// Call EndClip() after drawing the clipped content.
+func (p *Page) BeginClipEllipse(cx, cy, rx, ry float64) error {
+	if rx <= 0 || ry <= 0 {
+		return errors.New("clipping ellipse must have positive radii")
+	}
+
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+		Type:       GraphicsOpBeginClip,
+		BezierSegs: ellipseSegments(cx, cy, rx, ry),
+	})
+
+	return nil
+}
+
+// BeginClipPolygon begins a clipping region bounded by the closed polygon
+// through vertices (at least 3), using the nonzero winding rule.
+//
+// Call EndClip() after drawing the clipped content.
+//
+// Example:
+//
+//	// Clip a column of text to a slanted edge
+//	page.BeginClipPolygon([]creator.Point{{X: 50, Y: 100}, {X: 300, Y: 100}, {X: 250, Y: 700}, {X: 50, Y: 700}})
+//	// ... draw text ...
+//	page.EndClip()
+func (p *Page) BeginClipPolygon(vertices []Point) error {
+	if len(vertices) < 3 {
+		return errors.New("clip polygon must have at least 3 vertices")
+	}
+
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+		Type:     GraphicsOpBeginClip,
+		Vertices: append([]Point(nil), vertices...),
+	})
+
+	return nil
+}
+
+// EndClip ends a clipping region started by BeginClipRect, BeginClipCircle,
+// BeginClipEllipse or BeginClipPolygon.
+//
+// This restores the graphics state to what it was before the region began.
+// Every BeginClip call MUST have a matching EndClip.
 func (p *Page) EndClip() error {
 	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
 		Type: GraphicsOpEndClip,
@@ -471,6 +586,7 @@ func (p *Page) DrawTextClipped(text string, textX, textY, clipX, clipY, clipW, c
 	}
 
 	// Mark characters as used for font subsetting.
+	text = p.normalize(text)
 	font.UseString(text)
 
 	// Add BeginClip operation.
@@ -575,6 +691,23 @@ func validateRectOptions(opts *RectOptions) error {
 		return errors.New("stroke width must be non-negative")
 	}
 
+	// Validate dash pattern.
+	if opts.Dashed {
+		if err := validateDashArray(opts.DashArray); err != nil {
+			return err
+		}
+	}
+
+	// Validate corner radii.
+	if opts.CornerRadius < 0 {
+		return errors.New("corner radius must be non-negative")
+	}
+	for _, r := range opts.CornerRadii {
+		if r < 0 {
+			return errors.New("corner radius must be non-negative")
+		}
+	}
+
 	// At least one of stroke or fill must be set.
 	if opts.StrokeColor == nil && opts.FillColor == nil && opts.FillGradient == nil {
 		return errors.New("rectangle must have at least stroke, fill color, or gradient")
@@ -616,6 +749,13 @@ func validateCircleOptions(opts *CircleOptions) error {
 		return errors.New("stroke width must be non-negative")
 	}
 
+	// Validate dash pattern.
+	if opts.Dashed {
+		if err := validateDashArray(opts.DashArray); err != nil {
+			return err
+		}
+	}
+
 	// At least one of stroke or fill must be set.
 	if opts.StrokeColor == nil && opts.FillColor == nil && opts.FillGradient == nil {
 		return errors.New("circle must have at least stroke, fill color, or gradient")
@@ -638,8 +778,9 @@ func validateCircleOptions(opts *CircleOptions) error {
 
 // GetLayoutContext creates a LayoutContext for this page.
 //
-// The context is initialized with the cursor at the top-left of the content area
-// (inside margins).
+// The context is initialized with the page's layout cursor: the top-left
+// of the content area (inside margins) unless MoveCursor, KeepTogether or,
+// with SetFlowCursor, a Draw call has moved it.
 //
 // Example:
 //
@@ -647,27 +788,60 @@ func validateCircleOptions(opts *CircleOptions) error {
 //	paragraph := NewParagraph("Hello World")
 //	paragraph.Draw(ctx, page)
 func (p *Page) GetLayoutContext() *LayoutContext {
-	return &LayoutContext{
+	ctx := &LayoutContext{
 		PageWidth:  p.Width(),
 		PageHeight: p.Height(),
 		Margins:    p.margins,
 		CursorX:    p.margins.Left,
 		CursorY:    0, // Top of content area
+
+		normalization: p.textNormalization(),
+	}
+	if p.cursorSet {
+		ctx.SetCursor(p.cursorX, p.cursorY)
 	}
+	return ctx
 }
 
 // Draw renders a Drawable element on the page.
 //
 // This uses the page's layout context and automatically positions
-// the element. The cursor advances after drawing.
+// the element at the layout cursor. By default the cursor does not move,
+// so every Draw call starts at the same position; enable SetFlowCursor
+// to stack consecutive Draw calls top to bottom.
 //
 // Example:
 //
 //	p := NewParagraph("Hello World")
 //	page.Draw(p)
 func (p *Page) Draw(d Drawable) error {
+	if p.flowCursor {
+		return p.drawFlow(d)
+	}
+	return d.Draw(p.GetLayoutContext(), p)
+}
+
+// drawFlow draws d at the layout cursor and moves the cursor below it.
+func (p *Page) drawFlow(d Drawable) error {
 	ctx := p.GetLayoutContext()
-	return d.Draw(ctx, p)
+	if err := d.Draw(ctx, p); err != nil {
+		return err
+	}
+	p.cursorX, p.cursorY, p.cursorSet = ctx.CursorX, ctx.CursorY, true
+	return nil
+}
+
+// SetFlowCursor makes Draw advance the page's layout cursor past each
+// element, so consecutive Draw calls stack elements top to bottom
+// instead of all starting at the cursor. It is disabled by default.
+//
+// Example:
+//
+//	page.SetFlowCursor(true)
+//	page.Draw(title)
+//	page.Draw(body) // Below the title
+func (p *Page) SetFlowCursor(enabled bool) {
+	p.flowCursor = enabled
 }
 
 // DrawAt renders a Drawable element at a specific position.
@@ -687,18 +861,22 @@ func (p *Page) DrawAt(d Drawable, x, y float64) error {
 
 // MoveCursor moves the page's layout cursor to the specified position.
 //
-// This affects subsequent Draw() calls that use the default layout context.
-// Note: This creates a new context each time, so for multiple sequential
-// draws, use GetLayoutContext() and pass it to Draw() on the Drawable directly.
+// This affects subsequent Draw() and KeepTogether() calls that use the
+// page's layout context.
 //
 // x is measured from the left edge of the page.
 // y is measured from the top of the content area (below top margin).
 func (p *Page) MoveCursor(x, y float64) {
-	// Note: Since we create a new context each time in Draw(),
-	// this method primarily exists for API consistency.
-	// For efficient multi-draw operations, use GetLayoutContext() directly.
-	_ = x
-	_ = y
+	p.cursorX, p.cursorY, p.cursorSet = x, y, true
+}
+
+// Cursor returns the page's layout cursor position.
+//
+// x is measured from the left edge of the page.
+// y is measured from the top of the content area (below top margin).
+func (p *Page) Cursor() (x, y float64) {
+	ctx := p.GetLayoutContext()
+	return ctx.CursorX, ctx.CursorY
 }
 
 // Surface creates a new drawing surface for this page.
@@ -790,24 +968,34 @@ func (p *Page) AddInternalLink(text string, destPage int, x, y float64, font Fon
 // addLinkWithStyle is the internal implementation for adding links.
 //
 // This method:
-// 1. Renders the text at the specified position with the given style.
-// 2. Optionally draws an underline below the text.
-// 3. Calculates the bounding rectangle for the clickable area.
-// 4. Creates a LinkAnnotation and adds it to the domain page.
+// 1. Calculates the bounding rectangle for the clickable area.
+// 2. Creates and validates a LinkAnnotation, including its border style.
+// 3. Renders the text at the specified position with the given style.
+// 4. Optionally draws an underline below the text.
+// 5. Adds the annotation to the domain page.
 func (p *Page) addLinkWithStyle(text, url string, destPage int, isInternal bool, x, y float64, style LinkStyle) error {
 	// Validate inputs.
 	if err := validateLinkInputs(text, url, destPage, isInternal, style.Size); err != nil {
 		return err
 	}
 
+	// Measure text width for bounding rect and underline.
+	textWidth := measureTextWidth(string(style.Font), text, style.Size)
+
+	// Calculate bounding rectangle and create annotation. Validate it
+	// first so an invalid border style adds nothing to the page.
+	rect := calculateLinkRect(x, y, textWidth, style.Size)
+	annot := createLinkAnnotation(rect, url, destPage, isInternal)
+	style.applyBorder(annot)
+	if err := annot.Validate(); err != nil {
+		return err
+	}
+
 	// Render the link text with the specified style.
 	if err := p.AddTextColor(text, x, y, style.Font, style.Size, style.Color); err != nil {
 		return err
 	}
 
-	// Measure text width for bounding rect and underline.
-	textWidth := measureTextWidth(string(style.Font), text, style.Size)
-
 	// Draw underline if requested.
 	if style.Underline {
 		if err := p.drawUnderline(x, y, textWidth, style); err != nil {
@@ -815,10 +1003,6 @@ func (p *Page) addLinkWithStyle(text, url string, destPage int, isInternal bool,
 		}
 	}
 
-	// Calculate bounding rectangle and create annotation.
-	rect := calculateLinkRect(x, y, textWidth, style.Size)
-	annot := createLinkAnnotation(rect, url, destPage, isInternal)
-
 	// Add annotation to domain page.
 	return p.page.AddAnnotation(annot)
 }
@@ -962,7 +1146,9 @@ func (p *Page) AddStampAnnotation(annotation *StampAnnotation) error {
 //
 // Supported field types:
 //   - TextField: Single-line or multi-line text input
-//   - (Future: CheckBox, RadioButton, ComboBox, ListBox, PushButton)
+//   - Checkbox: On/off toggle with generated check appearance
+//   - RadioGroup: Mutually exclusive options (one widget per option)
+//   - (Future: ComboBox, ListBox, PushButton)
 //
 // Example:
 //
@@ -970,13 +1156,19 @@ func (p *Page) AddStampAnnotation(annotation *StampAnnotation) error {
 //	field.SetValue("John Doe").SetRequired(true)
 //	page.AddField(field)
 func (p *Page) AddField(field interface{}) error {
-	// Convert creator form field to domain form field
-	domainField, err := convertFieldToDomain(field)
+	// Convert creator form field to domain form field(s).
+	// Radio groups produce one widget per option.
+	domainFields, err := convertFieldToDomain(field)
 	if err != nil {
 		return err
 	}
 
-	return p.page.AddFormField(domainField)
+	for _, domainField := range domainFields {
+		if err := p.page.AddFormField(domainField); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Errors.
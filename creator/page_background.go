@@ -0,0 +1,115 @@
+package creator
+
+import (
+	"errors"
+	"math"
+)
+
+// BackgroundMode controls how SetBackgroundImage sizes an image to the page.
+type BackgroundMode int
+
+const (
+	// BackgroundStretch scales the image to the page size, ignoring its
+	// aspect ratio.
+	BackgroundStretch BackgroundMode = iota
+
+	// BackgroundFit scales the image to fit inside the page, preserving
+	// its aspect ratio, and centers it. Part of the page may stay
+	// uncovered (see SetBackgroundColor).
+	BackgroundFit
+
+	// BackgroundFill scales the image to cover the whole page, preserving
+	// its aspect ratio, and centers it. Parts of the image may fall
+	// outside the page.
+	BackgroundFill
+)
+
+// SetBackgroundColor fills the whole page (its MediaBox) with a color,
+// beneath all other content.
+//
+// The fill is always drawn first, whenever it is set. Calling it again
+// replaces the color. A background image, if any, is drawn over the color.
+//
+// Example:
+//
+//	page.SetBackgroundColor(creator.Color{R: 1, G: 0.98, B: 0.9})
+func (p *Page) SetBackgroundColor(c Color) error {
+	if c.R < 0 || c.R > 1 || c.G < 0 || c.G > 1 || c.B < 0 || c.B > 1 {
+		return errors.New("color components must be in range [0.0, 1.0]")
+	}
+
+	x, y, width, height := p.mediaBox()
+	p.setBackground(&p.backgroundColor, GraphicsOperation{
+		Type:     GraphicsOpRect,
+		X:        x,
+		Y:        y,
+		Width:    width,
+		Height:   height,
+		RectOpts: &RectOptions{FillColor: &c},
+	})
+	return nil
+}
+
+// SetBackgroundImage draws an image over the whole page (its MediaBox),
+// beneath all other content, sized according to mode.
+//
+// The image is always drawn first, whenever it is set. Calling it again
+// replaces the image.
+//
+// Example:
+//
+//	img, _ := creator.LoadImage("letterhead.png")
+//	page.SetBackgroundImage(img, creator.BackgroundFill)
+func (p *Page) SetBackgroundImage(img *Image, mode BackgroundMode) error {
+	if img == nil {
+		return errors.New("image cannot be nil")
+	}
+	if img.width <= 0 || img.height <= 0 {
+		return ErrInvalidImageDimensions
+	}
+
+	x, y, width, height := p.mediaBox()
+	imgW, imgH := float64(img.width), float64(img.height)
+	w, h := width, height
+	switch mode {
+	case BackgroundFit:
+		w, h = calculateFitDimensions(imgW, imgH, width, height)
+	case BackgroundFill:
+		scale := math.Max(width/imgW, height/imgH)
+		w, h = imgW*scale, imgH*scale
+	}
+
+	p.setBackground(&p.backgroundImage, GraphicsOperation{
+		Type:   GraphicsOpImage,
+		X:      x + (width-w)/2,
+		Y:      y + (height-h)/2,
+		Width:  w,
+		Height: h,
+		Image:  img,
+	})
+	return nil
+}
+
+// setBackground replaces the background operation in slot and moves the
+// background operations (color, then image) to the start of the page's
+// graphics operations.
+func (p *Page) setBackground(slot **GraphicsOperation, op GraphicsOperation) {
+	body := p.graphicsOps[p.backgroundOps:]
+	*slot = &op
+
+	ops := make([]GraphicsOperation, 0, 2+len(body))
+	for _, bg := range []*GraphicsOperation{p.backgroundColor, p.backgroundImage} {
+		if bg != nil {
+			ops = append(ops, *bg)
+		}
+	}
+	p.backgroundOps = len(ops)
+	p.graphicsOps = append(ops, body...)
+}
+
+// mediaBox returns the lower-left corner and size of the page's MediaBox.
+func (p *Page) mediaBox() (x, y, width, height float64) {
+	box := p.page.MediaBox()
+	llx, lly := box.LowerLeft()
+	return llx, lly, box.Width(), box.Height()
+}
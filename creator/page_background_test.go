@@ -0,0 +1,79 @@
+package creator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage_SetBackgroundColor(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Content drawn before the background must still render above it.
+	require.NoError(t, page.DrawRect(100, 100, 50, 50, &RectOptions{FillColor: &Red}))
+	require.NoError(t, page.SetBackgroundColor(LightGray))
+	require.NoError(t, page.SetBackgroundColor(Yellow)) // Replaces the first color
+
+	ops := page.GraphicsOperations()
+	require.Len(t, ops, 2)
+	bg := ops[0]
+	assert.Equal(t, GraphicsOpRect, bg.Type)
+	assert.Equal(t, [4]float64{0, 0, 595, 842}, [4]float64{bg.X, bg.Y, bg.Width, bg.Height})
+	assert.Equal(t, Yellow, *bg.RectOpts.FillColor)
+
+	_, graphicsContents := c.collectAllPageContents()
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, graphicsContents[0])
+	require.NoError(t, err)
+	stream := string(content)
+	background := strings.Index(stream, "0 0 595 842 re")
+	rect := strings.Index(stream, "100 100 50 50 re")
+	require.True(t, background >= 0 && rect >= 0, "content stream:\n%s", stream)
+	assert.Less(t, background, rect, "background must be drawn before the page content")
+
+	assert.Error(t, page.SetBackgroundColor(Color{R: 2}))
+}
+
+func TestPage_SetBackgroundImage(t *testing.T) {
+	img := &Image{width: 200, height: 100}
+
+	tests := []struct {
+		name string
+		mode BackgroundMode
+		want [4]float64 // x, y, width, height
+	}{
+		{"stretch", BackgroundStretch, [4]float64{0, 0, 595, 842}},
+		{"fit", BackgroundFit, [4]float64{0, 272.25, 595, 297.5}},
+		{"fill", BackgroundFill, [4]float64{-544.5, 0, 1684, 842}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			require.NoError(t, err)
+			require.NoError(t, page.DrawLine(0, 0, 100, 100, &LineOptions{Color: Black, Width: 1}))
+			require.NoError(t, page.SetBackgroundImage(img, tt.mode))
+			require.NoError(t, page.SetBackgroundColor(White))
+
+			// Color first, then image, then the page content.
+			ops := page.GraphicsOperations()
+			require.Len(t, ops, 3)
+			assert.Equal(t, GraphicsOpRect, ops[0].Type)
+			assert.Equal(t, GraphicsOpImage, ops[1].Type)
+			assert.Equal(t, GraphicsOpLine, ops[2].Type)
+			assert.Equal(t, tt.want, [4]float64{ops[1].X, ops[1].Y, ops[1].Width, ops[1].Height})
+		})
+	}
+
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	assert.Error(t, page.SetBackgroundImage(nil, BackgroundFill))
+	assert.Error(t, page.SetBackgroundImage(&Image{}, BackgroundFill))
+	assert.Empty(t, page.GraphicsOperations())
+}
@@ -0,0 +1,91 @@
+package creator
+
+import (
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// PageLabelStyle is the numbering style of a page label range.
+type PageLabelStyle string
+
+// Page label styles.
+const (
+	// PrefixOnly labels pages with the range prefix alone.
+	PrefixOnly PageLabelStyle = PageLabelStyle(document.PageLabelNone)
+
+	// DecimalArabic numbers pages 1, 2, 3.
+	DecimalArabic PageLabelStyle = PageLabelStyle(document.PageLabelDecimal)
+
+	// UppercaseRoman numbers pages I, II, III.
+	UppercaseRoman PageLabelStyle = PageLabelStyle(document.PageLabelRomanUpper)
+
+	// LowercaseRoman numbers pages i, ii, iii.
+	LowercaseRoman PageLabelStyle = PageLabelStyle(document.PageLabelRomanLower)
+
+	// UppercaseLetters numbers pages A..Z, then AA..ZZ.
+	UppercaseLetters PageLabelStyle = PageLabelStyle(document.PageLabelAlphaUpper)
+
+	// LowercaseLetters numbers pages a..z, then aa..zz.
+	LowercaseLetters PageLabelStyle = PageLabelStyle(document.PageLabelAlphaLower)
+)
+
+// PageLabelRange labels a run of pages, from PageIndex up to the page
+// before the next range (or the last page).
+type PageLabelRange struct {
+	// PageIndex is the first page of the range (0-based).
+	PageIndex int
+
+	// Style is the numbering style.
+	Style PageLabelStyle
+
+	// Prefix is put before each number, e.g. "A-" for A-1, A-2.
+	Prefix string
+
+	// Start is the number of the first page (0 = 1).
+	Start int
+}
+
+// ErrInvalidPageLabel is returned by SetPageLabels for invalid ranges.
+var ErrInvalidPageLabel = document.ErrInvalidPageLabel
+
+// SetPageLabels sets the labels viewers show for the pages in their page
+// navigator (/PageLabels), instead of the plain page numbers.
+//
+// The first range must start at page 0, and the ranges must be sorted by
+// PageIndex with no two starting on the same page. Passing nil removes
+// the labels.
+//
+// Example:
+//
+//	// Front matter i, ii, iii, then body 1, 2, 3, then appendix A-1, A-2
+//	err := c.SetPageLabels([]creator.PageLabelRange{
+//	    {PageIndex: 0, Style: creator.LowercaseRoman},
+//	    {PageIndex: 3, Style: creator.DecimalArabic},
+//	    {PageIndex: 40, Style: creator.DecimalArabic, Prefix: "A-"},
+//	})
+func (c *Creator) SetPageLabels(ranges []PageLabelRange) error {
+	labels := make([]document.PageLabelRange, 0, len(ranges))
+	for i, r := range ranges {
+		switch {
+		case i == 0 && r.PageIndex != 0:
+			return fmt.Errorf("%w: first range starts at page %d, want 0", ErrInvalidPageLabel, r.PageIndex)
+		case i > 0 && r.PageIndex <= ranges[i-1].PageIndex:
+			return fmt.Errorf("%w: range at page %d follows range at page %d",
+				ErrInvalidPageLabel, r.PageIndex, ranges[i-1].PageIndex)
+		}
+		switch r.Style {
+		case PrefixOnly, DecimalArabic, UppercaseRoman, LowercaseRoman, UppercaseLetters, LowercaseLetters:
+		default:
+			return fmt.Errorf("%w: unknown style %q", ErrInvalidPageLabel, r.Style)
+		}
+
+		labels = append(labels, document.PageLabelRange{
+			PageIndex: r.PageIndex,
+			Style:     document.PageLabelStyle(r.Style),
+			Prefix:    r.Prefix,
+			Start:     r.Start,
+		})
+	}
+	return c.doc.SetPageLabels(labels)
+}
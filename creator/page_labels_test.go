@@ -0,0 +1,128 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/reader"
+)
+
+func TestSetPageLabels(t *testing.T) {
+	c := New()
+	for i := 0; i < 6; i++ {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("NewPage failed: %v", err)
+		}
+	}
+
+	// Front matter i, ii; body 1..3; appendix A-3.
+	if err := c.SetPageLabels([]PageLabelRange{
+		{PageIndex: 0, Style: LowercaseRoman},
+		{PageIndex: 2, Style: DecimalArabic},
+		{PageIndex: 5, Style: UppercaseLetters, Prefix: "A-", Start: 3},
+	}); err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	want := "/PageLabels << /Nums [0 << /S /r >> 2 << /S /D >> 5 << /S /A /P (A-) /St 3 >>] >>"
+	if !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Errorf("catalog should contain %q", want)
+	}
+
+	path := filepath.Join(t.TempDir(), "labels.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile failed: %v", err)
+	}
+	r, err := reader.NewPdfReader(path)
+	if err != nil {
+		t.Fatalf("NewPdfReader failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	labels := r.PageLabels()
+	if len(labels) != 3 {
+		t.Fatalf("PageLabels = %+v, want 3 ranges", labels)
+	}
+	doc := document.NewDocument()
+	if err := doc.SetPageLabels(labels); err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+	for i, want := range []string{"i", "ii", "1", "2", "3", "A-C"} {
+		r, n, _ := doc.PageLabelAt(i)
+		if got := document.FormatPageLabel(r.Style, r.Prefix, n); got != want {
+			t.Errorf("label of page %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestSetPageLabels_NonASCIIPrefix(t *testing.T) {
+	c := New()
+	for i := 0; i < 2; i++ {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("NewPage failed: %v", err)
+		}
+	}
+	if err := c.SetPageLabels([]PageLabelRange{
+		{PageIndex: 0, Style: DecimalArabic, Prefix: "Ü-"},
+	}); err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "labels.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile failed: %v", err)
+	}
+	r, err := reader.NewPdfReader(path)
+	if err != nil {
+		t.Fatalf("NewPdfReader failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	// The prefix is a UTF-16BE text string, not raw UTF-8 bytes.
+	labels := r.PageLabels()
+	if len(labels) != 1 || labels[0].Prefix != "Ü-" {
+		t.Fatalf("PageLabels = %+v, want prefix %q", labels, "Ü-")
+	}
+	doc := document.NewDocument()
+	if err := doc.SetPageLabels(labels); err != nil {
+		t.Fatalf("SetPageLabels failed: %v", err)
+	}
+	for i, want := range []string{"Ü-1", "Ü-2"} {
+		r, n, _ := doc.PageLabelAt(i)
+		if got := document.FormatPageLabel(r.Style, r.Prefix, n); got != want {
+			t.Errorf("label of page %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestSetPageLabels_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []PageLabelRange
+	}{
+		{"not from first page", []PageLabelRange{{PageIndex: 1, Style: DecimalArabic}}},
+		{"unsorted", []PageLabelRange{{PageIndex: 0}, {PageIndex: 4}, {PageIndex: 2}}},
+		{"same page", []PageLabelRange{{PageIndex: 0}, {PageIndex: 2}, {PageIndex: 2}}},
+		{"unknown style", []PageLabelRange{{PageIndex: 0, Style: "X"}}},
+		{"negative start", []PageLabelRange{{PageIndex: 0, Start: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			if err := c.SetPageLabels(tt.ranges); !errors.Is(err, ErrInvalidPageLabel) {
+				t.Errorf("SetPageLabels() error = %v, want ErrInvalidPageLabel", err)
+			}
+			if labels := c.doc.PageLabels(); labels != nil {
+				t.Errorf("labels = %+v, want none after an error", labels)
+			}
+		})
+	}
+}
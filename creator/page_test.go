@@ -3,6 +3,7 @@ package creator
 import (
 	"testing"
 
+	"github.com/coregx/gxpdf/creator/forms"
 	"github.com/coregx/gxpdf/internal/document"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -206,3 +207,33 @@ func TestPageSize_ToDomainSize(t *testing.T) {
 		})
 	}
 }
+
+func TestPage_AddField_CheckboxAndRadio(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	agree := forms.NewCheckbox("agree", 100, 700, 15, 15)
+	agree.SetChecked(true)
+	require.NoError(t, page.AddField(agree))
+
+	size := forms.NewRadioGroup("size")
+	size.AddOption("s", 100, 650, "Small")
+	size.AddOption("l", 150, 650, "Large")
+	require.NoError(t, size.SetSelected("l"))
+	require.NoError(t, page.AddField(size))
+
+	// One checkbox widget plus one widget per radio option.
+	assert.Len(t, page.page.FormFields(), 3)
+
+	pdfBytes, err := c.Bytes()
+	require.NoError(t, err)
+	pdf := string(pdfBytes)
+
+	assert.Contains(t, pdf, "/AcroForm")
+	assert.Contains(t, pdf, "/T (agree) /V /Yes")
+	assert.Contains(t, pdf, "/AS /Yes")
+	assert.Contains(t, pdf, "/AS /l")
+	assert.Contains(t, pdf, "/AS /Off /AP << /N << /s ")
+	assert.Contains(t, pdf, "/T (size) /Ff 49152 /V /l /Kids [")
+}
@@ -113,14 +113,14 @@ func (p *Paragraph) SetText(text string) *Paragraph {
 
 // Height calculates the total height of the paragraph when rendered.
 func (p *Paragraph) Height(ctx *LayoutContext) float64 {
-	lines := p.wrapText(ctx.AvailableWidth())
+	lines := p.normalized(ctx.normalization).wrapText(ctx.AvailableWidth())
 	lineHeight := p.calculateLineHeight()
 	return float64(len(lines)) * lineHeight
 }
 
 // Draw renders the paragraph on the page at the current cursor position.
 func (p *Paragraph) Draw(ctx *LayoutContext, page *Page) error {
-	lines := p.wrapText(ctx.AvailableWidth())
+	lines := p.normalized(ctx.normalization).wrapText(ctx.AvailableWidth())
 	lineHeight := p.calculateLineHeight()
 
 	for _, line := range lines {
@@ -160,6 +160,17 @@ func (p *Paragraph) calculateLineX(ctx *LayoutContext, line string) float64 {
 	}
 }
 
+// normalized returns p, or a copy of p with normalized text.
+func (p *Paragraph) normalized(n Normalization) *Paragraph {
+	text := n.normalize(p.text)
+	if text == p.text {
+		return p
+	}
+	cp := *p
+	cp.text = text
+	return &cp
+}
+
 // wrapText breaks the text into lines that fit within the given width.
 func (p *Paragraph) wrapText(availableWidth float64) []string {
 	if p.text == "" {
@@ -171,14 +182,20 @@ func (p *Paragraph) wrapText(availableWidth float64) []string {
 		return []string{}
 	}
 
-	spaceWidth := fonts.MeasureString(string(p.font), " ", p.fontSize)
+	return wrapWords(words, p.font, p.fontSize, availableWidth)
+}
+
+// wrapWords breaks words into lines that fit within maxWidth when joined by
+// single spaces. A word wider than maxWidth is placed on a line of its own.
+func wrapWords(words []string, font FontName, size, maxWidth float64) []string {
+	spaceWidth := fonts.MeasureString(string(font), " ", size)
 
 	var lines []string
 	var currentLine []string
 	var currentWidth float64
 
 	for _, word := range words {
-		wordWidth := fonts.MeasureString(string(p.font), word, p.fontSize)
+		wordWidth := fonts.MeasureString(string(font), word, size)
 
 		// Check if adding this word exceeds available width.
 		newWidth := currentWidth + wordWidth
@@ -186,7 +203,7 @@ func (p *Paragraph) wrapText(availableWidth float64) []string {
 			newWidth += spaceWidth
 		}
 
-		if newWidth > availableWidth && len(currentLine) > 0 {
+		if newWidth > maxWidth && len(currentLine) > 0 {
 			// Start a new line.
 			lines = append(lines, strings.Join(currentLine, " "))
 			currentLine = []string{word}
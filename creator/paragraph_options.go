@@ -0,0 +1,148 @@
+package creator
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// ParagraphOptions configures wrapped text for AddParagraph.
+//
+// Example:
+//
+//	opts := &creator.ParagraphOptions{
+//	    Font:       creator.TimesRoman,
+//	    Size:       11,
+//	    LineHeight: 14,
+//	    Align:      creator.AlignJustify,
+//	}
+type ParagraphOptions struct {
+	// Font is the Standard 14 font (default: Helvetica).
+	Font FontName
+
+	// Size is the font size in points (default: 12).
+	Size float64
+
+	// Color is the text color (RGB, 0.0 to 1.0 range, default black).
+	Color Color
+
+	// LineHeight is the distance between successive baselines in points
+	// (default: 1.2 × Size).
+	LineHeight float64
+
+	// Align places each line within maxWidth (default: AlignLeft).
+	// AlignJustify widens the spaces between words so that every line
+	// fills maxWidth, except the last line before a break or the end.
+	Align Alignment
+}
+
+// AddParagraph adds text wrapped to fit within maxWidth.
+//
+// Lines are broken at word boundaries using the font's glyph widths; a
+// word wider than maxWidth gets a line of its own. Runs of spaces and
+// tabs collapse to one space, and each "\n" starts a new line (an empty
+// line for consecutive breaks). The first baseline is at y and each
+// further line is LineHeight below the previous one. Lines are aligned
+// within [x, x+maxWidth] according to opts.Align.
+//
+// Returns the height consumed, the number of lines times LineHeight, so
+// that callers can place the next content below the paragraph.
+//
+// Parameters:
+//   - text: The text to display
+//   - x: Left edge of the lines in points
+//   - y: Baseline of the first line in points (from bottom edge)
+//   - maxWidth: Maximum line width in points
+//   - opts: Font, size, color and line height (nil = defaults)
+//
+// Example:
+//
+//	h, err := page.AddParagraph(body, 72, 700, 468, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	nextY := 700 - h
+func (p *Page) AddParagraph(text string, x, y, maxWidth float64, opts *ParagraphOptions) (float64, error) {
+	if opts == nil {
+		opts = &ParagraphOptions{}
+	}
+	if maxWidth <= 0 {
+		return 0, errors.New("paragraph width must be positive")
+	}
+	if opts.Size < 0 {
+		return 0, errors.New("font size must be positive")
+	}
+	if opts.LineHeight < 0 {
+		return 0, errors.New("line height must be non-negative")
+	}
+
+	font := opts.Font
+	if font == "" {
+		font = Helvetica
+	}
+	size := opts.Size
+	if size == 0 {
+		size = 12
+	}
+	lineHeight := opts.LineHeight
+	if lineHeight == 0 {
+		lineHeight = size * 1.2
+	}
+
+	lines := paragraphLines(p.normalize(text), font, size, maxWidth)
+	for i, line := range lines {
+		if line.text == "" {
+			continue
+		}
+
+		width := fonts.MeasureString(string(font), line.text, size)
+		start := x
+		var wordSpacing float64
+		switch opts.Align {
+		case AlignCenter:
+			start = x + (maxWidth-width)/2
+		case AlignRight:
+			start = x + maxWidth - width
+		case AlignJustify:
+			if gaps := strings.Count(line.text, " "); gaps > 0 && !line.last {
+				wordSpacing = (maxWidth - width) / float64(gaps)
+			}
+		}
+
+		if err := p.AddTextColor(line.text, start, y-float64(i)*lineHeight, font, size, opts.Color); err != nil {
+			return 0, err
+		}
+		p.textOps[len(p.textOps)-1].WordSpacing = wordSpacing
+	}
+
+	return float64(len(lines)) * lineHeight, nil
+}
+
+// paragraphLine is one line laid out by AddParagraph.
+type paragraphLine struct {
+	text string
+	last bool // Last line before a hard break or the end (not justified)
+}
+
+// paragraphLines breaks text into lines for AddParagraph. Each "\n"
+// separated part is wrapped on its own; an empty part is an empty line.
+func paragraphLines(text string, font FontName, size, maxWidth float64) []paragraphLine {
+	if text == "" {
+		return nil
+	}
+
+	var lines []paragraphLine
+	for _, part := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(part)
+		if len(words) == 0 {
+			lines = append(lines, paragraphLine{last: true})
+			continue
+		}
+		wrapped := wrapWords(words, font, size, maxWidth)
+		for i, line := range wrapped {
+			lines = append(lines, paragraphLine{text: line, last: i == len(wrapped)-1})
+		}
+	}
+	return lines
+}
@@ -0,0 +1,194 @@
+package creator
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+func TestAddParagraph_BreakPoints(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	// Helvetica 12pt: "The quick brown" is 88.03pt wide; adding " fox"
+	// would make it 107.38pt, so the first line breaks before "fox".
+	height, err := page.AddParagraph("The quick brown fox jumps over the lazy dog", 72, 700, 90, nil)
+	if err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+
+	ops := page.TextOperations()
+	var lines []string
+	for _, op := range ops {
+		lines = append(lines, op.Text)
+	}
+	want := []string{"The quick brown", "fox jumps over", "the lazy dog"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+
+	// Default line height is 1.2 × 12pt.
+	if math.Abs(height-43.2) > 1e-9 {
+		t.Errorf("height = %v, want 43.2", height)
+	}
+	for i, op := range ops {
+		if wantY := 700 - float64(i)*14.4; math.Abs(op.Y-wantY) > 1e-9 || op.X != 72 {
+			t.Errorf("line %d at (%v, %v), want (72, %v)", i, op.X, op.Y, wantY)
+		}
+		if op.Font != Helvetica || op.Size != 12 {
+			t.Errorf("line %d uses %s %vpt, want Helvetica 12pt", i, op.Font, op.Size)
+		}
+	}
+}
+
+func TestAddParagraph_HardBreaksAndSpaces(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	opts := &ParagraphOptions{Font: TimesRoman, Size: 10, LineHeight: 15}
+	height, err := page.AddParagraph("Title\n\nBody   text\twith    spaces", 50, 500, 400, opts)
+	if err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+
+	// The empty line between the breaks takes space but emits no text.
+	if height != 45 {
+		t.Errorf("height = %v, want 45", height)
+	}
+	ops := page.TextOperations()
+	if len(ops) != 2 {
+		t.Fatalf("expected 2 text operations, got %d", len(ops))
+	}
+	if ops[0].Text != "Title" || ops[0].Y != 500 {
+		t.Errorf("first line = %q at y=%v, want \"Title\" at y=500", ops[0].Text, ops[0].Y)
+	}
+	if ops[1].Text != "Body text with spaces" || ops[1].Y != 470 {
+		t.Errorf("second line = %q at y=%v, want \"Body text with spaces\" at y=470", ops[1].Text, ops[1].Y)
+	}
+}
+
+func TestAddParagraph_LongWord(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	height, err := page.AddParagraph("a supercalifragilistic b", 0, 100, 30, &ParagraphOptions{LineHeight: 10})
+	if err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+	if height != 30 {
+		t.Errorf("height = %v, want 30", height)
+	}
+	if ops := page.TextOperations(); len(ops) != 3 || ops[1].Text != "supercalifragilistic" {
+		t.Errorf("a word wider than the paragraph should get its own line, got %+v", ops)
+	}
+}
+
+func TestAddParagraph_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxWidth float64
+		opts     *ParagraphOptions
+	}{
+		{"zero width", 0, nil},
+		{"negative size", 100, &ParagraphOptions{Size: -1}},
+		{"negative line height", 100, &ParagraphOptions{LineHeight: -1}},
+		{"invalid color", 100, &ParagraphOptions{Color: Color{R: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, _ := c.NewPage()
+			if _, err := page.AddParagraph("Hello", 72, 700, tt.maxWidth, tt.opts); err == nil {
+				t.Error("AddParagraph() expected error")
+			}
+		})
+	}
+}
+
+func TestAddParagraph_Empty(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	height, err := page.AddParagraph("", 72, 700, 200, nil)
+	if err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+	if height != 0 || len(page.TextOperations()) != 0 {
+		t.Errorf("empty text should consume no height, got %v", height)
+	}
+}
+
+func TestAddParagraph_Align(t *testing.T) {
+	// Helvetica 12pt in 90pt: "The quick brown" (88.032pt), "fox jumps
+	// over" (78.024pt), "the lazy dog" (64.704pt).
+	tests := []struct {
+		name  string
+		align Alignment
+		x     []float64
+	}{
+		{"left", AlignLeft, []float64{72, 72, 72}},
+		{"center", AlignCenter, []float64{72 + (90-88.032)/2, 72 + (90-78.024)/2, 72 + (90-64.704)/2}},
+		{"right", AlignRight, []float64{72 + 90 - 88.032, 72 + 90 - 78.024, 72 + 90 - 64.704}},
+		{"justify", AlignJustify, []float64{72, 72, 72}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, _ := c.NewPage()
+			if _, err := page.AddParagraph("The quick brown fox jumps over the lazy dog", 72, 700, 90,
+				&ParagraphOptions{Align: tt.align}); err != nil {
+				t.Fatalf("AddParagraph() error = %v", err)
+			}
+
+			ops := page.TextOperations()
+			if len(ops) != len(tt.x) {
+				t.Fatalf("expected %d lines, got %d", len(tt.x), len(ops))
+			}
+			for i, op := range ops {
+				if math.Abs(op.X-tt.x[i]) > 1e-9 {
+					t.Errorf("line %d starts at x=%v, want %v", i, op.X, tt.x[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAddParagraph_Justify(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+	if _, err := page.AddParagraph("The quick brown fox jumps over the lazy dog\nEnd of story", 72, 700, 90,
+		&ParagraphOptions{Align: AlignJustify}); err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+
+	// Two gaps share the free space of each full line; the last line of
+	// each part is left as is.
+	ops := page.TextOperations()
+	wantSpacing := []float64{(90 - 88.032) / 2, (90 - 78.024) / 2, 0, 0}
+	if len(ops) != len(wantSpacing) {
+		t.Fatalf("expected %d lines, got %d", len(wantSpacing), len(ops))
+	}
+	for i, op := range ops {
+		if math.Abs(op.WordSpacing-wantSpacing[i]) > 1e-9 {
+			t.Errorf("line %d word spacing = %v, want %v", i, op.WordSpacing, wantSpacing[i])
+		}
+	}
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(ops))
+	if err != nil {
+		t.Fatalf("GenerateContentStream() error = %v", err)
+	}
+	for _, want := range []string{"0.98 Tw\n(The quick brown) Tj\n0 Tw\n", "5.99 Tw\n(fox jumps over) Tj\n0 Tw\n"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected %q in content:\n%s", want, content)
+		}
+	}
+	if strings.Count(string(content), " Tw\n") != 4 {
+		t.Errorf("only the two justified lines should set word spacing:\n%s", content)
+	}
+}
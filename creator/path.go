@@ -3,6 +3,8 @@ package creator
 import (
 	"fmt"
 	"math"
+
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 // Path represents a vector path for drawing, filling, and clipping.
@@ -619,3 +621,33 @@ func (p *Path) toPDFOperators() string {
 	}
 	return result
 }
+
+// segments converts the path to writer path segments. Rectangles are
+// expanded into closed subpaths.
+func (p *Path) segments() []writer.PathSegment {
+	segs := make([]writer.PathSegment, 0, len(p.commands))
+	pt := func(x, y float64) writer.Point { return writer.Point{X: x, Y: y} }
+	for _, cmd := range p.commands {
+		a := cmd.args
+		switch cmd.op {
+		case pathOpMoveTo:
+			segs = append(segs, writer.PathSegment{Op: 'm', End: pt(a[0], a[1])})
+		case pathOpLineTo:
+			segs = append(segs, writer.PathSegment{Op: 'l', End: pt(a[0], a[1])})
+		case pathOpCubicTo:
+			segs = append(segs, writer.PathSegment{Op: 'c', C1: pt(a[0], a[1]), C2: pt(a[2], a[3]), End: pt(a[4], a[5])})
+		case pathOpClose:
+			segs = append(segs, writer.PathSegment{Op: 'h'})
+		case pathOpRect:
+			x, y, w, h := a[0], a[1], a[2], a[3]
+			segs = append(segs,
+				writer.PathSegment{Op: 'm', End: pt(x, y)},
+				writer.PathSegment{Op: 'l', End: pt(x+w, y)},
+				writer.PathSegment{Op: 'l', End: pt(x+w, y+h)},
+				writer.PathSegment{Op: 'l', End: pt(x, y+h)},
+				writer.PathSegment{Op: 'h'},
+			)
+		}
+	}
+	return segs
+}
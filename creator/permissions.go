@@ -0,0 +1,108 @@
+package creator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// Permissions describes what readers may do with the document.
+//
+// It is a named-field alternative to combining Permission flags and is
+// applied with SetPermissions. The zero value denies everything except
+// text extraction for accessibility, which is always allowed.
+//
+// Example:
+//
+//	c.SetPermissions(creator.Permissions{
+//	    AllowPrint: true, // Viewable and printable, but not copyable
+//	})
+type Permissions struct {
+	// AllowPrint allows printing (possibly at degraded quality).
+	AllowPrint bool
+
+	// AllowCopy allows copying text and graphics.
+	AllowCopy bool
+
+	// AllowModify allows modifying the document, including inserting,
+	// rotating and deleting pages.
+	AllowModify bool
+
+	// AllowAnnotate allows adding or modifying annotations and filling
+	// form fields.
+	AllowAnnotate bool
+
+	// AllowHighResPrint allows faithful high-quality printing.
+	// It implies AllowPrint.
+	AllowHighResPrint bool
+}
+
+// Flags returns the permissions as Permission flags.
+//
+// Reference: PDF 1.7 Specification, Section 7.6.3.2 (Table 22 - User access permissions).
+func (p Permissions) Flags() Permission {
+	flags := PermissionExtract
+	if p.AllowPrint || p.AllowHighResPrint {
+		flags |= PermissionPrint
+	}
+	if p.AllowHighResPrint {
+		flags |= PermissionPrintHighQuality
+	}
+	if p.AllowCopy {
+		flags |= PermissionCopy
+	}
+	if p.AllowModify {
+		flags |= PermissionModify | PermissionAssemble
+	}
+	if p.AllowAnnotate {
+		flags |= PermissionAnnotate | PermissionFillForms
+	}
+	return flags
+}
+
+// SetPermissions restricts what readers may do with the document.
+//
+// PDF permissions are enforced through the security handler, so they
+// require encryption. If SetEncryption was already called, only its
+// Permissions are replaced. Otherwise the document is encrypted with
+// AES-128, an empty user password and a random owner password: anyone
+// can open and view it, but conforming readers apply the restrictions.
+//
+// Example:
+//
+//	c := creator.New()
+//	c.SetPermissions(creator.Permissions{AllowPrint: true})
+//	c.WriteToFile("restricted.pdf")
+//
+// Note: the restrictions are advisory. A document without a user password
+// can be decrypted by anyone, so use SetEncryption with a user password to
+// keep content confidential.
+func (c *Creator) SetPermissions(perms Permissions) error {
+	if c.encryptionOpts != nil {
+		c.encryptionOpts.Permissions = perms.Flags()
+		return nil
+	}
+
+	owner, err := randomOwnerPassword()
+	if err != nil {
+		return err
+	}
+
+	return c.SetEncryption(EncryptionOptions{
+		OwnerPassword: owner,
+		Permissions:   perms.Flags(),
+		Algorithm:     EncryptionAES128,
+	})
+}
+
+// randomOwnerPassword returns a random owner password.
+//
+// An empty owner password would default to the (empty) user password and
+// grant full access to everyone.
+func randomOwnerPassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate owner password: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,88 @@
+package creator
+
+import "testing"
+
+func TestPermissions_Flags(t *testing.T) {
+	tests := []struct {
+		name  string
+		perms Permissions
+		want  Permission
+	}{
+		{"none", Permissions{}, PermissionExtract},
+		{"print", Permissions{AllowPrint: true}, PermissionExtract | PermissionPrint},
+		{"high-res print implies print", Permissions{AllowHighResPrint: true},
+			PermissionExtract | PermissionPrint | PermissionPrintHighQuality},
+		{"copy", Permissions{AllowCopy: true}, PermissionExtract | PermissionCopy},
+		{"modify", Permissions{AllowModify: true}, PermissionExtract | PermissionModify | PermissionAssemble},
+		{"annotate", Permissions{AllowAnnotate: true}, PermissionExtract | PermissionAnnotate | PermissionFillForms},
+		{"all", Permissions{
+			AllowPrint: true, AllowCopy: true, AllowModify: true,
+			AllowAnnotate: true, AllowHighResPrint: true,
+		}, PermissionAll},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.perms.Flags(); got != tt.want {
+				t.Errorf("Flags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreator_SetPermissions_WithoutEncryption(t *testing.T) {
+	c := New()
+	if err := c.SetPermissions(Permissions{AllowPrint: true}); err != nil {
+		t.Fatalf("SetPermissions() error = %v", err)
+	}
+
+	opts := c.encryptionOpts
+	if opts == nil {
+		t.Fatal("SetPermissions() did not enable encryption")
+	}
+	if opts.UserPassword != "" {
+		t.Errorf("UserPassword = %q, want empty", opts.UserPassword)
+	}
+	if opts.OwnerPassword == "" {
+		t.Error("OwnerPassword should be generated")
+	}
+	if opts.Algorithm != EncryptionAES128 {
+		t.Errorf("Algorithm = %v, want %v", opts.Algorithm, EncryptionAES128)
+	}
+	if !opts.Permissions.Has(PermissionPrint) || opts.Permissions.Has(PermissionCopy) {
+		t.Errorf("Permissions = %v, want print without copy", opts.Permissions)
+	}
+
+	other := New()
+	if err := other.SetPermissions(Permissions{}); err != nil {
+		t.Fatalf("SetPermissions() error = %v", err)
+	}
+	if other.encryptionOpts.OwnerPassword == opts.OwnerPassword {
+		t.Error("owner passwords should be random")
+	}
+}
+
+func TestCreator_SetPermissions_KeepsEncryption(t *testing.T) {
+	c := New()
+	err := c.SetEncryption(EncryptionOptions{
+		UserPassword:  "user",
+		OwnerPassword: "owner",
+		Permissions:   PermissionAll,
+		Algorithm:     EncryptionAES256,
+	})
+	if err != nil {
+		t.Fatalf("SetEncryption() error = %v", err)
+	}
+
+	if err := c.SetPermissions(Permissions{AllowCopy: true}); err != nil {
+		t.Fatalf("SetPermissions() error = %v", err)
+	}
+
+	opts := c.encryptionOpts
+	if opts.UserPassword != "user" || opts.OwnerPassword != "owner" || opts.Algorithm != EncryptionAES256 {
+		t.Errorf("SetPermissions() changed encryption settings: %+v", opts)
+	}
+	if want := PermissionExtract | PermissionCopy; opts.Permissions != want {
+		t.Errorf("Permissions = %v, want %v", opts.Permissions, want)
+	}
+}
@@ -48,11 +48,20 @@ type PolygonOptions struct {
 	// Only used when Dashed is true.
 	DashPhase float64
 
+	// FillRule decides which parts of a self-intersecting polygon are
+	// inside (default: FillRuleNonZero). With FillRuleEvenOdd, regions
+	// enclosed an even number of times, such as the center of a
+	// pentagram, are left unfilled.
+	FillRule FillRule
+
 	// Opacity is the polygon opacity (0.0 = transparent, 1.0 = opaque).
 	// Optional. If set, applies transparency via ExtGState.
 	// Affects both fill and stroke.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
 }
 
 // DrawPolygon draws a closed polygon through the specified vertices.
@@ -127,6 +136,10 @@ func validatePolygonOptions(opts *PolygonOptions) error {
 		return errors.New("stroke width must be non-negative")
 	}
 
+	if err := validateFillRule(opts.FillRule); err != nil {
+		return err
+	}
+
 	// At least one of stroke or fill must be set
 	if opts.StrokeColor == nil && opts.FillColor == nil && opts.FillGradient == nil {
 		return errors.New("polygon must have at least stroke, fill color, or gradient")
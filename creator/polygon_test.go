@@ -1,7 +1,10 @@
 package creator
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 func TestDrawPolygon(t *testing.T) {
@@ -230,3 +233,31 @@ func TestPolygonComplexShapes(t *testing.T) {
 		t.Errorf("expected 10 vertices, got %d", len(ops[0].Vertices))
 	}
 }
+
+func TestDrawPolygon_FillRule(t *testing.T) {
+	// A pentagram crosses itself; even-odd leaves its center unfilled.
+	star := []Point{{X: 150, Y: 250}, {X: 210, Y: 70}, {X: 60, Y: 180}, {X: 240, Y: 180}, {X: 90, Y: 70}}
+
+	content := func(opts *PolygonOptions) string {
+		c := New()
+		page, _ := c.NewPage()
+		if err := page.DrawPolygon(star, opts); err != nil {
+			t.Fatalf("DrawPolygon() error = %v", err)
+		}
+		out, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+		if err != nil {
+			t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+		}
+		return string(out)
+	}
+
+	if got := content(&PolygonOptions{FillColor: &Red, FillRule: FillRuleEvenOdd}); !strings.Contains(got, "f*\n") {
+		t.Errorf("expected even-odd fill, got:\n%s", got)
+	}
+	if got := content(&PolygonOptions{FillColor: &Red, StrokeColor: &Black, FillRule: FillRuleEvenOdd}); !strings.Contains(got, "B*\n") {
+		t.Errorf("expected even-odd fill and stroke, got:\n%s", got)
+	}
+	if got := content(&PolygonOptions{FillColor: &Red}); strings.Contains(got, "f*") {
+		t.Errorf("expected nonzero fill by default, got:\n%s", got)
+	}
+}
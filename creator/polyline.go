@@ -28,10 +28,25 @@ type PolylineOptions struct {
 	// Only used when Dashed is true.
 	DashPhase float64
 
+	// LineCap is the style of the line ends (default: LineCapButt).
+	LineCap LineCap
+
+	// LineJoin is the style of the corners between segments
+	// (default: LineJoinMiter).
+	LineJoin LineJoin
+
+	// MiterLimit limits the length of miter joins as a ratio of the
+	// line width; longer miters are beveled. Only used with LineJoinMiter.
+	// Must be at least 1.0 (0 = PDF default of 10).
+	MiterLimit float64
+
 	// Opacity is the polyline opacity (0.0 = transparent, 1.0 = opaque).
 	// Optional. If set, applies transparency via ExtGState.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
 }
 
 // DrawPolyline draws an open path through the specified vertices.
@@ -93,5 +108,5 @@ func validatePolylineOptions(opts *PolylineOptions) error {
 		return errors.New("line width must be non-negative")
 	}
 
-	return nil
+	return validateLineStyle(opts.LineCap, opts.LineJoin, opts.MiterLimit)
 }
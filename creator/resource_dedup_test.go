@@ -0,0 +1,137 @@
+package creator
+
+import (
+	"bytes"
+	"compress/flate"
+	"image/color"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWrite_SharesIdenticalImages(t *testing.T) {
+	logoData := createPNGData(t, 32, 32, color.RGBA{R: 200, G: 30, B: 30, A: 128})
+	otherData := createJPEGData(t, 32, 32, color.RGBA{B: 255, A: 255})
+
+	c := New()
+	for i := 0; i < 3; i++ {
+		// Load the logo anew for each page: sharing is by content.
+		logo, err := LoadImageFromReader(bytes.NewReader(logoData))
+		if err != nil {
+			t.Fatalf("LoadImageFromReader() error = %v", err)
+		}
+		page, _ := c.NewPage()
+		if err := page.DrawImage(logo, 50, 750, 64, 64); err != nil {
+			t.Fatalf("DrawImage() error = %v", err)
+		}
+		if i == 2 {
+			other, err := LoadImageFromReader(bytes.NewReader(otherData))
+			if err != nil {
+				t.Fatalf("LoadImageFromReader() error = %v", err)
+			}
+			if err := page.DrawImage(other, 150, 750, 64, 64); err != nil {
+				t.Fatalf("DrawImage() error = %v", err)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	pdf := buf.String()
+
+	// The logo and its soft mask once, plus the other image.
+	if n := strings.Count(pdf, "/Subtype /Image"); n != 3 {
+		t.Errorf("PDF has %d image objects, want 3 (logo, its SMask, other image)", n)
+	}
+	refs := regexp.MustCompile(`/Im1 (\d+) 0 R`).FindAllStringSubmatch(pdf, -1)
+	if len(refs) != 3 {
+		t.Fatalf("found %d /Im1 resources, want 3", len(refs))
+	}
+	for _, ref := range refs[1:] {
+		if ref[1] != refs[0][1] {
+			t.Errorf("pages reference logo objects %s and %s, want one shared object", refs[0][1], ref[1])
+		}
+	}
+}
+
+func TestWrite_SharesIdenticalFonts(t *testing.T) {
+	font := loadTestFont(t)
+	c := New()
+	for i := 0; i < 3; i++ {
+		page, _ := c.NewPage()
+		if err := page.AddTextCustomFont("Quarterly report", 72, 750, font, 12); err != nil {
+			t.Fatalf("AddTextCustomFont() error = %v", err)
+		}
+	}
+
+	pdf, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if n := strings.Count(string(pdf), "/Type /FontDescriptor"); n != 1 {
+		t.Errorf("PDF has %d font descriptors, want 1 shared by all pages", n)
+	}
+}
+
+func TestWrite_GlobalResourceNames(t *testing.T) {
+	logoData := createPNGData(t, 32, 32, color.RGBA{R: 200, G: 30, B: 30, A: 255})
+	otherData := createJPEGData(t, 32, 32, color.RGBA{B: 255, A: 255})
+	logo, err := LoadImageFromReader(bytes.NewReader(logoData))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+	other, err := LoadImageFromReader(bytes.NewReader(otherData))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+
+	build := func(global bool) string {
+		c := New()
+		c.SetGlobalResourceNames(global)
+		if err := c.SetCompressionLevel(flate.NoCompression); err != nil {
+			t.Fatalf("SetCompressionLevel() error = %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			page, _ := c.NewPage()
+			if i == 1 {
+				// Drawn first, so that the logo is the page's second image.
+				if err := page.DrawImage(other, 150, 750, 64, 64); err != nil {
+					t.Fatalf("DrawImage() error = %v", err)
+				}
+			}
+			if err := page.DrawImage(logo, 50, 750, 64, 64); err != nil {
+				t.Fatalf("DrawImage() error = %v", err)
+			}
+		}
+		pdf, err := c.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes() error = %v", err)
+		}
+		return string(pdf)
+	}
+
+	// By default, names are numbered per page: the logo is Im2 on page 2.
+	if pdf := build(false); !strings.Contains(pdf, "/Im2 ") {
+		t.Errorf("expected per-page image names, got:\n%s", pdf)
+	}
+
+	pdf := build(true)
+	refs := regexp.MustCompile(`/(Im\d+) (\d+) 0 R`).FindAllStringSubmatch(pdf, -1)
+	if len(refs) != 4 {
+		t.Fatalf("found %d image resources, want 4", len(refs))
+	}
+	logoName := ""
+	for _, ref := range refs {
+		if ref[1] != "Im"+ref[2] {
+			t.Errorf("resource %s refers to object %s, want name Im%s", ref[1], ref[2], ref[2])
+		}
+		if strings.Count(pdf, "/"+ref[1]+" Do") == 3 {
+			logoName = ref[1]
+		}
+	}
+	if logoName == "" {
+		t.Errorf("expected the logo to be drawn under one name on all 3 pages, got:\n%s", pdf)
+	}
+}
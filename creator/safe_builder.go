@@ -0,0 +1,180 @@
+package creator
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// SafeBuilderOptions configures the text written by a SafeBuilder.
+type SafeBuilderOptions struct {
+	// Font is the font of each line (default: Courier).
+	Font FontName
+
+	// FontSize is the font size in points (default: 9).
+	FontSize float64
+
+	// LineSpacing is the line height as a multiple of FontSize (default: 1.2).
+	LineSpacing float64
+
+	// Color is the text color (default: black).
+	Color Color
+}
+
+// SafeBuilder appends lines of text to a document from multiple goroutines.
+//
+// Creator and Page are not safe for concurrent use. SafeBuilder is the
+// supported way to build one document concurrently: it serializes every
+// call with a mutex, writes each line below the previous one, and starts a
+// new page when a line does not fit above the bottom margin. Use it, for
+// example, to tail logs from several producers into a paginated PDF.
+//
+// The wrapped Creator must not be used directly while the builder is in
+// use, other than through Do.
+//
+// Example:
+//
+//	b := creator.NewSafeBuilder(creator.New(), nil)
+//	for _, src := range sources {
+//	    go func(src <-chan string) {
+//	        for line := range src {
+//	            _ = b.WriteLine(line)
+//	        }
+//	    }(src)
+//	}
+//	// ... wait for producers ...
+//	err := b.WriteToFile("log.pdf")
+type SafeBuilder struct {
+	mu      sync.Mutex
+	creator *Creator
+	page    *Page // Page receiving lines (nil until the first line)
+	opts    SafeBuilderOptions
+}
+
+// NewSafeBuilder wraps a Creator for concurrent line writing.
+// Pass nil options for the defaults.
+//
+// Lines continue on the Creator's last page if it has pages, and on a new
+// page otherwise.
+func NewSafeBuilder(c *Creator, opts *SafeBuilderOptions) *SafeBuilder {
+	b := &SafeBuilder{
+		creator: c,
+		opts: SafeBuilderOptions{
+			Font:        Courier,
+			FontSize:    9,
+			LineSpacing: 1.2,
+			Color:       Black,
+		},
+	}
+	if opts != nil {
+		if opts.Font != "" {
+			b.opts.Font = opts.Font
+		}
+		if opts.FontSize > 0 {
+			b.opts.FontSize = opts.FontSize
+		}
+		if opts.LineSpacing > 0 {
+			b.opts.LineSpacing = opts.LineSpacing
+		}
+		b.opts.Color = opts.Color
+	}
+	return b
+}
+
+// WriteLine writes a line of text at the flow cursor and moves the cursor
+// to the next line, starting a new page if the line does not fit.
+//
+// Lines are not wrapped; text wider than the content area overflows the
+// right margin.
+func (b *SafeBuilder) WriteLine(text string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	page, err := b.currentPage()
+	if err != nil {
+		return err
+	}
+
+	lineHeight := b.opts.FontSize * b.opts.LineSpacing
+	ctx := page.GetLayoutContext()
+	if ctx.CursorY > 0 && lineHeight > ctx.AvailableHeight()+keepTogetherEpsilon {
+		if page.nextPage == nil {
+			return ErrNoNextPage
+		}
+		if page, err = page.nextPage(); err != nil {
+			return fmt.Errorf("line page break: %w", err)
+		}
+		b.page = page
+		ctx = page.GetLayoutContext()
+	}
+
+	y := ctx.CurrentPDFY() - b.opts.FontSize // baseline position
+	if err := page.AddTextColor(text, ctx.ContentLeft(), y, b.opts.Font, b.opts.FontSize, b.opts.Color); err != nil {
+		return err
+	}
+	page.MoveCursor(ctx.CursorX, ctx.CursorY+lineHeight)
+	return nil
+}
+
+// Do runs fn with exclusive access to the Creator and the page receiving
+// lines, serialized with WriteLine and the other builder methods.
+//
+// fn may draw on page, move its cursor, or add pages. Lines written after
+// fn continue on page at its cursor, unless fn returns a page to continue
+// on instead (nil keeps page).
+//
+// Example:
+//
+//	err := b.Do(func(c *creator.Creator, page *creator.Page) (*creator.Page, error) {
+//	    return page.KeepTogether(func(p *creator.Page) error {
+//	        return p.Draw(creator.NewParagraph("--- checkpoint ---"))
+//	    })
+//	})
+func (b *SafeBuilder) Do(fn func(c *Creator, page *Page) (*Page, error)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	page, err := b.currentPage()
+	if err != nil {
+		return err
+	}
+	next, err := fn(b.creator, page)
+	if next != nil {
+		b.page = next
+	}
+	return err
+}
+
+// WriteTo writes the document built so far to w.
+func (b *SafeBuilder) WriteTo(w io.Writer) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.creator.WriteTo(w)
+}
+
+// WriteToFile writes the document built so far to a file.
+func (b *SafeBuilder) WriteToFile(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.creator.WriteToFile(path)
+}
+
+// currentPage returns the page receiving lines, adding one if needed.
+// The caller must hold b.mu.
+func (b *SafeBuilder) currentPage() (*Page, error) {
+	if b.page != nil {
+		return b.page, nil
+	}
+	if n := len(b.creator.pages); n > 0 {
+		b.page = b.creator.pages[n-1]
+		return b.page, nil
+	}
+	page, err := b.creator.NewPage()
+	if err != nil {
+		return nil, err
+	}
+	b.page = page
+	return page, nil
+}
@@ -0,0 +1,66 @@
+package creator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeBuilder_ConcurrentWriteLine(t *testing.T) {
+	c := New()
+	b := NewSafeBuilder(c, &SafeBuilderOptions{FontSize: 10, LineSpacing: 1})
+
+	const producers, lines = 4, 50
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range lines {
+				assert.NoError(t, b.WriteLine(fmt.Sprintf("producer %d line %d", p, i)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A4 with 1 inch margins holds 69 lines of 10pt.
+	require.Equal(t, 3, c.PageCount())
+	total := 0
+	for _, page := range c.pages {
+		ops := page.TextOperations()
+		total += len(ops)
+		for i := 1; i < len(ops); i++ {
+			assert.InDelta(t, ops[i-1].Y-10, ops[i].Y, 1e-9, "lines stack top to bottom")
+		}
+		assert.GreaterOrEqual(t, ops[len(ops)-1].Y, page.margins.Bottom)
+	}
+	assert.Equal(t, producers*lines, total)
+	assert.Len(t, c.pages[0].TextOperations(), 69)
+}
+
+func TestSafeBuilder_ContinuesLastPage(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.Draw(NewParagraph("Title")))
+
+	b := NewSafeBuilder(c, nil)
+	require.NoError(t, b.WriteLine("first"))
+
+	err = b.Do(func(_ *Creator, p *Page) (*Page, error) {
+		assert.Same(t, page, p)
+		return nil, p.Draw(NewParagraph("checkpoint"))
+	})
+	require.NoError(t, err)
+	require.NoError(t, b.WriteLine("second"))
+
+	assert.Equal(t, 1, c.PageCount())
+	assert.Equal(t, []string{"Title", "first", "checkpoint", "second"}, pageTexts(page))
+
+	data, err := c.Bytes()
+	require.NoError(t, err)
+	assert.NotEmpty(t, data)
+}
@@ -0,0 +1,136 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// ErrStreamingUnsupported is returned by WriteStream for documents that
+// need all pages before anything is written.
+var ErrStreamingUnsupported = errors.New("document cannot be streamed")
+
+// PageFiller fills page pageNum (1-based) of a streamed document. It
+// returns whether more pages follow.
+type PageFiller func(page *Page, pageNum int) (more bool, err error)
+
+// WriteStream writes the document to w one page at a time, for reports
+// too large to hold in memory.
+//
+// fill is called with a new page (see NewPage) until it returns false or
+// an error. After each call the page is written out, together with any
+// pages that flow content added after it, and its content is released:
+// only a few bytes per page stay in memory. Pages added before
+// WriteStream are written first.
+//
+// pageCount is the total number of pages, including pages added before
+// WriteStream. It is passed to header and footer functions, which run as
+// each page is written, before the end of the document is known. It is
+// required (at least 1) when a header or footer is set, and WriteStream
+// fails if the document ends up with a different number of pages; it is
+// ignored otherwise.
+//
+// Streaming has limits:
+//   - Pages cannot be changed once fill returns.
+//   - Chapters, the checksum, usage rights and encryption need the whole
+//     document and make WriteStream return ErrStreamingUnsupported, as do
+//     headers and footers without a pageCount.
+//   - Objects are not packed into object streams.
+//
+// Example:
+//
+//	rows := queryRows()
+//	pages := (rows.Count() + 51) / 52
+//	_, err := c.WriteStream(w, pages, func(page *creator.Page, pageNum int) (bool, error) {
+//	    for y := 780.0; y > 60 && rows.Next(); y -= 14 {
+//	        page.AddText(rows.Line(), 50, y, creator.Helvetica, 10)
+//	    }
+//	    return rows.More(), nil
+//	})
+func (c *Creator) WriteStream(w io.Writer, pageCount int, fill PageFiller) (int64, error) {
+	decorated := c.hasDecorators()
+	switch {
+	case len(c.chapters) > 0:
+		return 0, fmt.Errorf("%w: chapters need all pages", ErrStreamingUnsupported)
+	case c.needsBuffering():
+		return 0, fmt.Errorf("%w: checksum, usage rights and encryption need the complete output", ErrStreamingUnsupported)
+	case decorated && pageCount < 1:
+		return 0, fmt.Errorf("%w: headers and footers need the page count", ErrStreamingUnsupported)
+	}
+	if !decorated {
+		pageCount = 0
+	}
+	if err := c.validateDocumentConformance(); err != nil {
+		return 0, err
+	}
+
+	cw := &countingWriter{w: w}
+	pdfWriter := writer.NewPdfWriterFromWriter(cw)
+	defer pdfWriter.Close()
+
+	c.configureWriter(pdfWriter)
+	if err := pdfWriter.BeginStream(c.doc); err != nil {
+		return cw.n, fmt.Errorf("failed to write PDF: %w", err)
+	}
+
+	written := 0
+	for more := true; ; {
+		for ; written < len(c.pages); written++ {
+			if pageCount > 0 && written >= pageCount {
+				return cw.n, fmt.Errorf("document has more than the %d pages given to WriteStream", pageCount)
+			}
+			if err := c.writeStreamPage(pdfWriter, written, pageCount); err != nil {
+				return cw.n, err
+			}
+		}
+		if !more {
+			break
+		}
+
+		page, err := c.NewPage()
+		if err != nil {
+			return cw.n, err
+		}
+		if more, err = fill(page, len(c.pages)); err != nil {
+			return cw.n, err
+		}
+	}
+	if pageCount > 0 && written != pageCount {
+		return cw.n, fmt.Errorf("document has %d pages, WriteStream was given %d", written, pageCount)
+	}
+
+	// Bookmarks may have been added while filling the pages.
+	pdfWriter.SetOutline(c.outline())
+	if err := pdfWriter.EndStream(c.doc); err != nil {
+		return cw.n, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return cw.n, nil
+}
+
+// hasDecorators reports whether a header or footer is set.
+func (c *Creator) hasDecorators() bool {
+	return c.headerFunc != nil || c.footerFunc != nil || c.header != nil || c.footer != nil
+}
+
+// writeStreamPage writes page i (0-based) of pageCount and releases its
+// content.
+func (c *Creator) writeStreamPage(pdfWriter *writer.PdfWriter, i, pageCount int) error {
+	textOps, graphicsOps := c.pageOperations(i, pageCount)
+	if err := c.validatePageConformance(i+1, textOps, graphicsOps); err != nil {
+		return err
+	}
+
+	page := c.pages[i]
+	err := pdfWriter.WriteStreamPage(page.page,
+		convertTextOps(c.normalization.normalizeTextOps(textOps)),
+		convertGraphicsOps(c.normalization.normalizeGraphicsOps(graphicsOps)))
+	if err != nil {
+		return fmt.Errorf("failed to write page %d: %w", i+1, err)
+	}
+
+	page.textOps, page.graphicsOps = nil, nil
+	page.backgroundColor, page.backgroundImage, page.backgroundOps = nil, nil, 0
+	return nil
+}
@@ -0,0 +1,203 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/reader"
+)
+
+// fillReportPage adds a page worth of text lines to page.
+func fillReportPage(page *Page, pageNum int) error {
+	for y := 780.0; y > 60; y -= 14 {
+		line := fmt.Sprintf("Page %d, row at %.0f: the quick brown fox jumps over the lazy dog", pageNum, y)
+		if err := page.AddText(line, 50, y, Helvetica, 10); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCreator_WriteStream(t *testing.T) {
+	c := New()
+	first, _ := c.NewPage()
+	if err := first.AddText("Cover", 100, 700, Helvetica, 24); err != nil {
+		t.Fatalf("AddText() error = %v", err)
+	}
+	var footerCounts []int
+	c.SetFooter(func(page *Page, pageNum, pageCount int) {
+		footerCounts = append(footerCounts, pageCount)
+		_ = page.AddText(fmt.Sprintf("- %d -", pageNum), 290, 30, Helvetica, 9)
+	})
+
+	var filled []*Page
+	var buf bytes.Buffer
+	n, err := c.WriteStream(&buf, 4, func(page *Page, pageNum int) (bool, error) {
+		filled = append(filled, page)
+		if err := page.AddText(fmt.Sprintf("Body %d", pageNum), 100, 700, Helvetica, 12); err != nil {
+			return false, err
+		}
+		return pageNum < 4, nil
+	})
+	if err != nil {
+		t.Fatalf("WriteStream() error = %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteStream() = %d bytes, wrote %d", n, buf.Len())
+	}
+	if len(filled) != 3 {
+		t.Errorf("fill called %d times, want 3", len(filled))
+	}
+	if fmt.Sprint(footerCounts) != "[4 4 4 4]" {
+		t.Errorf("footer page counts = %v, want 4 for each page", footerCounts)
+	}
+	for _, want := range []string{"(Cover) Tj", "(Body 2) Tj", "(Body 4) Tj", "(- 4 -) Tj"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	}
+	for i, page := range append([]*Page{first}, filled...) {
+		if len(page.TextOperations()) != 0 {
+			t.Errorf("page %d content should be released after writing", i+1)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "stream.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	r, err := reader.NewPdfReader(path)
+	if err != nil {
+		t.Fatalf("NewPdfReader failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if got := r.PageCount(); got != 4 {
+		t.Errorf("PageCount() = %d, want 4", got)
+	}
+}
+
+func TestCreator_WriteStream_Errors(t *testing.T) {
+	fill := func(*Page, int) (bool, error) { return false, nil }
+
+	c := New()
+	if err := c.AddChapter(NewChapter("Intro")); err != nil {
+		t.Fatalf("AddChapter() error = %v", err)
+	}
+	if _, err := c.WriteStream(io.Discard, 0, fill); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("chapters: error = %v, want ErrStreamingUnsupported", err)
+	}
+
+	c = New()
+	c.EmbedChecksum()
+	if _, err := c.WriteStream(io.Discard, 0, fill); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("checksum: error = %v, want ErrStreamingUnsupported", err)
+	}
+
+	c = New()
+	if err := c.SetPermissions(Permissions{AllowPrint: true}); err != nil {
+		t.Fatalf("SetPermissions() error = %v", err)
+	}
+	if _, err := c.WriteStream(io.Discard, 0, fill); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("encryption: error = %v, want ErrStreamingUnsupported", err)
+	}
+
+	errFill := errors.New("fill failed")
+	c = New()
+	_, err := c.WriteStream(io.Discard, 0, func(*Page, int) (bool, error) { return true, errFill })
+	if !errors.Is(err, errFill) {
+		t.Errorf("fill error: error = %v, want %v", err, errFill)
+	}
+
+	footer := func(page *Page, pageNum, pageCount int) {
+		_ = page.AddText(fmt.Sprintf("%d of %d", pageNum, pageCount), 290, 30, Helvetica, 9)
+	}
+	c = New()
+	c.SetFooter(footer)
+	if _, err := c.WriteStream(io.Discard, 0, fill); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("footer without page count: error = %v, want ErrStreamingUnsupported", err)
+	}
+
+	for _, pages := range []int{2, 4} {
+		c = New()
+		c.SetFooter(footer)
+		_, err := c.WriteStream(io.Discard, 3, func(_ *Page, pageNum int) (bool, error) {
+			return pageNum < pages, nil
+		})
+		if err == nil {
+			t.Errorf("%d pages with page count 3: error = nil, want an error", pages)
+		}
+	}
+}
+
+// streamHeapPeak streams a report of pages pages and returns the largest
+// live heap seen after writing each page.
+func streamHeapPeak(t *testing.T, pages int) uint64 {
+	t.Helper()
+	var peak uint64
+	var stats runtime.MemStats
+	c := New()
+	_, err := c.WriteStream(io.Discard, 0, func(page *Page, pageNum int) (bool, error) {
+		if pageNum%25 == 0 {
+			runtime.GC()
+			runtime.ReadMemStats(&stats)
+			peak = max(peak, stats.HeapAlloc)
+		}
+		return pageNum < pages, fillReportPage(page, pageNum)
+	})
+	if err != nil {
+		t.Fatalf("WriteStream() error = %v", err)
+	}
+	return peak
+}
+
+func TestCreator_WriteStream_FlatMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping memory test in short mode")
+	}
+
+	small := streamHeapPeak(t, 100)
+	large := streamHeapPeak(t, 800)
+
+	// Each page holds about 50 text operations; keeping them all would
+	// add megabytes for the larger report. Allow generous slack for the
+	// offsets and page numbers that do grow with the page count.
+	if large > small+2<<20 {
+		t.Errorf("peak heap grew from %d bytes (100 pages) to %d bytes (800 pages)", small, large)
+	}
+}
+
+func BenchmarkCreator_WriteStream(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := New()
+		_, err := c.WriteStream(io.Discard, 0, func(page *Page, pageNum int) (bool, error) {
+			return pageNum < 200, fillReportPage(page, pageNum)
+		})
+		if err != nil {
+			b.Fatalf("WriteStream() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkCreator_WriteTo(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := New()
+		for n := 1; n <= 200; n++ {
+			page, _ := c.NewPage()
+			if err := fillReportPage(page, n); err != nil {
+				b.Fatalf("fillReportPage() error = %v", err)
+			}
+		}
+		if _, err := c.WriteTo(io.Discard); err != nil {
+			b.Fatalf("WriteTo() error = %v", err)
+		}
+	}
+}
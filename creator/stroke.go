@@ -140,6 +140,39 @@ func (j LineJoin) String() string {
 	}
 }
 
+// validateLineStyle validates the cap, join and miter limit of a stroked
+// path. A zero miter limit keeps the PDF default.
+func validateLineStyle(lineCap LineCap, lineJoin LineJoin, miterLimit float64) error {
+	if lineCap < LineCapButt || lineCap > LineCapSquare {
+		return fmt.Errorf("invalid line cap: %d", int(lineCap))
+	}
+	if lineJoin < LineJoinMiter || lineJoin > LineJoinBevel {
+		return fmt.Errorf("invalid line join: %d", int(lineJoin))
+	}
+	if miterLimit != 0 && miterLimit < 1.0 {
+		return fmt.Errorf("miter limit must be >= 1.0, got: %f", miterLimit)
+	}
+	return nil
+}
+
+// validateDashArray validates a dash pattern: entries must be
+// non-negative and not all zero. An empty pattern draws a solid line.
+func validateDashArray(dashArray []float64) error {
+	allZero := true
+	for i, dash := range dashArray {
+		if dash < 0 {
+			return fmt.Errorf("dash array[%d] must be non-negative, got: %f", i, dash)
+		}
+		if dash > 0 {
+			allZero = false
+		}
+	}
+	if len(dashArray) > 0 && allZero {
+		return errors.New("dash array must not be all zeros")
+	}
+	return nil
+}
+
 // NewStroke creates a new Stroke with the specified paint.
 //
 // Default values:
@@ -115,7 +115,7 @@ func (sp *StyledParagraph) Height(ctx *LayoutContext) float64 {
 		return 0
 	}
 
-	lines := sp.wrapText(ctx.AvailableWidth())
+	lines := sp.normalized(ctx.normalization).wrapText(ctx.AvailableWidth())
 	if len(lines) == 0 {
 		return 0
 	}
@@ -135,7 +135,7 @@ func (sp *StyledParagraph) Draw(ctx *LayoutContext, page *Page) error {
 		return nil
 	}
 
-	lines := sp.wrapText(ctx.AvailableWidth())
+	lines := sp.normalized(ctx.normalization).wrapText(ctx.AvailableWidth())
 
 	for _, line := range lines {
 		if err := sp.drawLine(ctx, page, line); err != nil {
@@ -203,6 +203,20 @@ func (sp *StyledParagraph) calculateLineX(ctx *LayoutContext, line styledLine) f
 	}
 }
 
+// normalized returns sp, or a copy of sp with normalized chunk text.
+func (sp *StyledParagraph) normalized(n Normalization) *StyledParagraph {
+	if n == NormalizationNone {
+		return sp
+	}
+	cp := *sp
+	cp.chunks = make([]TextChunk, len(sp.chunks))
+	for i, chunk := range sp.chunks {
+		chunk.Text = n.normalize(chunk.Text)
+		cp.chunks[i] = chunk
+	}
+	return &cp
+}
+
 // wrapText breaks the text into lines that fit within the given width.
 func (sp *StyledParagraph) wrapText(availableWidth float64) []styledLine {
 	if len(sp.chunks) == 0 {
@@ -0,0 +1,187 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// styledTextLineSpacing is the baseline-to-baseline distance of
+// AddStyledText lines, relative to the largest font size on the line.
+const styledTextLineSpacing = 1.2
+
+// TextRun is a piece of text with its own font, size, and color.
+//
+// Runs are drawn left-to-right by AddStyledText.
+//
+// Example:
+//
+//	runs := []creator.TextRun{
+//	    {Text: "Total: ", Font: creator.Helvetica, Size: 12, Color: creator.Black},
+//	    {Text: "$1,234", Font: creator.HelveticaBold, Size: 12, Color: creator.Black},
+//	}
+type TextRun struct {
+	// Text is the text content. A newline starts a new line.
+	Text string
+
+	// Font is the font to use (one of the Standard 14 fonts).
+	// Ignored if CustomFont is set.
+	Font FontName
+
+	// CustomFont is an embedded TrueType/OpenType font (optional).
+	// When set, this takes precedence over Font.
+	CustomFont *CustomFont
+
+	// Size is the font size in points.
+	Size float64
+
+	// Color is the text color (RGB, 0.0 to 1.0 range).
+	Color Color
+
+	// Rise raises (positive) or lowers (negative) the run's baseline, in
+	// points, for superscripts and subscripts. It does not change the
+	// baseline of the runs that follow.
+	Rise float64
+
+	// Underline and Strikethrough draw a line under or through the run,
+	// in its color.
+	Underline     bool
+	Strikethrough bool
+}
+
+// width returns the advance width of text set in the run's font.
+func (r TextRun) width(text string) float64 {
+	if r.CustomFont != nil {
+		return r.CustomFont.MeasureString(text, r.Size)
+	}
+	return fonts.MeasureString(string(r.Font), text, r.Size)
+}
+
+// styledSegment is the part of a run that falls on one line.
+type styledSegment struct {
+	run  TextRun
+	text string
+}
+
+// styledTextLine is one line of AddStyledText output.
+type styledTextLine struct {
+	segments []styledSegment
+	maxSize  float64
+}
+
+// AddStyledText draws runs of differently styled text on a shared baseline.
+//
+// Each run starts where the previous one ends, so bold and regular text
+// sit together seamlessly. The runs of a line are written as one text
+// object, switching font and color between runs.
+//
+// A newline in a run's text starts a new line at x. Baselines are spaced
+// 1.2 times the largest font size on the following line.
+//
+// Parameters:
+//   - runs: The styled text runs, in drawing order
+//   - x: Horizontal position of the first run in points (from left edge)
+//   - y: Baseline of the first line in points (from bottom edge)
+//
+// Example:
+//
+//	err := page.AddStyledText([]creator.TextRun{
+//	    {Text: "Total: ", Font: creator.Helvetica, Size: 12},
+//	    {Text: "$1,234", Font: creator.HelveticaBold, Size: 12},
+//	}, 100, 700)
+//
+//	// H₂O with a lowered, smaller "2".
+//	err = page.AddStyledText([]creator.TextRun{
+//	    {Text: "H", Font: creator.Helvetica, Size: 12},
+//	    {Text: "2", Font: creator.Helvetica, Size: 8, Rise: -3},
+//	    {Text: "O", Font: creator.Helvetica, Size: 12},
+//	}, 100, 680)
+func (p *Page) AddStyledText(runs []TextRun, x, y float64) error {
+	if len(runs) == 0 {
+		return errors.New("styled text must have at least one run")
+	}
+	for i, run := range runs {
+		if err := validateTextRun(run); err != nil {
+			return fmt.Errorf("text run %d: %w", i, err)
+		}
+	}
+
+	// Normalize a copy so the caller's runs are left unchanged.
+	normalized := make([]TextRun, len(runs))
+	for i, run := range runs {
+		run.Text = p.normalize(run.Text)
+		normalized[i] = run
+	}
+	lines := splitStyledLines(normalized)
+
+	baseline := y
+	for i, line := range lines {
+		if i > 0 {
+			baseline -= line.maxSize * styledTextLineSpacing
+		}
+
+		cursorX := x
+		continued := false
+		for _, seg := range line.segments {
+			if seg.run.CustomFont != nil {
+				seg.run.CustomFont.UseString(seg.text)
+			}
+
+			p.textOps = append(p.textOps, TextOperation{
+				Text:          seg.text,
+				X:             cursorX,
+				Y:             baseline,
+				Font:          seg.run.Font,
+				CustomFont:    seg.run.CustomFont,
+				Size:          seg.run.Size,
+				Color:         seg.run.Color,
+				Continued:     continued,
+				Rise:          seg.run.Rise,
+				Underline:     seg.run.Underline,
+				Strikethrough: seg.run.Strikethrough,
+			})
+
+			cursorX += seg.run.width(seg.text)
+			continued = true
+		}
+	}
+
+	return nil
+}
+
+// validateTextRun validates a single text run.
+func validateTextRun(run TextRun) error {
+	if run.CustomFont == nil && run.Font == "" {
+		return errors.New("font is required")
+	}
+	if run.Size <= 0 {
+		return errors.New("font size must be positive")
+	}
+	c := run.Color
+	if c.R < 0 || c.R > 1 || c.G < 0 || c.G > 1 || c.B < 0 || c.B > 1 {
+		return errors.New("color components must be in range [0.0, 1.0]")
+	}
+	return nil
+}
+
+// splitStyledLines splits runs at newlines.
+//
+// Empty segments are dropped, but still count toward the line height.
+func splitStyledLines(runs []TextRun) []styledTextLine {
+	lines := []styledTextLine{{}}
+	for _, run := range runs {
+		for i, part := range strings.Split(run.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, styledTextLine{})
+			}
+			line := &lines[len(lines)-1]
+			line.maxSize = max(line.maxSize, run.Size)
+			if part != "" {
+				line.segments = append(line.segments, styledSegment{run: run, text: part})
+			}
+		}
+	}
+	return lines
+}
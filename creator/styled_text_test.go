@@ -0,0 +1,131 @@
+package creator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+	"github.com/coregx/gxpdf/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage_AddStyledText(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	err = page.AddStyledText([]TextRun{
+		{Text: "Total: ", Font: Helvetica, Size: 12},
+		{Text: "$1,234", Font: HelveticaBold, Size: 12, Color: Red},
+	}, 100, 700)
+	require.NoError(t, err)
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 2)
+
+	assert.Equal(t, 100.0, ops[0].X)
+	assert.False(t, ops[0].Continued)
+	assert.InDelta(t, 100+fonts.MeasureString("Helvetica", "Total: ", 12), ops[1].X, 1e-9)
+	assert.Equal(t, ops[0].Y, ops[1].Y, "runs share a baseline")
+	assert.True(t, ops[1].Continued)
+	assert.Equal(t, HelveticaBold, ops[1].Font)
+	assert.Equal(t, Red, ops[1].Color)
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(ops))
+	require.NoError(t, err)
+	got := string(content)
+	assert.Equal(t, 1, strings.Count(got, "BT"), "runs share one text object:\n%s", got)
+	assert.Equal(t, 1, strings.Count(got, " Td"), "only the first run is positioned:\n%s", got)
+	assert.Equal(t, 2, strings.Count(got, " Tf"), "font switches between runs:\n%s", got)
+}
+
+func TestPage_AddStyledText_Newlines(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	err = page.AddStyledText([]TextRun{
+		{Text: "Name: ", Font: Helvetica, Size: 10},
+		{Text: "Ada\nRole: ", Font: HelveticaBold, Size: 10},
+		{Text: "Engineer", Font: Helvetica, Size: 20},
+	}, 50, 500)
+	require.NoError(t, err)
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 4)
+
+	assert.Equal(t, []string{"Name: ", "Ada", "Role: ", "Engineer"},
+		[]string{ops[0].Text, ops[1].Text, ops[2].Text, ops[3].Text})
+	assert.False(t, ops[2].Continued, "a newline starts a new text object")
+	assert.Equal(t, 50.0, ops[2].X)
+	assert.InDelta(t, 500-20*styledTextLineSpacing, ops[2].Y, 1e-9, "line height follows the largest run")
+	assert.True(t, ops[3].Continued)
+}
+
+func TestPage_AddStyledText_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		runs []TextRun
+	}{
+		{"no runs", nil},
+		{"missing font", []TextRun{{Text: "x", Size: 12}}},
+		{"zero size", []TextRun{{Text: "x", Font: Helvetica}}},
+		{"bad color", []TextRun{{Text: "x", Font: Helvetica, Size: 12, Color: Color{R: 2}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			require.NoError(t, err)
+
+			assert.Error(t, page.AddStyledText(tt.runs, 0, 0))
+			assert.Empty(t, page.TextOperations(), "invalid runs add nothing")
+		})
+	}
+}
+
+func TestPage_AddStyledText_Rise(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	err = page.AddStyledText([]TextRun{
+		{Text: "H", Font: Helvetica, Size: 12},
+		{Text: "2", Font: Helvetica, Size: 8, Rise: -3},
+		{Text: "O", Font: Helvetica, Size: 12},
+	}, 100, 700)
+	require.NoError(t, err)
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+	got := string(content)
+	assert.Contains(t, got, "-3 Ts\n(2) Tj\n0 Ts\n", "the subscript is lowered and the rise reset:\n%s", got)
+	assert.Equal(t, 2, strings.Count(got, " Ts"), "only the subscript run sets a rise:\n%s", got)
+	assert.Equal(t, 1, strings.Count(got, "BT"), "runs share one text object:\n%s", got)
+}
+
+func TestPage_AddStyledText_Underline(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Only "$1,234" is underlined, from where it starts to its end.
+	err = page.AddStyledText([]TextRun{
+		{Text: "Total: ", Font: Helvetica, Size: 12},
+		{Text: "$1,234", Font: HelveticaBold, Size: 12, Underline: true},
+	}, 100, 700)
+	require.NoError(t, err)
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 2)
+	width := HelveticaBold.MeasureString("$1,234", 12)
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(ops))
+	require.NoError(t, err)
+	got := string(content)
+	assert.Equal(t, 1, strings.Count(got, "BT"), "runs share one text object:\n%s", got)
+	assert.Contains(t, got, fmt.Sprintf("ET\nq\n0 G\n0.60 w\n%.2f 698.80 m\n%.2f 698.80 l\nS\nQ\n", ops[1].X, ops[1].X+width))
+}
@@ -0,0 +1,380 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// PathOptions configures drawing of a path given as SVG path data.
+type PathOptions struct {
+	// StrokeColor is the outline color (nil = no stroke).
+	// If StrokeColorCMYK is set, this field is ignored.
+	StrokeColor *Color
+
+	// StrokeColorCMYK is the outline color in CMYK (nil = no stroke).
+	// If set, this takes precedence over StrokeColor (RGB).
+	StrokeColorCMYK *ColorCMYK
+
+	// StrokeWidth is the outline width in points (default: 1.0).
+	StrokeWidth float64
+
+	// FillColor is the fill color (nil = no fill).
+	// Mutually exclusive with FillGradient.
+	// If FillColorCMYK is set, this field is ignored.
+	FillColor *Color
+
+	// FillColorCMYK is the fill color in CMYK (nil = no fill).
+	// If set, this takes precedence over FillColor (RGB).
+	// Mutually exclusive with FillGradient.
+	FillColorCMYK *ColorCMYK
+
+	// FillGradient is the gradient fill (nil = no gradient fill).
+	// Mutually exclusive with FillColor and FillColorCMYK.
+	FillGradient *Gradient
+
+	// FillRule decides which regions of overlapping subpaths are inside
+	// (default: FillRuleNonZero). With FillRuleEvenOdd, a subpath inside
+	// another cuts a hole into it whatever its direction, as the SVG
+	// fill-rule="evenodd" attribute does.
+	FillRule FillRule
+
+	// Dashed enables dashed outline rendering.
+	Dashed bool
+
+	// DashArray defines the dash pattern for the outline.
+	// Only used when Dashed is true.
+	DashArray []float64
+
+	// DashPhase is the starting offset into the dash pattern.
+	// Only used when Dashed is true.
+	DashPhase float64
+
+	// LineCap is the style of open subpath ends (default: LineCapButt).
+	LineCap LineCap
+
+	// LineJoin is the style of the corners (default: LineJoinMiter).
+	LineJoin LineJoin
+
+	// MiterLimit limits the length of miter joins as a ratio of the
+	// stroke width; longer miters are beveled. Only used with LineJoinMiter.
+	// Must be at least 1.0 (0 = PDF default of 10).
+	MiterLimit float64
+
+	// GraphicsState sets stroke adjustment and overprint (optional).
+	GraphicsState *GraphicsStateOptions
+}
+
+// DrawPath draws a path given as SVG path data (the "d" attribute of an
+// SVG <path> element).
+//
+// The supported commands are M (move), L (line), H and V (horizontal and
+// vertical line), C (cubic Bézier), S (smooth cubic Bézier), Q (quadratic
+// Bézier) and Z (close). Lowercase commands take coordinates relative to
+// the current point. Repeated coordinates repeat the command; extra
+// coordinate pairs after M are lines. Quadratic and smooth curves are
+// converted to cubic curves, so the path is written with the m, l, c and h
+// operators.
+//
+// The path is filled if a fill is set and stroked if a stroke color is
+// set; open subpaths are closed implicitly for filling. Coordinates are in
+// PDF user space, so a path copied from SVG (y pointing down) appears
+// mirrored unless the page is flipped first (see Scale).
+//
+// Example:
+//
+//	err := page.DrawPath("M 100 100 l 50 0 q 25 25 0 50 Z", &creator.PathOptions{
+//	    StrokeColor: &creator.Black,
+//	    FillColor:   &creator.Yellow,
+//	})
+func (p *Page) DrawPath(d string, opts *PathOptions) error {
+	if opts == nil {
+		return errors.New("path options cannot be nil")
+	}
+	if err := validatePathOptions(opts); err != nil {
+		return err
+	}
+
+	path, err := parseSVGPath(d)
+	if err != nil {
+		return err
+	}
+
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+		Type:     GraphicsOpPath,
+		Path:     path,
+		PathOpts: opts,
+	})
+	return nil
+}
+
+// validatePathOptions validates path drawing options.
+func validatePathOptions(opts *PathOptions) error {
+	if opts.StrokeColor != nil {
+		if err := validateColor(*opts.StrokeColor); err != nil {
+			return errors.New("stroke " + err.Error())
+		}
+	}
+	if opts.FillColor != nil {
+		if err := validateColor(*opts.FillColor); err != nil {
+			return errors.New("fill " + err.Error())
+		}
+	}
+
+	if opts.StrokeWidth < 0 {
+		return errors.New("stroke width must be non-negative")
+	}
+	if opts.Dashed {
+		if err := validateDashArray(opts.DashArray); err != nil {
+			return err
+		}
+	}
+	if err := validateLineStyle(opts.LineCap, opts.LineJoin, opts.MiterLimit); err != nil {
+		return err
+	}
+	if err := validateFillRule(opts.FillRule); err != nil {
+		return err
+	}
+
+	hasStroke := opts.StrokeColor != nil || opts.StrokeColorCMYK != nil
+	hasFill := opts.FillColor != nil || opts.FillColorCMYK != nil || opts.FillGradient != nil
+	if !hasStroke && !hasFill {
+		return errors.New("path must have at least stroke, fill color, or gradient")
+	}
+
+	if (opts.FillColor != nil || opts.FillColorCMYK != nil) && opts.FillGradient != nil {
+		return errors.New("cannot use both fill color and fill gradient")
+	}
+	if opts.FillGradient != nil {
+		if err := opts.FillGradient.Validate(); err != nil {
+			return errors.New("fill gradient: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// svgPathArgs is the number of coordinates each SVG path command takes.
+var svgPathArgs = map[byte]int{
+	'M': 2, 'L': 2, 'H': 1, 'V': 1, 'C': 6, 'S': 4, 'Q': 4, 'Z': 0,
+}
+
+// parseSVGPath parses SVG path data into a Path with absolute coordinates.
+func parseSVGPath(d string) (*Path, error) {
+	s := svgPathScanner{d: d}
+	path := NewPath()
+
+	var (
+		cmd      byte  // Current command letter (case preserved)
+		cur      Point // Current point
+		start    Point // Start of the current subpath
+		ctrl     Point // Second control point of the previous C or S
+		smooth   bool  // Previous command was C or S (ctrl is valid)
+		closed   bool  // Previous command was Z
+		args     [6]float64
+		explicit bool
+	)
+
+	for {
+		s.skipSeparators()
+		if s.done() {
+			break
+		}
+
+		if c := s.peek(); isSVGPathCommand(c) {
+			cmd = c
+			explicit = true
+			s.pos++
+		} else if !s.atNumber() {
+			return nil, fmt.Errorf("svg path: unexpected %q at offset %d", c, s.pos)
+		} else {
+			switch upper(cmd) {
+			case 0:
+				return nil, errors.New("svg path: must start with a move command")
+			case 'Z':
+				return nil, fmt.Errorf("svg path: unexpected number at offset %d", s.pos)
+			}
+			explicit = false
+		}
+
+		op := upper(cmd)
+		if path.IsEmpty() && op != 'M' {
+			return nil, errors.New("svg path: must start with a move command")
+		}
+		if op == 'M' && !explicit {
+			// Coordinate pairs following a move are implicit lines.
+			op = 'L'
+		}
+
+		n := svgPathArgs[op]
+		for i := range n {
+			v, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+
+		// Relative commands offset their coordinates by the current point.
+		if cmd >= 'a' {
+			for i := range n {
+				switch {
+				case op == 'H':
+					args[i] += cur.X
+				case op == 'V':
+					args[i] += cur.Y
+				case i%2 == 0:
+					args[i] += cur.X
+				default:
+					args[i] += cur.Y
+				}
+			}
+		}
+
+		// A command after Z starts a new subpath at the closed one's start.
+		if closed && op != 'M' && op != 'Z' {
+			path.MoveTo(start.X, start.Y)
+		}
+
+		wasSmooth := smooth
+		smooth, closed = false, false
+		switch op {
+		case 'M':
+			path.MoveTo(args[0], args[1])
+			cur = Point{X: args[0], Y: args[1]}
+			start = cur
+		case 'L':
+			path.LineTo(args[0], args[1])
+			cur = Point{X: args[0], Y: args[1]}
+		case 'H':
+			path.LineTo(args[0], cur.Y)
+			cur.X = args[0]
+		case 'V':
+			path.LineTo(cur.X, args[0])
+			cur.Y = args[0]
+		case 'C':
+			path.CubicTo(args[0], args[1], args[2], args[3], args[4], args[5])
+			ctrl = Point{X: args[2], Y: args[3]}
+			cur = Point{X: args[4], Y: args[5]}
+			smooth = true
+		case 'S':
+			// The first control point reflects the previous curve's second
+			// control point about the current point.
+			c1 := cur
+			if wasSmooth {
+				c1 = Point{X: 2*cur.X - ctrl.X, Y: 2*cur.Y - ctrl.Y}
+			}
+			path.CubicTo(c1.X, c1.Y, args[0], args[1], args[2], args[3])
+			ctrl = Point{X: args[0], Y: args[1]}
+			cur = Point{X: args[2], Y: args[3]}
+			smooth = true
+		case 'Q':
+			path.QuadraticTo(args[0], args[1], args[2], args[3])
+			cur = Point{X: args[2], Y: args[3]}
+		case 'Z':
+			path.Close()
+			cur = start
+			closed = true
+		}
+	}
+
+	if path.IsEmpty() {
+		return nil, errors.New("svg path: no commands")
+	}
+	return path, nil
+}
+
+// isSVGPathCommand reports whether c is a supported SVG path command.
+func isSVGPathCommand(c byte) bool {
+	_, ok := svgPathArgs[upper(c)]
+	return ok
+}
+
+// upper returns the uppercase form of an ASCII letter.
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// svgPathScanner reads the tokens of SVG path data.
+type svgPathScanner struct {
+	d   string
+	pos int
+}
+
+func (s *svgPathScanner) done() bool { return s.pos >= len(s.d) }
+
+func (s *svgPathScanner) peek() byte { return s.d[s.pos] }
+
+// skipSeparators skips white space and commas.
+func (s *svgPathScanner) skipSeparators() {
+	for !s.done() {
+		switch s.peek() {
+		case ' ', '\t', '\n', '\r', '\f', ',':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// atNumber reports whether a number starts at the current position.
+func (s *svgPathScanner) atNumber() bool {
+	if s.done() {
+		return false
+	}
+	c := s.peek()
+	return c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9')
+}
+
+// number reads the next number. Numbers need no separator when the next
+// one starts with a sign or a second decimal point ("1-2", "0.5.5").
+func (s *svgPathScanner) number() (float64, error) {
+	s.skipSeparators()
+	if !s.atNumber() {
+		if s.done() {
+			return 0, errors.New("svg path: missing coordinate at end of data")
+		}
+		return 0, fmt.Errorf("svg path: expected number at offset %d, got %q", s.pos, s.peek())
+	}
+
+	begin := s.pos
+	if c := s.peek(); c == '+' || c == '-' {
+		s.pos++
+	}
+	digits := s.digits()
+	if !s.done() && s.peek() == '.' {
+		s.pos++
+		digits += s.digits()
+	}
+	if digits == 0 {
+		return 0, fmt.Errorf("svg path: invalid number at offset %d", begin)
+	}
+	if !s.done() && (s.peek() == 'e' || s.peek() == 'E') {
+		s.pos++
+		if !s.done() && (s.peek() == '+' || s.peek() == '-') {
+			s.pos++
+		}
+		if s.digits() == 0 {
+			return 0, fmt.Errorf("svg path: invalid exponent at offset %d", begin)
+		}
+	}
+
+	v, err := strconv.ParseFloat(s.d[begin:s.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("svg path: invalid number %q: %w", s.d[begin:s.pos], err)
+	}
+	return v, nil
+}
+
+// digits skips decimal digits and returns how many there were.
+func (s *svgPathScanner) digits() int {
+	n := 0
+	for !s.done() && s.peek() >= '0' && s.peek() <= '9' {
+		s.pos++
+		n++
+	}
+	return n
+}
@@ -0,0 +1,106 @@
+package creator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// pathContent draws d on a new page and returns the content stream.
+func pathContent(t *testing.T, d string, opts *PathOptions) string {
+	t.Helper()
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawPath(d, opts); err != nil {
+		t.Fatalf("DrawPath(%q) error = %v", d, err)
+	}
+	out, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	return string(out)
+}
+
+func TestPage_DrawPath_MixedCommands(t *testing.T) {
+	d := "M10 20 l30 0 H100 v-10 C110 0 120 0 130 10 s10 20 20 0 q10-10 20 0 Z m5 5 L 1e1,2.5e1 z"
+	got := pathContent(t, d, &PathOptions{StrokeColor: &Black})
+
+	want := strings.Join([]string{
+		"10 20 m",
+		"40 20 l",
+		"100 20 l",
+		"100 10 l",
+		"110 0 120 0 130 10 c",
+		"140 20 140 30 150 10 c",           // S reflects the previous control point
+		"156.67 3.33 163.33 3.33 170 10 c", // Q converted to a cubic
+		"h",
+		"15 25 m", // Relative to the start of the closed subpath
+		"10 25 l",
+		"h",
+		"S",
+	}, "\n")
+	if !strings.Contains(got, want) {
+		t.Errorf("expected path operators\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestPage_DrawPath_ImplicitCommands(t *testing.T) {
+	// Pairs after a move are lines; a line after Z starts at the subpath start.
+	got := pathContent(t, "m0 0 10 0 0 10 z l5 5", &PathOptions{FillColor: &Red, StrokeColor: &Black})
+
+	want := "0 0 m\n10 0 l\n10 10 l\nh\n0 0 m\n5 5 l\n1 0 0 rg\nB"
+	if !strings.Contains(got, want) {
+		t.Errorf("expected path operators\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestPage_DrawPath_Invalid(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	for _, d := range []string{"", "L 1 2", "10 20", "M 1", "M 1 2 X 3", "M 1 2 Z 3 4", "M 1 2 L 3 e", "M 1 2 A 1 1 0 0 1 3 4"} {
+		if err := page.DrawPath(d, &PathOptions{StrokeColor: &Black}); err == nil {
+			t.Errorf("DrawPath(%q) expected error", d)
+		}
+	}
+	if err := page.DrawPath("M 0 0 L 1 1", &PathOptions{}); err == nil {
+		t.Error("expected error for path without stroke or fill")
+	}
+	if err := page.DrawPath("M 0 0 L 1 1", nil); err == nil {
+		t.Error("expected error for nil options")
+	}
+	if len(page.GraphicsOperations()) != 0 {
+		t.Errorf("invalid paths must not be drawn, got %d operations", len(page.GraphicsOperations()))
+	}
+}
+
+func TestPage_DrawPath_EvenOddRing(t *testing.T) {
+	// Two concentric squares wound the same way: nonzero fills both, even-odd
+	// leaves the inner one as a hole.
+	ring := "M 100 100 H 300 V 300 H 100 Z M 150 150 H 250 V 250 H 150 Z"
+
+	got := pathContent(t, ring, &PathOptions{FillColor: &Red, FillRule: FillRuleEvenOdd})
+	if !strings.Contains(got, "h\n1 0 0 rg\nf*\n") {
+		t.Errorf("expected even-odd fill, got:\n%s", got)
+	}
+	if strings.Count(got, " m\n") != 2 {
+		t.Errorf("expected both rings in one path, got:\n%s", got)
+	}
+
+	got = pathContent(t, ring, &PathOptions{FillColor: &Red, StrokeColor: &Black, FillRule: FillRuleEvenOdd})
+	if !strings.Contains(got, "B*\n") {
+		t.Errorf("expected even-odd fill and stroke, got:\n%s", got)
+	}
+
+	got = pathContent(t, ring, &PathOptions{FillColor: &Red})
+	if !strings.Contains(got, "f\n") || strings.Contains(got, "f*") {
+		t.Errorf("expected nonzero fill by default, got:\n%s", got)
+	}
+
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawPath(ring, &PathOptions{FillColor: &Red, FillRule: FillRule(2)}); err == nil {
+		t.Error("expected error for invalid fill rule")
+	}
+}
@@ -0,0 +1,112 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TableOptions configures how DrawTable flows a table across pages.
+type TableOptions struct {
+	// RepeatHeaderRows is the number of leading rows redrawn at the top of
+	// each page the table continues on (typically HeaderRowCount()).
+	// 0 draws them on the first page only.
+	RepeatHeaderRows int
+
+	// RowColors are background colors cycled over the body rows, as set
+	// by TableLayout.SetRowColors, which they replace when not empty.
+	// Rows keep their color across page breaks.
+	RowColors []Color
+}
+
+// DrawTable draws a table at the flow cursor, breaking it across pages.
+//
+// Rows that do not fit above the bottom margin continue at the top of a
+// new page's content area. With opts.RepeatHeaderRows set, the first rows
+// of the table are redrawn on every continuation page before the
+// remaining rows. A row is never split, and every page receives at least
+// one row beyond the repeated header, so a row taller than the page is
+// drawn anyway.
+//
+// Returns the page the table ends on, with its layout cursor below the
+// table (whether or not SetFlowCursor is enabled). Subsequent flow
+// content should continue on that page.
+//
+// Example:
+//
+//	table := creator.NewTableLayout(3)
+//	table.AddHeaderRow("Date", "Item", "Amount")
+//	for _, e := range entries {
+//	    table.AddRow(e.Date, e.Item, e.Amount)
+//	}
+//	page, err = page.DrawTable(table, &creator.TableOptions{RepeatHeaderRows: 1})
+func (p *Page) DrawTable(table *TableLayout, opts *TableOptions) (*Page, error) {
+	if table == nil {
+		return nil, errors.New("table cannot be nil")
+	}
+
+	repeat := 0
+	if opts != nil {
+		repeat = min(max(opts.RepeatHeaderRows, 0), len(table.rows))
+		if len(opts.RowColors) > 0 {
+			colored := *table
+			colored.rowColors = opts.RowColors
+			table = &colored
+		}
+	}
+	rowHeight := table.calculateRowHeight()
+
+	page := p
+	var segment []int // Indices of the rows to draw on the current page
+	prefix := 0       // Repeated header rows at the start of segment
+
+	for i := range table.rows {
+		ctx := page.GetLayoutContext()
+		needed := float64(len(segment)+1)*rowHeight + table.borderWidth
+		fits := needed <= ctx.AvailableHeight()+keepTogetherEpsilon
+		canBreak := len(segment) > prefix || (len(segment) == 0 && ctx.CursorY > 0)
+
+		if !fits && canBreak {
+			if err := page.drawTableSegment(table, segment); err != nil {
+				return nil, err
+			}
+			if page.nextPage == nil {
+				return nil, ErrNoNextPage
+			}
+			next, err := page.nextPage()
+			if err != nil {
+				return nil, fmt.Errorf("table page break: %w", err)
+			}
+			page = next
+
+			segment, prefix = nil, 0
+			if i >= repeat {
+				for h := range repeat {
+					segment = append(segment, h)
+				}
+				prefix = repeat
+			}
+		}
+		segment = append(segment, i)
+	}
+
+	if err := page.drawTableSegment(table, segment); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// drawTableSegment draws the rows of a table at the given indices at the
+// flow cursor, with the table's column widths, borders, padding and row
+// colors.
+func (p *Page) drawTableSegment(table *TableLayout, rows []int) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	segment := *table
+	segment.rows = make([]TableRow, len(rows))
+	for i, idx := range rows {
+		segment.rows[i] = table.rows[idx]
+	}
+	segment.rowIndex = rows
+	return p.drawFlow(&segment)
+}
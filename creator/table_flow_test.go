@@ -0,0 +1,119 @@
+package creator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pageTexts returns the text of every text operation on a page.
+func pageTexts(p *Page) []string {
+	texts := make([]string, len(p.textOps))
+	for i, op := range p.textOps {
+		texts[i] = op.Text
+	}
+	return texts
+}
+
+// numberedTable returns a one-column table with a header and n rows.
+func numberedTable(n int) *TableLayout {
+	table := NewTableLayout(1).SetBorder(0.5, Black)
+	table.AddHeaderRow("Header")
+	for i := 1; i <= n; i++ {
+		table.AddRow(fmt.Sprintf("Row %d", i))
+	}
+	return table
+}
+
+func TestPage_DrawTable_RepeatsHeaderRows(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Leave room for the header and two rows (row height 18).
+	ctx := page.GetLayoutContext()
+	page.MoveCursor(ctx.ContentLeft(), ctx.AvailableHeight()-3*18-1)
+
+	last, err := page.DrawTable(numberedTable(4), &TableOptions{RepeatHeaderRows: 1})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, c.PageCount())
+	assert.Same(t, c.pages[1], last)
+	assert.Equal(t, []string{"Header", "Row 1", "Row 2"}, pageTexts(page))
+	assert.Equal(t, []string{"Header", "Row 3", "Row 4"}, pageTexts(last))
+
+	// The cursor continues below the last row on the new page.
+	_, y := last.Cursor()
+	assert.InDelta(t, 3*18+0.5, y, 1e-9)
+}
+
+func TestPage_DrawTable_NoRepeat(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	ctx := page.GetLayoutContext()
+	page.MoveCursor(ctx.ContentLeft(), ctx.AvailableHeight()-3*18-1)
+
+	last, err := page.DrawTable(numberedTable(4), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Header", "Row 1", "Row 2"}, pageTexts(page))
+	assert.Equal(t, []string{"Row 3", "Row 4"}, pageTexts(last))
+}
+
+func TestPage_DrawTable_StartsOnNextPage(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Not even the header fits: the whole table moves to the next page.
+	ctx := page.GetLayoutContext()
+	page.MoveCursor(ctx.ContentLeft(), ctx.AvailableHeight()-10)
+
+	last, err := page.DrawTable(numberedTable(2), &TableOptions{RepeatHeaderRows: 1})
+	require.NoError(t, err)
+
+	assert.Empty(t, pageTexts(page))
+	assert.Equal(t, []string{"Header", "Row 1", "Row 2"}, pageTexts(last))
+}
+
+func TestPage_DrawTable_NoNextPage(t *testing.T) {
+	page := &Page{page: document.NewPage(0, document.A4), margins: Margins{72, 72, 72, 72}}
+	page.MoveCursor(72, page.ContentHeight()-1)
+
+	_, err := page.DrawTable(numberedTable(2), nil)
+	assert.ErrorIs(t, err, ErrNoNextPage)
+}
+
+func TestPage_DrawTable_RowColorsAcrossPages(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	ctx := page.GetLayoutContext()
+	page.MoveCursor(ctx.ContentLeft(), ctx.AvailableHeight()-3*18-1)
+
+	table := numberedTable(3).SetBorder(0, Black)
+	last, err := page.DrawTable(table, &TableOptions{
+		RepeatHeaderRows: 1,
+		RowColors:        []Color{White, LightGray},
+	})
+	require.NoError(t, err)
+
+	fills := func(p *Page) []Color {
+		var colors []Color
+		for _, op := range p.GraphicsOperations() {
+			colors = append(colors, *op.RectOpts.FillColor)
+		}
+		return colors
+	}
+
+	// Rows 1 and 2 on the first page; row 3 continues the cycle.
+	assert.Equal(t, []Color{White, LightGray}, fills(page))
+	assert.Equal(t, []Color{White}, fills(last))
+	assert.Empty(t, table.rowColors, "options must not modify the table")
+}
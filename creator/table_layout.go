@@ -23,6 +23,10 @@ type TableCell struct {
 
 	// ColSpan is the number of columns this cell spans (future use).
 	ColSpan int
+
+	// Background is the cell fill color (nil = the row color, if any).
+	// It overrides the table's row colors for this cell.
+	Background *Color
 }
 
 // NewTableCell creates a new table cell with text content and default styling.
@@ -58,11 +62,14 @@ type TableRow struct {
 type TableLayout struct {
 	columns      int
 	columnWidths []float64 // nil = auto
+	columnWeight []float64 // nil = equal shares of the remaining width
 	rows         []TableRow
 	borderWidth  float64
 	borderColor  *Color
 	headerRows   int
 	cellPadding  float64 // padding inside cells
+	rowColors    []Color // cycled over body rows; nil = no row fill
+	rowIndex     []int   // original row indices when drawing a subset of rows
 }
 
 // NewTableLayout creates a new table with the specified number of columns.
@@ -88,6 +95,25 @@ func (t *TableLayout) SetColumnWidths(widths ...float64) *TableLayout {
 	return t
 }
 
+// SetColumnWeights sizes the auto-width columns in proportion to weights.
+//
+// Columns given an explicit width with SetColumnWidths stay fixed; the
+// width left over is divided among the other columns by weight. Columns
+// without a weight (or with a weight <= 0) get weight 1.
+// Returns the table for method chaining.
+//
+// Example:
+//
+//	// Label column 1 part, value column 3 parts of the available width.
+//	table := NewTableLayout(2).SetColumnWeights(1, 3)
+//
+//	// Fixed 60pt index column; the rest split 1:2.
+//	table = NewTableLayout(3).SetColumnWidths(60).SetColumnWeights(0, 1, 2)
+func (t *TableLayout) SetColumnWeights(weights ...float64) *TableLayout {
+	t.columnWeight = weights
+	return t
+}
+
 // SetBorder enables table borders with the specified width and color.
 // Returns the table for method chaining.
 func (t *TableLayout) SetBorder(width float64, color Color) *TableLayout {
@@ -103,6 +129,20 @@ func (t *TableLayout) SetCellPadding(padding float64) *TableLayout {
 	return t
 }
 
+// SetRowColors sets background colors cycled over the body rows (rows
+// after the header rows), e.g. two colors for zebra striping.
+// Header rows are not filled; cells with a Background keep their own color.
+// Call with no colors to remove row fills.
+// Returns the table for method chaining.
+//
+// Example:
+//
+//	table := NewTableLayout(3).SetRowColors(White, LightGray)
+func (t *TableLayout) SetRowColors(colors ...Color) *TableLayout {
+	t.rowColors = colors
+	return t
+}
+
 // AddHeaderRow adds a header row with the given cell texts.
 // Header rows use bold font by default.
 // Returns the table for method chaining.
@@ -182,14 +222,55 @@ func (t *TableLayout) Height(_ *LayoutContext) float64 {
 
 // Draw renders the table on the page at the current cursor position.
 func (t *TableLayout) Draw(ctx *LayoutContext, page *Page) error {
+	if err := t.drawAt(page, ctx.ContentLeft(), ctx.CurrentPDFY(), ctx.AvailableWidth()); err != nil {
+		return err
+	}
+
+	// Update cursor position.
+	ctx.CursorY += t.Height(ctx)
+
+	return nil
+}
+
+// DrawAt draws the table with its top-left corner at (x, y), in PDF
+// coordinates, without using or moving the flow cursor. Auto-width
+// columns share the width from x to the page's right margin.
+//
+// Returns the height of the table. The table is not broken across pages;
+// use DrawTable for tables that may overflow.
+//
+// Example:
+//
+//	table := creator.NewTableLayout(3).SetBorder(0.5, creator.Black)
+//	table.AddHeaderRow("Item", "Qty", "Price")
+//	table.AddRow("Widget", "2", "9.90")
+//	height, err := table.DrawAt(page, 72, 600)
+//	// Continue below the table at 600 - height.
+func (t *TableLayout) DrawAt(page *Page, x, y float64) (float64, error) {
+	if err := t.drawAt(page, x, y, page.Width()-page.Margins().Right-x); err != nil {
+		return 0, err
+	}
+	return t.Height(nil), nil
+}
+
+// drawAt draws the table with its top-left corner at (startX, startY),
+// sizing auto-width columns to share availableWidth.
+func (t *TableLayout) drawAt(page *Page, startX, startY, availableWidth float64) error {
 	if len(t.rows) == 0 {
 		return nil
 	}
 
-	colWidths := t.calculateColumnWidths(ctx.AvailableWidth())
+	colWidths := t.calculateColumnWidths(availableWidth)
 	rowHeight := t.calculateRowHeight()
-	startX := ctx.ContentLeft()
-	startY := ctx.CurrentPDFY()
+
+	// Fill backgrounds first so text and borders are drawn over them.
+	for rowIdx, row := range t.rows {
+		y := startY - float64(rowIdx)*rowHeight
+
+		if err := t.drawRowBackground(page, row, t.rowColor(rowIdx), startX, y, colWidths, rowHeight); err != nil {
+			return err
+		}
+	}
 
 	// Draw rows.
 	for rowIdx, row := range t.rows {
@@ -207,9 +288,6 @@ func (t *TableLayout) Draw(ctx *LayoutContext, page *Page) error {
 		}
 	}
 
-	// Update cursor position.
-	ctx.CursorY += t.Height(ctx)
-
 	return nil
 }
 
@@ -228,33 +306,35 @@ func (t *TableLayout) calculateRowHeight() float64 {
 }
 
 // calculateColumnWidths calculates widths for each column.
+//
+// Explicit widths are used as-is; the remaining width is divided among
+// the auto columns in proportion to their weights.
 func (t *TableLayout) calculateColumnWidths(availableWidth float64) []float64 {
 	widths := make([]float64, t.columns)
 
 	// Use explicit widths if provided.
 	explicitTotal := 0.0
-	autoCount := 0
+	weightTotal := 0.0
 
 	for i := 0; i < t.columns; i++ {
 		if i < len(t.columnWidths) && t.columnWidths[i] > 0 {
 			widths[i] = t.columnWidths[i]
 			explicitTotal += t.columnWidths[i]
 		} else {
-			autoCount++
+			weightTotal += t.columnWeightAt(i)
 		}
 	}
 
-	// Distribute remaining width to auto columns.
-	if autoCount > 0 {
+	// Distribute remaining width to auto columns by weight.
+	if weightTotal > 0 {
 		remainingWidth := availableWidth - explicitTotal
 		if remainingWidth < 0 {
 			remainingWidth = 0
 		}
-		autoWidth := remainingWidth / float64(autoCount)
 
 		for i := 0; i < t.columns; i++ {
 			if widths[i] == 0 {
-				widths[i] = autoWidth
+				widths[i] = remainingWidth * t.columnWeightAt(i) / weightTotal
 			}
 		}
 	}
@@ -262,6 +342,58 @@ func (t *TableLayout) calculateColumnWidths(availableWidth float64) []float64 {
 	return widths
 }
 
+// columnWeightAt returns the weight of column i (1 if not set).
+func (t *TableLayout) columnWeightAt(i int) float64 {
+	if i < len(t.columnWeight) && t.columnWeight[i] > 0 {
+		return t.columnWeight[i]
+	}
+	return 1
+}
+
+// rowColor returns the row color for the row at rowIdx, or nil for header
+// rows and tables without row colors.
+func (t *TableLayout) rowColor(rowIdx int) *Color {
+	if t.rowIndex != nil {
+		rowIdx = t.rowIndex[rowIdx]
+	}
+	body := rowIdx - t.headerRows
+	if body < 0 || len(t.rowColors) == 0 {
+		return nil
+	}
+	c := t.rowColors[body%len(t.rowColors)]
+	return &c
+}
+
+// drawRowBackground fills the cells of a row with their Background color,
+// or with rowColor (if not nil) for cells without one.
+func (t *TableLayout) drawRowBackground(
+	page *Page,
+	row TableRow,
+	rowColor *Color,
+	startX, y float64,
+	colWidths []float64,
+	rowHeight float64,
+) error {
+	x := startX
+
+	for colIdx := 0; colIdx < t.columns; colIdx++ {
+		fill := rowColor
+		if colIdx < len(row.Cells) && row.Cells[colIdx].Background != nil {
+			fill = row.Cells[colIdx].Background
+		}
+
+		if fill != nil {
+			if err := page.DrawRectFilled(x, y-rowHeight, colWidths[colIdx], rowHeight, *fill); err != nil {
+				return err
+			}
+		}
+
+		x += colWidths[colIdx]
+	}
+
+	return nil
+}
+
 // drawRow draws a single row at the specified position.
 func (t *TableLayout) drawRow(
 	page *Page,
@@ -1,7 +1,10 @@
 package creator
 
 import (
+	"math"
 	"testing"
+
+	"github.com/coregx/gxpdf/internal/fonts"
 )
 
 func TestNewTableLayout(t *testing.T) {
@@ -364,6 +367,65 @@ func TestTableLayout_Draw_CursorAdvances(t *testing.T) {
 	}
 }
 
+func TestTableLayout_DrawAt(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+
+	price := NewTableCell("9.90")
+	price.Align = AlignRight
+	table := NewTableLayout(2).
+		SetColumnWidths(100, 150).
+		SetBorder(1, Black).
+		AddHeaderRow("Item", "Price").
+		AddRowCells(NewTableCell("Widget"), price)
+
+	height, err := table.DrawAt(page, 72, 600)
+	if err != nil {
+		t.Fatalf("DrawAt() returned error: %v", err)
+	}
+	// Two 18pt rows (10pt text + 2 × 4pt padding) and the bottom border.
+	if height != 37 {
+		t.Errorf("DrawAt() height = %v, want 37", height)
+	}
+	if cursor := page.GetLayoutContext().CursorY; cursor != 0 {
+		t.Errorf("DrawAt() moved the cursor to %v", cursor)
+	}
+
+	ops := page.TextOperations()
+	priceX := 72 + 250 - 4 - fonts.MeasureString(string(Helvetica), "9.90", 10)
+	want := []struct {
+		text string
+		x, y float64
+	}{
+		{"Item", 76, 586},
+		{"Price", 176, 586},
+		{"Widget", 76, 568},
+		{"9.90", priceX, 568},
+	}
+	if len(ops) != len(want) {
+		t.Fatalf("Expected %d text operations, got %d", len(want), len(ops))
+	}
+	for i, w := range want {
+		if ops[i].Text != w.text || math.Abs(ops[i].X-w.x) > 1e-9 || ops[i].Y != w.y {
+			t.Errorf("text %d = %q at (%v, %v), want %q at (%v, %v)", i, ops[i].Text, ops[i].X, ops[i].Y, w.text, w.x, w.y)
+		}
+	}
+
+	// Three horizontal and three vertical border lines.
+	lines := 0
+	for _, op := range page.GraphicsOperations() {
+		if op.Type == GraphicsOpLine {
+			lines++
+		}
+	}
+	if lines != 6 {
+		t.Errorf("Expected 6 border lines, got %d", lines)
+	}
+}
+
 func TestTableLayout_CalculateColumnWidths_Auto(t *testing.T) {
 	table := NewTableLayout(4)
 	widths := table.calculateColumnWidths(400)
@@ -420,6 +482,32 @@ func TestTableLayout_CalculateColumnWidths_Partial(t *testing.T) {
 	}
 }
 
+func TestTableLayout_CalculateColumnWidths_Weights(t *testing.T) {
+	table := NewTableLayout(2).SetColumnWeights(1, 3)
+	widths := table.calculateColumnWidths(400)
+
+	if widths[0] != 100 {
+		t.Errorf("Width[0] = %v, want 100", widths[0])
+	}
+	if widths[1] != 300 {
+		t.Errorf("Width[1] = %v, want 300", widths[1])
+	}
+}
+
+func TestTableLayout_CalculateColumnWidths_FixedAndWeights(t *testing.T) {
+	// Column 0 is fixed (its weight is ignored); the remaining 400 is split
+	// 1:2:1, as column 3 has no weight and defaults to 1.
+	table := NewTableLayout(4).SetColumnWidths(100).SetColumnWeights(5, 1, 2)
+	widths := table.calculateColumnWidths(500)
+
+	want := []float64{100, 100, 200, 100}
+	for i, w := range want {
+		if widths[i] != w {
+			t.Errorf("Width[%d] = %v, want %v", i, widths[i], w)
+		}
+	}
+}
+
 func TestTableLayout_ImplementsDrawable(_ *testing.T) {
 	var _ Drawable = (*TableLayout)(nil)
 }
@@ -460,3 +548,44 @@ func TestTableLayout_CellAlignment(t *testing.T) {
 		t.Error("Text X positions should increase for different columns")
 	}
 }
+
+func TestTableLayout_SetRowColors(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error: %v", err)
+	}
+
+	highlight := Red
+	table := NewTableLayout(2).SetRowColors(White, LightGray)
+	table.AddHeaderRow("Name", "Score")
+	table.AddRow("Alice", "90")
+	table.AddRow("Bob", "85")
+	table.AddRowCells(NewTableCell("Carol"), TableCell{Content: "70", Font: Helvetica, FontSize: 10, Background: &highlight})
+
+	ctx := page.GetLayoutContext()
+	startY := ctx.CurrentPDFY()
+	if err := table.Draw(ctx, page); err != nil {
+		t.Fatalf("Draw() returned error: %v", err)
+	}
+
+	// Header row is not filled; each body row fills both cells.
+	ops := page.GraphicsOperations()
+	want := []Color{White, White, LightGray, LightGray, White, Red}
+	if len(ops) != len(want) {
+		t.Fatalf("Expected %d fills, got %d", len(want), len(ops))
+	}
+	for i, op := range ops {
+		if op.Type != GraphicsOpRect || op.RectOpts.FillColor == nil {
+			t.Fatalf("op %d: expected filled rectangle", i)
+		}
+		if *op.RectOpts.FillColor != want[i] {
+			t.Errorf("op %d: fill = %v, want %v", i, *op.RectOpts.FillColor, want[i])
+		}
+	}
+
+	// The first body row's fill spans the row below the header.
+	if ops[0].Height != 18 || ops[0].Y != startY-2*18 {
+		t.Errorf("first fill at y=%v height=%v", ops[0].Y, ops[0].Height)
+	}
+}
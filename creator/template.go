@@ -0,0 +1,112 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/models/types"
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// templateCount numbers templates so that each has a unique ID.
+var templateCount atomic.Int64
+
+// Template is a reusable drawing, such as a logo or a repeated vector
+// motif. Draw into it once with the usual Page methods, then place it on
+// any number of pages with Page.DrawTemplate. The PDF holds the drawing
+// once, as a form XObject with its own resources, however often it is
+// placed.
+//
+// Template coordinates run from (0, 0) to (width, height); drawing outside
+// that box is clipped. Only drawing operations are recorded: annotations,
+// links and form fields added to a template are ignored. Templates can
+// draw other templates.
+//
+// Example:
+//
+//	logo, _ := creator.NewTemplate(100, 40)
+//	logo.DrawRect(0, 0, 100, 40, &creator.RectOptions{FillColor: &creator.Blue})
+//	logo.AddText("ACME", 10, 14, creator.HelveticaBold, 20)
+//
+//	for _, page := range pages {
+//	    page.DrawTemplate(logo, 50, 780, 1)
+//	}
+type Template struct {
+	*Page // Drawing surface
+
+	id string
+}
+
+// NewTemplate creates an empty template of the given size in points.
+func NewTemplate(width, height float64) (*Template, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("template dimensions must be positive")
+	}
+
+	mediaBox, err := types.NewRectangle(0, 0, width, height)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{
+		Page: &Page{
+			page:        document.NewPageWithMediaBox(0, mediaBox),
+			textOps:     make([]TextOperation, 0),
+			graphicsOps: make([]GraphicsOperation, 0),
+		},
+		id: fmt.Sprintf("Tpl%d", templateCount.Add(1)),
+	}, nil
+}
+
+// DrawTemplate draws a template with its lower-left corner at (x, y),
+// scaled by scale (1 = the template's own size).
+//
+// Every placement refers to the same form XObject (Do operator), so
+// drawing a template on many pages adds its content to the file once.
+//
+// Example:
+//
+//	page.DrawTemplate(logo, 50, 780, 0.5) // Half size
+func (p *Page) DrawTemplate(tpl *Template, x, y, scale float64) error {
+	if tpl == nil {
+		return errors.New("template cannot be nil")
+	}
+	if scale <= 0 {
+		return errors.New("template scale must be positive")
+	}
+	if tpl.draws(p) {
+		return errors.New("template cannot draw itself")
+	}
+
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+		Type:     GraphicsOpTemplate,
+		Matrix:   [6]float64{scale, 0, 0, scale, x, y},
+		Template: tpl,
+	})
+	return nil
+}
+
+// draws reports whether drawing t paints p, because p is the drawing
+// surface of t or of a template that t draws.
+func (t *Template) draws(p *Page) bool {
+	if t.Page == p {
+		return true
+	}
+	for _, op := range t.graphicsOps {
+		if op.Type == GraphicsOpTemplate && op.Template != nil && op.Template.draws(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// form converts the template to a writer form XObject.
+func (t *Template) form() *writer.FormXObject {
+	return &writer.FormXObject{
+		ID:          t.id,
+		BBox:        [4]float64{0, 0, t.Width(), t.Height()},
+		TextOps:     convertTextOps(t.textOps),
+		GraphicsOps: convertGraphicsOps(t.graphicsOps),
+	}
+}
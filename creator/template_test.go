@@ -0,0 +1,138 @@
+package creator
+
+import (
+	"bytes"
+	"image/color"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+func TestDrawTemplate_SharedFormXObject(t *testing.T) {
+	logo, err := NewTemplate(100, 40)
+	if err != nil {
+		t.Fatalf("NewTemplate() error = %v", err)
+	}
+	if err := logo.DrawRect(0, 0, 100, 40, &RectOptions{FillColor: &Blue}); err != nil {
+		t.Fatalf("DrawRect() error = %v", err)
+	}
+	if err := logo.AddText("ACME", 10, 14, HelveticaBold, 20); err != nil {
+		t.Fatalf("AddText() error = %v", err)
+	}
+
+	c := New()
+	for i := 0; i < 3; i++ {
+		page, _ := c.NewPage()
+		if err := page.DrawTemplate(logo, 50, 750, 1); err != nil {
+			t.Fatalf("DrawTemplate() error = %v", err)
+		}
+	}
+
+	pdf, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	out := string(pdf)
+
+	if n := strings.Count(out, "/Subtype /Form"); n != 1 {
+		t.Errorf("PDF has %d form XObjects, want 1 shared by all pages", n)
+	}
+	if !strings.Contains(out, "/BBox [0 0 100 40] /Matrix [1 0 0 1 0 0] /Resources << /Font <<") {
+		t.Errorf("form XObject should have a bounding box, matrix and its own font resources")
+	}
+	refs := regexp.MustCompile(`/Fm1 (\d+) 0 R`).FindAllStringSubmatch(out, -1)
+	if len(refs) != 3 {
+		t.Fatalf("found %d /Fm1 resources, want 3", len(refs))
+	}
+	for _, ref := range refs[1:] {
+		if ref[1] != refs[0][1] {
+			t.Errorf("pages reference form objects %s and %s, want one shared object", refs[0][1], ref[1])
+		}
+	}
+}
+
+func TestDrawTemplate_Placement(t *testing.T) {
+	tpl, _ := NewTemplate(10, 10)
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawTemplate(tpl, 100, 200, 0.5); err != nil {
+		t.Fatalf("DrawTemplate() error = %v", err)
+	}
+	img, err := LoadImageFromReader(bytes.NewReader(createPNGData(t, 2, 2, color.RGBA{R: 255, A: 255})))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader() error = %v", err)
+	}
+	if err := page.DrawImage(img, 0, 0, 10, 10); err != nil {
+		t.Fatalf("DrawImage() error = %v", err)
+	}
+
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	got := string(content)
+	if !strings.Contains(got, "q\n0.5 0 0 0.5 100 200 cm\n/Fm1 Do\nQ\n") {
+		t.Errorf("expected the form to be placed with cm and drawn with Do, got:\n%s", got)
+	}
+	if !strings.Contains(got, "/Im1 Do") {
+		t.Errorf("images keep their own names next to forms, got:\n%s", got)
+	}
+}
+
+func TestDrawTemplate_Nested(t *testing.T) {
+	dot, _ := NewTemplate(4, 4)
+	if err := dot.DrawCircle(2, 2, 2, &CircleOptions{FillColor: &Red}); err != nil {
+		t.Fatalf("DrawCircle() error = %v", err)
+	}
+	row, _ := NewTemplate(40, 4)
+	for x := 0.0; x < 40; x += 8 {
+		if err := row.DrawTemplate(dot, x, 0, 1); err != nil {
+			t.Fatalf("DrawTemplate() error = %v", err)
+		}
+	}
+
+	c := New()
+	for i := 0; i < 2; i++ {
+		page, _ := c.NewPage()
+		if err := page.DrawTemplate(row, 100, 100, 2); err != nil {
+			t.Fatalf("DrawTemplate() error = %v", err)
+		}
+	}
+
+	pdf, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if n := strings.Count(string(pdf), "/Subtype /Form"); n != 2 {
+		t.Errorf("PDF has %d form XObjects, want 2 (row and dot)", n)
+	}
+}
+
+func TestDrawTemplate_Invalid(t *testing.T) {
+	if _, err := NewTemplate(0, 10); err == nil {
+		t.Error("expected error for zero width")
+	}
+
+	a, _ := NewTemplate(10, 10)
+	b, _ := NewTemplate(10, 10)
+	if err := a.DrawTemplate(a, 0, 0, 1); err == nil {
+		t.Error("expected error for a template drawing itself")
+	}
+	if err := a.DrawTemplate(b, 0, 0, 1); err != nil {
+		t.Fatalf("DrawTemplate() error = %v", err)
+	}
+	if err := b.DrawTemplate(a, 0, 0, 1); err == nil {
+		t.Error("expected error for a template cycle")
+	}
+
+	c := New()
+	page, _ := c.NewPage()
+	if err := page.DrawTemplate(nil, 0, 0, 1); err == nil {
+		t.Error("expected error for nil template")
+	}
+	if err := page.DrawTemplate(a, 0, 0, 0); err == nil {
+		t.Error("expected error for zero scale")
+	}
+}
@@ -54,6 +54,19 @@ var (
 	Magenta = Color{1, 0, 1}
 )
 
+// NewGrayColor creates a gray level color (0.0 = black, 1.0 = white).
+//
+// Gray colors, and any Color whose components are equal, are written to
+// the page in DeviceGray (g and G operators) rather than RGB. This keeps
+// black-and-white documents small and prints them with black ink only.
+//
+// Example:
+//
+//	caption := creator.NewGrayColor(0.4) // Dark gray
+func NewGrayColor(level float64) Color {
+	return Color{R: level, G: level, B: level}
+}
+
 // ColorCMYK represents a CMYK color with values in the range [0.0, 1.0].
 //
 // CMYK (Cyan, Magenta, Yellow, blacK) is a subtractive color model used in
@@ -401,4 +414,41 @@ type TextOperation struct {
 	// Works with both Color and ColorCMYK.
 	// Range: [0.0, 1.0]
 	Opacity *float64
+
+	// Continued marks a run added by AddStyledText or AddColoredText that
+	// continues the previous operation on the same line. The run is written
+	// in the same text object and starts where the previous run ended; X
+	// and Y record its measured position.
+	Continued bool
+
+	// Rotation rotates the text counter-clockwise about (X, Y), in degrees.
+	Rotation float64
+
+	// Matrix is the [a b c d] part of a text matrix applied about (X, Y).
+	// When not all zero it takes precedence over Rotation.
+	Matrix [4]float64
+
+	// CharSpacing is extra space in points added after each character.
+	CharSpacing float64
+
+	// WordSpacing is extra space in points added to each space between
+	// words, as used for justified lines. Standard 14 fonts only.
+	WordSpacing float64
+
+	// Rise raises (positive) or lowers (negative) the baseline, in points.
+	Rise float64
+
+	// Underline and Strikethrough draw a line under or through the text,
+	// spanning its measured width, in the text color.
+	Underline     bool
+	Strikethrough bool
+}
+
+// width returns the advance width of the operation's text in its font,
+// without character and word spacing.
+func (op TextOperation) width() float64 {
+	if op.CustomFont != nil {
+		return op.CustomFont.MeasureString(op.Text, op.Size)
+	}
+	return op.Font.MeasureString(op.Text, op.Size)
 }
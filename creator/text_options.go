@@ -0,0 +1,248 @@
+package creator
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// VerticalAlign selects which part of the text the y coordinate refers to.
+type VerticalAlign int
+
+const (
+	// VAlignBaseline places the text baseline at y (the PDF default).
+	VAlignBaseline VerticalAlign = iota
+
+	// VAlignTop places the font ascent at y, so the text hangs below y.
+	VAlignTop
+
+	// VAlignMiddle centers the text between ascent and descent on y.
+	VAlignMiddle
+
+	// VAlignBottom places the font descent at y, so descenders rest on y.
+	VAlignBottom
+)
+
+// TextOptions configures text placement for AddTextWithOptions and
+// AddTextCustomFontWithOptions.
+//
+// Example:
+//
+//	// Align text to the top edge of a table cell at y=700.
+//	page.AddTextWithOptions("Header", 105, 700, creator.Helvetica, 12,
+//	    &creator.TextOptions{VAlign: creator.VAlignTop})
+type TextOptions struct {
+	// Color is the text color (RGB, 0.0 to 1.0 range, default black).
+	Color Color
+
+	// VAlign selects the vertical reference of the y coordinate
+	// (default: VAlignBaseline).
+	VAlign VerticalAlign
+
+	// Align selects the horizontal reference of the x coordinate: the left
+	// edge (AlignLeft, default), the center or the right edge of the text.
+	// A single line has no width to fill, so AlignJustify acts as AlignLeft.
+	Align Alignment
+
+	// CharSpacing is extra space added after each character (Tc), in
+	// points; negative values tighten the text. Useful for letter-spaced
+	// titles.
+	CharSpacing float64
+
+	// WordSpacing is extra space added to each space character (Tw), in
+	// points. It only affects Standard 14 fonts; embedded fonts use
+	// two-byte codes, to which word spacing does not apply.
+	WordSpacing float64
+
+	// Rotation rotates the text counter-clockwise about (x, y), in
+	// degrees. Use 90 for labels that read bottom to top, such as a chart's
+	// y-axis title.
+	Rotation float64
+
+	// TextMatrix is a general text transformation [a b c d e f] applied
+	// about (x, y): a, b, c and d rotate, scale or skew the text, and e
+	// and f offset it. When not all zero it takes precedence over Rotation.
+	TextMatrix [6]float64
+
+	// Rise raises (positive) or lowers (negative) the baseline by the given
+	// points (Ts), for footnote markers and other superscripts and
+	// subscripts; combine it with a smaller font size. Vertical alignment
+	// refers to the unshifted baseline.
+	Rise float64
+
+	// Underline draws a line below the baseline, and Strikethrough one
+	// through the middle of the lowercase letters, spanning the measured
+	// text width in the text color. The line width scales with the font
+	// size.
+	Underline     bool
+	Strikethrough bool
+}
+
+// AddTextWithOptions adds text using a Standard 14 font, positioned
+// according to opts.
+//
+// With VAlignTop, VAlignMiddle or VAlignBottom the emitted baseline is
+// shifted using the font's ascender and descender metrics so that y
+// refers to the chosen reference line. With AlignCenter or AlignRight the
+// start x is shifted by the text width measured from the font metrics.
+// Rotated text pivots about (x, y), and the alignment shifts follow the
+// rotated baseline. A nil opts behaves like AddText.
+//
+// Example:
+//
+//	// Vertically center a label on y=400.
+//	page.AddTextWithOptions("Total", 72, 400, creator.HelveticaBold, 14,
+//	    &creator.TextOptions{VAlign: creator.VAlignMiddle})
+//
+//	// Right-align an amount against x=540.
+//	page.AddTextWithOptions("1,234.00", 540, 400, creator.Helvetica, 12,
+//	    &creator.TextOptions{Align: creator.AlignRight})
+//
+//	// Vertical axis title, centered on y=400.
+//	page.AddTextWithOptions("Revenue", 40, 400, creator.Helvetica, 10,
+//	    &creator.TextOptions{Align: creator.AlignCenter, Rotation: 90})
+func (p *Page) AddTextWithOptions(text string, x, y float64, font FontName, size float64, opts *TextOptions) error {
+	if opts == nil {
+		opts = &TextOptions{}
+	}
+	if size <= 0 {
+		return errors.New("font size must be positive")
+	}
+
+	text = p.normalize(text)
+	ascent, descent := standardFontExtents(font)
+	baseline := alignBaseline(y, opts.VAlign, ascent, descent, size)
+	width := fonts.MeasureString(string(font), text, size) + spacingWidth(text, opts.CharSpacing, opts.WordSpacing)
+	if err := p.AddTextColor(text, alignStart(x, opts.Align, width), baseline, font, size, opts.Color); err != nil {
+		return err
+	}
+	p.setTextState(opts, opts.WordSpacing)
+	p.setTextTransform(x, y, opts)
+	return nil
+}
+
+// AddTextCustomFontWithOptions adds text using an embedded font, positioned
+// according to opts.
+//
+// Ascent and descent come from the font's hhea table. A nil opts behaves
+// like AddTextCustomFont.
+func (p *Page) AddTextCustomFontWithOptions(text string, x, y float64, font *CustomFont, size float64, opts *TextOptions) error {
+	if opts == nil {
+		opts = &TextOptions{}
+	}
+	if font == nil {
+		return errors.New("font cannot be nil")
+	}
+	if size <= 0 {
+		return errors.New("font size must be positive")
+	}
+
+	text = p.normalize(text)
+	ascent, descent := customFontExtents(font)
+	baseline := alignBaseline(y, opts.VAlign, ascent, descent, size)
+	width := font.MeasureString(text, size) + spacingWidth(text, opts.CharSpacing, 0)
+	if err := p.AddTextCustomFontColor(text, alignStart(x, opts.Align, width), baseline, font, size, opts.Color); err != nil {
+		return err
+	}
+	p.setTextState(opts, 0)
+	p.setTextTransform(x, y, opts)
+	return nil
+}
+
+// setTextState sets the spacing, rise and decorations of opts on the text
+// operation added last. wordSpacing is passed separately because embedded
+// fonts do not use it.
+func (p *Page) setTextState(opts *TextOptions, wordSpacing float64) {
+	op := &p.textOps[len(p.textOps)-1]
+	op.CharSpacing = opts.CharSpacing
+	op.WordSpacing = wordSpacing
+	op.Rise = opts.Rise
+	op.Underline = opts.Underline
+	op.Strikethrough = opts.Strikethrough
+}
+
+// setTextTransform applies the rotation or text matrix of opts to the text
+// operation added last, pivoting about (x, y). The alignment offsets from
+// (x, y) to the text start are transformed too, so that aligned text
+// stays anchored at (x, y).
+func (p *Page) setTextTransform(x, y float64, opts *TextOptions) {
+	m, e, f := opts.TextMatrix[:4], opts.TextMatrix[4], opts.TextMatrix[5]
+	switch {
+	case opts.TextMatrix != [6]float64{}:
+	case opts.Rotation != 0:
+		radians := opts.Rotation * math.Pi / 180
+		cos, sin := math.Cos(radians), math.Sin(radians)
+		m = []float64{cos, sin, -sin, cos}
+	default:
+		return
+	}
+
+	op := &p.textOps[len(p.textOps)-1]
+	dx, dy := op.X-x, op.Y-y
+	op.X = x + m[0]*dx + m[2]*dy + e
+	op.Y = y + m[1]*dx + m[3]*dy + f
+	op.Matrix = [4]float64{m[0], m[1], m[2], m[3]}
+}
+
+// spacingWidth returns the width that character and word spacing add to
+// text: charSpacing after every character and wordSpacing after every
+// space.
+func spacingWidth(text string, charSpacing, wordSpacing float64) float64 {
+	return charSpacing*float64(utf8.RuneCountInString(text)) + wordSpacing*float64(strings.Count(text, " "))
+}
+
+// alignStart converts an x coordinate with the given alignment to the
+// start of text that is width points wide.
+func alignStart(x float64, align Alignment, width float64) float64 {
+	switch align {
+	case AlignCenter:
+		return x - width/2
+	case AlignRight:
+		return x - width
+	default:
+		return x
+	}
+}
+
+// alignBaseline converts a y coordinate with the given alignment to a
+// baseline position.
+//
+// ascent and descent are in 1000-unit glyph space (descent is negative).
+func alignBaseline(y float64, align VerticalAlign, ascent, descent, size float64) float64 {
+	switch align {
+	case VAlignTop:
+		return y - ascent*size/1000
+	case VAlignMiddle:
+		return y - (ascent+descent)/2*size/1000
+	case VAlignBottom:
+		return y - descent*size/1000
+	default:
+		return y
+	}
+}
+
+// standardFontExtents returns the ascender and descender of a Standard 14
+// font in 1000-unit glyph space.
+//
+// Unknown fonts fall back to Helvetica-like values.
+func standardFontExtents(font FontName) (ascent, descent float64) {
+	m := fonts.GetMetrics(string(font))
+	if m == nil {
+		return 718, -207
+	}
+	return float64(m.GetAscender()), float64(m.GetDescender())
+}
+
+// customFontExtents returns the ascender and descender of an embedded font
+// scaled to 1000-unit glyph space.
+func customFontExtents(font *CustomFont) (ascent, descent float64) {
+	ttf := font.GetTTF()
+	if ttf == nil || ttf.UnitsPerEm == 0 {
+		return 718, -207
+	}
+	scale := 1000.0 / float64(ttf.UnitsPerEm)
+	return float64(ttf.Ascender) * scale, float64(ttf.Descender) * scale
+}
@@ -0,0 +1,269 @@
+package creator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage_AddTextWithOptions_VAlign(t *testing.T) {
+	// Helvetica: ascender 718, descender -207.
+	tests := []struct {
+		name     string
+		align    VerticalAlign
+		baseline float64
+	}{
+		{"baseline", VAlignBaseline, 700},
+		{"top", VAlignTop, 700 - 7.18},
+		{"middle", VAlignMiddle, 700 - (7.18-2.07)/2},
+		{"bottom", VAlignBottom, 700 + 2.07},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			require.NoError(t, err)
+
+			err = page.AddTextWithOptions("Cell", 100, 700, Helvetica, 10, &TextOptions{VAlign: tt.align, Color: Red})
+			require.NoError(t, err)
+
+			ops := page.TextOperations()
+			require.Len(t, ops, 1)
+			assert.InDelta(t, tt.baseline, ops[0].Y, 1e-9)
+			assert.Equal(t, 100.0, ops[0].X)
+			assert.Equal(t, Red, ops[0].Color)
+		})
+	}
+}
+
+func TestPage_AddTextWithOptions_NilOptions(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	require.NoError(t, page.AddTextWithOptions("Hello", 50, 600, TimesRoman, 12, nil))
+	ops := page.TextOperations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, 600.0, ops[0].Y)
+	assert.Equal(t, Black, ops[0].Color)
+
+	assert.Error(t, page.AddTextWithOptions("Hello", 50, 600, TimesRoman, 0, nil))
+	assert.Error(t, page.AddTextCustomFontWithOptions("Hello", 50, 600, nil, 12, nil))
+}
+
+func TestAlignBaseline(t *testing.T) {
+	assert.InDelta(t, 80.0, alignBaseline(100, VAlignTop, 800, -200, 25), 1e-9)
+	assert.InDelta(t, 105.0, alignBaseline(100, VAlignBottom, 800, -200, 25), 1e-9)
+	assert.InDelta(t, 92.5, alignBaseline(100, VAlignMiddle, 800, -200, 25), 1e-9)
+	assert.InDelta(t, 100.0, alignBaseline(100, VAlignBaseline, 800, -200, 25), 1e-9)
+}
+
+func TestPage_AddTextWithOptions_Align(t *testing.T) {
+	// Helvetica 12pt: "The quick" is 52.02pt wide.
+	tests := []struct {
+		name  string
+		align Alignment
+		x     float64
+	}{
+		{"left", AlignLeft, 300},
+		{"center", AlignCenter, 300 - 52.02/2},
+		{"right", AlignRight, 300 - 52.02},
+		{"justify single line", AlignJustify, 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			page, err := c.NewPage()
+			require.NoError(t, err)
+
+			require.NoError(t, page.AddTextWithOptions("The quick", 300, 700, Helvetica, 12, &TextOptions{Align: tt.align}))
+			ops := page.TextOperations()
+			require.Len(t, ops, 1)
+			assert.InDelta(t, tt.x, ops[0].X, 1e-9)
+			assert.Zero(t, ops[0].WordSpacing)
+		})
+	}
+}
+
+func TestPage_AddTextCustomFontWithOptions_Align(t *testing.T) {
+	font := loadTestFont(t)
+	width := font.MeasureString("Total", 12)
+	require.Positive(t, width)
+
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	require.NoError(t, page.AddTextCustomFontWithOptions("Total", 540, 400, font, 12, &TextOptions{Align: AlignRight}))
+	ops := page.TextOperations()
+	require.Len(t, ops, 1)
+	assert.InDelta(t, 540-width, ops[0].X, 1e-9)
+}
+
+func TestPage_AddTextWithOptions_Spacing(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	require.NoError(t, page.AddTextWithOptions("TITLE", 72, 700, HelveticaBold, 24, &TextOptions{CharSpacing: 2.5}))
+	require.NoError(t, page.AddTextWithOptions("fit to width", 72, 650, Helvetica, 12, &TextOptions{WordSpacing: 4, CharSpacing: -0.25}))
+	require.NoError(t, page.AddText("plain", 72, 600, Helvetica, 12))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+
+	assert.Equal(t, "BT\n0 g\n/F1 24 Tf\n72 700 Td\n2.50 Tc\n(TITLE) Tj\n0 Tc\nET\n"+
+		"BT\n0 g\n/F2 12 Tf\n72 650 Td\n-0.25 Tc\n4 Tw\n(fit to width) Tj\n0 Tc\n0 Tw\nET\n"+
+		"BT\n0 g\n/F2 12 Tf\n72 600 Td\n(plain) Tj\nET\n", string(content),
+		"spacing must be set before the show operator and reset after it")
+}
+
+func TestPage_AddTextWithOptions_SpacingAlign(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// "The quick" is 52.02pt in Helvetica 12pt; 9 characters and one space
+	// add 9×1 + 1×3 points.
+	opts := &TextOptions{Align: AlignRight, CharSpacing: 1, WordSpacing: 3}
+	require.NoError(t, page.AddTextWithOptions("The quick", 300, 700, Helvetica, 12, opts))
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 1)
+	assert.InDelta(t, 300-52.02-12, ops[0].X, 1e-9)
+	assert.Equal(t, 1.0, ops[0].CharSpacing)
+	assert.Equal(t, 3.0, ops[0].WordSpacing)
+}
+
+func TestPage_AddTextWithOptions_Rotation(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	require.NoError(t, page.AddTextWithOptions("Revenue", 40, 400, Helvetica, 10, &TextOptions{Rotation: 90}))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+
+	// cos 90° = 0, sin 90° = 1: the baseline runs up the page from (40, 400).
+	assert.Equal(t, "BT\n0 g\n/F1 10 Tf\n0 1 -1 0 40 400 Tm\n(Revenue) Tj\nET\n", string(content))
+}
+
+func TestPage_AddTextWithOptions_Rotation30(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	require.NoError(t, page.AddTextWithOptions("Label", 100, 200, Helvetica, 10, &TextOptions{Rotation: 30}))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+
+	// cos 30° = 0.866025, sin 30° = 0.5: written with enough precision
+	// that the end of a long label stays on its rotated baseline.
+	assert.Contains(t, string(content), "\n0.86603 0.5 -0.5 0.86603 100 200 Tm\n")
+}
+
+func TestPage_AddTextWithOptions_RotationAlign(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// "The quick" is 52.02pt in Helvetica 12pt. Rotated by 90°, centering
+	// moves the start down the rotated baseline and VAlignTop moves it
+	// right, away from the ascent.
+	opts := &TextOptions{Align: AlignCenter, VAlign: VAlignTop, Rotation: 90}
+	require.NoError(t, page.AddTextWithOptions("The quick", 300, 400, Helvetica, 12, opts))
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 1)
+	assert.InDelta(t, 300+718*12.0/1000, ops[0].X, 1e-9)
+	assert.InDelta(t, 400-52.02/2, ops[0].Y, 1e-9)
+	assert.InDeltaSlice(t, []float64{0, 1, -1, 0}, ops[0].Matrix[:], 1e-12)
+}
+
+func TestPage_AddTextWithOptions_TextMatrix(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Horizontally mirrored text, offset 10pt to the right; TextMatrix
+	// takes precedence over Rotation.
+	opts := &TextOptions{TextMatrix: [6]float64{-1, 0, 0, 1, 10, 0}, Rotation: 45}
+	require.NoError(t, page.AddTextWithOptions("Mirror", 100, 500, Helvetica, 12, opts))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\n-1 0 0 1 110 500 Tm\n")
+	assert.NotContains(t, string(content), " Td\n")
+}
+
+func TestPage_AddTextWithOptions_Rise(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	require.NoError(t, page.AddTextWithOptions("1", 100, 700, Helvetica, 7, &TextOptions{Rise: 4}))
+	require.NoError(t, page.AddTextWithOptions("plain", 110, 700, Helvetica, 12, &TextOptions{}))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+
+	assert.Equal(t, "BT\n0 g\n/F1 7 Tf\n100 700 Td\n4 Ts\n(1) Tj\n0 Ts\nET\n"+
+		"BT\n0 g\n/F1 12 Tf\n110 700 Td\n(plain) Tj\nET\n", string(content),
+		"rise must be set before the show operator and reset after it; zero rise emits nothing")
+}
+
+func TestPage_AddTextWithOptions_Underline(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// "Hello" is 27.336pt wide in Helvetica 12pt. The underline sits 1.2pt
+	// below the baseline, 0.6pt thick, in the text color.
+	require.NoError(t, page.AddTextWithOptions("Hello", 100, 700, Helvetica, 12, &TextOptions{Underline: true, Color: Red}))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+
+	assert.Equal(t, "BT\n1 0 0 rg\n/F1 12 Tf\n100 700 Td\n(Hello) Tj\nET\n"+
+		"q\n1 0 0 RG\n0.60 w\n100 698.80 m\n127.34 698.80 l\nS\nQ\n", string(content),
+		"the line follows the text object")
+}
+
+func TestPage_AddTextWithOptions_Strikethrough(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Right-aligned with character spacing: the line spans the spaced
+	// text, 3.12pt above the baseline.
+	opts := &TextOptions{Strikethrough: true, Align: AlignRight, CharSpacing: 1}
+	require.NoError(t, page.AddTextWithOptions("Hello", 200, 700, Helvetica, 12, opts))
+	require.NoError(t, page.AddTextWithOptions("plain", 100, 650, Helvetica, 12, nil))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+
+	got := string(content)
+	assert.Contains(t, got, "(Hello) Tj\n0 Tc\nET\nq\n0 G\n0.60 w\n167.66 703.12 m\n200 703.12 l\nS\nQ\n")
+	assert.Equal(t, 1, strings.Count(got, " m\n"), "undecorated text draws no lines:\n%s", got)
+}
+
+func TestPage_AddTextWithOptions_UnderlineRotated(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Rotated 90°, the underline runs up the page, 1.2pt to the right of x.
+	require.NoError(t, page.AddTextWithOptions("Hello", 100, 700, Helvetica, 12, &TextOptions{Underline: true, Rotation: 90}))
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(page.TextOperations()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\n101.20 700 m\n101.20 727.34 l\nS\n")
+}
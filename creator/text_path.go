@@ -0,0 +1,163 @@
+package creator
+
+import (
+	"errors"
+	"math"
+	"unicode"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// TextPathOptions configures AddTextOnPath and AddTextOnCircle.
+//
+// Example:
+//
+//	opts := &creator.TextPathOptions{
+//	    Color:  creator.Navy,
+//	    Offset: 10, // Start 10pt along the path
+//	}
+type TextPathOptions struct {
+	// Color is the text color (RGB, 0.0 to 1.0 range, default black).
+	Color Color
+
+	// CustomFont is an embedded TrueType/OpenType font (optional).
+	// When set, this takes precedence over the font argument.
+	CustomFont *CustomFont
+
+	// Offset is the distance along the path, in points, at which the
+	// first glyph starts (default: 0).
+	Offset float64
+}
+
+// pathFunc returns the point at distance d along a path and the direction
+// of the path there, in radians counter-clockwise from the x axis.
+type pathFunc func(d float64) (x, y, angle float64)
+
+// AddTextOnPath draws text along a polyline, one glyph at a time.
+//
+// Each glyph's baseline follows the path, rotated to the path direction
+// at the glyph's center. The glyph tops point to the left of the path
+// direction, so a path drawn left-to-right reads upright. Glyphs are
+// advanced by their font widths; text longer than the path continues
+// along the direction of the last segment.
+//
+// Parameters:
+//   - text: The string to display
+//   - path: The polyline to follow (at least 2 points)
+//   - font: Font to use (one of the Standard 14 fonts, ignored if opts.CustomFont is set)
+//   - size: Font size in points
+//   - opts: Color, custom font and start offset (nil for defaults)
+//
+// Example:
+//
+//	wave := []creator.Point{{X: 100, Y: 500}, {X: 200, Y: 560}, {X: 300, Y: 500}}
+//	err := page.AddTextOnPath("Rise and fall", wave, creator.Helvetica, 14, nil)
+func (p *Page) AddTextOnPath(text string, path []Point, font FontName, size float64, opts *TextPathOptions) error {
+	at, err := polylinePath(path)
+	if err != nil {
+		return err
+	}
+	return p.addTextAlong(text, at, font, size, opts)
+}
+
+// AddTextOnCircle draws text clockwise around a circle.
+//
+// The text starts at startAngle (degrees, counter-clockwise from the
+// positive x axis) with its baseline on the circle and glyph tops pointing
+// outward, as on a seal or badge. For example, a startAngle of 180 starts
+// at the left of the circle and runs over the top.
+//
+// Example:
+//
+//	err := page.AddTextOnCircle("OFFICIAL SEAL", 300, 400, 80, 150,
+//	    creator.HelveticaBold, 16, &creator.TextPathOptions{Color: creator.Red})
+func (p *Page) AddTextOnCircle(text string, cx, cy, radius, startAngle float64, font FontName, size float64, opts *TextPathOptions) error {
+	if radius <= 0 {
+		return errors.New("radius must be positive")
+	}
+	start := startAngle * math.Pi / 180
+	at := func(d float64) (x, y, angle float64) {
+		theta := start - d/radius
+		return cx + radius*math.Cos(theta), cy + radius*math.Sin(theta), theta - math.Pi/2
+	}
+	return p.addTextAlong(text, at, font, size, opts)
+}
+
+// addTextAlong adds one rotated text operation per glyph along a path.
+func (p *Page) addTextAlong(text string, at pathFunc, font FontName, size float64, opts *TextPathOptions) error {
+	if opts == nil {
+		opts = &TextPathOptions{}
+	}
+	if size <= 0 {
+		return errors.New("font size must be positive")
+	}
+	c := opts.Color
+	if c.R < 0 || c.R > 1 || c.G < 0 || c.G > 1 || c.B < 0 || c.B > 1 {
+		return errors.New("color components must be in range [0.0, 1.0]")
+	}
+	text = p.normalize(text)
+	if opts.CustomFont != nil {
+		opts.CustomFont.UseString(text)
+	}
+
+	d := opts.Offset
+	for _, r := range text {
+		glyph := string(r)
+		var width float64
+		if opts.CustomFont != nil {
+			width = opts.CustomFont.MeasureString(glyph, size)
+		} else {
+			width = fonts.MeasureString(string(font), glyph, size)
+		}
+
+		if !unicode.IsSpace(r) {
+			// Rotate about the glyph center so it sits on curves evenly.
+			mx, my, angle := at(d + width/2)
+			p.textOps = append(p.textOps, TextOperation{
+				Text:       glyph,
+				X:          mx - width/2*math.Cos(angle),
+				Y:          my - width/2*math.Sin(angle),
+				Font:       font,
+				CustomFont: opts.CustomFont,
+				Size:       size,
+				Color:      opts.Color,
+				Rotation:   angle * 180 / math.Pi,
+			})
+		}
+
+		d += width
+	}
+
+	return nil
+}
+
+// polylinePath returns a pathFunc walking along the given points.
+func polylinePath(path []Point) (pathFunc, error) {
+	if len(path) < 2 {
+		return nil, errors.New("text path must have at least 2 points")
+	}
+
+	// Cumulative length at the start of each segment.
+	lengths := make([]float64, len(path))
+	for i := 1; i < len(path); i++ {
+		lengths[i] = lengths[i-1] + math.Hypot(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+	}
+	if lengths[len(path)-1] == 0 {
+		return nil, errors.New("text path must have non-zero length")
+	}
+
+	return func(d float64) (x, y, angle float64) {
+		// Find the segment containing d; zero-length segments are skipped.
+		i := 1
+		for i < len(path)-1 && (d > lengths[i] || lengths[i] == lengths[i-1]) {
+			i++
+		}
+		for lengths[i] == lengths[i-1] {
+			i--
+		}
+		a, b := path[i-1], path[i]
+		segLen := lengths[i] - lengths[i-1]
+		t := (d - lengths[i-1]) / segLen
+		return a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y), math.Atan2(b.Y-a.Y, b.X-a.X)
+	}, nil
+}
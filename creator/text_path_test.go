@@ -0,0 +1,93 @@
+package creator
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+	"github.com/coregx/gxpdf/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage_AddTextOnPath_Straight(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	path := []Point{{X: 100, Y: 100}, {X: 300, Y: 100}}
+	require.NoError(t, page.AddTextOnPath("A B", path, Helvetica, 12, nil))
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 2, "spaces advance but emit nothing")
+
+	assert.Equal(t, "A", ops[0].Text)
+	assert.InDelta(t, 100, ops[0].X, 1e-9)
+	assert.InDelta(t, 100, ops[0].Y, 1e-9)
+	assert.InDelta(t, 0, ops[0].Rotation, 1e-9)
+
+	advance := fonts.MeasureString("Helvetica", "A ", 12)
+	assert.Equal(t, "B", ops[1].Text)
+	assert.InDelta(t, 100+advance, ops[1].X, 1e-9)
+}
+
+func TestPage_AddTextOnPath_FollowsSegments(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	// Up 45 degrees, then down 45 degrees.
+	path := []Point{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 1000, Y: -980}}
+	require.NoError(t, page.AddTextOnPath("VVVV", path, Helvetica, 12, &TextPathOptions{Color: Red}))
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 4)
+	assert.InDelta(t, 45, ops[0].Rotation, 1e-9)
+	assert.InDelta(t, -45, ops[3].Rotation, 1e-9)
+	assert.Equal(t, Red, ops[3].Color)
+}
+
+func TestPage_AddTextOnCircle(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	const cx, cy, r = 300.0, 400.0, 80.0
+	require.NoError(t, page.AddTextOnCircle("SEAL", cx, cy, r, 90, HelveticaBold, 16, nil))
+
+	ops := page.TextOperations()
+	require.Len(t, ops, 4)
+
+	// The first glyph is centered just clockwise of the top, rotated slightly clockwise.
+	assert.Less(t, ops[0].Rotation, 0.0)
+	assert.Greater(t, ops[0].Rotation, -90.0)
+	for i := 1; i < len(ops); i++ {
+		assert.Less(t, ops[i].Rotation, ops[i-1].Rotation, "glyphs turn clockwise")
+	}
+	for _, op := range ops {
+		// Glyph origins lie on the tangent line, just outside the circle.
+		dist := math.Hypot(op.X-cx, op.Y-cy)
+		assert.GreaterOrEqual(t, dist, r-1e-9)
+		assert.Less(t, dist, r+2)
+	}
+
+	content, _, err := writer.GenerateContentStream(convertTextOps(ops))
+	require.NoError(t, err)
+	assert.Equal(t, 4, strings.Count(string(content), " Tm\n"), "each glyph gets a text matrix")
+	assert.NotContains(t, string(content), " Td\n")
+}
+
+func TestPage_AddTextOnPath_Errors(t *testing.T) {
+	c := New()
+	page, err := c.NewPage()
+	require.NoError(t, err)
+
+	line := []Point{{X: 0, Y: 0}, {X: 100, Y: 0}}
+	assert.Error(t, page.AddTextOnPath("x", []Point{{X: 1, Y: 1}}, Helvetica, 12, nil))
+	assert.Error(t, page.AddTextOnPath("x", []Point{{X: 1, Y: 1}, {X: 1, Y: 1}}, Helvetica, 12, nil))
+	assert.Error(t, page.AddTextOnPath("x", line, Helvetica, 0, nil))
+	assert.Error(t, page.AddTextOnPath("x", line, Helvetica, 12, &TextPathOptions{Color: Color{B: 2}}))
+	assert.Error(t, page.AddTextOnCircle("x", 0, 0, 0, 0, Helvetica, 12, nil))
+	assert.Empty(t, page.TextOperations())
+}
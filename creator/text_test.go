@@ -2,6 +2,7 @@ package creator
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -38,10 +39,10 @@ func TestCreator_HelloWorld(t *testing.T) {
 	// Check EOF marker
 	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(data), []byte("%%EOF")), "Should end with EOF marker")
 
-	// With compression, text is not visible in raw bytes.
-	// Check that content stream exists and is compressed.
+	// The content stream is too short to benefit from compression, so it is
+	// stored as-is and the text is visible in raw bytes.
 	assert.Contains(t, string(data), "/Contents", "Should have content stream")
-	assert.Contains(t, string(data), "/Filter /FlateDecode", "Content should be compressed")
+	assert.Contains(t, string(data), "(Hello World!) Tj", "Content should show the text")
 
 	// Check for font reference
 	assert.Contains(t, string(data), "/Font", "Should contain font resources")
@@ -117,19 +118,19 @@ func TestCreator_MultiplePagesWithText(t *testing.T) {
 	// Page 1
 	page1, err := c.NewPage()
 	require.NoError(t, err)
-	err = page1.AddText("Page 1", 100, 700, Helvetica, 24)
+	err = page1.AddText("Page 1 of 3", 100, 700, Helvetica, 24)
 	require.NoError(t, err)
 
 	// Page 2
 	page2, err := c.NewPage()
 	require.NoError(t, err)
-	err = page2.AddText("Page 2", 100, 700, Helvetica, 24)
+	err = page2.AddText("Page 2 of 3", 100, 700, Helvetica, 24)
 	require.NoError(t, err)
 
 	// Page 3
 	page3, err := c.NewPage()
 	require.NoError(t, err)
-	err = page3.AddText("Page 3", 100, 700, Helvetica, 24)
+	err = page3.AddText("Page 3 of 3", 100, 700, Helvetica, 24)
 	require.NoError(t, err)
 
 	// Write to file
@@ -141,11 +142,13 @@ func TestCreator_MultiplePagesWithText(t *testing.T) {
 	data, err := os.ReadFile(tmpFile)
 	require.NoError(t, err)
 
-	// With compression, text content is not visible in raw bytes.
-	// Check PDF structure instead.
+	// Check PDF structure. The content streams are too short to benefit
+	// from compression, so each page's text is visible in raw bytes.
 	assert.Contains(t, string(data), "/Count 3", "Should have 3 pages")
 	assert.Contains(t, string(data), "/Type /Page", "Should have page objects")
-	assert.Contains(t, string(data), "/Filter /FlateDecode", "Content should be compressed")
+	for i := 1; i <= 3; i++ {
+		assert.Contains(t, string(data), fmt.Sprintf("(Page %d of 3) Tj", i), "Page %d should show its text", i)
+	}
 }
 
 func TestPage_AddText_Validation(t *testing.T) {
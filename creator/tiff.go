@@ -0,0 +1,704 @@
+package creator
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/image/ccitt"
+	"golang.org/x/image/tiff/lzw"
+)
+
+// TIFF tags used by the loader.
+//
+// Reference: TIFF 6.0 Specification, Section 8 (Baseline Field Reference Guide).
+const (
+	tiffTagImageWidth      = 256
+	tiffTagImageLength     = 257
+	tiffTagBitsPerSample   = 258
+	tiffTagCompression     = 259
+	tiffTagPhotometric     = 262
+	tiffTagFillOrder       = 266
+	tiffTagStripOffsets    = 273
+	tiffTagSamplesPerPixel = 277
+	tiffTagRowsPerStrip    = 278
+	tiffTagStripByteCounts = 279
+	tiffTagXResolution     = 282
+	tiffTagYResolution     = 283
+	tiffTagPlanarConfig    = 284
+	tiffTagResolutionUnit  = 296
+	tiffTagPredictor       = 317
+	tiffTagTileWidth       = 322
+	tiffTagColorMap        = 320
+	tiffTagExtraSamples    = 338
+)
+
+// TIFF compression schemes.
+const (
+	tiffCompressionNone       = 1
+	tiffCompressionCCITTG4    = 4
+	tiffCompressionLZW        = 5
+	tiffCompressionDeflate    = 8
+	tiffCompressionDeflateOld = 32946
+	tiffCompressionPackBits   = 32773
+)
+
+// TIFF photometric interpretations.
+const (
+	tiffPhotometricWhiteIsZero = 0
+	tiffPhotometricBlackIsZero = 1
+	tiffPhotometricRGB         = 2
+	tiffPhotometricPalette     = 3
+	tiffPhotometricCMYK        = 5
+)
+
+// maxTIFFPages caps the number of IFDs followed, guarding against cycles
+// in malformed files.
+const maxTIFFPages = 10000
+
+// ErrUnsupportedTIFF is returned for TIFF features the loader does not
+// handle (tiled images, planar data, 16-bit samples, other compressions).
+var ErrUnsupportedTIFF = errors.New("unsupported TIFF feature")
+
+// tiffPage holds the fields of one TIFF image file directory (IFD).
+type tiffPage struct {
+	width, height   int
+	bitsPerSample   int
+	samplesPerPixel int
+	compression     int
+	photometric     int
+	fillOrder       int
+	rowsPerStrip    int
+	planarConfig    int
+	predictor       int
+	tiled           bool
+	stripOffsets    []int64
+	stripByteCounts []int64
+	colorMap        []uint16
+	extraSamples    int
+	xResolution     float64
+	yResolution     float64
+	resolutionUnit  int
+}
+
+// LoadTIFF loads every page of a TIFF file as a separate image.
+//
+// Scanned documents often arrive as multi-page TIFFs; each page (IFD)
+// becomes one image. Supported:
+//   - Compression: none, LZW, Deflate, PackBits, CCITT Group 4
+//   - Bilevel, grayscale (8-bit), RGB, RGBA, CMYK and palette images
+//
+// Single-strip Group 4 fax pages are embedded as-is with the PDF
+// CCITTFaxDecode filter, avoiding recompression. Other bilevel pages
+// (uncompressed, PackBits, LZW, Deflate or multi-strip Group 4) are
+// re-encoded with Group 4, unless FlateDecode-compressed 1-bit samples
+// are smaller, as they can be for noisy images. All other pages are
+// decoded and stored as FlateDecode-compressed samples.
+//
+// LoadImage also accepts TIFF files and returns the first page.
+//
+// Example:
+//
+//	pages, err := creator.LoadTIFF("scan.tif")
+//	if err != nil {
+//	    return err
+//	}
+//	for _, img := range pages {
+//	    page, _ := c.NewPage()
+//	    page.DrawImageFit(img, 0, 0, page.Width(), page.Height())
+//	}
+func LoadTIFF(path string) ([]*Image, error) {
+	//nolint:gosec // File path is provided by user, G304 false positive.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image file: %w", err)
+	}
+	return loadTIFF(data)
+}
+
+// LoadTIFFFromReader loads every page of a TIFF image from an io.Reader.
+//
+// See LoadTIFF for the supported features.
+func LoadTIFFFromReader(r io.Reader) ([]*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image data: %w", err)
+	}
+	return loadTIFF(data)
+}
+
+// loadTIFF decodes all pages of a TIFF file.
+func loadTIFF(data []byte) ([]*Image, error) {
+	pages, err := parseTIFF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]*Image, len(pages))
+	for i, page := range pages {
+		img, err := page.toImage(data)
+		if err != nil {
+			return nil, fmt.Errorf("TIFF page %d: %w", i, err)
+		}
+		images[i] = img
+	}
+	return images, nil
+}
+
+// loadTIFFFirstPage decodes the first page of a TIFF file.
+func loadTIFFFirstPage(data []byte) (*Image, error) {
+	pages, err := parseTIFF(data)
+	if err != nil {
+		return nil, err
+	}
+	return pages[0].toImage(data)
+}
+
+// parseTIFF reads the header and every IFD of a TIFF file.
+func parseTIFF(data []byte) ([]*tiffPage, error) {
+	if len(data) < 8 {
+		return nil, errors.New("failed to decode TIFF: file too short")
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("failed to decode TIFF: bad byte order mark")
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return nil, fmt.Errorf("%w: BigTIFF or bad magic number", ErrUnsupportedTIFF)
+	}
+
+	var pages []*tiffPage
+	seen := make(map[uint32]bool)
+	for offset := order.Uint32(data[4:8]); offset != 0; {
+		if seen[offset] || len(pages) >= maxTIFFPages {
+			return nil, errors.New("failed to decode TIFF: IFD loop")
+		}
+		seen[offset] = true
+
+		page, next, err := parseTIFFIFD(data, order, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode TIFF page %d: %w", len(pages), err)
+		}
+		pages = append(pages, page)
+		offset = next
+	}
+
+	if len(pages) == 0 {
+		return nil, errors.New("failed to decode TIFF: no pages")
+	}
+	return pages, nil
+}
+
+// parseTIFFIFD reads one IFD and returns it with the offset of the next.
+func parseTIFFIFD(data []byte, order binary.ByteOrder, offset uint32) (*tiffPage, uint32, error) {
+	if uint64(offset)+2 > uint64(len(data)) {
+		return nil, 0, errors.New("IFD offset out of range")
+	}
+	count := int(order.Uint16(data[offset:]))
+	end := uint64(offset) + 2 + uint64(count)*12
+	if end+4 > uint64(len(data)) {
+		return nil, 0, errors.New("IFD truncated")
+	}
+
+	page := &tiffPage{
+		bitsPerSample:   1,
+		samplesPerPixel: 1,
+		compression:     tiffCompressionNone,
+		photometric:     tiffPhotometricWhiteIsZero,
+		fillOrder:       1,
+		planarConfig:    1,
+		predictor:       1,
+		resolutionUnit:  tiffResolutionUnitInch,
+	}
+	page.rowsPerStrip = -1 // Default: the whole image is one strip
+
+	for i := 0; i < count; i++ {
+		entry := data[uint64(offset)+2+uint64(i)*12:]
+		tag := order.Uint16(entry[0:2])
+		switch tag {
+		case tiffTagXResolution:
+			page.xResolution = tiffRational(data, order, entry)
+			continue
+		case tiffTagYResolution:
+			page.yResolution = tiffRational(data, order, entry)
+			continue
+		}
+		values, err := tiffValues(data, order, entry)
+		if err != nil {
+			return nil, 0, fmt.Errorf("tag %d: %w", tag, err)
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		switch tag {
+		case tiffTagImageWidth:
+			page.width = int(values[0])
+		case tiffTagImageLength:
+			page.height = int(values[0])
+		case tiffTagBitsPerSample:
+			page.bitsPerSample = int(values[0])
+			for _, v := range values {
+				if int(v) != page.bitsPerSample {
+					return nil, 0, fmt.Errorf("%w: mixed bits per sample", ErrUnsupportedTIFF)
+				}
+			}
+		case tiffTagCompression:
+			page.compression = int(values[0])
+		case tiffTagPhotometric:
+			page.photometric = int(values[0])
+		case tiffTagFillOrder:
+			page.fillOrder = int(values[0])
+		case tiffTagStripOffsets:
+			page.stripOffsets = values
+		case tiffTagSamplesPerPixel:
+			page.samplesPerPixel = int(values[0])
+		case tiffTagRowsPerStrip:
+			page.rowsPerStrip = int(values[0])
+		case tiffTagStripByteCounts:
+			page.stripByteCounts = values
+		case tiffTagPlanarConfig:
+			page.planarConfig = int(values[0])
+		case tiffTagPredictor:
+			page.predictor = int(values[0])
+		case tiffTagTileWidth:
+			page.tiled = true
+		case tiffTagColorMap:
+			page.colorMap = make([]uint16, len(values))
+			for j, v := range values {
+				page.colorMap[j] = uint16(v)
+			}
+		case tiffTagExtraSamples:
+			page.extraSamples = len(values)
+		case tiffTagResolutionUnit:
+			page.resolutionUnit = int(values[0])
+		}
+	}
+
+	if page.width <= 0 || page.height <= 0 {
+		return nil, 0, errors.New("missing or invalid image dimensions")
+	}
+	if page.rowsPerStrip <= 0 || page.rowsPerStrip > page.height {
+		page.rowsPerStrip = page.height
+	}
+
+	return page, order.Uint32(data[end:]), nil
+}
+
+// tiffValues returns the values of an IFD entry as integers.
+//
+// Only the integer types used by the tags above are decoded; entries of
+// other types yield no values and are ignored.
+func tiffValues(data []byte, order binary.ByteOrder, entry []byte) ([]int64, error) {
+	typ := order.Uint16(entry[2:4])
+	count := uint64(order.Uint32(entry[4:8]))
+
+	var size uint64
+	switch typ {
+	case 1: // BYTE
+		size = 1
+	case 3: // SHORT
+		size = 2
+	case 4: // LONG
+		size = 4
+	default:
+		return nil, nil
+	}
+
+	raw := entry[8:12]
+	if count*size > 4 {
+		start := uint64(order.Uint32(entry[8:12]))
+		if start+count*size > uint64(len(data)) {
+			return nil, errors.New("value offset out of range")
+		}
+		raw = data[start : start+count*size]
+	}
+
+	values := make([]int64, count)
+	for i := range values {
+		switch size {
+		case 1:
+			values[i] = int64(raw[i])
+		case 2:
+			values[i] = int64(order.Uint16(raw[i*2:]))
+		case 4:
+			values[i] = int64(order.Uint32(raw[i*4:]))
+		}
+	}
+	return values, nil
+}
+
+// tiffRational returns the first value of a RATIONAL IFD entry, or 0.
+func tiffRational(data []byte, order binary.ByteOrder, entry []byte) float64 {
+	if order.Uint16(entry[2:4]) != 5 || order.Uint32(entry[4:8]) == 0 { // RATIONAL
+		return 0
+	}
+	start := uint64(order.Uint32(entry[8:12]))
+	if start+8 > uint64(len(data)) {
+		return 0
+	}
+	num, den := order.Uint32(data[start:]), order.Uint32(data[start+4:])
+	if den == 0 {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
+
+// strips returns the raw, still compressed bytes of each strip.
+func (p *tiffPage) strips(data []byte) ([][]byte, error) {
+	if len(p.stripOffsets) == 0 || len(p.stripOffsets) != len(p.stripByteCounts) {
+		return nil, errors.New("missing or inconsistent strip offsets")
+	}
+	strips := make([][]byte, len(p.stripOffsets))
+	for i, off := range p.stripOffsets {
+		n := p.stripByteCounts[i]
+		if off < 0 || n < 0 || off+n > int64(len(data)) {
+			return nil, fmt.Errorf("strip %d out of range", i)
+		}
+		strip := data[off : off+n]
+		if p.fillOrder == 2 {
+			strip = reverseBits(strip)
+		}
+		strips[i] = strip
+	}
+	return strips, nil
+}
+
+// toImage converts a TIFF page to an embeddable image.
+func (p *tiffPage) toImage(data []byte) (*Image, error) {
+	if p.tiled {
+		return nil, fmt.Errorf("%w: tiled images", ErrUnsupportedTIFF)
+	}
+	if p.planarConfig != 1 {
+		return nil, fmt.Errorf("%w: planar configuration %d", ErrUnsupportedTIFF, p.planarConfig)
+	}
+
+	strips, err := p.strips(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var img *Image
+	if p.compression == tiffCompressionCCITTG4 {
+		img, err = p.ccittImage(strips)
+	} else {
+		var samples []byte
+		if samples, err = p.decodeStrips(strips); err == nil {
+			img, err = p.samplesImage(samples)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	img.dpiX, img.dpiY = resolutionToDPI(p.xResolution, p.yResolution,
+		p.resolutionUnit == tiffResolutionUnitInch, p.resolutionUnit == tiffResolutionUnitCentimeter)
+	return img, nil
+}
+
+// ccittImage embeds a Group 4 fax page.
+//
+// A single strip is passed through to CCITTFaxDecode unchanged. Group 4
+// strips are coded independently, so multi-strip pages are decoded and
+// re-encoded as one (see bilevelImage).
+func (p *tiffPage) ccittImage(strips [][]byte) (*Image, error) {
+	if p.bitsPerSample != 1 || p.samplesPerPixel != 1 {
+		return nil, errors.New("CCITT Group 4 requires a 1-bit single-sample image")
+	}
+
+	// CCITT codes white and black runs directly; the decoders output 1 for
+	// white. BlackIsZero pages store their pixels inverted.
+	decode := ""
+	if p.photometric == tiffPhotometricBlackIsZero {
+		decode = "[1 0]"
+	}
+
+	img := &Image{
+		format:           "tiff",
+		width:            p.width,
+		height:           p.height,
+		colorSpace:       ColorSpaceGray,
+		components:       1,
+		bitsPerComponent: 1,
+		decode:           decode,
+	}
+
+	if len(strips) == 1 {
+		img.data = strips[0]
+		img.filter = "CCITTFaxDecode"
+		img.decodeParms = fmt.Sprintf("<< /K -1 /Columns %d /Rows %d >>", p.width, p.height)
+		return img, nil
+	}
+
+	rowBytes := (p.width + 7) / 8
+	samples := make([]byte, 0, rowBytes*p.height)
+	for i, strip := range strips {
+		rows := min(p.rowsPerStrip, p.height-i*p.rowsPerStrip)
+		if rows <= 0 {
+			break
+		}
+		r := ccitt.NewReader(bytes.NewReader(strip), ccitt.MSB, ccitt.Group4, p.width, rows, nil)
+		decoded, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode CCITT strip %d: %w", i, err)
+		}
+		samples = append(samples, decoded...)
+	}
+	if len(samples) < rowBytes*p.height {
+		return nil, errors.New("CCITT data shorter than image")
+	}
+
+	// The decoder outputs 1 for coded white, which is black on
+	// BlackIsZero pages.
+	return p.bilevelImage(samples[:rowBytes*p.height], p.photometric == tiffPhotometricBlackIsZero)
+}
+
+// bilevelImage stores 1-bit samples with Group 4 compression, or as
+// FlateDecode-compressed samples if those are smaller. blackIsOne tells
+// whether 1 bits are black.
+func (p *tiffPage) bilevelImage(samples []byte, blackIsOne bool) (*Image, error) {
+	img := &Image{
+		format:           "tiff",
+		width:            p.width,
+		height:           p.height,
+		colorSpace:       ColorSpaceGray,
+		components:       1,
+		bitsPerComponent: 1,
+	}
+	if err := img.setSamples(samples); err != nil {
+		return nil, err
+	}
+
+	if g4 := encodeG4(samples, p.width, p.height, blackIsOne); len(g4) < len(img.data) {
+		img.data, img.dataRaw = g4, false
+		img.filter = "CCITTFaxDecode"
+		img.decodeParms = fmt.Sprintf("<< /K -1 /Columns %d /Rows %d >>", p.width, p.height)
+		return img, nil
+	}
+	if blackIsOne {
+		img.decode = "[1 0]"
+	}
+	return img, nil
+}
+
+// decodeStrips decompresses and concatenates the strips of a page.
+func (p *tiffPage) decodeStrips(strips [][]byte) ([]byte, error) {
+	var samples []byte
+	for i, strip := range strips {
+		var r io.Reader
+		switch p.compression {
+		case tiffCompressionNone:
+			samples = append(samples, strip...)
+			continue
+		case tiffCompressionLZW:
+			r = lzw.NewReader(bytes.NewReader(strip), lzw.MSB, 8)
+		case tiffCompressionPackBits:
+			decoded, err := unpackBits(strip)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode strip %d: %w", i, err)
+			}
+			samples = append(samples, decoded...)
+			continue
+		case tiffCompressionDeflate, tiffCompressionDeflateOld:
+			zr, err := zlib.NewReader(bytes.NewReader(strip))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode strip %d: %w", i, err)
+			}
+			r = zr
+		default:
+			return nil, fmt.Errorf("%w: compression %d", ErrUnsupportedTIFF, p.compression)
+		}
+
+		decoded, err := io.ReadAll(r)
+		if err != nil && len(decoded) == 0 {
+			return nil, fmt.Errorf("failed to decode strip %d: %w", i, err)
+		}
+		samples = append(samples, decoded...)
+	}
+	return samples, nil
+}
+
+// unpackBits decodes PackBits run-length data: each header byte n is
+// followed by n+1 literal bytes (0 <= n <= 127), or by one byte repeated
+// 1-n times (-127 <= n <= -1); -128 is skipped.
+//
+// Reference: TIFF 6.0 Specification, Section 9 (PackBits Compression).
+func unpackBits(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data)*2)
+	for i := 0; i < len(data); {
+		n := int(int8(data[i]))
+		i++
+		switch {
+		case n >= 0:
+			if i+n+1 > len(data) {
+				return nil, errors.New("PackBits literal run truncated")
+			}
+			out = append(out, data[i:i+n+1]...)
+			i += n + 1
+		case n != -128:
+			if i >= len(data) {
+				return nil, errors.New("PackBits repeat run truncated")
+			}
+			out = append(out, bytes.Repeat(data[i:i+1], 1-n)...)
+			i++
+		}
+	}
+	return out, nil
+}
+
+// samplesImage builds an image from decoded, uncompressed samples.
+func (p *tiffPage) samplesImage(samples []byte) (*Image, error) {
+	bps := p.bitsPerSample
+	spp := p.samplesPerPixel
+	rowBytes := (p.width*spp*bps + 7) / 8
+	if len(samples) < rowBytes*p.height {
+		return nil, errors.New("image data shorter than image")
+	}
+	samples = samples[:rowBytes*p.height]
+
+	if p.predictor == 2 {
+		if bps != 8 {
+			return nil, fmt.Errorf("%w: predictor with %d-bit samples", ErrUnsupportedTIFF, bps)
+		}
+		undoHorizontalPredictor(samples, rowBytes, spp)
+	}
+
+	isGray := p.photometric == tiffPhotometricWhiteIsZero || p.photometric == tiffPhotometricBlackIsZero
+	if isGray && bps == 1 && spp == 1 {
+		return p.bilevelImage(samples, p.photometric == tiffPhotometricWhiteIsZero)
+	}
+
+	img := &Image{format: "tiff", width: p.width, height: p.height, bitsPerComponent: bps}
+
+	switch p.photometric {
+	case tiffPhotometricWhiteIsZero, tiffPhotometricBlackIsZero:
+		if spp != 1+p.extraSamples || (bps != 1 && bps != 2 && bps != 4 && bps != 8) {
+			return nil, fmt.Errorf("%w: %d-bit grayscale with %d samples", ErrUnsupportedTIFF, bps, spp)
+		}
+		img.colorSpace, img.components = ColorSpaceGray, 1
+		if p.photometric == tiffPhotometricWhiteIsZero {
+			img.decode = "[1 0]"
+		}
+	case tiffPhotometricRGB:
+		if spp != 3+p.extraSamples || bps != 8 {
+			return nil, fmt.Errorf("%w: %d-bit RGB with %d samples", ErrUnsupportedTIFF, bps, spp)
+		}
+		img.colorSpace, img.components = ColorSpaceRGB, 3
+	case tiffPhotometricCMYK:
+		if spp != 4+p.extraSamples || bps != 8 {
+			return nil, fmt.Errorf("%w: %d-bit CMYK with %d samples", ErrUnsupportedTIFF, bps, spp)
+		}
+		img.colorSpace, img.components = ColorSpaceCMYK, 4
+	case tiffPhotometricPalette:
+		rgb, err := p.expandPalette(samples, rowBytes)
+		if err != nil {
+			return nil, err
+		}
+		img.colorSpace, img.components, img.bitsPerComponent = ColorSpaceRGB, 3, 8
+		return img, img.setSamples(rgb)
+	default:
+		return nil, fmt.Errorf("%w: photometric interpretation %d", ErrUnsupportedTIFF, p.photometric)
+	}
+
+	if p.extraSamples > 0 {
+		if bps != 8 {
+			return nil, fmt.Errorf("%w: extra samples with %d-bit data", ErrUnsupportedTIFF, bps)
+		}
+		color, alpha := splitAlpha(samples, img.components, spp)
+		if err := img.setSamples(color); err != nil {
+			return nil, err
+		}
+		mask, raw, err := compressIfSmaller(alpha)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress alpha data: %w", err)
+		}
+		img.alphaMask, img.alphaRaw = mask, raw
+		return img, nil
+	}
+
+	return img, img.setSamples(samples)
+}
+
+// expandPalette converts palette indices to 8-bit RGB samples.
+func (p *tiffPage) expandPalette(samples []byte, rowBytes int) ([]byte, error) {
+	bps := p.bitsPerSample
+	entries := 1 << bps
+	if p.samplesPerPixel != 1 || bps > 8 || len(p.colorMap) < 3*entries {
+		return nil, errors.New("invalid palette image")
+	}
+
+	rgb := make([]byte, 0, p.width*p.height*3)
+	for y := 0; y < p.height; y++ {
+		row := samples[y*rowBytes : (y+1)*rowBytes]
+		for x := 0; x < p.width; x++ {
+			bit := x * bps
+			index := int(row[bit/8]>>(8-bps-bit%8)) & (entries - 1)
+			// ColorMap holds all red, then all green, then all blue values (16-bit).
+			rgb = append(rgb,
+				byte(p.colorMap[index]>>8),
+				byte(p.colorMap[entries+index]>>8),
+				byte(p.colorMap[2*entries+index]>>8))
+		}
+	}
+	return rgb, nil
+}
+
+// setSamples stores uncompressed samples, FlateDecode-compressed where
+// that makes them smaller.
+func (img *Image) setSamples(samples []byte) error {
+	data, raw, err := compressIfSmaller(samples)
+	if err != nil {
+		return fmt.Errorf("failed to compress image data: %w", err)
+	}
+	img.data, img.dataRaw = data, raw
+	img.filter = ""
+	if !raw {
+		img.filter = "FlateDecode"
+	}
+	return nil
+}
+
+// undoHorizontalPredictor reverses TIFF predictor 2 on 8-bit samples.
+func undoHorizontalPredictor(samples []byte, rowBytes, spp int) {
+	for row := 0; row+rowBytes <= len(samples); row += rowBytes {
+		for i := row + spp; i < row+rowBytes; i++ {
+			samples[i] += samples[i-spp]
+		}
+	}
+}
+
+// splitAlpha separates interleaved 8-bit samples into color samples and
+// an alpha channel taken from the first extra sample.
+func splitAlpha(samples []byte, components, spp int) (colorData, alpha []byte) {
+	pixels := len(samples) / spp
+	colorData = make([]byte, 0, pixels*components)
+	alpha = make([]byte, 0, pixels)
+	for i := 0; i+spp <= len(samples); i += spp {
+		colorData = append(colorData, samples[i:i+components]...)
+		alpha = append(alpha, samples[i+components])
+	}
+	return colorData, alpha
+}
+
+// reverseBits returns a copy of data with the bit order of each byte
+// reversed (TIFF FillOrder 2).
+func reverseBits(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		b = b>>4 | b<<4
+		b = (b&0xCC)>>2 | (b&0x33)<<2
+		b = (b&0xAA)>>1 | (b&0x55)<<1
+		out[i] = b
+	}
+	return out
+}
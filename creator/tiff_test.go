@@ -0,0 +1,425 @@
+package creator
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/ccitt"
+)
+
+// testTIFFPage describes one page of a generated little-endian TIFF.
+type testTIFFPage struct {
+	width, height   int
+	bitsPerSample   int
+	samplesPerPixel int
+	compression     int
+	photometric     int
+	rowsPerStrip    int
+	strips          [][]byte
+	dpi             uint32 // Resolution in pixels per inch (0 = none)
+}
+
+// buildTIFF assembles a TIFF file with one IFD per page.
+func buildTIFF(t *testing.T, pages ...testTIFFPage) []byte {
+	t.Helper()
+
+	le := binary.LittleEndian
+	buf := []byte{'I', 'I', 42, 0, 0, 0, 0, 0}
+	nextPtr := 4 // Position of the offset to patch with the next IFD.
+
+	for _, p := range pages {
+		var offsets, counts []uint32
+		for _, strip := range p.strips {
+			offsets = append(offsets, uint32(len(buf)))
+			counts = append(counts, uint32(len(strip)))
+			buf = append(buf, strip...)
+		}
+
+		// Strip arrays with more than one entry live outside the IFD.
+		arrayOrValue := func(values []uint32) uint32 {
+			if len(values) == 1 {
+				return values[0]
+			}
+			pos := uint32(len(buf))
+			for _, v := range values {
+				buf = le.AppendUint32(buf, v)
+			}
+			return pos
+		}
+		offsetsValue := arrayOrValue(offsets)
+		countsValue := arrayOrValue(counts)
+
+		type entry struct {
+			tag, typ uint16
+			count    uint32
+			value    uint32
+		}
+		entries := []entry{
+			{tiffTagImageWidth, 4, 1, uint32(p.width)},
+			{tiffTagImageLength, 4, 1, uint32(p.height)},
+			{tiffTagBitsPerSample, 3, 1, uint32(p.bitsPerSample)},
+			{tiffTagCompression, 3, 1, uint32(p.compression)},
+			{tiffTagPhotometric, 3, 1, uint32(p.photometric)},
+			{tiffTagStripOffsets, 4, uint32(len(offsets)), offsetsValue},
+			{tiffTagSamplesPerPixel, 3, 1, uint32(p.samplesPerPixel)},
+			{tiffTagRowsPerStrip, 4, 1, uint32(p.rowsPerStrip)},
+			{tiffTagStripByteCounts, 4, uint32(len(counts)), countsValue},
+		}
+		if p.dpi > 0 {
+			resolution := uint32(len(buf))
+			buf = le.AppendUint32(buf, p.dpi)
+			buf = le.AppendUint32(buf, 1)
+			entries = append(entries,
+				entry{tiffTagXResolution, 5, 1, resolution},
+				entry{tiffTagYResolution, 5, 1, resolution},
+				entry{tiffTagResolutionUnit, 3, 1, tiffResolutionUnitInch})
+		}
+
+		if len(buf)%2 == 1 {
+			buf = append(buf, 0)
+		}
+		le.PutUint32(buf[nextPtr:], uint32(len(buf)))
+		buf = le.AppendUint16(buf, uint16(len(entries)))
+		for _, e := range entries {
+			buf = le.AppendUint16(buf, e.tag)
+			buf = le.AppendUint16(buf, e.typ)
+			buf = le.AppendUint32(buf, e.count)
+			buf = le.AppendUint32(buf, e.value)
+		}
+		nextPtr = len(buf)
+		buf = le.AppendUint32(buf, 0)
+	}
+
+	return buf
+}
+
+// grayPage returns an uncompressed 8-bit grayscale page filled with value.
+func grayPage(width, height int, value byte) testTIFFPage {
+	return testTIFFPage{
+		width: width, height: height,
+		bitsPerSample: 8, samplesPerPixel: 1,
+		compression: tiffCompressionNone, photometric: tiffPhotometricBlackIsZero,
+		rowsPerStrip: height,
+		strips:       [][]byte{bytes.Repeat([]byte{value}, width*height)},
+	}
+}
+
+// whiteG4Rows returns Group 4 data for rows of an all-white image: each
+// row is a single V0 code (bit 1) against the white reference line.
+func whiteG4Rows(rows int) []byte {
+	data := make([]byte, (rows+7)/8)
+	for i := 0; i < rows; i++ {
+		data[i/8] |= 0x80 >> (i % 8)
+	}
+	return data
+}
+
+// decodeG4 decodes Group 4 image data (white = 1).
+func decodeG4(t *testing.T, data []byte, width, height int) []byte {
+	t.Helper()
+
+	out, err := io.ReadAll(ccitt.NewReader(bytes.NewReader(data), ccitt.MSB, ccitt.Group4, width, height, nil))
+	if err != nil {
+		t.Fatalf("decode Group 4 data: %v", err)
+	}
+	return out
+}
+
+// decompressFlate inflates FlateDecode image data.
+func decompressFlate(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("zlib.NewReader failed: %v", err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("inflate failed: %v", err)
+	}
+	return out
+}
+
+// bytesToCodes returns the LZW literal code of each byte.
+func bytesToCodes(data []byte) []uint32 {
+	codes := make([]uint32, len(data))
+	for i, b := range data {
+		codes[i] = uint32(b)
+	}
+	return codes
+}
+
+func TestLoadTIFF_MultiPage(t *testing.T) {
+	data := buildTIFF(t, grayPage(4, 3, 0x20), grayPage(6, 2, 0xC0))
+	path := filepath.Join(t.TempDir(), "scan.tif")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := LoadTIFF(path)
+	if err != nil {
+		t.Fatalf("LoadTIFF failed: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	if pages[0].Width() != 4 || pages[0].Height() != 3 {
+		t.Errorf("page 0: expected 4x3, got %dx%d", pages[0].Width(), pages[0].Height())
+	}
+	if pages[1].Width() != 6 || pages[1].Height() != 2 {
+		t.Errorf("page 1: expected 6x2, got %dx%d", pages[1].Width(), pages[1].Height())
+	}
+	if pages[0].ColorSpace() != ColorSpaceGray || pages[0].Format() != "tiff" {
+		t.Errorf("page 0: unexpected color space %s / format %s", pages[0].ColorSpace(), pages[0].Format())
+	}
+
+	// LoadImage picks the first page.
+	img, err := LoadImage(path)
+	if err != nil {
+		t.Fatalf("LoadImage failed: %v", err)
+	}
+	if img.Width() != 4 || img.Height() != 3 {
+		t.Errorf("LoadImage: expected first page 4x3, got %dx%d", img.Width(), img.Height())
+	}
+}
+
+func TestLoadTIFF_Compressions(t *testing.T) {
+	pixels := []byte{
+		10, 20, 30, 40, 50, 60,
+		70, 80, 90, 100, 110, 120,
+	}
+
+	// Hand-coded LZW: ClearCode, one 9-bit literal per byte, EOI. The
+	// table stays far below 511 entries, so the code width never changes.
+	var lzwCodes []byte
+	var bits uint32
+	var nBits uint
+	for _, code := range append(append([]uint32{256}, bytesToCodes(pixels)...), 257) {
+		bits = bits<<9 | code
+		nBits += 9
+		for nBits >= 8 {
+			lzwCodes = append(lzwCodes, byte(bits>>(nBits-8)))
+			nBits -= 8
+		}
+	}
+	if nBits > 0 {
+		lzwCodes = append(lzwCodes, byte(bits<<(8-nBits)))
+	}
+
+	var zBuf bytes.Buffer
+	zw := zlib.NewWriter(&zBuf)
+	_, _ = zw.Write(pixels)
+	_ = zw.Close()
+
+	tests := []struct {
+		name        string
+		compression int
+		strips      [][]byte
+	}{
+		{"none (two strips)", tiffCompressionNone, [][]byte{pixels[:6], pixels[6:]}},
+		{"LZW", tiffCompressionLZW, [][]byte{lzwCodes}},
+		{"Deflate", tiffCompressionDeflate, [][]byte{zBuf.Bytes()}},
+		{"PackBits (two strips)", tiffCompressionPackBits, [][]byte{
+			{2, 10, 20, 30, 0x80, 2, 40, 50, 60}, // Literals, no-op, literals
+			{0, 70, 4, 80, 90, 100, 110, 120},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := 2 / len(tt.strips)
+			data := buildTIFF(t, testTIFFPage{
+				width: 2, height: 2,
+				bitsPerSample: 8, samplesPerPixel: 3,
+				compression: tt.compression, photometric: tiffPhotometricRGB,
+				rowsPerStrip: rows, strips: tt.strips,
+			})
+
+			pages, err := LoadTIFFFromReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("LoadTIFFFromReader failed: %v", err)
+			}
+			img := pages[0]
+			if img.ColorSpace() != ColorSpaceRGB || img.components != 3 {
+				t.Fatalf("expected RGB, got %s", img.ColorSpace())
+			}
+
+			samples := img.Data()
+			if !img.dataRaw {
+				samples = decompressFlate(t, samples)
+			}
+			if !bytes.Equal(samples, pixels) {
+				t.Errorf("samples = %v, want %v", samples, pixels)
+			}
+		})
+	}
+}
+
+func TestUnpackBits(t *testing.T) {
+	// Example from the TIFF 6.0 specification.
+	packed := []byte{0xFE, 0xAA, 0x02, 0x80, 0x00, 0x2A, 0xFD, 0xAA, 0x03, 0x80, 0x00, 0x2A, 0x22, 0xF7, 0xAA}
+	want := []byte{
+		0xAA, 0xAA, 0xAA, 0x80, 0x00, 0x2A, 0xAA, 0xAA, 0xAA, 0xAA, 0x80, 0x00,
+		0x2A, 0x22, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA,
+	}
+	got, err := unpackBits(packed)
+	if err != nil {
+		t.Fatalf("unpackBits failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("unpackBits = % X, want % X", got, want)
+	}
+
+	for _, bad := range [][]byte{{0x03, 1, 2}, {0xFE}} {
+		if _, err := unpackBits(bad); err == nil {
+			t.Errorf("unpackBits(% X): expected error", bad)
+		}
+	}
+}
+
+func TestLoadTIFF_CCITTPassthrough(t *testing.T) {
+	g4 := whiteG4Rows(16)
+	data := buildTIFF(t, testTIFFPage{
+		width: 32, height: 16,
+		bitsPerSample: 1, samplesPerPixel: 1,
+		compression: tiffCompressionCCITTG4, photometric: tiffPhotometricWhiteIsZero,
+		rowsPerStrip: 16, strips: [][]byte{g4},
+	})
+
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader failed: %v", err)
+	}
+	if img.Filter() != "CCITTFaxDecode" {
+		t.Errorf("expected CCITTFaxDecode filter, got %q", img.Filter())
+	}
+	if !bytes.Equal(img.Data(), g4) {
+		t.Error("expected Group 4 data to pass through unchanged")
+	}
+	if img.BitsPerComponent() != 1 {
+		t.Errorf("expected 1 bit per component, got %d", img.BitsPerComponent())
+	}
+
+	c := New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := page.DrawImage(img, 100, 100, 64, 32); err != nil {
+		t.Fatalf("DrawImage failed: %v", err)
+	}
+	pdf, err := c.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := "/Filter /CCITTFaxDecode /DecodeParms << /K -1 /Columns 32 /Rows 16 >>"
+	if !bytes.Contains(pdf, []byte(want)) {
+		t.Errorf("expected image dictionary to contain %q", want)
+	}
+}
+
+func TestLoadTIFF_CCITTMultiStrip(t *testing.T) {
+	data := buildTIFF(t, testTIFFPage{
+		width: 16, height: 8,
+		bitsPerSample: 1, samplesPerPixel: 1,
+		compression: tiffCompressionCCITTG4, photometric: tiffPhotometricWhiteIsZero,
+		rowsPerStrip: 4, strips: [][]byte{whiteG4Rows(4), whiteG4Rows(4)},
+	})
+
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader failed: %v", err)
+	}
+	if img.Filter() != "CCITTFaxDecode" || img.decodeParms != "<< /K -1 /Columns 16 /Rows 8 >>" {
+		t.Fatalf("expected multi-strip Group 4 page to be re-encoded as one, got %q %q", img.Filter(), img.decodeParms)
+	}
+
+	// Decoded rows are 2 bytes each, white = 1.
+	if samples := decodeG4(t, img.Data(), 16, 8); !bytes.Equal(samples, bytes.Repeat([]byte{0xFF}, 16)) {
+		t.Errorf("samples = %x, want all white", samples)
+	}
+}
+
+func TestLoadTIFF_Bilevel(t *testing.T) {
+	// A 64x32 page with a black bar in rows 8-15, columns 16-47
+	// (1 = black, WhiteIsZero).
+	const width, height = 64, 32
+	black := make([]byte, width/8*height)
+	for y := 8; y < 16; y++ {
+		for x := 16; x < 48; x++ {
+			black[y*width/8+x/8] |= 0x80 >> (x % 8)
+		}
+	}
+	// The same pixels as decoded from CCITT data: white = 1.
+	want := make([]byte, len(black))
+	for i, b := range black {
+		want[i] = ^b
+	}
+
+	for _, tt := range []struct {
+		name        string
+		photometric int
+		pixels      []byte
+	}{
+		{"WhiteIsZero", tiffPhotometricWhiteIsZero, black},
+		{"BlackIsZero", tiffPhotometricBlackIsZero, want},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data := buildTIFF(t, testTIFFPage{
+				width: width, height: height,
+				bitsPerSample: 1, samplesPerPixel: 1,
+				compression: tiffCompressionNone, photometric: tt.photometric,
+				rowsPerStrip: height, strips: [][]byte{tt.pixels},
+			})
+
+			img, err := LoadImageFromReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("LoadImageFromReader failed: %v", err)
+			}
+			if img.Filter() != "CCITTFaxDecode" || img.decode != "" {
+				t.Fatalf("expected Group 4 data without /Decode, got %q %q", img.Filter(), img.decode)
+			}
+			if got := decodeG4(t, img.Data(), width, height); !bytes.Equal(got, want) {
+				t.Errorf("decoded = %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadTIFF_BilevelFlateFallback(t *testing.T) {
+	// Alternating pixels code worse with Group 4 than as raw samples.
+	data := buildTIFF(t, testTIFFPage{
+		width: 8, height: 1,
+		bitsPerSample: 1, samplesPerPixel: 1,
+		compression: tiffCompressionNone, photometric: tiffPhotometricWhiteIsZero,
+		rowsPerStrip: 1, strips: [][]byte{{0xAA}},
+	})
+
+	img, err := LoadImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadImageFromReader failed: %v", err)
+	}
+	if img.Filter() != "" || !bytes.Equal(img.Data(), []byte{0xAA}) || img.decode != "[1 0]" {
+		t.Errorf("expected raw samples with /Decode [1 0], got filter %q data %x decode %q",
+			img.Filter(), img.Data(), img.decode)
+	}
+}
+
+func TestLoadTIFF_Invalid(t *testing.T) {
+	if _, err := LoadTIFFFromReader(bytes.NewReader([]byte("II*\x00\x08\x00\x00\x00"))); err == nil {
+		t.Error("expected error for truncated TIFF")
+	}
+
+	page := grayPage(2, 2, 0)
+	page.compression = 7 // JPEG
+	_, err := LoadTIFFFromReader(bytes.NewReader(buildTIFF(t, page)))
+	if !errors.Is(err, ErrUnsupportedTIFF) {
+		t.Errorf("expected ErrUnsupportedTIFF, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package creator
 
-import "math"
+import (
+	"errors"
+	"math"
+)
 
 // Transform represents a 2D affine transformation matrix.
 //
@@ -138,3 +141,78 @@ func (t Transform) TransformPoint(x, y float64) (float64, float64) {
 func (t Transform) ToPDFMatrix() [6]float64 {
 	return [6]float64{t.A, t.B, t.C, t.D, t.E, t.F}
 }
+
+// Save saves the graphics state (q operator), starting a group of drawing
+// operations that Translate, Scale, RotateContent and Transform apply to.
+//
+// Transforms compose in call order: each one is relative to the
+// coordinate system set up by the ones before it. Restore ends the group
+// and undoes them. Groups can be nested.
+//
+// Transforms apply to graphics operations (shapes, images, clipped text).
+// Text added with AddText and similar methods is drawn after all graphics
+// and is not transformed.
+//
+// Example:
+//
+//	// Draw the same motif in four rotated copies around (300, 400)
+//	for i := 0; i < 4; i++ {
+//	    page.Save()
+//	    page.Translate(300, 400)
+//	    page.RotateContent(float64(i) * 90)
+//	    page.DrawRect(20, -5, 60, 10, &creator.RectOptions{FillColor: &creator.Blue})
+//	    page.Restore()
+//	}
+func (p *Page) Save() {
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{Type: GraphicsOpSaveState})
+	p.saveDepth++
+}
+
+// Restore restores the graphics state saved by the matching Save (Q
+// operator), undoing the transforms made since.
+func (p *Page) Restore() error {
+	if p.saveDepth == 0 {
+		return errors.New("restore without matching save")
+	}
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{Type: GraphicsOpRestoreState})
+	p.saveDepth--
+	return nil
+}
+
+// Transform concatenates t to the current transformation matrix (cm
+// operator). It must be called between Save and Restore.
+func (p *Page) Transform(t Transform) error {
+	if p.saveDepth == 0 {
+		return errors.New("transform requires a preceding Save")
+	}
+	p.graphicsOps = append(p.graphicsOps, GraphicsOperation{
+		Type:   GraphicsOpTransform,
+		Matrix: t.ToPDFMatrix(),
+	})
+	return nil
+}
+
+// Translate moves the origin of subsequent drawing by (dx, dy).
+// It must be called between Save and Restore.
+func (p *Page) Translate(dx, dy float64) error {
+	return p.Transform(Translate(dx, dy))
+}
+
+// Scale scales subsequent drawing by sx horizontally and sy vertically.
+// Negative factors mirror (Scale(-1, 1) flips horizontally).
+// It must be called between Save and Restore.
+func (p *Page) Scale(sx, sy float64) error {
+	if sx == 0 || sy == 0 {
+		return errors.New("scale factors must be non-zero")
+	}
+	return p.Transform(Scale(sx, sy))
+}
+
+// RotateContent rotates subsequent drawing about the current origin by
+// degrees, counter-clockwise on the page (the Rotate matrix). Unlike
+// Page.Rotate, which sets the page's /Rotate entry, it only turns the
+// drawing operations that follow. It must be called between Save and
+// Restore.
+func (p *Page) RotateContent(degrees float64) error {
+	return p.Transform(Rotate(degrees))
+}
@@ -2,7 +2,10 @@ package creator
 
 import (
 	"math"
+	"strings"
 	"testing"
+
+	"github.com/coregx/gxpdf/internal/writer"
 )
 
 func TestIdentity(t *testing.T) {
@@ -262,6 +265,130 @@ func TestRotateInverse(t *testing.T) {
 	}
 }
 
+func TestPage_TranslateThenRotate(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	page.Save()
+	if err := page.Translate(100, 200); err != nil {
+		t.Fatalf("Translate() error = %v", err)
+	}
+	if err := page.RotateContent(90); err != nil {
+		t.Fatalf("RotateContent() error = %v", err)
+	}
+	if err := page.DrawRect(0, 0, 50, 10, &RectOptions{FillColor: &Red}); err != nil {
+		t.Fatalf("DrawRect() error = %v", err)
+	}
+	if err := page.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if err := page.DrawRect(0, 0, 50, 10, &RectOptions{FillColor: &Blue}); err != nil {
+		t.Fatalf("DrawRect() error = %v", err)
+	}
+
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	got := string(content)
+
+	// The rotation follows the translation, so it turns about (100, 200).
+	want := "q\n1 0 0 1 100 200 cm\n0 1 -1 0 0 0 cm\nq\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("expected content to start with %q, got:\n%s", want, got)
+	}
+	red := strings.Index(got, "1 0 0 rg")
+	restore := strings.Index(got, "Q\nQ\n")
+	blue := strings.Index(got, "0 0 1 rg")
+	if red < 0 || restore < red || blue < restore {
+		t.Errorf("expected the transform to be restored before the second rectangle:\n%s", got)
+	}
+}
+
+func TestPage_ScaleMirror(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	page.Save()
+	if err := page.Scale(-1, 1); err != nil {
+		t.Fatalf("Scale() error = %v", err)
+	}
+	if err := page.Scale(0, 1); err == nil {
+		t.Error("expected error for zero scale factor")
+	}
+	if err := page.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	ops := page.GraphicsOperations()
+	if len(ops) != 3 || ops[1].Matrix != [6]float64{-1, 0, 0, 1, 0, 0} {
+		t.Errorf("unexpected operations: %+v", ops)
+	}
+}
+
+func TestPage_ScaleRotateContent(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	page.Save()
+	if err := page.Scale(1.0/3, 0.75); err != nil {
+		t.Fatalf("Scale() error = %v", err)
+	}
+	if err := page.RotateContent(30); err != nil {
+		t.Fatalf("RotateContent() error = %v", err)
+	}
+	if err := page.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	content, _, err := writer.GenerateContentStreamWithGraphics(nil, convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	want := "q\n0.33333 0 0 0.75 0 0 cm\n0.86603 0.5 -0.5 0.86603 0 0 cm\nQ\n"
+	if got := string(content); got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+}
+
+func TestPage_TransformRequiresSave(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	if err := page.Translate(10, 10); err == nil {
+		t.Error("expected error for Translate without Save")
+	}
+	if err := page.Restore(); err == nil {
+		t.Error("expected error for Restore without Save")
+	}
+	if n := len(page.GraphicsOperations()); n != 0 {
+		t.Errorf("rejected calls must not add operations, got %d", n)
+	}
+}
+
+func TestPage_UnbalancedSaveDoesNotTransformText(t *testing.T) {
+	c := New()
+	page, _ := c.NewPage()
+
+	page.Save()
+	if err := page.Translate(50, 50); err != nil {
+		t.Fatalf("Translate() error = %v", err)
+	}
+	if err := page.AddText("Hello", 100, 700, Helvetica, 12); err != nil {
+		t.Fatalf("AddText() error = %v", err)
+	}
+
+	content, _, err := writer.GenerateContentStreamWithGraphics(convertTextOps(page.TextOperations()),
+		convertGraphicsOps(page.GraphicsOperations()))
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+	got := string(content)
+	if !strings.HasPrefix(got, "q\n1 0 0 1 50 50 cm\nQ\nBT\n") {
+		t.Errorf("expected the open group to be closed before the text, got:\n%s", got)
+	}
+}
+
 // Helper functions for floating-point comparison
 
 func approxEqual(a, b float64) bool {
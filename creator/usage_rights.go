@@ -0,0 +1,104 @@
+package creator
+
+import (
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// UsageRights selects the rights a usage rights (UR3) signature grants
+// in Adobe Reader, as enabled by Adobe's "Reader Extensions".
+type UsageRights struct {
+	// SaveForm lets Reader save the document with filled-in form data
+	// (/Document [/FullSave]).
+	SaveForm bool
+
+	// FillForms lets Reader fill in, import, export and submit form data
+	// (/Form [/FillIn /Import /Export /SubmitStandalone /SpawnTemplate]).
+	FillForms bool
+
+	// Annotations lets Reader create, edit and delete comments
+	// (/Annots [/Create /Delete /Modify /Copy /Import /Export]).
+	Annotations bool
+
+	// SignForms lets Reader sign existing signature fields
+	// (/Signature [/Modify]).
+	SignForms bool
+
+	// Message is shown by Reader when it opens the document (optional).
+	Message string
+}
+
+// ErrNoUsageRights is returned by SignUsageRights for documents written
+// without SetUsageRights.
+var ErrNoUsageRights = writer.ErrNoUsageRights
+
+// ErrSignatureTooLarge is returned by SignUsageRights when the signature
+// exceeds the space reserved in the document.
+var ErrSignatureTooLarge = writer.ErrSignatureTooLarge
+
+// SetUsageRights adds a usage rights signature placeholder (/Perms /UR3)
+// to the document. Pass nil to remove it.
+//
+// Reader only honors usage rights signed with a certificate issued by
+// Adobe. The write methods produce the complete structure with an empty
+// signature; pass the output to SignUsageRights with a signing function
+// backed by an appropriate certificate. Because the signed byte range
+// depends on the complete output, the document is built in memory
+// before being written.
+//
+// Example:
+//
+//	c.SetUsageRights(&creator.UsageRights{SaveForm: true, FillForms: true})
+//	pdf, _ := c.Bytes()
+//	err := creator.SignUsageRights(pdf, func(data []byte) ([]byte, error) {
+//	    return pkcs7SignDetached(data, cert, key) // DER-encoded PKCS#7
+//	})
+//
+// Reference: PDF 1.7 Specification, Section 12.8.2.3 (UR Signatures).
+func (c *Creator) SetUsageRights(rights *UsageRights) {
+	c.usageRights = rights
+}
+
+// toWriter converts usage rights to the writer's TransformParams names.
+// Returns nil for nil rights.
+func (r *UsageRights) toWriter() *writer.UsageRights {
+	if r == nil {
+		return nil
+	}
+	out := &writer.UsageRights{Message: r.Message}
+	if r.SaveForm {
+		out.Document = []string{"FullSave"}
+	}
+	if r.FillForms {
+		out.Form = []string{"FillIn", "Import", "Export", "SubmitStandalone", "SpawnTemplate"}
+	}
+	if r.Annotations {
+		out.Annots = []string{"Create", "Delete", "Modify", "Copy", "Import", "Export"}
+	}
+	if r.SignForms {
+		out.Signature = []string{"Modify"}
+	}
+	return out
+}
+
+// SignUsageRights signs the usage rights placeholder of a document written
+// with SetUsageRights, in place.
+//
+// sign receives the bytes covered by the signature's /ByteRange (the file
+// without the signature value) and must return a detached, DER-encoded
+// PKCS#7 signature of them, at most 8 KB long.
+//
+// Signing changes the file, so a checksum embedded with EmbedChecksum no
+// longer verifies afterwards.
+func SignUsageRights(pdf []byte, sign func(data []byte) ([]byte, error)) error {
+	data, err := writer.UsageRightsSignedData(pdf)
+	if err != nil {
+		return err
+	}
+	signature, err := sign(data)
+	if err != nil {
+		return fmt.Errorf("failed to sign usage rights: %w", err)
+	}
+	return writer.EmbedUsageRightsSignature(pdf, signature)
+}
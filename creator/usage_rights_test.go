@@ -0,0 +1,65 @@
+package creator
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreator_SetUsageRights(t *testing.T) {
+	c := New()
+	_, err := c.NewPage()
+	require.NoError(t, err)
+	c.SetUsageRights(&UsageRights{SaveForm: true, FillForms: true, Message: "Reader enabled"})
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+	s := string(pdf)
+
+	assert.Regexp(t, `/Perms << /UR3 \d+ 0 R >>`, s)
+	assert.Contains(t, s, "/Type /Sig /Filter /Adobe.PPKLite /SubFilter /adbe.pkcs7.detached")
+	assert.Contains(t, s, "/TransformMethod /UR3 /TransformParams << /Type /TransformParams /V /2.2"+
+		" /Document [/FullSave] /Form [/FillIn /Import /Export /SubmitStandalone /SpawnTemplate] /Msg (Reader enabled) >>")
+	assert.NotContains(t, s, "/Annots [")
+
+	// The byte range covers the file except the /Contents hex string.
+	m := regexp.MustCompile(`/ByteRange \[0 (\d+) (\d+) (\d+)\]`).FindStringSubmatch(s)
+	require.NotNil(t, m)
+	start, _ := strconv.Atoi(m[1])
+	end, _ := strconv.Atoi(m[2])
+	tail, _ := strconv.Atoi(m[3])
+	assert.Equal(t, byte('<'), pdf[start])
+	assert.Equal(t, byte('>'), pdf[end-1])
+	assert.Equal(t, len(pdf), end+tail)
+
+	var signed []byte
+	err = SignUsageRights(pdf, func(data []byte) ([]byte, error) {
+		signed = data
+		return []byte{0xAB, 0xCD}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, append(append([]byte{}, pdf[:start]...), pdf[end:]...), signed)
+	assert.True(t, bytes.HasPrefix(pdf[start:], []byte("<abcd000")))
+}
+
+func TestSignUsageRights_Errors(t *testing.T) {
+	c := New()
+	_, err := c.NewPage()
+	require.NoError(t, err)
+
+	plain, err := c.Bytes()
+	require.NoError(t, err)
+	err = SignUsageRights(plain, func([]byte) ([]byte, error) { return nil, nil })
+	assert.True(t, errors.Is(err, ErrNoUsageRights))
+
+	c.SetUsageRights(&UsageRights{Annotations: true})
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+	err = SignUsageRights(pdf, func([]byte) ([]byte, error) { return make([]byte, 8193), nil })
+	assert.True(t, errors.Is(err, ErrSignatureTooLarge))
+}
@@ -0,0 +1,176 @@
+package creator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// EmbedXMPMetadata sets whether the document metadata is also written as
+// an XMP packet (/Metadata in the catalog), which Adobe tools and archival
+// validators prefer over the Info dictionary.
+//
+// The packet is generated from the title, author, subject, keywords,
+// creator, producer and dates at write time. Use SetXMP to supply a
+// packet instead.
+//
+// Example:
+//
+//	c.SetTitle("Annual Report")
+//	c.EmbedXMPMetadata(true)
+func (c *Creator) EmbedXMPMetadata(embed bool) {
+	c.embedXMP = embed
+}
+
+// SetXMP embeds packet as the XMP metadata packet, replacing the generated
+// one. The packet should be a complete <?xpacket?>-wrapped x:xmpmeta
+// document and agree with the document metadata. Pass nil to go back to
+// the generated packet.
+//
+// Example:
+//
+//	packet, _ := os.ReadFile("metadata.xmp")
+//	c.SetXMP(packet)
+func (c *Creator) SetXMP(packet []byte) {
+	c.xmp = nil
+	if len(packet) > 0 {
+		c.xmp = append([]byte(nil), packet...)
+	}
+}
+
+// xmpPacket returns the XMP packet to embed, or nil for none. PDF/A
+// output always embeds one.
+func (c *Creator) xmpPacket() []byte {
+	if c.xmp != nil {
+		return c.xmp
+	}
+	if !c.embedXMP && c.conformance != PDFA1B {
+		return nil
+	}
+	return buildXMP(c.doc, c.conformance)
+}
+
+// buildXMP builds a minimal XMP packet from the document metadata, using
+// the Dublin Core, XMP basic and Adobe PDF schemas, and for PDF/A output
+// the PDF/A identification schema.
+//
+// Format:
+//
+//	<?xpacket begin="\uFEFF" id="W5M0MpCehiHzreSzNTczkc9d"?>
+//	<x:xmpmeta xmlns:x="adobe:ns:meta/">
+//	 <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+//	  <rdf:Description rdf:about="" xmlns:dc=... xmlns:xmp=... xmlns:pdf=...>
+//	   <dc:title><rdf:Alt><rdf:li xml:lang="x-default">Title</rdf:li></rdf:Alt></dc:title>
+//	   ...
+//	  </rdf:Description>
+//	 </rdf:RDF>
+//	</x:xmpmeta>
+//	<?xpacket end="w"?>
+//
+// Reference: XMP Specification Part 1, Section 7 (Data Model),
+// PDF 1.7 Specification, Section 14.3.2 (Metadata Streams) and
+// ISO 19005-1:2005, Section 6.7.11 (Version and Conformance Level).
+func buildXMP(doc *document.Document, conformance Conformance) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	buf.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	buf.WriteString(" <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	buf.WriteString("  <rdf:Description rdf:about=\"\"")
+	buf.WriteString(" xmlns:dc=\"http://purl.org/dc/elements/1.1/\"")
+	buf.WriteString(" xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"")
+	buf.WriteString(" xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"")
+	if conformance == PDFA1B {
+		buf.WriteString(" xmlns:pdfaid=\"http://www.aiim.org/pdfa/ns/id/\"")
+	}
+	buf.WriteString(">\n")
+
+	buf.WriteString("   <dc:format>application/pdf</dc:format>\n")
+	if title := doc.Title(); title != "" {
+		writeXMPAlt(&buf, "dc:title", title)
+	}
+	if author := doc.Author(); author != "" {
+		buf.WriteString("   <dc:creator><rdf:Seq><rdf:li>")
+		writeXMPText(&buf, author)
+		buf.WriteString("</rdf:li></rdf:Seq></dc:creator>\n")
+	}
+	if subject := doc.Subject(); subject != "" {
+		writeXMPAlt(&buf, "dc:description", subject)
+	}
+	if keywords := xmpKeywords(doc); len(keywords) > 0 {
+		buf.WriteString("   <dc:subject><rdf:Bag>")
+		for _, k := range keywords {
+			buf.WriteString("<rdf:li>")
+			writeXMPText(&buf, k)
+			buf.WriteString("</rdf:li>")
+		}
+		buf.WriteString("</rdf:Bag></dc:subject>\n")
+	}
+
+	if creator := doc.Creator(); creator != "" {
+		writeXMPProperty(&buf, "xmp:CreatorTool", creator)
+	}
+	if created := doc.CreationDate(); !created.IsZero() {
+		writeXMPProperty(&buf, "xmp:CreateDate", created.Format(time.RFC3339))
+	}
+	if modified := doc.ModificationDate(); !modified.IsZero() {
+		writeXMPProperty(&buf, "xmp:ModifyDate", modified.Format(time.RFC3339))
+		writeXMPProperty(&buf, "xmp:MetadataDate", modified.Format(time.RFC3339))
+	}
+
+	if producer := doc.Producer(); producer != "" {
+		writeXMPProperty(&buf, "pdf:Producer", producer)
+	}
+	if keywords := writer.FormatKeywords(doc.Keywords()); keywords != "" {
+		writeXMPProperty(&buf, "pdf:Keywords", keywords)
+	}
+	if trapped := doc.Trapped(); trapped != document.TrappedUnset {
+		writeXMPProperty(&buf, "pdf:Trapped", string(trapped))
+	}
+
+	if conformance == PDFA1B {
+		writeXMPProperty(&buf, "pdfaid:part", "1")
+		writeXMPProperty(&buf, "pdfaid:conformance", "B")
+	}
+
+	buf.WriteString("  </rdf:Description>\n")
+	buf.WriteString(" </rdf:RDF>\n")
+	buf.WriteString("</x:xmpmeta>\n")
+	buf.WriteString("<?xpacket end=\"w\"?>")
+
+	return buf.Bytes()
+}
+
+// xmpKeywords returns the non-empty document keywords.
+func xmpKeywords(doc *document.Document) []string {
+	var keywords []string
+	for _, k := range doc.Keywords() {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	return keywords
+}
+
+// writeXMPProperty writes a simple text property.
+func writeXMPProperty(buf *bytes.Buffer, name, value string) {
+	buf.WriteString("   <" + name + ">")
+	writeXMPText(buf, value)
+	buf.WriteString("</" + name + ">\n")
+}
+
+// writeXMPAlt writes a language alternative property with a single
+// x-default entry.
+func writeXMPAlt(buf *bytes.Buffer, name, value string) {
+	buf.WriteString("   <" + name + "><rdf:Alt><rdf:li xml:lang=\"x-default\">")
+	writeXMPText(buf, value)
+	buf.WriteString("</rdf:li></rdf:Alt></" + name + ">\n")
+}
+
+// writeXMPText writes s with XML special characters escaped.
+func writeXMPText(buf *bytes.Buffer, s string) {
+	_ = xml.EscapeText(buf, []byte(s))
+}
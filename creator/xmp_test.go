@@ -0,0 +1,91 @@
+package creator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metadataStream returns the data of the stream referenced by the
+// catalog's /Metadata entry, and its dictionary.
+func metadataStream(t *testing.T, pdf []byte) (dict string, data []byte) {
+	t.Helper()
+
+	ref := regexp.MustCompile(`/Type /Catalog[^\n]*/Metadata (\d+) 0 R`).FindSubmatch(pdf)
+	require.NotNil(t, ref, "catalog should reference a /Metadata stream")
+
+	obj := regexp.MustCompile(`(?s)\n` + string(ref[1]) + ` 0 obj\n(<<[^\n]*>>)\nstream\n`).FindSubmatchIndex(pdf)
+	require.NotNil(t, obj, "metadata stream object not found")
+	dict = string(pdf[obj[2]:obj[3]])
+
+	m := regexp.MustCompile(`/Length (\d+)`).FindStringSubmatch(dict)
+	require.NotNil(t, m)
+	length, err := strconv.Atoi(m[1])
+	require.NoError(t, err)
+
+	return dict, pdf[obj[1] : obj[1]+length]
+}
+
+func TestCreator_EmbedXMPMetadata(t *testing.T) {
+	c := New()
+	c.SetMetadata("Q3 <Report> & Notes", "Jane Doe", "Finance")
+	c.SetKeywords("invoice", "2025")
+	c.EmbedXMPMetadata(true)
+	_, err := c.NewPage()
+	require.NoError(t, err)
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+
+	dict, packet := metadataStream(t, pdf)
+	assert.Contains(t, dict, "/Type /Metadata /Subtype /XML")
+	assert.NotContains(t, dict, "/Filter", "XMP must be left uncompressed")
+
+	var meta struct {
+		Title       []string `xml:"RDF>Description>title>Alt>li"`
+		Creator     []string `xml:"RDF>Description>creator>Seq>li"`
+		Description []string `xml:"RDF>Description>description>Alt>li"`
+		Subject     []string `xml:"RDF>Description>subject>Bag>li"`
+		Keywords    string   `xml:"RDF>Description>Keywords"`
+		Producer    string   `xml:"RDF>Description>Producer"`
+		CreateDate  string   `xml:"RDF>Description>CreateDate"`
+	}
+	// Unmarshal the x:xmpmeta element, without the xpacket wrapper.
+	start := bytes.Index(packet, []byte("<x:xmpmeta"))
+	end := bytes.Index(packet, []byte("</x:xmpmeta>"))
+	require.True(t, start >= 0 && end > start, "packet should contain x:xmpmeta")
+	require.NoError(t, xml.Unmarshal(packet[start:end+len("</x:xmpmeta>")], &meta))
+
+	assert.Equal(t, []string{"Q3 <Report> & Notes"}, meta.Title)
+	assert.Equal(t, []string{"Jane Doe"}, meta.Creator)
+	assert.Equal(t, []string{"Finance"}, meta.Description)
+	assert.Equal(t, []string{"invoice", "2025"}, meta.Subject)
+	assert.Equal(t, "invoice, 2025", meta.Keywords)
+	assert.Equal(t, c.Document().Producer(), meta.Producer)
+	assert.Equal(t, c.Document().CreationDate().Format("2006-01-02T15:04:05Z07:00"), meta.CreateDate)
+}
+
+func TestCreator_SetXMP(t *testing.T) {
+	packet := []byte(`<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?><x:xmpmeta xmlns:x="adobe:ns:meta/"/><?xpacket end="w"?>`)
+
+	c := New()
+	c.SetXMP(packet)
+	_, err := c.NewPage()
+	require.NoError(t, err)
+
+	pdf, err := c.Bytes()
+	require.NoError(t, err)
+	_, data := metadataStream(t, pdf)
+	assert.Equal(t, packet, data)
+
+	// Clearing the override without EmbedXMPMetadata writes no packet.
+	c.SetXMP(nil)
+	pdf, err = c.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(pdf), "/Metadata")
+}
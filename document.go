@@ -3,9 +3,12 @@ package gxpdf
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/coregx/gxpdf/internal/application/forms"
 	"github.com/coregx/gxpdf/internal/extractor"
+	"github.com/coregx/gxpdf/internal/models/types"
 	"github.com/coregx/gxpdf/internal/parser"
 	"github.com/coregx/gxpdf/internal/tabledetect"
 )
@@ -29,6 +32,7 @@ type Document struct {
 	reader *parser.Reader
 	ctx    context.Context
 	path   string
+	order  []int // Source page index of each page (nil = file order)
 }
 
 // Close closes the document and releases resources.
@@ -48,6 +52,9 @@ func (d *Document) Path() string {
 
 // PageCount returns the total number of pages in the document.
 func (d *Document) PageCount() int {
+	if d.order != nil {
+		return len(d.order)
+	}
 	count, err := d.reader.GetPageCount()
 	if err != nil {
 		return 0
@@ -73,7 +80,10 @@ func (d *Document) Page(index int) *Page {
 // Example:
 //
 //	for _, page := range doc.Pages() {
-//	    text := page.ExtractText()
+//	    text, err := page.ExtractText()
+//	    if err != nil {
+//	        return err
+//	    }
 //	    fmt.Println(text)
 //	}
 func (d *Document) Pages() []*Page {
@@ -85,6 +95,28 @@ func (d *Document) Pages() []*Page {
 	return pages
 }
 
+// ExtractText extracts the text of all pages in reading order (see
+// Page.ExtractText), with pages separated by a blank line.
+//
+// Example:
+//
+//	text, err := doc.ExtractText()
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(text)
+func (d *Document) ExtractText() (string, error) {
+	texts := make([]string, 0, d.PageCount())
+	for _, page := range d.Pages() {
+		text, err := page.ExtractText()
+		if err != nil {
+			return "", fmt.Errorf("page %d: %w", page.Number(), err)
+		}
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
 // ExtractTables extracts all tables from all pages.
 //
 // This is the simplest way to extract tables - uses automatic detection
@@ -140,7 +172,7 @@ func (d *Document) ExtractTablesWithOptions(opts *ExtractionOptions) ([]*Table,
 		}
 
 		// Extract text elements
-		textElements, err := textExtractor.ExtractFromPage(pageIndex)
+		textElements, err := textExtractor.ExtractFromPage(d.sourcePage(pageIndex))
 		if err != nil {
 			return nil, fmt.Errorf("gxpdf: failed to extract text from page %d: %w", pageIndex, err)
 		}
@@ -202,37 +234,66 @@ func (d *Document) GetImages() []*Image {
 // Use this when you need error handling for image extraction.
 func (d *Document) GetImagesWithError() ([]*Image, error) {
 	imageExtractor := extractor.NewImageExtractor(d.reader)
-	internalImages, err := imageExtractor.ExtractFromDocument()
-	if err != nil {
-		return nil, fmt.Errorf("gxpdf: failed to extract images: %w", err)
+	if d.order == nil {
+		internalImages, err := imageExtractor.ExtractFromDocument()
+		if err != nil {
+			return nil, fmt.Errorf("gxpdf: failed to extract images: %w", err)
+		}
+		return wrapImages(internalImages), nil
 	}
 
-	// Wrap internal images in public API
+	// Pages were removed or moved: extract in the current page order.
+	var internalImages []*types.Image
+	for _, index := range d.order {
+		pageImages, err := imageExtractor.ExtractFromPage(index)
+		if err != nil {
+			continue
+		}
+		internalImages = append(internalImages, pageImages...)
+	}
+	return wrapImages(internalImages), nil
+}
+
+// wrapImages wraps internal images in the public API.
+func wrapImages(internalImages []*types.Image) []*Image {
 	images := make([]*Image, len(internalImages))
 	for i, internal := range internalImages {
 		images[i] = &Image{internal: internal}
 	}
-
-	return images, nil
+	return images
 }
 
 // Info returns document metadata.
+//
+// Text fields are decoded from PDFDocEncoding or UTF-16 as needed. Fields
+// missing from the Info dictionary are left empty, and dates that are
+// missing or malformed are left zero.
 func (d *Document) Info() *DocumentInfo {
 	pinfo := d.reader.GetDocumentInfo()
 	return &DocumentInfo{
-		PageCount: d.PageCount(),
-		Path:      d.path,
-		Version:   pinfo.Version,
-		Title:     pinfo.Title,
-		Author:    pinfo.Author,
-		Subject:   pinfo.Subject,
-		Keywords:  pinfo.Keywords,
-		Creator:   pinfo.Creator,
-		Producer:  pinfo.Producer,
-		Encrypted: pinfo.Encrypted,
+		PageCount:    d.PageCount(),
+		Path:         d.path,
+		Version:      pinfo.Version,
+		Title:        pinfo.Title,
+		Author:       pinfo.Author,
+		Subject:      pinfo.Subject,
+		Keywords:     pinfo.Keywords,
+		Creator:      pinfo.Creator,
+		Producer:     pinfo.Producer,
+		CreationDate: pinfo.CreationDate,
+		ModDate:      pinfo.ModDate,
+		Encrypted:    pinfo.Encrypted,
 	}
 }
 
+// Metadata returns the document's XMP metadata packet (the catalog's
+// /Metadata stream), decoded.
+//
+// Returns nil (no error) if the document has no XMP metadata.
+func (d *Document) Metadata() ([]byte, error) {
+	return d.reader.GetMetadata()
+}
+
 // Version returns the PDF version (e.g., "1.7").
 func (d *Document) Version() string {
 	return d.reader.GetDocumentInfo().Version
@@ -282,7 +343,7 @@ func (d *Document) ExtractTextFromPage(pageNum int) (string, error) {
 	if page == nil {
 		return "", fmt.Errorf("page %d not found", pageNum)
 	}
-	return page.ExtractText(), nil
+	return page.ExtractText()
 }
 
 // ExtractTablesFromPage extracts tables from a specific page (1-based).
@@ -308,6 +369,11 @@ type DocumentInfo struct {
 	Keywords  string
 	Creator   string
 	Producer  string
+
+	// CreationDate and ModDate are zero if not set.
+	CreationDate time.Time
+	ModDate      time.Time
+
 	Encrypted bool
 }
 
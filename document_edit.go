@@ -0,0 +1,525 @@
+package gxpdf
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/parser"
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// inheritableKeys are the page attributes a page may inherit from its
+// ancestors in the page tree. They are copied onto each page when saving,
+// because the saved page tree is flattened.
+//
+// Reference: PDF 1.7 Specification, Section 7.7.3.4 (Inheritance of Page Attributes).
+var inheritableKeys = []string{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// RemovePage removes the page at the given index (0-based).
+//
+// The change affects PageCount, Page and the extraction methods immediately
+// and is written to disk by Save. Resources used only by the removed page
+// are dropped from the saved file.
+//
+// Example:
+//
+//	doc, _ := gxpdf.Open("report.pdf")
+//	defer doc.Close()
+//
+//	_ = doc.RemovePage(0) // Drop the cover page
+//	err := doc.Save("report-no-cover.pdf")
+func (d *Document) RemovePage(index int) error {
+	order := d.pageOrder()
+	if index < 0 || index >= len(order) {
+		return fmt.Errorf("%w: index %d (document has %d pages)", ErrPageNotFound, index, len(order))
+	}
+	d.order = append(order[:index:index], order[index+1:]...)
+	return nil
+}
+
+// MovePage moves the page at index from to index to (both 0-based).
+//
+// Pages between the two positions shift by one to make room. The change
+// is written to disk by Save.
+//
+// Example:
+//
+//	// Move the last page to the front
+//	err := doc.MovePage(doc.PageCount()-1, 0)
+func (d *Document) MovePage(from, to int) error {
+	order := d.pageOrder()
+	if from < 0 || from >= len(order) {
+		return fmt.Errorf("%w: index %d (document has %d pages)", ErrPageNotFound, from, len(order))
+	}
+	if to < 0 || to >= len(order) {
+		return fmt.Errorf("%w: index %d (document has %d pages)", ErrPageNotFound, to, len(order))
+	}
+
+	page := order[from]
+	moved := append(order[:from:from], order[from+1:]...)
+	moved = append(moved[:to], append([]int{page}, moved[to:]...)...)
+	d.order = moved
+	return nil
+}
+
+// PageRange is a span of pages, 1-based and inclusive: {Start: 2, End: 3}
+// covers the second and third pages. A single page has Start == End.
+type PageRange struct {
+	Start int
+	End   int
+}
+
+// ExtractPages returns a new document containing only the pages in ranges,
+// in the order given.
+//
+// Page numbers refer to the document as currently edited (after any
+// RemovePage or MovePage). Each page may be selected once. The new
+// document is independent of d and must be closed separately; Save writes
+// just its pages and the objects they depend on, such as fonts and images
+// shared with pages left out, while everything else is pruned.
+//
+// Example:
+//
+//	// Burst a report into one file per chapter.
+//	chapter, err := doc.ExtractPages(gxpdf.PageRange{Start: 5, End: 12})
+//	if err != nil {
+//	    return err
+//	}
+//	defer chapter.Close()
+//	err = chapter.Save("chapter-2.pdf")
+func (d *Document) ExtractPages(ranges ...PageRange) (*Document, error) {
+	order := d.pageOrder()
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("%w: no page ranges", ErrPageNotFound)
+	}
+
+	var selected []int
+	seen := make(map[int]bool)
+	for _, r := range ranges {
+		if r.Start < 1 || r.End < r.Start || r.End > len(order) {
+			return nil, fmt.Errorf("%w: range %d-%d (document has %d pages)", ErrPageNotFound, r.Start, r.End, len(order))
+		}
+		for number := r.Start; number <= r.End; number++ {
+			if seen[number] {
+				return nil, fmt.Errorf("page %d selected more than once", number)
+			}
+			seen[number] = true
+			selected = append(selected, order[number-1])
+		}
+	}
+
+	extracted, err := OpenWithContext(d.ctx, d.path)
+	if err != nil {
+		return nil, err
+	}
+	extracted.order = selected
+	return extracted, nil
+}
+
+// Save writes the document, including any page removals and moves, to path.
+//
+// The file is fully rewritten: objects reachable from the catalog, the
+// kept pages and the Info dictionary are copied and renumbered, and the
+// page tree is rebuilt as a single level in the current page order.
+// Stream contents are copied unchanged, without re-encoding.
+//
+// Links and outline entries that point to removed pages are left with a
+// null destination. Encrypted documents keep their encryption: the file
+// is written with the original encryption dictionary and file key, so the
+// same passwords and permissions apply. Use SaveEncrypted to change them.
+// Encrypted documents that need a password to open cannot be saved.
+//
+// Example:
+//
+//	doc, _ := gxpdf.Open("input.pdf")
+//	defer doc.Close()
+//
+//	_ = doc.MovePage(2, 0)
+//	if err := doc.Save("output.pdf"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (d *Document) Save(path string) error {
+	var enc *writer.Encryption
+	if d.IsEncrypted() {
+		var err error
+		if enc, err = d.originalEncryption(); err != nil {
+			return err
+		}
+	}
+	return d.save(path, enc)
+}
+
+// save writes the document to path, encrypting it if enc is not nil.
+func (d *Document) save(path string, enc *writer.Encryption) error {
+	objects, trailer, err := d.rewriteObjects(enc)
+	if err != nil {
+		return fmt.Errorf("gxpdf: failed to save %s: %w", path, err)
+	}
+
+	version := d.reader.Version()
+	if version == "" {
+		version = "1.7"
+	}
+	if enc != nil {
+		version = writer.MinVersion(version, enc.Version)
+	}
+
+	w, err := writer.NewPdfWriter(path)
+	if err != nil {
+		return fmt.Errorf("gxpdf: failed to create %s: %w", path, err)
+	}
+	if err := w.WriteObjects(version, objects, trailer); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("gxpdf: failed to save %s: %w", path, err)
+	}
+	return w.Close()
+}
+
+// pageOrder returns the source page index of each page in the document.
+func (d *Document) pageOrder() []int {
+	if d.order != nil {
+		return d.order
+	}
+	count, err := d.reader.GetPageCount()
+	if err != nil {
+		return nil
+	}
+	order := make([]int, count)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// sourcePage maps a page index to the page's index in the underlying file.
+func (d *Document) sourcePage(index int) int {
+	if d.order != nil && index >= 0 && index < len(d.order) {
+		return d.order[index]
+	}
+	return index
+}
+
+// sourcePageRef is a page object in the original file together with the
+// attributes it inherits from its ancestors.
+type sourcePageRef struct {
+	num       int
+	inherited map[string]parser.PdfObject
+}
+
+// rewriteObjects copies the objects needed by the current page order into
+// a new, contiguously numbered object list, encrypted if enc is not nil.
+func (d *Document) rewriteObjects(enc *writer.Encryption) ([]*writer.IndirectObject, writer.RawTrailer, error) {
+	var trailer writer.RawTrailer
+	rootObj, ok := d.reader.Trailer().Get("Root").(*parser.IndirectReference)
+	if !ok {
+		return nil, trailer, fmt.Errorf("trailer /Root is not an indirect reference")
+	}
+	catalog, err := d.reader.GetObject(rootObj.Number)
+	if err != nil {
+		return nil, trailer, fmt.Errorf("failed to load catalog: %w", err)
+	}
+	catalogDict, ok := catalog.(*parser.Dictionary)
+	if !ok {
+		return nil, trailer, fmt.Errorf("catalog is %T, not a dictionary", catalog)
+	}
+
+	c := newObjectCopier(d.reader)
+	c.encryption = enc
+	c.replace[rootObj.Number] = parser.NewIndirectReference(1, 0)
+	c.next = 3 // 1 = catalog, 2 = page tree root
+	rootPages := parser.NewIndirectReference(2, 0)
+
+	pages, treeNodes, err := c.collectPages(catalogDict.Get("Pages"))
+	if err != nil {
+		return nil, trailer, err
+	}
+	order := d.pageOrder()
+	for _, index := range order {
+		if index < 0 || index >= len(pages) {
+			return nil, trailer, fmt.Errorf("%w: source page %d", ErrPageNotFound, index)
+		}
+	}
+
+	// References to old tree nodes point at the new root; references to
+	// removed pages become null.
+	for _, num := range treeNodes {
+		c.replace[num] = rootPages
+	}
+	for _, page := range pages {
+		c.replace[page.num] = parser.NewNull()
+	}
+	kids := parser.NewArrayWithCapacity(len(order))
+	for _, index := range order {
+		page := pages[index]
+		delete(c.replace, page.num)
+		c.pages[page.num] = page.inherited
+		kids.Append(c.ref(page.num))
+	}
+
+	pagesDict := parser.NewDictionary()
+	pagesDict.SetName("Type", "Pages")
+	pagesDict.Set("Kids", kids)
+	pagesDict.SetInteger("Count", int64(len(order)))
+
+	newCatalog := c.copyDict(catalogDict, "")
+	newCatalog.Set("Pages", rootPages)
+
+	if info, ok := d.reader.Trailer().Get("Info").(*parser.IndirectReference); ok {
+		if ref, ok := c.ref(info.Number).(*parser.IndirectReference); ok {
+			trailer.Info = ref.Number
+		}
+	}
+
+	body, err := c.drain()
+	if err != nil {
+		return nil, trailer, err
+	}
+
+	objects := make([]*writer.IndirectObject, 0, len(body)+3)
+	for i, obj := range []parser.PdfObject{newCatalog, pagesDict} {
+		data, err := c.serialize(obj, i+1)
+		if err != nil {
+			return nil, trailer, err
+		}
+		objects = append(objects, writer.NewIndirectObject(i+1, 0, data))
+	}
+	objects = append(objects, body...)
+	trailer.Root = 1
+
+	// The encryption dictionary is written last, unencrypted.
+	if enc != nil {
+		data, err := serializeObject(enc.Dict)
+		if err != nil {
+			return nil, trailer, err
+		}
+		trailer.Encrypt = len(objects) + 1
+		trailer.ID = enc.ID
+		objects = append(objects, writer.NewIndirectObject(trailer.Encrypt, 0, data))
+	}
+
+	return objects, trailer, nil
+}
+
+// objectCopier copies objects out of a reader, renumbering them in the
+// order they are first referenced.
+type objectCopier struct {
+	reader  *parser.Reader
+	next    int                                 // Next free object number
+	numbers map[int]int                         // Old object number -> new
+	replace map[int]parser.PdfObject            // Old object number -> replacement value
+	pages   map[int]map[string]parser.PdfObject // Kept page -> inherited attributes
+	streams map[*parser.Stream]int              // Direct streams promoted to objects
+	queue   []pendingObject                     // Objects still to be copied
+
+	encryption *writer.Encryption // Encryption of the copies (nil for none)
+}
+
+// pendingObject is an object that has a new number but has not been copied yet.
+type pendingObject struct {
+	num    int
+	oldNum int              // Object number in the source file (0 if src is set)
+	src    parser.PdfObject // Direct object to copy when oldNum is 0
+}
+
+func newObjectCopier(reader *parser.Reader) *objectCopier {
+	return &objectCopier{
+		reader:  reader,
+		next:    1,
+		numbers: make(map[int]int),
+		replace: make(map[int]parser.PdfObject),
+		pages:   make(map[int]map[string]parser.PdfObject),
+		streams: make(map[*parser.Stream]int),
+	}
+}
+
+// collectPages walks the page tree and returns its leaf pages in order,
+// plus the object numbers of its intermediate nodes.
+func (c *objectCopier) collectPages(root parser.PdfObject) ([]sourcePageRef, []int, error) {
+	var pages []sourcePageRef
+	var nodes []int
+	visited := make(map[int]bool)
+
+	var walk func(obj parser.PdfObject, inherited map[string]parser.PdfObject) error
+	walk = func(obj parser.PdfObject, inherited map[string]parser.PdfObject) error {
+		ref, ok := obj.(*parser.IndirectReference)
+		if !ok {
+			return fmt.Errorf("page tree node is %T, not an indirect reference", obj)
+		}
+		if visited[ref.Number] {
+			return fmt.Errorf("page tree cycle at object %d", ref.Number)
+		}
+		visited[ref.Number] = true
+
+		resolved, err := c.reader.GetObject(ref.Number)
+		if err != nil {
+			return fmt.Errorf("failed to load page tree node %d: %w", ref.Number, err)
+		}
+		node, ok := resolved.(*parser.Dictionary)
+		if !ok {
+			return fmt.Errorf("page tree node %d is %T, not a dictionary", ref.Number, resolved)
+		}
+
+		if name := node.GetName("Type"); (name != nil && name.Value() == "Page") || !node.Has("Kids") {
+			pages = append(pages, sourcePageRef{num: ref.Number, inherited: inherited})
+			return nil
+		}
+
+		nodes = append(nodes, ref.Number)
+		next := make(map[string]parser.PdfObject, len(inheritableKeys))
+		for key, value := range inherited {
+			next[key] = value
+		}
+		for _, key := range inheritableKeys {
+			if value := node.Get(key); value != nil {
+				next[key] = value
+			}
+		}
+
+		kids, err := c.reader.ResolveArray(node.Get("Kids"))
+		if err != nil {
+			return fmt.Errorf("failed to resolve /Kids of node %d: %w", ref.Number, err)
+		}
+		for _, kid := range kids.Elements() {
+			if err := walk(kid, next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(root, nil); err != nil {
+		return nil, nil, err
+	}
+	return pages, nodes, nil
+}
+
+// ref returns the value to write in place of a reference to oldNum,
+// assigning a new object number on first use.
+func (c *objectCopier) ref(oldNum int) parser.PdfObject {
+	if value, ok := c.replace[oldNum]; ok {
+		return value
+	}
+	num, ok := c.numbers[oldNum]
+	if !ok {
+		num = c.next
+		c.next++
+		c.numbers[oldNum] = num
+		c.queue = append(c.queue, pendingObject{num: num, oldNum: oldNum})
+	}
+	return parser.NewIndirectReference(num, 0)
+}
+
+// copy returns a copy of obj with all references renumbered.
+func (c *objectCopier) copy(obj parser.PdfObject) parser.PdfObject {
+	switch o := obj.(type) {
+	case *parser.IndirectReference:
+		return c.ref(o.Number)
+	case *parser.Array:
+		arr := parser.NewArrayWithCapacity(o.Len())
+		for _, elem := range o.Elements() {
+			arr.Append(c.copy(elem))
+		}
+		return arr
+	case *parser.Dictionary:
+		return c.copyDict(o, "")
+	case *parser.Stream:
+		// Streams must be indirect objects. A stream can end up direct when
+		// a cached object had its references resolved in place.
+		num, ok := c.streams[o]
+		if !ok {
+			num = c.next
+			c.next++
+			c.streams[o] = num
+			c.queue = append(c.queue, pendingObject{num: num, src: o})
+		}
+		return parser.NewIndirectReference(num, 0)
+	default:
+		return obj
+	}
+}
+
+// copyObject copies an object being written at the top level.
+func (c *objectCopier) copyObject(obj parser.PdfObject) parser.PdfObject {
+	stream, ok := obj.(*parser.Stream)
+	if !ok {
+		return c.copy(obj)
+	}
+	// Length is rewritten from the content. It may be an indirect
+	// reference, which would otherwise be copied as an unused object.
+	return parser.NewStream(c.copyDict(stream.Dictionary(), "Length"), stream.Content())
+}
+
+// copyDict returns a copy of dict without the skip key.
+func (c *objectCopier) copyDict(dict *parser.Dictionary, skip string) *parser.Dictionary {
+	copied := parser.NewDictionaryWithCapacity(dict.Len())
+	for _, key := range dict.Keys() {
+		if key != skip {
+			copied.Set(key, c.copy(dict.Get(key)))
+		}
+	}
+	return copied
+}
+
+// drain copies every queued object, including the objects they reference.
+func (c *objectCopier) drain() ([]*writer.IndirectObject, error) {
+	var objects []*writer.IndirectObject
+	for len(c.queue) > 0 {
+		pending := c.queue[0]
+		c.queue = c.queue[1:]
+
+		src := pending.src
+		if src == nil {
+			obj, err := c.reader.GetObject(pending.oldNum)
+			if err != nil {
+				// Missing objects are written as null, as readers treat
+				// references to missing objects.
+				obj = parser.NewNull()
+			}
+			src = obj
+		}
+
+		copied := c.copyObject(src)
+
+		if inherited, ok := c.pages[pending.oldNum]; ok && pending.src == nil {
+			if page, ok := copied.(*parser.Dictionary); ok {
+				for _, key := range inheritableKeys {
+					if value, ok := inherited[key]; ok && !page.Has(key) {
+						page.Set(key, c.copy(value))
+					}
+				}
+				page.Set("Parent", parser.NewIndirectReference(2, 0))
+			}
+		}
+
+		data, err := c.serialize(copied, pending.num)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, writer.NewIndirectObject(pending.num, 0, data))
+	}
+
+	// Objects are numbered in the order they were first referenced, which
+	// is also the order they were queued.
+	return objects, nil
+}
+
+// serialize returns the PDF syntax for copied object num, encrypted if
+// the copier encrypts.
+func (c *objectCopier) serialize(obj parser.PdfObject, num int) ([]byte, error) {
+	if c.encryption != nil {
+		var err error
+		if obj, err = c.encryption.Encrypt(obj, num); err != nil {
+			return nil, fmt.Errorf("failed to encrypt object %d: %w", num, err)
+		}
+	}
+	return serializeObject(obj)
+}
+
+// serializeObject returns the PDF syntax for obj.
+func serializeObject(obj parser.PdfObject) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := obj.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to serialize object: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,294 @@
+package gxpdf_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf"
+	"github.com/coregx/gxpdf/creator"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// writeTestPDF creates a PDF with one page per label, each showing its label.
+func writeTestPDF(t *testing.T, labels ...string) string {
+	t.Helper()
+
+	c := creator.New()
+	for _, label := range labels {
+		page, err := c.NewPage()
+		if err != nil {
+			t.Fatalf("NewPage() error = %v", err)
+		}
+		if err := page.AddText(label, 100, 700, creator.Helvetica, 24); err != nil {
+			t.Fatalf("AddText() error = %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "input.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	return path
+}
+
+// pageTexts returns the trimmed text of every page.
+func pageTexts(t *testing.T, doc *gxpdf.Document) []string {
+	t.Helper()
+
+	var texts []string
+	for _, page := range doc.Pages() {
+		text, err := page.ExtractText()
+		if err != nil {
+			t.Fatalf("ExtractText() error = %v", err)
+		}
+		texts = append(texts, strings.TrimSpace(text))
+	}
+	return texts
+}
+
+func assertPageTexts(t *testing.T, doc *gxpdf.Document, want ...string) {
+	t.Helper()
+
+	got := pageTexts(t, doc)
+	if len(got) != len(want) {
+		t.Fatalf("pages = %q, want %q", got, want)
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("page %d text = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// savedObjects parses the PDF at path and returns the number of page
+// objects, the decoded data of every stream and the base font names.
+func savedObjects(t *testing.T, path string) (pages int, streams, fonts []string) {
+	t.Helper()
+
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for num, entry := range r.XRefTable().Entries {
+		if entry.IsFree() {
+			continue
+		}
+		obj, err := r.GetObject(num)
+		if err != nil {
+			t.Fatalf("GetObject(%d) error = %v", num, err)
+		}
+		switch v := obj.(type) {
+		case *parser.Stream:
+			data, err := r.DecodeStream(v)
+			if err != nil {
+				t.Fatalf("DecodeStream(%d) error = %v", num, err)
+			}
+			streams = append(streams, string(data))
+		case *parser.Dictionary:
+			switch typ := v.GetName("Type"); {
+			case typ == nil:
+			case typ.Value() == "Page":
+				pages++
+			case typ.Value() == "Font":
+				if name := v.GetName("BaseFont"); name != nil {
+					fonts = append(fonts, name.Value())
+				}
+			}
+		}
+	}
+	return pages, streams, fonts
+}
+
+// streamsContaining returns the number of streams that contain s.
+func streamsContaining(streams []string, s string) int {
+	n := 0
+	for _, data := range streams {
+		if strings.Contains(data, s) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDocument_RemovePageAndSave(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Alpha", "Bravo", "Charlie"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	if err := doc.RemovePage(1); err != nil {
+		t.Fatalf("RemovePage() error = %v", err)
+	}
+	assertPageTexts(t, doc, "Alpha", "Charlie")
+
+	out := filepath.Join(t.TempDir(), "removed.pdf")
+	if err := doc.Save(out); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := gxpdf.Open(out)
+	if err != nil {
+		t.Fatalf("Open(saved) error = %v", err)
+	}
+	defer saved.Close()
+
+	assertPageTexts(t, saved, "Alpha", "Charlie")
+
+	pages, streams, _ := savedObjects(t, out)
+	if pages != 2 {
+		t.Errorf("saved file has %d page objects, want 2", pages)
+	}
+	if streamsContaining(streams, "Alpha") != 1 || streamsContaining(streams, "Charlie") != 1 {
+		t.Error("saved file lost the content of a kept page")
+	}
+	if streamsContaining(streams, "Bravo") != 0 {
+		t.Error("saved file still contains the removed page's content")
+	}
+}
+
+func TestDocument_MovePageAndSave(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Alpha", "Bravo", "Charlie"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	if err := doc.MovePage(2, 0); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+	assertPageTexts(t, doc, "Charlie", "Alpha", "Bravo")
+
+	if err := doc.MovePage(0, 2); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+	assertPageTexts(t, doc, "Alpha", "Bravo", "Charlie")
+
+	if err := doc.MovePage(0, 1); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+
+	out := filepath.Join(t.TempDir(), "moved.pdf")
+	if err := doc.Save(out); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := gxpdf.Open(out)
+	if err != nil {
+		t.Fatalf("Open(saved) error = %v", err)
+	}
+	defer saved.Close()
+
+	assertPageTexts(t, saved, "Bravo", "Alpha", "Charlie")
+}
+
+func TestDocument_PageEditErrors(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Alpha", "Bravo"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	for name, err := range map[string]error{
+		"remove negative": doc.RemovePage(-1),
+		"remove past end": doc.RemovePage(2),
+		"move from":       doc.MovePage(5, 0),
+		"move to":         doc.MovePage(0, 2),
+	} {
+		if !errors.Is(err, gxpdf.ErrPageNotFound) {
+			t.Errorf("%s: error = %v, want ErrPageNotFound", name, err)
+		}
+	}
+	if doc.PageCount() != 2 {
+		t.Errorf("PageCount() = %d after failed edits, want 2", doc.PageCount())
+	}
+}
+
+func TestDocument_ExtractPages(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Alpha", "Bravo", "Charlie", "Delta", "Echo"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	extracted, err := doc.ExtractPages(gxpdf.PageRange{Start: 2, End: 3})
+	if err != nil {
+		t.Fatalf("ExtractPages() error = %v", err)
+	}
+	defer extracted.Close()
+
+	assertPageTexts(t, extracted, "Bravo", "Charlie")
+	assertPageTexts(t, doc, "Alpha", "Bravo", "Charlie", "Delta", "Echo")
+
+	out := filepath.Join(t.TempDir(), "extracted.pdf")
+	if err := extracted.Save(out); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := gxpdf.Open(out)
+	if err != nil {
+		t.Fatalf("Open(saved) error = %v", err)
+	}
+	defer saved.Close()
+
+	if saved.PageCount() != 2 {
+		t.Fatalf("PageCount() = %d, want 2", saved.PageCount())
+	}
+	assertPageTexts(t, saved, "Bravo", "Charlie")
+
+	pages, streams, fonts := savedObjects(t, out)
+	if pages != 2 {
+		t.Errorf("saved file has %d page objects, want 2", pages)
+	}
+	for _, kept := range []string{"Bravo", "Charlie"} {
+		if n := streamsContaining(streams, kept); n != 1 {
+			t.Errorf("%d streams contain %q, want 1", n, kept)
+		}
+	}
+	for _, dropped := range []string{"Alpha", "Delta", "Echo"} {
+		if streamsContaining(streams, dropped) != 0 {
+			t.Errorf("saved file still contains the content of page %q", dropped)
+		}
+	}
+	// The font shared with the dropped pages is still there.
+	if len(fonts) != 1 || fonts[0] != "Helvetica" {
+		t.Errorf("fonts = %q, want the shared Helvetica", fonts)
+	}
+}
+
+func TestDocument_ExtractPages_Ranges(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Alpha", "Bravo", "Charlie", "Delta", "Echo"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	// Ranges compose in the order given, on top of earlier edits.
+	if err := doc.RemovePage(0); err != nil {
+		t.Fatalf("RemovePage() error = %v", err)
+	}
+	extracted, err := doc.ExtractPages(gxpdf.PageRange{Start: 4, End: 4}, gxpdf.PageRange{Start: 1, End: 2})
+	if err != nil {
+		t.Fatalf("ExtractPages() error = %v", err)
+	}
+	defer extracted.Close()
+	assertPageTexts(t, extracted, "Echo", "Bravo", "Charlie")
+
+	for name, ranges := range map[string][]gxpdf.PageRange{
+		"none":     nil,
+		"zero":     {{Start: 0, End: 1}},
+		"past end": {{Start: 3, End: 5}},
+		"reversed": {{Start: 3, End: 2}},
+	} {
+		if _, err := doc.ExtractPages(ranges...); !errors.Is(err, gxpdf.ErrPageNotFound) {
+			t.Errorf("%s: error = %v, want ErrPageNotFound", name, err)
+		}
+	}
+	if _, err := doc.ExtractPages(gxpdf.PageRange{Start: 1, End: 2}, gxpdf.PageRange{Start: 2, End: 3}); err == nil {
+		t.Error("overlapping ranges: expected an error")
+	}
+}
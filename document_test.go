@@ -0,0 +1,74 @@
+package gxpdf_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coregx/gxpdf"
+)
+
+func TestDocument_Info(t *testing.T) {
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "info.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	info := doc.Info()
+	for _, tt := range []struct {
+		field, got, want string
+	}{
+		{"Title", info.Title, "Rapport annuel — 年次報告 📄"}, // UTF-16BE
+		{"Author", info.Author, "Jane (J.) Doe"},
+		{"Subject", info.Subject, "Annual report — 2024"}, // PDFDocEncoding
+		{"Keywords", info.Keywords, "finance, report"},    // Hex string
+		{"Creator", info.Creator, "gxpdf test generator"},
+		{"Producer", info.Producer, "gxpdf"}, // Indirect
+		{"Version", info.Version, "1.4"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("Info().%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+	if doc.Title() != info.Title {
+		t.Errorf("Title() = %q, want %q", doc.Title(), info.Title)
+	}
+
+	creation := time.Date(2024, 3, 15, 4, 0, 0, 0, time.UTC)
+	if !info.CreationDate.Equal(creation) {
+		t.Errorf("Info().CreationDate = %v, want %v", info.CreationDate, creation)
+	}
+	if _, offset := info.CreationDate.Zone(); offset != 5*3600+30*60 {
+		t.Errorf("Info().CreationDate offset = %d, want +05:30", offset)
+	}
+	modified := time.Date(2024, 12, 1, 20, 0, 0, 0, time.UTC)
+	if !info.ModDate.Equal(modified) {
+		t.Errorf("Info().ModDate = %v, want %v", info.ModDate, modified)
+	}
+
+	xmp, err := doc.Metadata()
+	if err != nil {
+		t.Fatalf("Metadata() error = %v", err)
+	}
+	if !bytes.HasPrefix(xmp, []byte("<?xpacket begin=")) || !bytes.Contains(xmp, []byte("年次報告")) {
+		t.Errorf("Metadata() = %q, want the decoded XMP packet", xmp)
+	}
+}
+
+func TestDocument_Info_Missing(t *testing.T) {
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "minimal.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	info := doc.Info()
+	if !info.CreationDate.IsZero() || !info.ModDate.IsZero() {
+		t.Errorf("Info() dates = %v, %v; want zero", info.CreationDate, info.ModDate)
+	}
+	if xmp, err := doc.Metadata(); xmp != nil || err != nil {
+		t.Errorf("Metadata() = %q, %v; want nil, nil", xmp, err)
+	}
+}
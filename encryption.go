@@ -0,0 +1,188 @@
+package gxpdf
+
+import (
+	"fmt"
+
+	"github.com/coregx/gxpdf/creator"
+	"github.com/coregx/gxpdf/internal/parser"
+	"github.com/coregx/gxpdf/internal/security"
+	"github.com/coregx/gxpdf/internal/writer"
+)
+
+// EncryptionInfo describes how a document is encrypted.
+//
+// It is read from the encryption dictionary, which is not itself
+// encrypted, so it is available without a password.
+type EncryptionInfo struct {
+	// Algorithm is the encryption algorithm, in the terms used by
+	// creator.SetEncryption.
+	Algorithm creator.EncryptionAlgorithm
+
+	// Permissions are the user access permissions (/P).
+	Permissions creator.Permission
+
+	// Version is the algorithm version of the encryption dictionary (/V).
+	Version int
+
+	// Revision is the standard security handler revision (/R).
+	Revision int
+
+	// KeyLength is the encryption key length in bits.
+	KeyLength int
+
+	// EncryptMetadata reports whether the metadata stream is encrypted.
+	EncryptMetadata bool
+}
+
+// Encryption returns the encryption settings of the document, or nil if
+// the document is not encrypted.
+//
+// Save keeps the encryption of the document as it is. Use Options to
+// re-apply the same encryption and permissions with new passwords, through
+// SaveEncrypted or to a document built from this one with the creator
+// package, so processing an encrypted source does not silently produce an
+// unprotected copy.
+//
+// Returns ErrUnsupportedFeature for security handlers other than the
+// Standard security handler.
+//
+// Example:
+//
+//	enc, err := doc.Encryption()
+//	if err != nil {
+//	    return err
+//	}
+//	if enc != nil {
+//	    err = c.SetEncryption(enc.Options(userPassword, ownerPassword))
+//	}
+//
+// Reference: PDF 1.7 Specification, Section 7.6.3 (Standard Security Handler).
+func (d *Document) Encryption() (*EncryptionInfo, error) {
+	info := d.reader.GetEncryptInfo()
+	if info == nil {
+		return nil, nil
+	}
+	if info.Filter != "Standard" {
+		return nil, fmt.Errorf("%w: security handler %q", ErrUnsupportedFeature, info.Filter)
+	}
+
+	algorithm, err := encryptionAlgorithm(info.V, info.Length, info.CFM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptionInfo{
+		Algorithm:       algorithm,
+		Permissions:     creator.Permission(info.P) & creator.PermissionAll,
+		Version:         info.V,
+		Revision:        info.R,
+		KeyLength:       info.Length,
+		EncryptMetadata: info.EncryptMetadata,
+	}, nil
+}
+
+// Permissions returns the user access permissions of the document.
+// Unencrypted documents allow everything.
+func (d *Document) Permissions() creator.Permission {
+	enc, err := d.Encryption()
+	if err != nil || enc == nil {
+		return creator.PermissionAll
+	}
+	return enc.Permissions
+}
+
+// Options returns encryption options that re-apply these settings with the
+// given passwords, for SaveEncrypted or creator.SetEncryption. The
+// passwords of the source document cannot be recovered from the file, so
+// the caller supplies them.
+func (e *EncryptionInfo) Options(userPassword, ownerPassword string) creator.EncryptionOptions {
+	return creator.EncryptionOptions{
+		UserPassword:  userPassword,
+		OwnerPassword: ownerPassword,
+		Permissions:   e.Permissions,
+		Algorithm:     e.Algorithm,
+	}
+}
+
+// encryptionAlgorithm maps an encryption dictionary's algorithm version,
+// key length and stream crypt filter method to an algorithm.
+func encryptionAlgorithm(v, length int, cfm string) (creator.EncryptionAlgorithm, error) {
+	switch {
+	case v == 1:
+		return creator.EncryptionRC4_40, nil
+	case v == 2 && length <= 40:
+		return creator.EncryptionRC4_40, nil
+	case v == 2:
+		return creator.EncryptionRC4_128, nil
+	case v == 4 && cfm == "AESV2":
+		return creator.EncryptionAES128, nil
+	case v == 4 && cfm == "V2":
+		return creator.EncryptionRC4_128, nil
+	case v == 5:
+		return creator.EncryptionAES256, nil
+	default:
+		return 0, fmt.Errorf("%w: encryption /V %d (%s)", ErrUnsupportedFeature, v, cfm)
+	}
+}
+
+// SaveEncrypted writes the document like Save, encrypted with opts.
+//
+// opts.Algorithm is used as given (the zero value is EncryptionRC4_40);
+// opts.KeyLength is ignored. The document may be encrypted already, as
+// long as it opened without a password: its content is re-encrypted with
+// the new passwords, permissions and algorithm.
+//
+// Example:
+//
+//	doc, _ := gxpdf.Open("protected.pdf")
+//	defer doc.Close()
+//
+//	enc, _ := doc.Encryption()
+//	_ = doc.RemovePage(0)
+//	err := doc.SaveEncrypted("out.pdf", enc.Options("", ownerPassword))
+//
+// Reference: PDF 1.7 Specification, Section 7.6 (Encryption).
+func (d *Document) SaveEncrypted(path string, opts creator.EncryptionOptions) error {
+	if d.IsEncrypted() && d.reader.Encryptor() == nil {
+		return fmt.Errorf("%w: saving encrypted documents that need a password", ErrUnsupportedFeature)
+	}
+	enc, err := writer.NewEncryption(security.StandardEncryptionConfig{
+		UserPassword:  opts.UserPassword,
+		OwnerPassword: opts.OwnerPassword,
+		Permissions:   writer.PDFPermissions(opts.Permissions),
+		Algorithm:     opts.Algorithm,
+	})
+	if err != nil {
+		return fmt.Errorf("gxpdf: failed to save %s: %w", path, err)
+	}
+	return d.save(path, enc)
+}
+
+// originalEncryption returns the encryption of the document itself: its
+// encryption dictionary and /ID, with its file key.
+func (d *Document) originalEncryption() (*writer.Encryption, error) {
+	encryptor := d.reader.Encryptor()
+	if encryptor == nil {
+		return nil, fmt.Errorf("%w: saving encrypted documents that need a password", ErrUnsupportedFeature)
+	}
+	trailer := d.reader.Trailer()
+	dict, ok := d.reader.ResolveReferences(trailer.Get("Encrypt")).(*parser.Dictionary)
+	if !ok {
+		return nil, fmt.Errorf("%w: encryption dictionary", ErrUnsupportedFeature)
+	}
+	ids := trailer.GetArray("ID")
+	if ids == nil || ids.Len() == 0 {
+		return nil, fmt.Errorf("%w: encrypted document without /ID", ErrUnsupportedFeature)
+	}
+	id, err := serializeObject(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writer.Encryption{
+		Encryptor: encryptor,
+		Dict:      dict,
+		ID:        string(id),
+		Metadata:  d.reader.GetEncryptInfo().EncryptMetadata,
+	}, nil
+}
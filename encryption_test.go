@@ -0,0 +1,381 @@
+package gxpdf_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf"
+	"github.com/coregx/gxpdf/creator"
+)
+
+// writeEncryptedPDF writes a one-page PDF whose trailer references the
+// given encryption dictionary. The page has no content to decrypt.
+func writeEncryptedPDF(t *testing.T, encrypt string) string {
+	t.Helper()
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+		encrypt,
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Encrypt 4 0 R /ID [<0123456789abcdef0123456789abcdef> <0123456789abcdef0123456789abcdef>] >>\n", len(objects)+1)
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xref)
+
+	path := filepath.Join(t.TempDir(), "encrypted.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestDocument_Encryption(t *testing.T) {
+	const hash = "<" + "00000000000000000000000000000000" + "00000000000000000000000000000000" + ">"
+	tests := []struct {
+		name      string
+		encrypt   string
+		algorithm creator.EncryptionAlgorithm
+		keyLength int
+	}{
+		{
+			name:      "RC4 40-bit",
+			encrypt:   "<< /Filter /Standard /V 1 /R 2 /O " + hash + " /U " + hash + " /P -3372 >>",
+			algorithm: creator.EncryptionRC4_40,
+			keyLength: 40,
+		},
+		{
+			name:      "RC4 128-bit",
+			encrypt:   "<< /Filter /Standard /V 2 /R 3 /Length 128 /O " + hash + " /U " + hash + " /P -3372 >>",
+			algorithm: creator.EncryptionRC4_128,
+			keyLength: 128,
+		},
+		{
+			name: "AES 128-bit",
+			encrypt: "<< /Filter /Standard /V 4 /R 4 /CF << /StdCF << /CFM /AESV2 /Length 16 >> >>" +
+				" /StmF /StdCF /StrF /StdCF /O " + hash + " /U " + hash + " /P -3372 >>",
+			algorithm: creator.EncryptionAES128,
+			keyLength: 128,
+		},
+		{
+			name: "AES 256-bit",
+			encrypt: "<< /Filter /Standard /V 5 /R 6 /Length 256 /CF << /StdCF << /CFM /AESV3 /Length 32 >> >>" +
+				" /StmF /StdCF /StrF /StdCF /O " + hash + " /U " + hash + " /P -3372 >>",
+			algorithm: creator.EncryptionAES256,
+			keyLength: 256,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := gxpdf.Open(writeEncryptedPDF(t, tt.encrypt))
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer doc.Close()
+
+			enc, err := doc.Encryption()
+			if err != nil {
+				t.Fatalf("Encryption() error = %v", err)
+			}
+			if enc == nil {
+				t.Fatal("Encryption() = nil for encrypted document")
+			}
+			if enc.Algorithm != tt.algorithm {
+				t.Errorf("Algorithm = %v, want %v", enc.Algorithm, tt.algorithm)
+			}
+			if enc.KeyLength != tt.keyLength {
+				t.Errorf("KeyLength = %d, want %d", enc.KeyLength, tt.keyLength)
+			}
+
+			// -3372 allows printing and copying, denies modifying and annotating.
+			perms := doc.Permissions()
+			if !perms.Has(creator.PermissionPrint) || !perms.Has(creator.PermissionCopy) {
+				t.Errorf("Permissions = %v, want print and copy", perms)
+			}
+			if perms.Has(creator.PermissionModify) || perms.Has(creator.PermissionAnnotate) {
+				t.Errorf("Permissions = %v, want no modify or annotate", perms)
+			}
+
+			opts := enc.Options("user", "owner")
+			if opts.Algorithm != tt.algorithm || opts.Permissions != perms || opts.OwnerPassword != "owner" {
+				t.Errorf("Options() = %+v", opts)
+			}
+			if err := creator.New().SetEncryption(opts); err != nil {
+				t.Errorf("SetEncryption(Options()) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDocument_Encryption_Unencrypted(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Plain"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	enc, err := doc.Encryption()
+	if err != nil || enc != nil {
+		t.Errorf("Encryption() = %v, %v; want nil, nil", enc, err)
+	}
+	if doc.Permissions() != creator.PermissionAll {
+		t.Errorf("Permissions() = %v, want all", doc.Permissions())
+	}
+}
+
+func TestDocument_Encryption_UnsupportedHandler(t *testing.T) {
+	doc, err := gxpdf.Open(writeEncryptedPDF(t, "<< /Filter /Adobe.PubSec /V 4 /R 4 >>"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	if _, err := doc.Encryption(); !errors.Is(err, gxpdf.ErrUnsupportedFeature) {
+		t.Errorf("Encryption() error = %v, want ErrUnsupportedFeature", err)
+	}
+}
+
+func TestDocument_Encryption_EmptyUserPassword(t *testing.T) {
+	for _, name := range []string{"encrypted_rc4.pdf", "encrypted_aes128.pdf", "encrypted_aes256.pdf"} {
+		t.Run(name, func(t *testing.T) {
+			doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", name))
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer doc.Close()
+
+			if doc.PageCount() != 1 {
+				t.Errorf("PageCount() = %d, want 1", doc.PageCount())
+			}
+			if got, err := doc.Page(0).ExtractText(); err != nil || got != "Encrypted Hello" {
+				t.Errorf("ExtractText() = %q, %v; want %q", got, err, "Encrypted Hello")
+			}
+			if got := doc.Title(); got != "Secret Report" {
+				t.Errorf("Title() = %q, want %q", got, "Secret Report")
+			}
+		})
+	}
+}
+
+func TestDocument_Save_KeepsEncryption(t *testing.T) {
+	for _, name := range []string{"encrypted_rc4.pdf", "encrypted_aes128.pdf", "encrypted_aes256.pdf"} {
+		t.Run(name, func(t *testing.T) {
+			doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", name))
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer doc.Close()
+			want, err := doc.Encryption()
+			if err != nil {
+				t.Fatalf("Encryption() error = %v", err)
+			}
+
+			out := filepath.Join(t.TempDir(), "saved.pdf")
+			if err := doc.Save(out); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			checkEncryptedCopy(t, out, want)
+
+			// Extracted pages are saved the same way.
+			extracted, err := doc.ExtractPages(gxpdf.PageRange{Start: 1, End: 1})
+			if err != nil {
+				t.Fatalf("ExtractPages() error = %v", err)
+			}
+			defer extracted.Close()
+			out = filepath.Join(t.TempDir(), "extracted.pdf")
+			if err := extracted.Save(out); err != nil {
+				t.Fatalf("ExtractPages().Save() error = %v", err)
+			}
+			checkEncryptedCopy(t, out, want)
+		})
+	}
+}
+
+func TestDocument_SaveEncrypted(t *testing.T) {
+	for _, algorithm := range []creator.EncryptionAlgorithm{
+		creator.EncryptionRC4_40, creator.EncryptionRC4_128, creator.EncryptionAES128, creator.EncryptionAES256,
+	} {
+		t.Run(fmt.Sprint(algorithm), func(t *testing.T) {
+			// Re-encrypt an encrypted source with other settings.
+			doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "encrypted_aes128.pdf"))
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer doc.Close()
+
+			opts := creator.EncryptionOptions{
+				OwnerPassword: "owner",
+				Permissions:   creator.PermissionPrint | creator.PermissionCopy,
+				Algorithm:     algorithm,
+			}
+			out := filepath.Join(t.TempDir(), "encrypted.pdf")
+			if err := doc.SaveEncrypted(out, opts); err != nil {
+				t.Fatalf("SaveEncrypted() error = %v", err)
+			}
+			checkEncryptedCopy(t, out, &gxpdf.EncryptionInfo{Algorithm: algorithm, Permissions: opts.Permissions})
+		})
+	}
+}
+
+func TestDocument_SaveEncrypted_UserPassword(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "Plain"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	out := filepath.Join(t.TempDir(), "protected.pdf")
+	opts := creator.EncryptionOptions{UserPassword: "secret", Algorithm: creator.EncryptionAES256}
+	if err := doc.SaveEncrypted(out, opts); err != nil {
+		t.Fatalf("SaveEncrypted() error = %v", err)
+	}
+
+	// The copy needs the password, so it cannot be decrypted or saved.
+	protected, err := gxpdf.Open(out)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer protected.Close()
+	if enc, err := protected.Encryption(); err != nil || enc == nil || enc.Algorithm != creator.EncryptionAES256 {
+		t.Fatalf("Encryption() = %+v, %v; want AES-256", enc, err)
+	}
+	if err := protected.Save(filepath.Join(t.TempDir(), "again.pdf")); !errors.Is(err, gxpdf.ErrUnsupportedFeature) {
+		t.Errorf("Save() error = %v, want ErrUnsupportedFeature", err)
+	}
+}
+
+func TestCreator_SetPermissions_Encrypts(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		write func(c *creator.Creator, path string) error
+	}{
+		{"WriteToFile", func(c *creator.Creator, path string) error { return c.WriteToFile(path) }},
+		{"WriteTo", func(c *creator.Creator, path string) error {
+			var buf bytes.Buffer
+			if _, err := c.WriteTo(&buf); err != nil {
+				return err
+			}
+			return os.WriteFile(path, buf.Bytes(), 0o600)
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := creator.New()
+			c.SetTitle("Secret Report")
+			page, err := c.NewPage()
+			if err != nil {
+				t.Fatalf("NewPage() error = %v", err)
+			}
+			if err := page.AddText("Encrypted Hello", 100, 700, creator.Helvetica, 12); err != nil {
+				t.Fatalf("AddText() error = %v", err)
+			}
+			if err := c.SetPermissions(creator.Permissions{AllowPrint: true}); err != nil {
+				t.Fatalf("SetPermissions() error = %v", err)
+			}
+
+			out := filepath.Join(t.TempDir(), "restricted.pdf")
+			if err := tt.write(c, out); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			checkEncryptedCopy(t, out, &gxpdf.EncryptionInfo{
+				Algorithm:   creator.EncryptionAES128,
+				Permissions: creator.PermissionExtract | creator.PermissionPrint,
+			})
+		})
+	}
+}
+
+func TestEncryptionInfo_Options_Creator(t *testing.T) {
+	// A document rebuilt with the creator package keeps the source's
+	// encryption through Options and SetEncryption.
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "encrypted_aes256.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+	enc, err := doc.Encryption()
+	if err != nil || enc == nil {
+		t.Fatalf("Encryption() = %v, %v; want encrypted", enc, err)
+	}
+
+	c := creator.New()
+	c.SetTitle(doc.Title())
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	text, err := doc.Page(0).ExtractText()
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if err := page.AddText(text, 100, 700, creator.Helvetica, 12); err != nil {
+		t.Fatalf("AddText() error = %v", err)
+	}
+	if err := c.SetEncryption(enc.Options("", "owner")); err != nil {
+		t.Fatalf("SetEncryption() error = %v", err)
+	}
+
+	out := filepath.Join(t.TempDir(), "rebuilt.pdf")
+	if err := c.WriteToFile(out); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	checkEncryptedCopy(t, out, enc)
+}
+
+// checkEncryptedCopy checks that the PDF at path is encrypted as want and
+// still opens with the empty user password to the fixture's content.
+func checkEncryptedCopy(t *testing.T, path string, want *gxpdf.EncryptionInfo) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Contains(data, []byte("/Encrypt")) {
+		t.Errorf("%s has no /Encrypt entry", filepath.Base(path))
+	}
+	if bytes.Contains(data, []byte("Secret Report")) || bytes.Contains(data, []byte("Encrypted Hello")) {
+		t.Errorf("%s has text in clear", filepath.Base(path))
+	}
+
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%s) error = %v", filepath.Base(path), err)
+	}
+	defer doc.Close()
+
+	enc, err := doc.Encryption()
+	if err != nil || enc == nil {
+		t.Fatalf("Encryption() = %v, %v; want encrypted", enc, err)
+	}
+	if enc.Algorithm != want.Algorithm || enc.Permissions != want.Permissions {
+		t.Errorf("Encryption() = %v, %v; want %v, %v", enc.Algorithm, enc.Permissions, want.Algorithm, want.Permissions)
+	}
+
+	text, err := doc.Page(0).ExtractText()
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if text != "Encrypted Hello" {
+		t.Errorf("ExtractText() = %q, want %q", text, "Encrypted Hello")
+	}
+	if doc.Title() != "Secret Report" {
+		t.Errorf("Title() = %q, want %q", doc.Title(), "Secret Report")
+	}
+}
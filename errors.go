@@ -24,6 +24,10 @@ var (
 
 	// ErrUnsupportedFeature is returned for PDF features not yet implemented.
 	ErrUnsupportedFeature = errors.New("gxpdf: unsupported PDF feature")
+
+	// ErrNoChecksum is returned by VerifyChecksum when the file has no
+	// embedded checksum.
+	ErrNoChecksum = errors.New("gxpdf: no document checksum")
 )
 
 // IsEncrypted returns true if the error indicates an encrypted PDF.
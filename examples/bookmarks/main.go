@@ -6,7 +6,6 @@
 //   - Section 1.2
 //
 // - Chapter 2
-//   - Section 2.1
 package main
 
 import (
@@ -50,18 +49,10 @@ func main() {
 	addChapter2Content(page4)
 
 	// Add bookmarks (hierarchical structure).
-	if err := c.AddBookmark("Chapter 1", 0, 0); err != nil {
-		log.Fatal(err)
-	}
-	if err := c.AddBookmark("Section 1.1", 1, 1); err != nil {
-		log.Fatal(err)
-	}
-	if err := c.AddBookmark("Section 1.2", 2, 1); err != nil {
-		log.Fatal(err)
-	}
-	if err := c.AddBookmark("Chapter 2", 3, 0); err != nil {
-		log.Fatal(err)
-	}
+	chapter1 := c.AddBookmark("Chapter 1", 0, 0)
+	chapter1.AddChild("Section 1.1", 1, 0)
+	chapter1.AddChild("Section 1.2", 2, 0)
+	c.AddBookmark("Chapter 2", 3, 0)
 
 	// Verify bookmarks were added.
 	bookmarks := c.Bookmarks()
@@ -82,7 +73,6 @@ func main() {
 	}
 
 	fmt.Printf("\nPDF created successfully: %s\n", outputPath)
-	fmt.Println("Note: Bookmark rendering in PDF will be implemented in Phase 3 (Writer)")
 }
 
 func addChapter1Content(page *creator.Page) {
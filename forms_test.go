@@ -0,0 +1,67 @@
+package gxpdf_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf"
+	"github.com/coregx/gxpdf/creator"
+	"github.com/coregx/gxpdf/creator/forms"
+)
+
+func TestDocument_FormFields_RoundTrip(t *testing.T) {
+	c := creator.New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	name := forms.NewTextField("name", 100, 700, 200, 20)
+	name.SetValue("Jane (Doe)")
+	if err := page.AddField(name); err != nil {
+		t.Fatalf("AddField(text field) error = %v", err)
+	}
+	agree := forms.NewCheckbox("agree", 100, 650, 15, 15)
+	agree.SetChecked(true)
+	if err := page.AddField(agree); err != nil {
+		t.Fatalf("AddField(checkbox) error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "form.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	if !doc.HasForm() {
+		t.Fatal("HasForm() = false, want true")
+	}
+	fields, err := doc.GetFormFields()
+	if err != nil {
+		t.Fatalf("GetFormFields() error = %v", err)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("GetFormFields() returned %d fields, want 2", len(fields))
+	}
+	if !fields[0].IsTextField() || fields[0].Name() != "name" {
+		t.Errorf("field 0 = %s (%s), want text field name", fields[0].Name(), fields[0].Type())
+	}
+	if !fields[1].IsButton() || fields[1].Name() != "agree" {
+		t.Errorf("field 1 = %s (%s), want button agree", fields[1].Name(), fields[1].Type())
+	}
+
+	value, err := doc.GetFieldValue("name")
+	if err != nil {
+		t.Fatalf("GetFieldValue(name) error = %v", err)
+	}
+	if value != "Jane (Doe)" {
+		t.Errorf("GetFieldValue(name) = %v, want %q", value, "Jane (Doe)")
+	}
+	if value, err := doc.GetFieldValue("agree"); err != nil || value != "Yes" {
+		t.Errorf("GetFieldValue(agree) = %v, %v, want Yes", value, err)
+	}
+}
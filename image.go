@@ -96,3 +96,34 @@ func (img *Image) ToGoImage() (image.Image, error) {
 func (img *Image) String() string {
 	return img.internal.String()
 }
+
+// ExtractedImage is an image drawn on a page, as returned by Page.Images,
+// with its data and where it is placed.
+//
+// Coordinates are in PDF user space: points from the bottom-left corner
+// of the page, with Y increasing upward.
+type ExtractedImage struct {
+	Name             string // XObject resource name (e.g., "Im1"); empty for inline images
+	Inline           bool   // Image is embedded in the content stream (BI ... EI)
+	Width            int    // Width in pixels
+	Height           int    // Height in pixels
+	BitsPerComponent int    // Bits per color component (1 for image masks)
+	ColorSpace       string // Color space family (e.g., "DeviceRGB", "ICCBased"); empty for image masks
+
+	// Filter is the filter still applied to Data: "DCTDecode" when Data
+	// holds a complete JPEG file, or another image filter such as
+	// "JPXDecode" or "CCITTFaxDecode" that is not decoded. Empty when Data
+	// holds the raw samples, row by row, after FlateDecode, LZWDecode and
+	// ASCII85Decode have been undone.
+	Filter string
+
+	Data    []byte // Decoded data (see Filter)
+	Encoded []byte // Data as stored in the PDF, before any filter is undone
+
+	// CTM is the current transformation matrix [a b c d e f] when the
+	// image is drawn. Images are drawn into the unit square, so the image
+	// covers the parallelogram with corners at CTM applied to (0, 0),
+	// (1, 0), (0, 1) and (1, 1); for an unrotated image, (e, f) is its
+	// bottom-left corner and a and d are its width and height in points.
+	CTM [6]float64
+}
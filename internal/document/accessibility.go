@@ -0,0 +1,27 @@
+package document
+
+// SetLanguage sets the natural language of the document's text (the
+// catalog /Lang entry), as a BCP 47 language tag such as "en-US".
+// An empty string omits /Lang.
+//
+// Reference: PDF 1.7 Specification, Section 14.9.2 (Natural Language Specification).
+func (d *Document) SetLanguage(lang string) {
+	d.language = lang
+}
+
+// Language returns the natural language of the document's text.
+func (d *Document) Language() string {
+	return d.language
+}
+
+// SetTagged marks the document as a tagged PDF (/MarkInfo << /Marked true >>).
+//
+// Reference: PDF 1.7 Specification, Section 14.8 (Tagged PDF).
+func (d *Document) SetTagged(tagged bool) {
+	d.tagged = tagged
+}
+
+// Tagged reports whether the document is marked as a tagged PDF.
+func (d *Document) Tagged() bool {
+	return d.tagged
+}
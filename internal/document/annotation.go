@@ -53,8 +53,43 @@ type LinkAnnotation struct {
 	// BorderWidth is the width of the border around the clickable area.
 	// 0 = no visible border (default for most links).
 	BorderWidth float64
+
+	// BorderStyle selects a border style dictionary (/BS). Empty writes
+	// only the legacy /Border array.
+	BorderStyle BorderStyle
+
+	// BorderColor is the border color in RGB (0.0 to 1.0 range).
+	// nil leaves the color to the viewer.
+	BorderColor *[3]float64
+
+	// DashArray is the dash pattern for BorderStyleDashed, in points
+	// (e.g., [3 2] for 3pt dashes with 2pt gaps). Empty uses [3].
+	DashArray []float64
 }
 
+// BorderStyle is an annotation border style (the /S entry of /BS).
+//
+// Reference: PDF 1.7 Specification, Section 12.5.4 (Border Styles).
+type BorderStyle string
+
+// Border styles.
+const (
+	// BorderStyleSolid draws a solid rectangle around the annotation.
+	BorderStyleSolid BorderStyle = "S"
+
+	// BorderStyleDashed draws a dashed rectangle (see DashArray).
+	BorderStyleDashed BorderStyle = "D"
+
+	// BorderStyleBeveled draws an embossed rectangle.
+	BorderStyleBeveled BorderStyle = "B"
+
+	// BorderStyleInset draws an engraved rectangle.
+	BorderStyleInset BorderStyle = "I"
+
+	// BorderStyleUnderline draws a single line along the bottom edge.
+	BorderStyleUnderline BorderStyle = "U"
+)
+
 // NewLinkAnnotation creates a new URL link annotation.
 //
 // The rect parameter defines the clickable area in PDF coordinates:
@@ -108,6 +143,28 @@ func (a *LinkAnnotation) Validate() error {
 		return ErrInvalidBorderWidth
 	}
 
+	// Validate border style, color and dash pattern.
+	switch a.BorderStyle {
+	case "", BorderStyleSolid, BorderStyleDashed, BorderStyleBeveled, BorderStyleInset, BorderStyleUnderline:
+	default:
+		return ErrInvalidBorderStyle
+	}
+	if a.BorderColor != nil && !isValidColor(*a.BorderColor) {
+		return ErrInvalidColor
+	}
+	if len(a.DashArray) > 0 {
+		total := 0.0
+		for _, d := range a.DashArray {
+			if d < 0 {
+				return ErrInvalidDashArray
+			}
+			total += d
+		}
+		if total == 0 {
+			return ErrInvalidDashArray
+		}
+	}
+
 	// Validate link target based on type.
 	if a.IsInternal {
 		if a.DestPage < 0 {
@@ -389,6 +446,13 @@ var (
 	// ErrInvalidBorderWidth is returned when border width is negative.
 	ErrInvalidBorderWidth = errors.New("border width must be non-negative")
 
+	// ErrInvalidBorderStyle is returned when the border style is unknown.
+	ErrInvalidBorderStyle = errors.New("unknown border style")
+
+	// ErrInvalidDashArray is returned when a dash pattern has negative
+	// or only zero entries.
+	ErrInvalidDashArray = errors.New("dash array must be non-negative and not all zero")
+
 	// ErrInvalidDestPage is returned when internal link destination is invalid.
 	ErrInvalidDestPage = errors.New("destination page must be >= 0")
 
@@ -41,6 +41,19 @@ type Document struct {
 	// Content
 	pages []*Page
 
+	// Page labels (/PageLabels number tree), sorted by PageIndex.
+	pageLabels []PageLabelRange
+
+	// Viewer panel shown on open (/PageMode).
+	pageMode PageMode
+
+	// Trapping state (Info /Trapped).
+	trapped Trapped
+
+	// Accessibility: text language (/Lang) and tagged PDF (/MarkInfo).
+	language string
+	tagged   bool
+
 	// Behavior (Rich Domain Model)
 	// pageNumbering could be added here for custom page numbering strategies
 }
@@ -191,11 +204,22 @@ func (d *Document) Keywords() []string {
 	return result
 }
 
+// SetKeywords replaces the document keywords. An empty list clears them.
+func (d *Document) SetKeywords(keywords []string) {
+	d.keywords = append([]string(nil), keywords...)
+	d.modDate = time.Now()
+}
+
 // Version returns the PDF version.
 func (d *Document) Version() types.Version {
 	return d.version
 }
 
+// SetVersion sets the PDF version declared in the file header.
+func (d *Document) SetVersion(v types.Version) {
+	d.version = v
+}
+
 // Creator returns the creator application.
 func (d *Document) Creator() string {
 	return d.creator
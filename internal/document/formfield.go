@@ -49,6 +49,10 @@ type FormField struct {
 
 	// Choice field specific
 	options []string // Choice options
+
+	// Button field specific
+	exportValue string     // On-state appearance name (e.g. "Yes" or a radio option value)
+	parent      *FormField // Parent field for radio button widgets (nil = top-level field)
 }
 
 // NewFormField creates a new form field.
@@ -123,6 +127,14 @@ func (f *FormField) Rect() [4]float64 {
 	return f.rect
 }
 
+// Translated returns a copy of the field with its rectangle moved by
+// (dx, dy). The copy shares the parent field and option list.
+func (f *FormField) Translated(dx, dy float64) *FormField {
+	c := *f
+	c.rect = [4]float64{f.rect[0] + dx, f.rect[1] + dy, f.rect[2] + dx, f.rect[3] + dy}
+	return &c
+}
+
 // SetFlags sets the field flags (Ff).
 func (f *FormField) SetFlags(flags int) {
 	f.flags = flags
@@ -203,6 +215,50 @@ func (f *FormField) Options() []string {
 	return result
 }
 
+// SetExportValue sets the on-state name of a button widget.
+//
+// For checkboxes this is the value stored when checked (default "Yes").
+// For radio button widgets it is the option value that selects the widget.
+func (f *FormField) SetExportValue(value string) {
+	f.exportValue = value
+}
+
+// ExportValue returns the on-state name of a button widget.
+//
+// Returns "Yes" if no export value was set.
+func (f *FormField) ExportValue() string {
+	if f.exportValue == "" {
+		return "Yes"
+	}
+	return f.exportValue
+}
+
+// SetParent makes this field a widget (kid) of a parent field.
+//
+// Radio groups are represented as one parent field holding the name, flags
+// and value, with one kid widget per option. Kid widgets inherit /FT, /T,
+// /Ff and /V from the parent.
+func (f *FormField) SetParent(parent *FormField) {
+	f.parent = parent
+}
+
+// Parent returns the parent field, or nil for a top-level field.
+func (f *FormField) Parent() *FormField {
+	return f.parent
+}
+
+// IsOn reports whether a button widget is in its on state.
+//
+// The widget is on when the field value (the parent's value for radio
+// widgets) equals the widget's export value.
+func (f *FormField) IsOn() bool {
+	value := f.value
+	if f.parent != nil {
+		value = f.parent.value
+	}
+	return f.fieldType == "Btn" && value == f.ExportValue()
+}
+
 // Validate checks if the form field is valid.
 //
 // Returns an error if:
@@ -27,6 +27,8 @@ type Page struct {
 	cropBox  *types.Rectangle // Visible area (optional)
 	rotation int              // Rotation angle (0, 90, 180, 270)
 
+	cropOrigin bool // Content coordinates relative to the crop box
+
 	// Content
 	contents []content.Content // Content elements on the page
 
@@ -49,9 +51,18 @@ type Page struct {
 //
 //	page := document.NewPage(0, document.A4)
 func NewPage(number int, size PageSize) *Page {
+	return NewPageWithMediaBox(number, size.ToRectangle())
+}
+
+// NewPageWithMediaBox creates a new page with an explicit media box.
+//
+// Example:
+//
+//	page := document.NewPageWithMediaBox(0, other.MediaBox())
+func NewPageWithMediaBox(number int, mediaBox types.Rectangle) *Page {
 	return &Page{
 		number:            number,
-		mediaBox:          size.ToRectangle(),
+		mediaBox:          mediaBox,
 		rotation:          0,
 		contents:          make([]content.Content, 0),
 		linkAnnotations:   make([]*LinkAnnotation, 0),
@@ -98,6 +109,34 @@ func (p *Page) SetCropBox(box types.Rectangle) error {
 	return nil
 }
 
+// SetCropBoxOrigin makes content coordinates relative to the lower-left
+// corner of the crop box instead of the media box origin.
+//
+// Viewers display the crop box, so on pages whose crop box is offset
+// within the media box (trimmed or scanned pages) this places content at
+// the same position relative to the visible area. The writer translates
+// the content stream and annotation rectangles accordingly. It has no
+// effect on pages without a crop box.
+func (p *Page) SetCropBoxOrigin(enabled bool) {
+	p.cropOrigin = enabled
+}
+
+// CropBoxOrigin reports whether content coordinates are relative to the
+// crop box.
+func (p *Page) CropBoxOrigin() bool {
+	return p.cropOrigin
+}
+
+// ContentOrigin returns the point, in default user space, that content
+// coordinates are relative to: the lower-left corner of the crop box with
+// SetCropBoxOrigin enabled, otherwise (0, 0).
+func (p *Page) ContentOrigin() (x, y float64) {
+	if !p.cropOrigin || p.cropBox == nil {
+		return 0, 0
+	}
+	return p.cropBox.LowerLeft()
+}
+
 // SetRotation sets the page rotation (0, 90, 180, 270 degrees).
 //
 // Rotation is applied clockwise.
@@ -0,0 +1,181 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PageLabelStyle is the numbering style of a page label range.
+//
+// Values correspond to the /S entry of a page label dictionary.
+//
+// Reference: PDF 1.7 Specification, Section 12.4.2 (Page Labels).
+type PageLabelStyle string
+
+const (
+	// PageLabelNone produces labels consisting of the prefix only.
+	PageLabelNone PageLabelStyle = ""
+
+	// PageLabelDecimal uses decimal arabic numerals (1, 2, 3).
+	PageLabelDecimal PageLabelStyle = "D"
+
+	// PageLabelRomanUpper uses uppercase roman numerals (I, II, III).
+	PageLabelRomanUpper PageLabelStyle = "R"
+
+	// PageLabelRomanLower uses lowercase roman numerals (i, ii, iii).
+	PageLabelRomanLower PageLabelStyle = "r"
+
+	// PageLabelAlphaUpper uses uppercase letters (A..Z, AA..ZZ).
+	PageLabelAlphaUpper PageLabelStyle = "A"
+
+	// PageLabelAlphaLower uses lowercase letters (a..z, aa..zz).
+	PageLabelAlphaLower PageLabelStyle = "a"
+)
+
+// PageLabelRange describes the labelling of a run of pages.
+//
+// A range starts at PageIndex and extends to the page before the next
+// range's PageIndex (or the end of the document).
+//
+// Example:
+//
+//	// Front matter i, ii, iii, then body 1, 2, 3...
+//	doc.SetPageLabels([]document.PageLabelRange{
+//	    {PageIndex: 0, Style: document.PageLabelRomanLower},
+//	    {PageIndex: 3, Style: document.PageLabelDecimal},
+//	})
+type PageLabelRange struct {
+	// PageIndex is the 0-based index of the first page in the range.
+	PageIndex int
+
+	// Style is the numbering style (/S).
+	Style PageLabelStyle
+
+	// Prefix is the label prefix (/P), e.g. "A-".
+	Prefix string
+
+	// Start is the numeric value of the first page label (/St).
+	// Zero is treated as 1.
+	Start int
+}
+
+// FirstNumber returns the numeric value of the first label in the range.
+func (r PageLabelRange) FirstNumber() int {
+	if r.Start < 1 {
+		return 1
+	}
+	return r.Start
+}
+
+// SetPageLabels sets the page label ranges of the document.
+//
+// Ranges are sorted by PageIndex. Passing nil or an empty slice removes
+// all page labels.
+//
+// Returns an error if a PageIndex is negative, two ranges start on the same
+// page, or a Start value is negative.
+func (d *Document) SetPageLabels(ranges []PageLabelRange) error {
+	if len(ranges) == 0 {
+		d.pageLabels = nil
+		return nil
+	}
+
+	sorted := make([]PageLabelRange, len(ranges))
+	copy(sorted, ranges)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PageIndex < sorted[j].PageIndex
+	})
+
+	for i, r := range sorted {
+		if r.PageIndex < 0 {
+			return fmt.Errorf("%w: negative page index %d", ErrInvalidPageLabel, r.PageIndex)
+		}
+		if r.Start < 0 {
+			return fmt.Errorf("%w: negative start %d", ErrInvalidPageLabel, r.Start)
+		}
+		if i > 0 && sorted[i-1].PageIndex == r.PageIndex {
+			return fmt.Errorf("%w: duplicate range at page %d", ErrInvalidPageLabel, r.PageIndex)
+		}
+	}
+
+	d.pageLabels = sorted
+	return nil
+}
+
+// PageLabels returns the page label ranges, sorted by PageIndex.
+//
+// The returned slice is a copy to prevent external modifications.
+func (d *Document) PageLabels() []PageLabelRange {
+	if len(d.pageLabels) == 0 {
+		return nil
+	}
+	result := make([]PageLabelRange, len(d.pageLabels))
+	copy(result, d.pageLabels)
+	return result
+}
+
+// PageLabelAt returns the range covering the given page and the numeric
+// value of that page's label.
+//
+// ok is false when the document has no range covering the page (for
+// example when no labels are defined).
+func (d *Document) PageLabelAt(index int) (r PageLabelRange, number int, ok bool) {
+	for i := len(d.pageLabels) - 1; i >= 0; i-- {
+		if d.pageLabels[i].PageIndex <= index {
+			r = d.pageLabels[i]
+			return r, r.FirstNumber() + index - r.PageIndex, true
+		}
+	}
+	return PageLabelRange{}, 0, false
+}
+
+// FormatPageLabel renders the label text for a page numbered n in the
+// given style and prefix (e.g. "A-iv").
+func FormatPageLabel(style PageLabelStyle, prefix string, n int) string {
+	switch style {
+	case PageLabelDecimal:
+		return fmt.Sprintf("%s%d", prefix, n)
+	case PageLabelRomanUpper:
+		return prefix + toRoman(n)
+	case PageLabelRomanLower:
+		return prefix + strings.ToLower(toRoman(n))
+	case PageLabelAlphaUpper:
+		return prefix + toAlpha(n)
+	case PageLabelAlphaLower:
+		return prefix + strings.ToLower(toAlpha(n))
+	default:
+		return prefix
+	}
+}
+
+// toRoman converts n to uppercase roman numerals.
+func toRoman(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var sb strings.Builder
+	for i, v := range values {
+		for n >= v {
+			sb.WriteString(symbols[i])
+			n -= v
+		}
+	}
+	return sb.String()
+}
+
+// toAlpha converts n to letters: A..Z, then AA..ZZ, AAA..ZZZ, and so on.
+func toAlpha(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	letter := byte('A' + (n-1)%26)
+	return strings.Repeat(string(letter), (n-1)/26+1)
+}
+
+// ErrInvalidPageLabel is returned when page label ranges are inconsistent.
+var ErrInvalidPageLabel = errors.New("invalid page label range")
@@ -0,0 +1,67 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_SetPageLabels(t *testing.T) {
+	doc := NewDocument()
+
+	err := doc.SetPageLabels([]PageLabelRange{
+		{PageIndex: 3, Style: PageLabelDecimal},
+		{PageIndex: 0, Style: PageLabelRomanLower},
+	})
+	require.NoError(t, err)
+
+	labels := doc.PageLabels()
+	require.Len(t, labels, 2)
+	assert.Equal(t, 0, labels[0].PageIndex, "ranges should be sorted")
+	assert.Equal(t, 3, labels[1].PageIndex)
+
+	r, n, ok := doc.PageLabelAt(4)
+	assert.True(t, ok)
+	assert.Equal(t, PageLabelDecimal, r.Style)
+	assert.Equal(t, 2, n)
+
+	require.NoError(t, doc.SetPageLabels(nil))
+	assert.Nil(t, doc.PageLabels())
+	_, _, ok = doc.PageLabelAt(0)
+	assert.False(t, ok)
+}
+
+func TestDocument_SetPageLabels_Invalid(t *testing.T) {
+	doc := NewDocument()
+
+	err := doc.SetPageLabels([]PageLabelRange{{PageIndex: -1}})
+	assert.ErrorIs(t, err, ErrInvalidPageLabel)
+
+	err = doc.SetPageLabels([]PageLabelRange{{PageIndex: 0}, {PageIndex: 0}})
+	assert.ErrorIs(t, err, ErrInvalidPageLabel)
+
+	err = doc.SetPageLabels([]PageLabelRange{{PageIndex: 0, Start: -2}})
+	assert.ErrorIs(t, err, ErrInvalidPageLabel)
+}
+
+func TestFormatPageLabel(t *testing.T) {
+	tests := []struct {
+		style  PageLabelStyle
+		prefix string
+		n      int
+		want   string
+	}{
+		{PageLabelDecimal, "", 12, "12"},
+		{PageLabelDecimal, "A-", 3, "A-3"},
+		{PageLabelRomanUpper, "", 1994, "MCMXCIV"},
+		{PageLabelRomanLower, "", 4, "iv"},
+		{PageLabelAlphaUpper, "", 28, "BB"},
+		{PageLabelAlphaLower, "", 1, "a"},
+		{PageLabelNone, "Cover", 1, "Cover"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatPageLabel(tt.style, tt.prefix, tt.n))
+	}
+}
@@ -0,0 +1,33 @@
+package document
+
+// PageMode specifies which viewer panel is visible when the document is
+// opened (the catalog /PageMode entry).
+//
+// Reference: PDF 1.7 Specification, Section 7.7.2 (Document Catalog).
+type PageMode string
+
+// Page modes.
+const (
+	// PageModeUseNone shows neither the outline nor thumbnails (the
+	// viewer default). Documents with this mode omit /PageMode.
+	PageModeUseNone PageMode = ""
+
+	// PageModeUseOutlines shows the document outline (bookmarks).
+	PageModeUseOutlines PageMode = "UseOutlines"
+
+	// PageModeUseThumbs shows page thumbnails.
+	PageModeUseThumbs PageMode = "UseThumbs"
+
+	// PageModeFullScreen opens the document in full-screen mode.
+	PageModeFullScreen PageMode = "FullScreen"
+)
+
+// SetPageMode sets the viewer panel shown when the document is opened.
+func (d *Document) SetPageMode(mode PageMode) {
+	d.pageMode = mode
+}
+
+// PageMode returns the viewer panel shown when the document is opened.
+func (d *Document) PageMode() PageMode {
+	return d.pageMode
+}
@@ -0,0 +1,34 @@
+package document
+
+// Trapped indicates whether the document has been modified to include
+// trapping information (the Info dictionary /Trapped entry). Prepress
+// tools use it to decide whether trapping still has to be applied.
+//
+// Reference: PDF 1.7 Specification, Section 14.3.3 (Document Information Dictionary).
+type Trapped string
+
+// Trapped states.
+const (
+	// TrappedUnset omits /Trapped from the Info dictionary.
+	TrappedUnset Trapped = ""
+
+	// TrappedTrue means the document has been fully trapped.
+	TrappedTrue Trapped = "True"
+
+	// TrappedFalse means the document has not been trapped.
+	TrappedFalse Trapped = "False"
+
+	// TrappedUnknown means it is unknown whether the document has been
+	// trapped, or it has been partly but not fully trapped.
+	TrappedUnknown Trapped = "Unknown"
+)
+
+// SetTrapped sets the trapping state of the document.
+func (d *Document) SetTrapped(trapped Trapped) {
+	d.trapped = trapped
+}
+
+// Trapped returns the trapping state of the document.
+func (d *Document) Trapped() Trapped {
+	return d.trapped
+}
@@ -0,0 +1,109 @@
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ASCII85Decoder implements ASCII85Decode stream decoding.
+//
+// ASCII85Decode encodes each 4 bytes of binary data as 5 characters in
+// the range '!' to 'u', with 'z' standing for 4 zero bytes. The data ends
+// with the EOD marker "~>". Streams produced from PostScript and EPS files
+// often use it, usually followed by a compression filter.
+//
+// Reference: PDF 1.7 specification, Section 7.4.3 (ASCII85Decode Filter).
+type ASCII85Decoder struct{}
+
+// NewASCII85Decoder creates a new ASCII85 decoder.
+func NewASCII85Decoder() *ASCII85Decoder {
+	return &ASCII85Decoder{}
+}
+
+// Decode decodes ASCII85-encoded data.
+//
+// White space is ignored, as is a leading "<~" (written by PostScript
+// encoders). Decoding stops at "~>"; data that ends without it is
+// accepted.
+//
+// Parameters:
+//   - data: ASCII85-encoded data bytes
+//
+// Returns: Decoded data bytes, or error if the data holds an invalid character or group.
+func (d *ASCII85Decoder) Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimLeft(data, "\x00\t\n\f\r ")
+	data = bytes.TrimPrefix(data, []byte("<~"))
+
+	out := make([]byte, 0, len(data)*4/5)
+	var group [5]byte
+	n := 0
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case isPDFWhiteSpace(c):
+			continue
+		case c == '~':
+			if i+1 < len(data) && data[i+1] != '>' {
+				return nil, fmt.Errorf("ASCII85: '~' at offset %d not followed by '>'", i)
+			}
+			return d.flush(out, group, n)
+		case c == 'z':
+			if n != 0 {
+				return nil, fmt.Errorf("ASCII85: 'z' inside a group at offset %d", i)
+			}
+			out = append(out, 0, 0, 0, 0)
+		case c >= '!' && c <= 'u':
+			group[n] = c
+			n++
+			if n == 5 {
+				var err error
+				if out, err = appendASCII85Group(out, group, 5); err != nil {
+					return nil, err
+				}
+				n = 0
+			}
+		default:
+			return nil, fmt.Errorf("ASCII85: invalid character %q at offset %d", c, i)
+		}
+	}
+	return d.flush(out, group, n)
+}
+
+// flush decodes a final partial group of n characters: n characters
+// encode n-1 bytes.
+func (d *ASCII85Decoder) flush(out []byte, group [5]byte, n int) ([]byte, error) {
+	switch n {
+	case 0:
+		return out, nil
+	case 1:
+		return nil, fmt.Errorf("ASCII85: final group has a single character")
+	}
+	for i := n; i < 5; i++ {
+		group[i] = 'u'
+	}
+	return appendASCII85Group(out, group, n)
+}
+
+// appendASCII85Group decodes a 5-character group and appends its first
+// n-1 bytes to out.
+func appendASCII85Group(out []byte, group [5]byte, n int) ([]byte, error) {
+	var v uint64
+	for _, c := range group {
+		v = v*85 + uint64(c-'!')
+	}
+	if v > 0xFFFFFFFF {
+		return nil, fmt.Errorf("ASCII85: group %q exceeds 2^32-1", group[:n])
+	}
+	b := [4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	return append(out, b[:n-1]...), nil
+}
+
+// isPDFWhiteSpace reports whether c is a PDF white-space character.
+func isPDFWhiteSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
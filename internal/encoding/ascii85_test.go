@@ -0,0 +1,74 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"testing"
+)
+
+func TestASCII85Decoder_Decode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "full groups", input: "87cURD]i,\"Ebo80~>", want: "Hello World!"},
+		{name: "partial final group", input: "87cURD]i,\"Ebo7~>", want: "Hello World"},
+		{name: "whitespace", input: "87cU\r\nRD]i,\t\"Ebo 80\n~>", want: "Hello World!"},
+		{name: "z group", input: "z87cUR~>", want: "\x00\x00\x00\x00Hell"},
+		{name: "leading marker", input: " <~87cURD]i,\"Ebo80~>", want: "Hello World!"},
+		{name: "data after EOD ignored", input: "87cUR~>garbage", want: "Hell"},
+		{name: "missing EOD", input: "87cUR", want: "Hell"},
+		{name: "empty", input: "~>", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewASCII85Decoder().Decode([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if string(result) != tt.want {
+				t.Errorf("Decode = %q, want %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestASCII85Decoder_Decode_Binary(t *testing.T) {
+	original := make([]byte, 1001)
+	for i := range original {
+		original[i] = byte(i * 7)
+	}
+	encoded := make([]byte, ascii85.MaxEncodedLen(len(original)))
+	encoded = append(encoded[:ascii85.Encode(encoded, original)], "~>"...)
+
+	result, err := NewASCII85Decoder().Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !bytes.Equal(result, original) {
+		t.Error("Decode did not recover the original data")
+	}
+}
+
+func TestASCII85Decoder_Decode_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "invalid character", input: "87cU{~>"},
+		{name: "z inside group", input: "87z~>"},
+		{name: "single final character", input: "87cURD~>"},
+		{name: "group overflow", input: "uuuuu~>"},
+		{name: "tilde without >", input: "87cUR~x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewASCII85Decoder().Decode([]byte(tt.input)); err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+		})
+	}
+}
@@ -24,7 +24,8 @@ func NewFlateDecoder() *FlateDecoder {
 // Decode decompresses Flate-encoded data.
 //
 // This is a straightforward zlib decompression without predictor support.
-// Predictors (like PNG filters) are typically not used for xref streams.
+// Predictors (/DecodeParms /Predictor) are reversed by the caller on the
+// decompressed data (see parser.ReversePredictor).
 //
 // Parameters:
 //   - data: Compressed data bytes
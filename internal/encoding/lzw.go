@@ -0,0 +1,116 @@
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// LZW codes with a fixed meaning.
+const (
+	lzwClearTable = 256
+	lzwEOD        = 257
+	lzwFirstCode  = 258
+	lzwMaxCodes   = 4096 // 12-bit codes
+)
+
+// LZWDecoder implements LZWDecode stream decompression.
+//
+// LZWDecode is found in older PDF files (before FlateDecode became
+// common). Codes are 9 to 12 bits wide, packed from the high-order bit,
+// with code 256 clearing the table and code 257 ending the data.
+//
+// Reference: PDF 1.7 specification, Section 7.4.4 (LZWDecode and
+// FlateDecode Filters).
+type LZWDecoder struct {
+	// EarlyChange is the /EarlyChange parameter:
+	// 1 = code width increases one code early (default)
+	// 0 = code width increases when the table is full at the current width
+	EarlyChange int
+}
+
+// NewLZWDecoder creates a new LZW decoder with the default EarlyChange 1.
+func NewLZWDecoder() *LZWDecoder {
+	return &LZWDecoder{
+		EarlyChange: 1,
+	}
+}
+
+// NewLZWDecoderWithParams creates an LZW decoder with specific parameters.
+func NewLZWDecoderWithParams(earlyChange int) *LZWDecoder {
+	return &LZWDecoder{
+		EarlyChange: earlyChange,
+	}
+}
+
+// Decode decompresses LZW-encoded data.
+//
+// Data that ends without an EOD code is accepted, as many writers omit it.
+//
+// Parameters:
+//   - data: LZW-compressed data bytes
+//
+// Returns: Decompressed data bytes, or error if the data holds an invalid code.
+func (d *LZWDecoder) Decode(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	table := newLZWTable()
+	codeLen := 9
+	var prev []byte
+
+	var bits uint32 // Unread bits, right-aligned
+	nBits := 0
+	pos := 0
+	for {
+		for nBits < codeLen {
+			if pos >= len(data) {
+				return out.Bytes(), nil
+			}
+			bits = bits<<8 | uint32(data[pos])
+			nBits += 8
+			pos++
+		}
+		nBits -= codeLen
+		code := int(bits >> nBits & (1<<codeLen - 1))
+		bits &= 1<<nBits - 1
+
+		switch code {
+		case lzwClearTable:
+			table = table[:lzwFirstCode]
+			codeLen = 9
+			prev = nil
+			continue
+		case lzwEOD:
+			return out.Bytes(), nil
+		}
+
+		var entry []byte
+		switch {
+		case code < len(table):
+			entry = table[code]
+		case code == len(table) && prev != nil:
+			// The code being defined: previous string plus its first byte.
+			entry = append(append(make([]byte, 0, len(prev)+1), prev...), prev[0])
+		default:
+			return nil, fmt.Errorf("LZW: invalid code %d (table has %d entries)", code, len(table))
+		}
+		out.Write(entry)
+
+		if prev != nil && len(table) < lzwMaxCodes {
+			table = append(table, append(append(make([]byte, 0, len(prev)+1), prev...), entry[0]))
+		}
+		prev = entry
+
+		if len(table)+d.EarlyChange >= 1<<codeLen && codeLen < 12 {
+			codeLen++
+		}
+	}
+}
+
+// newLZWTable returns a code table holding the 256 single-byte strings and
+// room for the codes defined while decoding.
+func newLZWTable() [][]byte {
+	table := make([][]byte, lzwFirstCode, lzwMaxCodes)
+	for i := 0; i < 256; i++ {
+		table[i] = []byte{byte(i)}
+	}
+	return table
+}
@@ -0,0 +1,78 @@
+package encoding
+
+import (
+	"bytes"
+	"compress/lzw"
+	"math/rand"
+	"testing"
+)
+
+func TestLZWDecoder_Decode_SpecExample(t *testing.T) {
+	// "-----A---B" encodes to the codes 45 258 258 65 259 66 257
+	// (PDF 1.7 specification, Section 7.4.4.2, Example 2).
+	data := []byte{0x80, 0x0B, 0x60, 0x50, 0x22, 0x0C, 0x0C, 0x85, 0x01}
+
+	result, err := NewLZWDecoder().Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if string(result) != "-----A---B" {
+		t.Errorf("Decode = %q, want %q", result, "-----A---B")
+	}
+}
+
+func TestLZWDecoder_Decode_MissingEOD(t *testing.T) {
+	// The spec example cut off inside its EOD code.
+	data := []byte{0x80, 0x0B, 0x60, 0x50, 0x22, 0x0C, 0x0C, 0x84}
+
+	result, err := NewLZWDecoder().Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if string(result) != "-----A---B" {
+		t.Errorf("Decode = %q, want %q", result, "-----A---B")
+	}
+}
+
+func TestLZWDecoder_Decode_EarlyChangeZero(t *testing.T) {
+	// compress/lzw in MSB order with 8-bit literals writes PDF LZW data
+	// with EarlyChange 0. The input is long enough to grow codes to 12 bits
+	// and fill the table, so the encoder emits Clear codes mid-stream.
+	rng := rand.New(rand.NewSource(1))
+	original := make([]byte, 64*1024)
+	for i := range original {
+		original[i] = byte('a' + rng.Intn(8))
+	}
+
+	var buf bytes.Buffer
+	w := lzw.NewWriter(&buf, lzw.MSB, 8)
+	if _, err := w.Write(original); err != nil {
+		t.Fatalf("lzw write failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("lzw close failed: %v", err)
+	}
+
+	result, err := NewLZWDecoderWithParams(0).Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !bytes.Equal(result, original) {
+		t.Fatal("Decode with EarlyChange 0 did not recover the original data")
+	}
+
+	// The same data read with EarlyChange 1 switches code width too early.
+	result, err = NewLZWDecoder().Decode(buf.Bytes())
+	if err == nil && bytes.Equal(result, original) {
+		t.Error("Decode with EarlyChange 1 unexpectedly recovered EarlyChange 0 data")
+	}
+}
+
+func TestLZWDecoder_Decode_InvalidCode(t *testing.T) {
+	// Code 300 as the first code: not yet in the table.
+	data := []byte{300 >> 1, 300 << 7 & 0xFF}
+
+	if _, err := NewLZWDecoder().Decode(data); err == nil {
+		t.Error("Expected error for undefined code")
+	}
+}
@@ -3,6 +3,7 @@ package extractor
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/coregx/gxpdf/internal/parser"
 )
@@ -75,12 +76,30 @@ func NewContentParser(content []byte) *ContentParser {
 // ParseOperators parses all operators from the content stream.
 //
 // Returns a slice of operators in the order they appear in the stream.
-// Returns error if parsing fails.
+// Returns error if parsing fails, together with the operators parsed
+// before the error.
 //
 // Content streams are sequences of objects followed by operators (keywords).
 // Example: "100 200 Td" means: push 100, push 200, execute Td operator.
+//
+// An inline image (BI ... ID data EI) is returned as a single "BI"
+// operator with two operands: the image dictionary, with its keys as
+// written (abbreviated or not), and the image data as a String.
 func (cp *ContentParser) ParseOperators() ([]*Operator, error) {
 	var operators []*Operator
+	err := cp.WalkOperators(func(op *Operator) {
+		operators = append(operators, op)
+	})
+	return operators, err
+}
+
+// WalkOperators calls visit for each operator of the content stream, in
+// order, as it is parsed. Operators are not retained, so only the operands
+// of the current operator are held in memory.
+//
+// Parsing stops at the first error, after the operators before it have
+// been visited. See ParseOperators for the operator format.
+func (cp *ContentParser) WalkOperators(visit func(op *Operator)) error {
 	var operandStack []parser.PdfObject
 
 	for {
@@ -88,18 +107,24 @@ func (cp *ContentParser) ParseOperators() ([]*Operator, error) {
 		token, err := cp.lexer.NextToken()
 		if err != nil {
 			// Error occurred during tokenization
-			return operators, err
+			return err
 		}
 		if token.Type == parser.TokenEOF {
 			// End of stream reached
-			break
+			return nil
 		}
 
 		// Check if token is an operator (keyword)
-		if token.Type == parser.TokenKeyword {
+		if token.Type == parser.TokenKeyword && token.Value == "BI" {
+			op, err := cp.parseInlineImage()
+			if err != nil {
+				return err
+			}
+			visit(op)
+			operandStack = nil
+		} else if token.Type == parser.TokenKeyword {
 			// Create operator with current operand stack
-			op := NewOperator(token.Value, operandStack)
-			operators = append(operators, op)
+			visit(NewOperator(token.Value, operandStack))
 
 			// Clear operand stack
 			operandStack = nil
@@ -107,13 +132,11 @@ func (cp *ContentParser) ParseOperators() ([]*Operator, error) {
 			// Token is an operand, convert it to an object
 			obj, err := cp.tokenToObject(token)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse operand: %w", err)
+				return fmt.Errorf("failed to parse operand: %w", err)
 			}
 			operandStack = append(operandStack, obj)
 		}
 	}
-
-	return operators, nil
 }
 
 // tokenToObject converts a token to a PDF object.
@@ -181,6 +204,44 @@ func (cp *ContentParser) tokenToObject(token parser.Token) (parser.PdfObject, er
 	}
 }
 
+// parseInlineImage parses an inline image: the key-value pairs of its
+// dictionary up to ID, then the raw data up to EI.
+//
+// Assumes the BI keyword has already been consumed.
+//
+// Reference: PDF 1.7 specification, Section 8.9.7 (Inline Images).
+func (cp *ContentParser) parseInlineImage() (*Operator, error) {
+	dict := parser.NewDictionary()
+	for {
+		token, err := cp.lexer.NextToken()
+		if err != nil {
+			return nil, fmt.Errorf("error reading inline image dictionary: %w", err)
+		}
+		if token.Type == parser.TokenKeyword && token.Value == "ID" {
+			break
+		}
+		if token.Type != parser.TokenName {
+			return nil, fmt.Errorf("inline image key must be a name, got %v", token.Type)
+		}
+
+		value, err := cp.lexer.NextToken()
+		if err != nil {
+			return nil, fmt.Errorf("error reading inline image dictionary: %w", err)
+		}
+		obj, err := cp.tokenToObject(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse inline image value: %w", err)
+		}
+		dict.Set(strings.TrimPrefix(token.Value, "/"), obj)
+	}
+
+	data, err := cp.lexer.ReadInlineImageData()
+	if err != nil {
+		return nil, err
+	}
+	return NewOperator("BI", []parser.PdfObject{dict, parser.NewStringBytes(data)}), nil
+}
+
 // parseArray parses an array from the content stream.
 //
 // Assumes TokenArrayStart has already been consumed.
@@ -3,6 +3,7 @@ package extractor
 import (
 	"testing"
 
+	"github.com/coregx/gxpdf/internal/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -216,3 +217,54 @@ func TestContentParser_ParseOperators_ComplexExample(t *testing.T) {
 	assert.Contains(t, names, "Td")
 	assert.Contains(t, names, "ET")
 }
+
+func TestContentParser_ParseOperators_InlineImage(t *testing.T) {
+	// "EI" inside the data does not end it unless surrounded by white
+	// space or delimiters; ")" and "(" are not valid tokens.
+	content := []byte("q BI /W 3 /H 1 /CS /RGB /BPC 8 ID xEI) EIx(\xff\x00\xff EI Q")
+
+	operators, err := NewContentParser(content).ParseOperators()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(operators))
+
+	assert.Equal(t, "q", operators[0].Name)
+	assert.Equal(t, "Q", operators[2].Name)
+
+	bi := operators[1]
+	assert.Equal(t, "BI", bi.Name)
+	require.Equal(t, 2, len(bi.Operands))
+	dict, ok := bi.Operands[0].(*parser.Dictionary)
+	require.True(t, ok)
+	assert.Equal(t, int64(3), dict.GetInteger("W"))
+	assert.Equal(t, "RGB", dict.GetName("CS").Value())
+	data, ok := bi.Operands[1].(*parser.String)
+	require.True(t, ok)
+	assert.Equal(t, []byte("xEI) EIx(\xff\x00\xff"), data.Bytes())
+}
+
+func TestContentParser_ParseOperators_InlineImageUnterminated(t *testing.T) {
+	_, err := NewContentParser([]byte("BI /W 1 /H 1 ID \x00\x00")).ParseOperators()
+	assert.Error(t, err)
+}
+
+func TestContentParser_WalkOperators(t *testing.T) {
+	var names []string
+	var operands []int
+	err := NewContentParser([]byte("BT /F1 12 Tf (Hi) Tj ET")).WalkOperators(func(op *Operator) {
+		names = append(names, op.Name)
+		operands = append(operands, len(op.Operands))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BT", "Tf", "Tj", "ET"}, names)
+	assert.Equal(t, []int{0, 2, 1, 0}, operands)
+}
+
+func TestContentParser_WalkOperators_StopsAtError(t *testing.T) {
+	// Operators before a malformed inline image are still visited.
+	var names []string
+	err := NewContentParser([]byte("q Q BI /W 1 /H 1 ID \x00\x00")).WalkOperators(func(op *Operator) {
+		names = append(names, op.Name)
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"q", "Q"}, names)
+}
@@ -0,0 +1,189 @@
+package extractor
+
+import (
+	"strings"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// defaultGlyphWidth is the width used for glyphs of fonts whose widths
+// are unknown (an average Latin glyph, in 1/1000 text space units).
+const defaultGlyphWidth = 600
+
+// fontWidths holds the glyph widths of a font, used to advance the text
+// position as text is shown.
+//
+// Widths are in 1/1000 text space units, as in the font's /Widths or /W
+// array (Type 3 widths are converted from glyph space).
+//
+// Reference: PDF 1.7 specification, Section 9.2.4 (Glyph Positioning and
+// Metrics), 9.6.2.1 (Widths) and 9.7.4.3 (Glyph Metrics in CIDFonts).
+type fontWidths struct {
+	twoByte      bool               // Codes are 2 bytes (Type 0 fonts with Identity CMaps)
+	widths       map[uint16]float64 // Code -> width
+	defaultWidth float64            // /MissingWidth or /DW
+	standard     *fonts.FontMetrics // Standard 14 metrics when /Widths is absent
+}
+
+// glyphWidth returns the width of the glyph for code.
+func (fw *fontWidths) glyphWidth(code uint16) float64 {
+	if w, ok := fw.widths[code]; ok {
+		return w
+	}
+	if fw.standard != nil {
+		return float64(fw.standard.GetCharWidth(rune(code)))
+	}
+	return fw.defaultWidth
+}
+
+// codes splits shown string bytes into character codes.
+func (fw *fontWidths) codes(data []byte) []uint16 {
+	if !fw.twoByte {
+		codes := make([]uint16, len(data))
+		for i, b := range data {
+			codes[i] = uint16(b)
+		}
+		return codes
+	}
+	codes := make([]uint16, 0, (len(data)+1)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		codes = append(codes, uint16(data[i])<<8|uint16(data[i+1]))
+	}
+	return codes
+}
+
+// loadFontWidths loads and caches the glyph widths of the font resource
+// fontName. Fonts that cannot be found get defaultGlyphWidth for every
+// glyph.
+func (te *TextExtractor) loadFontWidths(fontName string) {
+	if _, exists := te.fontWidths[fontName]; exists {
+		return
+	}
+
+	fw := &fontWidths{widths: make(map[uint16]float64), defaultWidth: defaultGlyphWidth}
+	te.fontWidths[fontName] = fw
+
+	fontDict := te.fontDict(fontName)
+	if fontDict == nil {
+		return
+	}
+
+	subtype := ""
+	if name := fontDict.GetName("Subtype"); name != nil {
+		subtype = name.Value()
+	}
+	if subtype == "Type0" {
+		fw.twoByte = true
+		fw.defaultWidth = 1000
+		if descendants, ok := te.resolve(fontDict.Get("DescendantFonts")).(*parser.Array); ok && descendants.Len() > 0 {
+			if cidFont, ok := te.resolve(descendants.Get(0)).(*parser.Dictionary); ok {
+				te.loadCIDWidths(fw, cidFont)
+			}
+		}
+		return
+	}
+
+	widths, _ := te.resolve(fontDict.Get("Widths")).(*parser.Array)
+	if widths == nil {
+		// Standard 14 fonts may omit /Widths (possibly with a subset prefix).
+		if name := fontDict.GetName("BaseFont"); name != nil {
+			base := name.Value()
+			if i := strings.IndexByte(base, '+'); i == 6 {
+				base = base[i+1:]
+			}
+			fw.standard = fonts.GetMetrics(base)
+		}
+		return
+	}
+
+	// Type 3 widths are in glyph space, mapped to text space by /FontMatrix.
+	scale := 1.0
+	if matrix, ok := te.resolve(fontDict.Get("FontMatrix")).(*parser.Array); ok && subtype == "Type3" && matrix.Len() == 6 {
+		if a := getNumber(te.resolve(matrix.Get(0))); a != nil {
+			scale = *a * 1000
+		}
+	}
+
+	if descriptor, ok := te.resolve(fontDict.Get("FontDescriptor")).(*parser.Dictionary); ok {
+		if w := getNumber(te.resolve(descriptor.Get("MissingWidth"))); w != nil {
+			fw.defaultWidth = *w * scale
+		}
+	}
+	firstChar := int(fontDict.GetInteger("FirstChar"))
+	for i := 0; i < widths.Len(); i++ {
+		if w := getNumber(te.resolve(widths.Get(i))); w != nil && firstChar+i >= 0 && firstChar+i <= 0xFFFF {
+			fw.widths[uint16(firstChar+i)] = *w * scale //nolint:gosec // Range checked above.
+		}
+	}
+}
+
+// loadCIDWidths reads /DW and /W from a CIDFont. /W mixes two forms:
+// "c [w1 w2 ...]" for consecutive CIDs from c, and "cFirst cLast w".
+// Codes are taken to be CIDs (Identity CMaps).
+func (te *TextExtractor) loadCIDWidths(fw *fontWidths, cidFont *parser.Dictionary) {
+	if dw := getNumber(te.resolve(cidFont.Get("DW"))); dw != nil {
+		fw.defaultWidth = *dw
+	}
+	w, ok := te.resolve(cidFont.Get("W")).(*parser.Array)
+	if !ok {
+		return
+	}
+
+	setWidth := func(cid int, width float64) {
+		if cid >= 0 && cid <= 0xFFFF {
+			fw.widths[uint16(cid)] = width //nolint:gosec // Range checked above.
+		}
+	}
+	for i := 0; i+1 < w.Len(); {
+		first := getNumber(te.resolve(w.Get(i)))
+		if first == nil {
+			return
+		}
+		if list, ok := te.resolve(w.Get(i + 1)).(*parser.Array); ok {
+			for j := 0; j < list.Len(); j++ {
+				if width := getNumber(te.resolve(list.Get(j))); width != nil {
+					setWidth(int(*first)+j, *width)
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= w.Len() {
+			return
+		}
+		last := getNumber(te.resolve(w.Get(i + 1)))
+		width := getNumber(te.resolve(w.Get(i + 2)))
+		if last == nil || width == nil || *last-*first > 0xFFFF {
+			return
+		}
+		for cid := int(*first); cid <= int(*last); cid++ {
+			setWidth(cid, *width)
+		}
+		i += 3
+	}
+}
+
+// fontDict returns the dictionary of the font resource fontName, or nil.
+func (te *TextExtractor) fontDict(fontName string) *parser.Dictionary {
+	fontsDict, ok := te.resolve(te.pageResources.Get("Font")).(*parser.Dictionary)
+	if !ok {
+		return nil
+	}
+	fontDict, _ := te.resolve(fontsDict.Get(fontName)).(*parser.Dictionary)
+	return fontDict
+}
+
+// resolve returns the object an indirect reference points to, or obj
+// itself if it is not a reference. Unresolvable references give nil.
+func (te *TextExtractor) resolve(obj parser.PdfObject) parser.PdfObject {
+	ref, ok := obj.(*parser.IndirectReference)
+	if !ok {
+		return obj
+	}
+	resolved, err := te.reader.GetObject(ref.Number)
+	if err != nil {
+		return nil
+	}
+	return resolved
+}
@@ -243,6 +243,10 @@ func (e *ImageExtractor) decodeImageData(stream *parser.Stream, filter string) (
 		if err != nil {
 			return nil, fmt.Errorf("flate decode failed: %w", err)
 		}
+		decodedData, err = parser.ReversePredictor(decodedData, stream.GetDecodeParams())
+		if err != nil {
+			return nil, fmt.Errorf("flate decode failed: %w", err)
+		}
 		return decodedData, nil
 
 	case "":
@@ -0,0 +1,451 @@
+package extractor
+
+import (
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/encoding"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// Content walking limits.
+const (
+	// maxImageFormDepth limits Form XObject nesting (guards against cycles).
+	maxImageFormDepth = 16
+
+	// maxResourceInheritDepth limits /Parent traversal for inherited page resources.
+	maxResourceInheritDepth = 32
+)
+
+// PlacedImage is an image painted by a page's content, together with the
+// transformation it is painted with.
+type PlacedImage struct {
+	Name             string // XObject resource name ("" for inline images)
+	Inline           bool   // Inline image (BI ... ID ... EI)
+	Width            int    // Width in samples
+	Height           int    // Height in samples
+	BitsPerComponent int    // Bits per color component
+	ColorSpace       string // Color space family ("" for image masks)
+
+	// Filter is the first filter still applied to Data: "DCTDecode" for
+	// JPEG data, another image filter, or a filter that could not be
+	// decoded. Empty if Data holds the raw samples.
+	Filter string
+
+	Data    []byte // Decoded data (see Filter)
+	Encoded []byte // Data as stored in the file
+
+	// CTM is the current transformation matrix at the point of use. It
+	// maps the unit square to the area the image covers on the page.
+	CTM Matrix
+}
+
+// ExtractPlacedFromPage returns the images painted by a page's content,
+// in painting order: image XObjects drawn with Do (also inside Form
+// XObjects) and inline images. An image drawn several times is returned
+// once per use.
+//
+// Parameters:
+//   - pageIndex: 0-based page index
+//
+// Returns an error if the page or its content stream cannot be read.
+// Images that cannot be read are skipped.
+func (e *ImageExtractor) ExtractPlacedFromPage(pageIndex int) ([]*PlacedImage, error) {
+	page, err := e.reader.GetPage(pageIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %d: %w", pageIndex, err)
+	}
+
+	content, err := e.pageContent(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page content: %w", err)
+	}
+
+	w := &imageWalker{e: e, ctm: Identity()}
+	for node, i := page, 0; node != nil && i < maxResourceInheritDepth; i++ {
+		if res, ok := e.resolve(node.Get("Resources")).(*parser.Dictionary); ok {
+			w.resources = res
+			break
+		}
+		node, _ = e.resolve(node.Get("Parent")).(*parser.Dictionary)
+	}
+
+	if err := w.run(content); err != nil {
+		return nil, err
+	}
+	return w.images, nil
+}
+
+// pageContent returns the decoded, concatenated page content streams.
+func (e *ImageExtractor) pageContent(page *parser.Dictionary) ([]byte, error) {
+	switch obj := e.resolve(page.Get("Contents")).(type) {
+	case nil:
+		return nil, nil
+	case *parser.Stream:
+		return e.streamData(obj)
+	case *parser.Array:
+		var all []byte
+		for i := 0; i < obj.Len(); i++ {
+			stream, ok := e.resolve(obj.Get(i)).(*parser.Stream)
+			if !ok {
+				continue
+			}
+			data, err := e.streamData(stream)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, data...)
+			all = append(all, '\n')
+		}
+		return all, nil
+	default:
+		return nil, fmt.Errorf("unexpected /Contents type: %T", obj)
+	}
+}
+
+// streamData returns the fully decoded data of a content stream.
+func (e *ImageExtractor) streamData(stream *parser.Stream) ([]byte, error) {
+	dict := stream.Dictionary()
+	data, filter, err := e.decodeFilters(stream.Content(), e.filterNames(dict.Get("Filter"), false), dict.Get("DecodeParms"))
+	if err != nil {
+		return nil, err
+	}
+	if filter != "" {
+		return nil, fmt.Errorf("unsupported filter: %s", filter)
+	}
+	return data, nil
+}
+
+// decodeFilters applies the filters to data in order, stopping at the
+// first filter it does not decode (image filters such as DCTDecode, whose
+// data is returned as-is). It returns the decoded data and the name of
+// that filter, or "" if all filters were decoded.
+func (e *ImageExtractor) decodeFilters(data []byte, filters []string, decodeParms parser.PdfObject) ([]byte, string, error) {
+	for i, filter := range filters {
+		parms := e.resolve(decodeParms)
+		if arr, ok := parms.(*parser.Array); ok {
+			parms = nil
+			if i < arr.Len() {
+				parms = e.resolve(arr.Get(i))
+			}
+		} else if i > 0 {
+			parms = nil
+		}
+
+		var err error
+		switch filter {
+		case "FlateDecode":
+			if data, err = e.flateDecoder.Decode(data); err == nil {
+				data, err = parser.ReversePredictor(data, parms)
+			}
+		case "LZWDecode":
+			earlyChange := 1
+			if dict, ok := parms.(*parser.Dictionary); ok && dict.Has("EarlyChange") {
+				earlyChange = int(dict.GetInteger("EarlyChange"))
+			}
+			if data, err = encoding.NewLZWDecoderWithParams(earlyChange).Decode(data); err == nil {
+				data, err = parser.ReversePredictor(data, parms)
+			}
+		case "ASCII85Decode":
+			data, err = encoding.NewASCII85Decoder().Decode(data)
+		default:
+			return data, filter, nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("%s failed: %w", filter, err)
+		}
+	}
+	return data, "", nil
+}
+
+// filterNames returns the names of the filters in a /Filter value, in
+// order. Abbreviated names of inline images are expanded if inline is true.
+func (e *ImageExtractor) filterNames(obj parser.PdfObject, inline bool) []string {
+	var names []string
+	add := func(obj parser.PdfObject) {
+		if name, ok := e.resolve(obj).(*parser.Name); ok {
+			names = append(names, inlineImageName(name.Value(), inline))
+		}
+	}
+	if arr, ok := e.resolve(obj).(*parser.Array); ok {
+		for _, elem := range arr.Elements() {
+			add(elem)
+		}
+	} else {
+		add(obj)
+	}
+	return names
+}
+
+// resolve follows a single indirect reference.
+func (e *ImageExtractor) resolve(obj parser.PdfObject) parser.PdfObject {
+	ref, ok := obj.(*parser.IndirectReference)
+	if !ok {
+		return obj
+	}
+	resolved, err := e.reader.GetObject(ref.Number)
+	if err != nil {
+		return nil
+	}
+	return resolved
+}
+
+// Abbreviations used in inline image dictionaries.
+//
+// Reference: PDF 1.7 specification, Section 8.9.7, Tables 93 and 94.
+var (
+	inlineImageKeys = map[string]string{
+		"BPC": "BitsPerComponent",
+		"CS":  "ColorSpace",
+		"D":   "Decode",
+		"DP":  "DecodeParms",
+		"F":   "Filter",
+		"H":   "Height",
+		"IM":  "ImageMask",
+		"I":   "Interpolate",
+		"W":   "Width",
+	}
+	inlineImageNames = map[string]string{
+		"G":    "DeviceGray",
+		"RGB":  "DeviceRGB",
+		"CMYK": "DeviceCMYK",
+		"I":    "Indexed",
+		"AHx":  "ASCIIHexDecode",
+		"A85":  "ASCII85Decode",
+		"LZW":  "LZWDecode",
+		"Fl":   "FlateDecode",
+		"RL":   "RunLengthDecode",
+		"CCF":  "CCITTFaxDecode",
+		"DCT":  "DCTDecode",
+	}
+)
+
+// inlineImageName expands an abbreviated color space or filter name of an
+// inline image if inline is true.
+func inlineImageName(name string, inline bool) string {
+	if full, ok := inlineImageNames[name]; ok && inline {
+		return full
+	}
+	return name
+}
+
+// imageWalker runs a content stream, tracking the CTM, and collects the
+// images it paints.
+type imageWalker struct {
+	e         *ImageExtractor
+	resources *parser.Dictionary
+	ctm       Matrix
+	stack     []Matrix
+	depth     int
+	images    []*PlacedImage
+}
+
+// run parses and executes a content stream.
+func (w *imageWalker) run(content []byte) error {
+	if len(content) == 0 {
+		return nil
+	}
+	ops, err := NewContentParser(content).ParseOperators()
+	if err != nil && len(ops) == 0 {
+		return fmt.Errorf("failed to parse content stream: %w", err)
+	}
+	for _, op := range ops {
+		w.execute(op)
+	}
+	return nil
+}
+
+// execute runs one content stream operator.
+func (w *imageWalker) execute(op *Operator) {
+	args := op.Operands
+	switch op.Name {
+	case "q":
+		w.stack = append(w.stack, w.ctm)
+	case "Q":
+		if n := len(w.stack); n > 0 {
+			w.ctm = w.stack[n-1]
+			w.stack = w.stack[:n-1]
+		}
+	case "cm":
+		if m, ok := matrixFromOperands(args); ok {
+			w.ctm = concat(m, w.ctm)
+		}
+	case "Do":
+		if len(args) == 1 {
+			if name, ok := args[0].(*parser.Name); ok {
+				w.drawXObject(name.Value())
+			}
+		}
+	case "BI":
+		if len(args) == 2 {
+			dict, _ := args[0].(*parser.Dictionary)
+			data, _ := args[1].(*parser.String)
+			if dict != nil && data != nil {
+				w.addImage("", inlineImageDict(dict), data.Bytes(), true)
+			}
+		}
+	}
+}
+
+// drawXObject handles Do: images are collected, forms are run with their
+// own matrix and resources.
+//
+// Reference: PDF 1.7 specification, Section 8.10 (Form XObjects).
+func (w *imageWalker) drawXObject(name string) {
+	stream, ok := w.e.resolve(w.resource("XObject", name)).(*parser.Stream)
+	if !ok {
+		return
+	}
+	dict := stream.Dictionary()
+	subtype := dict.GetName("Subtype")
+	if subtype == nil {
+		return
+	}
+
+	switch subtype.Value() {
+	case "Image":
+		w.addImage(name, dict, stream.Content(), false)
+	case "Form":
+		if w.depth >= maxImageFormDepth {
+			return
+		}
+		content, err := w.e.streamData(stream)
+		if err != nil {
+			return
+		}
+
+		savedCTM, savedStack, savedRes := w.ctm, w.stack, w.resources
+		w.stack = nil
+		if arr, ok := w.e.resolve(dict.Get("Matrix")).(*parser.Array); ok {
+			if m, ok := matrixFromOperands(arr.Elements()); ok {
+				w.ctm = concat(m, w.ctm)
+			}
+		}
+		if res, ok := w.e.resolve(dict.Get("Resources")).(*parser.Dictionary); ok {
+			w.resources = res
+		}
+
+		w.depth++
+		_ = w.run(content)
+		w.depth--
+
+		w.ctm, w.stack, w.resources = savedCTM, savedStack, savedRes
+	}
+}
+
+// addImage decodes an image and adds it at the current CTM. Images
+// without valid dimensions or whose data cannot be decoded are skipped.
+func (w *imageWalker) addImage(name string, dict *parser.Dictionary, encoded []byte, inline bool) {
+	width := int(dict.GetInteger("Width"))
+	height := int(dict.GetInteger("Height"))
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	data, filter, err := w.e.decodeFilters(encoded, w.e.filterNames(dict.Get("Filter"), inline), dict.Get("DecodeParms"))
+	if err != nil {
+		return
+	}
+
+	img := &PlacedImage{
+		Name:             name,
+		Inline:           inline,
+		Width:            width,
+		Height:           height,
+		BitsPerComponent: int(dict.GetInteger("BitsPerComponent")),
+		Filter:           filter,
+		Data:             data,
+		Encoded:          encoded,
+		CTM:              w.ctm,
+	}
+	if dict.GetBoolean("ImageMask") {
+		img.BitsPerComponent = 1
+	} else {
+		img.ColorSpace = w.colorSpace(dict.Get("ColorSpace"), inline)
+	}
+	if img.BitsPerComponent == 0 && filter == "DCTDecode" {
+		img.BitsPerComponent = 8
+	}
+	w.images = append(w.images, img)
+}
+
+// colorSpace returns the family name of an image color space. Inline
+// images may name a color space resource.
+func (w *imageWalker) colorSpace(obj parser.PdfObject, inline bool) string {
+	obj = w.e.resolve(obj)
+	if name, ok := obj.(*parser.Name); ok && inline {
+		if _, abbreviated := inlineImageNames[name.Value()]; !abbreviated {
+			if res := w.resource("ColorSpace", name.Value()); res != nil {
+				obj = w.e.resolve(res)
+			}
+		}
+	}
+
+	switch cs := obj.(type) {
+	case *parser.Name:
+		return inlineImageName(cs.Value(), inline)
+	case *parser.Array:
+		if cs.Len() > 0 {
+			if name, ok := w.e.resolve(cs.Get(0)).(*parser.Name); ok {
+				return inlineImageName(name.Value(), inline)
+			}
+		}
+	}
+	return ""
+}
+
+// resource looks up a named resource in a resource category.
+func (w *imageWalker) resource(category, name string) parser.PdfObject {
+	if w.resources == nil {
+		return nil
+	}
+	dict, ok := w.e.resolve(w.resources.Get(category)).(*parser.Dictionary)
+	if !ok {
+		return nil
+	}
+	return dict.Get(name)
+}
+
+// inlineImageDict returns an inline image dictionary with its abbreviated
+// keys expanded, so it can be read like an image XObject dictionary.
+func inlineImageDict(dict *parser.Dictionary) *parser.Dictionary {
+	expanded := parser.NewDictionary()
+	for _, key := range dict.Keys() {
+		full, ok := inlineImageKeys[key]
+		if !ok {
+			full = key
+		}
+		expanded.Set(full, dict.Get(key))
+	}
+	return expanded
+}
+
+// concat returns the matrix that applies m, then n (m × n in the
+// row-vector notation of the PDF specification, as cm uses it).
+//
+// Reference: PDF 1.7 specification, Section 8.3.4 (Transformation Matrices).
+func concat(m, n Matrix) Matrix {
+	return Matrix{
+		A: m.A*n.A + m.B*n.C,
+		B: m.A*n.B + m.B*n.D,
+		C: m.C*n.A + m.D*n.C,
+		D: m.C*n.B + m.D*n.D,
+		E: m.E*n.A + m.F*n.C + n.E,
+		F: m.E*n.B + m.F*n.D + n.F,
+	}
+}
+
+// matrixFromOperands reads a matrix from six numbers.
+func matrixFromOperands(args []parser.PdfObject) (Matrix, bool) {
+	if len(args) != 6 {
+		return Matrix{}, false
+	}
+	var v [6]float64
+	for i, arg := range args {
+		n := getNumber(arg)
+		if n == nil {
+			return Matrix{}, false
+		}
+		v[i] = *n
+	}
+	return NewMatrix(v[0], v[1], v[2], v[3], v[4], v[5]), true
+}
@@ -0,0 +1,37 @@
+package extractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageWalker_InlineImage(t *testing.T) {
+	w := &imageWalker{e: NewImageExtractor(nil), ctm: Identity()}
+	content := []byte(`1 0 0 1 50 0 cm
+q 0 1 -1 0 0 0 cm 20 0 0 10 100 200 cm
+BI /W 2 /H 1 /CS /G /BPC 8 /F /A85 ID !<3~> EI
+Q
+BI /W 1 /H 1 /IM true ID
+` + "\x80" + ` EI`)
+	require.NoError(t, w.run(content))
+	require.Len(t, w.images, 2)
+
+	img := w.images[0]
+	assert.True(t, img.Inline)
+	assert.Equal(t, 2, img.Width)
+	assert.Equal(t, 1, img.Height)
+	assert.Equal(t, "DeviceGray", img.ColorSpace)
+	assert.Equal(t, "", img.Filter)
+	assert.Equal(t, []byte{0x00, 0xFF}, img.Data)
+	assert.Equal(t, []byte("!<3~>"), img.Encoded)
+	// Scaled and placed, then rotated by 90 degrees and shifted right.
+	assert.Equal(t, NewMatrix(0, 20, -10, 0, -200+50, 100), img.CTM)
+
+	mask := w.images[1]
+	assert.Equal(t, 1, mask.BitsPerComponent)
+	assert.Equal(t, "", mask.ColorSpace)
+	assert.Equal(t, []byte{0x80}, mask.Data)
+	assert.Equal(t, Translation(50, 0), mask.CTM, "Q restores the CTM")
+}
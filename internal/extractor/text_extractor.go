@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"strings"
 
 	"github.com/coregx/gxpdf/internal/parser"
@@ -29,9 +30,10 @@ import (
 type TextExtractor struct {
 	reader        *parser.Reader
 	textState     *TextState
-	elements      []*TextElement
 	fontDecoders  map[string]*FontDecoder // fontName -> FontDecoder
+	fontWidths    map[string]*fontWidths  // fontName -> glyph widths
 	pageResources *parser.Dictionary      // Current page resources
+	visit         func(*TextElement)      // Receives each element (see WalkPage)
 }
 
 // NewTextExtractor creates a new TextExtractor for the given PDF reader.
@@ -39,8 +41,8 @@ func NewTextExtractor(reader *parser.Reader) *TextExtractor {
 	return &TextExtractor{
 		reader:       reader,
 		textState:    NewTextState(),
-		elements:     []*TextElement{},
 		fontDecoders: make(map[string]*FontDecoder),
+		fontWidths:   make(map[string]*fontWidths),
 	}
 }
 
@@ -50,15 +52,41 @@ func NewTextExtractor(reader *parser.Reader) *TextExtractor {
 //
 // Returns a slice of TextElements with position information, or error if extraction fails.
 func (te *TextExtractor) ExtractFromPage(pageNum int) ([]*TextElement, error) {
+	elements := []*TextElement{}
+	err := te.WalkPage(pageNum, func(elem *TextElement) {
+		elements = append(elements, elem)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// WalkPage calls visit for each text element of the specified page, in
+// content stream order, as the elements are decoded.
+//
+// Unlike ExtractFromPage, elements are not collected and operators are
+// parsed one at a time (see ContentParser.WalkOperators). The page's
+// decoded content streams are still held in memory while walking, so
+// memory use grows with the size of the content, not with the number of
+// text elements.
+//
+// If the content stream is malformed, elements before the error have
+// already been visited when the error is returned.
+//
+// Page numbers are 0-based (first page is 0).
+func (te *TextExtractor) WalkPage(pageNum int, visit func(*TextElement)) error {
 	// Reset state
-	te.elements = []*TextElement{}
 	te.textState = NewTextState()
 	te.fontDecoders = make(map[string]*FontDecoder)
+	te.fontWidths = make(map[string]*fontWidths)
+	te.visit = visit
+	defer func() { te.visit = nil }()
 
 	// Get page
 	page, err := te.reader.GetPage(pageNum)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get page %d: %w", pageNum, err)
+		return fmt.Errorf("failed to get page %d: %w", pageNum, err)
 	}
 
 	// Store page resources for font loading
@@ -67,27 +95,21 @@ func (te *TextExtractor) ExtractFromPage(pageNum int) ([]*TextElement, error) {
 	// Get content stream(s)
 	contentData, err := te.getPageContent(page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get page content: %w", err)
+		return fmt.Errorf("failed to get page content: %w", err)
 	}
 
-	// If no content, return empty list
+	// If no content, there is nothing to visit
 	if len(contentData) == 0 {
-		return []*TextElement{}, nil
+		return nil
 	}
 
-	// Parse content stream operators
+	// Parse content stream operators, extracting text as they are read
 	contentParser := NewContentParser(contentData)
-	operators, err := contentParser.ParseOperators()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse content stream: %w", err)
-	}
-
-	// Process operators to extract text
-	for _, op := range operators {
-		te.processOperator(op)
+	if err := contentParser.WalkOperators(te.processOperator); err != nil {
+		return fmt.Errorf("failed to parse content stream: %w", err)
 	}
 
-	return te.elements, nil
+	return nil
 }
 
 // getPageContent retrieves and decodes the content stream(s) for a page.
@@ -297,8 +319,9 @@ func (te *TextExtractor) processOperator(op *Operator) {
 		if len(op.Operands) >= 2 {
 			if name, ok := op.Operands[0].(*parser.Name); ok {
 				te.textState.FontName = name.Value()
-				// Load font decoder for this font (lazy loading)
+				// Load font decoder and widths for this font (lazy loading)
 				te.loadFontDecoder(name.Value())
+				te.loadFontWidths(name.Value())
 			}
 			if num := getNumber(op.Operands[1]); num != nil {
 				te.textState.FontSize = *num
@@ -390,10 +413,13 @@ func (te *TextExtractor) processOperator(op *Operator) {
 	}
 }
 
-// addTextBytes adds text from raw glyph bytes to the extracted elements.
+// addTextBytes passes text from raw glyph bytes to the visitor.
 //
 // This creates a TextElement with the current position from the text matrix.
-// The text is decoded from glyph bytes to Unicode using the current font's CMap/encoding.
+// The text is decoded from glyph bytes to Unicode using the current font's CMap/encoding,
+// and the text position advances by the glyph widths plus character and word spacing.
+//
+// Reference: PDF 1.7 specification, Section 9.4.4 (Text Space Details).
 func (te *TextExtractor) addTextBytes(glyphBytes []byte) {
 	if len(glyphBytes) == 0 {
 		return
@@ -406,15 +432,31 @@ func (te *TextExtractor) addTextBytes(glyphBytes []byte) {
 	x := te.textState.CurrentX
 	y := te.textState.CurrentY
 
-	// Estimate width (simple heuristic - will be improved with font metrics in Phase 3)
-	// Use decoded text length for more accurate width calculation
-	charWidth := te.textState.FontSize * 0.6 * (te.textState.HorizScale / 100.0)
-	width := float64(len(decodedText)) * charWidth
-	height := te.textState.FontSize
+	// Width in text space: tx = (w0 / 1000 * Tfs + Tc + Tw) * Th, with Tw
+	// applied to single-byte code 32 only
+	fw := te.fontWidths[te.textState.FontName]
+	if fw == nil {
+		fw = &fontWidths{defaultWidth: defaultGlyphWidth}
+	}
+	var width float64
+	for _, code := range fw.codes(glyphBytes) {
+		width += fw.glyphWidth(code)/1000*te.textState.FontSize + te.textState.CharSpace
+		if code == ' ' && !fw.twoByte {
+			width += te.textState.WordSpace
+		}
+	}
+	width *= te.textState.HorizScale / 100.0
+
+	// Element size in user space (the text matrix may scale text space)
+	tm := te.textState.Tm
+	userWidth := width * math.Hypot(tm.A, tm.B)
+	height := te.textState.FontSize * math.Hypot(tm.C, tm.D)
 
 	// Create text element with decoded text
-	elem := NewTextElement(decodedText, x, y, width, height, te.textState.FontName, te.textState.FontSize)
-	te.elements = append(te.elements, elem)
+	elem := NewTextElement(decodedText, x, y, userWidth, height, te.textState.FontName, te.textState.FontSize)
+	if te.visit != nil {
+		te.visit(elem)
+	}
 
 	// Advance text position
 	te.textState.AdvanceX(width)
@@ -446,7 +488,7 @@ func (te *TextExtractor) processTextArray(arr *parser.Array) {
 			if num := getNumber(obj); num != nil {
 				// Negative values move forward, positive values move backward
 				// The unit is 1/1000 of a text space unit
-				adjustment := -*num / 1000.0 * te.textState.FontSize
+				adjustment := -*num / 1000.0 * te.textState.FontSize * te.textState.HorizScale / 100.0
 				te.textState.AdvanceX(adjustment)
 			}
 		}
@@ -515,52 +557,10 @@ func (te *TextExtractor) loadFontDecoder(fontName string) {
 		return
 	}
 
-	// Get Font dictionary from Resources
-	fontsObj := te.pageResources.Get("Font")
-	if fontsObj == nil {
-		// No fonts in resources - use default decoder
-		te.fontDecoders[fontName] = NewFontDecoder(nil, "", false)
-		return
-	}
-
-	// Resolve Font dictionary
-	var fontsDict *parser.Dictionary
-	if ref, ok := fontsObj.(*parser.IndirectReference); ok {
-		resolved, err := te.reader.GetObject(ref.Number)
-		if err == nil {
-			fontsDict, _ = resolved.(*parser.Dictionary)
-		}
-	} else {
-		fontsDict, _ = fontsObj.(*parser.Dictionary)
-	}
-
-	if fontsDict == nil {
-		// Font dictionary not found - use default decoder
-		te.fontDecoders[fontName] = NewFontDecoder(nil, "", false)
-		return
-	}
-
-	// Get the specific font object
-	fontObj := fontsDict.Get(fontName)
-	if fontObj == nil {
-		// Font not found - use default decoder
-		te.fontDecoders[fontName] = NewFontDecoder(nil, "", false)
-		return
-	}
-
-	// Resolve font object
-	var fontDict *parser.Dictionary
-	if ref, ok := fontObj.(*parser.IndirectReference); ok {
-		resolved, err := te.reader.GetObject(ref.Number)
-		if err == nil {
-			fontDict, _ = resolved.(*parser.Dictionary)
-		}
-	} else {
-		fontDict, _ = fontObj.(*parser.Dictionary)
-	}
-
+	// Get the font dictionary from Resources
+	fontDict := te.fontDict(fontName)
 	if fontDict == nil {
-		// Font dictionary not resolved - use default decoder
+		// Font not found - use default decoder
 		te.fontDecoders[fontName] = NewFontDecoder(nil, "", false)
 		return
 	}
@@ -0,0 +1,87 @@
+package extractor
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Layout thresholds, as fractions of the text height (the font size in
+// user space).
+const (
+	// lineTolerance is how far apart two baselines may be and still be
+	// the same line (subscripts, superscripts, mixed font sizes).
+	lineTolerance = 0.5
+
+	// wordGap is the horizontal gap between two elements above which a
+	// space is inserted. Kerning in TJ arrays stays well below it; a
+	// space glyph is about 0.25 em.
+	wordGap = 0.15
+)
+
+// LayoutText joins text elements into plain text in reading order.
+//
+// Elements are grouped into lines by baseline, lines run from the top of
+// the page down and the elements of a line from left to right. A space is
+// inserted between neighboring elements separated by a visible gap
+// (unless one of them already has whitespace there), and lines are
+// separated by "\n".
+//
+// Example:
+//
+//	elements, err := te.ExtractFromPage(0)
+//	if err != nil {
+//	    return err
+//	}
+//	text := LayoutText(elements)
+func LayoutText(elements []*TextElement) string {
+	sorted := make([]*TextElement, 0, len(elements))
+	for _, elem := range elements {
+		if elem.Text != "" {
+			sorted = append(sorted, elem)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Y > sorted[j].Y
+	})
+
+	var lines [][]*TextElement
+	for _, elem := range sorted {
+		if n := len(lines); n > 0 {
+			first := lines[n-1][0]
+			if first.Y-elem.Y <= lineTolerance*max(first.Height, elem.Height, 1) {
+				lines[n-1] = append(lines[n-1], elem)
+				continue
+			}
+		}
+		lines = append(lines, []*TextElement{elem})
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		sort.SliceStable(line, func(i, j int) bool {
+			return line[i].X < line[j].X
+		})
+		for j, elem := range line {
+			if j > 0 && needsSpace(line[j-1], elem) {
+				b.WriteByte(' ')
+			}
+			b.WriteString(elem.Text)
+		}
+	}
+	return b.String()
+}
+
+// needsSpace reports whether a space separates prev and next on a line.
+func needsSpace(prev, next *TextElement) bool {
+	if next.X-prev.Right() <= wordGap*max(prev.Height, next.Height) {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(prev.Text)
+	first, _ := utf8.DecodeRuneInString(next.Text)
+	return !unicode.IsSpace(last) && !unicode.IsSpace(first)
+}
@@ -0,0 +1,75 @@
+package extractor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutText(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []*TextElement
+		want     string
+	}{
+		{
+			name:     "empty",
+			elements: nil,
+			want:     "",
+		},
+		{
+			name: "lines top to bottom",
+			elements: []*TextElement{
+				NewTextElement("Second", 72, 680, 40, 12, "F1", 12),
+				NewTextElement("First", 72, 700, 30, 12, "F1", 12),
+			},
+			want: "First\nSecond",
+		},
+		{
+			name: "gap inserts space",
+			elements: []*TextElement{
+				NewTextElement("World", 110, 700, 30, 12, "F1", 12),
+				NewTextElement("Hello", 72, 700, 30, 12, "F1", 12),
+			},
+			want: "Hello World",
+		},
+		{
+			name: "kerning does not",
+			elements: []*TextElement{
+				NewTextElement("Wa", 72, 700, 15, 12, "F1", 12),
+				NewTextElement("ter", 86.5, 700, 15, 12, "F1", 12),
+			},
+			want: "Water",
+		},
+		{
+			name: "existing whitespace kept",
+			elements: []*TextElement{
+				NewTextElement("Name: ", 72, 700, 40, 12, "F1", 12),
+				NewTextElement("Value", 130, 700, 30, 12, "F1", 12),
+			},
+			want: "Name: Value",
+		},
+		{
+			name: "superscript stays on the line",
+			elements: []*TextElement{
+				NewTextElement("E = mc", 72, 700, 40, 12, "F1", 12),
+				NewTextElement("2", 112, 704, 4, 8, "F1", 8),
+			},
+			want: "E = mc2",
+		},
+		{
+			name: "empty elements skipped",
+			elements: []*TextElement{
+				NewTextElement("", 72, 650, 0, 12, "F1", 12),
+				NewTextElement("Only", 72, 700, 30, 12, "F1", 12),
+			},
+			want: "Only",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LayoutText(tt.elements))
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// cffEndChar is a Type 2 charstring with an empty outline (endchar).
+var cffEndChar = []byte{14}
+
+// subsetCFF returns a copy of the OpenType font data in which the
+// charstrings of all glyphs not in keep are replaced by an empty outline.
+//
+// The CharStrings INDEX is rewritten in place: it only shrinks, and the
+// bytes it no longer uses are zeroed, so no other offset in the font
+// changes and glyph IDs stay the same. The zeroed bytes cost next to
+// nothing once the font stream is compressed.
+//
+// Reference: Adobe Technical Note #5176 (The Compact Font Format).
+func subsetCFF(font *TTFFont, keep map[uint16]bool) ([]byte, error) {
+	table, ok := font.Tables["CFF "]
+	if !ok {
+		return nil, errors.New("font has no CFF table")
+	}
+
+	cff := table.Data
+	csOffset, err := cffCharStringsOffset(cff)
+	if err != nil {
+		return nil, err
+	}
+	charStrings, csEnd, err := readCFFIndex(cff, csOffset)
+	if err != nil {
+		return nil, fmt.Errorf("read CharStrings: %w", err)
+	}
+	if len(charStrings) == 0 {
+		return nil, errors.New("CFF has no glyphs")
+	}
+
+	subset := make([][]byte, len(charStrings))
+	for gid, cs := range charStrings {
+		//nolint:gosec // CFF fonts have at most 65535 glyphs.
+		if keep[uint16(gid)] {
+			subset[gid] = cs
+		} else {
+			subset[gid] = cffEndChar
+		}
+	}
+	index := writeCFFIndex(subset, cff[csOffset+2])
+
+	out := make([]byte, len(font.FontData))
+	copy(out, font.FontData)
+	region := out[int(table.Offset)+csOffset : int(table.Offset)+csEnd]
+	clear(region[copy(region, index):])
+
+	updateChecksums(out, table)
+	return out, nil
+}
+
+// cffCharStringsOffset returns the offset of the CharStrings INDEX,
+// read from the Top DICT of the first font in the CFF data.
+func cffCharStringsOffset(cff []byte) (int, error) {
+	if len(cff) < 4 {
+		return 0, errors.New("CFF header too short")
+	}
+
+	// Header, then Name INDEX, then Top DICT INDEX.
+	_, off, err := readCFFIndex(cff, int(cff[2]))
+	if err != nil {
+		return 0, fmt.Errorf("read Name INDEX: %w", err)
+	}
+	topDicts, _, err := readCFFIndex(cff, off)
+	if err != nil {
+		return 0, fmt.Errorf("read Top DICT INDEX: %w", err)
+	}
+	if len(topDicts) == 0 {
+		return 0, errors.New("CFF has no Top DICT")
+	}
+
+	operands, err := cffDictOperands(topDicts[0], 17) // CharStrings
+	if err != nil {
+		return 0, err
+	}
+	if len(operands) != 1 || operands[0] <= 0 || operands[0] >= len(cff) {
+		return 0, errors.New("invalid CharStrings offset")
+	}
+	return operands[0], nil
+}
+
+// readCFFIndex reads the INDEX at off and returns its entries and the
+// offset of the first byte after it.
+//
+// INDEX format: count (Card16), offSize (1-4), count+1 offsets (1-based,
+// relative to the byte before the data), data.
+func readCFFIndex(cff []byte, off int) ([][]byte, int, error) {
+	if off+2 > len(cff) {
+		return nil, 0, errors.New("INDEX out of bounds")
+	}
+	count := int(binary.BigEndian.Uint16(cff[off:]))
+	if count == 0 {
+		return nil, off + 2, nil
+	}
+	if off+3 > len(cff) {
+		return nil, 0, errors.New("INDEX out of bounds")
+	}
+	offSize := int(cff[off+2])
+	if offSize < 1 || offSize > 4 {
+		return nil, 0, fmt.Errorf("invalid INDEX offset size: %d", offSize)
+	}
+
+	offsets := off + 3
+	dataStart := offsets + (count+1)*offSize - 1
+	if dataStart >= len(cff) {
+		return nil, 0, errors.New("INDEX out of bounds")
+	}
+	readOffset := func(i int) int {
+		v := 0
+		for _, b := range cff[offsets+i*offSize : offsets+(i+1)*offSize] {
+			v = v<<8 | int(b)
+		}
+		return dataStart + v
+	}
+
+	entries := make([][]byte, count)
+	start := readOffset(0)
+	for i := range entries {
+		end := readOffset(i + 1)
+		if end < start || end > len(cff) {
+			return nil, 0, errors.New("INDEX offsets out of bounds")
+		}
+		entries[i] = cff[start:end]
+		start = end
+	}
+	return entries, start, nil
+}
+
+// writeCFFIndex encodes entries as an INDEX with the given offset size.
+func writeCFFIndex(entries [][]byte, offSize byte) []byte {
+	buf := binary.BigEndian.AppendUint16(nil, uint16(len(entries))) //nolint:gosec // Glyph count fits Card16.
+	buf = append(buf, offSize)
+
+	offset := 1
+	writeOffset := func() {
+		for i := int(offSize) - 1; i >= 0; i-- {
+			buf = append(buf, byte(offset>>(8*i)))
+		}
+	}
+	writeOffset()
+	for _, e := range entries {
+		offset += len(e)
+		writeOffset()
+	}
+	for _, e := range entries {
+		buf = append(buf, e...)
+	}
+	return buf
+}
+
+// cffDictOperands returns the integer operands of operator op in a DICT.
+// Real-number operands are skipped; op must take integer operands.
+func cffDictOperands(dict []byte, op byte) ([]int, error) {
+	var operands []int
+	for i := 0; i < len(dict); {
+		b0 := dict[i]
+		switch {
+		case b0 <= 21: // Operator.
+			if b0 == 12 {
+				i++ // Two-byte operator; none of them is looked up.
+			} else if b0 == op {
+				return operands, nil
+			}
+			operands = operands[:0]
+			i++
+		case b0 == 28:
+			if i+3 > len(dict) {
+				return nil, errors.New("truncated DICT")
+			}
+			operands = append(operands, int(int16(binary.BigEndian.Uint16(dict[i+1:])))) //nolint:gosec // Signed by definition.
+			i += 3
+		case b0 == 29:
+			if i+5 > len(dict) {
+				return nil, errors.New("truncated DICT")
+			}
+			operands = append(operands, int(int32(binary.BigEndian.Uint32(dict[i+1:])))) //nolint:gosec // Signed by definition.
+			i += 5
+		case b0 == 30: // Real number: nibbles up to an 0xf terminator.
+			for i++; i < len(dict) && dict[i]&0x0f != 0x0f && dict[i]&0xf0 != 0xf0; i++ {
+			}
+			operands = append(operands, 0)
+			i++
+		case b0 >= 32 && b0 <= 246:
+			operands = append(operands, int(b0)-139)
+			i++
+		case b0 >= 247 && b0 <= 254:
+			if i+2 > len(dict) {
+				return nil, errors.New("truncated DICT")
+			}
+			v := (int(b0)-247)*256 + int(dict[i+1]) + 108
+			if b0 >= 251 {
+				v = -(int(b0)-251)*256 - int(dict[i+1]) - 108
+			}
+			operands = append(operands, v)
+			i += 2
+		default:
+			return nil, fmt.Errorf("invalid DICT byte: %d", b0)
+		}
+	}
+	return nil, fmt.Errorf("DICT operator %d not found", op)
+}
+
+// updateChecksums recomputes the checksum of table and the head table's
+// checkSumAdjustment after the table's data in font has changed.
+func updateChecksums(font []byte, table *TTFTable) {
+	numTables := int(binary.BigEndian.Uint16(font[4:]))
+	for i := range numTables {
+		entry := font[12+16*i:]
+		if string(entry[:4]) == table.Tag {
+			binary.BigEndian.PutUint32(entry[4:], sfntChecksum(font[table.Offset:table.Offset+table.Length]))
+		}
+	}
+
+	for i := range numTables {
+		entry := font[12+16*i:]
+		if string(entry[:4]) == "head" {
+			adjustment := font[binary.BigEndian.Uint32(entry[8:])+8:]
+			binary.BigEndian.PutUint32(adjustment, 0)
+			binary.BigEndian.PutUint32(adjustment, 0xB1B0AFBA-sfntChecksum(font))
+		}
+	}
+}
+
+// sfntChecksum returns the sum of data as big-endian uint32 words,
+// zero-padded to a multiple of four bytes.
+func sfntChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
@@ -0,0 +1,123 @@
+package fonts
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"testing"
+)
+
+// cffTestFont is an OpenType font with CFF outlines and the glyphs
+// .notdef, zero, one, uni4E2D and Q.
+const cffTestFont = "../../testdata/fonts/CFFTest.otf"
+
+// TestLoadTTF_CFF tests that "OTTO" fonts are parsed and marked as CFF.
+func TestLoadTTF_CFF(t *testing.T) {
+	font, err := LoadTTF(cffTestFont)
+	if err != nil {
+		t.Fatalf("LoadTTF failed: %v", err)
+	}
+
+	if !font.IsCFF {
+		t.Error("expected IsCFF for OTTO font")
+	}
+	if font.UnitsPerEm != 1000 {
+		t.Errorf("UnitsPerEm = %d, want 1000", font.UnitsPerEm)
+	}
+	if gid := font.CharToGlyph['0']; gid != 1 {
+		t.Errorf("glyph for '0' = %d, want 1", gid)
+	}
+	if w := font.GlyphWidths[font.CharToGlyph['Q']]; w != 1000 {
+		t.Errorf("width of 'Q' = %d, want 1000", w)
+	}
+}
+
+// TestSubsetCFF tests that unused glyphs lose their charstrings.
+func TestSubsetCFF(t *testing.T) {
+	font, err := LoadTTF(cffTestFont)
+	if err != nil {
+		t.Fatalf("LoadTTF failed: %v", err)
+	}
+	original := charStrings(t, font.Tables["CFF "].Data)
+
+	subset := NewFontSubset(font)
+	subset.UseString("0Q")
+	if err := subset.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(subset.SubsetData))
+	if err != nil {
+		t.Fatalf("zlib.NewReader failed: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress failed: %v", err)
+	}
+
+	if len(data) != len(font.FontData) {
+		t.Fatalf("subset size = %d, want %d (layout is kept)", len(data), len(font.FontData))
+	}
+	if sum := sfntChecksum(data); sum != 0xB1B0AFBA {
+		t.Errorf("font checksum = 0x%08X, want 0xB1B0AFBA", sum)
+	}
+
+	table := font.Tables["CFF "]
+	got := charStrings(t, data[table.Offset:table.Offset+table.Length])
+	if len(got) != len(original) {
+		t.Fatalf("glyph count = %d, want %d", len(got), len(original))
+	}
+	for gid, cs := range got {
+		kept := gid == 0 || gid == int(font.CharToGlyph['0']) || gid == int(font.CharToGlyph['Q'])
+		switch {
+		case kept && !bytes.Equal(cs, original[gid]):
+			t.Errorf("glyph %d: charstring changed", gid)
+		case !kept && !bytes.Equal(cs, cffEndChar):
+			t.Errorf("glyph %d: charstring = %v, want endchar", gid, cs)
+		}
+	}
+}
+
+// TestCFFDictOperands tests decoding of DICT operand encodings.
+func TestCFFDictOperands(t *testing.T) {
+	dict := []byte{
+		139, 1, // 0 Notice
+		30, 0x1a, 0x2f, 2, // 1.2 FullName (real operand)
+		247, 0, 251, 0, 15, // 108 -108 charset
+		28, 0x01, 0x00, 29, 0x00, 0x01, 0x00, 0x00, 17, // 256 65536 CharStrings
+	}
+
+	got, err := cffDictOperands(dict, 15)
+	if err != nil {
+		t.Fatalf("operator 15: %v", err)
+	}
+	if len(got) != 2 || got[0] != 108 || got[1] != -108 {
+		t.Errorf("operator 15 operands = %v, want [108 -108]", got)
+	}
+
+	got, err = cffDictOperands(dict, 17)
+	if err != nil {
+		t.Fatalf("operator 17: %v", err)
+	}
+	if len(got) != 2 || got[0] != 256 || got[1] != 65536 {
+		t.Errorf("operator 17 operands = %v, want [256 65536]", got)
+	}
+
+	if _, err := cffDictOperands(dict, 18); err == nil {
+		t.Error("expected error for missing operator")
+	}
+}
+
+// charStrings returns the CharStrings INDEX entries of a CFF table.
+func charStrings(t *testing.T, cff []byte) [][]byte {
+	t.Helper()
+	off, err := cffCharStringsOffset(cff)
+	if err != nil {
+		t.Fatalf("cffCharStringsOffset failed: %v", err)
+	}
+	entries, _, err := readCFFIndex(cff, off)
+	if err != nil {
+		t.Fatalf("readCFFIndex failed: %v", err)
+	}
+	return entries
+}
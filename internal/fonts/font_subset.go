@@ -26,6 +26,10 @@ type FontSubset struct {
 	// GlyphMapping maps old glyph IDs to new glyph IDs.
 	GlyphMapping map[uint16]uint16
 
+	// GlyphText maps glyphs that stand for more than one character, such
+	// as ligatures, to the text they represent (see UseGlyph).
+	GlyphText map[uint16]string
+
 	// SubsetData is the compressed font data (for embedding).
 	SubsetData []byte
 }
@@ -36,6 +40,7 @@ func NewFontSubset(font *TTFFont) *FontSubset {
 		BaseFont:     font,
 		UsedChars:    make(map[rune]bool),
 		GlyphMapping: make(map[uint16]uint16),
+		GlyphText:    make(map[uint16]string),
 	}
 }
 
@@ -51,6 +56,26 @@ func (s *FontSubset) UseString(text string) {
 	}
 }
 
+// UseGlyph marks a glyph that is not reached through the character map,
+// such as an "fi" ligature, as used, and records the text it stands for
+// so that the ToUnicode CMap maps it back to that text.
+func (s *FontSubset) UseGlyph(glyphID uint16, text string) {
+	s.GlyphText[glyphID] = text
+}
+
+// ForceInclude marks characters as used even if the document content
+// does not use them, so the subset can render them later (for example,
+// form field values filled in by an incremental update).
+//
+// Characters the font has no glyph for are ignored.
+func (s *FontSubset) ForceInclude(chars []rune) {
+	for _, ch := range chars {
+		if _, ok := s.BaseFont.CharToGlyph[ch]; ok {
+			s.UseChar(ch)
+		}
+	}
+}
+
 // Build builds the font subset.
 //
 // This process:
@@ -67,10 +92,22 @@ func (s *FontSubset) Build() error {
 	// Create glyph mapping (old ID -> new ID).
 	s.createGlyphMapping(usedGlyphs)
 
-	// For MVP, we'll embed the full font data (no actual subsetting).
-	// Real subsetting requires rebuilding TTF tables, which is complex.
-	// This is acceptable for MVP - subsetting can be optimized later.
-	if err := s.compressFont(); err != nil {
+	// TrueType fonts are embedded in full (no actual subsetting yet);
+	// rebuilding glyf/loca is left for later. CFF fonts keep only the
+	// charstrings of the used glyphs.
+	data := s.BaseFont.FontData
+	if s.BaseFont.IsCFF {
+		keep := make(map[uint16]bool, len(usedGlyphs))
+		for _, gid := range usedGlyphs {
+			keep[gid] = true
+		}
+		var err error
+		if data, err = subsetCFF(s.BaseFont, keep); err != nil {
+			return fmt.Errorf("subset CFF: %w", err)
+		}
+	}
+
+	if err := s.compressFont(data); err != nil {
 		return fmt.Errorf("compress font: %w", err)
 	}
 
@@ -90,6 +127,9 @@ func (s *FontSubset) identifyUsedGlyphs() []uint16 {
 			glyphSet[glyphID] = true
 		}
 	}
+	for glyphID := range s.GlyphText {
+		glyphSet[glyphID] = true
+	}
 
 	// Convert to sorted slice.
 	glyphs := make([]uint16, 0, len(glyphSet))
@@ -112,11 +152,11 @@ func (s *FontSubset) createGlyphMapping(usedGlyphs []uint16) {
 }
 
 // compressFont compresses the font data using FlateDecode.
-func (s *FontSubset) compressFont() error {
+func (s *FontSubset) compressFont(data []byte) error {
 	var buf bytes.Buffer
 	w := zlib.NewWriter(&buf)
 
-	if _, err := w.Write(s.BaseFont.FontData); err != nil {
+	if _, err := w.Write(data); err != nil {
 		_ = w.Close() // Best effort cleanup.
 		return fmt.Errorf("write font data: %w", err)
 	}
@@ -146,9 +186,9 @@ func (s *FontSubset) GetCharWidth(ch rune) uint16 {
 
 // MeasureString returns the width of a string in points.
 func (s *FontSubset) MeasureString(text string, size float64) float64 {
-	var totalWidth uint16
+	var totalWidth int
 	for _, ch := range text {
-		totalWidth += s.GetCharWidth(ch)
+		totalWidth += int(s.GetCharWidth(ch))
 	}
 
 	// Convert from font units to points.
@@ -233,3 +233,58 @@ func TestIdentifyUsedGlyphs(t *testing.T) {
 		t.Errorf("expected glyph 1 at index 1, got %d", glyphs[1])
 	}
 }
+
+// TestUseGlyph tests that glyphs without a character are kept.
+func TestUseGlyph(t *testing.T) {
+	font := &TTFFont{
+		UnitsPerEm:  1000,
+		CharToGlyph: map[rune]uint16{'f': 1, 'i': 2},
+	}
+	subset := NewFontSubset(font)
+
+	subset.UseGlyph(7, "fi")
+
+	glyphs := subset.identifyUsedGlyphs()
+	if len(glyphs) != 2 || glyphs[1] != 7 {
+		t.Errorf("expected glyphs [0 7], got %v", glyphs)
+	}
+	if subset.GlyphText[7] != "fi" {
+		t.Errorf("GlyphText[7] = %q, want %q", subset.GlyphText[7], "fi")
+	}
+}
+
+// TestForceInclude tests forcing characters into the subset.
+func TestForceInclude(t *testing.T) {
+	font := &TTFFont{
+		UnitsPerEm: 1000,
+		GlyphWidths: map[uint16]uint16{
+			0: 0,
+			1: 500,
+			2: 600,
+		},
+		CharToGlyph: map[rune]uint16{
+			'A': 1,
+			'B': 2,
+		},
+	}
+	subset := NewFontSubset(font)
+
+	subset.UseChar('A')
+	subset.ForceInclude([]rune{'B', 'Z'})
+
+	if !subset.UsedChars['B'] {
+		t.Error("forced character 'B' not included")
+	}
+	if subset.UsedChars['Z'] {
+		t.Error("character 'Z' without glyph should be ignored")
+	}
+
+	// Forced characters contribute glyphs and widths.
+	if glyphs := subset.identifyUsedGlyphs(); len(glyphs) != 3 {
+		t.Errorf("expected 3 glyphs, got %d", len(glyphs))
+	}
+	first, last, widths := subset.GetWidths()
+	if first != 'A' || last != 'B' || widths[1] != 600 {
+		t.Errorf("GetWidths() = %d, %d, %v", first, last, widths)
+	}
+}
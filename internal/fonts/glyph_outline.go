@@ -0,0 +1,332 @@
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// OutlinePoint is a point of a TrueType glyph contour in font units.
+//
+// Off-curve points are quadratic Bézier control points. Two consecutive
+// off-curve points imply an on-curve point midway between them.
+type OutlinePoint struct {
+	X, Y    float64
+	OnCurve bool
+}
+
+// Simple glyph flags.
+//
+// Reference: OpenType specification, 'glyf' table (Simple Glyph Description).
+const (
+	glyfOnCurve     = 0x01
+	glyfXShort      = 0x02
+	glyfYShort      = 0x04
+	glyfRepeat      = 0x08
+	glyfXSameOrPos  = 0x10
+	glyfYSameOrPos  = 0x20
+	maxGlyphDepth   = 8 // Composite glyph nesting limit
+	glyfHeaderBytes = 10
+)
+
+// Composite glyph flags.
+//
+// Reference: OpenType specification, 'glyf' table (Composite Glyph Description).
+const (
+	compArgsAreWords   = 0x0001
+	compArgsAreXY      = 0x0002
+	compHaveScale      = 0x0008
+	compMoreComponents = 0x0020
+	compHaveXYScale    = 0x0040
+	compHaveTwoByTwo   = 0x0080
+)
+
+// ParseTTF parses a TrueType font from memory.
+//
+// This is the in-memory counterpart of LoadTTF, used for fonts embedded in
+// PDF files (FontFile2 streams).
+func ParseTTF(data []byte) (*TTFFont, error) {
+	font := &TTFFont{
+		Tables:      make(map[string]*TTFTable),
+		GlyphWidths: make(map[uint16]uint16),
+		CharToGlyph: make(map[rune]uint16),
+		FontData:    data,
+	}
+
+	if err := font.parse(data); err != nil {
+		return nil, fmt.Errorf("parse TTF: %w", err)
+	}
+
+	return font, nil
+}
+
+// GlyphOutline returns the contours of a glyph in font units.
+//
+// Composite glyphs are flattened into the contours of their components.
+// Returns nil contours for empty glyphs (such as the space).
+//
+// Returns an error if the font has no 'glyf' outlines (e.g. CFF fonts) or
+// the glyph data is malformed.
+func (f *TTFFont) GlyphOutline(gid uint16) ([][]OutlinePoint, error) {
+	return f.glyphOutline(gid, 0)
+}
+
+// glyphOutline decodes one glyph, recursing into composite components.
+func (f *TTFFont) glyphOutline(gid uint16, depth int) ([][]OutlinePoint, error) {
+	if depth > maxGlyphDepth {
+		return nil, errors.New("composite glyph nesting too deep")
+	}
+
+	data, err := f.glyphData(gid)
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	if len(data) < glyfHeaderBytes {
+		return nil, ErrMalformedGlyph
+	}
+
+	numContours := int16(binary.BigEndian.Uint16(data))
+	if numContours >= 0 {
+		return parseSimpleGlyph(data, int(numContours))
+	}
+	return f.parseCompositeGlyph(data, depth)
+}
+
+// glyphData returns the raw 'glyf' bytes of a glyph using the 'loca' table.
+func (f *TTFFont) glyphData(gid uint16) ([]byte, error) {
+	glyf, ok := f.Tables["glyf"]
+	if !ok {
+		return nil, errors.New("font has no glyf table")
+	}
+	loca, ok := f.Tables["loca"]
+	if !ok {
+		return nil, errors.New("font has no loca table")
+	}
+	head, ok := f.Tables["head"]
+	if !ok || len(head.Data) < 54 {
+		return nil, errors.New("font has no valid head table")
+	}
+
+	// indexToLocFormat: 0 = short offsets (uint16 / 2), 1 = long (uint32).
+	longOffsets := binary.BigEndian.Uint16(head.Data[50:]) != 0
+
+	var start, end int
+	if longOffsets {
+		i := int(gid) * 4
+		if i+8 > len(loca.Data) {
+			return nil, ErrMalformedGlyph
+		}
+		start = int(binary.BigEndian.Uint32(loca.Data[i:]))
+		end = int(binary.BigEndian.Uint32(loca.Data[i+4:]))
+	} else {
+		i := int(gid) * 2
+		if i+4 > len(loca.Data) {
+			return nil, ErrMalformedGlyph
+		}
+		start = int(binary.BigEndian.Uint16(loca.Data[i:])) * 2
+		end = int(binary.BigEndian.Uint16(loca.Data[i+2:])) * 2
+	}
+
+	if start > end || end > len(glyf.Data) {
+		return nil, ErrMalformedGlyph
+	}
+	return glyf.Data[start:end], nil
+}
+
+// parseSimpleGlyph decodes a simple glyph description.
+func parseSimpleGlyph(data []byte, numContours int) ([][]OutlinePoint, error) {
+	if numContours == 0 {
+		return nil, nil
+	}
+
+	p := glyfHeaderBytes
+	if p+numContours*2+2 > len(data) {
+		return nil, ErrMalformedGlyph
+	}
+
+	endPts := make([]int, numContours)
+	for i := range endPts {
+		endPts[i] = int(binary.BigEndian.Uint16(data[p:]))
+		p += 2
+	}
+	numPoints := endPts[numContours-1] + 1
+
+	// Skip instructions.
+	instrLen := int(binary.BigEndian.Uint16(data[p:]))
+	p += 2 + instrLen
+	if p > len(data) {
+		return nil, ErrMalformedGlyph
+	}
+
+	// Flags, with run-length repeats.
+	flags := make([]byte, 0, numPoints)
+	for len(flags) < numPoints {
+		if p >= len(data) {
+			return nil, ErrMalformedGlyph
+		}
+		flag := data[p]
+		p++
+		flags = append(flags, flag)
+		if flag&glyfRepeat != 0 {
+			if p >= len(data) {
+				return nil, ErrMalformedGlyph
+			}
+			for n := int(data[p]); n > 0 && len(flags) < numPoints; n-- {
+				flags = append(flags, flag)
+			}
+			p++
+		}
+	}
+
+	xs, p, err := readGlyphCoords(data, p, flags, glyfXShort, glyfXSameOrPos)
+	if err != nil {
+		return nil, err
+	}
+	ys, _, err := readGlyphCoords(data, p, flags, glyfYShort, glyfYSameOrPos)
+	if err != nil {
+		return nil, err
+	}
+
+	contours := make([][]OutlinePoint, 0, numContours)
+	start := 0
+	for _, end := range endPts {
+		if end < start || end >= numPoints {
+			return nil, ErrMalformedGlyph
+		}
+		contour := make([]OutlinePoint, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			contour = append(contour, OutlinePoint{
+				X:       float64(xs[i]),
+				Y:       float64(ys[i]),
+				OnCurve: flags[i]&glyfOnCurve != 0,
+			})
+		}
+		contours = append(contours, contour)
+		start = end + 1
+	}
+
+	return contours, nil
+}
+
+// readGlyphCoords decodes the delta-encoded x or y coordinate array.
+func readGlyphCoords(data []byte, p int, flags []byte, shortBit, sameBit byte) ([]int, int, error) {
+	coords := make([]int, len(flags))
+	v := 0
+	for i, flag := range flags {
+		switch {
+		case flag&shortBit != 0:
+			if p >= len(data) {
+				return nil, p, ErrMalformedGlyph
+			}
+			d := int(data[p])
+			p++
+			if flag&sameBit == 0 {
+				d = -d
+			}
+			v += d
+		case flag&sameBit == 0:
+			if p+2 > len(data) {
+				return nil, p, ErrMalformedGlyph
+			}
+			v += int(int16(binary.BigEndian.Uint16(data[p:])))
+			p += 2
+		}
+		coords[i] = v
+	}
+	return coords, p, nil
+}
+
+// parseCompositeGlyph decodes a composite glyph by transforming and
+// concatenating its components.
+//
+// Only offset (ARGS_ARE_XY_VALUES) positioning is supported; point-matched
+// components are placed at the origin.
+func (f *TTFFont) parseCompositeGlyph(data []byte, depth int) ([][]OutlinePoint, error) {
+	var contours [][]OutlinePoint
+	p := glyfHeaderBytes
+
+	for {
+		if p+4 > len(data) {
+			return nil, ErrMalformedGlyph
+		}
+		flags := binary.BigEndian.Uint16(data[p:])
+		gid := binary.BigEndian.Uint16(data[p+2:])
+		p += 4
+
+		var dx, dy float64
+		if flags&compArgsAreWords != 0 {
+			if p+4 > len(data) {
+				return nil, ErrMalformedGlyph
+			}
+			dx = float64(int16(binary.BigEndian.Uint16(data[p:])))
+			dy = float64(int16(binary.BigEndian.Uint16(data[p+2:])))
+			p += 4
+		} else {
+			if p+2 > len(data) {
+				return nil, ErrMalformedGlyph
+			}
+			dx = float64(int8(data[p]))
+			dy = float64(int8(data[p+1]))
+			p += 2
+		}
+		if flags&compArgsAreXY == 0 {
+			dx, dy = 0, 0
+		}
+
+		// Transform matrix [a b c d] in F2Dot14.
+		a, b, c, d := 1.0, 0.0, 0.0, 1.0
+		switch {
+		case flags&compHaveScale != 0:
+			if p+2 > len(data) {
+				return nil, ErrMalformedGlyph
+			}
+			a = f2dot14(data[p:])
+			d = a
+			p += 2
+		case flags&compHaveXYScale != 0:
+			if p+4 > len(data) {
+				return nil, ErrMalformedGlyph
+			}
+			a = f2dot14(data[p:])
+			d = f2dot14(data[p+2:])
+			p += 4
+		case flags&compHaveTwoByTwo != 0:
+			if p+8 > len(data) {
+				return nil, ErrMalformedGlyph
+			}
+			a = f2dot14(data[p:])
+			b = f2dot14(data[p+2:])
+			c = f2dot14(data[p+4:])
+			d = f2dot14(data[p+6:])
+			p += 8
+		}
+
+		component, err := f.glyphOutline(gid, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, contour := range component {
+			out := make([]OutlinePoint, len(contour))
+			for i, pt := range contour {
+				out[i] = OutlinePoint{
+					X:       a*pt.X + c*pt.Y + dx,
+					Y:       b*pt.X + d*pt.Y + dy,
+					OnCurve: pt.OnCurve,
+				}
+			}
+			contours = append(contours, out)
+		}
+
+		if flags&compMoreComponents == 0 {
+			return contours, nil
+		}
+	}
+}
+
+// f2dot14 decodes a 2.14 fixed-point number.
+func f2dot14(b []byte) float64 {
+	return float64(int16(binary.BigEndian.Uint16(b))) / 16384
+}
+
+// ErrMalformedGlyph is returned when glyph outline data is inconsistent.
+var ErrMalformedGlyph = errors.New("malformed glyph data")
@@ -0,0 +1,112 @@
+package fonts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// buildGlyphFont creates a font with two glyphs: a simple square (GID 0)
+// and a composite that places the square at offset (100, 50) (GID 1).
+func buildGlyphFont(t *testing.T) *TTFFont {
+	t.Helper()
+
+	var glyf bytes.Buffer
+
+	// GID 0: one contour, 4 on-curve points of a 0..100 square.
+	_ = binary.Write(&glyf, binary.BigEndian, int16(1))                 // numberOfContours
+	_ = binary.Write(&glyf, binary.BigEndian, [4]int16{0, 0, 100, 100}) // bbox
+	_ = binary.Write(&glyf, binary.BigEndian, uint16(3))                // endPtsOfContours
+	_ = binary.Write(&glyf, binary.BigEndian, uint16(0))                // instructionLength
+	// Flags: "same" without "short" repeats the previous coordinate; the
+	// other deltas are single bytes with the sign in the "same" bit.
+	glyf.Write([]byte{
+		glyfOnCurve | glyfXSameOrPos | glyfYSameOrPos,              // (0, 0)
+		glyfOnCurve | glyfXShort | glyfXSameOrPos | glyfYSameOrPos, // (+100, 0)
+		glyfOnCurve | glyfXSameOrPos | glyfYShort | glyfYSameOrPos, // (0, +100)
+		glyfOnCurve | glyfXShort | glyfYSameOrPos,                  // (-100, 0)
+	})
+	glyf.Write([]byte{100, 100}) // x deltas
+	glyf.Write([]byte{100})      // y deltas
+	glyf.WriteByte(0)            // pad to even length
+	simpleEnd := glyf.Len()
+
+	// GID 1: composite of GID 0 with byte offsets.
+	_ = binary.Write(&glyf, binary.BigEndian, int16(-1))
+	_ = binary.Write(&glyf, binary.BigEndian, [4]int16{100, 50, 200, 150})
+	_ = binary.Write(&glyf, binary.BigEndian, uint16(compArgsAreXY))
+	_ = binary.Write(&glyf, binary.BigEndian, uint16(0))
+	glyf.Write([]byte{100, 50})
+	compositeEnd := glyf.Len()
+
+	// Short loca offsets are stored divided by 2.
+	var loca bytes.Buffer
+	for _, off := range []int{0, simpleEnd, compositeEnd} {
+		_ = binary.Write(&loca, binary.BigEndian, uint16(off/2))
+	}
+
+	head := make([]byte, 54) // indexToLocFormat (offset 50) = 0
+
+	return &TTFFont{
+		Tables: map[string]*TTFTable{
+			"glyf": {Tag: "glyf", Data: glyf.Bytes()},
+			"loca": {Tag: "loca", Data: loca.Bytes()},
+			"head": {Tag: "head", Data: head},
+		},
+	}
+}
+
+// TestGlyphOutline_Simple tests decoding of a simple glyph.
+func TestGlyphOutline_Simple(t *testing.T) {
+	font := buildGlyphFont(t)
+
+	contours, err := font.GlyphOutline(0)
+	if err != nil {
+		t.Fatalf("GlyphOutline() error = %v", err)
+	}
+	if len(contours) != 1 || len(contours[0]) != 4 {
+		t.Fatalf("expected 1 contour of 4 points, got %v", contours)
+	}
+
+	want := []OutlinePoint{
+		{X: 0, Y: 0, OnCurve: true},
+		{X: 100, Y: 0, OnCurve: true},
+		{X: 100, Y: 100, OnCurve: true},
+		{X: 0, Y: 100, OnCurve: true},
+	}
+	for i, p := range contours[0] {
+		if p != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+// TestGlyphOutline_Composite tests that components are offset.
+func TestGlyphOutline_Composite(t *testing.T) {
+	font := buildGlyphFont(t)
+
+	contours, err := font.GlyphOutline(1)
+	if err != nil {
+		t.Fatalf("GlyphOutline() error = %v", err)
+	}
+	if len(contours) != 1 || len(contours[0]) != 4 {
+		t.Fatalf("expected 1 contour of 4 points, got %v", contours)
+	}
+	if p := contours[0][2]; p.X != 200 || p.Y != 150 {
+		t.Errorf("offset point = (%v, %v), want (200, 150)", p.X, p.Y)
+	}
+}
+
+// TestGlyphOutline_Errors tests missing tables and out-of-range glyphs.
+func TestGlyphOutline_Errors(t *testing.T) {
+	font := buildGlyphFont(t)
+
+	if _, err := font.GlyphOutline(5); err == nil {
+		t.Error("expected error for glyph beyond loca table")
+	}
+
+	delete(font.Tables, "glyf")
+	if _, err := font.GlyphOutline(0); err == nil {
+		t.Error("expected error for font without glyf table")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"unicode/utf16"
 )
 
 // GenerateToUnicodeCMap generates a ToUnicode CMap for text extraction.
@@ -57,33 +58,51 @@ endcodespacerange
 	return err
 }
 
-// glyphMapping represents a mapping from glyph ID to Unicode code point.
+// glyphMapping represents a mapping from glyph ID to Unicode text.
 type glyphMapping struct {
 	glyphID uint16
-	unicode rune
+	text    string // One character, or more for ligature glyphs
 }
 
 // writeCharMappings writes glyph ID to Unicode mappings.
 //
 // For TrueType fonts, the content stream uses glyph IDs as character codes.
 // This CMap maps those glyph IDs back to Unicode code points for text extraction.
+//
+// When several used characters share a glyph (for example U+0020 and
+// U+00A0), the glyph maps to the lowest code point, so that every code
+// has exactly one entry. Ligature glyphs map to their full text.
 func writeCharMappings(buf *bytes.Buffer, subset *FontSubset) error {
 	// Build glyph ID → Unicode mappings.
-	mappings := make([]glyphMapping, 0, len(subset.UsedChars))
-
+	chars := make([]rune, 0, len(subset.UsedChars))
 	for ch := range subset.UsedChars {
+		chars = append(chars, ch)
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+
+	mapped := make(map[uint16]bool, len(chars)+len(subset.GlyphText))
+	mappings := make([]glyphMapping, 0, len(chars)+len(subset.GlyphText))
+
+	for _, ch := range chars {
 		glyphID, ok := subset.BaseFont.CharToGlyph[ch]
-		if !ok {
-			// Character not in font - skip.
+		if !ok || mapped[glyphID] {
+			// Character not in font, or glyph already mapped - skip.
 			continue
 		}
 
+		mapped[glyphID] = true
 		mappings = append(mappings, glyphMapping{
 			glyphID: glyphID,
-			unicode: ch,
+			text:    string(ch),
 		})
 	}
 
+	for glyphID, text := range subset.GlyphText {
+		if !mapped[glyphID] {
+			mappings = append(mappings, glyphMapping{glyphID: glyphID, text: text})
+		}
+	}
+
 	// Sort by glyph ID for consistent output.
 	sort.Slice(mappings, func(i, j int) bool {
 		return mappings[i].glyphID < mappings[j].glyphID
@@ -117,8 +136,13 @@ func writeMappingBatch(buf *bytes.Buffer, mappings []glyphMapping) error {
 		// Glyph ID as 2-byte hex (TrueType uses 16-bit glyph IDs).
 		glyphCode := fmt.Sprintf("<%04X>", m.glyphID)
 
-		// Unicode code point as 4-digit hex.
-		unicode := fmt.Sprintf("<%04X>", m.unicode)
+		// Unicode text in UTF-16BE: 4 hex digits per character, or a
+		// surrogate pair for characters outside the BMP (such as emoji).
+		unicode := "<"
+		for _, unit := range utf16.Encode([]rune(m.text)) {
+			unicode += fmt.Sprintf("%04X", unit)
+		}
+		unicode += ">"
 
 		// Write mapping line.
 		if _, err := fmt.Fprintf(buf, "%s %s\n", glyphCode, unicode); err != nil {
@@ -63,6 +63,33 @@ func TestGenerateToUnicodeCMap(t *testing.T) {
 	t.Logf("Generated CMap:\n%s", cmapStr)
 }
 
+// TestGenerateToUnicodeCMapSupplementary tests that characters outside
+// the BMP map to UTF-16 surrogate pairs.
+func TestGenerateToUnicodeCMapSupplementary(t *testing.T) {
+	ttf := &TTFFont{
+		CharToGlyph: map[rune]uint16{
+			'中':          4,
+			'\U0001F0A1': 5, // Playing card ace of spades
+		},
+	}
+
+	subset := NewFontSubset(ttf)
+	subset.UseString("中\U0001F0A1")
+
+	cmap, err := GenerateToUnicodeCMap(subset)
+	if err != nil {
+		t.Fatalf("GenerateToUnicodeCMap failed: %v", err)
+	}
+
+	cmapStr := string(cmap)
+	if !strings.Contains(cmapStr, "<0004> <4E2D>") {
+		t.Error("CMap should map glyph ID 0x0004 to Unicode U+4E2D")
+	}
+	if !strings.Contains(cmapStr, "<0005> <D83CDCA1>") {
+		t.Errorf("CMap should map glyph ID 0x0005 to the surrogate pair of U+1F0A1:\n%s", cmapStr)
+	}
+}
+
 // TestGenerateToUnicodeCMapEmpty tests CMap generation with empty subset.
 func TestGenerateToUnicodeCMapEmpty(t *testing.T) {
 	ttf := &TTFFont{
@@ -145,3 +172,53 @@ func TestGlyphMappingOrder(t *testing.T) {
 		t.Error("mappings should be sorted by glyph ID")
 	}
 }
+
+// TestGenerateToUnicodeCMapSharedGlyph tests that a glyph used by several
+// characters gets a single entry for the lowest code point.
+func TestGenerateToUnicodeCMapSharedGlyph(t *testing.T) {
+	ttf := &TTFFont{
+		CharToGlyph: map[rune]uint16{
+			' ':      3,
+			'\u00A0': 3, // No-break space drawn with the space glyph
+			'x':      91,
+		},
+	}
+
+	subset := NewFontSubset(ttf)
+	subset.UseString("x\u00A0 ")
+
+	cmap, err := GenerateToUnicodeCMap(subset)
+	if err != nil {
+		t.Fatalf("GenerateToUnicodeCMap failed: %v", err)
+	}
+
+	cmapStr := string(cmap)
+	if !strings.Contains(cmapStr, "2 beginbfchar\n<0003> <0020>\n<005B> <0078>\nendbfchar") {
+		t.Errorf("expected one entry per glyph:\n%s", cmapStr)
+	}
+}
+
+// TestGenerateToUnicodeCMapLigature tests that ligature glyphs map to
+// their full text in UTF-16BE.
+func TestGenerateToUnicodeCMapLigature(t *testing.T) {
+	ttf := &TTFFont{
+		CharToGlyph: map[rune]uint16{'f': 70, 'i': 73},
+	}
+
+	subset := NewFontSubset(ttf)
+	subset.UseString("f")
+	subset.UseGlyph(200, "fi")
+	subset.UseGlyph(201, "f\U0001D56B") // f + mathematical double-struck i
+
+	cmap, err := GenerateToUnicodeCMap(subset)
+	if err != nil {
+		t.Fatalf("GenerateToUnicodeCMap failed: %v", err)
+	}
+
+	cmapStr := string(cmap)
+	for _, want := range []string{"<0046> <0066>", "<00C8> <00660069>", "<00C9> <0066D835DD6B>"} {
+		if !strings.Contains(cmapStr, want) {
+			t.Errorf("CMap should contain %q:\n%s", want, cmapStr)
+		}
+	}
+}
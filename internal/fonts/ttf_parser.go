@@ -10,8 +10,9 @@ import (
 
 // TTFFont represents a parsed TrueType/OpenType font.
 //
-// TrueType fonts (.ttf) and OpenType fonts with TrueType outlines (.otf)
-// share the same basic structure and can be parsed using the same logic.
+// TrueType fonts (.ttf) and OpenType fonts (.otf) share the same basic
+// structure and can be parsed using the same logic. OpenType fonts with
+// CFF (PostScript) outlines are marked with IsCFF.
 //
 // The font file contains:
 //   - Font directory with table entries
@@ -41,6 +42,10 @@ type TTFFont struct {
 	// FontData is the raw font file data (for embedding).
 	FontData []byte
 
+	// IsCFF indicates that the glyph outlines are in a 'CFF ' table
+	// (sfnt version "OTTO") rather than in 'glyf'.
+	IsCFF bool
+
 	// === Font metrics from head table ===
 
 	// FontBBox is the font bounding box [xMin, yMin, xMax, yMax].
@@ -156,6 +161,11 @@ func (f *TTFFont) parse(data []byte) error {
 		return fmt.Errorf("load tables: %w", err)
 	}
 
+	// CFF outlines are embedded from the 'CFF ' table (CFF2 is not supported).
+	if _, ok := f.Tables["CFF "]; f.IsCFF && !ok {
+		return fmt.Errorf("OpenType font has no CFF table")
+	}
+
 	// Parse required tables.
 	if err := f.parseRequiredTables(); err != nil {
 		return fmt.Errorf("parse required tables: %w", err)
@@ -181,8 +191,12 @@ func (f *TTFFont) parseFontDirectory(r io.Reader) error {
 		return fmt.Errorf("read sfnt version: %w", err)
 	}
 
-	// Check version (0x00010000 for TrueType).
-	if version != 0x00010000 {
+	// Check version (0x00010000 for TrueType, "OTTO" for CFF).
+	switch version {
+	case 0x00010000:
+	case 0x4F54544F:
+		f.IsCFF = true
+	default:
 		return fmt.Errorf("unsupported font format: 0x%08X", version)
 	}
 
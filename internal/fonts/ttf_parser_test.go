@@ -357,3 +357,19 @@ func TestBuildCharToGlyphMappingWithIdRangeOffset(t *testing.T) {
 		}
 	}
 }
+
+// TestLoadTTF_CmapFormat12 tests that the full-repertoire cmap subtable
+// is preferred so that characters outside the BMP are mapped.
+func TestLoadTTF_CmapFormat12(t *testing.T) {
+	font, err := LoadTTF("../../testdata/fonts/cmapTest.ttf")
+	if err != nil {
+		t.Fatalf("LoadTTF failed: %v", err)
+	}
+
+	want := map[rune]uint16{'0': 3, 'A': 6, 'a': 8, 'ā': 11, '中': 12, '\U0001F0A1': 13, '\U0001F0B2': 15}
+	for ch, gid := range want {
+		if got := font.CharToGlyph[ch]; got != gid {
+			t.Errorf("glyph for U+%04X = %d, want %d", ch, got, gid)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"unicode"
 )
 
 // HeadTable represents the 'head' (font header) table.
@@ -298,9 +299,17 @@ func (f *TTFFont) readCmapHeader(data []byte) (uint16, error) {
 }
 
 // findBestCmapSubtable finds the best cmap subtable offset.
+//
+// Windows Unicode full repertoire (platformID=3, encodingID=10) is
+// preferred, since it also maps characters outside the BMP, then
+// Windows Unicode BMP (3, 1), then the Unicode platform (0, 4) and (0, 3).
 func (f *TTFFont) findBestCmapSubtable(data []byte, numTables uint16) (uint32, error) {
 	r := bytes.NewReader(data[4:]) // Skip version and numTables.
 
+	preference := map[[2]uint16]int{{3, 10}: 4, {3, 1}: 3, {0, 4}: 2, {0, 3}: 1}
+	var best uint32
+	bestRank := 0
+
 	for i := uint16(0); i < numTables; i++ {
 		var platformID, encodingID uint16
 		var offset uint32
@@ -315,13 +324,15 @@ func (f *TTFFont) findBestCmapSubtable(data []byte, numTables uint16) (uint32, e
 			return 0, fmt.Errorf("read offset: %w", err)
 		}
 
-		// Prefer Windows Unicode BMP (platformID=3, encodingID=1).
-		if platformID == 3 && encodingID == 1 {
-			return offset, nil
+		if rank := preference[[2]uint16{platformID, encodingID}]; rank > bestRank {
+			best, bestRank = offset, rank
 		}
 	}
 
-	return 0, fmt.Errorf("no suitable cmap subtable found")
+	if bestRank == 0 {
+		return 0, fmt.Errorf("no suitable cmap subtable found")
+	}
+	return best, nil
 }
 
 // parseCmapSubtable parses a cmap subtable (format 4 or 12).
@@ -494,10 +505,45 @@ func (f *TTFFont) buildCharToGlyphMapping(segCount uint16, arrays *format4Arrays
 }
 
 // parseCmapFormat12 parses cmap format 12 (segmented coverage).
-func (f *TTFFont) parseCmapFormat12(_ []byte, _ uint32) error {
-	// Format 12 is more complex, but less common for basic fonts.
-	// For MVP, we'll focus on format 4 support.
-	return fmt.Errorf("cmap format 12 not yet implemented")
+//
+// Format 12 header: format (2), reserved (2), length (4), language (4),
+// numGroups (4), followed by groups of startCharCode, endCharCode and
+// startGlyphID (4 bytes each). Unlike format 4, it covers characters
+// outside the BMP.
+func (f *TTFFont) parseCmapFormat12(data []byte, offset uint32) error {
+	r := bytes.NewReader(data[offset:])
+
+	// Skip format (2) + reserved (2) + length (4) + language (4) = 12 bytes.
+	if err := skipBytes(r, 12); err != nil {
+		return err
+	}
+
+	var numGroups uint32
+	if err := binary.Read(r, binary.BigEndian, &numGroups); err != nil {
+		return fmt.Errorf("read numGroups: %w", err)
+	}
+	if uint64(numGroups)*12 > uint64(r.Len()) {
+		return fmt.Errorf("cmap format 12 groups out of bounds")
+	}
+
+	for i := uint32(0); i < numGroups; i++ {
+		var group struct{ StartCharCode, EndCharCode, StartGlyphID uint32 }
+		if err := binary.Read(r, binary.BigEndian, &group); err != nil {
+			return fmt.Errorf("read group %d: %w", i, err)
+		}
+		if group.EndCharCode < group.StartCharCode || group.EndCharCode > unicode.MaxRune {
+			continue
+		}
+		for ch := group.StartCharCode; ch <= group.EndCharCode; ch++ {
+			glyphID := group.StartGlyphID + (ch - group.StartCharCode)
+			if glyphID == 0 || glyphID > 0xFFFF {
+				continue
+			}
+			f.CharToGlyph[rune(ch)] = uint16(glyphID) //nolint:gosec // Checked above.
+		}
+	}
+
+	return nil
 }
 
 // skipBytes skips n bytes in the reader.
@@ -0,0 +1,253 @@
+package parser
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/coregx/gxpdf/internal/security"
+	"github.com/coregx/gxpdf/logging"
+)
+
+// EncryptInfo contains the settings of a document's encryption dictionary.
+//
+// Reference: PDF 1.7 Specification, Section 7.6.1 (Encryption), Table 20
+// and Section 7.6.3.2 (Standard Encryption Dictionary), Table 21.
+type EncryptInfo struct {
+	Filter          string // Security handler (/Filter), e.g. "Standard"
+	SubFilter       string // Encoding of the handler's data (/SubFilter)
+	V               int    // Algorithm version (/V)
+	R               int    // Standard security handler revision (/R)
+	Length          int    // Key length in bits (/Length, default 40)
+	P               int32  // User access permission flags (/P)
+	StmF            string // Crypt filter for streams (/StmF, V 4 and 5)
+	StrF            string // Crypt filter for strings (/StrF, V 4 and 5)
+	CFM             string // Method of the stream crypt filter (/CFM), e.g. "AESV2"
+	EncryptMetadata bool   // Whether metadata streams are encrypted
+}
+
+// GetEncryptInfo returns the settings of the document's encryption
+// dictionary (the trailer /Encrypt entry), or nil if the document is not
+// encrypted.
+//
+// The encryption dictionary itself is never encrypted, so its settings
+// can be read without a password.
+func (r *Reader) GetEncryptInfo() *EncryptInfo {
+	if r.trailer == nil {
+		return nil
+	}
+	encrypt := r.trailer.Get("Encrypt")
+	if encrypt == nil {
+		return nil
+	}
+	dict, ok := r.resolveReferences(encrypt).(*Dictionary)
+	if !ok {
+		return nil
+	}
+
+	info := &EncryptInfo{
+		V:               int(dict.GetInteger("V")),
+		R:               int(dict.GetInteger("R")),
+		Length:          int(dict.GetInteger("Length")),
+		P:               int32(dict.GetInteger("P")), //nolint:gosec // /P is a 32-bit field.
+		EncryptMetadata: true,
+	}
+	if name := dict.GetName("Filter"); name != nil {
+		info.Filter = name.Value()
+	}
+	if name := dict.GetName("SubFilter"); name != nil {
+		info.SubFilter = name.Value()
+	}
+	if info.Length == 0 {
+		info.Length = 40
+	}
+	if b, ok := dict.Get("EncryptMetadata").(*Boolean); ok {
+		info.EncryptMetadata = b.Value()
+	}
+
+	// Crypt filters (V 4 and 5): /CF << /StdCF << /CFM /AESV2 /Length 16 >> >>.
+	if name := dict.GetName("StmF"); name != nil {
+		info.StmF = name.Value()
+	}
+	if name := dict.GetName("StrF"); name != nil {
+		info.StrF = name.Value()
+	}
+	if cf, ok := r.resolveReferences(dict.Get("CF")).(*Dictionary); ok && info.StmF != "" {
+		if filter, ok := r.resolveReferences(cf.Get(info.StmF)).(*Dictionary); ok {
+			if name := filter.GetName("CFM"); name != nil {
+				info.CFM = name.Value()
+			}
+			// Crypt filter /Length is in bytes (some writers use bits).
+			if n := int(filter.GetInteger("Length")); n > 0 && n <= 32 {
+				info.Length = n * 8
+			} else if n > 32 {
+				info.Length = n
+			}
+		}
+	}
+
+	return info
+}
+
+// setupDecryption prepares transparent decryption of an encrypted
+// document that opens with the empty user password.
+//
+// Strings and streams are then decrypted as objects are loaded, before
+// any filter is applied. Documents that need a password, or that use a
+// handler other than the Standard Security Handler V 1, 2, 4 or 5, stay
+// readable as before: their structure loads, their encrypted data does not
+// decode.
+//
+// Reference: PDF 1.7 Specification, Section 7.6.2 (General Encryption
+// Algorithm) and 7.6.5 (Crypt Filters); ISO 32000-2, Section 7.6.4 (AES-256).
+func (r *Reader) setupDecryption() {
+	info := r.GetEncryptInfo()
+	if info == nil {
+		return
+	}
+	if ref, ok := r.trailer.Get("Encrypt").(*IndirectReference); ok {
+		r.encryptObjNum = ref.Number
+	}
+
+	decryptor, err := r.standardDecryptor(info)
+	if err != nil {
+		logging.Logger().Warn("encrypted document not decrypted",
+			slog.String("filter", info.Filter),
+			slog.Int("v", info.V),
+			slog.Int("r", info.R),
+			slog.String("error", err.Error()))
+		return
+	}
+	r.decryptor = decryptor
+	r.decryptMetadata = info.EncryptMetadata
+}
+
+// Encryptor returns an encryptor that encrypts with the document's own
+// file key and crypt filters, for saving a decrypted document with its
+// original encryption dictionary. Returns nil if the document is not
+// encrypted or could not be decrypted.
+func (r *Reader) Encryptor() *security.StandardEncryptor {
+	if r.decryptor == nil {
+		return nil
+	}
+	info := r.GetEncryptInfo()
+	return r.decryptor.Encryptor(&security.EncryptionDict{
+		Filter: info.Filter,
+		V:      info.V,
+		R:      info.R,
+		Length: info.Length,
+		P:      info.P,
+		CFM:    info.CFM,
+	})
+}
+
+// standardDecryptor creates the decryptor for the empty user password.
+func (r *Reader) standardDecryptor(info *EncryptInfo) (*security.StandardDecryptor, error) {
+	if info.Filter != "Standard" {
+		return nil, fmt.Errorf("%w: security handler %q", security.ErrUnsupportedVersion, info.Filter)
+	}
+	dict, _ := r.resolveReferences(r.trailer.Get("Encrypt")).(*Dictionary)
+
+	var fileID []byte
+	if ids := r.trailer.GetArray("ID"); ids != nil && ids.Len() > 0 {
+		if id, ok := ids.Get(0).(*String); ok {
+			fileID = id.Bytes()
+		}
+	}
+
+	config := &security.DecryptionConfig{
+		Dict: &security.EncryptionDict{
+			Filter: info.Filter,
+			V:      info.V,
+			R:      info.R,
+			Length: info.Length,
+			P:      info.P,
+			O:      stringBytes(dict.Get("O")),
+			U:      stringBytes(dict.Get("U")),
+			OE:     stringBytes(dict.Get("OE")),
+			UE:     stringBytes(dict.Get("UE")),
+			Perms:  stringBytes(dict.Get("Perms")),
+		},
+		FileID:          fileID,
+		EncryptMetadata: info.EncryptMetadata,
+		StreamMethod:    r.cryptFilterMethod(dict, info.StmF),
+		StringMethod:    r.cryptFilterMethod(dict, info.StrF),
+	}
+	return security.NewStandardDecryptor(config)
+}
+
+// cryptFilterMethod returns the method (/CFM) of the named crypt filter.
+// /Identity and an absent name (the default) leave data unchanged.
+func (r *Reader) cryptFilterMethod(dict *Dictionary, name string) string {
+	if name == "" || name == "Identity" {
+		return security.CryptMethodNone
+	}
+	cf, _ := r.resolveReferences(dict.Get("CF")).(*Dictionary)
+	if cf == nil {
+		return security.CryptMethodNone
+	}
+	filter, _ := r.resolveReferences(cf.Get(name)).(*Dictionary)
+	if filter == nil {
+		return security.CryptMethodNone
+	}
+	if cfm := filter.GetName("CFM"); cfm != nil {
+		return cfm.Value()
+	}
+	return security.CryptMethodNone
+}
+
+// decryptObject decrypts, in place, the strings and stream data of a
+// freshly loaded object objNum with generation gen.
+//
+// The encryption dictionary, cross-reference streams and (unless
+// /EncryptMetadata is true) metadata streams are not encrypted.
+func (r *Reader) decryptObject(obj PdfObject, objNum, gen int) {
+	if r.decryptor == nil || objNum == r.encryptObjNum {
+		return
+	}
+
+	switch o := obj.(type) {
+	case *String:
+		decrypted, err := r.decryptor.DecryptString(objNum, gen, o.value)
+		if err != nil {
+			logging.Logger().Warn("failed to decrypt string",
+				slog.Int("object", objNum), slog.String("error", err.Error()))
+			return
+		}
+		o.value = decrypted
+
+	case *Array:
+		for _, elem := range o.Elements() {
+			r.decryptObject(elem, objNum, gen)
+		}
+
+	case *Dictionary:
+		for _, key := range o.Keys() {
+			r.decryptObject(o.Get(key), objNum, gen)
+		}
+
+	case *Stream:
+		dict := o.Dictionary()
+		if t := dict.GetName("Type"); t != nil {
+			if t.Value() == "XRef" || (t.Value() == "Metadata" && !r.decryptMetadata) {
+				return
+			}
+		}
+		r.decryptObject(dict, objNum, gen)
+
+		decrypted, err := r.decryptor.DecryptStream(objNum, gen, o.Content())
+		if err != nil {
+			logging.Logger().Warn("failed to decrypt stream",
+				slog.Int("object", objNum), slog.String("error", err.Error()))
+			return
+		}
+		o.SetContent(decrypted)
+	}
+}
+
+// stringBytes returns the bytes of a string object, or nil.
+func stringBytes(obj PdfObject) []byte {
+	if s, ok := obj.(*String); ok {
+		return s.Bytes()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReader_DecryptEmptyUserPassword tests that documents encrypted with
+// an empty user password are decrypted while loading.
+func TestReader_DecryptEmptyUserPassword(t *testing.T) {
+	tests := []struct {
+		file string
+		cfm  string
+		oLen int
+	}{
+		{"encrypted_rc4.pdf", "", 32},
+		{"encrypted_aes128.pdf", "AESV2", 32},
+		{"encrypted_aes256.pdf", "AESV3", 48},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			reader, err := OpenPDF(getTestFilePath(tt.file))
+			require.NoError(t, err)
+			defer reader.Close()
+
+			require.NotNil(t, reader.decryptor, "empty user password should open the document")
+			assert.Equal(t, tt.cfm, reader.GetEncryptInfo().CFM)
+
+			// Strings are decrypted.
+			info := reader.GetDocumentInfo()
+			assert.True(t, info.Encrypted)
+			assert.Equal(t, "Secret Report", info.Title)
+
+			// Streams are decrypted before their filters are applied.
+			page, err := reader.GetPage(0)
+			require.NoError(t, err)
+			contents, ok := reader.resolveReferences(page.Get("Contents")).(*Stream)
+			require.True(t, ok, "page contents should be a stream")
+			data, err := reader.decodeStream(contents)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "(Encrypted Hello) Tj")
+
+			// The encryption dictionary itself is left alone.
+			encrypt, err := reader.GetObject(reader.encryptObjNum)
+			require.NoError(t, err)
+			assert.Len(t, encrypt.(*Dictionary).Get("O").(*String).Bytes(), tt.oLen)
+		})
+	}
+}
+
+// TestReader_DecryptCryptFilterMethod tests /StmF, /StrF and /CF selection.
+func TestReader_DecryptCryptFilterMethod(t *testing.T) {
+	reader := NewReader("unused.pdf")
+	dict := NewDictionary()
+	cf := NewDictionary()
+	aes := NewDictionary()
+	aes.SetName("CFM", "AESV2")
+	cf.Set("StdCF", aes)
+	cf.Set("NoMethod", NewDictionary())
+	dict.Set("CF", cf)
+
+	assert.Equal(t, "AESV2", reader.cryptFilterMethod(dict, "StdCF"))
+	assert.Equal(t, "None", reader.cryptFilterMethod(dict, "Identity"))
+	assert.Equal(t, "None", reader.cryptFilterMethod(dict, ""))
+	assert.Equal(t, "None", reader.cryptFilterMethod(dict, "NoMethod"))
+	assert.Equal(t, "None", reader.cryptFilterMethod(dict, "Missing"))
+}
@@ -555,6 +555,38 @@ func (l *Lexer) skipTo(target string) error {
 	}
 }
 
+// ReadInlineImageData reads the data of an inline image, which follows
+// the ID operator as raw bytes, and consumes the EI operator that ends it.
+//
+// The data starts after the single white-space character following ID
+// and ends at the first EI preceded by white space and followed by white
+// space, a delimiter or the end of the stream. That white space is not
+// part of the data.
+//
+// Reference: PDF 1.7 specification, Section 8.9.7 (Inline Images).
+func (l *Lexer) ReadInlineImageData() ([]byte, error) {
+	if ch, err := l.peek(); err == nil && isWhitespace(ch) {
+		_, _ = l.readByte()
+	}
+
+	var data []byte
+	for {
+		ch, err := l.readByte()
+		if err != nil {
+			return nil, fmt.Errorf("inline image data not terminated by EI at %d:%d", l.line, l.column)
+		}
+		data = append(data, ch)
+
+		n := len(data)
+		if n < 3 || data[n-1] != 'I' || data[n-2] != 'E' || !isWhitespace(data[n-3]) {
+			continue
+		}
+		if next, err := l.peek(); err != nil || isWhitespace(next) || isDelimiter(next) {
+			return data[:n-3], nil
+		}
+	}
+}
+
 // Reset resets the lexer to read from a new reader.
 func (l *Lexer) Reset(r io.Reader) {
 	l.reader = bufio.NewReader(r)
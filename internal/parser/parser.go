@@ -3,9 +3,13 @@ package parser
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"strconv"
+
+	"github.com/coregx/gxpdf/logging"
 )
 
 // Use keyword constants from token.go
@@ -338,32 +342,42 @@ func (p *Parser) parseStreamContent(dict *Dictionary) (*Stream, error) {
 	// Read exactly 'length' bytes from the underlying reader
 	content := make([]byte, length)
 
-	// We need to read raw bytes from the lexer's reader
-	// Skip the newline after 'stream' keyword first
-	reader := p.getReaderFromLexer()
-
-	// Skip whitespace/newline after stream
-	b, err := reader.ReadByte()
+	// Skip the newline after 'stream' keyword first. Reading the keyword
+	// left the byte after it peeked in the lexer, so it goes through the
+	// lexer rather than the raw reader.
+	b, err := p.lexer.readByte()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read after stream keyword: %w", err)
 	}
 	// If it's CR, check for CRLF
 	if b == '\r' {
-		next, _ := reader.ReadByte()
-		if next != '\n' {
-			_ = reader.UnreadByte()
+		if next, err := p.lexer.peek(); err == nil && next == '\n' {
+			_, _ = p.lexer.readByte()
 		}
 	} else if b != '\n' {
 		// No newline, put it back
-		_ = reader.UnreadByte()
+		p.lexer.peekedChar, p.lexer.hasPeeked = b, true
 	}
 
-	n, err := io.ReadFull(reader, content)
+	// A byte still peeked by the lexer is the first byte of content
+	start := 0
+	if p.lexer.hasPeeked {
+		content[0] = p.lexer.peekedChar
+		p.lexer.hasPeeked = false
+		start = 1
+	}
+
+	// Read the rest from the underlying reader
+	n, err := io.ReadFull(p.getReaderFromLexer(), content[start:])
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		// /Length runs past the end of the data
+		return p.recoverStreamLength(dict, content[:start+n])
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read stream content: %w", err)
 	}
-	if n != int(length) {
-		return nil, fmt.Errorf("expected %d bytes, got %d", length, n)
+	if !p.endstreamFollows() {
+		return p.recoverStreamLength(dict, content)
 	}
 
 	// Skip optional whitespace/newline before endstream
@@ -386,6 +400,60 @@ func (p *Parser) parseStreamContent(dict *Dictionary) (*Stream, error) {
 	return NewStream(dict, content), nil
 }
 
+// endstreamFollows reports whether the next bytes, after optional
+// whitespace, are the 'endstream' keyword. Nothing is consumed.
+func (p *Parser) endstreamFollows() bool {
+	const maxPeek = 64
+	buf, _ := p.getReaderFromLexer().Peek(maxPeek) // Fewer bytes at EOF
+	i := 0
+	for i < len(buf) && isWhitespace(buf[i]) {
+		i++
+	}
+	return bytes.HasPrefix(buf[i:], []byte(KeywordEndstream))
+}
+
+// recoverStreamLength finds the end of a stream whose /Length does not
+// match its data, which is common in real-world files. content holds the
+// bytes read for /Length. If 'endstream' starts within them, the stream
+// ends there and the bytes after the keyword are parsed again; otherwise
+// the data continues and is scanned up to 'endstream'.
+func (p *Parser) recoverStreamLength(dict *Dictionary, content []byte) (*Stream, error) {
+	logging.Logger().Warn("stream /Length does not match its data, scanning for endstream",
+		slog.Int64("length", dict.GetInteger("Length")))
+
+	// The keyword may straddle the end of content, so look a little ahead
+	reader := p.getReaderFromLexer()
+	ahead, _ := reader.Peek(len(KeywordEndstream)) // Fewer bytes at EOF
+	window := append(content[:len(content):len(content)], ahead...)
+	if idx := bytes.Index(window, []byte(KeywordEndstream)); idx >= 0 && idx < len(content) {
+		var rest []byte
+		if end := idx + len(KeywordEndstream); end <= len(content) {
+			rest = content[end:]
+		} else {
+			_, _ = reader.Discard(end - len(content))
+		}
+		p.lexer.reader = bufio.NewReader(io.MultiReader(bytes.NewReader(rest), reader))
+		p.lexer.skipWhitespace()
+		p.current, _ = p.lexer.NextToken()
+		return NewStream(dict, trimStreamEOL(content[:idx])), nil
+	}
+
+	stream, err := p.parseStreamUntilEndstream(dict)
+	if err != nil {
+		return nil, err
+	}
+	return NewStream(dict, trimStreamEOL(append(content, stream.Content()...))), nil
+}
+
+// trimStreamEOL removes the end-of-line marker that precedes 'endstream'
+// and is not part of the stream data.
+//
+// Reference: PDF 1.7 specification, Section 7.3.8.1 (Stream Objects).
+func trimStreamEOL(data []byte) []byte {
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	return bytes.TrimSuffix(data, []byte("\r"))
+}
+
 // parseStreamUntilEndstream is a fallback parser for streams without proper Length.
 func (p *Parser) parseStreamUntilEndstream(dict *Dictionary) (*Stream, error) {
 	var content []byte
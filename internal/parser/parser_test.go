@@ -711,6 +711,70 @@ func TestParser_ParseStream_MultiLine(t *testing.T) {
 	}
 }
 
+func TestParser_ParseStream_LeadingEOL(t *testing.T) {
+	// Binary stream data may itself start with CR or LF; only the EOL
+	// after 'stream' is skipped.
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"LF data", "1 0 obj\n<< /Length 3 >>\nstream\n\nab\nendstream\nendobj", "\nab"},
+		{"CR data", "1 0 obj\n<< /Length 3 >>\nstream\n\rab\nendstream\nendobj", "\rab"},
+		{"CRLF EOL", "1 0 obj\n<< /Length 3 >>\nstream\r\n\nab\nendstream\nendobj", "\nab"},
+		{"CR EOL", "1 0 obj\n<< /Length 3 >>\nstream\rabc\nendstream\nendobj", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(strings.NewReader(tt.input))
+			obj, err := p.ParseIndirectObject()
+			if err != nil {
+				t.Fatalf("ParseIndirectObject() error = %v", err)
+			}
+			stream, ok := obj.Object.(*Stream)
+			if !ok {
+				t.Fatalf("expected *Stream, got %T", obj.Object)
+			}
+			if got := string(stream.Content()); got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseStream_WrongLength(t *testing.T) {
+	// Real-world files often have a wrong /Length; the stream then ends at
+	// 'endstream' and the object still parses up to 'endobj'.
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"too long", "1 0 obj\n<< /Length 8 >>\nstream\nHello\nendstream\nendobj\n2 0 obj"},
+		{"into keyword", "1 0 obj\n<< /Length 12 >>\nstream\nHello\nendstream\nendobj\n2 0 obj"},
+		{"past endobj", "1 0 obj\n<< /Length 40 >>\nstream\nHello\nendstream\nendobj\n2 0 obj"},
+		{"past EOF", "1 0 obj\n<< /Length 100 >>\nstream\nHello\nendstream\nendobj\n"},
+		{"too short", "1 0 obj\n<< /Length 3 >>\nstream\nHello\nendstream\nendobj\n2 0 obj"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(strings.NewReader(tt.input))
+			obj, err := p.ParseIndirectObject()
+			if err != nil {
+				t.Fatalf("ParseIndirectObject() error = %v", err)
+			}
+			stream, ok := obj.Object.(*Stream)
+			if !ok {
+				t.Fatalf("expected *Stream, got %T", obj.Object)
+			}
+			if got := string(stream.Content()); got != "Hello" {
+				t.Errorf("content = %q, want %q", got, "Hello")
+			}
+		})
+	}
+}
+
 func TestParser_ParseStream_WithFilter(t *testing.T) {
 	input := "3 0 obj\n<< /Length 5 /Filter /FlateDecode >>\nstream\nHello\nendstream\nendobj"
 	p := NewParser(strings.NewReader(input))
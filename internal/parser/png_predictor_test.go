@@ -118,7 +118,7 @@ func TestApplyPNGPredictor(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := applyPNGPredictor(tt.input, tt.columns)
+			result, err := applyPNGPredictor(tt.input, tt.columns, 1)
 			require.NoError(t, err)
 			assert.Equal(t, tt.expected, result)
 		})
@@ -129,7 +129,7 @@ func TestApplyPNGPredictor_Errors(t *testing.T) {
 	t.Run("invalid filter type", func(t *testing.T) {
 		// Filter byte 5 is invalid
 		input := []byte{5, 1, 2, 3}
-		_, err := applyPNGPredictor(input, 3)
+		_, err := applyPNGPredictor(input, 3, 1)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unknown PNG filter type: 5")
 	})
@@ -137,28 +137,28 @@ func TestApplyPNGPredictor_Errors(t *testing.T) {
 	t.Run("data length not divisible by row size", func(t *testing.T) {
 		// columns=3 means rowSize=4, but we have 5 bytes
 		input := []byte{0, 1, 2, 3, 4}
-		_, err := applyPNGPredictor(input, 3)
+		_, err := applyPNGPredictor(input, 3, 1)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not divisible by row size")
 	})
 
 	t.Run("columns zero", func(t *testing.T) {
 		input := []byte{0, 1, 2, 3}
-		_, err := applyPNGPredictor(input, 0)
+		_, err := applyPNGPredictor(input, 0, 1)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "out of valid range")
 	})
 
 	t.Run("columns negative", func(t *testing.T) {
 		input := []byte{0, 1, 2, 3}
-		_, err := applyPNGPredictor(input, -1)
+		_, err := reversePredictor(input, predictorParams{predictor: 12, columns: -1})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "out of valid range")
 	})
 
 	t.Run("columns exceeds limit", func(t *testing.T) {
 		input := []byte{0, 1, 2, 3}
-		_, err := applyPNGPredictor(input, 100_001)
+		_, err := reversePredictor(input, predictorParams{predictor: 12, columns: 100_001})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "out of valid range")
 	})
@@ -239,7 +239,7 @@ func TestFlateDecoder_DecodeWithPredictor(t *testing.T) {
 			0xcb, 0x48, 0xcd, 0xc9, 0xc9, 0x07, 0x00, // compressed "hello"
 			0x06, 0x2c, 0x02, 0x15, // adler32 checksum
 		}
-		result, err := decoder.DecodeWithPredictor(compressed, 1, 5)
+		result, err := decoder.DecodeWithPredictor(compressed, predictorParams{predictor: 1, columns: 5})
 		require.NoError(t, err)
 		assert.Equal(t, []byte("hello"), result)
 	})
@@ -251,23 +251,23 @@ func TestFlateDecoder_DecodeWithPredictor(t *testing.T) {
 			0xcb, 0x48, 0xcd, 0xc9, 0xc9, 0x07, 0x00,
 			0x06, 0x2c, 0x02, 0x15,
 		}
-		result, err := decoder.DecodeWithPredictor(compressed, 0, 5)
+		result, err := decoder.DecodeWithPredictor(compressed, predictorParams{predictor: 0, columns: 5})
 		require.NoError(t, err)
 		assert.Equal(t, []byte("hello"), result)
 	})
 
-	t.Run("predictor 2 (TIFF) returns error", func(t *testing.T) {
+	t.Run("predictor 2 (TIFF) reverses differences", func(t *testing.T) {
 		decoder := &flateDecoder{}
-		compressed := []byte{0x78, 0x9c, 0x03, 0x00, 0x00, 0x00, 0x00, 0x01} // empty
-		_, err := decoder.DecodeWithPredictor(compressed, 2, 5)
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "TIFF predictor not implemented")
+		compressed := zlibCompress(t, []byte{10, 5, 5, 5, 5})
+		result, err := decoder.DecodeWithPredictor(compressed, predictorParams{predictor: 2, columns: 5})
+		require.NoError(t, err)
+		assert.Equal(t, []byte{10, 15, 20, 25, 30}, result)
 	})
 
 	t.Run("unsupported predictor returns error", func(t *testing.T) {
 		decoder := &flateDecoder{}
 		compressed := []byte{0x78, 0x9c, 0x03, 0x00, 0x00, 0x00, 0x00, 0x01}
-		_, err := decoder.DecodeWithPredictor(compressed, 99, 5)
+		_, err := decoder.DecodeWithPredictor(compressed, predictorParams{predictor: 99, columns: 5})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unsupported predictor: 99")
 	})
@@ -278,7 +278,7 @@ func TestFlateDecoder_DecodeWithPredictor(t *testing.T) {
 		// Row: [filter=0, 0x41, 0x42, 0x43] -> decoded as "ABC"
 		// Generated using: zlib.NewWriter + Write([0, 0x41, 0x42, 0x43])
 		compressed := []byte{0x78, 0x9c, 0x62, 0x70, 0x74, 0x72, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x01, 0x8e, 0x00, 0xc7}
-		result, err := decoder.DecodeWithPredictor(compressed, 12, 3)
+		result, err := decoder.DecodeWithPredictor(compressed, predictorParams{predictor: 12, columns: 3})
 		require.NoError(t, err)
 		assert.Equal(t, []byte{0x41, 0x42, 0x43}, result)
 	})
@@ -290,7 +290,7 @@ func TestFlateDecoder_DecodeWithPredictor(t *testing.T) {
 		// Row 2: [filter=2, 5, 5]   -> Up   -> [15, 25]
 		// Compressed using zlib.NewWriter
 		compressed := []byte{0x78, 0x9c, 0x62, 0xe0, 0x12, 0x61, 0x62, 0x65, 0x05, 0x04, 0x00, 0x00, 0xff, 0xff, 0x00, 0x9d, 0x00, 0x2b}
-		result, err := decoder.DecodeWithPredictor(compressed, 15, 2)
+		result, err := decoder.DecodeWithPredictor(compressed, predictorParams{predictor: 15, columns: 2})
 		require.NoError(t, err)
 		assert.Equal(t, []byte{10, 20, 15, 25}, result)
 	})
@@ -299,7 +299,7 @@ func TestFlateDecoder_DecodeWithPredictor(t *testing.T) {
 		decoder := &flateDecoder{}
 		// Invalid zlib data (bad header)
 		invalidCompressed := []byte{0x00, 0x00, 0x00, 0x00}
-		_, err := decoder.DecodeWithPredictor(invalidCompressed, 12, 3)
+		_, err := decoder.DecodeWithPredictor(invalidCompressed, predictorParams{predictor: 12, columns: 3})
 		require.Error(t, err)
 		// Should fail during decompression
 	})
@@ -333,7 +333,7 @@ func TestApplyPNGPredictor_XRefStreamPattern(t *testing.T) {
 			1, 0, 173, 0, 0, // Entry 3: type=1, offset=173
 		}
 
-		result, err := applyPNGPredictor(input, columns)
+		result, err := applyPNGPredictor(input, columns, 1)
 		require.NoError(t, err)
 		assert.Equal(t, expected, result)
 	})
@@ -382,7 +382,7 @@ func BenchmarkApplyPNGPredictor_Small(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = applyPNGPredictor(input, 5)
+		_, _ = applyPNGPredictor(input, 5, 1)
 	}
 }
 
@@ -395,7 +395,7 @@ func BenchmarkApplyPNGPredictor_Large(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = applyPNGPredictor(input, 5)
+		_, _ = applyPNGPredictor(input, 5, 1)
 	}
 }
 
@@ -0,0 +1,272 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Predictor limits guard against excessive allocation on malformed PDFs.
+const (
+	maxPredictorColumns = 100_000
+	maxPredictorColors  = 32
+)
+
+// predictorParams holds the predictor entries of a FlateDecode or
+// LZWDecode /DecodeParms dictionary.
+//
+// Reference: PDF 1.7 specification, Section 7.4.4.4 (LZW and Flate
+// Predictor Functions), Table 8.
+type predictorParams struct {
+	predictor        int // 1 = none, 2 = TIFF Predictor 2, 10-15 = PNG filters
+	colors           int // Interleaved color components per sample (default 1)
+	bitsPerComponent int // Bits per color component: 1, 2, 4, 8 or 16 (default 8)
+	columns          int // Samples per row (default 1)
+}
+
+// parsePredictorParams reads the predictor entries from a /DecodeParms
+// value. For filter arrays the parameters of the first filter are used.
+// Missing entries take their defaults.
+func parsePredictorParams(decodeParms PdfObject) predictorParams {
+	if arr, ok := decodeParms.(*Array); ok && arr.Len() > 0 {
+		decodeParms = arr.Get(0)
+	}
+
+	var params predictorParams
+	if dict, ok := decodeParms.(*Dictionary); ok {
+		get := func(key string) int {
+			if n, ok := dict.Get(key).(*Integer); ok {
+				return int(n.Value())
+			}
+			return 0
+		}
+		params = predictorParams{
+			predictor:        get("Predictor"),
+			colors:           get("Colors"),
+			bitsPerComponent: get("BitsPerComponent"),
+			columns:          get("Columns"),
+		}
+	}
+	return params.withDefaults()
+}
+
+// withDefaults fills in the defaults of unset (zero) entries.
+func (p predictorParams) withDefaults() predictorParams {
+	if p.predictor == 0 {
+		p.predictor = 1
+	}
+	if p.colors == 0 {
+		p.colors = 1
+	}
+	if p.bitsPerComponent == 0 {
+		p.bitsPerComponent = 8
+	}
+	if p.columns == 0 {
+		p.columns = 1
+	}
+	return p
+}
+
+// ReversePredictor undoes the FlateDecode or LZWDecode predictor described
+// by the /DecodeParms value decodeParms on decompressed stream data: TIFF
+// Predictor 2 or the PNG filters (10-15), honoring /Colors,
+// /BitsPerComponent and /Columns. Data without a predictor is returned
+// unchanged. decodeParms must be resolved (not an indirect reference); for
+// filter arrays, pass the entry of the decompressing filter.
+func ReversePredictor(data []byte, decodeParms PdfObject) ([]byte, error) {
+	return reversePredictor(data, parsePredictorParams(decodeParms))
+}
+
+// DecodeWithPredictor decompresses data and reverses the predictor
+// described by params.
+func (d *flateDecoder) DecodeWithPredictor(data []byte, params predictorParams) ([]byte, error) {
+	decompressed, err := d.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return reversePredictor(decompressed, params)
+}
+
+// reversePredictor undoes the prediction applied to data before it was
+// compressed, returning the original bytes. Data without a predictor
+// (predictor 1) is returned as-is.
+func reversePredictor(data []byte, params predictorParams) ([]byte, error) {
+	p := params.withDefaults()
+	if p.predictor <= 1 {
+		return data, nil
+	}
+
+	if p.columns < 1 || p.columns > maxPredictorColumns {
+		return nil, fmt.Errorf("predictor: columns %d out of valid range (1-%d)", p.columns, maxPredictorColumns)
+	}
+	if p.colors < 1 || p.colors > maxPredictorColors {
+		return nil, fmt.Errorf("predictor: colors %d out of valid range (1-%d)", p.colors, maxPredictorColors)
+	}
+	switch p.bitsPerComponent {
+	case 1, 2, 4, 8, 16:
+	default:
+		return nil, fmt.Errorf("predictor: unsupported BitsPerComponent %d", p.bitsPerComponent)
+	}
+
+	rowBytes := (p.colors*p.bitsPerComponent*p.columns + 7) / 8
+	switch {
+	case p.predictor == 2:
+		return applyTIFFPredictor(data, p, rowBytes)
+	case p.predictor >= 10 && p.predictor <= 15:
+		// The left neighbor is one whole sample back, or one byte for
+		// samples smaller than a byte.
+		bpp := max(1, p.colors*p.bitsPerComponent/8)
+		return applyPNGPredictor(data, rowBytes, bpp)
+	default:
+		return nil, fmt.Errorf("unsupported predictor: %d", p.predictor)
+	}
+}
+
+// applyPNGPredictor reverses PNG prediction filters.
+// PNG encoded data has a filter byte at the start of each row of rowBytes
+// bytes. Filter types: 0=None, 1=Sub, 2=Up, 3=Average, 4=Paeth. The
+// predictor value (10-15) only names the encoder's choice; each row's
+// filter byte decides how it is decoded.
+//
+// bpp is the distance in bytes to the corresponding byte of the sample on
+// the left (1 for single-byte samples).
+func applyPNGPredictor(data []byte, rowBytes, bpp int) ([]byte, error) {
+	if rowBytes <= 0 || bpp <= 0 {
+		return nil, fmt.Errorf("PNG predictor: row size %d out of valid range", rowBytes)
+	}
+
+	rowSize := rowBytes + 1 // +1 for filter byte
+	if len(data)%rowSize != 0 {
+		return nil, fmt.Errorf("PNG predictor: data length %d not divisible by row size %d", len(data), rowSize)
+	}
+
+	numRows := len(data) / rowSize
+	result := make([]byte, 0, numRows*rowBytes)
+	prevRow := make([]byte, rowBytes)
+
+	for row := 0; row < numRows; row++ {
+		rowStart := row * rowSize
+		filterByte := data[rowStart]
+		rowData := data[rowStart+1 : rowStart+rowSize]
+		decodedRow := make([]byte, rowBytes)
+
+		// left and upLeft are zero for the first sample of a row.
+		left := func(i int) byte {
+			if i >= bpp {
+				return decodedRow[i-bpp]
+			}
+			return 0
+		}
+		upLeft := func(i int) byte {
+			if i >= bpp {
+				return prevRow[i-bpp]
+			}
+			return 0
+		}
+
+		switch filterByte {
+		case 0: // None
+			copy(decodedRow, rowData)
+
+		case 1: // Sub: each byte depends on the byte to its left
+			for i := range rowBytes {
+				decodedRow[i] = rowData[i] + left(i)
+			}
+
+		case 2: // Up: each byte depends on the byte above
+			for i := range rowBytes {
+				decodedRow[i] = rowData[i] + prevRow[i]
+			}
+
+		case 3: // Average: each byte depends on average of left and above
+			for i := range rowBytes {
+				avg := (int(left(i)) + int(prevRow[i])) / 2
+				decodedRow[i] = rowData[i] + byte(avg)
+			}
+
+		case 4: // Paeth: each byte uses Paeth predictor
+			for i := range rowBytes {
+				decodedRow[i] = rowData[i] + paethPredictor(left(i), prevRow[i], upLeft(i))
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown PNG filter type: %d", filterByte)
+		}
+
+		result = append(result, decodedRow...)
+		copy(prevRow, decodedRow)
+	}
+
+	return result, nil
+}
+
+// paethPredictor implements the Paeth predictor algorithm from PNG spec.
+func paethPredictor(left, up, upLeft byte) byte {
+	iLeft := int(left)
+	iUp := int(up)
+	iUpLeft := int(upLeft)
+
+	p := iLeft + iUp - iUpLeft
+	pLeft := abs(p - iLeft)
+	pUp := abs(p - iUp)
+	pUpLeft := abs(p - iUpLeft)
+
+	if pLeft <= pUp && pLeft <= pUpLeft {
+		return left
+	}
+	if pUp <= pUpLeft {
+		return up
+	}
+	return upLeft
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// applyTIFFPredictor reverses TIFF Predictor 2 (horizontal differencing):
+// each color component of a sample was stored as the difference from the
+// same component of the sample to its left, modulo 2^bitsPerComponent.
+// Rows are rowBytes long and start on byte boundaries.
+func applyTIFFPredictor(data []byte, p predictorParams, rowBytes int) ([]byte, error) {
+	if len(data)%rowBytes != 0 {
+		return nil, fmt.Errorf("TIFF predictor: data length %d not divisible by row size %d", len(data), rowBytes)
+	}
+
+	result := slices.Clone(data)
+	components := p.colors * p.columns // Components per row
+	for rowStart := 0; rowStart < len(result); rowStart += rowBytes {
+		row := result[rowStart : rowStart+rowBytes]
+		switch p.bitsPerComponent {
+		case 8:
+			for i := p.colors; i < components; i++ {
+				row[i] += row[i-p.colors]
+			}
+
+		case 16:
+			for i := p.colors; i < components; i++ {
+				prev := uint16(row[2*(i-p.colors)])<<8 | uint16(row[2*(i-p.colors)+1])
+				cur := uint16(row[2*i])<<8 | uint16(row[2*i+1])
+				cur += prev
+				row[2*i], row[2*i+1] = byte(cur>>8), byte(cur)
+			}
+
+		default: // 1, 2 or 4 bits, packed from the high-order bit
+			bpc := p.bitsPerComponent
+			mask := byte(1<<bpc - 1)
+			sample := func(i int) byte {
+				shift := 8 - bpc - (i*bpc)%8
+				return row[i*bpc/8] >> shift & mask
+			}
+			for i := p.colors; i < components; i++ {
+				v := (sample(i) + sample(i-p.colors)) & mask
+				shift := 8 - bpc - (i*bpc)%8
+				row[i*bpc/8] = row[i*bpc/8]&^(mask<<shift) | v<<shift
+			}
+		}
+	}
+
+	return result, nil
+}
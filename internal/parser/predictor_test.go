@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"bytes"
+	"compress/zlib"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zlibCompress compresses data as a FlateDecode stream.
+func zlibCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// paethEncode applies the PNG Paeth filter to every row of data.
+func paethEncode(data []byte, rowBytes, bpp int) []byte {
+	var out []byte
+	prev := make([]byte, rowBytes)
+	for start := 0; start < len(data); start += rowBytes {
+		row := data[start : start+rowBytes]
+		out = append(out, 4)
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			out = append(out, row[i]-paethPredictor(left, prev[i], upLeft))
+		}
+		prev = row
+	}
+	return out
+}
+
+func predictorParmsDict(predictor, colors, bpc, columns int) *Dictionary {
+	dict := NewDictionary()
+	dict.Set("Predictor", NewInteger(int64(predictor)))
+	dict.Set("Colors", NewInteger(int64(colors)))
+	dict.Set("BitsPerComponent", NewInteger(int64(bpc)))
+	dict.Set("Columns", NewInteger(int64(columns)))
+	return dict
+}
+
+func TestReversePredictor_PNGPaeth(t *testing.T) {
+	// Three rows of four RGB samples: the left neighbor is 3 bytes back.
+	original := []byte{
+		10, 20, 30, 12, 22, 33, 15, 25, 36, 200, 100, 50,
+		11, 21, 31, 14, 25, 35, 18, 27, 40, 190, 110, 60,
+		13, 19, 29, 16, 28, 37, 20, 30, 44, 180, 120, 70,
+	}
+	encoded := paethEncode(original, 12, 3)
+
+	decoder := &flateDecoder{}
+	params := parsePredictorParams(predictorParmsDict(15, 3, 8, 4))
+	result, err := decoder.DecodeWithPredictor(zlibCompress(t, encoded), params)
+	require.NoError(t, err)
+	assert.Equal(t, original, result)
+
+	// Decoding with the wrong sample size does not recover the data.
+	wrong, err := reversePredictor(encoded, predictorParams{predictor: 15, columns: 12})
+	require.NoError(t, err)
+	assert.NotEqual(t, original, wrong)
+}
+
+func TestReversePredictor_TIFF(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   *Dictionary
+		encoded  []byte
+		original []byte
+	}{
+		{
+			name:     "8-bit gray",
+			params:   predictorParmsDict(2, 1, 8, 4),
+			encoded:  []byte{10, 5, 5, 250, 100, 1, 1, 1},
+			original: []byte{10, 15, 20, 14, 100, 101, 102, 103},
+		},
+		{
+			name:     "8-bit RGB",
+			params:   predictorParmsDict(2, 3, 8, 2),
+			encoded:  []byte{10, 20, 30, 1, 2, 3},
+			original: []byte{10, 20, 30, 11, 22, 33},
+		},
+		{
+			name:     "16-bit gray",
+			params:   predictorParmsDict(2, 1, 16, 3),
+			encoded:  []byte{0x01, 0xFF, 0x00, 0x01, 0xFF, 0xFF},
+			original: []byte{0x01, 0xFF, 0x02, 0x00, 0x01, 0xFF},
+		},
+		{
+			name:     "4-bit gray, odd columns pad each row",
+			params:   predictorParmsDict(2, 1, 4, 3),
+			encoded:  []byte{0x31, 0xF0, 0x12, 0x10},
+			original: []byte{0x34, 0x30, 0x13, 0x40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ReversePredictor(tt.encoded, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.original, result)
+			assert.NotEqual(t, tt.original, tt.encoded, "input must not be modified")
+		})
+	}
+}
+
+func TestReversePredictor_Params(t *testing.T) {
+	data := []byte{1, 2, 3}
+
+	// No /DecodeParms, or no /Predictor: data is unchanged.
+	result, err := ReversePredictor(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, data, result)
+
+	// Filter arrays use the first filter's parameters.
+	arr := NewArray()
+	arr.Append(predictorParmsDict(2, 1, 8, 3))
+	result, err = ReversePredictor(data, arr)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 3, 6}, result)
+
+	_, err = ReversePredictor(data, predictorParmsDict(2, 1, 3, 3))
+	assert.ErrorContains(t, err, "unsupported BitsPerComponent 3")
+	_, err = ReversePredictor(data, predictorParmsDict(12, 33, 8, 1))
+	assert.ErrorContains(t, err, "colors 33 out of valid range")
+}
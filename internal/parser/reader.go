@@ -7,18 +7,23 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/coregx/gxpdf/internal/encoding"
+	"github.com/coregx/gxpdf/internal/security"
 	"github.com/coregx/gxpdf/logging"
 )
 
 // PDF filter name constants.
 const (
-	filterFlateDecode = "FlateDecode"
-	filterDCTDecode   = "DCTDecode"
+	filterFlateDecode   = "FlateDecode"
+	filterLZWDecode     = "LZWDecode"
+	filterASCII85Decode = "ASCII85Decode"
+	filterDCTDecode     = "DCTDecode"
 )
 
 // Page tree node type constants.
@@ -76,6 +81,18 @@ type Reader struct {
 
 	// File access mutex (for seek and read operations)
 	fileMu sync.Mutex
+
+	// Object offsets found by scanning the whole file for "N G obj"
+	// headers, built on first use when the xref table is wrong
+	// (see findObjectByScan).
+	scannedOffsets map[int]int64
+	scanOnce       sync.Once
+
+	// Decryption of documents that open with the empty user password
+	// (nil if the document is not encrypted or cannot be decrypted).
+	decryptor       *security.StandardDecryptor
+	decryptMetadata bool // Whether metadata streams are encrypted
+	encryptObjNum   int  // Object number of the encryption dictionary
 }
 
 // NewReader creates a new PDF document reader.
@@ -133,6 +150,9 @@ func (r *Reader) Open() error {
 		return fmt.Errorf("failed to parse xref table: %w", err)
 	}
 
+	// Decrypt strings and streams as objects load (empty user password)
+	r.setupDecryption()
+
 	// Load catalog
 	if err := r.loadCatalog(); err != nil {
 		_ = r.Close()
@@ -623,12 +643,21 @@ func (r *Reader) GetObject(objectNum int) (PdfObject, error) {
 // recovered objects bypass strict xref validation.
 func (r *Reader) getInUseObject(objectNum int, entry *XRefEntry) (PdfObject, error) {
 	indirectObj, err := r.parseObjectAtOffset(entry.Offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse object %d: %w", objectNum, err)
-	}
-
-	// Check for object number mismatch and attempt recovery
-	if indirectObj.Number != objectNum {
+	switch {
+	case err != nil:
+		// Nothing parseable at the offset: rebuild offsets from the file
+		recoveredObj := r.findObjectByScan(objectNum)
+		if recoveredObj == nil {
+			return nil, fmt.Errorf("failed to parse object %d: %w", objectNum, err)
+		}
+		logging.Logger().Warn("xref recovery: no object at offset",
+			slog.Int("expected", objectNum),
+			slog.Int64("offset", entry.Offset),
+			slog.String("error", err.Error()),
+			slog.String("strategy", "full-scan"))
+		indirectObj = recoveredObj
+
+	case indirectObj.Number != objectNum:
 		// Try recovery strategies for malformed PDFs with incorrect xref entries
 		var recoveredObj *IndirectObject
 		var recoveryStrategy string
@@ -651,28 +680,35 @@ func (r *Reader) getInUseObject(objectNum int, entry *XRefEntry) (PdfObject, err
 			}
 		}
 
-		if recoveredObj != nil {
-			logging.Logger().Warn("xref recovery: object number mismatch",
-				slog.Int("expected", objectNum),
-				slog.Int("found", indirectObj.Number),
-				slog.Int64("offset", entry.Offset),
-				slog.String("strategy", recoveryStrategy))
-			indirectObj = recoveredObj
-		} else {
+		// Strategy 3: Scan the whole file
+		if recoveredObj == nil {
+			recoveredObj = r.findObjectByScan(objectNum)
+			if recoveredObj != nil {
+				recoveryStrategy = "full-scan"
+			}
+		}
+
+		if recoveredObj == nil {
 			return nil, fmt.Errorf("object number mismatch: expected %d, got %d",
 				objectNum, indirectObj.Number)
 		}
-	} else {
+		logging.Logger().Warn("xref recovery: object number mismatch",
+			slog.Int("expected", objectNum),
+			slog.Int("found", indirectObj.Number),
+			slog.Int64("offset", entry.Offset),
+			slog.String("strategy", recoveryStrategy))
+		indirectObj = recoveredObj
+
+	case indirectObj.Generation != entry.Generation:
 		// Object found at expected offset - validate generation number
 		// (PDF 1.7 Section 7.3.10: generation numbers are part of object identity)
-		if indirectObj.Generation != entry.Generation {
-			return nil, fmt.Errorf("object %d generation mismatch: expected %d, got %d",
-				objectNum, entry.Generation, indirectObj.Generation)
-		}
+		return nil, fmt.Errorf("object %d generation mismatch: expected %d, got %d",
+			objectNum, entry.Generation, indirectObj.Generation)
 	}
 
 	// Get the object (do NOT auto-resolve references to avoid circular refs)
 	obj := indirectObj.Object
+	r.decryptObject(obj, objectNum, indirectObj.Generation)
 
 	// Cache the object (write lock)
 	r.mu.Lock()
@@ -774,6 +810,56 @@ func (r *Reader) scanDirection(startOffset int64, pattern []byte, maxSize int, f
 	return obj
 }
 
+// objectHeaderPattern matches an indirect object header "N G obj" that is
+// not preceded by a digit. Group 1 is the object number.
+var objectHeaderPattern = regexp.MustCompile(`(?:^|[^0-9])([0-9]+)[\x00\t\n\f\r ]+[0-9]+[\x00\t\n\f\r ]+obj\b`)
+
+// findObjectByScan locates an object by scanning the whole file for object
+// headers, the way viewers rebuild a damaged cross-reference table. The
+// offsets are collected once per document; when an object is defined more
+// than once (incremental updates), the last definition wins.
+// Returns nil if the object is not in the file.
+func (r *Reader) findObjectByScan(objectNum int) *IndirectObject {
+	r.scanOnce.Do(r.scanObjectOffsets)
+
+	offset, ok := r.scannedOffsets[objectNum]
+	if !ok {
+		return nil
+	}
+	obj, err := r.parseObjectAtOffset(offset)
+	if err != nil || obj.Number != objectNum {
+		return nil
+	}
+	return obj
+}
+
+// scanObjectOffsets fills scannedOffsets from the object headers in the
+// file. Offsets are stored relative to the %PDF- header, like xref offsets.
+func (r *Reader) scanObjectOffsets() {
+	r.fileMu.Lock()
+	defer r.fileMu.Unlock()
+
+	r.scannedOffsets = make(map[int]int64)
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	data, err := io.ReadAll(r.file)
+	if err != nil {
+		return
+	}
+
+	for _, m := range objectHeaderPattern.FindAllSubmatchIndex(data, -1) {
+		objectNum, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+		r.scannedOffsets[objectNum] = int64(m[2]) - r.headerOffset
+	}
+
+	logging.Logger().Warn("xref recovery: rebuilt object offsets by scanning file",
+		slog.Int("objects", len(r.scannedOffsets)))
+}
+
 // getCompressedObject retrieves a compressed object from an Object Stream (PDF 1.5+).
 //
 // Compressed objects are stored in special stream objects (Type /ObjStm) along
@@ -860,7 +946,9 @@ func (r *Reader) getCompressedObject(objectNum int, entry *XRefEntry) (PdfObject
 		return nil, fmt.Errorf("ObjStm %d has invalid /First: %d", objStmNum, firstOffset)
 	}
 
-	// Decode the stream
+	// Decrypt and decode the stream (the objects inside are not encrypted
+	// on their own)
+	r.decryptObject(stream, objStmNum, indirectObj.Generation)
 	decodedData, err := r.decodeStream(stream)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode ObjStm %d: %w", objStmNum, err)
@@ -889,66 +977,90 @@ func (r *Reader) getCompressedObject(objectNum int, entry *XRefEntry) (PdfObject
 	return obj, nil
 }
 
-// createDCTDecoder creates a DCT decoder with parameters from the stream dictionary.
-func (r *Reader) createDCTDecoder(dict *Dictionary) *encoding.DCTDecoder {
-	// Check for decode parameters
-	decodeParmsObj := dict.Get("DecodeParms")
-	if decodeParmsObj == nil {
+// createDCTDecoder creates a DCT decoder with parameters from the filter's
+// /DecodeParms dictionary.
+func (r *Reader) createDCTDecoder(decodeParms PdfObject) *encoding.DCTDecoder {
+	parmsDict, ok := decodeParms.(*Dictionary)
+	if !ok {
 		// No parameters - use defaults
 		return encoding.NewDCTDecoder()
 	}
 
 	// Extract ColorTransform parameter
 	colorTransform := 1 // Default: YCbCr to RGB
-	if parmsDict, ok := decodeParmsObj.(*Dictionary); ok {
-		if ctObj := parmsDict.Get("ColorTransform"); ctObj != nil {
-			if ctInt, ok := ctObj.(*Integer); ok {
-				colorTransform = int(ctInt.Value())
-			}
-		}
+	if ctInt, ok := parmsDict.Get("ColorTransform").(*Integer); ok {
+		colorTransform = int(ctInt.Value())
 	}
 
 	return encoding.NewDCTDecoderWithParams(colorTransform)
 }
 
+// DecodeStream returns the data of stream with all its filters decoded.
+//
+// Reference: PDF 1.7 specification, Section 7.4 (Filters).
+func (r *Reader) DecodeStream(stream *Stream) ([]byte, error) {
+	return r.decodeStream(stream)
+}
+
 // decodeStream decodes a stream object based on its filters.
+//
+// Filter arrays are applied in order, each filter with the matching entry
+// of a /DecodeParms array, so data encoded as [/ASCII85Decode /LZWDecode]
+// is first converted from ASCII85 and then decompressed.
 func (r *Reader) decodeStream(stream *Stream) ([]byte, error) {
 	dict := stream.Dictionary()
-	filterObj := dict.Get("Filter")
-
-	// No filter - return raw content
-	if filterObj == nil {
-		return stream.Content(), nil
-	}
+	filterNames := r.filterNames(r.resolveReferences(dict.Get("Filter")))
+	decodeParms := r.resolveReferences(dict.Get("DecodeParms"))
 
-	// Extract filter name from Filter entry
-	filterName := r.extractFilterName(filterObj)
-	if filterName == "" {
-		return stream.Content(), nil
+	content := stream.Content()
+	for i, filterName := range filterNames {
+		var err error
+		content, err = r.applyFilter(filterName, filterDecodeParms(decodeParms, i), content)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	// Apply the filter
-	return r.applyFilter(filterName, dict, stream.Content())
+	return content, nil
 }
 
-// extractFilterName extracts the filter name from a Filter object.
-func (r *Reader) extractFilterName(filterObj PdfObject) string {
+// filterNames extracts the filter names from a Filter object, in the
+// order they are applied.
+func (r *Reader) filterNames(filterObj PdfObject) []string {
 	switch obj := filterObj.(type) {
 	case *Name:
-		return obj.Value()
+		return []string{obj.Value()}
 	case *Array:
-		// Multiple filters - for now, handle single filter case
-		if obj.Len() > 0 {
-			if nameObj, ok := obj.Get(0).(*Name); ok {
-				return nameObj.Value()
+		names := make([]string, 0, obj.Len())
+		for i := 0; i < obj.Len(); i++ {
+			if nameObj, ok := obj.Get(i).(*Name); ok {
+				names = append(names, nameObj.Value())
 			}
 		}
+		return names
 	}
-	return ""
+	return nil
 }
 
-// applyFilter applies the specified filter to stream content.
-func (r *Reader) applyFilter(filterName string, dict *Dictionary, content []byte) ([]byte, error) {
+// filterDecodeParms returns the /DecodeParms entry of the i-th filter:
+// the i-th element of a parameter array, or the dictionary itself for a
+// single filter.
+func filterDecodeParms(decodeParms PdfObject, i int) PdfObject {
+	switch parms := decodeParms.(type) {
+	case *Array:
+		if i < parms.Len() {
+			return parms.Get(i)
+		}
+	case *Dictionary:
+		if i == 0 {
+			return parms
+		}
+	}
+	return nil
+}
+
+// applyFilter applies the specified filter to stream content. decodeParms
+// is the filter's own /DecodeParms entry (nil if absent).
+func (r *Reader) applyFilter(filterName string, decodeParms PdfObject, content []byte) ([]byte, error) {
 	switch filterName {
 	case filterFlateDecode:
 		decoder := encoding.NewFlateDecoder()
@@ -956,10 +1068,38 @@ func (r *Reader) applyFilter(filterName string, dict *Dictionary, content []byte
 		if err != nil {
 			return nil, fmt.Errorf("%s failed: %w", filterFlateDecode, err)
 		}
+		decoded, err = ReversePredictor(decoded, decodeParms)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed: %w", filterFlateDecode, err)
+		}
+		return decoded, nil
+
+	case filterLZWDecode:
+		earlyChange := 1
+		if parmsDict, ok := decodeParms.(*Dictionary); ok {
+			if ecInt, ok := parmsDict.Get("EarlyChange").(*Integer); ok {
+				earlyChange = int(ecInt.Value())
+			}
+		}
+		decoded, err := encoding.NewLZWDecoderWithParams(earlyChange).Decode(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed: %w", filterLZWDecode, err)
+		}
+		decoded, err = ReversePredictor(decoded, decodeParms)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed: %w", filterLZWDecode, err)
+		}
+		return decoded, nil
+
+	case filterASCII85Decode:
+		decoded, err := encoding.NewASCII85Decoder().Decode(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed: %w", filterASCII85Decode, err)
+		}
 		return decoded, nil
 
 	case filterDCTDecode:
-		decoder := r.createDCTDecoder(dict)
+		decoder := r.createDCTDecoder(decodeParms)
 		decoded, err := decoder.Decode(content)
 		if err != nil {
 			return nil, fmt.Errorf("DCTDecode failed: %w", err)
@@ -1284,15 +1424,19 @@ func (r *Reader) XRefTable() *XRefTable {
 }
 
 // DocInfo contains document metadata from the Info dictionary.
+//
+// Text fields are decoded to UTF-8. Dates are zero if missing or invalid.
 type DocInfo struct {
-	Version   string
-	Title     string
-	Author    string
-	Subject   string
-	Keywords  string
-	Creator   string
-	Producer  string
-	Encrypted bool
+	Version      string
+	Title        string
+	Author       string
+	Subject      string
+	Keywords     string
+	Creator      string
+	Producer     string
+	CreationDate time.Time
+	ModDate      time.Time
+	Encrypted    bool
 }
 
 // GetDocumentInfo returns document metadata from the Info dictionary.
@@ -1327,17 +1471,61 @@ func (r *Reader) GetDocumentInfo() DocInfo {
 		return info
 	}
 
-	// Extract string fields using GetString helper
-	info.Title = dict.GetString("Title")
-	info.Author = dict.GetString("Author")
-	info.Subject = dict.GetString("Subject")
-	info.Keywords = dict.GetString("Keywords")
-	info.Creator = dict.GetString("Creator")
-	info.Producer = dict.GetString("Producer")
+	// Values may themselves be indirect references.
+	text := func(key string) string {
+		if s, ok := r.resolveReferences(dict.Get(key)).(*String); ok {
+			return DecodeTextString(s.Bytes())
+		}
+		return ""
+	}
+	date := func(key string) time.Time {
+		t, err := ParseDate(text(key))
+		if err != nil {
+			return time.Time{}
+		}
+		return t
+	}
+
+	info.Title = text("Title")
+	info.Author = text("Author")
+	info.Subject = text("Subject")
+	info.Keywords = text("Keywords")
+	info.Creator = text("Creator")
+	info.Producer = text("Producer")
+	info.CreationDate = date("CreationDate")
+	info.ModDate = date("ModDate")
 
 	return info
 }
 
+// GetMetadata returns the document's XMP metadata packet from the /Metadata
+// stream of the catalog, with any filters decoded.
+//
+// Returns nil (no error) if the document has no metadata stream.
+//
+// Reference: PDF 1.7 specification, Section 14.3.2 (Metadata Streams).
+func (r *Reader) GetMetadata() ([]byte, error) {
+	if r.catalog == nil {
+		return nil, fmt.Errorf("catalog not loaded (call Open first)")
+	}
+
+	metadataObj := r.catalog.Get("Metadata")
+	if metadataObj == nil {
+		return nil, nil // No metadata
+	}
+
+	stream, ok := r.resolveReferences(metadataObj).(*Stream)
+	if !ok {
+		return nil, fmt.Errorf("metadata is not a stream")
+	}
+
+	data, err := r.decodeStream(stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode metadata stream: %w", err)
+	}
+	return data, nil
+}
+
 // OpenPDF is a convenience function that creates a Reader and opens the PDF.
 //
 // This is equivalent to:
@@ -1177,9 +1177,10 @@ func buildNearbyScanPDF() []byte {
 	return []byte(body + xref + trailer)
 }
 
-// buildUnrecoverablePDF creates a PDF where xref offset is completely wrong
-// and the object cannot be found (outside 4KB scan range).
-func buildUnrecoverablePDF() []byte {
+// buildFarAwayPDF creates a PDF where xref offset is completely wrong
+// and the object is outside the 4KB nearby-scan range, so it can only be
+// found by scanning the whole file.
+func buildFarAwayPDF() []byte {
 	// Add 8KB of padding to push object 3 outside scan range
 	padding := strings.Repeat(" ", 8192)
 
@@ -1211,6 +1212,35 @@ func buildUnrecoverablePDF() []byte {
 	return []byte(body + xref + trailer)
 }
 
+// buildUnrecoverablePDF creates a PDF whose xref table lists an object
+// that is not in the file at all.
+func buildUnrecoverablePDF() []byte {
+	body := "%PDF-1.7\n" +
+		"1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+		"2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n"
+
+	obj1Offset := 9
+	obj2Offset := 58
+
+	xrefOffset := len(body)
+
+	// Object 3 is missing; its entry points to the start of the file
+	xref := fmt.Sprintf("xref\n0 4\n"+
+		"0000000000 65535 f \n"+
+		"%010d 00000 n \n"+
+		"%010d 00000 n \n"+
+		"%010d 00000 n \n",
+		obj1Offset,
+		obj2Offset,
+		0,
+	)
+
+	trailer := fmt.Sprintf("trailer\n<< /Size 4 /Root 1 0 R >>\n"+
+		"startxref\n%d\n%%%%EOF\n", xrefOffset)
+
+	return []byte(body + xref + trailer)
+}
+
 func TestReader_XRefRecovery_OffByOne(t *testing.T) {
 	data := buildOffByOnePDF()
 
@@ -1266,6 +1296,55 @@ func TestReader_XRefRecovery_NearbyScan(t *testing.T) {
 	assert.Equal(t, "Page", dict3.GetName("Type").Value())
 }
 
+func TestReader_XRefRecovery_FullScan(t *testing.T) {
+	data := buildFarAwayPDF()
+
+	tmpFile, err := os.CreateTemp("", "fullscan-*.pdf")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, tmpFile.Close())
+
+	reader := NewReader(tmpFile.Name())
+	err = reader.Open()
+	require.NoError(t, err)
+	defer reader.Close()
+
+	// Object 3 is 8KB away from its xref offset: found by scanning the file
+	obj3, err := reader.GetObject(3)
+	require.NoError(t, err, "should find object 3 by scanning the whole file")
+	dict3, ok := obj3.(*Dictionary)
+	require.True(t, ok)
+	assert.Equal(t, "Page", dict3.GetName("Type").Value())
+}
+
+// TestReader_XRefRecovery_CorruptXRefFixture opens a file whose xref
+// offsets are all wrong (testdata/generators/corrupt_xref.go).
+func TestReader_XRefRecovery_CorruptXRefFixture(t *testing.T) {
+	reader := NewReader(getTestFilePath("corrupt_xref.pdf"))
+	require.NoError(t, reader.Open(), "should rebuild offsets by scanning")
+	defer reader.Close()
+
+	count, err := reader.GetPageCount()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	for i := 0; i < count; i++ {
+		page, err := reader.GetPage(i)
+		require.NoError(t, err, "page %d", i)
+		assert.Equal(t, "Page", page.GetName("Type").Value())
+	}
+
+	// The last page has its own MediaBox (A4)
+	page, err := reader.GetPage(2)
+	require.NoError(t, err)
+	mediaBox, ok := page.Get("MediaBox").(*Array)
+	require.True(t, ok)
+	assert.Equal(t, int64(595), mediaBox.Get(2).(*Integer).Value())
+}
+
 func TestReader_XRefRecovery_Failure(t *testing.T) {
 	data := buildUnrecoverablePDF()
 
@@ -3,6 +3,7 @@ package parser
 import (
 	"bytes"
 	"compress/zlib"
+	"encoding/ascii85"
 	"image"
 	"image/color"
 	"image/jpeg"
@@ -134,7 +135,7 @@ func TestStreamDecoder_DCTDecode_WithParams(t *testing.T) {
 // TestStreamDecoder_UnsupportedFilter tests handling of unsupported filters.
 func TestStreamDecoder_UnsupportedFilter(t *testing.T) {
 	dict := NewDictionary()
-	dict.Set("Filter", NewName("LZWDecode"))
+	dict.Set("Filter", NewName("JBIG2Decode"))
 	stream := NewStream(dict, []byte("data"))
 
 	reader := NewReader("")
@@ -158,7 +159,7 @@ func TestStreamDecoder_MultipleFilters(t *testing.T) {
 	require.NoError(t, err)
 	compressedData := buf.Bytes()
 
-	// Create stream with filter array (only first filter is applied in current implementation)
+	// Create stream with a single-element filter array
 	dict := NewDictionary()
 	filters := NewArray()
 	filters.Append(NewName("FlateDecode"))
@@ -173,21 +174,65 @@ func TestStreamDecoder_MultipleFilters(t *testing.T) {
 	assert.Equal(t, originalData, decoded)
 }
 
-// TestExtractFilterName tests the filter name extraction logic.
-func TestExtractFilterName(t *testing.T) {
+// TestStreamDecoder_ChainedFilters tests that filter arrays are applied in
+// order, each with its own /DecodeParms entry.
+func TestStreamDecoder_ChainedFilters(t *testing.T) {
+	reader := NewReader("")
+
+	t.Run("ASCII85Decode then LZWDecode", func(t *testing.T) {
+		// "-----A---B" as LZW (PDF 1.7 specification, Section 7.4.4.2).
+		lzwData := []byte{0x80, 0x0B, 0x60, 0x50, 0x22, 0x0C, 0x0C, 0x85, 0x01}
+		encoded := make([]byte, ascii85.MaxEncodedLen(len(lzwData)))
+		encoded = append(encoded[:ascii85.Encode(encoded, lzwData)], "~>"...)
+
+		dict := NewDictionary()
+		filters := NewArray()
+		filters.Append(NewName("ASCII85Decode"))
+		filters.Append(NewName("LZWDecode"))
+		dict.Set("Filter", filters)
+
+		decoded, err := reader.decodeStream(NewStream(dict, encoded))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("-----A---B"), decoded)
+	})
+
+	t.Run("ASCII85Decode then FlateDecode with predictor", func(t *testing.T) {
+		original := []byte{1, 2, 3, 10, 20, 30}
+		compressed := zlibCompress(t, []byte{2, 1, 2, 3, 2, 9, 18, 27})
+		encoded := make([]byte, ascii85.MaxEncodedLen(len(compressed)))
+		encoded = append(encoded[:ascii85.Encode(encoded, compressed)], "~>"...)
+
+		dict := NewDictionary()
+		filters := NewArray()
+		filters.Append(NewName("ASCII85Decode"))
+		filters.Append(NewName("FlateDecode"))
+		dict.Set("Filter", filters)
+		parms := NewArray()
+		parms.Append(NewNull())
+		parms.Append(predictorParmsDict(12, 1, 8, 3))
+		dict.Set("DecodeParms", parms)
+
+		decoded, err := reader.decodeStream(NewStream(dict, encoded))
+		require.NoError(t, err)
+		assert.Equal(t, original, decoded)
+	})
+}
+
+// TestFilterNames tests the filter name extraction logic.
+func TestFilterNames(t *testing.T) {
 	reader := NewReader("")
 
 	tests := []struct {
 		name     string
 		setup    func() PdfObject
-		expected string
+		expected []string
 	}{
 		{
 			name: "Name object",
 			setup: func() PdfObject {
 				return NewName("FlateDecode")
 			},
-			expected: "FlateDecode",
+			expected: []string{"FlateDecode"},
 		},
 		{
 			name: "Array with single filter",
@@ -196,7 +241,7 @@ func TestExtractFilterName(t *testing.T) {
 				arr.Append(NewName("DCTDecode"))
 				return arr
 			},
-			expected: "DCTDecode",
+			expected: []string{"DCTDecode"},
 		},
 		{
 			name: "Array with multiple filters",
@@ -206,28 +251,28 @@ func TestExtractFilterName(t *testing.T) {
 				arr.Append(NewName("FlateDecode"))
 				return arr
 			},
-			expected: "ASCII85Decode", // First filter
+			expected: []string{"ASCII85Decode", "FlateDecode"}, // In order
 		},
 		{
 			name: "Empty array",
 			setup: func() PdfObject {
 				return NewArray()
 			},
-			expected: "",
+			expected: []string{},
 		},
 		{
 			name: "Nil object",
 			setup: func() PdfObject {
 				return nil
 			},
-			expected: "",
+			expected: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			filterObj := tt.setup()
-			result := reader.extractFilterName(filterObj)
+			result := reader.filterNames(filterObj)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -276,7 +321,7 @@ func TestCreateDCTDecoder(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dict := tt.setup()
-			decoder := reader.createDCTDecoder(dict)
+			decoder := reader.createDCTDecoder(dict.Get("DecodeParms"))
 			require.NotNil(t, decoder)
 			assert.Equal(t, tt.expectedTransform, decoder.ColorTransform)
 		})
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// pdfDocEncodingHigh maps the bytes 0x80-0xA0 of PDFDocEncoding, where it
+// differs from Latin-1, to Unicode (0x9F is undefined).
+var pdfDocEncodingHigh = [...]rune{
+	'•', '†', '‡', '…', '—', '–', 'ƒ', '⁄', '‹', '›', '−', '‰', '„', '“', '”', '‘',
+	'’', '‚', '™', 'ﬁ', 'ﬂ', 'Ł', 'Œ', 'Š', 'Ÿ', 'Ž', 'ı', 'ł', 'œ', 'š', 'ž', '�',
+	'€',
+}
+
+// DecodeTextString decodes a PDF text string, such as a document title or
+// an outline item: UTF-16BE if it starts with the byte order mark,
+// PDFDocEncoding otherwise.
+//
+// Reference: PDF 1.7 specification, Section 7.9.2.2 (Text String Type).
+func DecodeTextString(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFE && b[1] == 0xFF {
+		units := make([]uint16, 0, (len(b)-2)/2)
+		for i := 2; i+1 < len(b); i += 2 {
+			units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		if c >= 0x80 && c <= 0xA0 {
+			runes[i] = pdfDocEncodingHigh[c-0x80]
+		} else {
+			runes[i] = rune(c)
+		}
+	}
+	return string(runes)
+}
+
+// ParseDate parses a PDF date string: D:YYYYMMDDHHmmSSOHH'mm'.
+//
+// Everything after the year is optional; missing fields take their
+// smallest value. O is the relationship to UT: "+" or "-" followed by the
+// offset, or "Z". Dates without it are taken as UT. The "D:" prefix and
+// the apostrophes are accepted when missing, as written by some producers.
+//
+// Reference: PDF 1.7 specification, Section 7.9.4 (Dates).
+func ParseDate(s string) (time.Time, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "D:")
+
+	// digits consumes n digits from rest; ok is false if there are none
+	// left, and an error is returned for a partial field.
+	digits := func(n int) (v int, ok bool, err error) {
+		if rest == "" || rest[0] < '0' || rest[0] > '9' {
+			return 0, false, nil
+		}
+		if len(rest) < n {
+			return 0, false, fmt.Errorf("invalid PDF date %q", s)
+		}
+		for _, c := range rest[:n] {
+			if c < '0' || c > '9' {
+				return 0, false, fmt.Errorf("invalid PDF date %q", s)
+			}
+			v = v*10 + int(c-'0')
+		}
+		rest = rest[n:]
+		return v, true, nil
+	}
+
+	year, ok, err := digits(4)
+	if err != nil || !ok {
+		return time.Time{}, fmt.Errorf("invalid PDF date %q", s)
+	}
+	// Month, day, hour, minute, second.
+	fields := [5]int{1, 1, 0, 0, 0}
+	limits := [5][2]int{{1, 12}, {1, 31}, {0, 23}, {0, 59}, {0, 59}}
+	for i := range fields {
+		v, ok, err := digits(2)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !ok {
+			break
+		}
+		if v < limits[i][0] || v > limits[i][1] {
+			return time.Time{}, fmt.Errorf("invalid PDF date %q: field out of range", s)
+		}
+		fields[i] = v
+	}
+
+	loc := time.UTC
+	if rest != "" {
+		sign := rest[0]
+		rest = rest[1:]
+		switch sign {
+		case 'Z':
+			// Sometimes followed by a zero offset ("Z00'00'").
+		case '+', '-':
+			hours, _, err := digits(2)
+			if err != nil {
+				return time.Time{}, err
+			}
+			rest = strings.TrimPrefix(rest, "'")
+			minutes, _, err := digits(2)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if hours > 23 || minutes > 59 {
+				return time.Time{}, fmt.Errorf("invalid PDF date %q: offset out of range", s)
+			}
+			offset := hours*3600 + minutes*60
+			if sign == '-' {
+				offset = -offset
+			}
+			if offset != 0 {
+				loc = time.FixedZone("", offset)
+			}
+		default:
+			return time.Time{}, fmt.Errorf("invalid PDF date %q", s)
+		}
+	}
+
+	t := time.Date(year, time.Month(fields[0]), fields[1], fields[2], fields[3], fields[4], 0, loc)
+	if t.Day() != fields[1] {
+		return time.Time{}, fmt.Errorf("invalid PDF date %q: no day %d in month %d", s, fields[1], fields[0])
+	}
+	return t, nil
+}
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTextString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{"empty", nil, ""},
+		{"ASCII", []byte("Hello"), "Hello"},
+		{"PDFDocEncoding", []byte("a\x84b\x92c\xa0"), "a—b™c€"},
+		{"Latin-1 range", []byte("R\xe9sum\xe9"), "Résumé"},
+		{"UTF-16BE", []byte("\xfe\xff\x00H\x00i\x65\xe5"), "Hi日"},
+		{"UTF-16BE surrogate pair", []byte("\xfe\xff\xd8\x3d\xdc\xc4"), "📄"},
+		{"UTF-16BE odd length", []byte("\xfe\xff\x00A\x00"), "A"},
+		{"BOM only", []byte("\xfe\xff"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DecodeTextString(tt.in))
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"D:20240315093000+05'30'", time.Date(2024, 3, 15, 9, 30, 0, 0, time.FixedZone("", 5*3600+30*60))},
+		{"D:20241201120000-08'00'", time.Date(2024, 12, 1, 12, 0, 0, 0, time.FixedZone("", -8*3600))},
+		{"D:20241201120000Z", time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)},
+		{"D:20241201120000Z00'00'", time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)},
+		{"D:20241201120000+02", time.Date(2024, 12, 1, 12, 0, 0, 0, time.FixedZone("", 2*3600))},
+		{"D:20241201120000+0130", time.Date(2024, 12, 1, 12, 0, 0, 0, time.FixedZone("", 90*60))},
+		{"D:20241201120000", time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)},
+		{"20241201120000Z", time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)},
+		{"D:202412", time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"D:2024", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDate(tt.in)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+			_, wantOffset := tt.want.Zone()
+			_, gotOffset := got.Zone()
+			assert.Equal(t, wantOffset, gotOffset)
+		})
+	}
+}
+
+func TestParseDate_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"D:",
+		"D:24",
+		"D:2024131",
+		"D:20241301",
+		"D:20240230",
+		"D:20241201250000",
+		"D:20241201120000X",
+		"D:20241201120000+25'00'",
+		"yesterday",
+	} {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseDate(in)
+			assert.Error(t, err)
+		})
+	}
+}
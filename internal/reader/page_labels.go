@@ -0,0 +1,103 @@
+package reader
+
+import (
+	"sort"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// maxNumberTreeDepth limits recursion into /Kids of a number tree so a
+// malformed (cyclic) tree cannot recurse forever.
+const maxNumberTreeDepth = 32
+
+// PageLabels returns the page label ranges from the catalog's /PageLabels
+// number tree, sorted by page index.
+//
+// Returns nil if the document defines no page labels. Malformed entries
+// are skipped rather than reported, since labels are purely cosmetic; of
+// several entries for the same page, the first in tree order is kept.
+//
+// Reference: PDF 1.7 Specification, Section 12.4.2 (Page Labels).
+func (r *PdfReader) PageLabels() []document.PageLabelRange {
+	catalog, err := r.reader.GetCatalog()
+	if err != nil {
+		return nil
+	}
+
+	root, ok := r.reader.ResolveReferences(catalog.Get("PageLabels")).(*parser.Dictionary)
+	if !ok {
+		return nil
+	}
+
+	var ranges []document.PageLabelRange
+	r.collectPageLabels(root, &ranges, 0)
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	// Keep the first entry for each page; the domain model rejects
+	// duplicates.
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].PageIndex < ranges[j].PageIndex
+	})
+	unique := ranges[:1]
+	for _, lr := range ranges[1:] {
+		if lr.PageIndex != unique[len(unique)-1].PageIndex {
+			unique = append(unique, lr)
+		}
+	}
+
+	doc := document.NewDocument()
+	if err := doc.SetPageLabels(unique); err != nil {
+		return nil
+	}
+	return doc.PageLabels()
+}
+
+// collectPageLabels walks a number tree node, appending its /Nums entries.
+func (r *PdfReader) collectPageLabels(node *parser.Dictionary, out *[]document.PageLabelRange, depth int) {
+	if depth > maxNumberTreeDepth {
+		return
+	}
+
+	if nums, ok := r.reader.ResolveReferences(node.Get("Nums")).(*parser.Array); ok {
+		for i := 0; i+1 < nums.Len(); i += 2 {
+			key, ok := nums.Get(i).(*parser.Integer)
+			if !ok || key.Int() < 0 {
+				continue
+			}
+			dict, ok := r.reader.ResolveReferences(nums.Get(i + 1)).(*parser.Dictionary)
+			if !ok {
+				continue
+			}
+			*out = append(*out, pageLabelFromDict(key.Int(), dict))
+		}
+	}
+
+	if kids, ok := r.reader.ResolveReferences(node.Get("Kids")).(*parser.Array); ok {
+		for i := 0; i < kids.Len(); i++ {
+			if kid, ok := r.reader.ResolveReferences(kids.Get(i)).(*parser.Dictionary); ok {
+				r.collectPageLabels(kid, out, depth+1)
+			}
+		}
+	}
+}
+
+// pageLabelFromDict converts a page label dictionary to a domain range.
+func pageLabelFromDict(pageIndex int, dict *parser.Dictionary) document.PageLabelRange {
+	label := document.PageLabelRange{
+		PageIndex: pageIndex,
+		Start:     int(dict.GetInteger("St")),
+	}
+	if prefix, ok := dict.Get("P").(*parser.String); ok {
+		label.Prefix = parser.DecodeTextString(prefix.Bytes())
+	}
+	if style := dict.GetName("S"); style != nil {
+		label.Style = document.PageLabelStyle(style.Value())
+	}
+	if label.Start < 0 {
+		label.Start = 0
+	}
+	return label
+}
@@ -36,6 +36,13 @@ func NewPdfReader(path string) (*PdfReader, error) {
 	return &PdfReader{reader: r}, nil
 }
 
+// NewPdfReaderFromParser wraps an already opened parser.Reader.
+//
+// Closing the returned reader closes r.
+func NewPdfReaderFromParser(r *parser.Reader) *PdfReader {
+	return &PdfReader{reader: r}
+}
+
 // Close closes the PDF file and releases resources.
 func (r *PdfReader) Close() error {
 	return r.reader.Close()
@@ -0,0 +1,326 @@
+package render
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/coregx/gxpdf/internal/encoding"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// maxImageSamples limits the decoded size of a single image XObject.
+const maxImageSamples = 64_000_000
+
+// sampledImage is a decoded image XObject ready for painting.
+type sampledImage struct {
+	w, h  int
+	pix   []color.RGBA // Row-major, top row first
+	alpha []uint8      // Optional per-pixel alpha (nil = opaque)
+	mask  bool         // Stencil mask: paint the fill color where alpha > 0
+}
+
+// drawImage paints an image XObject into the unit square of the CTM.
+//
+// Each device pixel inside the transformed unit square is mapped back to
+// image space and sampled with nearest-neighbor filtering.
+//
+// Reference: PDF 1.7 Specification, Section 8.9 (Images).
+func (r *renderer) drawImage(stream *parser.Stream) {
+	img := r.decodeImage(stream)
+	if img == nil {
+		return
+	}
+
+	ctm := r.state.ctm
+	inv, ok := ctm.invert()
+	if !ok {
+		return
+	}
+
+	// Device bounding box of the unit square.
+	corners := []point{ctm.apply(0, 0), ctm.apply(1, 0), ctm.apply(0, 1), ctm.apply(1, 1)}
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range corners {
+		minX, maxX = math.Min(minX, c.x), math.Max(maxX, c.x)
+		minY, maxY = math.Min(minY, c.y), math.Max(maxY, c.y)
+	}
+	bounds := r.img.Bounds()
+	x0 := clampInt(int(math.Floor(minX)), 0, bounds.Dx())
+	x1 := clampInt(int(math.Ceil(maxX)), 0, bounds.Dx())
+	y0 := clampInt(int(math.Floor(minY)), 0, bounds.Dy())
+	y1 := clampInt(int(math.Ceil(maxY)), 0, bounds.Dy())
+
+	clip := r.state.clip
+	stride := r.img.Stride
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			u := inv.apply(float64(px)+0.5, float64(py)+0.5)
+			if u.x < 0 || u.x >= 1 || u.y < 0 || u.y >= 1 {
+				continue
+			}
+
+			// Image space maps row 0 to the top (v = 1) of the unit square.
+			sx := int(u.x * float64(img.w))
+			sy := int((1 - u.y) * float64(img.h))
+			if sx >= img.w || sy >= img.h {
+				continue
+			}
+			i := sy*img.w + sx
+
+			a := r.state.fillAlpha
+			if img.alpha != nil {
+				a *= float64(img.alpha[i]) / 255
+			}
+			if clip != nil {
+				a *= float64(clip.at(px, py))
+			}
+			if a <= 0 {
+				continue
+			}
+
+			c := r.state.fill
+			if !img.mask {
+				c = img.pix[i]
+			}
+			blend(r.img.Pix[py*stride+px*4:], c, a)
+		}
+	}
+}
+
+// decodeImage decodes an image XObject, returning nil if the image uses
+// features the renderer does not support.
+func (r *renderer) decodeImage(stream *parser.Stream) *sampledImage {
+	dict := stream.Dictionary()
+	w := int(dict.GetInteger("Width"))
+	h := int(dict.GetInteger("Height"))
+	if w <= 0 || h <= 0 || w*h > maxImageSamples {
+		return nil
+	}
+
+	if isFlag(r.resolve(dict.Get("ImageMask"))) {
+		return r.decodeStencil(stream, w, h)
+	}
+
+	var img *sampledImage
+	filters := r.filterNames(dict)
+	if len(filters) > 0 && (filters[len(filters)-1] == "DCTDecode" || filters[len(filters)-1] == "DCT") {
+		img = decodeJPEG(stream.Content(), w, h)
+	} else {
+		img = r.decodeSamples(stream, w, h)
+	}
+	if img == nil {
+		return nil
+	}
+
+	// Soft mask: a grayscale image giving per-pixel alpha (Section 11.6.5.3).
+	if smask, ok := r.resolve(dict.Get("SMask")).(*parser.Stream); ok {
+		img.alpha = r.decodeSoftMask(smask, w, h)
+	}
+
+	return img
+}
+
+// decodeJPEG decodes DCTDecode data.
+func decodeJPEG(data []byte, w, h int) *sampledImage {
+	src, err := encoding.NewDCTDecoder().DecodeToImage(data)
+	if err != nil {
+		return nil
+	}
+	b := src.Bounds()
+	if b.Dx() != w || b.Dy() != h {
+		w, h = b.Dx(), b.Dy()
+	}
+
+	img := &sampledImage{w: w, h: h, pix: make([]color.RGBA, w*h)}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBAModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.RGBA)
+			c.A = 0xFF
+			img.pix[y*w+x] = c
+		}
+	}
+	return img
+}
+
+// decodeSamples decodes 8-bit Flate or unfiltered samples in a gray, RGB,
+// CMYK, ICC-based or indexed color space.
+func (r *renderer) decodeSamples(stream *parser.Stream, w, h int) *sampledImage {
+	dict := stream.Dictionary()
+	if bpc := dict.GetInteger("BitsPerComponent"); bpc != 8 {
+		return nil
+	}
+
+	data, err := r.decode(stream)
+	if err != nil {
+		return nil
+	}
+
+	cs := r.colorSpace(r.resolve(dict.Get("ColorSpace")))
+	if cs.components == 0 {
+		return nil
+	}
+
+	bpp := cs.components
+	if cs.palette != nil {
+		bpp = 1
+	}
+	if len(data) < w*h*bpp {
+		return nil
+	}
+
+	img := &sampledImage{w: w, h: h, pix: make([]color.RGBA, w*h)}
+	v := make([]float64, cs.components)
+	for i := range img.pix {
+		s := data[i*bpp : (i+1)*bpp]
+		if cs.palette != nil {
+			idx := int(s[0])
+			if idx < len(cs.palette) {
+				img.pix[i] = cs.palette[idx]
+			}
+			continue
+		}
+		for j := range v {
+			v[j] = float64(s[j]) / 255
+		}
+		img.pix[i] = deviceColor(v)
+	}
+	return img
+}
+
+// decodeSoftMask decodes an 8-bit grayscale soft mask of the image size.
+func (r *renderer) decodeSoftMask(stream *parser.Stream, w, h int) []uint8 {
+	dict := stream.Dictionary()
+	if int(dict.GetInteger("Width")) != w || int(dict.GetInteger("Height")) != h ||
+		dict.GetInteger("BitsPerComponent") != 8 {
+		return nil
+	}
+	data, err := r.decode(stream)
+	if err != nil || len(data) < w*h {
+		return nil
+	}
+	return data[:w*h]
+}
+
+// decodeStencil decodes a 1-bit image mask (Section 8.9.6.2).
+//
+// Sample value 0 paints with the current fill color unless /Decode is [1 0].
+func (r *renderer) decodeStencil(stream *parser.Stream, w, h int) *sampledImage {
+	data, err := r.decode(stream)
+	if err != nil {
+		return nil
+	}
+	rowBytes := (w + 7) / 8
+	if len(data) < rowBytes*h {
+		return nil
+	}
+
+	paintOn := byte(0)
+	if dec, ok := r.resolve(stream.Dictionary().Get("Decode")).(*parser.Array); ok && dec.Len() == 2 {
+		if v, ok := r.number(dec.Get(0)); ok && v == 1 {
+			paintOn = 1
+		}
+	}
+
+	img := &sampledImage{w: w, h: h, alpha: make([]uint8, w*h), mask: true}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			bit := data[y*rowBytes+x/8] >> (7 - uint(x%8)) & 1
+			if bit == paintOn {
+				img.alpha[y*w+x] = 0xFF
+			}
+		}
+	}
+	return img
+}
+
+// imageColorSpace describes how to interpret image samples.
+type imageColorSpace struct {
+	components int
+	palette    []color.RGBA // Set for /Indexed
+}
+
+// colorSpace interprets an image /ColorSpace entry.
+//
+// Reference: PDF 1.7 Specification, Section 8.6 (Colour Spaces).
+func (r *renderer) colorSpace(obj parser.PdfObject) imageColorSpace {
+	switch cs := obj.(type) {
+	case *parser.Name:
+		switch cs.Value() {
+		case "DeviceGray", "G", "CalGray":
+			return imageColorSpace{components: 1}
+		case "DeviceRGB", "RGB", "CalRGB":
+			return imageColorSpace{components: 3}
+		case "DeviceCMYK", "CMYK":
+			return imageColorSpace{components: 4}
+		}
+	case *parser.Array:
+		if cs.Len() < 2 {
+			return imageColorSpace{}
+		}
+		family, ok := cs.Get(0).(*parser.Name)
+		if !ok {
+			return imageColorSpace{}
+		}
+		switch family.Value() {
+		case "ICCBased":
+			if s, ok := r.resolve(cs.Get(1)).(*parser.Stream); ok {
+				if n := int(s.Dictionary().GetInteger("N")); n == 1 || n == 3 || n == 4 {
+					return imageColorSpace{components: n}
+				}
+			}
+		case "CalGray", "CalRGB":
+			return r.colorSpace(family)
+		case "Indexed", "I":
+			return r.indexedColorSpace(cs)
+		}
+	}
+	return imageColorSpace{}
+}
+
+// indexedColorSpace builds the palette of [/Indexed base hival lookup].
+func (r *renderer) indexedColorSpace(cs *parser.Array) imageColorSpace {
+	if cs.Len() < 4 {
+		return imageColorSpace{}
+	}
+	base := r.colorSpace(r.resolve(cs.Get(1)))
+	if base.components == 0 || base.palette != nil {
+		return imageColorSpace{}
+	}
+	hival, ok := r.number(cs.Get(2))
+	if !ok || hival < 0 || hival > 255 {
+		return imageColorSpace{}
+	}
+
+	var lookup []byte
+	switch l := r.resolve(cs.Get(3)).(type) {
+	case *parser.String:
+		lookup = l.Bytes()
+	case *parser.Stream:
+		data, err := r.decode(l)
+		if err != nil {
+			return imageColorSpace{}
+		}
+		lookup = data
+	}
+
+	n := int(hival) + 1
+	if len(lookup) < n*base.components {
+		return imageColorSpace{}
+	}
+	palette := make([]color.RGBA, n)
+	v := make([]float64, base.components)
+	for i := range palette {
+		for j := range v {
+			v[j] = float64(lookup[i*base.components+j]) / 255
+		}
+		palette[i] = deviceColor(v)
+	}
+	return imageColorSpace{components: 1, palette: palette}
+}
+
+// isFlag reports whether obj is the boolean true.
+func isFlag(obj parser.PdfObject) bool {
+	b, ok := obj.(*parser.Boolean)
+	return ok && b.Value()
+}
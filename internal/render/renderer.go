@@ -0,0 +1,781 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/coregx/gxpdf/internal/encoding"
+	"github.com/coregx/gxpdf/internal/extractor"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// Rendering limits.
+const (
+	// maxFormDepth limits Form XObject nesting (guards against cycles).
+	maxFormDepth = 16
+
+	// maxInheritDepth limits /Parent traversal for inherited page attributes.
+	maxInheritDepth = 32
+
+	// maxPixels limits the size of the output image (about 100 megapixels).
+	maxPixels = 100_000_000
+)
+
+// Default page size (US Letter) used when a page has no valid /MediaBox.
+const (
+	defaultPageWidth  = 612
+	defaultPageHeight = 792
+)
+
+// subpath is a flattened subpath in device space.
+type subpath struct {
+	points []point
+	closed bool
+}
+
+// graphicsState is the part of the PDF graphics state the renderer tracks.
+//
+// Reference: PDF 1.7 Specification, Section 8.4 (Graphics State).
+type graphicsState struct {
+	ctm         matrix
+	fill        color.RGBA
+	stroke      color.RGBA
+	fillAlpha   float64
+	strokeAlpha float64
+	lineWidth   float64
+	lineCap     int
+	clip        *coverage // nil = no clipping
+
+	// Text state (Section 9.3).
+	font       *fontInfo
+	fontSize   float64
+	charSpace  float64
+	wordSpace  float64
+	hScale     float64 // Horizontal scaling as a fraction (Tz / 100)
+	leading    float64
+	rise       float64
+	renderMode int
+}
+
+// renderer paints one page's content into an RGBA image.
+type renderer struct {
+	reader *parser.Reader
+	img    *image.RGBA
+
+	state graphicsState
+	stack []graphicsState
+
+	// Current path in device space.
+	path     []subpath
+	hasPoint bool
+	current  point // Current point in user space
+	start    point // Start of the current subpath in user space
+
+	// Pending clip from W / W*, applied by the next painting operator.
+	clipPending bool
+	clipRule    fillRule
+
+	// Text object state (Section 9.4.2).
+	tm, tlm matrix
+
+	resources *parser.Dictionary
+	fonts     map[*parser.Dictionary]*fontInfo
+	depth     int
+}
+
+// RenderPage rasterizes a page at the given resolution.
+//
+// pageIndex is 0-based. The page is painted on an opaque white background
+// and the output is sized to the page's /MediaBox at dpi dots per inch.
+// /Rotate is not applied.
+//
+// Content the renderer does not support is skipped rather than reported;
+// an error is returned only if the page or its content stream cannot be
+// read.
+func RenderPage(reader *parser.Reader, pageIndex int, dpi float64) (*image.RGBA, error) {
+	if reader == nil {
+		return nil, errors.New("reader cannot be nil")
+	}
+	if dpi <= 0 || math.IsNaN(dpi) || math.IsInf(dpi, 0) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDPI, dpi)
+	}
+
+	page, err := reader.GetPage(pageIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %d: %w", pageIndex, err)
+	}
+
+	r := &renderer{
+		reader: reader,
+		fonts:  make(map[*parser.Dictionary]*fontInfo),
+	}
+
+	box := r.mediaBox(page)
+	scale := dpi / 72
+	w := int(math.Ceil((box[2] - box[0]) * scale))
+	h := int(math.Ceil((box[3] - box[1]) * scale))
+	if w <= 0 || h <= 0 || w*h > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrImageTooLarge, w, h)
+	}
+
+	r.img = image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range r.img.Pix {
+		r.img.Pix[i] = 0xFF
+	}
+
+	// User space to device space: scale, then flip y so the page top is row 0.
+	r.state = graphicsState{
+		ctm:         matrix{scale, 0, 0, -scale, -box[0] * scale, box[3] * scale},
+		fill:        color.RGBA{0, 0, 0, 0xFF},
+		stroke:      color.RGBA{0, 0, 0, 0xFF},
+		fillAlpha:   1,
+		strokeAlpha: 1,
+		lineWidth:   1,
+		hScale:      1,
+	}
+	r.resources, _ = r.inherited(page, "Resources").(*parser.Dictionary)
+
+	content, err := r.pageContent(page)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.run(content); err != nil {
+		return nil, err
+	}
+
+	return r.img, nil
+}
+
+// mediaBox returns the page's (possibly inherited) media box.
+func (r *renderer) mediaBox(page *parser.Dictionary) [4]float64 {
+	box := [4]float64{0, 0, defaultPageWidth, defaultPageHeight}
+	arr, ok := r.inherited(page, "MediaBox").(*parser.Array)
+	if !ok || arr.Len() < 4 {
+		return box
+	}
+	var v [4]float64
+	for i := 0; i < 4; i++ {
+		f, ok := r.number(arr.Get(i))
+		if !ok {
+			return box
+		}
+		v[i] = f
+	}
+	x0, x1 := math.Min(v[0], v[2]), math.Max(v[0], v[2])
+	y0, y1 := math.Min(v[1], v[3]), math.Max(v[1], v[3])
+	if x1-x0 <= 0 || y1-y0 <= 0 {
+		return box
+	}
+	return [4]float64{x0, y0, x1, y1}
+}
+
+// inherited looks up an inheritable page attribute, walking /Parent.
+//
+// Reference: PDF 1.7 Specification, Section 7.7.3.4 (Inheritance of Page Attributes).
+func (r *renderer) inherited(page *parser.Dictionary, key string) parser.PdfObject {
+	node := page
+	for i := 0; node != nil && i < maxInheritDepth; i++ {
+		if v := r.resolve(node.Get(key)); v != nil {
+			return v
+		}
+		node, _ = r.resolve(node.Get("Parent")).(*parser.Dictionary)
+	}
+	return nil
+}
+
+// pageContent returns the decoded, concatenated page content streams.
+func (r *renderer) pageContent(page *parser.Dictionary) ([]byte, error) {
+	switch obj := r.resolve(page.Get("Contents")).(type) {
+	case nil:
+		return nil, nil
+	case *parser.Stream:
+		return r.decode(obj)
+	case *parser.Array:
+		var all []byte
+		for i := 0; i < obj.Len(); i++ {
+			stream, ok := r.resolve(obj.Get(i)).(*parser.Stream)
+			if !ok {
+				continue
+			}
+			data, err := r.decode(stream)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, data...)
+			all = append(all, '\n')
+		}
+		return all, nil
+	default:
+		return nil, fmt.Errorf("unexpected /Contents type: %T", obj)
+	}
+}
+
+// run parses and executes a content stream.
+func (r *renderer) run(content []byte) error {
+	if len(content) == 0 {
+		return nil
+	}
+	ops, err := extractor.NewContentParser(content).ParseOperators()
+	if err != nil && len(ops) == 0 {
+		return fmt.Errorf("failed to parse content stream: %w", err)
+	}
+	for _, op := range ops {
+		r.execute(op)
+	}
+	return nil
+}
+
+// execute runs one content stream operator.
+//
+//nolint:cyclop,funlen // Operator dispatch requires many cases
+func (r *renderer) execute(op *extractor.Operator) {
+	args := op.Operands
+	switch op.Name {
+	// Graphics state (Section 8.4.4).
+	case "q":
+		r.stack = append(r.stack, r.state)
+	case "Q":
+		if n := len(r.stack); n > 0 {
+			r.state = r.stack[n-1]
+			r.stack = r.stack[:n-1]
+		}
+	case "cm":
+		if m, ok := r.matrixArgs(args); ok {
+			r.state.ctm = m.mul(r.state.ctm)
+		}
+	case "w":
+		if v, ok := r.arg(args, 0); ok {
+			r.state.lineWidth = v
+		}
+	case "J":
+		if v, ok := r.arg(args, 0); ok {
+			r.state.lineCap = int(v)
+		}
+	case "gs":
+		r.applyExtGState(args)
+
+	// Path construction (Section 8.5.2).
+	case "m":
+		if x, y, ok := r.point(args); ok {
+			r.moveTo(x, y)
+		}
+	case "l":
+		if x, y, ok := r.point(args); ok {
+			r.lineTo(x, y)
+		}
+	case "c":
+		if v, ok := r.numbers(args, 6); ok {
+			r.curveTo(v[0], v[1], v[2], v[3], v[4], v[5])
+		}
+	case "v":
+		if v, ok := r.numbers(args, 4); ok {
+			r.curveTo(r.current.x, r.current.y, v[0], v[1], v[2], v[3])
+		}
+	case "y":
+		if v, ok := r.numbers(args, 4); ok {
+			r.curveTo(v[0], v[1], v[2], v[3], v[2], v[3])
+		}
+	case "h":
+		r.closePath()
+	case "re":
+		if v, ok := r.numbers(args, 4); ok {
+			r.moveTo(v[0], v[1])
+			r.lineTo(v[0]+v[2], v[1])
+			r.lineTo(v[0]+v[2], v[1]+v[3])
+			r.lineTo(v[0], v[1]+v[3])
+			r.closePath()
+		}
+
+	// Path painting (Section 8.5.3).
+	case "f", "F":
+		r.paint(true, false, nonZero, false)
+	case "f*":
+		r.paint(true, false, evenOdd, false)
+	case "S":
+		r.paint(false, true, nonZero, false)
+	case "s":
+		r.paint(false, true, nonZero, true)
+	case "B":
+		r.paint(true, true, nonZero, false)
+	case "B*":
+		r.paint(true, true, evenOdd, false)
+	case "b":
+		r.paint(true, true, nonZero, true)
+	case "b*":
+		r.paint(true, true, evenOdd, true)
+	case "n":
+		r.paint(false, false, nonZero, false)
+
+	// Clipping (Section 8.5.4).
+	case "W":
+		r.clipPending, r.clipRule = true, nonZero
+	case "W*":
+		r.clipPending, r.clipRule = true, evenOdd
+
+	// Color (Section 8.6.8).
+	case "g":
+		r.state.fill = r.colorFromArgs(args)
+	case "G":
+		r.state.stroke = r.colorFromArgs(args)
+	case "rg":
+		r.state.fill = r.colorFromArgs(args)
+	case "RG":
+		r.state.stroke = r.colorFromArgs(args)
+	case "k":
+		r.state.fill = r.colorFromArgs(args)
+	case "K":
+		r.state.stroke = r.colorFromArgs(args)
+	case "sc", "scn":
+		if len(args) > 0 && r.allNumbers(args) {
+			r.state.fill = r.colorFromArgs(args)
+		}
+	case "SC", "SCN":
+		if len(args) > 0 && r.allNumbers(args) {
+			r.state.stroke = r.colorFromArgs(args)
+		}
+	case "cs":
+		r.state.fill = color.RGBA{0, 0, 0, 0xFF}
+	case "CS":
+		r.state.stroke = color.RGBA{0, 0, 0, 0xFF}
+
+	// XObjects (Section 8.8).
+	case "Do":
+		if name, ok := nameArg(args, 0); ok {
+			r.drawXObject(name)
+		}
+
+	// Text (Section 9).
+	default:
+		r.executeText(op)
+	}
+}
+
+// moveTo starts a new subpath.
+func (r *renderer) moveTo(x, y float64) {
+	r.path = append(r.path, subpath{points: []point{r.state.ctm.apply(x, y)}})
+	r.current = point{x, y}
+	r.start = r.current
+	r.hasPoint = true
+}
+
+// lineTo appends a straight segment.
+func (r *renderer) lineTo(x, y float64) {
+	if !r.hasPoint || len(r.path) == 0 {
+		r.moveTo(x, y)
+		return
+	}
+	sp := &r.path[len(r.path)-1]
+	if sp.closed {
+		r.moveTo(r.start.x, r.start.y)
+		sp = &r.path[len(r.path)-1]
+	}
+	sp.points = append(sp.points, r.state.ctm.apply(x, y))
+	r.current = point{x, y}
+}
+
+// curveTo appends a cubic Bézier segment, flattened in device space.
+func (r *renderer) curveTo(x1, y1, x2, y2, x3, y3 float64) {
+	if !r.hasPoint || len(r.path) == 0 {
+		r.moveTo(x1, y1)
+	}
+	sp := &r.path[len(r.path)-1]
+	p0 := sp.points[len(sp.points)-1]
+	ctm := r.state.ctm
+	sp.points = flattenCubic(sp.points, p0, ctm.apply(x1, y1), ctm.apply(x2, y2), ctm.apply(x3, y3))
+	r.current = point{x3, y3}
+}
+
+// closePath closes the current subpath.
+func (r *renderer) closePath() {
+	if len(r.path) == 0 {
+		return
+	}
+	r.path[len(r.path)-1].closed = true
+	r.current = r.start
+}
+
+// paint fills and/or strokes the current path, applies any pending clip,
+// and clears the path.
+func (r *renderer) paint(fill, stroke bool, rule fillRule, closeFirst bool) {
+	if closeFirst {
+		r.closePath()
+	}
+
+	w, h := r.img.Bounds().Dx(), r.img.Bounds().Dy()
+	if fill {
+		polys := make([][]point, 0, len(r.path))
+		for _, sp := range r.path {
+			polys = append(polys, sp.points)
+		}
+		r.composite(rasterize(polys, w, h, rule), r.state.fill, r.state.fillAlpha)
+	}
+	if stroke {
+		width := r.state.lineWidth * r.state.ctm.scale()
+		polys := strokePolygons(r.path, width, r.state.lineCap)
+		r.composite(rasterize(polys, w, h, nonZero), r.state.stroke, r.state.strokeAlpha)
+	}
+
+	if r.clipPending {
+		polys := make([][]point, 0, len(r.path))
+		for _, sp := range r.path {
+			polys = append(polys, sp.points)
+		}
+		cov := rasterize(polys, w, h, r.clipRule)
+		if cov == nil {
+			cov = &coverage{}
+		}
+		r.state.clip = intersect(r.state.clip, cov)
+		r.clipPending = false
+	}
+
+	r.path = nil
+	r.hasPoint = false
+}
+
+// composite blends a color through a coverage mask and the current clip.
+func (r *renderer) composite(cov *coverage, c color.RGBA, alpha float64) {
+	if cov == nil || alpha <= 0 {
+		return
+	}
+	clip := r.state.clip
+	stride := r.img.Stride
+	for py := cov.y; py < cov.y+cov.h; py++ {
+		for px := cov.x; px < cov.x+cov.w; px++ {
+			a := float64(minf(cov.a[(py-cov.y)*cov.w+(px-cov.x)], 1))
+			if clip != nil {
+				a *= float64(clip.at(px, py))
+			}
+			if a <= 0 {
+				continue
+			}
+			blend(r.img.Pix[py*stride+px*4:], c, a*alpha)
+		}
+	}
+}
+
+// blend mixes color c into an opaque RGBA pixel with coverage a.
+func blend(pix []byte, c color.RGBA, a float64) {
+	if a >= 1 {
+		pix[0], pix[1], pix[2] = c.R, c.G, c.B
+		return
+	}
+	pix[0] = byte(float64(c.R)*a + float64(pix[0])*(1-a) + 0.5)
+	pix[1] = byte(float64(c.G)*a + float64(pix[1])*(1-a) + 0.5)
+	pix[2] = byte(float64(c.B)*a + float64(pix[2])*(1-a) + 0.5)
+}
+
+// applyExtGState applies constant alpha from a named ExtGState.
+//
+// Reference: PDF 1.7 Specification, Section 8.4.5 (Graphics State Parameter Dictionaries).
+func (r *renderer) applyExtGState(args []parser.PdfObject) {
+	name, ok := nameArg(args, 0)
+	if !ok {
+		return
+	}
+	gs, ok := r.resource("ExtGState", name).(*parser.Dictionary)
+	if !ok {
+		return
+	}
+	if v, ok := r.number(gs.Get("ca")); ok {
+		r.state.fillAlpha = math.Max(0, math.Min(1, v))
+	}
+	if v, ok := r.number(gs.Get("CA")); ok {
+		r.state.strokeAlpha = math.Max(0, math.Min(1, v))
+	}
+	if v, ok := r.number(gs.Get("LW")); ok {
+		r.state.lineWidth = v
+	}
+}
+
+// drawXObject paints a named image or form XObject.
+func (r *renderer) drawXObject(name string) {
+	stream, ok := r.resource("XObject", name).(*parser.Stream)
+	if !ok {
+		return
+	}
+	subtype := stream.Dictionary().GetName("Subtype")
+	if subtype == nil {
+		return
+	}
+	switch subtype.Value() {
+	case "Image":
+		r.drawImage(stream)
+	case "Form":
+		r.drawForm(stream)
+	}
+}
+
+// drawForm runs a Form XObject's content with its own matrix and resources.
+//
+// Reference: PDF 1.7 Specification, Section 8.10 (Form XObjects).
+func (r *renderer) drawForm(stream *parser.Stream) {
+	if r.depth >= maxFormDepth {
+		return
+	}
+	content, err := r.decode(stream)
+	if err != nil {
+		return
+	}
+
+	dict := stream.Dictionary()
+	savedState, savedStack := r.state, r.stack
+	savedRes, savedPath := r.resources, r.path
+	r.stack = nil
+	r.path = nil
+	if m, ok := r.resolve(dict.Get("Matrix")).(*parser.Array); ok {
+		if fm, ok := r.matrixArray(m); ok {
+			r.state.ctm = fm.mul(r.state.ctm)
+		}
+	}
+	if res, ok := r.resolve(dict.Get("Resources")).(*parser.Dictionary); ok {
+		r.resources = res
+	}
+
+	r.depth++
+	_ = r.run(content)
+	r.depth--
+
+	r.state, r.stack = savedState, savedStack
+	r.resources, r.path = savedRes, savedPath
+}
+
+// resource looks up a named resource in a resource category.
+func (r *renderer) resource(category, name string) parser.PdfObject {
+	if r.resources == nil {
+		return nil
+	}
+	dict, ok := r.resolve(r.resources.Get(category)).(*parser.Dictionary)
+	if !ok {
+		return nil
+	}
+	return r.resolve(dict.Get(name))
+}
+
+// decode returns the decoded data of a stream.
+//
+// FlateDecode, LZWDecode and ASCII85Decode are decoded, chained in
+// /Filter order; unfiltered streams are returned as-is.
+func (r *renderer) decode(stream *parser.Stream) ([]byte, error) {
+	filters := r.filterNames(stream.Dictionary())
+	data := stream.Content()
+	for i, f := range filters {
+		switch f {
+		case "FlateDecode", "Fl":
+			decoded, err := encoding.NewFlateDecoder().Decode(data)
+			if err != nil {
+				return nil, fmt.Errorf("FlateDecode failed: %w", err)
+			}
+			decoded, err = parser.ReversePredictor(decoded, r.decodeParms(stream.Dictionary(), i))
+			if err != nil {
+				return nil, fmt.Errorf("FlateDecode failed: %w", err)
+			}
+			data = decoded
+		case "LZWDecode", "LZW":
+			parms := r.decodeParms(stream.Dictionary(), i)
+			earlyChange := 1
+			if d, ok := parms.(*parser.Dictionary); ok {
+				if n, ok := d.Get("EarlyChange").(*parser.Integer); ok {
+					earlyChange = int(n.Value())
+				}
+			}
+			decoded, err := encoding.NewLZWDecoderWithParams(earlyChange).Decode(data)
+			if err != nil {
+				return nil, fmt.Errorf("LZWDecode failed: %w", err)
+			}
+			decoded, err = parser.ReversePredictor(decoded, parms)
+			if err != nil {
+				return nil, fmt.Errorf("LZWDecode failed: %w", err)
+			}
+			data = decoded
+		case "ASCII85Decode", "A85":
+			decoded, err := encoding.NewASCII85Decoder().Decode(data)
+			if err != nil {
+				return nil, fmt.Errorf("ASCII85Decode failed: %w", err)
+			}
+			data = decoded
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedFilter, f)
+		}
+	}
+	return data, nil
+}
+
+// decodeParms returns the /DecodeParms entry of the i-th filter of a
+// stream, resolved, or nil.
+func (r *renderer) decodeParms(dict *parser.Dictionary, i int) parser.PdfObject {
+	switch p := r.resolve(dict.Get("DecodeParms")).(type) {
+	case *parser.Array:
+		if i < p.Len() {
+			return r.resolve(p.Get(i))
+		}
+	case *parser.Dictionary:
+		if i == 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+// filterNames returns the stream's /Filter entries in order.
+func (r *renderer) filterNames(dict *parser.Dictionary) []string {
+	switch f := r.resolve(dict.Get("Filter")).(type) {
+	case *parser.Name:
+		return []string{f.Value()}
+	case *parser.Array:
+		names := make([]string, 0, f.Len())
+		for i := 0; i < f.Len(); i++ {
+			if n, ok := r.resolve(f.Get(i)).(*parser.Name); ok {
+				names = append(names, n.Value())
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// resolve follows a single indirect reference.
+func (r *renderer) resolve(obj parser.PdfObject) parser.PdfObject {
+	if ref, ok := obj.(*parser.IndirectReference); ok {
+		resolved, err := r.reader.GetObject(ref.Number)
+		if err != nil {
+			return nil
+		}
+		return resolved
+	}
+	return obj
+}
+
+// number converts an integer or real object to float64.
+func (r *renderer) number(obj parser.PdfObject) (float64, bool) {
+	switch v := r.resolve(obj).(type) {
+	case *parser.Integer:
+		return float64(v.Value()), true
+	case *parser.Real:
+		return v.Value(), true
+	}
+	return 0, false
+}
+
+// arg returns numeric operand i.
+func (r *renderer) arg(args []parser.PdfObject, i int) (float64, bool) {
+	if i >= len(args) {
+		return 0, false
+	}
+	return r.number(args[i])
+}
+
+// numbers returns the last n operands as numbers.
+func (r *renderer) numbers(args []parser.PdfObject, n int) ([]float64, bool) {
+	if len(args) < n {
+		return nil, false
+	}
+	args = args[len(args)-n:]
+	v := make([]float64, n)
+	for i, a := range args {
+		f, ok := r.number(a)
+		if !ok {
+			return nil, false
+		}
+		v[i] = f
+	}
+	return v, true
+}
+
+// allNumbers reports whether every operand is numeric.
+func (r *renderer) allNumbers(args []parser.PdfObject) bool {
+	for _, a := range args {
+		if _, ok := r.number(a); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// point returns the last two operands as a point.
+func (r *renderer) point(args []parser.PdfObject) (x, y float64, ok bool) {
+	v, ok := r.numbers(args, 2)
+	if !ok {
+		return 0, 0, false
+	}
+	return v[0], v[1], true
+}
+
+// matrixArgs returns six operands as a matrix.
+func (r *renderer) matrixArgs(args []parser.PdfObject) (matrix, bool) {
+	v, ok := r.numbers(args, 6)
+	if !ok {
+		return matrix{}, false
+	}
+	return matrix{v[0], v[1], v[2], v[3], v[4], v[5]}, true
+}
+
+// matrixArray converts a six-element array to a matrix.
+func (r *renderer) matrixArray(arr *parser.Array) (matrix, bool) {
+	if arr.Len() != 6 {
+		return matrix{}, false
+	}
+	return r.matrixArgs(arr.Elements())
+}
+
+// colorFromArgs converts 1 (gray), 3 (RGB) or 4 (CMYK) operands to RGB.
+func (r *renderer) colorFromArgs(args []parser.PdfObject) color.RGBA {
+	v := make([]float64, 0, 4)
+	for _, a := range args {
+		if f, ok := r.number(a); ok {
+			v = append(v, f)
+		}
+	}
+	return deviceColor(v)
+}
+
+// deviceColor converts gray, RGB or CMYK components in [0, 1] to RGBA.
+func deviceColor(v []float64) color.RGBA {
+	to8 := func(f float64) uint8 {
+		return uint8(math.Round(math.Max(0, math.Min(1, f)) * 255))
+	}
+	switch len(v) {
+	case 1:
+		g := to8(v[0])
+		return color.RGBA{g, g, g, 0xFF}
+	case 3:
+		return color.RGBA{to8(v[0]), to8(v[1]), to8(v[2]), 0xFF}
+	case 4:
+		k := v[3]
+		return color.RGBA{
+			to8((1 - v[0]) * (1 - k)),
+			to8((1 - v[1]) * (1 - k)),
+			to8((1 - v[2]) * (1 - k)),
+			0xFF,
+		}
+	}
+	return color.RGBA{0, 0, 0, 0xFF}
+}
+
+// nameArg returns operand i as a name value.
+func nameArg(args []parser.PdfObject, i int) (string, bool) {
+	if i >= len(args) {
+		return "", false
+	}
+	n, ok := args[i].(*parser.Name)
+	if !ok {
+		return "", false
+	}
+	return n.Value(), true
+}
+
+// Errors.
+var (
+	// ErrInvalidDPI is returned for non-positive or non-finite resolutions.
+	ErrInvalidDPI = errors.New("dpi must be a positive number")
+
+	// ErrImageTooLarge is returned when the output image would be empty or
+	// exceed the pixel limit.
+	ErrImageTooLarge = errors.New("rendered image size out of range")
+
+	// ErrUnsupportedFilter is returned for stream filters the renderer
+	// cannot decode.
+	ErrUnsupportedFilter = errors.New("unsupported stream filter")
+)
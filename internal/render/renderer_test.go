@@ -0,0 +1,183 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf/creator"
+	"github.com/coregx/gxpdf/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderCreated writes a Letter page built by draw and renders it.
+func renderCreated(t *testing.T, dpi float64, draw func(p *creator.Page)) *image.RGBA {
+	t.Helper()
+
+	c := creator.New()
+	c.SetPageSize(creator.Letter)
+	page, err := c.NewPage()
+	require.NoError(t, err)
+	draw(page)
+
+	path := filepath.Join(t.TempDir(), "render.pdf")
+	require.NoError(t, c.WriteToFile(path))
+
+	reader, err := parser.OpenPDF(path)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	img, err := RenderPage(reader, 0, dpi)
+	require.NoError(t, err)
+	return img
+}
+
+// pixelAt returns the pixel at PDF user-space point (x, y) for a Letter page.
+func pixelAt(img *image.RGBA, dpi, x, y float64) color.RGBA {
+	s := dpi / 72
+	return img.RGBAAt(int(x*s), int((792-y)*s))
+}
+
+func TestRenderPage_Size(t *testing.T) {
+	img := renderCreated(t, 144, func(*creator.Page) {})
+
+	assert.Equal(t, 1224, img.Bounds().Dx())
+	assert.Equal(t, 1584, img.Bounds().Dy())
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, img.RGBAAt(10, 10), "background is white")
+}
+
+func TestRenderPage_FilledRect(t *testing.T) {
+	img := renderCreated(t, 72, func(p *creator.Page) {
+		require.NoError(t, p.DrawRectFilled(100, 100, 200, 100, creator.Red))
+	})
+
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, pixelAt(img, 72, 150, 150), "inside rect")
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, pixelAt(img, 72, 50, 50), "outside rect")
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, pixelAt(img, 72, 150, 250), "above rect")
+}
+
+func TestRenderPage_AntiAliasedEdge(t *testing.T) {
+	// Rect edge at x = 100.5 pt covers half of pixel column 100.
+	img := renderCreated(t, 72, func(p *creator.Page) {
+		require.NoError(t, p.DrawRectFilled(100.5, 100, 50, 50, creator.Black))
+	})
+
+	edge := img.RGBAAt(100, 792-120)
+	assert.InDelta(t, 128, int(edge.R), 2, "half-covered pixel is mid gray")
+}
+
+func TestRenderPage_Line(t *testing.T) {
+	img := renderCreated(t, 72, func(p *creator.Page) {
+		require.NoError(t, p.DrawLine(100, 400, 300, 400, &creator.LineOptions{
+			Color: creator.Blue,
+			Width: 4,
+		}))
+	})
+
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, pixelAt(img, 72, 200, 400), "on line")
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, pixelAt(img, 72, 200, 410), "beside line")
+}
+
+func TestRenderPage_Image(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.RGBA{0, 255, 0, 255}) // Top-left
+	src.Set(1, 0, color.RGBA{0, 0, 255, 255})
+	src.Set(0, 1, color.RGBA{0, 0, 255, 255})
+	src.Set(1, 1, color.RGBA{0, 255, 0, 255})
+
+	img := renderCreated(t, 72, func(p *creator.Page) {
+		pdfImg, err := creator.ImageFromGo(src, "png")
+		require.NoError(t, err)
+		require.NoError(t, p.DrawImage(pdfImg, 100, 500, 100, 100))
+	})
+
+	assert.Equal(t, color.RGBA{0, 255, 0, 255}, pixelAt(img, 72, 125, 575), "top-left quadrant")
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, pixelAt(img, 72, 175, 575), "top-right quadrant")
+	assert.Equal(t, color.RGBA{0, 255, 0, 255}, pixelAt(img, 72, 175, 525), "bottom-right quadrant")
+}
+
+func TestRenderPage_StandardFontText(t *testing.T) {
+	img := renderCreated(t, 72, func(p *creator.Page) {
+		require.NoError(t, p.AddText("HHHH", 100, 300, creator.Helvetica, 40))
+	})
+
+	// Each H is 722/1000 em = 28.88 pt wide. Its stems are drawn near the
+	// baseline, with the counter between them left empty (a glyph box
+	// would fill it).
+	dark := 0
+	for x := 100; x < 200; x++ {
+		if img.RGBAAt(x, 792-305).R < 128 {
+			dark++
+		}
+	}
+	assert.Greater(t, dark, 20, "text paints pixels")
+	assert.Less(t, dark, 70, "H counters stay empty")
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, pixelAt(img, 72, 114.4, 305), "inside the first H")
+	assert.Less(t, pixelAt(img, 72, 114.4, 316).R, uint8(128), "crossbar of the first H")
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, pixelAt(img, 72, 150, 360), "above text")
+}
+
+func TestRenderPage_StandardFontText_Advance(t *testing.T) {
+	// Substitute glyphs are stretched to the Standard 14 widths: "iiii"
+	// in Courier (600/1000 em) spans 4 * 24 pt.
+	img := renderCreated(t, 72, func(p *creator.Page) {
+		require.NoError(t, p.AddText("iiiiiiii", 100, 300, creator.Courier, 40))
+	})
+
+	last := 0
+	for x := 0; x < img.Bounds().Dx(); x++ {
+		for y := 792 - 330; y < 792-300; y++ {
+			if img.RGBAAt(x, y).R < 128 {
+				last = x
+			}
+		}
+	}
+	assert.InDelta(t, 100+8*24, last, 24, "last glyph ends within the eighth advance")
+}
+
+func TestRenderPage_InvalidDPI(t *testing.T) {
+	reader := parser.NewReader("unused.pdf")
+	for _, dpi := range []float64{0, -72} {
+		_, err := RenderPage(reader, 0, dpi)
+		assert.ErrorIs(t, err, ErrInvalidDPI)
+	}
+}
+
+func TestRasterize_EvenOdd(t *testing.T) {
+	outer := []point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	inner := []point{{3, 3}, {7, 3}, {7, 7}, {3, 7}}
+
+	nz := rasterize([][]point{outer, inner}, 10, 10, nonZero)
+	eo := rasterize([][]point{outer, inner}, 10, 10, evenOdd)
+
+	assert.InDelta(t, 1, nz.at(5, 5), 1e-6, "nonzero fills the hole (same winding)")
+	assert.InDelta(t, 0, eo.at(5, 5), 1e-6, "even-odd leaves the hole")
+	assert.InDelta(t, 1, eo.at(1, 1), 1e-6)
+}
+
+func TestIntersect(t *testing.T) {
+	a := rasterize([][]point{{{0, 0}, {6, 0}, {6, 6}, {0, 6}}}, 10, 10, nonZero)
+	b := rasterize([][]point{{{4, 4}, {10, 4}, {10, 10}, {4, 10}}}, 10, 10, nonZero)
+
+	c := intersect(a, b)
+	assert.InDelta(t, 1, c.at(5, 5), 1e-6)
+	assert.InDelta(t, 0, c.at(1, 1), 1e-6)
+	assert.InDelta(t, 0, c.at(8, 8), 1e-6)
+	assert.Same(t, b, intersect(nil, b), "nil clip means no clipping")
+}
+
+func TestMatrix_Invert(t *testing.T) {
+	m := matrix{2, 0, 0, -3, 10, 20}
+	inv, ok := m.invert()
+	require.True(t, ok)
+
+	p := m.apply(4, 5)
+	q := inv.apply(p.x, p.y)
+	assert.InDelta(t, 4, q.x, 1e-9)
+	assert.InDelta(t, 5, q.y, 1e-9)
+
+	_, ok = matrix{0, 0, 0, 0, 1, 1}.invert()
+	assert.False(t, ok)
+}
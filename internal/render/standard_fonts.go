@@ -0,0 +1,95 @@
+package render
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/coregx/gxpdf/internal/extractor"
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// substituteFace is a bundled font that draws the glyphs of a non-embedded
+// Standard 14 font.
+type substituteFace struct {
+	data []byte
+	once sync.Once
+	font *fonts.TTFFont // nil if data does not parse
+}
+
+// Substitutes for the Standard 14 fonts, from the Go font family: Go for
+// Helvetica and Times (sans serif for both), Go Mono for Courier. Glyphs
+// are stretched to the Standard 14 advance widths, so text keeps its
+// layout. Symbol and ZapfDingbats have no substitute.
+var (
+	substituteRegular        = &substituteFace{data: goregular.TTF}
+	substituteBold           = &substituteFace{data: gobold.TTF}
+	substituteItalic         = &substituteFace{data: goitalic.TTF}
+	substituteBoldItalic     = &substituteFace{data: gobolditalic.TTF}
+	substituteMono           = &substituteFace{data: gomono.TTF}
+	substituteMonoBold       = &substituteFace{data: gomonobold.TTF}
+	substituteMonoItalic     = &substituteFace{data: gomonoitalic.TTF}
+	substituteMonoBoldItalic = &substituteFace{data: gomonobolditalic.TTF}
+)
+
+// substituteFont returns the bundled font for a Standard 14 font name, or
+// nil if there is none.
+func substituteFont(name string) *fonts.TTFFont {
+	var face *substituteFace
+	bold := strings.Contains(name, "Bold")
+	italic := strings.Contains(name, "Italic") || strings.Contains(name, "Oblique")
+	switch {
+	case strings.HasPrefix(name, "Courier"):
+		face = pickFace(bold, italic, substituteMono, substituteMonoBold, substituteMonoItalic, substituteMonoBoldItalic)
+	case strings.HasPrefix(name, "Helvetica"), strings.HasPrefix(name, "Times"):
+		face = pickFace(bold, italic, substituteRegular, substituteBold, substituteItalic, substituteBoldItalic)
+	default:
+		return nil
+	}
+
+	face.once.Do(func() {
+		if ttf, err := fonts.ParseTTF(face.data); err == nil && ttf.UnitsPerEm > 0 {
+			face.font = ttf
+		}
+	})
+	return face.font
+}
+
+// pickFace selects one of four styles.
+func pickFace(bold, italic bool, regular, boldFace, italicFace, boldItalic *substituteFace) *substituteFace {
+	switch {
+	case bold && italic:
+		return boldItalic
+	case bold:
+		return boldFace
+	case italic:
+		return italicFace
+	default:
+		return regular
+	}
+}
+
+// codeRunes maps the 1-byte codes of a simple font to Unicode through its
+// base encoding, for looking glyphs up in a substitute font.
+func codeRunes(encoding string) [256]rune {
+	if encoding == "" {
+		encoding = "WinAnsiEncoding"
+	}
+	dec := extractor.NewFontDecoder(nil, encoding, false)
+
+	var runes [256]rune
+	for code := range runes {
+		if s := []rune(dec.DecodeString([]byte{byte(code)})); len(s) == 1 {
+			runes[code] = s[0]
+		}
+	}
+	return runes
+}
@@ -0,0 +1,133 @@
+package render
+
+import "math"
+
+// Line cap styles (J operator).
+//
+// Reference: PDF 1.7 Specification, Section 8.4.3.3 (Line Cap Style).
+const (
+	capButt   = 0
+	capRound  = 1
+	capSquare = 2
+)
+
+// minStrokeWidth is the device width of hairlines (line width 0) and the
+// minimum width of any stroke, in pixels.
+const minStrokeWidth = 1.0
+
+// joinSegments is the number of segments of a round join or cap polygon.
+const joinSegments = 12
+
+// strokePolygons converts flattened subpaths into polygons covering the
+// stroke outline.
+//
+// Every segment becomes a counter-clockwise quad; joins are round and
+// caps follow lineCap. All polygons share one orientation so they can be
+// filled together with the nonzero rule.
+func strokePolygons(subpaths []subpath, width float64, lineCap int) [][]point {
+	width = math.Max(width, minStrokeWidth)
+	half := width / 2
+
+	var polys [][]point
+	for _, sp := range subpaths {
+		pts := dedupe(sp.points)
+		if len(pts) == 1 {
+			// Zero-length subpath: only round and square caps paint a dot.
+			switch lineCap {
+			case capRound:
+				polys = append(polys, disc(pts[0], half))
+			case capSquare:
+				p := pts[0]
+				polys = append(polys, ccw([]point{
+					{p.x - half, p.y - half}, {p.x + half, p.y - half},
+					{p.x + half, p.y + half}, {p.x - half, p.y + half},
+				}))
+			}
+			continue
+		}
+
+		closed := sp.closed && len(pts) > 2
+		n := len(pts)
+		segs := n - 1
+		if closed {
+			segs = n
+		}
+
+		for i := 0; i < segs; i++ {
+			a, b := pts[i], pts[(i+1)%n]
+			dx, dy := b.x-a.x, b.y-a.y
+			l := math.Hypot(dx, dy)
+			ux, uy := dx/l, dy/l
+			nx, ny := -uy*half, ux*half
+
+			// Square caps extend the open ends by half the width.
+			if !closed && lineCap == capSquare {
+				if i == 0 {
+					a = point{a.x - ux*half, a.y - uy*half}
+				}
+				if i == segs-1 {
+					b = point{b.x + ux*half, b.y + uy*half}
+				}
+			}
+
+			polys = append(polys, ccw([]point{
+				{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny},
+				{b.x - nx, b.y - ny}, {a.x - nx, a.y - ny},
+			}))
+		}
+
+		// Round joins fill the wedge gaps between segments.
+		if width > 1.5 {
+			for i := 1; i < n-1; i++ {
+				polys = append(polys, disc(pts[i], half))
+			}
+			if closed {
+				polys = append(polys, disc(pts[0], half), disc(pts[n-1], half))
+			}
+		}
+
+		if !closed && lineCap == capRound {
+			polys = append(polys, disc(pts[0], half), disc(pts[n-1], half))
+		}
+	}
+
+	return polys
+}
+
+// dedupe removes consecutive duplicate points.
+func dedupe(pts []point) []point {
+	out := make([]point, 0, len(pts))
+	for _, p := range pts {
+		if len(out) > 0 && dist(out[len(out)-1], p) < 1e-9 {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// disc returns a counter-clockwise polygon approximating a circle.
+func disc(c point, r float64) []point {
+	pts := make([]point, joinSegments)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / joinSegments
+		pts[i] = point{c.x + r*math.Cos(a), c.y + r*math.Sin(a)}
+	}
+	return ccw(pts)
+}
+
+// ccw returns the polygon with a positive signed area, reversing it if
+// necessary.
+func ccw(pts []point) []point {
+	area := 0.0
+	for i := range pts {
+		p, q := pts[i], pts[(i+1)%len(pts)]
+		area += p.x*q.y - q.x*p.y
+	}
+	if area < 0 {
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+	return pts
+}
@@ -0,0 +1,564 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/coregx/gxpdf/internal/extractor"
+	"github.com/coregx/gxpdf/internal/fonts"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// Text rendering modes that fill or stroke glyphs (Tr operator).
+//
+// Reference: PDF 1.7 Specification, Section 9.3.6 (Text Rendering Mode).
+const (
+	textFill       = 0
+	textStroke     = 1
+	textFillStroke = 2
+)
+
+// Fallback glyph metrics in 1/1000 em for fonts without usable metrics.
+const (
+	fallbackWidth     = 500
+	fallbackXHeight   = 500
+	fallbackCapHeight = 700
+)
+
+// fontInfo is the rendering view of a PDF font dictionary.
+type fontInfo struct {
+	// twoByte selects 2-byte character codes (Identity-H/V CID fonts).
+	twoByte bool
+
+	// ttf holds the embedded TrueType program, nil if none.
+	ttf *fonts.TTFFont
+
+	// widths maps character codes (CIDs for Type0) to widths in 1/1000 em.
+	widths       map[int]float64
+	defaultWidth float64
+
+	// metrics are the Standard 14 metrics for non-embedded fonts.
+	metrics *fonts.FontMetrics
+
+	// substitute draws the glyphs of a non-embedded Standard 14 font
+	// (see substituteFont), looked up by the Unicode value of each code.
+	substitute *fonts.TTFFont
+	runes      [256]rune
+
+	// outlines caches glyph contours in em units (y up).
+	outlines map[uint16][][]point
+}
+
+// executeText runs text object, text state and text showing operators.
+//
+//nolint:cyclop // Operator dispatch requires many cases
+func (r *renderer) executeText(op *extractor.Operator) {
+	args := op.Operands
+	st := &r.state
+	switch op.Name {
+	case "BT":
+		r.tm, r.tlm = identity, identity
+	case "ET":
+		// Text clipping modes (4-7) are not supported.
+	case "Tf":
+		if name, ok := nameArg(args, 0); ok {
+			st.font = r.loadFont(name)
+		}
+		if v, ok := r.arg(args, 1); ok {
+			st.fontSize = v
+		}
+	case "Tc":
+		if v, ok := r.arg(args, 0); ok {
+			st.charSpace = v
+		}
+	case "Tw":
+		if v, ok := r.arg(args, 0); ok {
+			st.wordSpace = v
+		}
+	case "Tz":
+		if v, ok := r.arg(args, 0); ok {
+			st.hScale = v / 100
+		}
+	case "TL":
+		if v, ok := r.arg(args, 0); ok {
+			st.leading = v
+		}
+	case "Ts":
+		if v, ok := r.arg(args, 0); ok {
+			st.rise = v
+		}
+	case "Tr":
+		if v, ok := r.arg(args, 0); ok {
+			st.renderMode = int(v)
+		}
+	case "Td":
+		if x, y, ok := r.point(args); ok {
+			r.moveText(x, y)
+		}
+	case "TD":
+		if x, y, ok := r.point(args); ok {
+			st.leading = -y
+			r.moveText(x, y)
+		}
+	case "Tm":
+		if m, ok := r.matrixArgs(args); ok {
+			r.tm, r.tlm = m, m
+		}
+	case "T*":
+		r.moveText(0, -st.leading)
+	case "Tj":
+		if len(args) > 0 {
+			r.showString(args[0])
+		}
+	case "'":
+		r.moveText(0, -st.leading)
+		if len(args) > 0 {
+			r.showString(args[0])
+		}
+	case "\"":
+		if len(args) == 3 {
+			if v, ok := r.number(args[0]); ok {
+				st.wordSpace = v
+			}
+			if v, ok := r.number(args[1]); ok {
+				st.charSpace = v
+			}
+			r.moveText(0, -st.leading)
+			r.showString(args[2])
+		}
+	case "TJ":
+		if arr, ok := firstArray(args); ok {
+			r.showArray(arr)
+		}
+	}
+}
+
+// moveText starts a new line offset from the start of the current line.
+func (r *renderer) moveText(tx, ty float64) {
+	r.tlm = matrix{1, 0, 0, 1, tx, ty}.mul(r.tlm)
+	r.tm = r.tlm
+}
+
+// showArray shows a TJ array of strings and kerning adjustments.
+func (r *renderer) showArray(arr *parser.Array) {
+	for _, elem := range arr.Elements() {
+		if v, ok := r.number(elem); ok {
+			tx := -v / 1000 * r.state.fontSize * r.state.hScale
+			r.tm = matrix{1, 0, 0, 1, tx, 0}.mul(r.tm)
+			continue
+		}
+		r.showString(elem)
+	}
+}
+
+// showString paints a string and advances the text matrix.
+//
+// Reference: PDF 1.7 Specification, Section 9.4.4 (Text Space Details).
+func (r *renderer) showString(obj parser.PdfObject) {
+	s, ok := obj.(*parser.String)
+	if !ok {
+		return
+	}
+	font := r.state.font
+	if font == nil {
+		font = &fontInfo{defaultWidth: fallbackWidth}
+	}
+
+	data := s.Bytes()
+	step := 1
+	if font.twoByte {
+		step = 2
+	}
+
+	st := &r.state
+	for i := 0; i+step <= len(data); i += step {
+		code := int(data[i])
+		if step == 2 {
+			code = code<<8 | int(data[i+1])
+		}
+
+		// Text rendering matrix: font size, scaling and rise in text space.
+		trm := matrix{st.fontSize * st.hScale, 0, 0, st.fontSize, 0, st.rise}.mul(r.tm).mul(st.ctm)
+		r.paintGlyph(font, code, trm)
+
+		tx := font.width(code)/1000*st.fontSize + st.charSpace
+		if step == 1 && code == ' ' {
+			tx += st.wordSpace
+		}
+		r.tm = matrix{1, 0, 0, 1, tx * st.hScale, 0}.mul(r.tm)
+	}
+}
+
+// paintGlyph fills and/or strokes one glyph, given the matrix from em
+// space to device space.
+func (r *renderer) paintGlyph(font *fontInfo, code int, trm matrix) {
+	mode := r.state.renderMode
+	fill := mode == textFill || mode == textFillStroke || mode == 4 || mode == 6
+	stroke := mode == textStroke || mode == textFillStroke || mode == 5 || mode == 6
+	if !fill && !stroke {
+		return
+	}
+
+	contours := font.glyph(code)
+	if len(contours) == 0 {
+		return
+	}
+
+	paths := make([]subpath, len(contours))
+	polys := make([][]point, len(contours))
+	for i, c := range contours {
+		pts := make([]point, len(c))
+		for j, p := range c {
+			pts[j] = trm.apply(p.x, p.y)
+		}
+		paths[i] = subpath{points: pts, closed: true}
+		polys[i] = pts
+	}
+
+	w, h := r.img.Bounds().Dx(), r.img.Bounds().Dy()
+	if fill {
+		r.composite(rasterize(polys, w, h, nonZero), r.state.fill, r.state.fillAlpha)
+	}
+	if stroke {
+		width := r.state.lineWidth * r.state.ctm.scale()
+		r.composite(rasterize(strokePolygons(paths, width, capButt), w, h, nonZero),
+			r.state.stroke, r.state.strokeAlpha)
+	}
+}
+
+// loadFont resolves a font resource, caching the result per dictionary.
+func (r *renderer) loadFont(name string) *fontInfo {
+	dict, ok := r.resource("Font", name).(*parser.Dictionary)
+	if !ok {
+		return nil
+	}
+	if f, ok := r.fonts[dict]; ok {
+		return f
+	}
+
+	f := &fontInfo{defaultWidth: fallbackWidth, outlines: make(map[uint16][][]point)}
+	if sub := dict.GetName("Subtype"); sub != nil && sub.Value() == "Type0" {
+		r.loadType0Font(f, dict)
+	} else {
+		r.loadSimpleFont(f, dict)
+	}
+
+	r.fonts[dict] = f
+	return f
+}
+
+// loadType0Font reads a composite font with a CIDFontType2 descendant.
+//
+// Only the Identity-H and Identity-V encodings are supported; CIDs are
+// used directly as glyph IDs (CIDToGIDMap /Identity).
+//
+// Reference: PDF 1.7 Specification, Section 9.7 (Composite Fonts).
+func (r *renderer) loadType0Font(f *fontInfo, dict *parser.Dictionary) {
+	f.twoByte = true
+	f.defaultWidth = 1000
+
+	desc, ok := r.resolve(dict.Get("DescendantFonts")).(*parser.Array)
+	if !ok || desc.Len() == 0 {
+		return
+	}
+	cid, ok := r.resolve(desc.Get(0)).(*parser.Dictionary)
+	if !ok {
+		return
+	}
+
+	if v, ok := r.number(cid.Get("DW")); ok {
+		f.defaultWidth = v
+	}
+	if w, ok := r.resolve(cid.Get("W")).(*parser.Array); ok {
+		f.widths = r.cidWidths(w)
+	}
+	f.ttf = r.embeddedTrueType(cid)
+}
+
+// cidWidths parses a CIDFont /W array: "c [w1 w2 ...]" and "c1 c2 w".
+//
+// Reference: PDF 1.7 Specification, Section 9.7.4.3 (Glyph Metrics in CIDFonts).
+func (r *renderer) cidWidths(arr *parser.Array) map[int]float64 {
+	widths := make(map[int]float64)
+	elems := arr.Elements()
+	for i := 0; i < len(elems); {
+		first, ok := r.number(elems[i])
+		if !ok || i+1 >= len(elems) {
+			break
+		}
+		if list, ok := r.resolve(elems[i+1]).(*parser.Array); ok {
+			for j, e := range list.Elements() {
+				if w, ok := r.number(e); ok {
+					widths[int(first)+j] = w
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(elems) {
+			break
+		}
+		last, ok1 := r.number(elems[i+1])
+		w, ok2 := r.number(elems[i+2])
+		if !ok1 || !ok2 || last-first > 0xFFFF {
+			break
+		}
+		for c := int(first); c <= int(last); c++ {
+			widths[c] = w
+		}
+		i += 3
+	}
+	return widths
+}
+
+// loadSimpleFont reads a Type1 or TrueType font with 1-byte codes.
+//
+// Embedded TrueType programs are drawn as outlines, and non-embedded
+// Standard 14 fonts with a bundled substitute (see substituteFont). Other
+// fonts fall back to glyph boxes sized from the Standard 14 metrics when
+// available.
+func (r *renderer) loadSimpleFont(f *fontInfo, dict *parser.Dictionary) {
+	var name string
+	if base := dict.GetName("BaseFont"); base != nil {
+		name = base.Value()
+		// Strip a subset tag such as "ABCDEF+".
+		if i := strings.IndexByte(name, '+'); i == 6 {
+			name = name[i+1:]
+		}
+		f.metrics = fonts.GetMetrics(name)
+	}
+
+	if arr, ok := r.resolve(dict.Get("Widths")).(*parser.Array); ok {
+		first, _ := r.number(dict.Get("FirstChar"))
+		f.widths = make(map[int]float64, arr.Len())
+		for i, e := range arr.Elements() {
+			if w, ok := r.number(e); ok {
+				f.widths[int(first)+i] = w
+			}
+		}
+	}
+
+	if fd, ok := r.resolve(dict.Get("FontDescriptor")).(*parser.Dictionary); ok {
+		if v, ok := r.number(fd.Get("MissingWidth")); ok && v > 0 {
+			f.defaultWidth = v
+		}
+	}
+	f.ttf = r.embeddedTrueType(dict)
+
+	if f.ttf == nil && f.metrics != nil {
+		if f.substitute = substituteFont(name); f.substitute != nil {
+			f.runes = codeRunes(r.baseEncoding(dict))
+		}
+	}
+}
+
+// baseEncoding returns the name of a simple font's base encoding: its
+// /Encoding name or the /BaseEncoding of its encoding dictionary.
+func (r *renderer) baseEncoding(dict *parser.Dictionary) string {
+	switch enc := r.resolve(dict.Get("Encoding")).(type) {
+	case *parser.Name:
+		return enc.Value()
+	case *parser.Dictionary:
+		if base := enc.GetName("BaseEncoding"); base != nil {
+			return base.Value()
+		}
+	}
+	return ""
+}
+
+// embeddedTrueType loads the FontFile2 program of a font's descriptor.
+func (r *renderer) embeddedTrueType(dict *parser.Dictionary) *fonts.TTFFont {
+	fd, ok := r.resolve(dict.Get("FontDescriptor")).(*parser.Dictionary)
+	if !ok {
+		return nil
+	}
+	stream, ok := r.resolve(fd.Get("FontFile2")).(*parser.Stream)
+	if !ok {
+		return nil
+	}
+	data, err := r.decode(stream)
+	if err != nil {
+		return nil
+	}
+	ttf, err := fonts.ParseTTF(data)
+	if err != nil || ttf.UnitsPerEm == 0 {
+		return nil
+	}
+	return ttf
+}
+
+// width returns the advance width of a character code in 1/1000 em.
+func (f *fontInfo) width(code int) float64 {
+	if w, ok := f.widths[code]; ok {
+		return w
+	}
+	if f.ttf != nil {
+		if gid, ok := f.glyphID(code); ok {
+			if w, ok := f.ttf.GlyphWidths[gid]; ok {
+				return float64(w) * 1000 / float64(f.ttf.UnitsPerEm)
+			}
+		}
+	}
+	if f.metrics != nil && !f.twoByte {
+		return float64(f.metrics.GetCharWidth(rune(code)))
+	}
+	return f.defaultWidth
+}
+
+// glyphID maps a character code to a glyph in the embedded font.
+func (f *fontInfo) glyphID(code int) (uint16, bool) {
+	if f.twoByte {
+		return uint16(code), true
+	}
+	gid, ok := f.ttf.CharToGlyph[rune(code)]
+	return gid, ok
+}
+
+// glyph returns the glyph contours for a code in em units.
+//
+// Without an embedded or substitute outline, a box of the glyph's advance
+// width and x-height (or cap height for non-lowercase codes) stands in for
+// the glyph.
+func (f *fontInfo) glyph(code int) [][]point {
+	if f.ttf != nil {
+		gid, ok := f.glyphID(code)
+		if !ok {
+			return nil
+		}
+		if c, ok := f.outlines[gid]; ok {
+			return c
+		}
+		outline, err := f.ttf.GlyphOutline(gid)
+		if err != nil {
+			outline = nil
+		}
+		c := flattenOutline(outline, float64(f.ttf.UnitsPerEm))
+		f.outlines[gid] = c
+		return c
+	}
+
+	if !f.twoByte && (code <= ' ' || code == 0xA0) {
+		return nil
+	}
+	if f.substitute != nil && code < len(f.runes) {
+		if c, ok := f.substituteGlyph(code); ok {
+			return c
+		}
+	}
+	w := f.width(code) / 1000
+	height := float64(fallbackCapHeight)
+	if f.metrics != nil {
+		height = float64(f.metrics.CapHeight)
+	}
+	if !f.twoByte && code >= 'a' && code <= 'z' {
+		height = fallbackXHeight
+		if f.metrics != nil {
+			height = float64(f.metrics.XHeight)
+		}
+	}
+	height /= 1000
+	return [][]point{{
+		{w * 0.1, 0}, {w * 0.9, 0}, {w * 0.9, height}, {w * 0.1, height},
+	}}
+}
+
+// substituteGlyph returns the substitute font's glyph for a code,
+// stretched horizontally to the code's advance width.
+func (f *fontInfo) substituteGlyph(code int) ([][]point, bool) {
+	gid, ok := f.substitute.CharToGlyph[f.runes[code]]
+	if !ok || gid == 0 {
+		return nil, false
+	}
+	if c, ok := f.outlines[gid]; ok {
+		return c, true
+	}
+
+	outline, err := f.substitute.GlyphOutline(gid)
+	if err != nil {
+		return nil, false
+	}
+	c := flattenOutline(outline, float64(f.substitute.UnitsPerEm))
+	if advance := float64(f.substitute.GlyphWidths[gid]) / float64(f.substitute.UnitsPerEm); advance > 0 {
+		sx := f.width(code) / 1000 / advance
+		for _, poly := range c {
+			for i := range poly {
+				poly[i].x *= sx
+			}
+		}
+	}
+	f.outlines[gid] = c
+	return c, true
+}
+
+// flattenOutline converts quadratic TrueType contours to polygons in em
+// units.
+//
+// Reference: OpenType specification, 'glyf' table (implied on-curve points).
+func flattenOutline(contours [][]fonts.OutlinePoint, upem float64) [][]point {
+	// Flatten in 1/1000 em units so curveSteps sees a sensible length.
+	unit := 1000 / upem
+
+	out := make([][]point, 0, len(contours))
+	for _, c := range contours {
+		n := len(c)
+		if n < 2 {
+			continue
+		}
+
+		// Rotate the contour to begin at an on-curve point. A contour of
+		// only off-curve points starts at the implied midpoint of the first two.
+		start := -1
+		for i, p := range c {
+			if p.OnCurve {
+				start = i
+				break
+			}
+		}
+		var pts []fonts.OutlinePoint
+		if start < 0 {
+			mid := fonts.OutlinePoint{X: (c[0].X + c[1].X) / 2, Y: (c[0].Y + c[1].Y) / 2, OnCurve: true}
+			pts = append(append(append(pts, mid), c[1:]...), c[0])
+		} else {
+			pts = append(append(pts, c[start:]...), c[:start]...)
+		}
+		pts = append(pts, pts[0])
+
+		at := func(p fonts.OutlinePoint) point { return point{p.X * unit, p.Y * unit} }
+		prev := at(pts[0])
+		poly := []point{prev}
+		var ctrl *point
+		for _, op := range pts[1:] {
+			p := at(op)
+			if !op.OnCurve {
+				if ctrl != nil {
+					mid := point{(ctrl.x + p.x) / 2, (ctrl.y + p.y) / 2}
+					poly = flattenQuad(poly, prev, *ctrl, mid)
+					prev = mid
+				}
+				ctrl = &p
+				continue
+			}
+			if ctrl != nil {
+				poly = flattenQuad(poly, prev, *ctrl, p)
+				ctrl = nil
+			} else {
+				poly = append(poly, p)
+			}
+			prev = p
+		}
+
+		for i := range poly {
+			poly[i] = point{poly[i].x / 1000, poly[i].y / 1000}
+		}
+		out = append(out, poly)
+	}
+	return out
+}
+
+// firstArray returns the first operand as an array.
+func firstArray(args []parser.PdfObject) (*parser.Array, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+	arr, ok := args[0].(*parser.Array)
+	return arr, ok
+}
@@ -371,13 +371,15 @@ func encryptAES(key, data []byte) ([]byte, error) {
 //   - Data of 16 bytes with block size 16 needs 16 bytes padding: [data][0x10 * 16]
 func addPKCS7Padding(data []byte, blockSize int) []byte {
 	padding := blockSize - (len(data) % blockSize)
-	padText := make([]byte, padding)
-
-	for i := range padText {
-		padText[i] = byte(padding)
+	// Copy rather than append: data may have spare capacity that the
+	// caller still uses.
+	padded := make([]byte, len(data)+padding)
+	copy(padded, data)
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = byte(padding)
 	}
 
-	return append(data, padText...)
+	return padded
 }
 
 // DecryptData decrypts AES-encrypted data.
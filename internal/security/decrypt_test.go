@@ -0,0 +1,184 @@
+package security
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"testing"
+)
+
+func TestNewStandardDecryptor_Authenticates(t *testing.T) {
+	for _, keyLength := range []int{40, 128} {
+		for _, userPwd := range []string{"", "secret"} {
+			config := &EncryptionConfig{
+				UserPassword:  userPwd,
+				OwnerPassword: "owner",
+				Permissions:   PermissionPrint,
+				KeyLength:     keyLength,
+				FileID:        "0123456789abcdef",
+			}
+			enc, err := NewRC4Encryptor(config)
+			if err != nil {
+				t.Fatalf("NewRC4Encryptor() error = %v", err)
+			}
+
+			decConfig := &DecryptionConfig{
+				Dict:            enc.GetEncryptionDict(),
+				FileID:          []byte(config.FileID),
+				EncryptMetadata: true,
+			}
+			_, err = NewStandardDecryptor(decConfig)
+			if userPwd == "" && err != nil {
+				t.Errorf("%d-bit, empty password: error = %v", keyLength, err)
+			}
+			if userPwd != "" && !errors.Is(err, ErrInvalidPassword) {
+				t.Errorf("%d-bit, password %q with empty password: error = %v, want ErrInvalidPassword", keyLength, userPwd, err)
+			}
+
+			decConfig.Password = userPwd
+			if _, err := NewStandardDecryptor(decConfig); err != nil {
+				t.Errorf("%d-bit, password %q: error = %v", keyLength, userPwd, err)
+			}
+		}
+	}
+}
+
+func TestStandardDecryptor_Methods(t *testing.T) {
+	d := &StandardDecryptor{key: []byte("0123456789abcdef")}
+	plain := []byte("BT /F1 12 Tf (Hello) Tj ET")
+
+	// RC4 is symmetric.
+	rc4Data := make([]byte, len(plain))
+	if err := encryptRC4(d.objectKey(7, 0, false), plain, rc4Data); err != nil {
+		t.Fatal(err)
+	}
+	d.streamMethod = CryptMethodRC4
+	if got, err := d.DecryptStream(7, 0, rc4Data); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("RC4 DecryptStream() = %q, %v; want %q", got, err, plain)
+	}
+	if got, _ := d.DecryptStream(8, 0, rc4Data); bytes.Equal(got, plain) {
+		t.Error("object key should depend on the object number")
+	}
+
+	aesData, err := encryptAES(d.objectKey(7, 0, true), plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.stringMethod = CryptMethodAESV2
+	if got, err := d.DecryptString(7, 0, aesData); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("AESV2 DecryptString() = %q, %v; want %q", got, err, plain)
+	}
+
+	d.stringMethod = CryptMethodNone
+	if got, _ := d.DecryptString(7, 0, plain); !bytes.Equal(got, plain) {
+		t.Errorf("None DecryptString() = %q, want unchanged", got)
+	}
+}
+
+func TestNewStandardDecryptor_Unsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *DecryptionConfig
+	}{
+		{"AES-256 R 5", &DecryptionConfig{Dict: &EncryptionDict{V: 5, R: 5, Length: 256}}},
+		{"unknown method", &DecryptionConfig{
+			Dict:         &EncryptionDict{V: 4, R: 4, Length: 128},
+			StreamMethod: "MyCrypt", StringMethod: CryptMethodAESV2,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStandardDecryptor(tt.config); !errors.Is(err, ErrUnsupportedVersion) {
+				t.Errorf("error = %v, want ErrUnsupportedVersion", err)
+			}
+		})
+	}
+}
+
+// r6Dict builds an R 6 encryption dictionary for the user password and
+// file key (Algorithms 8 and 10; /O and /OE are not checked when opening
+// with the user password).
+func r6Dict(t *testing.T, password string, key []byte) *EncryptionDict {
+	t.Helper()
+	validationSalt, keySalt := []byte("vsalt123"), []byte("ksalt123")
+
+	u := append(hashR6([]byte(password), validationSalt, nil), validationSalt...)
+	u = append(u, keySalt...)
+
+	block, err := aes.NewCipher(hashR6([]byte(password), keySalt, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ue := make([]byte, 32)
+	cipher.NewCBCEncrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(ue, key)
+
+	block, err = aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perms := append(int32ToBytes(-4), 0xFF, 0xFF, 0xFF, 0xFF, 'T', 'a', 'd', 'b', 0, 0, 0, 0)
+	block.Encrypt(perms, perms)
+
+	return &EncryptionDict{
+		V: 5, R: 6, Length: 256, P: -4,
+		O: make([]byte, 48), U: u, OE: make([]byte, 32), UE: ue, Perms: perms,
+	}
+}
+
+func TestNewStandardDecryptor_AES256(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	plain := []byte("BT /F1 12 Tf (Hello) Tj ET")
+	encrypted, err := encryptAES(key, plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, password := range []string{"", "secret"} {
+		config := &DecryptionConfig{
+			Dict:            r6Dict(t, password, key),
+			EncryptMetadata: true,
+			StreamMethod:    CryptMethodAESV3,
+			StringMethod:    CryptMethodAESV3,
+			Password:        password,
+		}
+		d, err := NewStandardDecryptor(config)
+		if err != nil {
+			t.Fatalf("password %q: error = %v", password, err)
+		}
+		if !bytes.Equal(d.key, key) {
+			t.Errorf("password %q: file key = %x, want %x", password, d.key, key)
+		}
+		if got, err := d.DecryptStream(4, 0, encrypted); err != nil || !bytes.Equal(got, plain) {
+			t.Errorf("DecryptStream() = %q, %v; want %q", got, err, plain)
+		}
+	}
+
+	config := &DecryptionConfig{
+		Dict:            r6Dict(t, "secret", key),
+		EncryptMetadata: true,
+		StreamMethod:    CryptMethodAESV3,
+		StringMethod:    CryptMethodAESV3,
+	}
+	if _, err := NewStandardDecryptor(config); !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("wrong password: error = %v, want ErrInvalidPassword", err)
+	}
+
+	config.Password = "secret"
+	config.Dict.P = -8
+	if _, err := NewStandardDecryptor(config); !errors.Is(err, ErrInvalidPerms) {
+		t.Errorf("changed /P: error = %v, want ErrInvalidPerms", err)
+	}
+
+	config.Dict.P = -4
+	config.EncryptMetadata = false
+	if _, err := NewStandardDecryptor(config); !errors.Is(err, ErrInvalidPerms) {
+		t.Errorf("changed /EncryptMetadata: error = %v, want ErrInvalidPerms", err)
+	}
+
+	config.Dict.UE = config.Dict.UE[:16]
+	if _, err := NewStandardDecryptor(config); !errors.Is(err, ErrDataTooShort) {
+		t.Errorf("short /UE: error = %v, want ErrDataTooShort", err)
+	}
+}
@@ -0,0 +1,103 @@
+package security
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStandardEncryptor_RoundTrip(t *testing.T) {
+	plain := []byte("BT /F1 12 Tf (Hello) Tj ET")
+	fileID := []byte("0123456789abcdef")
+
+	for _, tt := range []struct {
+		name      string
+		algorithm Algorithm
+		method    string
+		v, r      int
+	}{
+		{"RC4-40", AlgorithmRC4_40, CryptMethodRC4, 1, 2},
+		{"RC4-128", AlgorithmRC4_128, CryptMethodRC4, 2, 3},
+		{"AES-128", AlgorithmAES128, CryptMethodAESV2, 4, 4},
+		{"AES-256", AlgorithmAES256, CryptMethodAESV3, 5, 6},
+	} {
+		for _, userPwd := range []string{"", "secret"} {
+			enc, err := NewStandardEncryptor(&StandardEncryptionConfig{
+				UserPassword:  userPwd,
+				OwnerPassword: "owner",
+				Permissions:   int32(PermissionPrint),
+				Algorithm:     tt.algorithm,
+				FileID:        fileID,
+			})
+			if err != nil {
+				t.Fatalf("%s: NewStandardEncryptor() error = %v", tt.name, err)
+			}
+			dict := enc.Dict()
+			if dict.V != tt.v || dict.R != tt.r {
+				t.Errorf("%s: V %d, R %d; want V %d, R %d", tt.name, dict.V, dict.R, tt.v, tt.r)
+			}
+
+			stream, err := enc.EncryptStream(12, 0, plain)
+			if err != nil {
+				t.Fatalf("%s: EncryptStream() error = %v", tt.name, err)
+			}
+			str, err := enc.EncryptString(12, 0, plain)
+			if err != nil {
+				t.Fatalf("%s: EncryptString() error = %v", tt.name, err)
+			}
+			if bytes.Contains(stream, plain) {
+				t.Errorf("%s: EncryptStream() left the data in clear", tt.name)
+			}
+
+			config := &DecryptionConfig{
+				Dict:            dict,
+				FileID:          fileID,
+				EncryptMetadata: true,
+				StreamMethod:    tt.method,
+				StringMethod:    tt.method,
+				Password:        userPwd,
+			}
+			dec, err := NewStandardDecryptor(config)
+			if err != nil {
+				t.Fatalf("%s, password %q: NewStandardDecryptor() error = %v", tt.name, userPwd, err)
+			}
+			if got, err := dec.DecryptStream(12, 0, stream); err != nil || !bytes.Equal(got, plain) {
+				t.Errorf("%s: DecryptStream() = %q, %v; want %q", tt.name, got, err, plain)
+			}
+			if got, err := dec.DecryptString(12, 0, str); err != nil || !bytes.Equal(got, plain) {
+				t.Errorf("%s: DecryptString() = %q, %v; want %q", tt.name, got, err, plain)
+			}
+
+			// Re-encrypting with the decryptor's key opens the same way.
+			again, err := dec.Encryptor(dict).EncryptStream(13, 0, plain)
+			if err != nil {
+				t.Fatalf("%s: Encryptor().EncryptStream() error = %v", tt.name, err)
+			}
+			if got, err := dec.DecryptStream(13, 0, again); err != nil || !bytes.Equal(got, plain) {
+				t.Errorf("%s: DecryptStream(Encryptor().EncryptStream()) = %q, %v; want %q", tt.name, got, err, plain)
+			}
+
+			if userPwd != "" {
+				config.Password = "wrong"
+				if _, err := NewStandardDecryptor(config); !errors.Is(err, ErrInvalidPassword) {
+					t.Errorf("%s: wrong password error = %v, want ErrInvalidPassword", tt.name, err)
+				}
+			}
+		}
+	}
+}
+
+func TestNewStandardEncryptor_Invalid(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config StandardEncryptionConfig
+		want   error
+	}{
+		{"no file ID", StandardEncryptionConfig{Algorithm: AlgorithmAES128}, ErrMissingFileID},
+		{"algorithm", StandardEncryptionConfig{Algorithm: 7, FileID: []byte("id")}, ErrUnsupportedVersion},
+	} {
+		if _, err := NewStandardEncryptor(&tt.config); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -12,6 +12,9 @@ var (
 	// ErrInvalidPassword is returned when password verification fails.
 	ErrInvalidPassword = errors.New("invalid password")
 
+	// ErrInvalidPerms is returned when /Perms does not match /P (R 6).
+	ErrInvalidPerms = errors.New("encrypted permissions do not match /P")
+
 	// ErrUnsupportedVersion is returned when encryption version is not supported.
 	ErrUnsupportedVersion = errors.New("unsupported encryption version")
 
@@ -87,6 +87,15 @@ type EncryptionDict struct {
 
 	// CFM is the crypt filter method (empty for RC4, "AESV2" for AES-128, "AESV3" for AES-256).
 	CFM string
+
+	// OE and UE are the file key encrypted with the owner and user
+	// password hashes (32 bytes each, R 6 only).
+	OE []byte
+	UE []byte
+
+	// Perms is the permissions value encrypted with the file key
+	// (16 bytes, R 6 only).
+	Perms []byte
 }
 
 // RC4Encryptor handles RC4 encryption/decryption for PDF objects.
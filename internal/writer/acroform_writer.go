@@ -7,14 +7,26 @@ import (
 	"github.com/coregx/gxpdf/internal/document"
 )
 
+// formParent tracks a parent field (radio group) whose kid widgets are
+// spread over one or more pages.
+type formParent struct {
+	objNum int
+	kids   []int
+}
+
 // writeFormFields writes form field widget annotations.
 //
 // Form fields are special annotations that combine field properties with
-// widget appearance.
+// widget appearance. Button widgets (checkboxes, radio buttons) and text
+// fields also get their appearance streams written here.
+//
+// Top-level fields are recorded for the AcroForm /Fields array. Radio
+// widgets are recorded as kids of their parent field, which is written
+// later by createFormParentObjects once all pages have been processed.
 //
 // Returns:
-//   - formFieldObjs: Array of form field indirect objects
-//   - formFieldRefs: Array of form field object numbers (for /Fields and /Annots arrays)
+//   - formFieldObjs: Widget and appearance stream indirect objects
+//   - formFieldRefs: Widget object numbers (for the page /Annots array)
 //   - error: Any error that occurred
 func (w *PdfWriter) writeFormFields(
 	fields []*document.FormField,
@@ -30,13 +42,91 @@ func (w *PdfWriter) writeFormFields(
 		objNum := w.allocateObjNum()
 		fieldRefs = append(fieldRefs, objNum)
 
-		fieldObj := createFormFieldObject(objNum, field)
+		parentObjNum := 0
+		if parent := field.Parent(); parent != nil {
+			fp := w.formParentFor(parent)
+			fp.kids = append(fp.kids, objNum)
+			parentObjNum = fp.objNum
+		} else {
+			w.formFieldRefs = append(w.formFieldRefs, objNum)
+			w.recordFormFieldName(field.Name(), objNum)
+		}
+
+		// Button and text appearance streams (/AP) must exist before the
+		// widget dictionary references them.
+		var ap *buttonAppearance
+		textAP := 0
+		switch {
+		case isToggleButton(field):
+			var apObjs []*IndirectObject
+			ap, apObjs = w.createButtonAppearances(field)
+			fieldObjs = append(fieldObjs, apObjs...)
+		case field.FieldType() == "Tx":
+			apObj := w.createTextAppearance(field)
+			textAP = apObj.Number
+			fieldObjs = append(fieldObjs, apObj)
+		}
+
+		fieldObj := createFormFieldObject(objNum, field, parentObjNum, ap, textAP)
 		fieldObjs = append(fieldObjs, fieldObj)
 	}
 
 	return fieldObjs, fieldRefs, nil
 }
 
+// formParentFor returns the tracking entry for a parent field, allocating
+// its object number on first use.
+func (w *PdfWriter) formParentFor(parent *document.FormField) *formParent {
+	if w.formParents == nil {
+		w.formParents = make(map[*document.FormField]*formParent)
+	}
+	fp, ok := w.formParents[parent]
+	if !ok {
+		fp = &formParent{objNum: w.allocateObjNum()}
+		w.formParents[parent] = fp
+		w.formParentOrder = append(w.formParentOrder, parent)
+		w.formFieldRefs = append(w.formFieldRefs, fp.objNum)
+		w.recordFormFieldName(parent.Name(), fp.objNum)
+	}
+	return fp
+}
+
+// createFormParentObjects writes the parent field dictionaries collected by
+// writeFormFields.
+//
+// Format:
+//
+//	<< /FT /Btn /T (gender) /Ff 49152 /V /male /Kids [101 0 R 102 0 R] >>
+func (w *PdfWriter) createFormParentObjects() []*IndirectObject {
+	objs := make([]*IndirectObject, 0, len(w.formParentOrder))
+
+	for _, parent := range w.formParentOrder {
+		fp := w.formParents[parent]
+
+		var buf bytes.Buffer
+		buf.WriteString("<<")
+		buf.WriteString(fmt.Sprintf(" /FT /%s", parent.FieldType()))
+		buf.WriteString(fmt.Sprintf(" /T (%s)", EscapePDFString(parent.Name())))
+		if parent.Flags() != 0 {
+			buf.WriteString(fmt.Sprintf(" /Ff %d", parent.Flags()))
+		}
+		writeFieldValues(&buf, parent)
+
+		buf.WriteString(" /Kids [")
+		for i, kid := range fp.kids {
+			if i > 0 {
+				buf.WriteString(" ")
+			}
+			buf.WriteString(fmt.Sprintf("%d 0 R", kid))
+		}
+		buf.WriteString("] >>")
+
+		objs = append(objs, NewIndirectObject(fp.objNum, 0, buf.Bytes()))
+	}
+
+	return objs
+}
+
 // createFormFieldObject creates a form field widget annotation indirect object.
 //
 // PDF form field format (text field example):
@@ -58,30 +148,31 @@ func (w *PdfWriter) writeFormFields(
 //	    /BG [1 1 1]             % Background color
 //	  >>
 //	>>
-func createFormFieldObject(objNum int, field *document.FormField) *IndirectObject {
+//
+// Widgets with a parent (parentObjNum > 0) are kids of a radio group: they
+// carry /Parent instead of /FT, /T, /Ff and /V. Button widgets with an
+// appearance (ap != nil) get /AS and /AP << /N << /On .. /Off .. >> /D .. >>;
+// text fields with an appearance stream (textAP > 0) get /AP << /N textAP >>.
+func createFormFieldObject(objNum int, field *document.FormField, parentObjNum int, ap *buttonAppearance, textAP int) *IndirectObject {
 	var buf bytes.Buffer
 
 	buf.WriteString("<<")
 	buf.WriteString(" /Type /Annot")
 	buf.WriteString(" /Subtype /Widget")
 
-	// Field type (/FT)
-	buf.WriteString(fmt.Sprintf(" /FT /%s", field.FieldType()))
+	if parentObjNum > 0 {
+		// Kid widget: field-level entries are inherited from the parent.
+		buf.WriteString(fmt.Sprintf(" /Parent %d 0 R", parentObjNum))
+	} else {
+		// Field type (/FT)
+		buf.WriteString(fmt.Sprintf(" /FT /%s", field.FieldType()))
 
-	// Field name (/T)
-	escapedName := EscapePDFString(field.Name())
-	buf.WriteString(fmt.Sprintf(" /T (%s)", escapedName))
+		// Field name (/T)
+		escapedName := EscapePDFString(field.Name())
+		buf.WriteString(fmt.Sprintf(" /T (%s)", escapedName))
 
-	// Field value (/V)
-	if field.Value() != "" {
-		escapedValue := EscapePDFString(field.Value())
-		buf.WriteString(fmt.Sprintf(" /V (%s)", escapedValue))
-	}
-
-	// Default value (/DV)
-	if field.DefaultValue() != "" {
-		escapedDefault := EscapePDFString(field.DefaultValue())
-		buf.WriteString(fmt.Sprintf(" /DV (%s)", escapedDefault))
+		// Field value (/V) and default value (/DV)
+		writeFieldValues(&buf, field)
 	}
 
 	// Alternate text for accessibility (/TU)
@@ -93,15 +184,15 @@ func createFormFieldObject(objNum int, field *document.FormField) *IndirectObjec
 	// Rectangle (/Rect)
 	rect := field.Rect()
 	buf.WriteString(fmt.Sprintf(
-		" /Rect [%.2f %.2f %.2f %.2f]",
-		rect[0], rect[1], rect[2], rect[3],
+		" /Rect [%s]",
+		formatNumbers(rect[0], rect[1], rect[2], rect[3]),
 	))
 
 	// Annotation flags (/F) - 4 = Print flag
 	buf.WriteString(fmt.Sprintf(" /F %d", field.AnnotationFlags()))
 
 	// Field flags (/Ff)
-	if field.Flags() != 0 {
+	if parentObjNum == 0 && field.Flags() != 0 {
 		buf.WriteString(fmt.Sprintf(" /Ff %d", field.Flags()))
 	}
 
@@ -121,22 +212,148 @@ func createFormFieldObject(objNum int, field *document.FormField) *IndirectObjec
 
 		// Border color (/BC)
 		if bc := field.BorderColor(); bc != nil {
-			buf.WriteString(fmt.Sprintf(" /BC [%.2f %.2f %.2f]", bc[0], bc[1], bc[2]))
+			buf.WriteString(fmt.Sprintf(" /BC [%s]", formatNumbers(bc[0], bc[1], bc[2])))
 		}
 
 		// Background fill color (/BG)
 		if fc := field.FillColor(); fc != nil {
-			buf.WriteString(fmt.Sprintf(" /BG [%.2f %.2f %.2f]", fc[0], fc[1], fc[2]))
+			buf.WriteString(fmt.Sprintf(" /BG [%s]", formatNumbers(fc[0], fc[1], fc[2])))
 		}
 
 		buf.WriteString(" >>")
 	}
 
+	// Button appearance state (/AS) and appearance dictionary (/AP)
+	if ap != nil {
+		onName := escapePDFName(field.ExportValue())
+		state := "Off"
+		if field.IsOn() {
+			state = onName
+		}
+		buf.WriteString(fmt.Sprintf(" /AS /%s", state))
+		buf.WriteString(fmt.Sprintf(
+			" /AP << /N << /%s %d 0 R /Off %d 0 R >> /D << /%s %d 0 R /Off %d 0 R >> >>",
+			onName, ap.normalOn, ap.normalOff, onName, ap.downOn, ap.downOff,
+		))
+	}
+
+	// Text appearance dictionary (/AP)
+	if textAP > 0 {
+		buf.WriteString(fmt.Sprintf(" /AP << /N %d 0 R >>", textAP))
+	}
+
 	buf.WriteString(" >>")
 
 	return NewIndirectObject(objNum, 0, buf.Bytes())
 }
 
+// writeFieldValues writes /V and /DV for a field.
+//
+// Button values are names (/V /Yes, /V /Off); other field values are strings.
+func writeFieldValues(buf *bytes.Buffer, field *document.FormField) {
+	if field.FieldType() == "Btn" {
+		if field.Value() != "" {
+			buf.WriteString(fmt.Sprintf(" /V /%s", escapePDFName(field.Value())))
+		}
+		if field.DefaultValue() != "" {
+			buf.WriteString(fmt.Sprintf(" /DV /%s", escapePDFName(field.DefaultValue())))
+		}
+		return
+	}
+
+	// Field value (/V)
+	if field.Value() != "" {
+		escapedValue := EscapePDFString(field.Value())
+		buf.WriteString(fmt.Sprintf(" /V (%s)", escapedValue))
+	}
+
+	// Default value (/DV)
+	if field.DefaultValue() != "" {
+		escapedDefault := EscapePDFString(field.DefaultValue())
+		buf.WriteString(fmt.Sprintf(" /DV (%s)", escapedDefault))
+	}
+}
+
+// resetFormState clears AcroForm state from a previous write.
+func (w *PdfWriter) resetFormState() {
+	w.formFieldRefs = nil
+	w.formParents = nil
+	w.formParentOrder = nil
+	w.formFieldNums = nil
+	w.acroFormFontNum = 0
+}
+
+// recordFormFieldName maps a top-level field name to its object number,
+// for the /CO calculation order. The first field with a name wins.
+func (w *PdfWriter) recordFormFieldName(name string, objNum int) {
+	if w.formFieldNums == nil {
+		w.formFieldNums = make(map[string]int)
+	}
+	if _, ok := w.formFieldNums[name]; !ok {
+		w.formFieldNums[name] = objNum
+	}
+}
+
+// AcroFormOptions configures document-level AcroForm entries.
+type AcroFormOptions struct {
+	// NeedAppearances asks viewers to regenerate field appearance streams
+	// (/NeedAppearances true).
+	NeedAppearances bool
+
+	// CalculationOrder lists the names of calculated fields in the order
+	// their values are recalculated (/CO). Names that match no top-level
+	// field are ignored.
+	CalculationOrder []string
+}
+
+// SetAcroFormOptions sets the AcroForm dictionary options. Pass nil for
+// the defaults (/NeedAppearances true, no /CO). Must be called before
+// writing.
+func (w *PdfWriter) SetAcroFormOptions(opts *AcroFormOptions) {
+	w.acroFormOpts = opts
+}
+
+// acroFormDict creates the AcroForm dictionary for the catalog from the
+// fields written and the AcroForm options.
+//
+// PDF/A-1 output never sets /NeedAppearances (ISO 19005-1, Section 6.9).
+func (w *PdfWriter) acroFormDict() string {
+	opts := w.acroFormOpts
+	if opts == nil {
+		if !w.pdfa {
+			return CreateAcroFormDict(w.formFieldRefs, w.acroFormFontNum)
+		}
+		opts = &AcroFormOptions{}
+	}
+
+	var calcRefs []int
+	for _, name := range opts.CalculationOrder {
+		if ref, ok := w.formFieldNums[name]; ok {
+			calcRefs = append(calcRefs, ref)
+		}
+	}
+	return createAcroFormDict(w.formFieldRefs, w.acroFormFontNum, opts.NeedAppearances && !w.pdfa, calcRefs)
+}
+
+// createAcroFormObjects creates the document-level objects needed by the
+// AcroForm dictionary: radio group parent fields and the Helvetica font
+// used by /DR.
+//
+// Returns nil if no form fields were written.
+func (w *PdfWriter) createAcroFormObjects() []*IndirectObject {
+	if len(w.formFieldRefs) == 0 {
+		return nil
+	}
+
+	objs := w.createFormParentObjects()
+
+	w.acroFormFontNum = w.allocateObjNum()
+	objs = append(objs, NewIndirectObject(w.acroFormFontNum, 0,
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")))
+
+	return objs
+}
+
 // CreateAcroFormDict creates the AcroForm dictionary for the catalog.
 //
 // The AcroForm dictionary is required when a document contains form fields.
@@ -167,6 +384,13 @@ func createFormFieldObject(objNum int, field *document.FormField) *IndirectObjec
 //
 // Returns the AcroForm dictionary as a PDF object string.
 func CreateAcroFormDict(fieldRefs []int, fontObjNum int) string {
+	return createAcroFormDict(fieldRefs, fontObjNum, true, nil)
+}
+
+// createAcroFormDict creates the AcroForm dictionary, with
+// /NeedAppearances true if needAppearances is set and a /CO array of
+// calcRefs if non-empty.
+func createAcroFormDict(fieldRefs []int, fontObjNum int, needAppearances bool, calcRefs []int) string {
 	if len(fieldRefs) == 0 {
 		return ""
 	}
@@ -186,7 +410,21 @@ func CreateAcroFormDict(fieldRefs []int, fontObjNum int) string {
 	buf.WriteString("]")
 
 	// NeedAppearances flag (let PDF reader generate field appearances)
-	buf.WriteString(" /NeedAppearances true")
+	if needAppearances {
+		buf.WriteString(" /NeedAppearances true")
+	}
+
+	// Calculation order
+	if len(calcRefs) > 0 {
+		buf.WriteString(" /CO [")
+		for i, ref := range calcRefs {
+			if i > 0 {
+				buf.WriteString(" ")
+			}
+			buf.WriteString(fmt.Sprintf("%d 0 R", ref))
+		}
+		buf.WriteString("]")
+	}
 
 	// Default resources (fonts)
 	if fontObjNum > 0 {
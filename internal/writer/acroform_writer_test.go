@@ -0,0 +1,302 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// writeFormDocument writes a one-page document with the given fields and
+// returns the PDF bytes.
+func writeFormDocument(t *testing.T, fields ...*document.FormField) string {
+	t.Helper()
+
+	doc := document.NewDocument()
+	page, err := doc.AddPage(document.A4)
+	if err != nil {
+		t.Fatalf("AddPage() error: %v", err)
+	}
+	for _, f := range fields {
+		if err := page.AddFormField(f); err != nil {
+			t.Fatalf("AddFormField() error: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriteFormFields_CheckboxAppearances(t *testing.T) {
+	checked := document.NewFormField("Btn", "agree", [4]float64{100, 700, 115, 715})
+	checked.SetValue("Yes")
+	checked.SetBorderColor(0, 0, 0)
+
+	unchecked := document.NewFormField("Btn", "news", [4]float64{100, 650, 115, 665})
+	unchecked.SetValue("Off")
+
+	pdf := writeFormDocument(t, checked, unchecked)
+
+	for _, want := range []string{
+		"/FT /Btn /T (agree) /V /Yes",
+		"/AS /Yes",
+		"/AS /Off",
+		"/AcroForm << /Fields [",
+	} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	}
+
+	apPattern := regexp.MustCompile(`/AP << /N << /Yes \d+ 0 R /Off \d+ 0 R >> /D << /Yes \d+ 0 R /Off \d+ 0 R >> >>`)
+	if got := len(apPattern.FindAllString(pdf, -1)); got != 2 {
+		t.Errorf("expected 2 checkbox /AP dictionaries, got %d", got)
+	}
+
+	// Each widget has 4 appearance streams.
+	if got := strings.Count(pdf, "/Subtype /Form /BBox [0 0 15 15]"); got != 8 {
+		t.Errorf("expected 8 appearance streams, got %d", got)
+	}
+}
+
+func TestWriteFormFields_RadioGroup(t *testing.T) {
+	parent := document.NewFormField("Btn", "size", [4]float64{100, 600, 115, 615})
+	parent.SetFlags(1<<15 | 1<<14) // Radio | NoToggleToOff
+	parent.SetValue("large")
+
+	var widgets []*document.FormField
+	for i, value := range []string{"small", "large"} {
+		x := 100 + float64(i)*50
+		widget := document.NewFormField("Btn", "size", [4]float64{x, 600, x + 15, 615})
+		widget.SetParent(parent)
+		widget.SetExportValue(value)
+		widgets = append(widgets, widget)
+	}
+
+	pdf := writeFormDocument(t, widgets...)
+
+	// Shared parent field with both kids.
+	parentPattern := regexp.MustCompile(`/FT /Btn /T \(size\) /Ff 49152 /V /large /Kids \[\d+ 0 R \d+ 0 R\]`)
+	if !parentPattern.MatchString(pdf) {
+		t.Error("PDF should contain radio parent field with two kids")
+	}
+
+	// Distinct on-state names per widget; only "large" is on.
+	for _, want := range []string{
+		"/AS /Off /AP << /N << /small ",
+		"/AS /large /AP << /N << /large ",
+	} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	}
+
+	// Kids inherit field entries from the parent.
+	if got := strings.Count(pdf, "/T (size)"); got != 1 {
+		t.Errorf("expected /T only on the parent, found %d occurrences", got)
+	}
+	if got := strings.Count(pdf, "/Parent"); got < 2 {
+		t.Errorf("expected kid widgets to reference the parent, found %d /Parent entries", got)
+	}
+
+	// Only the parent is a top-level field.
+	fieldsPattern := regexp.MustCompile(`/Fields \[\d+ 0 R\]`)
+	if !fieldsPattern.MatchString(pdf) {
+		t.Error("AcroForm /Fields should list only the radio parent")
+	}
+}
+
+func TestWriteFormFields_TextFieldNoAppearanceStates(t *testing.T) {
+	field := document.NewFormField("Tx", "name", [4]float64{100, 700, 300, 720})
+	field.SetValue("John")
+
+	pdf := writeFormDocument(t, field)
+
+	if !strings.Contains(pdf, "/FT /Tx /T (name) /V (John)") {
+		t.Error("PDF should contain text field with string value")
+	}
+	if strings.Contains(pdf, "/AS ") {
+		t.Error("text field should not have an appearance state")
+	}
+}
+
+func TestWriteFormFields_TextFieldAppearance(t *testing.T) {
+	field := document.NewFormField("Tx", "name", [4]float64{100, 700, 300, 720})
+	field.SetValue("John")
+	field.SetAppearance("/Cour 10.00 Tf 0.000 0.000 1.000 rg")
+	field.SetBorderColor(0, 0, 0)
+
+	pdf := writeFormDocument(t, field)
+
+	if !regexp.MustCompile(`/FT /Tx /T \(name\) /V \(John\).* /AP << /N \d+ 0 R >>`).MatchString(pdf) {
+		t.Error("text field should reference a normal appearance stream")
+	}
+	for _, want := range []string{
+		"/BBox [0 0 200 20] /Resources << /Font << /Cour << /Type /Font /Subtype /Type1 /BaseFont /Courier",
+		"/Tx BMC",
+		"/Cour 10 Tf",
+		"0 0 1 rg",
+		"(John) Tj",
+		"EMC",
+	} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	}
+}
+
+func TestWriteFormFields_TextFieldAppearanceLines(t *testing.T) {
+	multiline := document.NewFormField("Tx", "notes", [4]float64{100, 600, 300, 680})
+	multiline.SetValue("first\nsecond")
+	multiline.SetFlags(textFlagMultiline)
+
+	password := document.NewFormField("Tx", "pin", [4]float64{100, 550, 300, 570})
+	password.SetValue("1234")
+	password.SetFlags(textFlagPassword)
+
+	pdf := writeFormDocument(t, multiline, password)
+
+	for _, want := range []string{"(first) Tj", "(second) '", "(****) Tj"} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	}
+	if strings.Contains(pdf, "(1234) Tj") {
+		t.Error("password appearance should not show the value")
+	}
+}
+
+func TestParseDefaultAppearance(t *testing.T) {
+	tests := []struct {
+		da    string
+		font  string
+		size  float64
+		color []float64
+	}{
+		{"/Helv 12 Tf 0 g", "Helv", 12, []float64{0}},
+		{"/TiRo 9.50 Tf 0.000 0.500 1.000 rg", "TiRo", 9.5, []float64{0, 0.5, 1}},
+		{"0 0 0 1 k /Cour 0 Tf", "Cour", 0, []float64{0, 0, 0, 1}},
+		{"", "Helv", 12, nil},
+	}
+
+	for _, tt := range tests {
+		got := parseDefaultAppearance(tt.da)
+		if got.font != tt.font || got.size != tt.size || fmt.Sprint(got.color) != fmt.Sprint(tt.color) {
+			t.Errorf("parseDefaultAppearance(%q) = %+v, want %s %v %v", tt.da, got, tt.font, tt.size, tt.color)
+		}
+	}
+}
+
+func TestEscapePDFName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Yes", "Yes"},
+		{"Option A", "Option#20A"},
+		{"a/b#c", "a#2Fb#23c"},
+		{"(x)", "#28x#29"},
+	}
+
+	for _, tt := range tests {
+		if got := escapePDFName(tt.input); got != tt.want {
+			t.Errorf("escapePDFName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFormFields_AcroFormOptions(t *testing.T) {
+	newDoc := func() *document.Document {
+		doc := document.NewDocument()
+		page, err := doc.AddPage(document.A4)
+		if err != nil {
+			t.Fatalf("AddPage() error: %v", err)
+		}
+		for i, name := range []string{"subtotal", "tax", "total"} {
+			y := 700 - float64(i)*30
+			if err := page.AddFormField(document.NewFormField("Tx", name, [4]float64{100, y, 200, y + 20})); err != nil {
+				t.Fatalf("AddFormField() error: %v", err)
+			}
+		}
+		return doc
+	}
+	write := func(opts *AcroFormOptions) string {
+		var buf bytes.Buffer
+		w := NewPdfWriterFromWriter(&buf)
+		w.SetAcroFormOptions(opts)
+		if err := w.WriteWithAllContent(newDoc(), nil, nil); err != nil {
+			t.Fatalf("WriteWithAllContent() error: %v", err)
+		}
+		return buf.String()
+	}
+	objNum := func(pdf, name string) string {
+		m := regexp.MustCompile(`(\d+) 0 obj\s*<<[^\n]*/T \(` + name + `\)`).FindStringSubmatch(pdf)
+		if m == nil {
+			t.Fatalf("field %q not found", name)
+		}
+		return m[1]
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		pdf := write(nil)
+		if !strings.Contains(pdf, "/NeedAppearances true") {
+			t.Error("default AcroForm should contain /NeedAppearances true")
+		}
+		if strings.Contains(pdf, "/CO ") {
+			t.Error("default AcroForm should not contain /CO")
+		}
+	})
+
+	t.Run("calculation order", func(t *testing.T) {
+		pdf := write(&AcroFormOptions{
+			CalculationOrder: []string{"tax", "unknown", "total"},
+		})
+		if strings.Contains(pdf, "/NeedAppearances") {
+			t.Error("AcroForm should not contain /NeedAppearances when disabled")
+		}
+		want := "/CO [" + objNum(pdf, "tax") + " 0 R " + objNum(pdf, "total") + " 0 R]"
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF should contain %q", want)
+		}
+	})
+}
+
+func TestWriteFormFields_CalculationOrderRadioParent(t *testing.T) {
+	parent := document.NewFormField("Btn", "size", [4]float64{100, 600, 115, 615})
+	parent.SetFlags(1<<15 | 1<<14)
+	widget := document.NewFormField("Btn", "size", [4]float64{100, 600, 115, 615})
+	widget.SetParent(parent)
+	widget.SetExportValue("small")
+
+	doc := document.NewDocument()
+	page, err := doc.AddPage(document.A4)
+	if err != nil {
+		t.Fatalf("AddPage() error: %v", err)
+	}
+	if err := page.AddFormField(widget); err != nil {
+		t.Fatalf("AddFormField() error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetAcroFormOptions(&AcroFormOptions{NeedAppearances: true, CalculationOrder: []string{"size"}})
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error: %v", err)
+	}
+	pdf := buf.String()
+
+	m := regexp.MustCompile(`/Fields \[(\d+) 0 R\] /NeedAppearances true /CO \[(\d+) 0 R\]`).FindStringSubmatch(pdf)
+	if m == nil {
+		t.Fatal("AcroForm should list the radio parent in /Fields and /CO")
+	}
+	if m[1] != m[2] {
+		t.Errorf("/CO references object %s, want radio parent %s", m[2], m[1])
+	}
+}
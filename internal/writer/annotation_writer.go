@@ -10,7 +10,7 @@ import (
 
 // WriteAllAnnotations writes all annotations from a page and returns annotation objects.
 //
-// This handles link, text, markup, and stamp annotations.
+// This handles link, text, markup, and stamp annotations, plus form field widgets.
 //
 // Returns:
 //   - annotObjs: Array of annotation indirect objects
@@ -22,8 +22,15 @@ func (w *PdfWriter) WriteAllAnnotations(
 	var annotObjs []*IndirectObject
 	var annotRefs []int
 
+	// Annotation rectangles follow the content origin.
+	dx, dy := page.ContentOrigin()
+	shifted := dx != 0 || dy != 0
+
 	// Write link annotations.
 	linkAnnots := page.LinkAnnotations()
+	if shifted {
+		linkAnnots = offsetLinkAnnotations(linkAnnots, dx, dy)
+	}
 	if len(linkAnnots) > 0 {
 		objs, refs, err := w.writeLinkAnnotations(linkAnnots)
 		if err != nil {
@@ -35,6 +42,9 @@ func (w *PdfWriter) WriteAllAnnotations(
 
 	// Write text annotations.
 	textAnnots := page.TextAnnotations()
+	if shifted {
+		textAnnots = offsetTextAnnotations(textAnnots, dx, dy)
+	}
 	if len(textAnnots) > 0 {
 		objs, refs, err := w.writeTextAnnotations(textAnnots)
 		if err != nil {
@@ -46,6 +56,9 @@ func (w *PdfWriter) WriteAllAnnotations(
 
 	// Write markup annotations.
 	markupAnnots := page.MarkupAnnotations()
+	if shifted {
+		markupAnnots = offsetMarkupAnnotations(markupAnnots, dx, dy)
+	}
 	if len(markupAnnots) > 0 {
 		objs, refs, err := w.writeMarkupAnnotations(markupAnnots)
 		if err != nil {
@@ -57,6 +70,9 @@ func (w *PdfWriter) WriteAllAnnotations(
 
 	// Write stamp annotations.
 	stampAnnots := page.StampAnnotations()
+	if shifted {
+		stampAnnots = offsetStampAnnotations(stampAnnots, dx, dy)
+	}
 	if len(stampAnnots) > 0 {
 		objs, refs, err := w.writeStampAnnotations(stampAnnots)
 		if err != nil {
@@ -66,6 +82,20 @@ func (w *PdfWriter) WriteAllAnnotations(
 		annotRefs = append(annotRefs, refs...)
 	}
 
+	// Write form field widgets.
+	formFields := page.FormFields()
+	if shifted {
+		formFields = offsetFormFields(formFields, dx, dy)
+	}
+	if len(formFields) > 0 {
+		objs, refs, err := w.writeFormFields(formFields)
+		if err != nil {
+			return nil, nil, err
+		}
+		annotObjs = append(annotObjs, objs...)
+		annotRefs = append(annotRefs, refs...)
+	}
+
 	return annotObjs, annotRefs, nil
 }
 
@@ -210,12 +240,13 @@ func createLinkAnnotationObject(objNum int, annot *document.LinkAnnotation) (*In
 
 	// Write rectangle (clickable area).
 	buf.WriteString(fmt.Sprintf(
-		" /Rect [%.2f %.2f %.2f %.2f]",
-		annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3],
+		" /Rect [%s]",
+		formatNumbers(annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3]),
 	))
 
 	// Write border (0 0 0 = no visible border, or use BorderWidth).
-	buf.WriteString(fmt.Sprintf(" /Border [0 0 %.2f]", annot.BorderWidth))
+	buf.WriteString(fmt.Sprintf(" /Border [0 0 %s]", formatNumber(annot.BorderWidth)))
+	buf.WriteString(formatBorderStyle(annot))
 
 	// Write action or destination based on link type.
 	if annot.IsInternal {
@@ -241,6 +272,39 @@ func createLinkAnnotationObject(objNum int, annot *document.LinkAnnotation) (*In
 	return NewIndirectObject(objNum, 0, buf.Bytes()), nil
 }
 
+// formatBorderStyle returns the /BS border style and /C border color
+// entries of a link annotation, or an empty string if neither is set.
+//
+// /BS takes precedence over /Border in viewers that support it; /Border
+// is still written for older viewers.
+//
+// Format:
+//
+//	/BS << /W 1 /S /D /D [3 2] >> /C [0 0 1]
+//
+// Reference: PDF 1.7 Specification, Section 12.5.4 (Border Styles).
+func formatBorderStyle(annot *document.LinkAnnotation) string {
+	var buf bytes.Buffer
+
+	if annot.BorderStyle != "" {
+		buf.WriteString(fmt.Sprintf(" /BS << /W %s /S /%s", formatNumber(annot.BorderWidth), annot.BorderStyle))
+		if annot.BorderStyle == document.BorderStyleDashed {
+			dash := annot.DashArray
+			if len(dash) == 0 {
+				dash = []float64{3}
+			}
+			buf.WriteString(fmt.Sprintf(" /D [%s]", formatNumbers(dash...)))
+		}
+		buf.WriteString(" >>")
+	}
+
+	if c := annot.BorderColor; c != nil {
+		buf.WriteString(fmt.Sprintf(" /C [%s]", formatNumbers(c[0], c[1], c[2])))
+	}
+
+	return buf.String()
+}
+
 // createTextAnnotationObject creates a text annotation indirect object.
 //
 // PDF annotation format:
@@ -263,8 +327,8 @@ func createTextAnnotationObject(objNum int, annot *document.TextAnnotation) *Ind
 
 	// Rectangle.
 	buf.WriteString(fmt.Sprintf(
-		" /Rect [%.2f %.2f %.2f %.2f]",
-		annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3],
+		" /Rect [%s]",
+		formatNumbers(annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3]),
 	))
 
 	// Contents (pop-up text).
@@ -280,8 +344,8 @@ func createTextAnnotationObject(objNum int, annot *document.TextAnnotation) *Ind
 	}
 
 	// Color.
-	buf.WriteString(fmt.Sprintf(" /C [%.2f %.2f %.2f]",
-		annot.Color[0], annot.Color[1], annot.Color[2]))
+	buf.WriteString(fmt.Sprintf(" /C [%s]",
+		formatNumbers(annot.Color[0], annot.Color[1], annot.Color[2])))
 
 	// Open flag.
 	if annot.Open {
@@ -328,8 +392,8 @@ func createMarkupAnnotationObject(objNum int, annot *document.MarkupAnnotation)
 
 	// Rectangle.
 	buf.WriteString(fmt.Sprintf(
-		" /Rect [%.2f %.2f %.2f %.2f]",
-		annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3],
+		" /Rect [%s]",
+		formatNumbers(annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3]),
 	))
 
 	// QuadPoints.
@@ -337,16 +401,15 @@ func createMarkupAnnotationObject(objNum int, annot *document.MarkupAnnotation)
 		buf.WriteString(" /QuadPoints [")
 		var parts []string
 		for _, quad := range annot.QuadPoints {
-			parts = append(parts, fmt.Sprintf("%.2f %.2f %.2f %.2f %.2f %.2f %.2f %.2f",
-				quad[0], quad[1], quad[2], quad[3], quad[4], quad[5], quad[6], quad[7]))
+			parts = append(parts, formatNumbers(quad[0], quad[1], quad[2], quad[3], quad[4], quad[5], quad[6], quad[7]))
 		}
 		buf.WriteString(strings.Join(parts, " "))
 		buf.WriteString("]")
 	}
 
 	// Color.
-	buf.WriteString(fmt.Sprintf(" /C [%.2f %.2f %.2f]",
-		annot.Color[0], annot.Color[1], annot.Color[2]))
+	buf.WriteString(fmt.Sprintf(" /C [%s]",
+		formatNumbers(annot.Color[0], annot.Color[1], annot.Color[2])))
 
 	// Title (author).
 	if annot.Title != "" {
@@ -387,16 +450,16 @@ func createStampAnnotationObject(objNum int, annot *document.StampAnnotation) *I
 
 	// Rectangle.
 	buf.WriteString(fmt.Sprintf(
-		" /Rect [%.2f %.2f %.2f %.2f]",
-		annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3],
+		" /Rect [%s]",
+		formatNumbers(annot.Rect[0], annot.Rect[1], annot.Rect[2], annot.Rect[3]),
 	))
 
 	// Name (stamp type).
 	buf.WriteString(fmt.Sprintf(" /Name /%s", annot.Name))
 
 	// Color.
-	buf.WriteString(fmt.Sprintf(" /C [%.2f %.2f %.2f]",
-		annot.Color[0], annot.Color[1], annot.Color[2]))
+	buf.WriteString(fmt.Sprintf(" /C [%s]",
+		formatNumbers(annot.Color[0], annot.Color[1], annot.Color[2])))
 
 	// Title (author).
 	if annot.Title != "" {
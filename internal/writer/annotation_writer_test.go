@@ -0,0 +1,74 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+func TestCreateLinkAnnotationObject_BorderStyle(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(a *document.LinkAnnotation)
+		want  []string
+		avoid []string
+	}{
+		{
+			name:  "no border",
+			setup: func(*document.LinkAnnotation) {},
+			want:  []string{"/Border [0 0 0]"},
+			avoid: []string{"/BS", "/C "},
+		},
+		{
+			name: "underline",
+			setup: func(a *document.LinkAnnotation) {
+				a.BorderStyle = document.BorderStyleUnderline
+				a.BorderWidth = 1
+			},
+			want:  []string{"/BS << /W 1 /S /U >>"},
+			avoid: []string{"/D ["},
+		},
+		{
+			name: "dashed with color",
+			setup: func(a *document.LinkAnnotation) {
+				a.BorderStyle = document.BorderStyleDashed
+				a.BorderWidth = 0.5
+				a.DashArray = []float64{3, 2}
+				a.BorderColor = &[3]float64{0, 0, 1}
+			},
+			want: []string{"/BS << /W 0.50 /S /D /D [3 2] >>", "/C [0 0 1]"},
+		},
+		{
+			name: "dashed default pattern",
+			setup: func(a *document.LinkAnnotation) {
+				a.BorderStyle = document.BorderStyleDashed
+				a.BorderWidth = 1
+			},
+			want: []string{"/S /D /D [3] >>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annot := document.NewLinkAnnotation([4]float64{100, 690, 200, 710}, "https://example.com")
+			tt.setup(annot)
+
+			obj, err := createLinkAnnotationObject(5, annot)
+			if err != nil {
+				t.Fatalf("createLinkAnnotationObject() error = %v", err)
+			}
+			data := string(obj.Data)
+			for _, want := range tt.want {
+				if !strings.Contains(data, want) {
+					t.Errorf("annotation should contain %q, got: %s", want, data)
+				}
+			}
+			for _, avoid := range tt.avoid {
+				if strings.Contains(data, avoid) {
+					t.Errorf("annotation should not contain %q, got: %s", avoid, data)
+				}
+			}
+		})
+	}
+}
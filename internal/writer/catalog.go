@@ -32,10 +32,51 @@ func (w *PdfWriter) createCatalog(pagesRef int, doc *document.Document) *Indirec
 	catalog.WriteString(fmt.Sprintf(" /Pages %d 0 R", pagesRef))
 
 	// Add optional entries
+	if labels := doc.PageLabels(); len(labels) > 0 {
+		catalog.WriteString(" /PageLabels ")
+		catalog.WriteString(formatPageLabels(labels))
+	}
+
+	if mode := doc.PageMode(); mode != document.PageModeUseNone {
+		catalog.WriteString(fmt.Sprintf(" /PageMode /%s", mode))
+	}
+
+	if lang := doc.Language(); lang != "" {
+		catalog.WriteString(fmt.Sprintf(" /Lang (%s)", EscapePDFString(lang)))
+	}
+
+	if doc.Tagged() {
+		catalog.WriteString(" /MarkInfo << /Marked true >>")
+	}
+
+	if w.structTreeRootNum > 0 {
+		catalog.WriteString(fmt.Sprintf(" /StructTreeRoot %d 0 R", w.structTreeRootNum))
+	}
+
+	if w.metadataNum > 0 {
+		catalog.WriteString(fmt.Sprintf(" /Metadata %d 0 R", w.metadataNum))
+	}
+
+	if w.outputProfileNum > 0 {
+		catalog.WriteString(" /OutputIntents ")
+		catalog.WriteString(w.outputIntents())
+	}
+
+	if w.outlineNum > 0 {
+		catalog.WriteString(fmt.Sprintf(" /Outlines %d 0 R", w.outlineNum))
+	}
+
+	if w.usageRightsNum > 0 {
+		catalog.WriteString(fmt.Sprintf(" /Perms << /UR3 %d 0 R >>", w.usageRightsNum))
+	}
+
+	if len(w.formFieldRefs) > 0 {
+		catalog.WriteString(" /AcroForm ")
+		catalog.WriteString(w.acroFormDict())
+	}
+
 	// TODO: Add more catalog entries as needed:
 	// - /PageLayout (SinglePage, OneColumn, etc.)
-	// - /PageMode (UseNone, UseOutlines, UseThumbs, FullScreen)
-	// - /Outlines (bookmarks)
 	// - /Names (named destinations)
 	// - /OpenAction (action to perform when document is opened)
 
@@ -43,3 +84,35 @@ func (w *PdfWriter) createCatalog(pagesRef int, doc *document.Document) *Indirec
 
 	return NewIndirectObject(catalogNum, 0, catalog.Bytes())
 }
+
+// formatPageLabels formats page label ranges as a number tree dictionary.
+//
+// Format:
+//
+//	<< /Nums [0 << /S /r >> 4 << /S /D /P (A-) /St 5 >>] >>
+//
+// Ranges must be sorted by PageIndex (document.SetPageLabels guarantees this).
+//
+// Reference: PDF 1.7 Specification, Section 12.4.2 (Page Labels).
+func formatPageLabels(labels []document.PageLabelRange) string {
+	var buf bytes.Buffer
+	buf.WriteString("<< /Nums [")
+	for i, r := range labels {
+		if i > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%d <<", r.PageIndex))
+		if r.Style != document.PageLabelNone {
+			buf.WriteString(fmt.Sprintf(" /S /%s", r.Style))
+		}
+		if r.Prefix != "" {
+			buf.WriteString(" /P " + FormatTextString(r.Prefix))
+		}
+		if r.Start > 1 {
+			buf.WriteString(fmt.Sprintf(" /St %d", r.Start))
+		}
+		buf.WriteString(" >>")
+	}
+	buf.WriteString("] >>")
+	return buf.String()
+}
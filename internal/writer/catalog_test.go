@@ -1,6 +1,9 @@
 package writer
 
 import (
+	"bytes"
+	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -134,3 +137,105 @@ func TestCreateCatalog_ValidDictionary(t *testing.T) {
 		t.Error("/Pages should be inside dictionary")
 	}
 }
+
+func TestCreateCatalog_PageLabels(t *testing.T) {
+	w := &PdfWriter{
+		nextObjNum: 1,
+	}
+
+	doc := document.NewDocument()
+	err := doc.SetPageLabels([]document.PageLabelRange{
+		{PageIndex: 0, Style: document.PageLabelRomanLower},
+		{PageIndex: 2, Style: document.PageLabelDecimal, Prefix: "A-", Start: 5},
+	})
+	if err != nil {
+		t.Fatalf("SetPageLabels() error: %v", err)
+	}
+
+	data := string(w.createCatalog(2, doc).Data)
+
+	want := "/PageLabels << /Nums [0 << /S /r >> 2 << /S /D /P (A-) /St 5 >>] >>"
+	if !strings.Contains(data, want) {
+		t.Errorf("Catalog should contain '%s', got: %s", want, data)
+	}
+}
+
+func TestCreateCatalog_NoPageLabels(t *testing.T) {
+	w := &PdfWriter{
+		nextObjNum: 1,
+	}
+
+	data := string(w.createCatalog(2, document.NewDocument()).Data)
+	if strings.Contains(data, "/PageLabels") {
+		t.Errorf("Catalog should not contain /PageLabels, got: %s", data)
+	}
+}
+
+func TestCreateCatalog_PageMode(t *testing.T) {
+	w := &PdfWriter{
+		nextObjNum: 1,
+	}
+
+	doc := document.NewDocument()
+	if data := string(w.createCatalog(2, doc).Data); strings.Contains(data, "/PageMode") {
+		t.Errorf("Catalog should not contain /PageMode by default, got: %s", data)
+	}
+
+	doc.SetPageMode(document.PageModeUseThumbs)
+	data := string(w.createCatalog(2, doc).Data)
+	if !strings.Contains(data, "/PageMode /UseThumbs") {
+		t.Errorf("Catalog should contain '/PageMode /UseThumbs', got: %s", data)
+	}
+}
+
+func TestPdfWriter_XMPMetadata(t *testing.T) {
+	packet := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><dc:title>Report</dc:title></x:xmpmeta>`)
+
+	writes := map[string]func(w *PdfWriter, doc *document.Document) error{
+		"Write":                func(w *PdfWriter, doc *document.Document) error { return w.Write(doc) },
+		"WriteWithPageContent": func(w *PdfWriter, doc *document.Document) error { return w.WriteWithPageContent(doc, nil) },
+		"WriteWithAllContent":  func(w *PdfWriter, doc *document.Document) error { return w.WriteWithAllContent(doc, nil, nil) },
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			doc := document.NewDocument()
+			if _, err := doc.AddPage(document.A4); err != nil {
+				t.Fatalf("AddPage() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			w := NewPdfWriterFromWriter(&buf)
+			w.SetXMPMetadata(packet)
+			if err := write(w, doc); err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			pdf := buf.String()
+
+			m := regexp.MustCompile(`/Type /Catalog[^\n]* /Metadata (\d+) 0 R`).FindStringSubmatch(pdf)
+			if m == nil {
+				t.Fatal("catalog should reference the metadata stream")
+			}
+			want := fmt.Sprintf("\n%s 0 obj\n<< /Type /Metadata /Subtype /XML /Length %d >>\nstream\n%s\nendstream",
+				m[1], len(packet), packet)
+			if !strings.Contains(pdf, want) {
+				t.Errorf("PDF should contain uncompressed metadata stream %q", want)
+			}
+		})
+	}
+}
+
+func TestPdfWriter_NoXMPMetadata(t *testing.T) {
+	doc := document.NewDocument()
+	if _, err := doc.AddPage(document.A4); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewPdfWriterFromWriter(&buf).WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "/Metadata") {
+		t.Error("PDF should not contain /Metadata without an XMP packet")
+	}
+}
@@ -0,0 +1,104 @@
+package writer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// checksumKey is the Info dictionary entry holding the document checksum.
+// The value is a hex string of the SHA-256 digest.
+const checksumKey = "/GXPDF_Checksum <"
+
+// infoRefPattern matches the /Info entry of a trailer or cross-reference
+// stream dictionary.
+var infoRefPattern = regexp.MustCompile(`/Info (\d+) 0 R`)
+
+// checksumHexLen is the length of the hex-encoded digest.
+const checksumHexLen = sha256.Size * 2
+
+// ErrNoChecksum is returned by VerifyChecksum for documents without a
+// checksum entry.
+var ErrNoChecksum = errors.New("no document checksum found")
+
+// SetChecksum reserves a checksum entry in the document Info dictionary,
+// which is written even if the document has no metadata. The entry is
+// written as a placeholder; call EmbedChecksum on the complete output to
+// fill it in. Must be called before writing.
+func (w *PdfWriter) SetChecksum(enabled bool) {
+	w.checksum = enabled
+}
+
+// checksumPlaceholder returns the Info entry written by SetChecksum.
+func checksumPlaceholder() string {
+	return " " + checksumKey + string(bytes.Repeat([]byte{'0'}, checksumHexLen)) + ">"
+}
+
+// findChecksum returns the offset of the checksum hex digits in pdf, or -1.
+//
+// The entry is looked up in the Info dictionary named by the last /Info
+// reference, which is the one in the trailer (or cross-reference stream),
+// so text elsewhere in the file cannot be mistaken for it.
+func findChecksum(pdf []byte) int {
+	refs := infoRefPattern.FindAllSubmatch(pdf, -1)
+	if len(refs) == 0 {
+		return -1
+	}
+	header := []byte(fmt.Sprintf("\n%s 0 obj", refs[len(refs)-1][1]))
+	start := bytes.Index(pdf, header)
+	if start < 0 {
+		return -1
+	}
+	end := bytes.Index(pdf[start:], []byte("endobj"))
+	if end < 0 {
+		return -1
+	}
+	i := bytes.Index(pdf[start:start+end], []byte(checksumKey))
+	if i < 0 {
+		return -1
+	}
+	at := start + i + len(checksumKey)
+	if len(pdf) < at+checksumHexLen+1 || pdf[at+checksumHexLen] != '>' {
+		return -1
+	}
+	return at
+}
+
+// computeChecksum hashes pdf, excluding the checksum digits at offset at.
+func computeChecksum(pdf []byte, at int) []byte {
+	h := sha256.New()
+	h.Write(pdf[:at])
+	h.Write(pdf[at+checksumHexLen:])
+	return h.Sum(nil)
+}
+
+// EmbedChecksum fills in the checksum placeholder of a complete PDF in place.
+//
+// The checksum is a SHA-256 digest over every byte of the file except the
+// 64 hex digits of the checksum itself, so the file can be verified
+// without knowing how it was written.
+func EmbedChecksum(pdf []byte) error {
+	at := findChecksum(pdf)
+	if at < 0 {
+		return ErrNoChecksum
+	}
+	hex.Encode(pdf[at:at+checksumHexLen], computeChecksum(pdf, at))
+	return nil
+}
+
+// VerifyChecksum reports whether the checksum embedded in pdf matches its
+// content. It returns ErrNoChecksum if pdf has no checksum entry.
+func VerifyChecksum(pdf []byte) (bool, error) {
+	at := findChecksum(pdf)
+	if at < 0 {
+		return false, ErrNoChecksum
+	}
+	stored := make([]byte, sha256.Size)
+	if _, err := hex.Decode(stored, pdf[at:at+checksumHexLen]); err != nil {
+		return false, nil
+	}
+	return bytes.Equal(stored, computeChecksum(pdf, at)), nil
+}
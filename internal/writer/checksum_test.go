@@ -0,0 +1,101 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+func TestEmbedChecksum(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n" +
+		"2 0 obj\n<< /Producer (gxpdf)" + checksumPlaceholder() + " >>\nendobj\n" +
+		"trailer\n<< /Root 1 0 R /Info 2 0 R >>\n%%EOF\n")
+
+	if err := EmbedChecksum(pdf); err != nil {
+		t.Fatalf("EmbedChecksum() error = %v", err)
+	}
+	if bytes.Contains(pdf, bytes.Repeat([]byte{'0'}, checksumHexLen)) {
+		t.Error("placeholder was not filled in")
+	}
+
+	ok, err := VerifyChecksum(pdf)
+	if err != nil || !ok {
+		t.Fatalf("VerifyChecksum() = %v, %v; want true, nil", ok, err)
+	}
+
+	pdf[len(pdf)-2] = 'X'
+	if ok, _ := VerifyChecksum(pdf); ok {
+		t.Error("VerifyChecksum() = true after modification")
+	}
+}
+
+func TestEmbedChecksum_OnlyInfo(t *testing.T) {
+	// A checksum entry outside the Info dictionary is not used.
+	pdf := []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog" + checksumPlaceholder() + " >>\nendobj\n" +
+		"2 0 obj\n<< /Producer (gxpdf) >>\nendobj\n" +
+		"trailer\n<< /Root 1 0 R /Info 2 0 R >>\n%%EOF\n")
+
+	if err := EmbedChecksum(pdf); !errors.Is(err, ErrNoChecksum) {
+		t.Errorf("EmbedChecksum() error = %v, want ErrNoChecksum", err)
+	}
+}
+
+func TestPdfWriter_ChecksumInInfo(t *testing.T) {
+	for _, objectStreams := range []bool{false, true} {
+		doc := document.NewDocument()
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+
+		var buf bytes.Buffer
+		w := NewPdfWriterFromWriter(&buf)
+		w.SetChecksum(true)
+		w.SetObjectStreams(objectStreams)
+		if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+			t.Fatalf("WriteWithAllContent() error = %v", err)
+		}
+		pdf := buf.Bytes()
+		if err := EmbedChecksum(pdf); err != nil {
+			t.Fatalf("object streams %v: EmbedChecksum() error = %v", objectStreams, err)
+		}
+
+		path := filepath.Join(t.TempDir(), "checksum.pdf")
+		if err := os.WriteFile(path, pdf, 0o600); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		r, err := parser.OpenPDF(path)
+		if err != nil {
+			t.Fatalf("OpenPDF() error = %v", err)
+		}
+		catalog, err := r.GetCatalog()
+		if err != nil {
+			t.Fatalf("GetCatalog() error = %v", err)
+		}
+		if catalog.Has("GXPDF_Checksum") {
+			t.Errorf("object streams %v: checksum should not be in the catalog", objectStreams)
+		}
+		info, ok := r.ResolveReferences(r.Trailer().Get("Info")).(*parser.Dictionary)
+		if !ok {
+			t.Fatalf("object streams %v: trailer /Info = %v, want a dictionary", objectStreams, r.Trailer().Get("Info"))
+		}
+		if sum := info.Get("GXPDF_Checksum"); sum == nil {
+			t.Errorf("object streams %v: Info has no /GXPDF_Checksum", objectStreams)
+		}
+		_ = r.Close()
+	}
+}
+
+func TestVerifyChecksum_Missing(t *testing.T) {
+	_, err := VerifyChecksum([]byte("%PDF-1.7\n%%EOF\n"))
+	if !errors.Is(err, ErrNoChecksum) {
+		t.Errorf("VerifyChecksum() error = %v, want ErrNoChecksum", err)
+	}
+	if err := EmbedChecksum([]byte("%PDF-1.7\n")); !errors.Is(err, ErrNoChecksum) {
+		t.Errorf("EmbedChecksum() error = %v, want ErrNoChecksum", err)
+	}
+}
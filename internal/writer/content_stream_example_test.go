@@ -21,8 +21,8 @@ func ExampleContentStreamWriter_simpleText() {
 	fmt.Println(csw.String())
 	// Output:
 	// BT
-	// /Helvetica 12.00 Tf
-	// 100.00 700.00 Td
+	// /Helvetica 12 Tf
+	// 100 700 Td
 	// (Hello World) Tj
 	// ET
 }
@@ -42,9 +42,9 @@ func ExampleContentStreamWriter_rectangle() {
 	fmt.Println(csw.String())
 	// Output:
 	// q
-	// 1.00 0.00 0.00 RG
-	// 2.00 w
-	// 50.00 50.00 200.00 100.00 re
+	// 1 0 0 RG
+	// 2 w
+	// 50 50 200 100 re
 	// S
 	// Q
 }
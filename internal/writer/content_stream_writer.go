@@ -28,6 +28,8 @@ import (
 type ContentStreamWriter struct {
 	buf         bytes.Buffer
 	compression CompressionLevel // Compression level (default: DefaultCompression)
+	style       ContentStyle     // Operator layout (default: ContentStyleDefault)
+	depth       int              // Block nesting depth (for ContentStylePretty)
 }
 
 // NewContentStreamWriter creates a new content stream writer.
@@ -58,10 +60,40 @@ func (csw *ContentStreamWriter) Len() int {
 // Reset clears the content stream buffer.
 func (csw *ContentStreamWriter) Reset() {
 	csw.buf.Reset()
+	csw.depth = 0
+}
+
+// SetStyle sets the operator layout used for subsequent operators.
+//
+// Example:
+//
+//	csw := NewContentStreamWriter()
+//	csw.SetStyle(ContentStyleCompact) // "q 1 0 0 RG ... Q"
+func (csw *ContentStreamWriter) SetStyle(style ContentStyle) {
+	csw.style = style
+}
+
+// Style returns the current operator layout.
+func (csw *ContentStreamWriter) Style() ContentStyle {
+	return csw.style
 }
 
 // writeOp writes an operator with optional operands.
 func (csw *ContentStreamWriter) writeOp(operands string, operator string) {
+	switch csw.style {
+	case ContentStyleCompact:
+		csw.writeCompactOp(operands, operator)
+		return
+	case ContentStylePretty:
+		if isBlockEnd(operator) && csw.depth > 0 {
+			csw.depth--
+		}
+		csw.buf.WriteString(strings.Repeat(prettyIndent, csw.depth))
+		if isBlockStart(operator) {
+			csw.depth++
+		}
+	}
+
 	if operands != "" {
 		csw.buf.WriteString(operands)
 		csw.buf.WriteString(" ")
@@ -70,6 +102,30 @@ func (csw *ContentStreamWriter) writeOp(operands string, operator string) {
 	csw.buf.WriteString("\n")
 }
 
+// writeCompactOp writes an operator in ContentStyleCompact layout.
+//
+// Operators are separated by a single space; the separator is omitted
+// where a delimiter already ends the previous token.
+func (csw *ContentStreamWriter) writeCompactOp(operands string, operator string) {
+	if operands != "" {
+		operands = compactOperands(operands)
+	}
+	first := operands
+	if first == "" {
+		first = operator
+	}
+	if n := csw.buf.Len(); n > 0 && !isDelimiter(csw.buf.Bytes()[n-1]) && !isDelimiter(first[0]) {
+		csw.buf.WriteByte(' ')
+	}
+	if operands != "" {
+		csw.buf.WriteString(operands)
+		if !isDelimiter(operands[len(operands)-1]) {
+			csw.buf.WriteByte(' ')
+		}
+	}
+	csw.buf.WriteString(operator)
+}
+
 // --- TEXT OPERATORS ---
 
 // BeginText begins a text object (BT operator).
@@ -94,7 +150,7 @@ func (csw *ContentStreamWriter) EndText() {
 //
 // Reference: PDF 1.7 Spec, Section 9.3 (Text State Parameters and Operators).
 func (csw *ContentStreamWriter) SetFont(fontName string, size float64) {
-	csw.writeOp(fmt.Sprintf("/%s %.2f", fontName, size), "Tf")
+	csw.writeOp(fmt.Sprintf("/%s %s", fontName, formatNumber(size)), "Tf")
 }
 
 // MoveTextPosition moves to the start of the next line (Td operator).
@@ -105,7 +161,7 @@ func (csw *ContentStreamWriter) SetFont(fontName string, size float64) {
 //
 // Reference: PDF 1.7 Spec, Section 9.4.2 (Text-Positioning Operators).
 func (csw *ContentStreamWriter) MoveTextPosition(tx, ty float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f", tx, ty), "Td")
+	csw.writeOp(formatNumbers(tx, ty), "Td")
 }
 
 // MoveTextPositionSetLeading moves to next line and sets leading (TD operator).
@@ -116,7 +172,7 @@ func (csw *ContentStreamWriter) MoveTextPosition(tx, ty float64) {
 //
 // Reference: PDF 1.7 Spec, Section 9.4.2 (Text-Positioning Operators).
 func (csw *ContentStreamWriter) MoveTextPositionSetLeading(tx, ty float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f", tx, ty), "TD")
+	csw.writeOp(formatNumbers(tx, ty), "TD")
 }
 
 // SetTextMatrix sets the text matrix (Tm operator).
@@ -129,7 +185,7 @@ func (csw *ContentStreamWriter) MoveTextPositionSetLeading(tx, ty float64) {
 //
 // Reference: PDF 1.7 Spec, Section 9.4.2 (Text-Positioning Operators).
 func (csw *ContentStreamWriter) SetTextMatrix(a, b, c, d, e, f float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f %.2f %.2f %.2f", a, b, c, d, e, f), "Tm")
+	csw.writeOp(formatMatrix(a, b, c, d, e, f), "Tm")
 }
 
 // ShowText shows a text string (Tj operator).
@@ -179,7 +235,45 @@ func (csw *ContentStreamWriter) ShowTextNextLine(text string) {
 //
 // Reference: PDF 1.7 Spec, Section 9.3.5 (Text State Parameters).
 func (csw *ContentStreamWriter) SetLeading(leading float64) {
-	csw.writeOp(fmt.Sprintf("%.2f", leading), "TL")
+	csw.writeOp(formatNumber(leading), "TL")
+}
+
+// SetCharSpacing sets the character spacing (Tc operator).
+//
+// The spacing is added after every glyph shown afterwards.
+//
+// Parameters:
+//   - spacing: Extra space per glyph in unscaled text space units
+//
+// Reference: PDF 1.7 Spec, Section 9.3.2 (Character Spacing).
+func (csw *ContentStreamWriter) SetCharSpacing(spacing float64) {
+	csw.writeOp(formatNumber(spacing), "Tc")
+}
+
+// SetWordSpacing sets the word spacing (Tw operator).
+//
+// The spacing is added to every single-byte space character (code 32)
+// shown afterwards; it has no effect on multi-byte encodings.
+//
+// Parameters:
+//   - spacing: Extra space per word gap in unscaled text space units
+//
+// Reference: PDF 1.7 Spec, Section 9.3.3 (Word Spacing).
+func (csw *ContentStreamWriter) SetWordSpacing(spacing float64) {
+	csw.writeOp(formatNumber(spacing), "Tw")
+}
+
+// SetTextRise sets the text rise (Ts operator).
+//
+// The rise moves the baseline up (positive) or down (negative) for
+// superscripts and subscripts.
+//
+// Parameters:
+//   - rise: Baseline shift in unscaled text space units
+//
+// Reference: PDF 1.7 Spec, Section 9.3.7 (Text Rise).
+func (csw *ContentStreamWriter) SetTextRise(rise float64) {
+	csw.writeOp(formatNumber(rise), "Ts")
 }
 
 // MoveToNextLine moves to the start of the next line (T* operator).
@@ -198,7 +292,7 @@ func (csw *ContentStreamWriter) MoveToNextLine() {
 //
 // Reference: PDF 1.7 Spec, Section 8.5.2 (Path Construction Operators).
 func (csw *ContentStreamWriter) MoveTo(x, y float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f", x, y), "m")
+	csw.writeOp(formatNumbers(x, y), "m")
 }
 
 // LineTo appends a straight line segment (l operator).
@@ -208,7 +302,7 @@ func (csw *ContentStreamWriter) MoveTo(x, y float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.5.2 (Path Construction Operators).
 func (csw *ContentStreamWriter) LineTo(x, y float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f", x, y), "l")
+	csw.writeOp(formatNumbers(x, y), "l")
 }
 
 // CurveTo appends a cubic Bezier curve (c operator).
@@ -220,7 +314,7 @@ func (csw *ContentStreamWriter) LineTo(x, y float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.5.2 (Path Construction Operators).
 func (csw *ContentStreamWriter) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f %.2f %.2f %.2f", x1, y1, x2, y2, x3, y3), "c")
+	csw.writeOp(formatNumbers(x1, y1, x2, y2, x3, y3), "c")
 }
 
 // Rectangle appends a rectangle (re operator).
@@ -231,7 +325,7 @@ func (csw *ContentStreamWriter) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.5.2 (Path Construction Operators).
 func (csw *ContentStreamWriter) Rectangle(x, y, width, height float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f %.2f", x, y, width, height), "re")
+	csw.writeOp(formatNumbers(x, y, width, height), "re")
 }
 
 // ClosePath closes the current subpath (h operator).
@@ -343,7 +437,7 @@ func (csw *ContentStreamWriter) RestoreState() {
 //
 // Reference: PDF 1.7 Spec, Section 8.4.4 (Coordinate Systems).
 func (csw *ContentStreamWriter) ConcatMatrix(a, b, c, d, e, f float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f %.2f %.2f %.2f", a, b, c, d, e, f), "cm")
+	csw.writeOp(formatMatrix(a, b, c, d, e, f), "cm")
 }
 
 // SetLineWidth sets the line width (w operator).
@@ -353,7 +447,7 @@ func (csw *ContentStreamWriter) ConcatMatrix(a, b, c, d, e, f float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.4.3 (Graphics State Parameters).
 func (csw *ContentStreamWriter) SetLineWidth(width float64) {
-	csw.writeOp(fmt.Sprintf("%.2f", width), "w")
+	csw.writeOp(formatNumber(width), "w")
 }
 
 // SetLineCap sets the line cap style (J operator).
@@ -383,7 +477,7 @@ func (csw *ContentStreamWriter) SetLineJoin(style int) {
 //
 // Reference: PDF 1.7 Spec, Section 8.4.3 (Graphics State Parameters).
 func (csw *ContentStreamWriter) SetMiterLimit(limit float64) {
-	csw.writeOp(fmt.Sprintf("%.2f", limit), "M")
+	csw.writeOp(formatNumber(limit), "M")
 }
 
 // SetDashPattern sets the line dash pattern (d operator).
@@ -394,11 +488,7 @@ func (csw *ContentStreamWriter) SetMiterLimit(limit float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.4.3 (Graphics State Parameters).
 func (csw *ContentStreamWriter) SetDashPattern(dashArray []float64, dashPhase float64) {
-	parts := make([]string, 0, len(dashArray))
-	for _, v := range dashArray {
-		parts = append(parts, fmt.Sprintf("%.2f", v))
-	}
-	csw.writeOp(fmt.Sprintf("[%s] %.2f", strings.Join(parts, " "), dashPhase), "d")
+	csw.writeOp(fmt.Sprintf("[%s] %s", formatNumbers(dashArray...), formatNumber(dashPhase)), "d")
 }
 
 // SetStrokeColorRGB sets the stroke color in RGB (RG operator).
@@ -408,7 +498,7 @@ func (csw *ContentStreamWriter) SetDashPattern(dashArray []float64, dashPhase fl
 //
 // Reference: PDF 1.7 Spec, Section 8.6.8 (Color Operators).
 func (csw *ContentStreamWriter) SetStrokeColorRGB(r, g, b float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f", r, g, b), "RG")
+	csw.writeOp(formatNumbers(r, g, b), "RG")
 }
 
 // SetFillColorRGB sets the fill color in RGB (rg operator).
@@ -418,7 +508,7 @@ func (csw *ContentStreamWriter) SetStrokeColorRGB(r, g, b float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.6.8 (Color Operators).
 func (csw *ContentStreamWriter) SetFillColorRGB(r, g, b float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f", r, g, b), "rg")
+	csw.writeOp(formatNumbers(r, g, b), "rg")
 }
 
 // SetStrokeColorGray sets the stroke color in grayscale (G operator).
@@ -428,7 +518,7 @@ func (csw *ContentStreamWriter) SetFillColorRGB(r, g, b float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.6.8 (Color Operators).
 func (csw *ContentStreamWriter) SetStrokeColorGray(gray float64) {
-	csw.writeOp(fmt.Sprintf("%.2f", gray), "G")
+	csw.writeOp(formatNumber(gray), "G")
 }
 
 // SetFillColorGray sets the fill color in grayscale (g operator).
@@ -438,7 +528,7 @@ func (csw *ContentStreamWriter) SetStrokeColorGray(gray float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.6.8 (Color Operators).
 func (csw *ContentStreamWriter) SetFillColorGray(gray float64) {
-	csw.writeOp(fmt.Sprintf("%.2f", gray), "g")
+	csw.writeOp(formatNumber(gray), "g")
 }
 
 // SetStrokeColorCMYK sets the stroke color in CMYK (K operator).
@@ -448,7 +538,7 @@ func (csw *ContentStreamWriter) SetFillColorGray(gray float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.6.8 (Color Operators).
 func (csw *ContentStreamWriter) SetStrokeColorCMYK(c, m, y, k float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f %.2f", c, m, y, k), "K")
+	csw.writeOp(formatNumbers(c, m, y, k), "K")
 }
 
 // SetFillColorCMYK sets the fill color in CMYK (k operator).
@@ -458,7 +548,7 @@ func (csw *ContentStreamWriter) SetStrokeColorCMYK(c, m, y, k float64) {
 //
 // Reference: PDF 1.7 Spec, Section 8.6.8 (Color Operators).
 func (csw *ContentStreamWriter) SetFillColorCMYK(c, m, y, k float64) {
-	csw.writeOp(fmt.Sprintf("%.2f %.2f %.2f %.2f", c, m, y, k), "k")
+	csw.writeOp(formatNumbers(c, m, y, k), "k")
 }
 
 // SetGraphicsState applies an extended graphics state (gs operator).
@@ -481,6 +571,50 @@ func (csw *ContentStreamWriter) SetGraphicsState(name string) {
 	csw.writeOp(fmt.Sprintf("/%s", name), "gs")
 }
 
+// PaintShading paints a shading over the current clipping region (sh operator).
+//
+// Parameters:
+//   - name: Shading resource name (e.g., "Sh1")
+//
+// Example:
+//
+//	csw.SaveState()
+//	csw.Rectangle(100, 100, 200, 50)
+//	csw.Clip()
+//	csw.EndPath()
+//	csw.PaintShading("Sh1")
+//	csw.RestoreState()
+//
+// Reference: PDF 1.7 Spec, Section 8.7.4.2 (Shading Operator).
+func (csw *ContentStreamWriter) PaintShading(name string) {
+	csw.writeOp(fmt.Sprintf("/%s", name), "sh")
+}
+
+// --- MARKED CONTENT ---
+
+// BeginMarkedContent begins a marked-content sequence with a marked-content
+// ID (BDC operator), linking the content to a structure element.
+//
+// Parameters:
+//   - tag: Structure type (e.g., "Figure", "P")
+//   - mcid: Marked-content ID, unique within the page
+//
+// Example:
+//
+//	csw.BeginMarkedContent("Figure", 0)  // /Figure <</MCID 0>> BDC
+//	// ... draw the figure ...
+//	csw.EndMarkedContent()               // EMC
+//
+// Reference: PDF 1.7 Spec, Section 14.6 (Marked Content).
+func (csw *ContentStreamWriter) BeginMarkedContent(tag string, mcid int) {
+	csw.writeOp(fmt.Sprintf("/%s <</MCID %d>>", tag, mcid), "BDC")
+}
+
+// EndMarkedContent ends a marked-content sequence (EMC operator).
+func (csw *ContentStreamWriter) EndMarkedContent() {
+	csw.writeOp("", "EMC")
+}
+
 // --- COMPRESSION ---
 
 // SetCompression sets the compression level for this content stream.
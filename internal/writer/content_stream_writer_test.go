@@ -27,28 +27,28 @@ func TestContentStreamWriter_TextOperators(t *testing.T) {
 			build: func(csw *ContentStreamWriter) {
 				csw.SetFont("F1", 12.0)
 			},
-			expected: "/F1 12.00 Tf\n",
+			expected: "/F1 12 Tf\n",
 		},
 		{
 			name: "MoveTextPosition",
 			build: func(csw *ContentStreamWriter) {
 				csw.MoveTextPosition(100.0, 700.0)
 			},
-			expected: "100.00 700.00 Td\n",
+			expected: "100 700 Td\n",
 		},
 		{
 			name: "MoveTextPositionSetLeading",
 			build: func(csw *ContentStreamWriter) {
 				csw.MoveTextPositionSetLeading(0.0, -14.0)
 			},
-			expected: "0.00 -14.00 TD\n",
+			expected: "0 -14 TD\n",
 		},
 		{
 			name: "SetTextMatrix",
 			build: func(csw *ContentStreamWriter) {
 				csw.SetTextMatrix(1.0, 0.0, 0.0, 1.0, 50.0, 750.0)
 			},
-			expected: "1.00 0.00 0.00 1.00 50.00 750.00 Tm\n",
+			expected: "1 0 0 1 50 750 Tm\n",
 		},
 		{
 			name: "ShowText",
@@ -76,7 +76,7 @@ func TestContentStreamWriter_TextOperators(t *testing.T) {
 			build: func(csw *ContentStreamWriter) {
 				csw.SetLeading(14.0)
 			},
-			expected: "14.00 TL\n",
+			expected: "14 TL\n",
 		},
 		{
 			name: "MoveToNextLine",
@@ -94,7 +94,7 @@ func TestContentStreamWriter_TextOperators(t *testing.T) {
 				csw.ShowText("Hello World")
 				csw.EndText()
 			},
-			expected: "BT\n/F1 12.00 Tf\n100.00 700.00 Td\n(Hello World) Tj\nET\n",
+			expected: "BT\n/F1 12 Tf\n100 700 Td\n(Hello World) Tj\nET\n",
 		},
 	}
 
@@ -123,28 +123,28 @@ func TestContentStreamWriter_GraphicsOperators(t *testing.T) {
 			build: func(csw *ContentStreamWriter) {
 				csw.MoveTo(100.0, 100.0)
 			},
-			expected: "100.00 100.00 m\n",
+			expected: "100 100 m\n",
 		},
 		{
 			name: "LineTo",
 			build: func(csw *ContentStreamWriter) {
 				csw.LineTo(200.0, 200.0)
 			},
-			expected: "200.00 200.00 l\n",
+			expected: "200 200 l\n",
 		},
 		{
 			name: "CurveTo",
 			build: func(csw *ContentStreamWriter) {
 				csw.CurveTo(100.0, 150.0, 150.0, 200.0, 200.0, 200.0)
 			},
-			expected: "100.00 150.00 150.00 200.00 200.00 200.00 c\n",
+			expected: "100 150 150 200 200 200 c\n",
 		},
 		{
 			name: "Rectangle",
 			build: func(csw *ContentStreamWriter) {
 				csw.Rectangle(50.0, 50.0, 200.0, 100.0)
 			},
-			expected: "50.00 50.00 200.00 100.00 re\n",
+			expected: "50 50 200 100 re\n",
 		},
 		{
 			name: "ClosePath",
@@ -209,7 +209,7 @@ func TestContentStreamWriter_GraphicsOperators(t *testing.T) {
 				csw.LineTo(200.0, 200.0)
 				csw.Stroke()
 			},
-			expected: "100.00 100.00 m\n200.00 200.00 l\nS\n",
+			expected: "100 100 m\n200 200 l\nS\n",
 		},
 		{
 			name: "Complete rectangle example",
@@ -217,7 +217,7 @@ func TestContentStreamWriter_GraphicsOperators(t *testing.T) {
 				csw.Rectangle(50.0, 50.0, 200.0, 100.0)
 				csw.Fill()
 			},
-			expected: "50.00 50.00 200.00 100.00 re\nf\n",
+			expected: "50 50 200 100 re\nf\n",
 		},
 	}
 
@@ -254,7 +254,7 @@ func TestContentStreamWriter_GraphicsStateOperators(t *testing.T) {
 			build: func(csw *ContentStreamWriter) {
 				csw.ConcatMatrix(2.0, 0.0, 0.0, 2.0, 0.0, 0.0)
 			},
-			expected: "2.00 0.00 0.00 2.00 0.00 0.00 cm\n",
+			expected: "2 0 0 2 0 0 cm\n",
 		},
 		{
 			name: "SetLineWidth",
@@ -282,35 +282,35 @@ func TestContentStreamWriter_GraphicsStateOperators(t *testing.T) {
 			build: func(csw *ContentStreamWriter) {
 				csw.SetMiterLimit(10.0)
 			},
-			expected: "10.00 M\n",
+			expected: "10 M\n",
 		},
 		{
 			name: "SetDashPattern empty",
 			build: func(csw *ContentStreamWriter) {
 				csw.SetDashPattern([]float64{}, 0.0)
 			},
-			expected: "[] 0.00 d\n",
+			expected: "[] 0 d\n",
 		},
 		{
 			name: "SetDashPattern dashed",
 			build: func(csw *ContentStreamWriter) {
 				csw.SetDashPattern([]float64{3.0, 1.0}, 0.0)
 			},
-			expected: "[3.00 1.00] 0.00 d\n",
+			expected: "[3 1] 0 d\n",
 		},
 		{
 			name: "SetStrokeColorRGB",
 			build: func(csw *ContentStreamWriter) {
 				csw.SetStrokeColorRGB(1.0, 0.0, 0.0)
 			},
-			expected: "1.00 0.00 0.00 RG\n",
+			expected: "1 0 0 RG\n",
 		},
 		{
 			name: "SetFillColorRGB",
 			build: func(csw *ContentStreamWriter) {
 				csw.SetFillColorRGB(0.0, 1.0, 0.0)
 			},
-			expected: "0.00 1.00 0.00 rg\n",
+			expected: "0 1 0 rg\n",
 		},
 		{
 			name: "SetStrokeColorGray",
@@ -331,14 +331,14 @@ func TestContentStreamWriter_GraphicsStateOperators(t *testing.T) {
 			build: func(csw *ContentStreamWriter) {
 				csw.SetStrokeColorCMYK(0.0, 1.0, 1.0, 0.0)
 			},
-			expected: "0.00 1.00 1.00 0.00 K\n",
+			expected: "0 1 1 0 K\n",
 		},
 		{
 			name: "SetFillColorCMYK",
 			build: func(csw *ContentStreamWriter) {
 				csw.SetFillColorCMYK(1.0, 0.0, 0.0, 0.0)
 			},
-			expected: "1.00 0.00 0.00 0.00 k\n",
+			expected: "1 0 0 0 k\n",
 		},
 		{
 			name: "Complete state example",
@@ -350,7 +350,7 @@ func TestContentStreamWriter_GraphicsStateOperators(t *testing.T) {
 				csw.Stroke()
 				csw.RestoreState()
 			},
-			expected: "q\n1.00 0.00 0.00 RG\n2.00 w\n100.00 100.00 50.00 50.00 re\nS\nQ\n",
+			expected: "q\n1 0 0 RG\n2 w\n100 100 50 50 re\nS\nQ\n",
 		},
 	}
 
@@ -393,13 +393,13 @@ func TestContentStreamWriter_CombinedOperations(t *testing.T) {
 			},
 			contains: []string{
 				"q\n",
-				"0.00 0.00 1.00 RG\n",
-				"50.00 50.00 200.00 100.00 re\n",
+				"0 0 1 RG\n",
+				"50 50 200 100 re\n",
 				"S\n",
 				"Q\n",
 				"BT\n",
-				"/Helvetica 14.00 Tf\n",
-				"60.00 90.00 Td\n",
+				"/Helvetica 14 Tf\n",
+				"60 90 Td\n",
 				"(Inside Box) Tj\n",
 				"ET\n",
 			},
@@ -420,9 +420,9 @@ func TestContentStreamWriter_CombinedOperations(t *testing.T) {
 			},
 			contains: []string{
 				"BT\n",
-				"/Times-Roman 12.00 Tf\n",
-				"14.00 TL\n",
-				"50.00 750.00 Td\n",
+				"/Times-Roman 12 Tf\n",
+				"14 TL\n",
+				"50 750 Td\n",
 				"(Line 1) Tj\n",
 				"T*\n",
 				"(Line 2) Tj\n",
@@ -449,13 +449,13 @@ func TestContentStreamWriter_CombinedOperations(t *testing.T) {
 			},
 			contains: []string{
 				"q\n",
-				"0.80 0.80 1.00 rg\n",
-				"0.00 0.00 0.50 RG\n",
+				"0.80 0.80 1 rg\n",
+				"0 0 0.50 RG\n",
 				"1.50 w\n",
-				"100.00 100.00 m\n",
-				"200.00 100.00 l\n",
-				"200.00 200.00 l\n",
-				"100.00 200.00 l\n",
+				"100 100 m\n",
+				"200 100 l\n",
+				"200 200 l\n",
+				"100 200 l\n",
 				"h\n",
 				"B\n",
 				"Q\n",
@@ -698,3 +698,92 @@ func BenchmarkContentStreamWriter_Compression(b *testing.B) {
 		}
 	}
 }
+
+// buildStyleSample writes a representative mix of graphics and text.
+func buildStyleSample(csw *ContentStreamWriter) {
+	csw.SaveState()
+	csw.SetStrokeColorRGB(1.0, 0.5, 0.0)
+	csw.MoveTo(100.0, 100.0)
+	csw.LineTo(200.25, -0.5)
+	csw.Stroke()
+	csw.RestoreState()
+	csw.BeginText()
+	csw.SetFont("F1", 12.0)
+	csw.MoveTextPosition(72, 720)
+	csw.ShowText("Total: 10 (net)")
+	csw.EndText()
+}
+
+// TestContentStreamWriter_Styles tests operator layout for each style.
+func TestContentStreamWriter_Styles(t *testing.T) {
+	tests := []struct {
+		style    ContentStyle
+		expected string
+	}{
+		{
+			style: ContentStyleDefault,
+			expected: "q\n1 0.50 0 RG\n100 100 m\n200.25 -0.50 l\nS\nQ\n" +
+				"BT\n/F1 12 Tf\n72 720 Td\n(Total: 10 \\(net\\)) Tj\nET\n",
+		},
+		{
+			style: ContentStylePretty,
+			expected: "q\n  1 0.50 0 RG\n  100 100 m\n  200.25 -0.50 l\n  S\nQ\n" +
+				"BT\n  /F1 12 Tf\n  72 720 Td\n  (Total: 10 \\(net\\)) Tj\nET\n",
+		},
+		{
+			style: ContentStyleCompact,
+			expected: "q 1 .5 0 RG 100 100 m 200.25 -.5 l S Q " +
+				"BT/F1 12 Tf 72 720 Td(Total: 10 \\(net\\))Tj ET",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style.String(), func(t *testing.T) {
+			csw := NewContentStreamWriter()
+			csw.SetStyle(tt.style)
+			buildStyleSample(csw)
+			if got := csw.String(); got != tt.expected {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCompactOperands tests number and delimiter compaction.
+func TestCompactOperands(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1.00 0.00 -0.00", "1 0 0"},
+		{"0.50 -0.25 10.10", ".5 -.25 10.1"},
+		{"/F1 12", "/F1 12"},
+		{"[3 2] 0", "[3 2]0"},
+		{"(1.50 \\) 2)", "(1.50 \\) 2)"},
+		{"<00410042>", "<00410042>"},
+		{"/GS1", "/GS1"},
+	}
+
+	for _, tt := range tests {
+		if got := compactOperands(tt.in); got != tt.want {
+			t.Errorf("compactOperands(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// BenchmarkContentStreamWriter_Styles compares output size per style.
+func BenchmarkContentStreamWriter_Styles(b *testing.B) {
+	for _, style := range []ContentStyle{ContentStyleDefault, ContentStylePretty, ContentStyleCompact} {
+		b.Run(style.String(), func(b *testing.B) {
+			size := 0
+			for i := 0; i < b.N; i++ {
+				csw := NewContentStreamWriter()
+				csw.SetStyle(style)
+				for j := 0; j < 100; j++ {
+					buildStyleSample(csw)
+				}
+				size = csw.Len()
+			}
+			b.ReportMetric(float64(size), "bytes/stream")
+		})
+	}
+}
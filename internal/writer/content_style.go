@@ -0,0 +1,183 @@
+package writer
+
+import (
+	"strings"
+)
+
+// ContentStyle controls the whitespace layout of content stream operators.
+//
+// The style affects only formatting; all styles produce equivalent page
+// content. Use ContentStylePretty to debug uncompressed streams and
+// ContentStyleCompact to minimize output size.
+type ContentStyle int
+
+const (
+	// ContentStyleDefault writes one operator per line (the historic format).
+	ContentStyleDefault ContentStyle = iota
+
+	// ContentStylePretty writes one operator per line and indents the
+	// contents of q/Q, BT/ET and marked-content blocks by two spaces.
+	//
+	// Example:
+	//
+	//	q
+	//	  1 0 0 RG
+	//	  100 100 m
+	//	  200 200 l
+	//	  S
+	//	Q
+	ContentStylePretty
+
+	// ContentStyleCompact separates operators with a single space, drops
+	// whitespace next to string and array delimiters and writes numbers
+	// without redundant zeros ("1.50" becomes "1.5", "0.25" becomes ".25").
+	//
+	// Example:
+	//
+	//	q 1 0 0 RG 100 100 m 200 200 l S Q
+	ContentStyleCompact
+)
+
+// prettyIndent is the indentation unit of ContentStylePretty.
+const prettyIndent = "  "
+
+// String returns the style name.
+func (s ContentStyle) String() string {
+	switch s {
+	case ContentStylePretty:
+		return "Pretty"
+	case ContentStyleCompact:
+		return "Compact"
+	default:
+		return "Default"
+	}
+}
+
+// isBlockStart reports whether an operator opens an indented block.
+func isBlockStart(operator string) bool {
+	return operator == "q" || operator == "BT" || operator == "BMC" || operator == "BDC"
+}
+
+// isBlockEnd reports whether an operator closes an indented block.
+func isBlockEnd(operator string) bool {
+	return operator == "Q" || operator == "ET" || operator == "EMC"
+}
+
+// compactOperands shortens the numbers in an operand list.
+//
+// Literal strings are copied verbatim, since their bytes are text rather
+// than operands.
+func compactOperands(operands string) string {
+	out := make([]byte, 0, len(operands))
+
+	for i := 0; i < len(operands); {
+		c := operands[i]
+		switch {
+		case c == '(':
+			end := literalStringEnd(operands, i)
+			out = append(out, operands[i:end]...)
+			i = end
+		case c == ' ':
+			// Spaces around delimiters are redundant.
+			var prev, next byte
+			if len(out) > 0 {
+				prev = out[len(out)-1]
+			}
+			if i+1 < len(operands) {
+				next = operands[i+1]
+			}
+			if prev != 0 && !isDelimiter(prev) && !isDelimiter(next) && next != ' ' {
+				out = append(out, ' ')
+			}
+			i++
+		case isDelimiter(c):
+			out = append(out, c)
+			i++
+		default:
+			end := i
+			for end < len(operands) && operands[end] != ' ' && !isDelimiter(operands[end]) {
+				end++
+			}
+			out = append(out, compactNumber(operands[i:end])...)
+			i = end
+		}
+	}
+
+	return string(out)
+}
+
+// literalStringEnd returns the index just past the literal string starting
+// at start, honoring backslash escapes and balanced parentheses.
+func literalStringEnd(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+// compactNumber strips redundant zeros from a real number token.
+//
+// Tokens that are not plain decimal reals (names, integers) are returned
+// unchanged.
+func compactNumber(tok string) string {
+	dot := strings.IndexByte(tok, '.')
+	if dot < 0 || !isDecimalReal(tok) {
+		return tok
+	}
+
+	tok = strings.TrimRight(tok, "0")
+	tok = strings.TrimSuffix(tok, ".")
+
+	neg := strings.HasPrefix(tok, "-")
+	digits := strings.TrimPrefix(tok, "-")
+	if strings.HasPrefix(digits, "0.") {
+		digits = digits[1:]
+	}
+	if digits == "" || digits == "0" {
+		return "0"
+	}
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// isDecimalReal reports whether tok is an optionally signed decimal number.
+func isDecimalReal(tok string) bool {
+	tok = strings.TrimPrefix(tok, "-")
+	if tok == "" || tok == "." {
+		return false
+	}
+	dots := 0
+	for i := 0; i < len(tok); i++ {
+		switch {
+		case tok[i] == '.':
+			dots++
+		case tok[i] < '0' || tok[i] > '9':
+			return false
+		}
+	}
+	return dots == 1
+}
+
+// isDelimiter reports whether c is a PDF delimiter character.
+//
+// Reference: PDF 1.7 Specification, Section 7.2.2 (Character Set).
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
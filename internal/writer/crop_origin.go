@@ -0,0 +1,99 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// offsetContent wraps a page content stream in a translation to the page's
+// content origin (see document.Page.SetCropBoxOrigin).
+//
+// The stream is returned unchanged when the origin is (0, 0).
+func offsetContent(content []byte, page *document.Page) []byte {
+	ox, oy := page.ContentOrigin()
+	if ox == 0 && oy == 0 {
+		return content
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(content) + 48)
+	buf.WriteString(fmt.Sprintf("q\n1 0 0 1 %s cm\n", formatNumbers(ox, oy)))
+	buf.Write(content)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("Q\n")
+	return buf.Bytes()
+}
+
+// offsetRect translates an annotation rectangle by (dx, dy).
+func offsetRect(rect [4]float64, dx, dy float64) [4]float64 {
+	return [4]float64{rect[0] + dx, rect[1] + dy, rect[2] + dx, rect[3] + dy}
+}
+
+// offsetLinkAnnotations returns copies of the annotations translated by
+// (dx, dy). The page's own annotations are left untouched so the
+// document can be written more than once.
+func offsetLinkAnnotations(annots []*document.LinkAnnotation, dx, dy float64) []*document.LinkAnnotation {
+	out := make([]*document.LinkAnnotation, len(annots))
+	for i, a := range annots {
+		c := *a
+		c.Rect = offsetRect(a.Rect, dx, dy)
+		out[i] = &c
+	}
+	return out
+}
+
+// offsetTextAnnotations returns copies of the annotations translated by (dx, dy).
+func offsetTextAnnotations(annots []*document.TextAnnotation, dx, dy float64) []*document.TextAnnotation {
+	out := make([]*document.TextAnnotation, len(annots))
+	for i, a := range annots {
+		c := *a
+		c.Rect = offsetRect(a.Rect, dx, dy)
+		out[i] = &c
+	}
+	return out
+}
+
+// offsetMarkupAnnotations returns copies of the annotations translated by
+// (dx, dy), including their QuadPoints.
+func offsetMarkupAnnotations(annots []*document.MarkupAnnotation, dx, dy float64) []*document.MarkupAnnotation {
+	out := make([]*document.MarkupAnnotation, len(annots))
+	for i, a := range annots {
+		c := *a
+		c.Rect = offsetRect(a.Rect, dx, dy)
+		c.QuadPoints = make([][8]float64, len(a.QuadPoints))
+		for j, q := range a.QuadPoints {
+			for k := 0; k < 8; k += 2 {
+				q[k] += dx
+				q[k+1] += dy
+			}
+			c.QuadPoints[j] = q
+		}
+		out[i] = &c
+	}
+	return out
+}
+
+// offsetStampAnnotations returns copies of the annotations translated by (dx, dy).
+func offsetStampAnnotations(annots []*document.StampAnnotation, dx, dy float64) []*document.StampAnnotation {
+	out := make([]*document.StampAnnotation, len(annots))
+	for i, a := range annots {
+		c := *a
+		c.Rect = offsetRect(a.Rect, dx, dy)
+		out[i] = &c
+	}
+	return out
+}
+
+// offsetFormFields returns copies of the widgets translated by (dx, dy).
+// Parent links are preserved so radio groups still share one parent field.
+func offsetFormFields(fields []*document.FormField, dx, dy float64) []*document.FormField {
+	out := make([]*document.FormField, len(fields))
+	for i, f := range fields {
+		out[i] = f.Translated(dx, dy)
+	}
+	return out
+}
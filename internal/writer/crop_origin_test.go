@@ -0,0 +1,72 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/models/types"
+)
+
+func newCroppedPage(t *testing.T) *document.Page {
+	t.Helper()
+	page := document.NewPage(0, document.A4)
+	if err := page.SetCropBox(types.MustRectangle(30, 40, 545, 792)); err != nil {
+		t.Fatalf("SetCropBox() error = %v", err)
+	}
+	return page
+}
+
+func TestOffsetContent(t *testing.T) {
+	page := newCroppedPage(t)
+	content := []byte("BT /F1 12 Tf 10 10 Td (Hi) Tj ET")
+
+	if got := offsetContent(content, page); string(got) != string(content) {
+		t.Errorf("offsetContent() without crop origin = %q, want unchanged", got)
+	}
+
+	page.SetCropBoxOrigin(true)
+	got := string(offsetContent(content, page))
+	if !strings.HasPrefix(got, "q\n1 0 0 1 30 40 cm\n") {
+		t.Errorf("offsetContent() = %q, want leading translation", got)
+	}
+	if !strings.HasSuffix(got, "ET\nQ\n") {
+		t.Errorf("offsetContent() = %q, want trailing Q", got)
+	}
+}
+
+func TestOffsetContent_NoCropBox(t *testing.T) {
+	page := document.NewPage(0, document.A4)
+	page.SetCropBoxOrigin(true)
+
+	content := []byte("0 0 m 10 10 l S\n")
+	if got := offsetContent(content, page); string(got) != string(content) {
+		t.Errorf("offsetContent() = %q, want unchanged", got)
+	}
+}
+
+func TestWriteAllAnnotations_CropBoxOrigin(t *testing.T) {
+	page := newCroppedPage(t)
+	page.SetCropBoxOrigin(true)
+
+	link := document.NewLinkAnnotation([4]float64{10, 20, 110, 40}, "https://example.com")
+	if err := page.AddLinkAnnotation(link); err != nil {
+		t.Fatalf("AddLinkAnnotation() error = %v", err)
+	}
+
+	w := &PdfWriter{nextObjNum: 1}
+	objs, _, err := w.WriteAllAnnotations(page)
+	if err != nil {
+		t.Fatalf("WriteAllAnnotations() error = %v", err)
+	}
+	if len(objs) != 1 {
+		t.Fatalf("len(objs) = %d, want 1", len(objs))
+	}
+
+	if data := string(objs[0].Data); !strings.Contains(data, "/Rect [40 60 140 80]") {
+		t.Errorf("annotation should be translated to the crop box, got: %s", data)
+	}
+	if link.Rect != [4]float64{10, 20, 110, 40} {
+		t.Errorf("page annotation was modified: %v", link.Rect)
+	}
+}
@@ -0,0 +1,229 @@
+package writer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/parser"
+	"github.com/coregx/gxpdf/internal/security"
+)
+
+// Encryption is how WriteObjects output is encrypted: the objects are
+// encrypted with Encrypt before they are serialized, and Dict and ID go
+// in the trailer.
+type Encryption struct {
+	Encryptor *security.StandardEncryptor
+	Dict      *parser.Dictionary // Encryption dictionary, written unencrypted
+	ID        string             // Trailer /ID array (the file key depends on it)
+	Metadata  bool               // Whether metadata streams are encrypted
+	Version   string             // Minimum PDF version for the algorithm
+}
+
+// NewEncryption creates the encryption for config with a new random file
+// identifier (config.FileID is ignored).
+func NewEncryption(config security.StandardEncryptionConfig) (*Encryption, error) {
+	config.FileID = make([]byte, 16)
+	if _, err := rand.Read(config.FileID); err != nil {
+		return nil, fmt.Errorf("failed to generate file identifier: %w", err)
+	}
+	encryptor, err := security.NewStandardEncryptor(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	dict := encryptor.Dict()
+	version := map[int]string{1: "1.1", 2: "1.4", 4: "1.6", 5: "2.0"}[dict.V]
+	id := hex.EncodeToString(config.FileID)
+	return &Encryption{
+		Encryptor: encryptor,
+		Dict:      encryptionDictionary(dict),
+		ID:        fmt.Sprintf("[<%s> <%s>]", id, id),
+		Metadata:  true,
+		Version:   version,
+	}, nil
+}
+
+// PDFPermissions returns the /P value for permission flags: the flags
+// with the reserved bits 7-8 and 13-32 set.
+//
+// Reference: PDF 1.7 Specification, Section 7.6.3.2, Table 22.
+func PDFPermissions(perms security.Permission) int32 {
+	return int32(perms&security.PermissionAll) | 0xC0 | ^int32(0xFFF) //nolint:gosec // Flags fit in 12 bits.
+}
+
+// encryptionDictionary returns the encryption dictionary for dict. V 4
+// and 5 use a single crypt filter, StdCF, for strings and streams.
+//
+// Reference: PDF 1.7 Specification, Section 7.6.3.2, Table 21 and
+// Section 7.6.5 (Crypt Filters).
+func encryptionDictionary(dict *security.EncryptionDict) *parser.Dictionary {
+	d := parser.NewDictionary()
+	d.SetName("Filter", dict.Filter)
+	d.SetInteger("V", int64(dict.V))
+	d.SetInteger("R", int64(dict.R))
+	d.SetInteger("Length", int64(dict.Length))
+	d.SetInteger("P", int64(dict.P))
+	d.Set("O", parser.NewHexString(string(dict.O)))
+	d.Set("U", parser.NewHexString(string(dict.U)))
+
+	if dict.V >= 4 {
+		filter := parser.NewDictionary()
+		filter.SetName("Type", "CryptFilter")
+		filter.SetName("CFM", dict.CFM)
+		filter.SetName("AuthEvent", "DocOpen")
+		filter.SetInteger("Length", int64(dict.Length/8))
+		cf := parser.NewDictionary()
+		cf.Set("StdCF", filter)
+		d.Set("CF", cf)
+		d.SetName("StmF", "StdCF")
+		d.SetName("StrF", "StdCF")
+	}
+	if dict.R == 6 {
+		d.Set("OE", parser.NewHexString(string(dict.OE)))
+		d.Set("UE", parser.NewHexString(string(dict.UE)))
+		d.Set("Perms", parser.NewHexString(string(dict.Perms)))
+	}
+	return d
+}
+
+// Encrypt returns a copy of obj, object number num, with its strings and
+// stream data encrypted. Metadata streams are left in clear text unless
+// Metadata is set.
+//
+// Reference: PDF 1.7 Specification, Section 7.6.2 (General Encryption Algorithm).
+func (e *Encryption) Encrypt(obj parser.PdfObject, num int) (parser.PdfObject, error) {
+	switch o := obj.(type) {
+	case *parser.String:
+		data, err := e.Encryptor.EncryptString(num, 0, o.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		return parser.NewHexString(string(data)), nil
+
+	case *parser.Array:
+		arr := parser.NewArrayWithCapacity(o.Len())
+		for _, elem := range o.Elements() {
+			encrypted, err := e.Encrypt(elem, num)
+			if err != nil {
+				return nil, err
+			}
+			arr.Append(encrypted)
+		}
+		return arr, nil
+
+	case *parser.Dictionary:
+		dict := parser.NewDictionaryWithCapacity(o.Len())
+		for _, key := range o.Keys() {
+			encrypted, err := e.Encrypt(o.Get(key), num)
+			if err != nil {
+				return nil, err
+			}
+			dict.Set(key, encrypted)
+		}
+		return dict, nil
+
+	case *parser.Stream:
+		if t := o.Dictionary().GetName("Type"); t != nil && t.Value() == "Metadata" && !e.Metadata {
+			return o, nil
+		}
+		dict, err := e.Encrypt(o.Dictionary(), num)
+		if err != nil {
+			return nil, err
+		}
+		data, err := e.Encryptor.EncryptStream(num, 0, o.Content())
+		if err != nil {
+			return nil, err
+		}
+		return parser.NewStream(dict.(*parser.Dictionary), data), nil
+
+	default:
+		return obj, nil
+	}
+}
+
+// EncryptFile rewrites the unencrypted PDF file at path with enc.
+//
+// Every object is read back, encrypted and written again under its own
+// number, followed by the encryption dictionary. Object streams and
+// cross-reference streams are dropped: their objects are written
+// individually, with a classic cross-reference table.
+func EncryptFile(path string, enc *Encryption) error {
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	objects, trailer, version, err := encryptObjects(r, enc)
+	_ = r.Close()
+	if err != nil {
+		return err
+	}
+
+	w, err := NewPdfWriter(path)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteObjects(version, objects, trailer); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// encryptObjects reads and encrypts every object of r.
+func encryptObjects(r *parser.Reader, enc *Encryption) ([]*IndirectObject, RawTrailer, string, error) {
+	src := r.Trailer()
+	var trailer RawTrailer
+	if root, ok := src.Get("Root").(*parser.IndirectReference); ok {
+		trailer.Root = root.Number
+	}
+	if info, ok := src.Get("Info").(*parser.IndirectReference); ok {
+		trailer.Info = info.Number
+	}
+
+	size := int(src.GetInteger("Size"))
+	objects := make([]*IndirectObject, 0, size)
+	for num := 1; num < size; num++ {
+		obj, err := r.GetObject(num)
+		if err != nil {
+			obj = parser.NewNull() // Free or missing entries
+		}
+		if stream, ok := obj.(*parser.Stream); ok {
+			if t := stream.Dictionary().GetName("Type"); t != nil && (t.Value() == "ObjStm" || t.Value() == "XRef") {
+				obj = parser.NewNull()
+			}
+		}
+
+		encrypted, err := enc.Encrypt(obj, num)
+		if err != nil {
+			return nil, trailer, "", fmt.Errorf("failed to encrypt object %d: %w", num, err)
+		}
+		var buf bytes.Buffer
+		if _, err := encrypted.WriteTo(&buf); err != nil {
+			return nil, trailer, "", fmt.Errorf("failed to write object %d: %w", num, err)
+		}
+		objects = append(objects, NewIndirectObject(num, 0, buf.Bytes()))
+	}
+
+	var buf bytes.Buffer
+	if _, err := enc.Dict.WriteTo(&buf); err != nil {
+		return nil, trailer, "", fmt.Errorf("failed to write encryption dictionary: %w", err)
+	}
+	trailer.Encrypt = len(objects) + 1
+	trailer.ID = enc.ID
+	objects = append(objects, NewIndirectObject(trailer.Encrypt, 0, buf.Bytes()))
+
+	return objects, trailer, MinVersion(r.Version(), enc.Version), nil
+}
+
+// MinVersion returns version, raised to at least minimum.
+func MinVersion(version, minimum string) string {
+	if version == "" {
+		version = "1.7"
+	}
+	if version < minimum {
+		return minimum
+	}
+	return version
+}
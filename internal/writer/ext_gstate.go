@@ -0,0 +1,79 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ExtGStateParams are the parameters of a graphics state parameter
+// dictionary (ExtGState).
+//
+// The struct is comparable and used as the ExtGState cache key, so equal
+// parameters share one ExtGState object per page.
+//
+// Reference: PDF 1.7 Specification, Section 8.4.5 (Graphics State Parameter Dictionaries).
+type ExtGStateParams struct {
+	// Opacity sets both /ca (fill) and /CA (stroke) when below 1.0.
+	Opacity float64
+
+	// StrokeAdjust sets /SA true, asking the renderer to adjust thin
+	// strokes to the device pixel grid (Section 10.7.5).
+	StrokeAdjust bool
+
+	// Overprint sets /OP and /op true with /OPM 1, so painting a color
+	// does not knock out colorants it leaves at zero (Section 11.7.4).
+	Overprint bool
+}
+
+// Bytes returns the ExtGState dictionary.
+//
+// Example output:
+//
+//	<< /Type /ExtGState /ca 0.50 /CA 0.50 /SA true /OP true /op true /OPM 1 >>
+func (p ExtGStateParams) Bytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<< /Type /ExtGState")
+	if p.Opacity < 1.0 {
+		opacity := p.Opacity
+		if opacity < 0 {
+			opacity = 0
+		}
+		fmt.Fprintf(&buf, " /ca %s /CA %s", formatNumber(opacity), formatNumber(opacity))
+	}
+	if p.StrokeAdjust {
+		buf.WriteString(" /SA true")
+	}
+	if p.Overprint {
+		buf.WriteString(" /OP true /op true /OPM 1")
+	}
+	buf.WriteString(" >>")
+	return buf.Bytes()
+}
+
+// applyExtGState selects an ExtGState for an operation's graphics state
+// flags. It does nothing if the operation sets none.
+func applyExtGState(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) {
+	if !gop.StrokeAdjust && !gop.Overprint {
+		return
+	}
+	name, _ := resources.GetOrCreateExtGStateParams(ExtGStateParams{
+		Opacity:      1.0,
+		StrokeAdjust: gop.StrokeAdjust,
+		Overprint:    gop.Overprint,
+	})
+	csw.SetGraphicsState(name)
+}
+
+// createExtGStateObjects creates objects for the page's pending ExtGStates
+// and assigns their object numbers in the resource dictionary.
+func (w *PdfWriter) createExtGStateObjects(resources *ResourceDictionary) []*IndirectObject {
+	names := resources.PendingExtGStates()
+	objs := make([]*IndirectObject, 0, len(names))
+	for _, name := range names {
+		params, _ := resources.ExtGStateParamsByName(name)
+		objNum := w.allocateObjNum()
+		objs = append(objs, NewIndirectObject(objNum, 0, params.Bytes()))
+		resources.SetExtGStateObjNum(name, objNum)
+	}
+	return objs
+}
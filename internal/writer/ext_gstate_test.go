@@ -0,0 +1,109 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtGStateParams_Bytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ExtGStateParams
+		want   string
+	}{
+		{
+			name:   "opacity",
+			params: ExtGStateParams{Opacity: 0.5},
+			want:   "<< /Type /ExtGState /ca 0.50 /CA 0.50 >>",
+		},
+		{
+			name:   "stroke adjustment",
+			params: ExtGStateParams{Opacity: 1, StrokeAdjust: true},
+			want:   "<< /Type /ExtGState /SA true >>",
+		},
+		{
+			name:   "overprint",
+			params: ExtGStateParams{Opacity: 1, Overprint: true},
+			want:   "<< /Type /ExtGState /OP true /op true /OPM 1 >>",
+		},
+		{
+			name:   "all",
+			params: ExtGStateParams{Opacity: 0.25, StrokeAdjust: true, Overprint: true},
+			want:   "<< /Type /ExtGState /ca 0.25 /CA 0.25 /SA true /OP true /op true /OPM 1 >>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.params.Bytes()); got != tt.want {
+				t.Errorf("Bytes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceDictionary_GetOrCreateExtGStateParams(t *testing.T) {
+	rd := NewResourceDictionary()
+
+	op := ExtGStateParams{Opacity: 1, Overprint: true}
+	name1, created := rd.GetOrCreateExtGStateParams(op)
+	if name1 != "GS1" || !created {
+		t.Errorf("first call = (%q, %v), want (GS1, true)", name1, created)
+	}
+
+	name2, created := rd.GetOrCreateExtGStateParams(op)
+	if name2 != name1 || created {
+		t.Errorf("same params = (%q, %v), want (%q, false)", name2, created, name1)
+	}
+
+	name3, _ := rd.GetOrCreateExtGStateParams(ExtGStateParams{Opacity: 1, StrokeAdjust: true})
+	name4, _ := rd.GetOrCreateExtGState(0.5)
+	if name3 != "GS2" || name4 != "GS3" {
+		t.Errorf("different params = %q, %q, want GS2, GS3", name3, name4)
+	}
+
+	pending := rd.PendingExtGStates()
+	if len(pending) != 3 {
+		t.Fatalf("PendingExtGStates() = %v, want 3 names", pending)
+	}
+
+	rd.SetExtGStateObjNum("GS1", 20)
+	if pending := rd.PendingExtGStates(); len(pending) != 2 || pending[0] != "GS2" {
+		t.Errorf("PendingExtGStates() after assigning GS1 = %v, want [GS2 GS3]", pending)
+	}
+
+	if params, ok := rd.ExtGStateParamsByName("GS3"); !ok || params.Opacity != 0.5 {
+		t.Errorf("ExtGStateParamsByName(GS3) = (%+v, %v), want opacity 0.5", params, ok)
+	}
+}
+
+func TestRenderGraphicsOp_ExtGStateFlags(t *testing.T) {
+	ops := []GraphicsOp{
+		{Type: 1, X: 10, Y: 10, Width: 50, Height: 50, FillColor: &RGB{R: 1}, Overprint: true},
+		{Type: 0, X: 0, Y: 0, X2: 100, Y2: 0, StrokeColor: &RGB{}, StrokeWidth: 0, StrokeAdjust: true},
+		{Type: 1, X: 70, Y: 10, Width: 50, Height: 50, FillColor: &RGB{G: 1}, Overprint: true},
+		{Type: 1, X: 130, Y: 10, Width: 50, Height: 50, FillColor: &RGB{B: 1}},
+	}
+
+	content, resources, err := GenerateContentStreamWithGraphics(nil, ops)
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+
+	got := string(content)
+	if strings.Count(got, "/GS1 gs") != 2 {
+		t.Errorf("overprint rects should share GS1:\n%s", got)
+	}
+	if strings.Count(got, "/GS2 gs") != 1 {
+		t.Errorf("stroke-adjusted line should use GS2:\n%s", got)
+	}
+	if strings.Count(got, " gs") != 3 {
+		t.Errorf("plain rect should not select a graphics state:\n%s", got)
+	}
+	if i := strings.Index(got, "/GS1 gs"); i < 0 || !strings.HasSuffix(got[:i], "q\n") {
+		t.Errorf("graphics state must follow q:\n%s", got)
+	}
+	if len(resources.PendingExtGStates()) != 2 {
+		t.Errorf("expected 2 pending ExtGStates, got %v", resources.PendingExtGStates())
+	}
+}
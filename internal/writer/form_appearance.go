@@ -0,0 +1,370 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// Button field flags (Ff) relevant to appearance generation.
+//
+// Reference: PDF 1.7 Specification, Section 12.7.4.2 (Button Fields).
+const (
+	buttonFlagRadio      = 1 << 15 // 32768
+	buttonFlagPushbutton = 1 << 16 // 65536
+)
+
+// Text field flags (Ff) relevant to appearance generation.
+//
+// Reference: PDF 1.7 Specification, Section 12.7.4.3 (Text Fields).
+const (
+	textFlagMultiline = 1 << 12 // 4096
+	textFlagPassword  = 1 << 13 // 8192
+)
+
+// textPadding is the inset of text from the edges of a text field.
+const textPadding = 2
+
+// appearanceFonts maps the font names used in default appearance strings
+// (/DA) to Standard 14 fonts. Other names fall back to Helvetica.
+var appearanceFonts = map[string]string{
+	"Helv": "Helvetica",
+	"HeBo": "Helvetica-Bold",
+	"Cour": "Courier",
+	"CoBo": "Courier-Bold",
+	"TiRo": "Times-Roman",
+	"TiBo": "Times-Bold",
+	"Symb": "Symbol",
+	"ZaDb": "ZapfDingbats",
+}
+
+// downBackgroundGray is the background shade of the pressed (/D) appearance.
+const downBackgroundGray = 0.75
+
+// buttonAppearance holds the object numbers of a button widget's
+// appearance streams.
+//
+// Each widget has a normal (/N) and a down (/D) appearance for both its
+// on state (named by the export value) and its Off state.
+type buttonAppearance struct {
+	normalOn  int
+	normalOff int
+	downOn    int
+	downOff   int
+}
+
+// isToggleButton reports whether a field is a checkbox or radio widget
+// (a button field that is not a pushbutton).
+func isToggleButton(field *document.FormField) bool {
+	if field.FieldType() != "Btn" {
+		return false
+	}
+	return buttonFlags(field)&buttonFlagPushbutton == 0
+}
+
+// isRadioButton reports whether a button widget belongs to a radio group.
+func isRadioButton(field *document.FormField) bool {
+	return buttonFlags(field)&buttonFlagRadio != 0
+}
+
+// buttonFlags returns the effective field flags, inherited from the parent
+// for radio kid widgets.
+func buttonFlags(field *document.FormField) int {
+	if parent := field.Parent(); parent != nil {
+		return parent.Flags()
+	}
+	return field.Flags()
+}
+
+// createButtonAppearances creates the four appearance streams of a
+// checkbox or radio widget.
+//
+// Off appearances draw only the background and border from /MK. On
+// appearances add a checkmark (checkboxes) or a filled dot (radio buttons).
+// Down appearances use a gray background to give press feedback.
+//
+// Returns the appearance object numbers and the stream objects.
+//
+// Reference: PDF 1.7 Specification, Section 12.5.5 (Appearance Streams).
+func (w *PdfWriter) createButtonAppearances(field *document.FormField) (*buttonAppearance, []*IndirectObject) {
+	rect := field.Rect()
+	width := rect[2] - rect[0]
+	height := rect[3] - rect[1]
+	radio := isRadioButton(field)
+
+	ap := &buttonAppearance{
+		normalOn:  w.allocateObjNum(),
+		normalOff: w.allocateObjNum(),
+		downOn:    w.allocateObjNum(),
+		downOff:   w.allocateObjNum(),
+	}
+
+	objs := []*IndirectObject{
+		createAppearanceStream(ap.normalOn, width, height,
+			buttonAppearanceContent(field, width, height, radio, true, false)),
+		createAppearanceStream(ap.normalOff, width, height,
+			buttonAppearanceContent(field, width, height, radio, false, false)),
+		createAppearanceStream(ap.downOn, width, height,
+			buttonAppearanceContent(field, width, height, radio, true, true)),
+		createAppearanceStream(ap.downOff, width, height,
+			buttonAppearanceContent(field, width, height, radio, false, true)),
+	}
+
+	return ap, objs
+}
+
+// createTextAppearance creates the normal appearance stream of a text
+// field, showing its value in the font and color of its /DA string.
+//
+// Single-line values are centered vertically; multiline values start at
+// the top, one line per "\n" (no word wrapping). Password fields show one
+// asterisk per character. The text is clipped to the field and marked as
+// /Tx content, so viewers replace only that part when the value is edited.
+//
+// Reference: PDF 1.7 Specification, Section 12.7.3.3 (Variable Text).
+func (w *PdfWriter) createTextAppearance(field *document.FormField) *IndirectObject {
+	rect := field.Rect()
+	width := rect[2] - rect[0]
+	height := rect[3] - rect[1]
+
+	da := parseDefaultAppearance(field.Appearance())
+	if da.size == 0 {
+		// Auto size: fit one line in the field.
+		da.size = math.Max(1, (height-2*textPadding)*0.8)
+	}
+	baseFont, ok := appearanceFonts[da.font]
+	if !ok {
+		baseFont = "Helvetica"
+	}
+
+	csw := NewContentStreamWriter()
+	appendWidgetBackground(csw, field, width, height, false, false)
+
+	value := field.Value()
+	if value != "" {
+		if field.Flags()&textFlagPassword != 0 {
+			value = strings.Repeat("*", utf8.RuneCountInString(value))
+		}
+		lines := []string{value}
+		if field.Flags()&textFlagMultiline != 0 {
+			lines = strings.Split(value, "\n")
+		}
+
+		ascent, descent := 0.8, -0.2
+		if metrics := fonts.GetMetrics(baseFont); metrics != nil && metrics.GetAscender() > 0 {
+			ascent = float64(metrics.GetAscender()) / 1000
+			descent = float64(metrics.GetDescender()) / 1000
+		}
+		baseline := (height-(ascent-descent)*da.size)/2 - descent*da.size
+		if len(lines) > 1 {
+			baseline = height - textPadding - ascent*da.size
+		}
+
+		csw.writeOp("/Tx", "BMC")
+		csw.SaveState()
+		csw.Rectangle(1, 1, width-2, height-2)
+		csw.Clip()
+		csw.EndPath()
+		csw.BeginText()
+		csw.SetFont(da.font, da.size)
+		da.setColor(csw)
+		csw.MoveTextPositionSetLeading(textPadding, baseline)
+		for i, line := range lines {
+			if i > 0 {
+				csw.ShowTextNextLine(line)
+				continue
+			}
+			csw.ShowText(line)
+		}
+		csw.EndText()
+		csw.RestoreState()
+		csw.EndMarkedContent()
+	}
+
+	resources := fmt.Sprintf("<< /Font << /%s << /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >> >> >>",
+		escapePDFName(da.font), baseFont)
+	return createAppearanceStreamWithResources(w.allocateObjNum(), width, height, resources, csw.Bytes())
+}
+
+// defaultAppearance is a parsed default appearance string (/DA).
+type defaultAppearance struct {
+	font  string    // Font resource name, without the slash
+	size  float64   // Font size (0 = auto)
+	color []float64 // Fill color: 1 (gray), 3 (RGB) or 4 (CMYK) components
+}
+
+// parseDefaultAppearance parses the font (Tf) and fill color (g, rg or k)
+// of a /DA string such as "/Helv 12 Tf 0 0 1 rg". Missing entries default
+// to Helvetica 12 pt in black.
+func parseDefaultAppearance(da string) defaultAppearance {
+	result := defaultAppearance{font: "Helv", size: 12}
+	var operands []float64
+	var name string
+	for _, token := range strings.Fields(da) {
+		if strings.HasPrefix(token, "/") {
+			name = token[1:]
+			continue
+		}
+		if v, err := strconv.ParseFloat(token, 64); err == nil {
+			operands = append(operands, v)
+			continue
+		}
+		switch {
+		case token == "Tf" && name != "" && len(operands) == 1:
+			result.font, result.size = name, math.Max(0, operands[0])
+		case token == "g" && len(operands) == 1, token == "rg" && len(operands) == 3, token == "k" && len(operands) == 4:
+			result.color = operands
+		}
+		operands, name = nil, ""
+	}
+	return result
+}
+
+// setColor sets the fill color of the appearance.
+func (da defaultAppearance) setColor(csw *ContentStreamWriter) {
+	switch len(da.color) {
+	case 1:
+		csw.SetFillColorGray(da.color[0])
+	case 3:
+		csw.SetFillColorRGB(da.color[0], da.color[1], da.color[2])
+	case 4:
+		csw.SetFillColorCMYK(da.color[0], da.color[1], da.color[2], da.color[3])
+	default:
+		csw.SetFillColorGray(0)
+	}
+}
+
+// createAppearanceStream wraps appearance content in a Form XObject.
+//
+// Format:
+//
+//	N 0 obj
+//	<< /Type /XObject /Subtype /Form /BBox [0 0 W H] /Resources << >> /Length L >>
+//	stream
+//	... content ...
+//	endstream
+//	endobj
+func createAppearanceStream(objNum int, width, height float64, content []byte) *IndirectObject {
+	return createAppearanceStreamWithResources(objNum, width, height, "<< >>", content)
+}
+
+// createAppearanceStreamWithResources wraps appearance content in a Form
+// XObject with the given resource dictionary.
+func createAppearanceStreamWithResources(objNum int, width, height float64, resources string, content []byte) *IndirectObject {
+	var buf bytes.Buffer
+	buf.WriteString("<< /Type /XObject /Subtype /Form")
+	buf.WriteString(fmt.Sprintf(" /BBox [0 0 %s]", formatNumbers(width, height)))
+	buf.WriteString(" /Resources " + resources)
+	buf.WriteString(fmt.Sprintf(" /Length %d >>\n", len(content)))
+	buf.WriteString("stream\n")
+	buf.Write(content)
+	buf.WriteString("\nendstream")
+
+	return NewIndirectObject(objNum, 0, buf.Bytes())
+}
+
+// buttonAppearanceContent generates the content stream of one button state.
+func buttonAppearanceContent(field *document.FormField, width, height float64, radio, on, down bool) []byte {
+	csw := NewContentStreamWriter()
+	appendWidgetBackground(csw, field, width, height, radio, down)
+
+	if !on {
+		return csw.Bytes()
+	}
+
+	if radio {
+		// Filled dot.
+		csw.SetFillColorGray(0)
+		appendCircle(csw, width/2, height/2, math.Min(width, height)/4)
+		csw.Fill()
+		return csw.Bytes()
+	}
+
+	// Checkmark.
+	csw.SaveState()
+	csw.SetStrokeColorGray(0)
+	csw.SetLineWidth(math.Max(1, math.Min(width, height)*0.12))
+	csw.SetLineCap(1)
+	csw.SetLineJoin(1)
+	csw.MoveTo(width*0.2, height*0.5)
+	csw.LineTo(width*0.42, height*0.25)
+	csw.LineTo(width*0.8, height*0.78)
+	csw.Stroke()
+	csw.RestoreState()
+
+	return csw.Bytes()
+}
+
+// appendWidgetBackground draws the background and border of a widget from
+// /MK, as a circle for radio buttons.
+func appendWidgetBackground(csw *ContentStreamWriter, field *document.FormField, width, height float64, radio, down bool) {
+	cx, cy := width/2, height/2
+	radius := math.Min(width, height) / 2
+
+	// Background: gray when pressed, /MK /BG otherwise.
+	fill := field.FillColor()
+	if down || fill != nil {
+		if down {
+			csw.SetFillColorGray(downBackgroundGray)
+		} else {
+			csw.SetFillColorRGB(fill[0], fill[1], fill[2])
+		}
+		if radio {
+			appendCircle(csw, cx, cy, radius)
+		} else {
+			csw.Rectangle(0, 0, width, height)
+		}
+		csw.Fill()
+	}
+
+	// Border from /MK /BC, inset by half the line width.
+	if bc := field.BorderColor(); bc != nil {
+		csw.SetStrokeColorRGB(bc[0], bc[1], bc[2])
+		csw.SetLineWidth(1)
+		if radio {
+			appendCircle(csw, cx, cy, radius-0.5)
+		} else {
+			csw.Rectangle(0.5, 0.5, width-1, height-1)
+		}
+		csw.Stroke()
+	}
+}
+
+// appendCircle appends a circle path built from four Bézier curves.
+func appendCircle(csw *ContentStreamWriter, cx, cy, r float64) {
+	// kappa = 4/3 * (sqrt(2) - 1) ≈ 0.5522847498
+	const kappa = 0.5522847498
+	k := r * kappa
+	csw.MoveTo(cx+r, cy)
+	csw.CurveTo(cx+r, cy+k, cx+k, cy+r, cx, cy+r)
+	csw.CurveTo(cx-k, cy+r, cx-r, cy+k, cx-r, cy)
+	csw.CurveTo(cx-r, cy-k, cx-k, cy-r, cx, cy-r)
+	csw.CurveTo(cx+k, cy-r, cx+r, cy-k, cx+r, cy)
+	csw.ClosePath()
+}
+
+// escapePDFName escapes a string for use as a PDF name object (without the
+// leading slash).
+//
+// Whitespace, delimiters, '#' and bytes outside the printable ASCII range
+// are written as #XX hex escapes.
+//
+// Reference: PDF 1.7 Specification, Section 7.3.5 (Name Objects).
+func escapePDFName(name string) string {
+	var sb strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c < '!' || c > '~' || strings.IndexByte("#()<>[]{}/%", c) >= 0 {
+			sb.WriteString(fmt.Sprintf("#%02X", c))
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package writer
+
+import (
+	"fmt"
+)
+
+// FormXObject is a reusable group of text and graphics operations (a form
+// XObject). It is written to the document once, with its own resource
+// dictionary, and drawn wherever a GraphicsOp of Type 26 places it.
+//
+// Reference: PDF 1.7 specification, Section 8.10 (Form XObjects).
+type FormXObject struct {
+	ID          string     // Identifies the form across pages
+	BBox        [4]float64 // Bounding box in form space: llx lly urx ury
+	TextOps     []TextOp
+	GraphicsOps []GraphicsOp
+
+	// Imported, if set, supplies the content and resources of the form
+	// from a page of an existing PDF instead of TextOps and GraphicsOps.
+	Imported *ImportedPage
+}
+
+// renderForm draws a form XObject placed by gop.Matrix (Do operator).
+func renderForm(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
+	if gop.Form == nil {
+		return fmt.Errorf("form XObject is nil")
+	}
+
+	formResName := resources.AddForm(gop.Form.ID) // Object number set later
+	m := gop.Matrix
+	csw.ConcatMatrix(m[0], m[1], m[2], m[3], m[4], m[5])
+	csw.writeOp(fmt.Sprintf("/%s", formResName), "Do")
+
+	// Restore graphics state
+	csw.RestoreState()
+	return nil
+}
+
+// createAndAssignFormXObjects creates the form XObjects drawn by
+// graphicsOps and assigns their object numbers to the resource
+// dictionary entries created during content stream generation.
+func (w *PdfWriter) createAndAssignFormXObjects(graphicsOps []GraphicsOp, resources *ResourceDictionary) ([]*IndirectObject, error) {
+	var objects []*IndirectObject
+	for _, gop := range graphicsOps {
+		if gop.Type != 26 || gop.Form == nil {
+			continue
+		}
+		formObjNum, formObjs, err := w.formXObject(gop.Form)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, formObjs...)
+		resources.SetFormObjNum(gop.Form.ID, formObjNum)
+	}
+	return objects, nil
+}
+
+// formXObject returns the object number of the form XObject for form, and
+// the objects to write on the form's first use in the document (nil
+// afterwards): the form stream and the fonts, images and nested forms its
+// resources refer to. As with images, page-chunked output gives every
+// page its own copy (see SetPageChunked).
+//
+// Format:
+//
+//	N 0 obj
+//	<< /Type /XObject /Subtype /Form /BBox [llx lly urx ury]
+//	   /Matrix [1 0 0 1 0 0] /Resources << ... >> /Length L >>
+//	stream
+//	... content ...
+//	endstream
+//	endobj
+func (w *PdfWriter) formXObject(form *FormXObject) (int, []*IndirectObject, error) {
+	if objNum, ok := w.formNums[form.ID]; ok {
+		if objNum == 0 {
+			return 0, nil, fmt.Errorf("form XObject %s draws itself", form.ID)
+		}
+		if !w.pageChunked {
+			return objNum, nil, nil
+		}
+	}
+
+	if w.formNums == nil {
+		w.formNums = make(map[string]int)
+	}
+	w.formNums[form.ID] = 0 // Being written: a nested use is a cycle
+
+	var content []byte
+	var resources string
+	var objects []*IndirectObject
+	var err error
+	if form.Imported != nil {
+		content, resources, objects, err = w.importedForm(form.Imported)
+	} else {
+		var res *ResourceDictionary
+		content, res, objects, err = w.buildContent(form.TextOps, form.GraphicsOps)
+		if res != nil {
+			resources = string(res.Bytes())
+		}
+	}
+	if err != nil {
+		delete(w.formNums, form.ID)
+		return 0, nil, fmt.Errorf("form XObject %s: %w", form.ID, err)
+	}
+
+	objNum := w.allocateObjNum()
+	entries := fmt.Sprintf("/Type /XObject /Subtype /Form /BBox [%s] /Matrix [1 0 0 1 0 0] /Resources %s",
+		formatNumbers(form.BBox[:]...), resources)
+	objects = append(objects, createStreamObject(objNum, entries, content, w.compression))
+
+	w.formNums[form.ID] = objNum
+	return objNum, objects, nil
+}
@@ -0,0 +1,150 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sync"
+)
+
+// srgbProfileOnce guards srgbProfile.
+var (
+	srgbProfileOnce sync.Once
+	srgbProfile     []byte
+)
+
+// srgbICCProfile returns an ICC version 2 display profile for sRGB
+// (IEC 61966-2.1), built once on first use.
+//
+// The profile holds the tags required for a three-component matrix/TRC
+// display profile: description, copyright, media white point, the
+// D50-adapted sRGB colorants and a sampled sRGB tone curve shared by the
+// three channels.
+//
+// Reference: ICC.1:2001-04 (ICC profile format, version 2),
+// Sections 6.1 (Header), 6.3.1.2 (Three-Component Matrix-Based Input
+// Profiles) and 6.5 (Tag Types).
+func srgbICCProfile() []byte {
+	srgbProfileOnce.Do(func() {
+		srgbProfile = buildSRGBProfile()
+	})
+	return srgbProfile
+}
+
+// iccTag is one entry of an ICC profile tag table.
+type iccTag struct {
+	sig  string
+	data []byte
+}
+
+// buildSRGBProfile assembles the sRGB profile.
+func buildSRGBProfile() []byte {
+	trc := iccCurve()
+	tags := []iccTag{
+		{"desc", iccTextDescription(srgbOutputCondition)},
+		{"cprt", iccText("No copyright, use freely")},
+		{"wtpt", iccXYZ(0.9642, 1.0, 0.8249)},
+		{"rXYZ", iccXYZ(0.4361, 0.2225, 0.0139)},
+		{"gXYZ", iccXYZ(0.3851, 0.7169, 0.0971)},
+		{"bXYZ", iccXYZ(0.1431, 0.0606, 0.7141)},
+		{"rTRC", trc},
+		{"gTRC", trc},
+		{"bTRC", trc},
+	}
+
+	// Tag data follows the 128-byte header and the tag table. Tags with
+	// identical data share one copy, as the TRC tags do.
+	tableLen := 4 + 12*len(tags)
+	offset := 128 + tableLen
+
+	var table, body bytes.Buffer
+	_ = binary.Write(&table, binary.BigEndian, uint32(len(tags)))
+	shared := make(map[*byte]int)
+	for _, tag := range tags {
+		at, ok := shared[&tag.data[0]]
+		if !ok {
+			at = offset + body.Len()
+			shared[&tag.data[0]] = at
+			body.Write(tag.data)
+			for body.Len()%4 != 0 {
+				body.WriteByte(0)
+			}
+		}
+		table.WriteString(tag.sig)
+		_ = binary.Write(&table, binary.BigEndian, uint32(at))
+		_ = binary.Write(&table, binary.BigEndian, uint32(len(tag.data)))
+	}
+
+	size := 128 + table.Len() + body.Len()
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[0:], uint32(size))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // Version 2.1
+	copy(header[12:], "mntr")                          // Display device
+	copy(header[16:], "RGB ")
+	copy(header[20:], "XYZ ")
+	for i, v := range []uint16{2024, 1, 1, 0, 0, 0} { // Creation date
+		binary.BigEndian.PutUint16(header[24+2*i:], v)
+	}
+	copy(header[36:], "acsp")
+	copy(header[68:], iccXYZ(0.9642, 1.0, 0.8249)[8:]) // D50 illuminant
+
+	profile := make([]byte, 0, size)
+	profile = append(profile, header...)
+	profile = append(profile, table.Bytes()...)
+	return append(profile, body.Bytes()...)
+}
+
+// iccS15Fixed16 encodes v as an s15Fixed16Number.
+func iccS15Fixed16(v float64) uint32 {
+	return uint32(int32(math.Round(v * 65536)))
+}
+
+// iccXYZ returns an XYZType tag holding one XYZ number.
+func iccXYZ(x, y, z float64) []byte {
+	data := make([]byte, 20)
+	copy(data, "XYZ ")
+	binary.BigEndian.PutUint32(data[8:], iccS15Fixed16(x))
+	binary.BigEndian.PutUint32(data[12:], iccS15Fixed16(y))
+	binary.BigEndian.PutUint32(data[16:], iccS15Fixed16(z))
+	return data
+}
+
+// iccText returns a textType tag holding a NUL-terminated ASCII string.
+func iccText(s string) []byte {
+	data := make([]byte, 8, 8+len(s)+1)
+	copy(data, "text")
+	data = append(data, s...)
+	return append(data, 0)
+}
+
+// iccTextDescription returns a textDescriptionType tag with an ASCII
+// description and empty Unicode and ScriptCode descriptions.
+func iccTextDescription(s string) []byte {
+	data := make([]byte, 12, 12+len(s)+1+8+3+67)
+	copy(data, "desc")
+	binary.BigEndian.PutUint32(data[8:], uint32(len(s)+1))
+	data = append(data, s...)
+	data = append(data, 0)
+	data = append(data, make([]byte, 8)...)  // Unicode language code and count
+	data = append(data, make([]byte, 3)...)  // ScriptCode code and count
+	return append(data, make([]byte, 67)...) // ScriptCode description
+}
+
+// iccCurve returns a curveType tag sampling the sRGB transfer function
+// at 1024 points.
+func iccCurve() []byte {
+	const n = 1024
+	data := make([]byte, 12+2*n)
+	copy(data, "curv")
+	binary.BigEndian.PutUint32(data[8:], n)
+	for i := 0; i < n; i++ {
+		v := float64(i) / (n - 1)
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		binary.BigEndian.PutUint16(data[12+2*i:], uint16(math.Round(v*65535)))
+	}
+	return data
+}
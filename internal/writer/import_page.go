@@ -0,0 +1,235 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// maxImportTreeDepth limits /Parent traversal when looking up the
+// inherited /Resources of an imported page.
+const maxImportTreeDepth = 32
+
+// ImportedPage is a page of an existing PDF, drawn as a form XObject
+// (see FormXObject.Imported).
+//
+// The page's content streams are decoded and written as the form's
+// content, and the objects its resources refer to (fonts, images, nested
+// forms) are copied from the source file with new object numbers. Objects
+// shared by several imported pages of the same source are copied once.
+type ImportedPage struct {
+	Reader *parser.Reader
+	Page   *parser.Dictionary // The page object
+}
+
+// importKey identifies an object of an imported PDF.
+type importKey struct {
+	reader *parser.Reader
+	num    int
+}
+
+// importedForm returns the content, resource dictionary and copied
+// objects of an imported page's form XObject.
+func (w *PdfWriter) importedForm(page *ImportedPage) ([]byte, string, []*IndirectObject, error) {
+	if page.Reader == nil || page.Page == nil {
+		return nil, "", nil, fmt.Errorf("imported page has no source")
+	}
+
+	content, err := importedContent(page.Reader, page.Page)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// Page-chunked output gives every page its own copies (see
+	// SetPageChunked).
+	if w.importNums == nil || w.pageChunked {
+		w.importNums = make(map[importKey]int)
+	}
+	c := &importCopier{w: w, reader: page.Reader}
+
+	resources := parser.NewDictionary()
+	if res, ok := c.resolve(inheritedResources(page.Reader, page.Page)).(*parser.Dictionary); ok {
+		resources = c.copyDict(res, "")
+	}
+	objects, err := c.drain()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var buf bytes.Buffer
+	if _, err := resources.WriteTo(&buf); err != nil {
+		return nil, "", nil, fmt.Errorf("failed to write imported resources: %w", err)
+	}
+	return content, buf.String(), objects, nil
+}
+
+// importedContent returns the decoded content of a page, joining the
+// streams of a /Contents array.
+func importedContent(r *parser.Reader, page *parser.Dictionary) ([]byte, error) {
+	var streams []parser.PdfObject
+	switch contents := resolveImported(r, page.Get("Contents")).(type) {
+	case nil:
+		return nil, nil
+	case *parser.Stream:
+		streams = []parser.PdfObject{contents}
+	case *parser.Array:
+		streams = contents.Elements()
+	default:
+		return nil, fmt.Errorf("unexpected /Contents type: %T", contents)
+	}
+
+	var content []byte
+	for _, obj := range streams {
+		stream, ok := resolveImported(r, obj).(*parser.Stream)
+		if !ok {
+			continue
+		}
+		data, err := r.DecodeStream(stream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode page content: %w", err)
+		}
+		// Streams of an array may split tokens only at whitespace.
+		content = append(content, data...)
+		content = append(content, '\n')
+	}
+	return content, nil
+}
+
+// inheritedResources returns the /Resources of a page, looking it up on
+// its ancestors in the page tree if the page has none.
+func inheritedResources(r *parser.Reader, page *parser.Dictionary) parser.PdfObject {
+	node := page
+	for i := 0; node != nil && i < maxImportTreeDepth; i++ {
+		if res := node.Get("Resources"); res != nil {
+			return res
+		}
+		node, _ = resolveImported(r, node.Get("Parent")).(*parser.Dictionary)
+	}
+	return nil
+}
+
+// resolveImported resolves a single level of indirect reference.
+func resolveImported(r *parser.Reader, obj parser.PdfObject) parser.PdfObject {
+	if ref, ok := obj.(*parser.IndirectReference); ok {
+		resolved, err := r.GetObject(ref.Number)
+		if err != nil {
+			return nil
+		}
+		return resolved
+	}
+	return obj
+}
+
+// importCopier copies objects out of an imported PDF, giving each a new
+// object number in the output.
+type importCopier struct {
+	w      *PdfWriter
+	reader *parser.Reader
+	queue  []importPending // Objects numbered but not copied yet
+}
+
+// importPending is an object that has a new number but has not been
+// copied yet.
+type importPending struct {
+	num    int
+	oldNum int              // Object number in the source (0 if src is set)
+	src    parser.PdfObject // Direct stream to copy when oldNum is 0
+}
+
+// resolve resolves a single level of indirect reference in the source.
+func (c *importCopier) resolve(obj parser.PdfObject) parser.PdfObject {
+	return resolveImported(c.reader, obj)
+}
+
+// ref returns the reference to write in place of a reference to oldNum,
+// numbering the object on first use.
+func (c *importCopier) ref(oldNum int) parser.PdfObject {
+	key := importKey{reader: c.reader, num: oldNum}
+	num, ok := c.w.importNums[key]
+	if !ok {
+		num = c.w.allocateObjNum()
+		c.w.importNums[key] = num
+		c.queue = append(c.queue, importPending{num: num, oldNum: oldNum})
+	}
+	return parser.NewIndirectReference(num, 0)
+}
+
+// copy returns a copy of obj with all references renumbered.
+func (c *importCopier) copy(obj parser.PdfObject) parser.PdfObject {
+	switch o := obj.(type) {
+	case *parser.IndirectReference:
+		return c.ref(o.Number)
+	case *parser.Array:
+		arr := parser.NewArrayWithCapacity(o.Len())
+		for _, elem := range o.Elements() {
+			arr.Append(c.copy(elem))
+		}
+		return arr
+	case *parser.Dictionary:
+		return c.copyDict(o, "")
+	case *parser.Stream:
+		// Streams must be indirect objects; one can end up direct when a
+		// cached object had its references resolved in place.
+		num := c.w.allocateObjNum()
+		c.queue = append(c.queue, importPending{num: num, src: o})
+		return parser.NewIndirectReference(num, 0)
+	default:
+		return obj
+	}
+}
+
+// copyDict returns a copy of dict without the skip key, with all
+// references renumbered.
+func (c *importCopier) copyDict(dict *parser.Dictionary, skip string) *parser.Dictionary {
+	copied := parser.NewDictionaryWithCapacity(dict.Len())
+	for _, key := range dict.Keys() {
+		if key != skip {
+			copied.Set(key, c.copy(dict.Get(key)))
+		}
+	}
+	return copied
+}
+
+// drain copies every queued object, including the objects they reference.
+func (c *importCopier) drain() ([]*IndirectObject, error) {
+	var objects []*IndirectObject
+	for len(c.queue) > 0 {
+		pending := c.queue[0]
+		c.queue = c.queue[1:]
+
+		src := pending.src
+		if src == nil {
+			obj, err := c.reader.GetObject(pending.oldNum)
+			if err != nil {
+				obj = parser.NewNull() // Missing objects read as null
+			}
+			src = obj
+		}
+
+		var copied parser.PdfObject
+		switch o := src.(type) {
+		case *parser.Stream:
+			// /Length is rewritten from the content. It may be an indirect
+			// reference, which would otherwise be copied as an unused object.
+			copied = parser.NewStream(c.copyDict(o.Dictionary(), "Length"), o.Content())
+		case *parser.Dictionary:
+			// Resources do not draw pages: do not pull in the source's
+			// page tree through a stray page reference.
+			if t := o.GetName("Type"); t != nil && (t.Value() == "Page" || t.Value() == "Pages") {
+				copied = parser.NewNull()
+			} else {
+				copied = c.copyDict(o, "")
+			}
+		default:
+			copied = c.copy(src)
+		}
+
+		var buf bytes.Buffer
+		if _, err := copied.WriteTo(&buf); err != nil {
+			return nil, fmt.Errorf("failed to copy imported object %d: %w", pending.oldNum, err)
+		}
+		objects = append(objects, NewIndirectObject(pending.num, 0, buf.Bytes()))
+	}
+	return objects, nil
+}
@@ -0,0 +1,359 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// prevTrailer holds the entries of the last trailer of an existing PDF
+// file that an incremental update carries forward.
+type prevTrailer struct {
+	xrefOffset int64  // Offset of the last cross-reference section (/Prev)
+	xrefStream bool   // Whether that section is a cross-reference stream
+	size       int    // /Size
+	root       string // /Root reference, e.g. "1 0 R"
+	info       string // /Info reference ("" = none)
+	id         string // /ID array ("" = none)
+}
+
+// NextObjectNumber returns the first object number available for new
+// objects in an incremental update of original: the /Size of its last
+// trailer.
+func NextObjectNumber(original []byte) (int, error) {
+	prev, err := readPrevTrailer(original)
+	if err != nil {
+		return 0, err
+	}
+	return prev.size, nil
+}
+
+// WriteIncremental writes original unchanged, followed by an incremental
+// update holding objects.
+//
+// objects are the new objects, numbered from NextObjectNumber(original)
+// up, and the modified objects, which keep their original object number
+// and replace the earlier definitions. The update ends with a
+// cross-reference section listing only these objects and a trailer whose
+// /Prev points at the previous section, so readers still resolve every
+// unchanged object from the original bytes. Originals using a
+// cross-reference stream get a cross-reference stream; others get an xref
+// table.
+//
+// Because the original bytes are untouched, byte ranges covered by an
+// existing digital signature stay valid.
+//
+// Example:
+//
+//	num, _ := writer.NextObjectNumber(original)
+//	annot := writer.NewIndirectObject(num, 0, annotDict)
+//	page := writer.NewIndirectObject(pageNum, 0, pageDictWithAnnots)
+//	err := w.WriteIncremental(original, []*writer.IndirectObject{annot, page})
+//
+// Reference: PDF 1.7 Specification, Section 7.5.6 (Incremental Updates).
+func (w *PdfWriter) WriteIncremental(original []byte, objects []*IndirectObject) error {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+
+	prev, err := readPrevTrailer(original)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.writer.Write(original); err != nil {
+		return fmt.Errorf("failed to write original: %w", err)
+	}
+	if len(original) > 0 && original[len(original)-1] != '\n' && original[len(original)-1] != '\r' {
+		if err := w.writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	return w.writeIncrementalUpdate(prev, objects)
+}
+
+// AppendTo appends an incremental update holding objects to the PDF file
+// at path, without rewriting the existing bytes. See WriteIncremental.
+func AppendTo(path string, objects []*IndirectObject) error {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	prev, err := readPrevTrailer(original)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	// Offsets continue from the end of the existing file.
+	w := NewPdfWriterFromWriter(file)
+	w.countWriter.n = int64(len(original))
+	if len(original) > 0 && original[len(original)-1] != '\n' && original[len(original)-1] != '\r' {
+		_ = w.writer.WriteByte('\n')
+	}
+
+	if err := w.writeIncrementalUpdate(prev, objects); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeIncrementalUpdate writes objects, their cross-reference section and
+// the trailer of an incremental update.
+func (w *PdfWriter) writeIncrementalUpdate(prev *prevTrailer, objects []*IndirectObject) error {
+	if len(objects) == 0 {
+		return errors.New("incremental update has no objects")
+	}
+
+	w.offsets = make(map[int]int64)
+	w.nextObjNum = prev.size
+	gens := make(map[int]int, len(objects))
+	for _, obj := range objects {
+		if obj.Number <= 0 {
+			return fmt.Errorf("invalid object number %d", obj.Number)
+		}
+		if _, dup := gens[obj.Number]; dup {
+			return fmt.Errorf("duplicate object %d in incremental update", obj.Number)
+		}
+		gens[obj.Number] = obj.Generation
+		if obj.Number >= w.nextObjNum {
+			w.nextObjNum = obj.Number + 1
+		}
+	}
+
+	for _, obj := range objects {
+		pos := w.getCurrentOffset()
+		w.offsets[obj.Number] = pos
+
+		if _, err := obj.WriteTo(w.writer); err != nil {
+			return fmt.Errorf("failed to write object %d: %w", obj.Number, err)
+		}
+	}
+
+	if prev.xrefStream {
+		if err := w.writeIncrementalXRefStream(prev, gens); err != nil {
+			return fmt.Errorf("failed to write xref stream: %w", err)
+		}
+	} else if err := w.writeIncrementalXRef(prev, gens); err != nil {
+		return err
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+	return nil
+}
+
+// writeIncrementalXRef writes an xref table with one subsection per run of
+// consecutive object numbers, followed by the trailer.
+//
+// Format:
+//
+//	xref
+//	4 1
+//	0000001234 00000 n
+//	12 2
+//	0000001301 00000 n
+//	0000001377 00000 n
+//	trailer
+//	<< /Size 14 /Root 1 0 R /Prev 1156 >>
+//	startxref
+//	1420
+//	%%EOF
+func (w *PdfWriter) writeIncrementalXRef(prev *prevTrailer, gens map[int]int) error {
+	xrefOffset := w.getCurrentOffset()
+
+	var buf bytes.Buffer
+	buf.WriteString("xref\n")
+	for _, run := range objectRuns(gens) {
+		buf.WriteString(fmt.Sprintf("%d %d\n", run[0], len(run)))
+		for _, num := range run {
+			buf.WriteString(fmt.Sprintf("%010d %05d n \n", w.offsets[num], gens[num]))
+		}
+	}
+
+	buf.WriteString("trailer\n")
+	buf.WriteString("<<")
+	buf.WriteString(fmt.Sprintf(" /Size %d", w.nextObjNum))
+	buf.WriteString(prev.entries())
+	buf.WriteString(" >>\n")
+	buf.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOffset))
+
+	if _, err := w.writer.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write xref: %w", err)
+	}
+	return nil
+}
+
+// writeIncrementalXRefStream writes a cross-reference stream listing the
+// updated objects and the stream itself, followed by startxref.
+func (w *PdfWriter) writeIncrementalXRefStream(prev *prevTrailer, gens map[int]int) error {
+	xrefOffset := w.getCurrentOffset()
+
+	objNum := w.allocateObjNum()
+	w.offsets[objNum] = xrefOffset
+	gens[objNum] = 0
+
+	offsetWidth := 1
+	for v := max(xrefOffset, int64(w.nextObjNum)) >> 8; v > 0; v >>= 8 {
+		offsetWidth++
+	}
+
+	var entries, index bytes.Buffer
+	for _, run := range objectRuns(gens) {
+		if index.Len() > 0 {
+			index.WriteByte(' ')
+		}
+		index.WriteString(fmt.Sprintf("%d %d", run[0], len(run)))
+		for _, num := range run {
+			writeXRefStreamEntry(&entries, 1, w.offsets[num], offsetWidth, gens[num])
+		}
+	}
+
+	data, err := CompressStream(entries.Bytes(), DefaultCompression)
+	if err != nil {
+		return err
+	}
+
+	var dict bytes.Buffer
+	dict.WriteString("<< /Type /XRef")
+	dict.WriteString(fmt.Sprintf(" /Size %d", w.nextObjNum))
+	dict.WriteString(fmt.Sprintf(" /W [1 %d 2]", offsetWidth))
+	dict.WriteString(fmt.Sprintf(" /Index [%s]", index.String()))
+	dict.WriteString(prev.entries())
+	dict.WriteString(fmt.Sprintf(" /Filter /FlateDecode /Length %d >>\n", len(data)))
+	dict.WriteString("stream\n")
+	dict.Write(data)
+	dict.WriteString("\nendstream")
+
+	if _, err := NewIndirectObject(objNum, 0, dict.Bytes()).WriteTo(w.writer); err != nil {
+		return fmt.Errorf("failed to write object %d: %w", objNum, err)
+	}
+
+	if _, err := w.writer.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOffset)); err != nil {
+		return fmt.Errorf("failed to write startxref: %w", err)
+	}
+	return nil
+}
+
+// entries returns the trailer entries carried forward from the previous
+// trailer, including /Prev.
+func (p *prevTrailer) entries() string {
+	s := " /Root " + p.root
+	if p.info != "" {
+		s += " /Info " + p.info
+	}
+	if p.id != "" {
+		s += " /ID " + p.id
+	}
+	return s + fmt.Sprintf(" /Prev %d", p.xrefOffset)
+}
+
+// objectRuns returns the object numbers of gens in ascending order, split
+// into runs of consecutive numbers (xref subsections).
+func objectRuns(gens map[int]int) [][]int {
+	nums := make([]int, 0, len(gens))
+	for num := range gens {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	var runs [][]int
+	for i, num := range nums {
+		if i == 0 || num != nums[i-1]+1 {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], num)
+	}
+	return runs
+}
+
+// readPrevTrailer reads the last trailer of original, from the xref table
+// trailer or the cross-reference stream dictionary that the final
+// startxref points at.
+func readPrevTrailer(original []byte) (*prevTrailer, error) {
+	idx := bytes.LastIndex(original, []byte("startxref"))
+	if idx < 0 {
+		return nil, errors.New("startxref not found")
+	}
+	fields := bytes.Fields(original[idx+len("startxref"):])
+	if len(fields) == 0 {
+		return nil, errors.New("missing startxref offset")
+	}
+	offset, err := strconv.ParseInt(string(fields[0]), 10, 64)
+	if err != nil || offset < 0 || offset >= int64(len(original)) {
+		return nil, fmt.Errorf("invalid startxref offset %q", fields[0])
+	}
+
+	prev := &prevTrailer{xrefOffset: offset}
+	section := original[offset:]
+
+	var trailer *parser.Dictionary
+	if bytes.HasPrefix(section, []byte("xref")) {
+		t := bytes.Index(section, []byte("trailer"))
+		if t < 0 {
+			return nil, errors.New("trailer not found")
+		}
+		obj, err := parser.NewParser(bytes.NewReader(section[t+len("trailer"):])).ParseObject()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse trailer: %w", err)
+		}
+		dict, ok := obj.(*parser.Dictionary)
+		if !ok {
+			return nil, errors.New("trailer is not a dictionary")
+		}
+		trailer = dict
+	} else {
+		obj, err := parser.NewParser(bytes.NewReader(section)).ParseIndirectObject()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse xref stream: %w", err)
+		}
+		stream, ok := obj.Object.(*parser.Stream)
+		if !ok {
+			return nil, errors.New("startxref does not point at an xref section")
+		}
+		if typ := stream.Dictionary().GetName("Type"); typ == nil || typ.Value() != "XRef" {
+			return nil, errors.New("startxref does not point at an xref section")
+		}
+		trailer = stream.Dictionary()
+		prev.xrefStream = true
+	}
+
+	if trailer.Has("Encrypt") {
+		return nil, errors.New("incremental updates of encrypted documents are not supported")
+	}
+
+	prev.size = int(trailer.GetInteger("Size"))
+	if prev.size <= 0 {
+		return nil, errors.New("trailer has no /Size")
+	}
+	root := trailer.Get("Root")
+	if root == nil {
+		return nil, errors.New("trailer has no /Root")
+	}
+	prev.root = root.String()
+	if info := trailer.Get("Info"); info != nil {
+		prev.info = info.String()
+	}
+	if id := trailer.Get("ID"); id != nil {
+		prev.id = id.String()
+	}
+
+	return prev, nil
+}
@@ -0,0 +1,154 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/models/types"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// annotationUpdate returns the objects of an incremental update adding a
+// text annotation to the first page of original.
+func annotationUpdate(t *testing.T, original []byte) []*IndirectObject {
+	t.Helper()
+
+	m := regexp.MustCompile(`(?m)^(\d+) 0 obj\n(<< /Type /Page /Parent [^\n]*>>)\nendobj`).FindSubmatch(original)
+	if m == nil {
+		t.Fatal("page object not found")
+	}
+	var pageNum int
+	fmt.Sscan(string(m[1]), &pageNum)
+
+	annotNum, err := NextObjectNumber(original)
+	if err != nil {
+		t.Fatalf("NextObjectNumber() error = %v", err)
+	}
+
+	annot := NewIndirectObject(annotNum, 0,
+		[]byte("<< /Type /Annot /Subtype /Text /Rect [100 100 120 120] /Contents (Note) >>"))
+	pageDict := strings.TrimSuffix(string(m[2]), " >>") + fmt.Sprintf(" /Annots [%d 0 R] >>", annotNum)
+	page := NewIndirectObject(pageNum, 0, []byte(pageDict))
+
+	return []*IndirectObject{annot, page}
+}
+
+// checkIncrementalUpdate checks that updated starts with original and
+// reads back with the annotation and the original pages and metadata.
+func checkIncrementalUpdate(t *testing.T, original, updated []byte) {
+	t.Helper()
+
+	if !bytes.HasPrefix(updated, original) {
+		t.Fatal("update must not modify the original bytes")
+	}
+	tail := string(updated[len(original):])
+	if !strings.Contains(tail, "/Prev ") {
+		t.Error("update trailer should contain /Prev")
+	}
+	if strings.Contains(tail, "%PDF-") {
+		t.Error("update should not repeat the header")
+	}
+
+	path := filepath.Join(t.TempDir(), "updated.pdf")
+	if err := os.WriteFile(path, updated, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer r.Close()
+
+	if n, err := r.GetPageCount(); err != nil || n != 3 {
+		t.Errorf("GetPageCount() = %d, %v; want 3", n, err)
+	}
+	if title := r.GetDocumentInfo().Title; title != "XRef Stream" {
+		t.Errorf("Title = %q, want %q", title, "XRef Stream")
+	}
+	page, err := r.GetPage(0)
+	if err != nil {
+		t.Fatalf("GetPage(0) error = %v", err)
+	}
+	annots, err := r.ResolveArray(page.Get("Annots"))
+	if err != nil || annots.Len() != 1 {
+		t.Fatalf("page /Annots = %v, %v; want one annotation", page.Get("Annots"), err)
+	}
+}
+
+func TestPdfWriter_WriteIncremental(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    types.Version
+		xrefStream bool
+	}{
+		{"xref table", types.PDF14, false},
+		{"xref stream", types.PDF17, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, original := writeXRefStreamTestDoc(t, tt.version)
+
+			var buf bytes.Buffer
+			w := NewPdfWriterFromWriter(&buf)
+			if err := w.WriteIncremental(original, annotationUpdate(t, original)); err != nil {
+				t.Fatalf("WriteIncremental() error = %v", err)
+			}
+			updated := buf.Bytes()
+
+			checkIncrementalUpdate(t, original, updated)
+
+			tail := string(updated[len(original):])
+			if got := strings.Contains(tail, "/Type /XRef"); got != tt.xrefStream {
+				t.Errorf("update has xref stream = %v, want %v", got, tt.xrefStream)
+			}
+			if !tt.xrefStream && !regexp.MustCompile(`xref\n\d+ 1\n\d{10} 00000 n \n\d+ 1\n`).MatchString(tail) {
+				t.Errorf("xref table should have one subsection per object, got:\n%s", tail)
+			}
+		})
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	path, original := writeXRefStreamTestDoc(t, types.PDF14)
+
+	if err := AppendTo(path, annotationUpdate(t, original)); err != nil {
+		t.Fatalf("AppendTo() error = %v", err)
+	}
+
+	updated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	checkIncrementalUpdate(t, original, updated)
+}
+
+func TestPdfWriter_WriteIncrementalErrors(t *testing.T) {
+	_, original := writeXRefStreamTestDoc(t, types.PDF14)
+	obj := NewIndirectObject(1, 0, []byte("<< >>"))
+
+	tests := []struct {
+		name     string
+		original []byte
+		objects  []*IndirectObject
+	}{
+		{"no startxref", []byte("%PDF-1.4\n"), []*IndirectObject{obj}},
+		{"no objects", original, nil},
+		{"duplicate object", original, []*IndirectObject{obj, obj}},
+		{"encrypted", bytes.Replace(original, []byte("/Root"), []byte("/Encrypt 9 0 R /Root"), 1), []*IndirectObject{obj}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewPdfWriterFromWriter(&bytes.Buffer{})
+			if err := w.WriteIncremental(tt.original, tt.objects); err == nil {
+				t.Error("WriteIncremental() should fail")
+			}
+		})
+	}
+}
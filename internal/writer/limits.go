@@ -0,0 +1,37 @@
+package writer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is returned when writing a document would exceed a limit
+// set with SetLimits.
+var ErrLimitExceeded = errors.New("document limit exceeded")
+
+// SetLimits caps the number of indirect objects and the output size in
+// bytes. A value of 0 means no limit. Must be called before writing.
+//
+// The object limit is checked as object numbers are allocated; the byte
+// limit is checked before each object is written, so the output can
+// exceed it by at most one object.
+func (w *PdfWriter) SetLimits(maxObjects, maxBytes int64) {
+	w.maxObjects = maxObjects
+	w.maxBytes = maxBytes
+}
+
+// checkObjectLimit records an error once more than maxObjects object
+// numbers have been allocated.
+func (w *PdfWriter) checkObjectLimit(num int) {
+	if w.maxObjects > 0 && int64(num) > w.maxObjects && w.limitErr == nil {
+		w.limitErr = fmt.Errorf("%w: more than %d objects", ErrLimitExceeded, w.maxObjects)
+	}
+}
+
+// checkByteLimit returns an error if offset exceeds maxBytes.
+func (w *PdfWriter) checkByteLimit(offset int64) error {
+	if w.maxBytes > 0 && offset > w.maxBytes {
+		return fmt.Errorf("%w: more than %d bytes", ErrLimitExceeded, w.maxBytes)
+	}
+	return nil
+}
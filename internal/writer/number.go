@@ -0,0 +1,67 @@
+package writer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// numberPrecision is the number of decimal places written for real numbers.
+const numberPrecision = 2
+
+// matrixPrecision is the number of decimal places written for matrix
+// coefficients. Rotation and scale factors are multiplied by every
+// coordinate they transform, so rounding cos 30° to 0.87 would move a
+// point 500 units from the origin by about 2 units.
+const matrixPrecision = 5
+
+// formatNumber formats a real number operand for PDF output.
+//
+// Values are rounded to numberPrecision decimal places. Values that round
+// to an integer are written without a fractional part, and negative zero
+// is written as 0:
+//
+//	formatNumber(100.0)  // "100"
+//	formatNumber(-0.001) // "0"
+//	formatNumber(0.5)    // "0.50"
+//
+// Reference: PDF 1.7 Specification, Section 7.3.3 (Numeric Objects).
+func formatNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', numberPrecision, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 && strings.Trim(s[i+1:], "0") == "" {
+		s = s[:i]
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// formatMatrix formats the coefficients of a transformation matrix for PDF
+// output, separated by spaces.
+//
+// Coefficients are rounded to matrixPrecision decimal places and written
+// without trailing zeros:
+//
+//	formatMatrix(0.8660254, 0.5, -0.5, 0.8660254, 100, 200)
+//	// "0.86603 0.5 -0.5 0.86603 100 200"
+func formatMatrix(a, b, c, d, e, f float64) string {
+	parts := make([]string, 0, 6)
+	for _, v := range [...]float64{a, b, c, d, e, f} {
+		s := strconv.FormatFloat(v, 'f', matrixPrecision, 64)
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+		if s == "-0" {
+			s = "0"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatNumbers formats real numbers with formatNumber, separated by spaces.
+func formatNumbers(vs ...float64) string {
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = formatNumber(v)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,66 @@
+package writer
+
+import "testing"
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{100.0, "100"},
+		{-0.001, "0"},
+		{-0.004, "0"},
+		{0, "0"},
+		{-3, "-3"},
+		{99.999, "100"},
+		{0.5, "0.50"},
+		{-0.25, "-0.25"},
+		{595.28, "595.28"},
+		{12.345, "12.35"},
+	}
+
+	for _, tt := range tests {
+		if got := formatNumber(tt.in); got != tt.want {
+			t.Errorf("formatNumber(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatNumbers(t *testing.T) {
+	if got := formatNumbers(100, -0.001, 0.5); got != "100 0 0.50" {
+		t.Errorf("formatNumbers() = %q, want %q", got, "100 0 0.50")
+	}
+	if got := formatNumbers(); got != "" {
+		t.Errorf("formatNumbers() with no values = %q, want empty", got)
+	}
+}
+
+func TestFormatMatrix(t *testing.T) {
+	tests := []struct {
+		m    [6]float64
+		want string
+	}{
+		{[6]float64{1, 0, 0, 1, 0, 0}, "1 0 0 1 0 0"},
+		{[6]float64{0.8660254, 0.5, -0.5, 0.8660254, 100, 200}, "0.86603 0.5 -0.5 0.86603 100 200"},
+		{[6]float64{0.333333, 0, 0, 1.25, 10.125, -0.000001}, "0.33333 0 0 1.25 10.125 0"},
+		{[6]float64{-1, 0, 0, 1, 595.28, 0}, "-1 0 0 1 595.28 0"},
+	}
+
+	for _, tt := range tests {
+		m := tt.m
+		if got := formatMatrix(m[0], m[1], m[2], m[3], m[4], m[5]); got != tt.want {
+			t.Errorf("formatMatrix(%v) = %q, want %q", m, got, tt.want)
+		}
+	}
+}
+
+func TestContentStreamWriter_NoNegativeZero(t *testing.T) {
+	csw := NewContentStreamWriter()
+	csw.MoveTo(-0.001, 100.0)
+	csw.SetFillColorRGB(1, 0, -0.0001)
+
+	want := "0 100 m\n1 0 0 rg\n"
+	if got := csw.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// writeObjectStreamTestDoc writes a text document with one line per page.
+func writeObjectStreamTestDoc(t *testing.T, pages int, objectStreams bool) (string, int) {
+	t.Helper()
+
+	doc := document.NewDocument()
+	doc.SetMetadata("Object Streams", "Test Author", "")
+	textOps := make(map[int][]TextOp, pages)
+	for i := 0; i < pages; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+		textOps[i] = []TextOp{{Text: fmt.Sprintf("Page %d", i+1), X: 72, Y: 770, Font: "Helvetica", Size: 12}}
+	}
+
+	path := filepath.Join(t.TempDir(), "objstm.pdf")
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetObjectStreams(objectStreams)
+	if err := w.WriteWithAllContent(doc, textOps, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	return path, buf.Len()
+}
+
+func TestPdfWriter_ObjectStreamsRoundTrip(t *testing.T) {
+	const pages = 250
+	path, _ := writeObjectStreamTestDoc(t, pages, true)
+
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	count, err := r.GetPageCount()
+	if err != nil {
+		t.Fatalf("GetPageCount() error = %v", err)
+	}
+	if count != pages {
+		t.Fatalf("page count = %d, want %d", count, pages)
+	}
+
+	for i := 0; i < pages; i++ {
+		page, err := r.GetPage(i)
+		if err != nil {
+			t.Fatalf("GetPage(%d) error = %v", i, err)
+		}
+		if page.GetName("Type") == nil || page.GetName("Type").Value() != "Page" {
+			t.Fatalf("page %d: /Type = %v, want /Page", i, page.Get("Type"))
+		}
+	}
+
+	if info := r.GetDocumentInfo(); info.Title != "Object Streams" {
+		t.Errorf("Info Title = %q, want %q", info.Title, "Object Streams")
+	}
+}
+
+func TestPdfWriter_ObjectStreamsLayout(t *testing.T) {
+	doc := document.NewDocument()
+	for i := 0; i < 3; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetObjectStreams(true)
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{"/Type /ObjStm", "/First ", "/Type /XRef", "/Type /Catalog"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("output should contain %q", want)
+		}
+	}
+	if strings.Contains(content, "/Type /Page ") {
+		t.Error("page dictionaries should be packed into an object stream")
+	}
+	if len(w.compressed) == 0 {
+		t.Error("no objects were recorded as compressed")
+	}
+}
+
+func TestPdfWriter_ObjectStreamsSmaller(t *testing.T) {
+	_, plain := writeObjectStreamTestDoc(t, 500, false)
+	_, packed := writeObjectStreamTestDoc(t, 500, true)
+
+	if packed*10 > plain*7 {
+		t.Errorf("object streams size = %d, plain = %d; want at least 30%% smaller", packed, plain)
+	}
+}
+
+func TestPdfWriter_ObjectStreamsPageChunked(t *testing.T) {
+	doc := document.NewDocument()
+	textOps := make(map[int][]TextOp, 3)
+	for i := 0; i < 3; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+		textOps[i] = []TextOp{{Text: fmt.Sprintf("Page %d", i+1), X: 72, Y: 770, Font: "Helvetica", Size: 12}}
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetObjectStreams(true)
+	w.SetPageChunked(true)
+	if err := w.WriteWithAllContent(doc, textOps, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	content := buf.String()
+
+	// Page dictionaries stay in their chunks, in page order.
+	if strings.Contains(content, "/Type /ObjStm") {
+		t.Error("page-chunked output should not use object streams")
+	}
+	if len(w.compressed) != 0 {
+		t.Errorf("%d objects recorded as compressed, want 0", len(w.compressed))
+	}
+	if got := strings.Count(content, "/Type /Page "); got != 3 {
+		t.Errorf("found %d page dictionaries in the file, want 3", got)
+	}
+	if !strings.Contains(content, "/Type /XRef") {
+		t.Error("output should still use a cross-reference stream")
+	}
+
+	path := filepath.Join(t.TempDir(), "chunked.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if count, err := r.GetPageCount(); err != nil || count != 3 {
+		t.Errorf("GetPageCount() = %d, %v; want 3", count, err)
+	}
+}
@@ -0,0 +1,133 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// OutlineItem is an entry of the document outline (bookmarks).
+type OutlineItem struct {
+	// Title is the text shown in the outline.
+	Title string
+
+	// PageIndex is the target page (0-based).
+	PageIndex int
+
+	// Top is the top of the view on the target page, in points from the
+	// bottom edge (0 = top of the page).
+	Top float64
+
+	// Children are the nested items, shown below this one.
+	Children []*OutlineItem
+}
+
+// SetOutline sets the document outline. Items whose page does not exist
+// are left out, with their children. Pass nil to write no outline. Must
+// be called before writing.
+func (w *PdfWriter) SetOutline(items []*OutlineItem) {
+	w.outline = items
+}
+
+// outlineNode is an outline item with its object number assigned.
+type outlineNode struct {
+	item     *OutlineItem
+	num      int
+	children []*outlineNode
+}
+
+// createOutlineObjects creates the outline dictionary and one object per
+// item, or returns nil if there is no outline. Must be called after the
+// page tree is created.
+//
+// Items are linked to their siblings with /Prev and /Next and to their
+// parent with /Parent; each parent points to its first and last child
+// with /First and /Last. All items are open, so /Count is the number of
+// descendants. Each item jumps to its page with an /XYZ destination that
+// keeps the current zoom:
+//
+//	<< /Title (Chapter 1) /Parent 12 0 R /Next 14 0 R /First 15 0 R
+//	   /Last 16 0 R /Count 2 /Dest [3 0 R /XYZ 0 842 null] >>
+//
+// Reference: PDF 1.7 Specification, Section 12.3.3 (Document Outline)
+// and 12.3.2.2 (Explicit Destinations).
+func (w *PdfWriter) createOutlineObjects(doc *document.Document) []*IndirectObject {
+	w.outlineNum = 0
+	if len(w.outline) == 0 {
+		return nil
+	}
+
+	nodes := w.outlineNodes(w.outline)
+	if len(nodes) == 0 {
+		return nil
+	}
+	w.outlineNum = w.allocateObjNum()
+	w.assignOutlineNums(nodes)
+
+	root := fmt.Sprintf("<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count %d >>",
+		nodes[0].num, nodes[len(nodes)-1].num, countOutlineNodes(nodes))
+	objs := []*IndirectObject{NewIndirectObject(w.outlineNum, 0, []byte(root))}
+	return w.appendOutlineItems(objs, doc, nodes, w.outlineNum)
+}
+
+// outlineNodes drops items that point to missing pages.
+func (w *PdfWriter) outlineNodes(items []*OutlineItem) []*outlineNode {
+	var nodes []*outlineNode
+	for _, item := range items {
+		if item == nil || item.PageIndex < 0 || item.PageIndex >= len(w.pageNums) {
+			continue
+		}
+		nodes = append(nodes, &outlineNode{item: item, children: w.outlineNodes(item.Children)})
+	}
+	return nodes
+}
+
+// assignOutlineNums numbers the items in document order.
+func (w *PdfWriter) assignOutlineNums(nodes []*outlineNode) {
+	for _, node := range nodes {
+		node.num = w.allocateObjNum()
+		w.assignOutlineNums(node.children)
+	}
+}
+
+// countOutlineNodes returns the number of nodes and their descendants.
+func countOutlineNodes(nodes []*outlineNode) int {
+	count := len(nodes)
+	for _, node := range nodes {
+		count += countOutlineNodes(node.children)
+	}
+	return count
+}
+
+// appendOutlineItems appends the objects of the sibling items nodes,
+// children of parentNum, and of their descendants.
+func (w *PdfWriter) appendOutlineItems(objs []*IndirectObject, doc *document.Document, nodes []*outlineNode, parentNum int) []*IndirectObject {
+	for i, node := range nodes {
+		var buf bytes.Buffer
+		buf.WriteString("<< /Title " + FormatTextString(node.item.Title))
+		fmt.Fprintf(&buf, " /Parent %d 0 R", parentNum)
+		if i > 0 {
+			fmt.Fprintf(&buf, " /Prev %d 0 R", nodes[i-1].num)
+		}
+		if i < len(nodes)-1 {
+			fmt.Fprintf(&buf, " /Next %d 0 R", nodes[i+1].num)
+		}
+		if len(node.children) > 0 {
+			fmt.Fprintf(&buf, " /First %d 0 R /Last %d 0 R /Count %d",
+				node.children[0].num, node.children[len(node.children)-1].num, countOutlineNodes(node.children))
+		}
+
+		top := node.item.Top
+		if top == 0 {
+			if page, err := doc.Page(node.item.PageIndex); err == nil {
+				_, top = page.MediaBox().UpperRight()
+			}
+		}
+		fmt.Fprintf(&buf, " /Dest [%d 0 R /XYZ 0 %s null] >>", w.pageNums[node.item.PageIndex], formatNumber(top))
+
+		objs = append(objs, NewIndirectObject(node.num, 0, buf.Bytes()))
+		objs = w.appendOutlineItems(objs, doc, node.children, node.num)
+	}
+	return objs
+}
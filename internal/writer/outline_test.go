@@ -0,0 +1,223 @@
+package writer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+func TestPdfWriter_Outline(t *testing.T) {
+	// Every write path emits the outline.
+	for _, tt := range []struct {
+		name  string
+		write func(w *PdfWriter, doc *document.Document) error
+	}{
+		{"Write", func(w *PdfWriter, doc *document.Document) error { return w.Write(doc) }},
+		{"WriteWithPageContent", func(w *PdfWriter, doc *document.Document) error {
+			return w.WriteWithPageContent(doc, nil)
+		}},
+		{"WriteWithAllContent", func(w *PdfWriter, doc *document.Document) error {
+			return w.WriteWithAllContent(doc, nil, nil)
+		}},
+		{"WriteStreamPage", func(w *PdfWriter, doc *document.Document) error {
+			if err := w.BeginStream(doc); err != nil {
+				return err
+			}
+			for _, page := range doc.Pages() {
+				if err := w.WriteStreamPage(page, nil, nil); err != nil {
+					return err
+				}
+			}
+			return w.EndStream(doc)
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			testOutline(t, tt.write)
+		})
+	}
+}
+
+// testOutline writes a three-page document with a two-level outline and
+// checks the outline tree of the output.
+func testOutline(t *testing.T, write func(w *PdfWriter, doc *document.Document) error) {
+	t.Helper()
+
+	doc := document.NewDocument()
+	for i := 0; i < 3; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetOutline([]*OutlineItem{
+		{Title: "Chapter 1", PageIndex: 0, Children: []*OutlineItem{
+			{Title: "Section 1.1", PageIndex: 0, Top: 400},
+			{Title: "Section 1.2", PageIndex: 1},
+		}},
+		{Title: "Chapter 2", PageIndex: 2},
+		{Title: "Missing", PageIndex: 3}, // Left out: no such page
+	})
+	if err := write(w, doc); err != nil {
+		t.Fatalf("write error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "outline.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	catalog, err := r.GetCatalog()
+	if err != nil {
+		t.Fatalf("GetCatalog() error = %v", err)
+	}
+	rootRef, ok := catalog.Get("Outlines").(*parser.IndirectReference)
+	if !ok {
+		t.Fatalf("/Outlines = %v, want an indirect reference", catalog.Get("Outlines"))
+	}
+	pages, err := r.GetPages()
+	if err != nil {
+		t.Fatalf("GetPages() error = %v", err)
+	}
+	kids := pages.GetArray("Kids")
+
+	dict := func(ref parser.PdfObject) (int, *parser.Dictionary) {
+		t.Helper()
+		indirect, ok := ref.(*parser.IndirectReference)
+		if !ok {
+			t.Fatalf("entry = %v, want an indirect reference", ref)
+		}
+		obj, err := r.GetObject(indirect.Number)
+		if err != nil {
+			t.Fatalf("GetObject(%d) error = %v", indirect.Number, err)
+		}
+		d, ok := obj.(*parser.Dictionary)
+		if !ok {
+			t.Fatalf("object %d = %T, want dictionary", indirect.Number, obj)
+		}
+		return indirect.Number, d
+	}
+	refNum := func(d *parser.Dictionary, key string) int {
+		if ref, ok := d.Get(key).(*parser.IndirectReference); ok {
+			return ref.Number
+		}
+		return 0
+	}
+	// checkItems walks the children of parent and checks their pointers,
+	// titles, counts and destinations.
+	type want struct {
+		title string
+		page  int
+		top   float64
+		count int
+	}
+	checkItems := func(parentNum int, parent *parser.Dictionary, items []want) []int {
+		t.Helper()
+		var nums []int
+		prev := 0
+		for next := parent.Get("First"); next != nil; {
+			num, item := dict(next)
+			if len(nums) == len(items) {
+				t.Fatalf("more than %d items under object %d", len(items), parentNum)
+			}
+			w := items[len(nums)]
+			if title := item.GetString("Title"); title != w.title {
+				t.Errorf("/Title = %q, want %q", title, w.title)
+			}
+			if got := refNum(item, "Parent"); got != parentNum {
+				t.Errorf("%s: /Parent = %d, want %d", w.title, got, parentNum)
+			}
+			if got := refNum(item, "Prev"); got != prev {
+				t.Errorf("%s: /Prev = %d, want %d", w.title, got, prev)
+			}
+			if got := item.GetInteger("Count"); int(got) != w.count {
+				t.Errorf("%s: /Count = %d, want %d", w.title, got, w.count)
+			}
+
+			dest := item.GetArray("Dest")
+			if dest == nil || dest.Len() != 5 {
+				t.Fatalf("%s: /Dest = %v, want [page /XYZ left top zoom]", w.title, item.Get("Dest"))
+			}
+			pageRef, _ := dest.Get(0).(*parser.IndirectReference)
+			kid, _ := kids.Get(w.page).(*parser.IndirectReference)
+			if pageRef == nil || kid == nil || pageRef.Number != kid.Number {
+				t.Errorf("%s: /Dest page = %v, want page %d (%v)", w.title, dest.Get(0), w.page, kids.Get(w.page))
+			}
+			if name, ok := dest.Get(1).(*parser.Name); !ok || name.Value() != "XYZ" {
+				t.Errorf("%s: /Dest type = %v, want /XYZ", w.title, dest.Get(1))
+			}
+			if top := getFloat(dest.Get(3)); top != w.top {
+				t.Errorf("%s: /Dest top = %v, want %v", w.title, top, w.top)
+			}
+			if _, ok := dest.Get(4).(*parser.Null); !ok {
+				t.Errorf("%s: /Dest zoom = %v, want null", w.title, dest.Get(4))
+			}
+
+			nums = append(nums, num)
+			prev = num
+			next = item.Get("Next")
+		}
+		if len(nums) != len(items) {
+			t.Fatalf("got %d items under object %d, want %d", len(nums), parentNum, len(items))
+		}
+		if got := refNum(parent, "Last"); got != prev {
+			t.Errorf("/Last of object %d = %d, want %d", parentNum, got, prev)
+		}
+		return nums
+	}
+
+	rootNum, root := dict(rootRef)
+	if typ := root.GetName("Type"); typ == nil || typ.Value() != "Outlines" {
+		t.Errorf("/Type = %v, want /Outlines", root.Get("Type"))
+	}
+	if count := root.GetInteger("Count"); count != 4 {
+		t.Errorf("outline /Count = %d, want 4", count)
+	}
+	chapters := checkItems(rootNum, root, []want{
+		{title: "Chapter 1", page: 0, top: 842, count: 2},
+		{title: "Chapter 2", page: 2, top: 842},
+	})
+	_, chapter1 := dict(parser.NewIndirectReference(chapters[0], 0))
+	checkItems(chapters[0], chapter1, []want{
+		{title: "Section 1.1", page: 0, top: 400},
+		{title: "Section 1.2", page: 1, top: 842},
+	})
+}
+
+func TestPdfWriter_NoOutline(t *testing.T) {
+	doc := document.NewDocument()
+	if _, err := doc.AddPage(document.A4); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetOutline([]*OutlineItem{{Title: "Missing", PageIndex: 1}})
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("/Outlines")) {
+		t.Error("PDF should not contain /Outlines when no item points to a page")
+	}
+}
+
+// getFloat returns the value of a parsed number, or -1.
+func getFloat(obj parser.PdfObject) float64 {
+	switch v := obj.(type) {
+	case *parser.Integer:
+		return float64(v.Value())
+	case *parser.Real:
+		return v.Value()
+	}
+	return -1
+}
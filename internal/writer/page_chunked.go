@@ -0,0 +1,47 @@
+package writer
+
+// SetPageChunked enables page-chunked object ordering. Must be called
+// before writing.
+//
+// In page-chunked output the file is laid out as:
+//
+//	header
+//	catalog, Pages root, document-level form objects
+//	page 1: Page object, content stream, fonts, images, ExtGStates, annotations
+//	page 2: ...
+//	xref table and trailer
+//
+// Every object a page depends on is written inside that page's chunk, and
+// chunks appear in page order with no objects between them. A client that
+// has received the file up to the end of page N's chunk (plus the leading
+// document-level objects) can render pages 1 to N without the rest of the
+// file. Resources are never shared between pages, so no chunk refers
+// forward into a later one.
+//
+// This is not linearization: there is no linearization dictionary or hint
+// stream and the cross-reference table remains at the end of the file, so
+// clients must locate chunks by parsing objects as they arrive rather than
+// from an up-front index.
+//
+// Reference: PDF 1.7 Specification, Section 7.5 (File Structure).
+func (w *PdfWriter) SetPageChunked(chunked bool) {
+	w.pageChunked = chunked
+}
+
+// orderObjects returns the objects in write order.
+//
+// pagesObjs is the Pages root followed by the page chunks, as returned by
+// createPageTreeWithAllContent; docObjs are document-level objects created
+// after the page tree. By default they follow the pages; in page-chunked
+// mode they precede them so that the chunks end the object sequence.
+func (w *PdfWriter) orderObjects(catalog *IndirectObject, pagesObjs, docObjs []*IndirectObject) []*IndirectObject {
+	objects := make([]*IndirectObject, 0, 1+len(pagesObjs)+len(docObjs))
+	objects = append(objects, catalog)
+	if w.pageChunked && len(pagesObjs) > 0 {
+		objects = append(objects, pagesObjs[0])
+		objects = append(objects, docObjs...)
+		return append(objects, pagesObjs[1:]...)
+	}
+	objects = append(objects, pagesObjs...)
+	return append(objects, docObjs...)
+}
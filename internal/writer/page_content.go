@@ -40,8 +40,51 @@ type TextOp struct {
 	// When set, this takes precedence over the Font field.
 	// The font must be registered with the document before use.
 	CustomFont *EmbeddedFont
+
+	// Continued appends the text to the previous operation's text object.
+	// Only the color and font are set (no BT or Td), so the text starts
+	// where the previous text ended. X and Y are ignored.
+	Continued bool
+
+	// Rotation rotates the text counter-clockwise about (X, Y), in degrees.
+	// When set, the position is written as a text matrix (Tm) instead of Td.
+	Rotation float64
+
+	// Matrix is the [a b c d] part of a text matrix that transforms the
+	// text about (X, Y), for rotated, skewed or mirrored text. When not all
+	// zero it takes precedence over Rotation and is written with Tm.
+	Matrix [4]float64
+
+	// CharSpacing is extra space added after each glyph (Tc), in points.
+	// It is reset after the text is shown.
+	CharSpacing float64
+
+	// WordSpacing is extra space added to each space character (Tw), in
+	// points. It is reset after the text is shown. Standard 14 fonts only.
+	WordSpacing float64
+
+	// Rise moves the baseline up (positive) or down (negative) by the given
+	// points (Ts), for superscripts and subscripts. It is reset after the
+	// text is shown.
+	Rise float64
+
+	// Underline and Strikethrough draw a line below the baseline or
+	// through the lowercase letters, DecorationWidth points long (the
+	// measured text width), in the text color. The lines are drawn after
+	// the text object ends.
+	Underline       bool
+	Strikethrough   bool
+	DecorationWidth float64
 }
 
+// Text decoration geometry, relative to the font size. The values match
+// Helvetica's AFM underline metrics and half its x-height.
+const (
+	underlinePosition       = -0.1 // Underline center below the baseline
+	strikethroughPosition   = 0.26 // Strikethrough center above the baseline
+	textDecorationThickness = 0.05 // Line width of both decorations
+)
+
 // EmbeddedFont represents a custom TrueType/OpenType font for embedding.
 //
 // This is used internally to pass font data from Creator to Writer.
@@ -85,15 +128,32 @@ type BezierSegment struct {
 	End   Point
 }
 
+// PathSegment is one construction operator of a general path (Type == 27):
+// 'm' (move to End), 'l' (line to End), 'c' (curve through C1 and C2 to
+// End) or 'h' (close subpath).
+type PathSegment struct {
+	Op  byte
+	C1  Point
+	C2  Point
+	End Point
+}
+
 // ImageData represents image data for embedding in PDF.
 type ImageData struct {
 	Data             []byte // Raw image data (JPEG bytes or compressed PNG pixels)
 	AlphaMask        []byte // Alpha mask data for PNG with transparency
 	Width            int    // Image width in pixels
 	Height           int    // Image height in pixels
-	ColorSpace       string // Color space: "DeviceRGB", "DeviceCMYK", "DeviceGray"
-	Format           string // Image format: "jpeg" or "png"
+	ColorSpace       string // Color space: "DeviceRGB", "DeviceCMYK", "DeviceGray", "Indexed"
+	Format           string // Image format: "jpeg", "png", or a custom encoder name
+	Filter           string // Explicit /Filter from a custom encoder (overrides Format)
 	BitsPerComponent int    // Bits per component (usually 8)
+	DataRaw          bool   // Data holds uncompressed samples (no /Filter)
+	AlphaMaskRaw     bool   // AlphaMask holds uncompressed samples (no /Filter)
+	Decode           string // Optional /Decode array, e.g. "[1 0]"
+	DecodeParms      string // Optional /DecodeParms dictionary for Filter
+	Palette          []byte // RGB triplets of an "Indexed" image's color table
+	ImageMask        bool   // 1-bit stencil mask painted in the fill color
 }
 
 // GraphicsOp represents a graphics drawing operation.
@@ -112,8 +172,9 @@ type GraphicsOp struct {
 	Y2 float64
 
 	// Rectangle fields
-	Width  float64
-	Height float64
+	Width       float64
+	Height      float64
+	CornerRadii [4]float64 // Rounded corners: lower-left, lower-right, upper-right, upper-left (0 = sharp)
 
 	// Circle fields
 	Radius float64
@@ -122,15 +183,27 @@ type GraphicsOp struct {
 	RX float64 // Horizontal radius
 	RY float64 // Vertical radius
 
-	// Polygon/Polyline fields
+	// Polygon/Polyline fields (also a polygon clip path for Type == 20)
 	Vertices []Point
 
-	// Bezier fields
+	// Bezier fields (also a curved clip path for Type == 20)
 	BezierSegs []BezierSegment
 	Closed     bool // For Bezier curves
 
+	// General path fields (for Type == 27)
+	Path []PathSegment
+
+	// Transform fields (for Type == 25): a b c d e f of the cm operator.
+	// Also the placement of the form for Type == 26.
+	Matrix [6]float64
+
+	// Form XObject fields (for Type == 26)
+	Form *FormXObject
+
 	// Image fields (for Type == 3)
-	Image *ImageData
+	Image   *ImageData
+	AltText string // Alternate text; tags the image as a /Figure
+	MCID    int    // Marked-content ID of the Figure (assigned by the writer)
 
 	// Appearance
 	StrokeColor     *RGB
@@ -142,10 +215,18 @@ type GraphicsOp struct {
 	Dashed          bool
 	DashArray       []float64
 	DashPhase       float64
+	LineCap         int     // 0=butt (default), 1=round, 2=square
+	LineJoin        int     // 0=miter (default), 1=round, 2=bevel
+	MiterLimit      float64 // Miter limit for miter joins (0 = PDF default)
+	EvenOdd         bool    // Fill with the even-odd rule (f*, B*) instead of nonzero
 
 	// Clipping
 	IsClipPath bool // If true, this shape defines a clipping path (not drawn)
 
+	// Graphics state parameters (written via an ExtGState)
+	StrokeAdjust bool // /SA: adjust strokes to the device pixel grid
+	Overprint    bool // /OP, /op, /OPM 1: do not knock out underlying colorants
+
 	// TextBlock fields (for Type == 22)
 	Text       string
 	TextFont   *EmbeddedFont
@@ -234,19 +315,37 @@ func GenerateContentStream(textOps []TextOp) (content []byte, resources *Resourc
 //   - resources: The resource dictionary for fonts used
 //   - error: Any error that occurred
 func GenerateContentStreamWithGraphics(textOps []TextOp, graphicsOps []GraphicsOp) (content []byte, resources *ResourceDictionary, err error) {
+	return GenerateContentStreamWithStyle(textOps, graphicsOps, ContentStyleDefault)
+}
+
+// GenerateContentStreamWithStyle generates a content stream like
+// GenerateContentStreamWithGraphics, laying out operators in the given style.
+func GenerateContentStreamWithStyle(textOps []TextOp, graphicsOps []GraphicsOp, style ContentStyle) (content []byte, resources *ResourceDictionary, err error) {
 	if len(textOps) == 0 && len(graphicsOps) == 0 {
 		// Empty content stream
 		return []byte{}, NewResourceDictionary(), nil
 	}
 
 	csw := NewContentStreamWriter()
+	csw.SetStyle(style)
 	resources = NewResourceDictionary()
 
 	// STEP 1: Draw graphics FIRST (so text appears on top)
+	depth := 0 // Open clips and saved states
 	for _, gop := range graphicsOps {
 		if err := renderGraphicsOp(csw, gop, resources); err != nil {
 			return nil, nil, fmt.Errorf("failed to render graphics: %w", err)
 		}
+		switch gop.Type {
+		case 20, 23:
+			depth++
+		case 21, 24:
+			depth--
+		}
+	}
+	// Close clips and transforms left open, so they do not affect the text.
+	for ; depth > 0; depth-- {
+		csw.RestoreState()
 	}
 
 	// STEP 2: Draw text
@@ -254,7 +353,8 @@ func GenerateContentStreamWithGraphics(textOps []TextOp, graphicsOps []GraphicsO
 	// Key is either standard font name or custom font ID.
 	usedFonts := make(map[string]string) // font key -> resource name
 
-	for _, op := range textOps {
+	var decorated []TextOp // Decorated runs of the current text object
+	for i, op := range textOps {
 		// Determine font key (custom font ID or standard font name).
 		var fontKey string
 		if op.CustomFont != nil {
@@ -274,21 +374,36 @@ func GenerateContentStreamWithGraphics(textOps []TextOp, graphicsOps []GraphicsO
 			usedFonts[fontKey] = fontResName
 		}
 
-		// Begin text object
-		csw.BeginText()
+		// Begin text object, unless this run continues the previous one
+		continued := op.Continued && i > 0
+		if !continued {
+			csw.BeginText()
+		}
 
 		// Set color (CMYK takes precedence over RGB)
 		if op.ColorCMYK != nil {
 			csw.SetFillColorCMYK(op.ColorCMYK.C, op.ColorCMYK.M, op.ColorCMYK.Y, op.ColorCMYK.K)
 		} else {
-			csw.SetFillColorRGB(op.Color.R, op.Color.G, op.Color.B)
+			fillRGB(csw, op.Color.R, op.Color.G, op.Color.B)
 		}
 
 		// Set font and size
 		csw.SetFont(fontResName, op.Size)
 
 		// Set position
-		csw.MoveTextPosition(op.X, op.Y)
+		if !continued {
+			moveText(csw, op)
+		}
+
+		if op.CharSpacing != 0 {
+			csw.SetCharSpacing(op.CharSpacing)
+		}
+		if op.WordSpacing != 0 {
+			csw.SetWordSpacing(op.WordSpacing)
+		}
+		if op.Rise != 0 {
+			csw.SetTextRise(op.Rise)
+		}
 
 		// Show text (for custom fonts, encode using glyph IDs)
 		if op.CustomFont != nil {
@@ -297,49 +412,138 @@ func GenerateContentStreamWithGraphics(textOps []TextOp, graphicsOps []GraphicsO
 			csw.ShowText(op.Text)
 		}
 
-		// End text object
-		csw.EndText()
+		// Spacing and rise are part of the graphics state and outlive ET.
+		if op.CharSpacing != 0 {
+			csw.SetCharSpacing(0)
+		}
+		if op.WordSpacing != 0 {
+			csw.SetWordSpacing(0)
+		}
+		if op.Rise != 0 {
+			csw.SetTextRise(0)
+		}
+
+		if op.Underline || op.Strikethrough {
+			decorated = append(decorated, op)
+		}
+
+		// End text object, unless the next run continues it
+		if i+1 == len(textOps) || !textOps[i+1].Continued {
+			csw.EndText()
+			drawTextDecorations(csw, decorated)
+			decorated = decorated[:0]
+		}
 	}
 
 	return csw.Bytes(), resources, nil
 }
 
+// moveText positions a text operation, rotating or transforming it about
+// its origin if needed.
+func moveText(csw *ContentStreamWriter, op TextOp) {
+	m, ok := textTransform(op)
+	if !ok {
+		csw.MoveTextPosition(op.X, op.Y)
+		return
+	}
+	csw.SetTextMatrix(m[0], m[1], m[2], m[3], op.X, op.Y)
+}
+
+// textTransform returns the [a b c d] text matrix of a rotated or
+// transformed text operation, or false for upright text.
+func textTransform(op TextOp) ([4]float64, bool) {
+	if op.Matrix != [4]float64{} {
+		return op.Matrix, true
+	}
+	if op.Rotation == 0 {
+		return [4]float64{1, 0, 0, 1}, false
+	}
+	radians := op.Rotation * math.Pi / 180.0
+	cos, sin := math.Cos(radians), math.Sin(radians)
+	return [4]float64{cos, sin, -sin, cos}, true
+}
+
+// drawTextDecorations draws the underline and strikethrough lines of the
+// runs of a text object. Path operators are not allowed inside BT/ET, so
+// the lines follow the text object. They are transformed like the text and
+// shifted by its rise.
+func drawTextDecorations(csw *ContentStreamWriter, ops []TextOp) {
+	for _, op := range ops {
+		var offsets []float64
+		if op.Underline {
+			offsets = append(offsets, underlinePosition*op.Size+op.Rise)
+		}
+		if op.Strikethrough {
+			offsets = append(offsets, strikethroughPosition*op.Size+op.Rise)
+		}
+
+		csw.SaveState()
+		if op.ColorCMYK != nil {
+			csw.SetStrokeColorCMYK(op.ColorCMYK.C, op.ColorCMYK.M, op.ColorCMYK.Y, op.ColorCMYK.K)
+		} else {
+			strokeRGB(csw, op.Color.R, op.Color.G, op.Color.B)
+		}
+		csw.SetLineWidth(textDecorationThickness * op.Size)
+
+		m, _ := textTransform(op)
+		for _, dy := range offsets {
+			csw.MoveTo(op.X+m[2]*dy, op.Y+m[3]*dy)
+			csw.LineTo(op.X+m[0]*op.DecorationWidth+m[2]*dy, op.Y+m[1]*op.DecorationWidth+m[3]*dy)
+			csw.Stroke()
+		}
+		csw.RestoreState()
+	}
+}
+
 // renderGraphicsOp renders a single graphics operation to the content stream.
 func renderGraphicsOp(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
-	// Clipping and text operations manage their own state - don't wrap them.
-	if gop.Type == 20 || gop.Type == 21 || gop.Type == 22 {
-		switch gop.Type {
-		case 20: // BeginClipRect - starts a clipping region
-			return renderBeginClipRect(csw, gop)
-		case 21: // EndClip - ends clipping region
-			return renderEndClip(csw)
-		case 22: // TextBlock - text rendered inline with graphics
-			return renderTextBlock(csw, gop, resources)
-		}
+	// Clipping, state and text operations manage their own state - don't wrap them.
+	switch gop.Type {
+	case 20: // BeginClip - starts a clipping region
+		return renderBeginClip(csw, gop)
+	case 21: // EndClip - ends clipping region
+		return renderEndClip(csw)
+	case 22: // TextBlock - text rendered inline with graphics
+		return renderTextBlock(csw, gop, resources)
+	case 23: // SaveState - starts a transformed group
+		csw.SaveState()
+		return nil
+	case 24: // RestoreState - ends a transformed group
+		csw.RestoreState()
+		return nil
+	case 25: // Transform - concatenates Matrix to the CTM
+		m := gop.Matrix
+		csw.ConcatMatrix(m[0], m[1], m[2], m[3], m[4], m[5])
+		return nil
 	}
 
 	// Save graphics state for regular drawing operations.
 	csw.SaveState()
+	applyExtGState(csw, gop, resources)
 
 	switch gop.Type {
 	case 0: // Line
 		return renderLine(csw, gop)
 	case 1: // Rectangle
-		return renderRect(csw, gop)
+		return renderRect(csw, gop, resources)
 	case 2: // Circle
-		return renderCircle(csw, gop)
+		return renderCircle(csw, gop, resources)
 	case 3: // Image
 		return renderImage(csw, gop, resources)
 	case 4: // Watermark
 		return renderWatermark(csw, gop, resources)
 	case 5: // Polygon
-		return renderPolygon(csw, gop)
+		return renderPolygon(csw, gop, resources)
 	case 6: // Polyline
 		return renderPolyline(csw, gop)
 	case 7: // Ellipse
-		return renderEllipse(csw, gop)
+		return renderEllipse(csw, gop, resources)
 	case 8: // Bezier
-		return renderBezier(csw, gop)
+		return renderBezier(csw, gop, resources)
+	case 26: // Form XObject
+		return renderForm(csw, gop, resources)
+	case 27: // Path
+		return renderPath(csw, gop, resources)
 	default:
 		return fmt.Errorf("unknown graphics operation type: %d", gop.Type)
 	}
@@ -350,7 +554,7 @@ func setStrokeColor(csw *ContentStreamWriter, rgb *RGB, cmyk *CMYK) {
 	if cmyk != nil {
 		csw.SetStrokeColorCMYK(cmyk.C, cmyk.M, cmyk.Y, cmyk.K)
 	} else if rgb != nil {
-		csw.SetStrokeColorRGB(rgb.R, rgb.G, rgb.B)
+		strokeRGB(csw, rgb.R, rgb.G, rgb.B)
 	}
 }
 
@@ -359,7 +563,43 @@ func setFillColor(csw *ContentStreamWriter, rgb *RGB, cmyk *CMYK) {
 	if cmyk != nil {
 		csw.SetFillColorCMYK(cmyk.C, cmyk.M, cmyk.Y, cmyk.K)
 	} else if rgb != nil {
-		csw.SetFillColorRGB(rgb.R, rgb.G, rgb.B)
+		fillRGB(csw, rgb.R, rgb.G, rgb.B)
+	}
+}
+
+// fillRGB sets an RGB fill color. Neutral colors (r = g = b), such as
+// black text, are written in DeviceGray (g): the operator is shorter and
+// printers render it with black ink only.
+func fillRGB(csw *ContentStreamWriter, r, g, b float64) {
+	if r == g && g == b {
+		csw.SetFillColorGray(r)
+		return
+	}
+	csw.SetFillColorRGB(r, g, b)
+}
+
+// strokeRGB sets an RGB stroke color, writing neutral colors in
+// DeviceGray (G) like fillRGB.
+func strokeRGB(csw *ContentStreamWriter, r, g, b float64) {
+	if r == g && g == b {
+		csw.SetStrokeColorGray(r)
+		return
+	}
+	csw.SetStrokeColorRGB(r, g, b)
+}
+
+// setLineStyle sets the line cap, line join and miter limit of a stroke.
+//
+// Only non-default values are written, so default strokes render with the
+// PDF defaults (butt cap, miter join, miter limit 10) as before.
+func setLineStyle(csw *ContentStreamWriter, gop GraphicsOp) {
+	if gop.LineCap != 0 {
+		csw.SetLineCap(gop.LineCap)
+	}
+	if gop.LineJoin != 0 {
+		csw.SetLineJoin(gop.LineJoin)
+	} else if gop.MiterLimit > 0 {
+		csw.SetMiterLimit(gop.MiterLimit)
 	}
 }
 
@@ -377,6 +617,8 @@ func renderLine(csw *ContentStreamWriter, gop GraphicsOp) error {
 		csw.SetDashPattern(gop.DashArray, gop.DashPhase)
 	}
 
+	setLineStyle(csw, gop)
+
 	// Set stroke color (lines only have stroke, no fill)
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
@@ -391,7 +633,7 @@ func renderLine(csw *ContentStreamWriter, gop GraphicsOp) error {
 }
 
 // renderRect renders a rectangle to the content stream.
-func renderRect(csw *ContentStreamWriter, gop GraphicsOp) error {
+func renderRect(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
 	// Set line width
 	if gop.StrokeWidth > 0 {
 		csw.SetLineWidth(gop.StrokeWidth)
@@ -408,52 +650,110 @@ func renderRect(csw *ContentStreamWriter, gop GraphicsOp) error {
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
 	// Draw rectangle path
-	csw.Rectangle(gop.X, gop.Y, gop.Width, gop.Height)
+	path := func() {
+		if gop.CornerRadii != [4]float64{} {
+			roundedRectangle(csw, gop.X, gop.Y, gop.Width, gop.Height, gop.CornerRadii)
+		} else {
+			csw.Rectangle(gop.X, gop.Y, gop.Width, gop.Height)
+		}
+	}
 
-	// Handle fill (gradient or solid color)
+	// Fill (gradient or solid color) and/or stroke
 	hasFill := gop.FillColor != nil || gop.FillColorCMYK != nil || gop.FillGradient != nil
 	hasStroke := gop.StrokeColor != nil || gop.StrokeColorCMYK != nil
+	paintPath(csw, gop, resources, hasFill, hasStroke, path)
 
-	if gop.FillGradient != nil {
-		// Use gradient fill
-		// Note: Full gradient implementation requires shading pattern resource
-		// For now, use a simplified approach with color interpolation
-		renderGradientFill(csw, gop.FillGradient)
-	} else {
-		// Use solid color fill
-		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
+	// Restore graphics state
+	csw.RestoreState()
+	return nil
+}
+
+// roundedRectangle appends a closed rectangle path whose corners are
+// quarter-circle arcs with the given radii (lower-left, lower-right,
+// upper-right, upper-left).
+//
+// Each radius is clamped to half the smaller side so that the arcs never
+// overlap. The path runs counterclockwise from the bottom edge, like the
+// re operator.
+func roundedRectangle(csw *ContentStreamWriter, x, y, width, height float64, radii [4]float64) {
+	// Normalize negative sizes so the radii always point inwards.
+	if width < 0 {
+		x, width = x+width, -width
+	}
+	if height < 0 {
+		y, height = y+height, -height
 	}
 
-	// Fill and/or stroke
-	if hasStroke && hasFill {
-		csw.FillAndStroke()
-	} else if hasFill {
-		csw.Fill()
-	} else {
-		csw.Stroke()
+	limit := math.Min(width, height) / 2
+	var r [4]float64
+	for i, radius := range radii {
+		r[i] = math.Max(0, math.Min(radius, limit))
 	}
 
-	// Restore graphics state
-	csw.RestoreState()
-	return nil
+	// kappa places the control points of a quarter-circle Bézier arc.
+	const kappa = 0.5522847498
+	right, top := x+width, y+height
+
+	csw.MoveTo(x+r[0], y)
+	csw.LineTo(right-r[1], y)
+	if r[1] > 0 {
+		k := r[1] * kappa
+		csw.CurveTo(right-r[1]+k, y, right, y+r[1]-k, right, y+r[1])
+	}
+	csw.LineTo(right, top-r[2])
+	if r[2] > 0 {
+		k := r[2] * kappa
+		csw.CurveTo(right, top-r[2]+k, right-r[2]+k, top, right-r[2], top)
+	}
+	csw.LineTo(x+r[3], top)
+	if r[3] > 0 {
+		k := r[3] * kappa
+		csw.CurveTo(x+r[3]-k, top, x, top-r[3]+k, x, top-r[3])
+	}
+	csw.LineTo(x, y+r[0])
+	if r[0] > 0 {
+		k := r[0] * kappa
+		csw.CurveTo(x, y+r[0]-k, x+r[0]-k, y, x+r[0], y)
+	}
+	csw.ClosePath()
 }
 
-// renderBeginClipRect starts a rectangular clipping region.
+// renderBeginClip starts a clipping region.
+//
+// This saves the graphics state, defines the clip path, and sets it as the clipping path.
+// All subsequent drawing operations will be clipped to this path until EndClip is called.
 //
-// This saves the graphics state, defines a rectangle path, and sets it as the clipping path.
-// All subsequent drawing operations will be clipped to this rectangle until EndClip is called.
+// The clip path is chosen from the operation fields:
+//   - Vertices (3 or more): closed polygon
+//   - BezierSegs: closed path built from the Bézier segments
+//   - otherwise: rectangle (X, Y, Width, Height)
 //
 // Usage:
 //
-//	BeginClipRect(x, y, width, height)
+//	BeginClip(path)
 //	... draw content that should be clipped ...
 //	EndClip()
-func renderBeginClipRect(csw *ContentStreamWriter, gop GraphicsOp) error {
+func renderBeginClip(csw *ContentStreamWriter, gop GraphicsOp) error {
 	// Save graphics state (so we can restore after clipping).
 	csw.SaveState()
 
-	// Define rectangle path.
-	csw.Rectangle(gop.X, gop.Y, gop.Width, gop.Height)
+	// Define clip path.
+	switch {
+	case len(gop.Vertices) >= 3:
+		csw.MoveTo(gop.Vertices[0].X, gop.Vertices[0].Y)
+		for i := 1; i < len(gop.Vertices); i++ {
+			csw.LineTo(gop.Vertices[i].X, gop.Vertices[i].Y)
+		}
+		csw.ClosePath()
+	case len(gop.BezierSegs) > 0:
+		csw.MoveTo(gop.BezierSegs[0].Start.X, gop.BezierSegs[0].Start.Y)
+		for _, seg := range gop.BezierSegs {
+			csw.CurveTo(seg.C1.X, seg.C1.Y, seg.C2.X, seg.C2.Y, seg.End.X, seg.End.Y)
+		}
+		csw.ClosePath()
+	default:
+		csw.Rectangle(gop.X, gop.Y, gop.Width, gop.Height)
+	}
 
 	// Set clipping path and end path (W n).
 	csw.Clip()
@@ -492,7 +792,7 @@ func renderTextBlock(csw *ContentStreamWriter, gop GraphicsOp, resources *Resour
 	csw.BeginText()
 
 	// Set fill color.
-	csw.SetFillColorRGB(gop.TextColorR, gop.TextColorG, gop.TextColorB)
+	fillRGB(csw, gop.TextColorR, gop.TextColorG, gop.TextColorB)
 
 	// Set font and size.
 	csw.SetFont(fontResName, gop.TextSize)
@@ -510,7 +810,7 @@ func renderTextBlock(csw *ContentStreamWriter, gop GraphicsOp, resources *Resour
 }
 
 // renderCircle renders a circle to the content stream using Bézier curves.
-func renderCircle(csw *ContentStreamWriter, gop GraphicsOp) error {
+func renderCircle(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
 	// Set line width
 	if gop.StrokeWidth > 0 {
 		csw.SetLineWidth(gop.StrokeWidth)
@@ -518,6 +818,11 @@ func renderCircle(csw *ContentStreamWriter, gop GraphicsOp) error {
 		csw.SetLineWidth(1.0) // Default
 	}
 
+	// Set dash pattern if dashed
+	if gop.Dashed && len(gop.DashArray) > 0 {
+		csw.SetDashPattern(gop.DashArray, gop.DashPhase)
+	}
+
 	// Set stroke color
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
@@ -527,42 +832,30 @@ func renderCircle(csw *ContentStreamWriter, gop GraphicsOp) error {
 	cx, cy, r := gop.X, gop.Y, gop.Radius
 	k := r * kappa
 
-	// Start at right (3 o'clock)
-	csw.MoveTo(cx+r, cy)
+	path := func() {
+		// Start at right (3 o'clock)
+		csw.MoveTo(cx+r, cy)
 
-	// Top-right quarter
-	csw.CurveTo(cx+r, cy+k, cx+k, cy+r, cx, cy+r)
+		// Top-right quarter
+		csw.CurveTo(cx+r, cy+k, cx+k, cy+r, cx, cy+r)
 
-	// Top-left quarter
-	csw.CurveTo(cx-k, cy+r, cx-r, cy+k, cx-r, cy)
+		// Top-left quarter
+		csw.CurveTo(cx-k, cy+r, cx-r, cy+k, cx-r, cy)
 
-	// Bottom-left quarter
-	csw.CurveTo(cx-r, cy-k, cx-k, cy-r, cx, cy-r)
+		// Bottom-left quarter
+		csw.CurveTo(cx-r, cy-k, cx-k, cy-r, cx, cy-r)
 
-	// Bottom-right quarter (back to start)
-	csw.CurveTo(cx+k, cy-r, cx+r, cy-k, cx+r, cy)
+		// Bottom-right quarter (back to start)
+		csw.CurveTo(cx+k, cy-r, cx+r, cy-k, cx+r, cy)
 
-	// Close path
-	csw.ClosePath()
+		// Close path
+		csw.ClosePath()
+	}
 
-	// Handle fill (gradient or solid color)
+	// Fill (gradient or solid color) and/or stroke
 	hasFill := gop.FillColor != nil || gop.FillColorCMYK != nil || gop.FillGradient != nil
 	hasStroke := gop.StrokeColor != nil || gop.StrokeColorCMYK != nil
-
-	if gop.FillGradient != nil {
-		renderGradientFill(csw, gop.FillGradient)
-	} else {
-		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
-	}
-
-	// Fill and/or stroke
-	if hasStroke && hasFill {
-		csw.FillAndStroke()
-	} else if hasFill {
-		csw.Fill()
-	} else {
-		csw.Stroke()
-	}
+	paintPath(csw, gop, resources, hasFill, hasStroke, path)
 
 	// Restore graphics state
 	csw.RestoreState()
@@ -570,7 +863,7 @@ func renderCircle(csw *ContentStreamWriter, gop GraphicsOp) error {
 }
 
 // renderPolygon renders a polygon to the content stream.
-func renderPolygon(csw *ContentStreamWriter, gop GraphicsOp) error {
+func renderPolygon(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
 	if len(gop.Vertices) < 3 {
 		return fmt.Errorf("polygon must have at least 3 vertices")
 	}
@@ -591,35 +884,23 @@ func renderPolygon(csw *ContentStreamWriter, gop GraphicsOp) error {
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
 	// Draw polygon path
-	// Start at first vertex
-	csw.MoveTo(gop.Vertices[0].X, gop.Vertices[0].Y)
+	path := func() {
+		// Start at first vertex
+		csw.MoveTo(gop.Vertices[0].X, gop.Vertices[0].Y)
 
-	// Draw lines to remaining vertices
-	for i := 1; i < len(gop.Vertices); i++ {
-		csw.LineTo(gop.Vertices[i].X, gop.Vertices[i].Y)
-	}
+		// Draw lines to remaining vertices
+		for i := 1; i < len(gop.Vertices); i++ {
+			csw.LineTo(gop.Vertices[i].X, gop.Vertices[i].Y)
+		}
 
-	// Close path (back to first vertex)
-	csw.ClosePath()
+		// Close path (back to first vertex)
+		csw.ClosePath()
+	}
 
-	// Handle fill (gradient or solid color)
+	// Fill (gradient or solid color) and/or stroke
 	hasFill := gop.FillColor != nil || gop.FillColorCMYK != nil || gop.FillGradient != nil
 	hasStroke := gop.StrokeColor != nil || gop.StrokeColorCMYK != nil
-
-	if gop.FillGradient != nil {
-		renderGradientFill(csw, gop.FillGradient)
-	} else {
-		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
-	}
-
-	// Fill and/or stroke
-	if hasStroke && hasFill {
-		csw.FillAndStroke()
-	} else if hasFill {
-		csw.Fill()
-	} else {
-		csw.Stroke()
-	}
+	paintPath(csw, gop, resources, hasFill, hasStroke, path)
 
 	// Restore graphics state
 	csw.RestoreState()
@@ -644,6 +925,8 @@ func renderPolyline(csw *ContentStreamWriter, gop GraphicsOp) error {
 		csw.SetDashPattern(gop.DashArray, gop.DashPhase)
 	}
 
+	setLineStyle(csw, gop)
+
 	// Set stroke color (polyline only has stroke, no fill)
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
@@ -665,7 +948,7 @@ func renderPolyline(csw *ContentStreamWriter, gop GraphicsOp) error {
 }
 
 // renderEllipse renders an ellipse to the content stream using Bézier curves.
-func renderEllipse(csw *ContentStreamWriter, gop GraphicsOp) error {
+func renderEllipse(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
 	// Set line width
 	if gop.StrokeWidth > 0 {
 		csw.SetLineWidth(gop.StrokeWidth)
@@ -673,6 +956,11 @@ func renderEllipse(csw *ContentStreamWriter, gop GraphicsOp) error {
 		csw.SetLineWidth(1.0) // Default
 	}
 
+	// Set dash pattern if dashed
+	if gop.Dashed && len(gop.DashArray) > 0 {
+		csw.SetDashPattern(gop.DashArray, gop.DashPhase)
+	}
+
 	// Set stroke color
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
@@ -683,72 +971,85 @@ func renderEllipse(csw *ContentStreamWriter, gop GraphicsOp) error {
 	kx := rx * kappa
 	ky := ry * kappa
 
-	// Start at right (3 o'clock)
-	csw.MoveTo(cx+rx, cy)
+	path := func() {
+		// Start at right (3 o'clock)
+		csw.MoveTo(cx+rx, cy)
 
-	// Top-right quarter
-	csw.CurveTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+		// Top-right quarter
+		csw.CurveTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
 
-	// Top-left quarter
-	csw.CurveTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+		// Top-left quarter
+		csw.CurveTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
 
-	// Bottom-left quarter
-	csw.CurveTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+		// Bottom-left quarter
+		csw.CurveTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
 
-	// Bottom-right quarter (back to start)
-	csw.CurveTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+		// Bottom-right quarter (back to start)
+		csw.CurveTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
 
-	// Close path
-	csw.ClosePath()
+		// Close path
+		csw.ClosePath()
+	}
 
-	// Handle fill (gradient or solid color)
+	// Fill (gradient or solid color) and/or stroke
 	hasFill := gop.FillColor != nil || gop.FillColorCMYK != nil || gop.FillGradient != nil
 	hasStroke := gop.StrokeColor != nil || gop.StrokeColorCMYK != nil
-
-	if gop.FillGradient != nil {
-		renderGradientFill(csw, gop.FillGradient)
-	} else {
-		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
-	}
-
-	// Fill and/or stroke
-	if hasStroke && hasFill {
-		csw.FillAndStroke()
-	} else if hasFill {
-		csw.Fill()
-	} else {
-		csw.Stroke()
-	}
+	paintPath(csw, gop, resources, hasFill, hasStroke, path)
 
 	// Restore graphics state
 	csw.RestoreState()
 	return nil
 }
 
-// renderGradientFill applies a gradient fill to the current path.
-//
-// TODO: Full gradient implementation requires:
-// 1. Creating shading dictionary with Function objects
-// 2. Adding shading to resource dictionary
-// 3. Using 'sh' operator to apply shading
+// paintPath draws a shape outline and fills and/or strokes it.
 //
-// For now, this function uses a fallback: the middle color of the gradient.
-// This allows the API to work while we build the full infrastructure.
-func renderGradientFill(csw *ContentStreamWriter, grad *GradientOp) {
-	if grad == nil || len(grad.ColorStops) == 0 {
+// path appends the outline to the content stream. A solid fill and the
+// stroke are painted with one operator. A gradient fill is painted as a
+// shading clipped to the outline (q path W n /ShN sh Q); the outline is
+// then appended again to stroke it, so the stroke is not clipped.
+// A shape with neither fill nor stroke is stroked. EvenOdd selects the
+// even-odd rule for the fill and the gradient clip.
+func paintPath(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary, hasFill, hasStroke bool, path func()) {
+	if hasFill && gop.FillGradient != nil && len(gop.FillGradient.ColorStops) > 0 {
+		csw.SaveState()
+		path()
+		if gop.EvenOdd {
+			csw.ClipEvenOdd()
+		} else {
+			csw.Clip()
+		}
+		csw.EndPath()
+		csw.PaintShading(resources.AddShading(gop.FillGradient))
+		csw.RestoreState()
+
+		if hasStroke {
+			path()
+			csw.Stroke()
+		}
 		return
 	}
 
-	// Fallback: use middle color stop
-	// In the future, this will create a proper PDF shading pattern
-	midIdx := len(grad.ColorStops) / 2
-	midColor := grad.ColorStops[midIdx].Color
+	path()
+	if hasFill {
+		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
+	}
 
-	csw.SetFillColorRGB(midColor.R, midColor.G, midColor.B)
+	switch {
+	case hasStroke && hasFill && gop.EvenOdd:
+		csw.FillAndStrokeEvenOdd()
+	case hasStroke && hasFill:
+		csw.FillAndStroke()
+	case hasFill && gop.EvenOdd:
+		csw.FillEvenOdd()
+	case hasFill:
+		csw.Fill()
+	default:
+		csw.Stroke()
+	}
 }
 
 // renderBezier renders a Bézier curve to the content stream.
-func renderBezier(csw *ContentStreamWriter, gop GraphicsOp) error {
+func renderBezier(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
 	if len(gop.BezierSegs) == 0 {
 		return fmt.Errorf("bezier curve must have at least 1 segment")
 	}
@@ -765,44 +1066,74 @@ func renderBezier(csw *ContentStreamWriter, gop GraphicsOp) error {
 		csw.SetDashPattern(gop.DashArray, gop.DashPhase)
 	}
 
+	setLineStyle(csw, gop)
+
 	// Set stroke color
 	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
 
 	// Draw Bézier curve path
-	// Start at first segment's start point
-	firstSeg := gop.BezierSegs[0]
-	csw.MoveTo(firstSeg.Start.X, firstSeg.Start.Y)
-
-	// Draw each segment
-	for _, seg := range gop.BezierSegs {
-		csw.CurveTo(seg.C1.X, seg.C1.Y, seg.C2.X, seg.C2.Y, seg.End.X, seg.End.Y)
-	}
+	path := func() {
+		// Start at first segment's start point
+		firstSeg := gop.BezierSegs[0]
+		csw.MoveTo(firstSeg.Start.X, firstSeg.Start.Y)
+
+		// Draw each segment
+		for _, seg := range gop.BezierSegs {
+			csw.CurveTo(seg.C1.X, seg.C1.Y, seg.C2.X, seg.C2.Y, seg.End.X, seg.End.Y)
+		}
 
-	// Close path if requested
-	if gop.Closed {
-		csw.ClosePath()
+		// Close path if requested
+		if gop.Closed {
+			csw.ClosePath()
+		}
 	}
 
-	// Handle fill (gradient or solid color)
+	// Fill (gradient or solid color, closed curves only) and/or stroke
 	hasFill := (gop.FillColor != nil || gop.FillColorCMYK != nil || gop.FillGradient != nil) && gop.Closed
 	hasStroke := gop.StrokeColor != nil || gop.StrokeColorCMYK != nil
+	paintPath(csw, gop, resources, hasFill, hasStroke, path)
 
-	if gop.FillGradient != nil && gop.Closed {
-		renderGradientFill(csw, gop.FillGradient)
-	} else if gop.Closed {
-		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
+	// Restore graphics state
+	csw.RestoreState()
+	return nil
+}
+
+// renderPath renders a general path of lines and curves to the content
+// stream. The path may hold several subpaths; it is filled when a fill is
+// set, whether or not its subpaths are closed.
+func renderPath(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDictionary) error {
+	if len(gop.Path) == 0 || gop.Path[0].Op != 'm' {
+		return fmt.Errorf("path must start with a move")
 	}
 
-	// Fill and/or stroke
-	if hasStroke && hasFill {
-		csw.FillAndStroke()
-	} else if hasFill {
-		csw.Fill()
-	} else {
-		csw.Stroke()
+	if gop.StrokeWidth > 0 {
+		csw.SetLineWidth(gop.StrokeWidth)
+	}
+	if gop.Dashed && len(gop.DashArray) > 0 {
+		csw.SetDashPattern(gop.DashArray, gop.DashPhase)
 	}
+	setLineStyle(csw, gop)
+	setStrokeColor(csw, gop.StrokeColor, gop.StrokeColorCMYK)
+
+	path := func() {
+		for _, seg := range gop.Path {
+			switch seg.Op {
+			case 'm':
+				csw.MoveTo(seg.End.X, seg.End.Y)
+			case 'l':
+				csw.LineTo(seg.End.X, seg.End.Y)
+			case 'c':
+				csw.CurveTo(seg.C1.X, seg.C1.Y, seg.C2.X, seg.C2.Y, seg.End.X, seg.End.Y)
+			case 'h':
+				csw.ClosePath()
+			}
+		}
+	}
+
+	hasFill := gop.FillColor != nil || gop.FillColorCMYK != nil || gop.FillGradient != nil
+	hasStroke := gop.StrokeColor != nil || gop.StrokeColorCMYK != nil
+	paintPath(csw, gop, resources, hasFill, hasStroke, path)
 
-	// Restore graphics state
 	csw.RestoreState()
 	return nil
 }
@@ -835,6 +1166,16 @@ func renderImage(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDi
 	// Register image in resources (object number will be set later)
 	imageResName := resources.AddImage(0) // Placeholder object number
 
+	// Tagged figures are marked content linked to a structure element
+	if gop.AltText != "" {
+		csw.BeginMarkedContent("Figure", gop.MCID)
+	}
+
+	// Stencil masks paint their samples in the fill color
+	if gop.Image.ImageMask {
+		setFillColor(csw, gop.FillColor, gop.FillColorCMYK)
+	}
+
 	// Apply CTM transformation: width 0 0 height x y cm
 	// This scales the 1x1 unit image to width×height and positions it at (x,y)
 	csw.ConcatMatrix(gop.Width, 0, 0, gop.Height, gop.X, gop.Y)
@@ -842,6 +1183,10 @@ func renderImage(csw *ContentStreamWriter, gop GraphicsOp, resources *ResourceDi
 	// Draw the image XObject
 	csw.writeOp(fmt.Sprintf("/%s", imageResName), "Do")
 
+	if gop.AltText != "" {
+		csw.EndMarkedContent()
+	}
+
 	// Restore graphics state
 	csw.RestoreState()
 	return nil
@@ -913,7 +1258,7 @@ func renderWatermark(csw *ContentStreamWriter, gop GraphicsOp, resources *Resour
 	csw.BeginText()
 
 	// Set text color
-	csw.SetFillColorRGB(gop.TextColorR, gop.TextColorG, gop.TextColorB)
+	fillRGB(csw, gop.TextColorR, gop.TextColorG, gop.TextColorB)
 
 	// Set font and size
 	csw.SetFont(fontResName, gop.TextSize)
@@ -1140,12 +1485,31 @@ func getStandard14Font(name string) (*fonts.Standard14Font, error) {
 //
 // Returns the IndirectObject ready to write.
 func CreateContentStreamObject(objNum int, content []byte, compress bool) *IndirectObject {
+	level := NoCompression
+	if compress {
+		level = DefaultCompression
+	}
+	return CreateContentStreamObjectWithLevel(objNum, content, level)
+}
+
+// CreateContentStreamObjectWithLevel is like CreateContentStreamObject but
+// compresses the content at the given Flate level. NoCompression writes
+// the content as plain text, without a /Filter.
+func CreateContentStreamObjectWithLevel(objNum int, content []byte, level CompressionLevel) *IndirectObject {
+	return createStreamObject(objNum, "", content, level)
+}
+
+// createStreamObject is like CreateContentStreamObjectWithLevel, with
+// extra stream dictionary entries (e.g. "/Type /XObject /Subtype /Form")
+// written before /Length.
+func createStreamObject(objNum int, entries string, content []byte, level CompressionLevel) *IndirectObject {
 	var buf bytes.Buffer
+	compress := level != NoCompression
 
 	// Compress content if requested
 	actualContent := content
 	if compress && ShouldCompress(content) {
-		compressed, err := CompressStream(content, DefaultCompression)
+		compressed, err := CompressStream(content, level)
 		if err == nil {
 			// Compression succeeded, use compressed content
 			actualContent = compressed
@@ -1154,7 +1518,11 @@ func CreateContentStreamObject(objNum int, content []byte, compress bool) *Indir
 	}
 
 	// Write stream dictionary
-	buf.WriteString("<< /Length ")
+	buf.WriteString("<< ")
+	if entries != "" {
+		buf.WriteString(entries + " ")
+	}
+	buf.WriteString("/Length ")
 	buf.WriteString(fmt.Sprintf("%d", len(actualContent)))
 
 	// Add Filter if compressed
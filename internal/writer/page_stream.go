@@ -0,0 +1,123 @@
+package writer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// errNotStreaming is returned by WriteStreamPage and EndStream without a
+// preceding BeginStream.
+var errNotStreaming = errors.New("no streamed document in progress (call BeginStream)")
+
+// BeginStream starts writing a document page by page.
+//
+// WriteWithAllContent holds every object of the document in memory until
+// the end. A streamed document is written as it is produced instead: the
+// header now, each page's objects (Page, content stream, fonts, images,
+// annotations) as WriteStreamPage is called, and the page tree, catalog,
+// document-level objects and cross-reference table at EndStream. Between
+// pages the writer keeps only the object offsets and a few numbers per
+// page, so memory stays flat however many pages are written.
+//
+// Streamed documents are never packed into object streams (see
+// SetObjectStreams), since that needs all objects at once. Other settings
+// apply as usual.
+//
+// Example:
+//
+//	w.BeginStream(doc)
+//	for each page {
+//	    page, _ := doc.AddPage(document.A4)
+//	    w.WriteStreamPage(page, textOps, graphicsOps)
+//	}
+//	w.EndStream(doc)
+func (w *PdfWriter) BeginStream(doc *document.Document) error {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+
+	w.objects = make([]*IndirectObject, 0)
+	w.offsets = make(map[int]int64)
+	w.nextObjNum = 1
+	w.limitErr = nil
+	w.stdFontNums = nil
+	w.imageNums, w.embeddedFontNums, w.formNums = nil, nil, nil
+	w.fontSubsets = nil
+	w.compressed = nil
+	w.resetFormState()
+	w.resetStructState()
+
+	if err := w.writeHeader(doc.Version().String()); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	w.pageNums = nil
+	w.streamRootNum = w.allocateObjNum()
+	return nil
+}
+
+// WriteStreamPage writes the next page of a streamed document (see
+// BeginStream) with its content. page must belong to the document passed
+// to BeginStream. The operations are not kept after the call.
+func (w *PdfWriter) WriteStreamPage(page *document.Page, textOps []TextOp, graphicsOps []GraphicsOp) error {
+	if w.streamRootNum == 0 {
+		return errNotStreaming
+	}
+
+	pageRef := w.allocateObjNum()
+	objs := w.createPageObjects(page, pageRef, w.streamRootNum, textOps, graphicsOps)
+	if w.limitErr != nil {
+		return w.limitErr
+	}
+	for _, obj := range objs {
+		if err := w.writeObject(obj); err != nil {
+			return err
+		}
+	}
+	w.pageNums = append(w.pageNums, pageRef)
+	return nil
+}
+
+// EndStream finishes a streamed document (see BeginStream): it writes the
+// page tree, the document-level objects, the catalog, the cross-reference
+// table and the trailer.
+func (w *PdfWriter) EndStream(doc *document.Document) error {
+	if w.streamRootNum == 0 {
+		return errNotStreaming
+	}
+	rootNum := w.streamRootNum
+	w.streamRootNum = 0
+
+	if len(w.pageNums) != doc.PageCount() {
+		return fmt.Errorf("streamed %d pages, document has %d", len(w.pageNums), doc.PageCount())
+	}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("document validation failed: %w", err)
+	}
+
+	objects := []*IndirectObject{w.createPagesRoot(rootNum, w.pageNums, len(w.pageNums))}
+	docObjs, infoObj, err := w.createDocumentObjects(doc)
+	if err != nil {
+		return err
+	}
+	catalogObj := w.createCatalog(rootNum, doc)
+	objects = append(append(objects, docObjs...), catalogObj)
+	if w.limitErr != nil {
+		return w.limitErr
+	}
+
+	for _, obj := range objects {
+		if err := w.writeObject(obj); err != nil {
+			return err
+		}
+	}
+	if err := w.writeXRefAndTrailer(doc, catalogObj.Number, infoObjNum(infoObj)); err != nil {
+		return err
+	}
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+func TestPdfWriter_StreamPages(t *testing.T) {
+	doc := document.NewDocument()
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetObjectStreams(true) // Ignored when streaming
+	if err := w.BeginStream(doc); err != nil {
+		t.Fatalf("BeginStream() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		page, err := doc.AddPage(document.A4)
+		if err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+		textOps := []TextOp{{Text: fmt.Sprintf("Page %d", i+1), X: 72, Y: 770, Font: "Helvetica", Size: 12}}
+		if err := w.WriteStreamPage(page, textOps, nil); err != nil {
+			t.Fatalf("WriteStreamPage() error = %v", err)
+		}
+	}
+	if err := w.EndStream(doc); err != nil {
+		t.Fatalf("EndStream() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "/ObjStm") {
+		t.Error("streamed document should not use object streams")
+	}
+
+	path := filepath.Join(t.TempDir(), "stream.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if n, err := r.GetPageCount(); err != nil || n != 3 {
+		t.Errorf("GetPageCount() = %d, %v; want 3", n, err)
+	}
+}
+
+func TestPdfWriter_StreamErrors(t *testing.T) {
+	doc := document.NewDocument()
+	page, err := doc.AddPage(document.A4)
+	if err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	if err := w.WriteStreamPage(page, nil, nil); !errors.Is(err, errNotStreaming) {
+		t.Errorf("WriteStreamPage() without BeginStream: error = %v, want errNotStreaming", err)
+	}
+	if err := w.EndStream(doc); !errors.Is(err, errNotStreaming) {
+		t.Errorf("EndStream() without BeginStream: error = %v, want errNotStreaming", err)
+	}
+
+	// The document has a page that was never streamed.
+	if err := w.BeginStream(doc); err != nil {
+		t.Fatalf("BeginStream() error = %v", err)
+	}
+	if err := w.EndStream(doc); err == nil {
+		t.Error("EndStream() with a missing page: expected an error")
+	}
+}
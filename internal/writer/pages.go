@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/fonts"
 )
 
 // hasTextBlockOps checks if any graphics operations contain TextBlock (type 22).
@@ -17,6 +18,58 @@ func hasTextBlockOps(graphicsOps []GraphicsOp) bool {
 	return false
 }
 
+// isTextOnlyPage reports whether a page can be written by
+// createPageWithContent: it has no graphics, no annotations or form fields,
+// and all text uses Standard 14 fonts.
+func isTextOnlyPage(page *document.Page, textOps []TextOp, graphicsOps []GraphicsOp) bool {
+	if len(graphicsOps) > 0 || page.AnnotationCount() > 0 {
+		return false
+	}
+	for _, op := range textOps {
+		if op.CustomFont != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// standardFontObject returns the object number of the font dictionary for
+// a Standard 14 font, and the font object itself on the font's first use
+// in the document (nil afterwards). Standard 14 fonts carry no per-page
+// data, so each is written once and shared by all pages, except in
+// page-chunked output where every page gets its own copy (see
+// SetPageChunked).
+//
+// Returns 0 if the font object cannot be created.
+func (w *PdfWriter) standardFontObject(name string, font *fonts.Standard14Font) (int, *IndirectObject) {
+	if objNum, ok := w.stdFontNums[name]; ok && !w.pageChunked {
+		return objNum, nil
+	}
+
+	fontObjNum := w.allocateObjNum()
+
+	// Create font object using WriteFontObject
+	var fontBuf bytes.Buffer
+	if err := font.WriteFontObject(fontObjNum, &fontBuf); err != nil {
+		return 0, nil
+	}
+
+	// Extract just the dictionary part (without N 0 obj and endobj)
+	fontBytes := fontBuf.Bytes()
+	dictStart := bytes.Index(fontBytes, []byte("<<"))
+	dictEnd := bytes.LastIndex(fontBytes, []byte(">>")) + 2
+	if dictStart < 0 || dictEnd <= dictStart {
+		return 0, nil
+	}
+
+	if w.stdFontNums == nil {
+		w.stdFontNums = make(map[string]int)
+	}
+	w.stdFontNums[name] = fontObjNum
+
+	return fontObjNum, NewIndirectObject(fontObjNum, 0, fontBytes[dictStart:dictEnd])
+}
+
 // createPageTreeWithContent creates the Pages tree with content operations.
 //
 // This version accepts page content operations and generates content streams.
@@ -59,9 +112,15 @@ func (w *PdfWriter) createPageTreeWithContent(
 
 		// Add font objects
 		objects = append(objects, fontObjs...)
+
+		// Stop early if the object limit was exceeded.
+		if w.limitErr != nil {
+			return nil, 0, w.limitErr
+		}
 	}
 
 	// Create Pages root object
+	w.pageNums = pageRefs
 	pagesRootObj := w.createPagesRoot(pagesRootRef, pageRefs, doc.PageCount())
 	objects = append([]*IndirectObject{pagesRootObj}, objects...)
 
@@ -95,30 +154,43 @@ func (w *PdfWriter) createPageTreeWithAllContent(
 		pageRef := w.allocateObjNum()
 		pageRefs = append(pageRefs, pageRef)
 
-		// Get content operations for this page
-		textOps := textContents[i]
-		graphicsOps := graphicsContents[i]
-
-		// Create page with all content
-		pageObj, contentObj, fontObjs := w.createPageWithAllContent(page, pageRef, pagesRootRef, textOps, graphicsOps)
-		objects = append(objects, pageObj)
-
-		// Add content stream object if present
-		if contentObj != nil {
-			objects = append(objects, contentObj)
-		}
-
-		// Add font objects
-		objects = append(objects, fontObjs...)
+		objects = append(objects, w.createPageObjects(page, pageRef, pagesRootRef, textContents[i], graphicsContents[i])...)
 	}
 
 	// Create Pages root object
+	w.pageNums = pageRefs
 	pagesRootObj := w.createPagesRoot(pagesRootRef, pageRefs, doc.PageCount())
 	objects = append([]*IndirectObject{pagesRootObj}, objects...)
 
 	return objects, pagesRootRef, nil
 }
 
+// createPageObjects creates the Page object of a page with its content
+// stream and the fonts and other resources it introduces.
+func (w *PdfWriter) createPageObjects(
+	page *document.Page,
+	pageRef int,
+	pagesRootRef int,
+	textOps []TextOp,
+	graphicsOps []GraphicsOp,
+) []*IndirectObject {
+	// Text-only pages, the common case for reports and letters, skip the
+	// graphics machinery.
+	var pageObj, contentObj *IndirectObject
+	var fontObjs []*IndirectObject
+	if isTextOnlyPage(page, textOps, graphicsOps) {
+		pageObj, contentObj, fontObjs = w.createPageWithContent(page, pageRef, pagesRootRef, textOps)
+	} else {
+		pageObj, contentObj, fontObjs = w.createPageWithAllContent(page, pageRef, pagesRootRef, textOps, graphicsOps)
+	}
+
+	objects := []*IndirectObject{pageObj}
+	if contentObj != nil {
+		objects = append(objects, contentObj)
+	}
+	return append(objects, fontObjs...)
+}
+
 // createPageTree creates the Pages tree for the document.
 //
 // PDF uses a tree structure for pages to optimize navigation in large documents.
@@ -204,13 +276,13 @@ func (w *PdfWriter) createPageWithContent(
 	mediaBox := page.MediaBox()
 	llx, lly := mediaBox.LowerLeft()
 	urx, ury := mediaBox.UpperRight()
-	pageDict.WriteString(fmt.Sprintf(" /MediaBox [%.2f %.2f %.2f %.2f]", llx, lly, urx, ury))
+	pageDict.WriteString(fmt.Sprintf(" /MediaBox [%s]", formatNumbers(llx, lly, urx, ury)))
 
 	// CropBox (if set)
 	if cropBox := page.CropBox(); cropBox != nil {
 		llx, lly := cropBox.LowerLeft()
 		urx, ury := cropBox.UpperRight()
-		pageDict.WriteString(fmt.Sprintf(" /CropBox [%.2f %.2f %.2f %.2f]", llx, lly, urx, ury))
+		pageDict.WriteString(fmt.Sprintf(" /CropBox [%s]", formatNumbers(llx, lly, urx, ury)))
 	}
 
 	// Rotation (if not 0)
@@ -221,7 +293,7 @@ func (w *PdfWriter) createPageWithContent(
 	// Generate content stream and resources
 	if len(textOps) > 0 {
 		// Generate content stream
-		content, resources, err := GenerateContentStream(textOps)
+		content, resources, err := GenerateContentStreamWithStyle(textOps, nil, w.contentStyle)
 		if err != nil {
 			// For now, skip content on error
 			// TODO: Better error handling
@@ -229,6 +301,7 @@ func (w *PdfWriter) createPageWithContent(
 			pageDict.WriteString(" >>")
 			return NewIndirectObject(objNum, 0, pageDict.Bytes()), nil, nil
 		}
+		content = offsetContent(content, page)
 
 		// Create font objects and assign object numbers
 		fontMap, err := CreateFontObjects(textOps)
@@ -240,43 +313,32 @@ func (w *PdfWriter) createPageWithContent(
 
 		fontObjs = make([]*IndirectObject, 0)
 		for fontName, fontDef := range fontMap {
-			fontObjNum := w.allocateObjNum()
-
-			// Create font object using WriteFontObject
-			var fontBuf bytes.Buffer
-			if err := fontDef.WriteFontObject(fontObjNum, &fontBuf); err != nil {
+			fontObjNum, fontObj := w.standardFontObject(fontName, fontDef)
+			if fontObjNum == 0 {
 				continue
 			}
-
-			// Extract just the dictionary part (without N 0 obj and endobj)
-			fontBytes := fontBuf.Bytes()
-			// Find the start of the dictionary (after "N 0 obj\n")
-			dictStart := bytes.Index(fontBytes, []byte("<<"))
-			dictEnd := bytes.LastIndex(fontBytes, []byte(">>")) + 2
-
-			if dictStart >= 0 && dictEnd > dictStart {
-				fontDict := fontBytes[dictStart:dictEnd]
-				fontObjs = append(fontObjs, NewIndirectObject(fontObjNum, 0, fontDict))
-
-				// Update resource dictionary using font ID.
-				fontKey := "std:" + fontName
-				resources.SetFontObjNumByID(fontKey, fontObjNum)
+			if fontObj != nil {
+				fontObjs = append(fontObjs, fontObj)
 			}
+
+			// Update resource dictionary using font ID.
+			resources.SetFontObjNumByID("std:"+fontName, fontObjNum)
 		}
+		content = w.applyGlobalResourceNames(content, resources)
 
 		// Write resources dictionary
 		pageDict.WriteString(" /Resources ")
 		pageDict.Write(resources.Bytes())
 
-		// Create content stream object with compression enabled
+		// Create content stream object at the configured compression level
 		contentObjNum := w.allocateObjNum()
-		contentObj = CreateContentStreamObject(contentObjNum, content, true)
+		contentObj = CreateContentStreamObjectWithLevel(contentObjNum, content, w.compression)
 
 		// Reference content stream
 		pageDict.WriteString(fmt.Sprintf(" /Contents %d 0 R", contentObjNum))
 	} else {
 		// No content - empty resources
-		pageDict.WriteString(" /Resources << >>")
+		contentObj = w.emptyPageContent(&pageDict)
 	}
 
 	pageDict.WriteString(" >>")
@@ -284,6 +346,20 @@ func (w *PdfWriter) createPageWithContent(
 	return NewIndirectObject(objNum, 0, pageDict.Bytes()), contentObj, fontObjs
 }
 
+// emptyPageContent writes the empty resources of a page without content
+// and, if enabled with SetEmptyContentStreams, a reference to an empty
+// content stream. Returns the content stream object, or nil.
+func (w *PdfWriter) emptyPageContent(pageDict *bytes.Buffer) *IndirectObject {
+	pageDict.WriteString(" /Resources << >>")
+	if !w.emptyContentStreams {
+		return nil
+	}
+
+	contentObjNum := w.allocateObjNum()
+	pageDict.WriteString(fmt.Sprintf(" /Contents %d 0 R", contentObjNum))
+	return CreateContentStreamObject(contentObjNum, nil, false)
+}
+
 // createPageWithAllContent creates a Page object with both text and graphics content.
 //
 // Similar to createPageWithContent but accepts both text and graphics operations.
@@ -308,13 +384,13 @@ func (w *PdfWriter) createPageWithAllContent(
 	mediaBox := page.MediaBox()
 	llx, lly := mediaBox.LowerLeft()
 	urx, ury := mediaBox.UpperRight()
-	pageDict.WriteString(fmt.Sprintf(" /MediaBox [%.2f %.2f %.2f %.2f]", llx, lly, urx, ury))
+	pageDict.WriteString(fmt.Sprintf(" /MediaBox [%s]", formatNumbers(llx, lly, urx, ury)))
 
 	// CropBox (if set)
 	if cropBox := page.CropBox(); cropBox != nil {
 		llx, lly := cropBox.LowerLeft()
 		urx, ury := cropBox.UpperRight()
-		pageDict.WriteString(fmt.Sprintf(" /CropBox [%.2f %.2f %.2f %.2f]", llx, lly, urx, ury))
+		pageDict.WriteString(fmt.Sprintf(" /CropBox [%s]", formatNumbers(llx, lly, urx, ury)))
 	}
 
 	// Rotation (if not 0)
@@ -325,98 +401,43 @@ func (w *PdfWriter) createPageWithAllContent(
 	// Generate content stream with graphics and text
 	if len(textOps) > 0 || len(graphicsOps) > 0 {
 		fontObjs = make([]*IndirectObject, 0)
-		hasTextContent := len(textOps) > 0 || hasTextBlockOps(graphicsOps)
-
-		// STEP 1: Collect fonts and BUILD SUBSETS FIRST.
-		// This is critical: content stream encoding needs GlyphMapping from built subsets.
-		var fontCollection *FontCollection
-		if hasTextContent {
-			var err error
-			fontCollection, err = CreateFontCollectionWithGraphics(textOps, graphicsOps)
-			if err != nil {
-				pageDict.WriteString(" /Resources << >>")
-				pageDict.WriteString(" >>")
-				return NewIndirectObject(objNum, 0, pageDict.Bytes()), nil, nil
-			}
-
-			// Build all embedded font subsets BEFORE generating content stream.
-			for _, embFont := range fontCollection.Embedded {
-				if embFont.Subset != nil {
-					_ = embFont.Subset.Build() // Ignore errors for now, will handle below.
-				}
-			}
-		}
 
-		// STEP 2: Generate content stream (now subsets are built, GlyphMapping available).
-		content, resources, err := GenerateContentStreamWithGraphics(textOps, graphicsOps)
+		// Images with alternate text become tagged /Figure elements.
+		var figures []structFigure
+		graphicsOps, figures = tagFigures(graphicsOps, objNum)
+		content, resources, objs, err := w.buildContent(textOps, graphicsOps)
 		if err != nil {
 			pageDict.WriteString(" /Resources << >>")
 			pageDict.WriteString(" >>")
 			return NewIndirectObject(objNum, 0, pageDict.Bytes()), nil, nil
 		}
+		content = offsetContent(content, page)
+		fontObjs = append(fontObjs, objs...)
 
-		// STEP 3: Create font objects and assign object numbers.
-		if fontCollection != nil {
-			// Process Standard14 fonts.
-			for fontName, fontDef := range fontCollection.Standard14 {
-				fontObjNum := w.allocateObjNum()
-
-				var fontBuf bytes.Buffer
-				if err := fontDef.WriteFontObject(fontObjNum, &fontBuf); err != nil {
-					continue
-				}
-
-				fontBytes := fontBuf.Bytes()
-				dictStart := bytes.Index(fontBytes, []byte("<<"))
-				dictEnd := bytes.LastIndex(fontBytes, []byte(">>")) + 2
-
-				if dictStart >= 0 && dictEnd > dictStart {
-					fontDict := fontBytes[dictStart:dictEnd]
-					fontObjs = append(fontObjs, NewIndirectObject(fontObjNum, 0, fontDict))
-
-					fontKey := "std:" + fontName
-					resources.SetFontObjNumByID(fontKey, fontObjNum)
-				}
-			}
-
-			// Process embedded TrueType fonts (subsets already built in STEP 1).
-			for fontID, embFont := range fontCollection.Embedded {
-				fontWriter := NewTrueTypeFontWriter(embFont.TTF, embFont.Subset, w.allocateObjNum)
-				fontObjects, refs, err := fontWriter.WriteFont()
-				if err != nil {
-					continue
-				}
-
-				fontObjs = append(fontObjs, fontObjects...)
+		// Write resources dictionary
+		pageDict.WriteString(" /Resources ")
+		pageDict.Write(resources.Bytes())
 
-				fontKey := "custom:" + fontID
-				resources.SetFontObjNumByID(fontKey, refs.FontObjNum)
-			}
+		// Transparent content needs a page group so it is composited
+		// against the page, not the viewer's background.
+		if usesTransparency(graphicsOps, resources) {
+			pageDict.WriteString(fmt.Sprintf(" /Group << /Type /Group /S /Transparency /CS /%s >>",
+				blendingColorSpace(textOps, graphicsOps)))
 		}
 
-		// STEP 3.5: Create image XObjects for image operations and assign object numbers.
-		imageObjs, err := w.createAndAssignImageXObjects(graphicsOps, resources)
-		if err != nil {
-			// Log error but continue - don't fail the whole page
-			// TODO: Add logging when available
-			_ = err
-		} else {
-			fontObjs = append(fontObjs, imageObjs...)
+		if len(figures) > 0 {
+			pageDict.WriteString(fmt.Sprintf(" /StructParents %d", w.addStructPage(figures)))
 		}
 
-		// Write resources dictionary
-		pageDict.WriteString(" /Resources ")
-		pageDict.Write(resources.Bytes())
-
-		// Create content stream object with compression enabled
+		// Create content stream object at the configured compression level
 		contentObjNum := w.allocateObjNum()
-		contentObj = CreateContentStreamObject(contentObjNum, content, true)
+		contentObj = CreateContentStreamObjectWithLevel(contentObjNum, content, w.compression)
 
 		// Reference content stream
 		pageDict.WriteString(fmt.Sprintf(" /Contents %d 0 R", contentObjNum))
 	} else {
 		// No content - empty resources
-		pageDict.WriteString(" /Resources << >>")
+		contentObj = w.emptyPageContent(&pageDict)
 	}
 
 	// Add annotations if present (all types).
@@ -444,6 +465,133 @@ func (w *PdfWriter) createPageWithAllContent(
 	return NewIndirectObject(objNum, 0, pageDict.Bytes()), contentObj, fontObjs
 }
 
+// buildContent generates the content stream of text and graphics
+// operations and creates the objects its resources refer to: fonts,
+// images, form XObjects, graphics states and shadings. Pages and form
+// XObjects are built the same way.
+func (w *PdfWriter) buildContent(textOps []TextOp, graphicsOps []GraphicsOp) ([]byte, *ResourceDictionary, []*IndirectObject, error) {
+	// STEP 1: Collect fonts and BUILD SUBSETS FIRST.
+	// This is critical: content stream encoding needs GlyphMapping from built subsets.
+	var fontCollection *FontCollection
+	if len(textOps) > 0 || hasTextBlockOps(graphicsOps) {
+		var err error
+		fontCollection, err = CreateFontCollectionWithGraphics(textOps, graphicsOps)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// Build all embedded font subsets BEFORE generating content stream.
+		for _, embFont := range fontCollection.Embedded {
+			w.buildFontSubset(embFont)
+		}
+	}
+
+	// STEP 2: Generate content stream (now subsets are built, GlyphMapping available).
+	content, resources, err := GenerateContentStreamWithStyle(textOps, graphicsOps, w.contentStyle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// STEP 3: Create font objects and assign object numbers.
+	var objs []*IndirectObject
+	if fontCollection != nil {
+		// Process Standard14 fonts.
+		for fontName, fontDef := range fontCollection.Standard14 {
+			fontObjNum, fontObj := w.standardFontObject(fontName, fontDef)
+			if fontObjNum == 0 {
+				continue
+			}
+			if fontObj != nil {
+				objs = append(objs, fontObj)
+			}
+
+			resources.SetFontObjNumByID("std:"+fontName, fontObjNum)
+		}
+
+		// Process embedded TrueType fonts (subsets already built in STEP 1).
+		for fontID, embFont := range fontCollection.Embedded {
+			fontObjNum, fontObjects, err := w.embeddedFontObject(embFont)
+			if err != nil {
+				continue
+			}
+
+			objs = append(objs, fontObjects...)
+
+			fontKey := "custom:" + fontID
+			resources.SetFontObjNumByID(fontKey, fontObjNum)
+		}
+	}
+
+	// STEP 3.5: Create image XObjects for image operations and assign object numbers.
+	imageObjs, err := w.createAndAssignImageXObjects(graphicsOps, resources)
+	if err != nil {
+		// Log error but continue - don't fail the whole page
+		// TODO: Add logging when available
+		_ = err
+	} else {
+		objs = append(objs, imageObjs...)
+	}
+
+	// STEP 3.6: Create form XObjects (templates) and assign object numbers.
+	formObjs, err := w.createAndAssignFormXObjects(graphicsOps, resources)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	objs = append(objs, formObjs...)
+
+	// STEP 3.7: Create ExtGState objects (opacity, stroke adjustment, overprint).
+	objs = append(objs, w.createExtGStateObjects(resources)...)
+
+	// STEP 3.8: Create shading objects (gradient fills).
+	objs = append(objs, w.createShadingObjects(resources)...)
+
+	// STEP 4: Name the resources after their objects (if enabled).
+	content = w.applyGlobalResourceNames(content, resources)
+
+	return content, resources, objs, nil
+}
+
+// usesTransparency reports whether a page's content uses transparency:
+// an ExtGState with an opacity below 1.0 or an image with a soft mask.
+func usesTransparency(graphicsOps []GraphicsOp, resources *ResourceDictionary) bool {
+	if resources.UsesTransparency() {
+		return true
+	}
+	for _, gop := range graphicsOps {
+		if gop.Type == 3 && gop.Image != nil && len(gop.Image.AlphaMask) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// blendingColorSpace returns the transparency group color space for a
+// page: DeviceCMYK if everything on it is painted in CMYK, otherwise
+// DeviceRGB.
+//
+// Reference: PDF 1.7 Specification, Section 11.6.6 (Transparency Group XObjects).
+func blendingColorSpace(textOps []TextOp, graphicsOps []GraphicsOp) string {
+	if len(textOps) > 0 {
+		return "DeviceRGB"
+	}
+	cmyk := false
+	for _, gop := range graphicsOps {
+		switch {
+		case gop.Type == 3 || gop.Type == 4 || gop.Type == 22:
+			return "DeviceRGB" // Images, watermarks and text blocks are RGB
+		case (gop.FillColor != nil && gop.FillColorCMYK == nil) ||
+			(gop.StrokeColor != nil && gop.StrokeColorCMYK == nil):
+			return "DeviceRGB"
+		case gop.FillColorCMYK != nil || gop.StrokeColorCMYK != nil:
+			cmyk = true
+		}
+	}
+	if cmyk {
+		return "DeviceCMYK"
+	}
+	return "DeviceRGB"
+}
+
 // createPage creates an individual Page object (backward compatibility).
 //
 // This is kept for existing code that doesn't have content operations.
@@ -456,10 +604,11 @@ func (w *PdfWriter) createPage(page *document.Page, objNum int, parentRef int) *
 // and assigns their object numbers to the resource dictionary.
 //
 // This function:
-// 1. Collects all image operations from graphicsOps
-// 2. For each image, allocates an object number and creates the XObject
-// 3. Creates an SMask (soft mask) for images with alpha transparency
-// 4. Assigns the object numbers to the resource dictionary entries created during content stream generation
+//  1. Collects all image operations from graphicsOps
+//  2. For each image, allocates an object number and creates the XObject,
+//     or reuses the XObject of an identical image (see imageXObject)
+//  3. Creates an SMask (soft mask) for images with alpha transparency
+//  4. Assigns the object numbers to the resource dictionary entries created during content stream generation
 //
 // Note: The resource dictionary already has placeholder image entries (Im1, Im2, etc.)
 // created during content stream generation. This function assigns real object numbers to them.
@@ -478,22 +627,10 @@ func (w *PdfWriter) createAndAssignImageXObjects(graphicsOps []GraphicsOp, resou
 		}
 	}
 
-	// Create XObject for each image
+	// Create XObject for each image, or reuse an identical one
 	for i, img := range images {
-		// Allocate object number for the image XObject
-		imageObjNum := w.allocateObjNum()
-
-		// Handle alpha mask (SMask) for PNG with transparency
-		var smaskObjNum int
-		if len(img.AlphaMask) > 0 {
-			smaskObjNum = w.allocateObjNum()
-			smaskObj := w.createSMaskObject(smaskObjNum, img)
-			objects = append(objects, smaskObj)
-		}
-
-		// Create the image XObject
-		imageObj := w.createImageXObject(imageObjNum, img, smaskObjNum)
-		objects = append(objects, imageObj)
+		imageObjNum, imageObjs := w.imageXObject(img)
+		objects = append(objects, imageObjs...)
 
 		// Set the object number in the resource dictionary
 		// The resource names (Im1, Im2, ...) were created during content stream generation
@@ -535,21 +672,45 @@ func (w *PdfWriter) setImageResourceObjNum(resources *ResourceDictionary, name s
 //	... compressed pixel data ...
 //	endstream
 //	endobj
+//
+// Uncompressed samples (img.DataRaw: PNG samples that did not shrink under
+// Flate, or raw output of a custom encoder) are written without a /Filter
+// entry. An explicit img.Filter (from a custom image
+// encoder) is written as given. Indexed images carry their palette in the
+// color space: /ColorSpace [/Indexed /DeviceRGB hival <palette>]. Stencil
+// masks (img.ImageMask) are written with /ImageMask true and no color
+// space.
 func (w *PdfWriter) createImageXObject(objNum int, img *ImageData, smaskObjNum int) *IndirectObject {
 	var buf bytes.Buffer
 
 	// Write stream dictionary
 	buf.WriteString("<< /Type /XObject /Subtype /Image")
 	buf.WriteString(fmt.Sprintf(" /Width %d /Height %d", img.Width, img.Height))
-	buf.WriteString(fmt.Sprintf(" /ColorSpace /%s", img.ColorSpace))
-	buf.WriteString(fmt.Sprintf(" /BitsPerComponent %d", img.BitsPerComponent))
+	switch {
+	case img.ImageMask:
+		buf.WriteString(" /ImageMask true")
+	case img.ColorSpace == "Indexed":
+		buf.WriteString(fmt.Sprintf(" /ColorSpace [/Indexed /DeviceRGB %d <%X>]", len(img.Palette)/3-1, img.Palette))
+		buf.WriteString(fmt.Sprintf(" /BitsPerComponent %d", img.BitsPerComponent))
+	default:
+		buf.WriteString(fmt.Sprintf(" /ColorSpace /%s", img.ColorSpace))
+		buf.WriteString(fmt.Sprintf(" /BitsPerComponent %d", img.BitsPerComponent))
+	}
 
 	// Add filter based on format
-	if img.Format == "jpeg" {
+	if img.Filter != "" {
+		buf.WriteString(fmt.Sprintf(" /Filter /%s", img.Filter))
+	} else if img.Format == "jpeg" && !img.DataRaw {
 		buf.WriteString(" /Filter /DCTDecode")
-	} else if img.Format == "png" {
+	} else if img.Format == "png" && !img.DataRaw {
 		buf.WriteString(" /Filter /FlateDecode")
 	}
+	if img.DecodeParms != "" {
+		buf.WriteString(" /DecodeParms " + img.DecodeParms)
+	}
+	if img.Decode != "" {
+		buf.WriteString(" /Decode " + img.Decode)
+	}
 
 	// Add SMask reference if alpha mask exists
 	if smaskObjNum > 0 {
@@ -587,7 +748,9 @@ func (w *PdfWriter) createSMaskObject(objNum int, img *ImageData) *IndirectObjec
 	buf.WriteString(fmt.Sprintf(" /Width %d /Height %d", img.Width, img.Height))
 	buf.WriteString(" /ColorSpace /DeviceGray")
 	buf.WriteString(" /BitsPerComponent 8")
-	buf.WriteString(" /Filter /FlateDecode")
+	if !img.AlphaMaskRaw {
+		buf.WriteString(" /Filter /FlateDecode")
+	}
 	buf.WriteString(fmt.Sprintf(" /Length %d >>\n", len(img.AlphaMask)))
 
 	// Write stream
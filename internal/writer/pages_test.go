@@ -1,11 +1,16 @@
 package writer
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
+	"golang.org/x/image/font/gofont/goregular"
+
 	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/fonts"
 	"github.com/coregx/gxpdf/internal/models/types"
 )
 
@@ -318,11 +323,11 @@ func TestCreatePage_DifferentSizes(t *testing.T) {
 
 			// Check coordinates are present (with some tolerance for formatting)
 			mediaBoxPart := data[strings.Index(data, "/MediaBox"):]
-			if !strings.Contains(mediaBoxPart, fmt.Sprintf("%.2f", llx)) {
-				t.Errorf("MediaBox should contain llx coordinate %.2f", llx)
+			if !strings.Contains(mediaBoxPart, formatNumber(llx)) {
+				t.Errorf("MediaBox should contain llx coordinate %s", formatNumber(llx))
 			}
-			if !strings.Contains(mediaBoxPart, fmt.Sprintf("%.2f", urx)) {
-				t.Errorf("MediaBox should contain urx coordinate %.2f", urx)
+			if !strings.Contains(mediaBoxPart, formatNumber(urx)) {
+				t.Errorf("MediaBox should contain urx coordinate %s", formatNumber(urx))
 			}
 		})
 	}
@@ -350,3 +355,402 @@ func TestCreatePageTree_EmptyDocument(t *testing.T) {
 		t.Error("createPageTree() should return at least the Pages root object")
 	}
 }
+
+func TestCreateImageXObject_RawPNG(t *testing.T) {
+	w := &PdfWriter{
+		nextObjNum: 1,
+		objects:    make([]*IndirectObject, 0),
+		offsets:    make(map[int]int64),
+	}
+
+	img := &ImageData{
+		Data:             []byte{1, 2, 3},
+		AlphaMask:        []byte{4},
+		Width:            1,
+		Height:           1,
+		ColorSpace:       "DeviceRGB",
+		Format:           "png",
+		BitsPerComponent: 8,
+		DataRaw:          true,
+		AlphaMaskRaw:     true,
+	}
+
+	imageObj := string(w.createImageXObject(5, img, 6).Data)
+	if strings.Contains(imageObj, "/Filter") {
+		t.Errorf("raw image should not have /Filter, got: %s", imageObj)
+	}
+	if !strings.Contains(imageObj, "/Length 3") {
+		t.Errorf("expected /Length 3, got: %s", imageObj)
+	}
+
+	smaskObj := string(w.createSMaskObject(6, img).Data)
+	if strings.Contains(smaskObj, "/Filter") {
+		t.Errorf("raw SMask should not have /Filter, got: %s", smaskObj)
+	}
+
+	img.DataRaw = false
+	imageObj = string(w.createImageXObject(5, img, 0).Data)
+	if !strings.Contains(imageObj, "/Filter /FlateDecode") {
+		t.Errorf("compressed image should have /Filter /FlateDecode, got: %s", imageObj)
+	}
+}
+
+// TestCreateImageXObject_CustomFilter tests that an explicit encoder filter
+// is written as given.
+func TestCreateImageXObject_CustomFilter(t *testing.T) {
+	w := &PdfWriter{
+		nextObjNum: 1,
+		objects:    make([]*IndirectObject, 0),
+		offsets:    make(map[int]int64),
+	}
+
+	img := &ImageData{
+		Data:             []byte{1, 2, 3},
+		Width:            1,
+		Height:           1,
+		ColorSpace:       "DeviceGray",
+		Format:           "webp-flate",
+		Filter:           "FlateDecode",
+		BitsPerComponent: 8,
+	}
+
+	imageObj := string(w.createImageXObject(5, img, 0).Data)
+	if !strings.Contains(imageObj, "/ColorSpace /DeviceGray") {
+		t.Errorf("expected /ColorSpace /DeviceGray, got: %s", imageObj)
+	}
+	if strings.Count(imageObj, "/Filter") != 1 || !strings.Contains(imageObj, "/Filter /FlateDecode") {
+		t.Errorf("expected a single /Filter /FlateDecode, got: %s", imageObj)
+	}
+}
+
+func TestCreatePageWithAllContent_TransparencyGroup(t *testing.T) {
+	watermark := GraphicsOp{
+		Type:             4,
+		X:                100,
+		Y:                400,
+		Text:             "DRAFT",
+		TextSize:         48,
+		WatermarkFont:    "Helvetica",
+		WatermarkOpacity: 0.3,
+	}
+	rect := GraphicsOp{Type: 1, X: 10, Y: 10, Width: 50, Height: 50, FillColor: &RGB{R: 1}}
+	cmykRect := GraphicsOp{Type: 1, X: 10, Y: 10, Width: 50, Height: 50, FillColorCMYK: &CMYK{C: 1}}
+
+	tests := []struct {
+		name string
+		ops  []GraphicsOp
+		want string // Expected /Group entry, empty for none
+	}{
+		{"opaque", []GraphicsOp{rect}, ""},
+		{"transparent watermark", []GraphicsOp{rect, watermark}, "/Group << /Type /Group /S /Transparency /CS /DeviceRGB >>"},
+		{"opaque CMYK", []GraphicsOp{cmykRect}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &PdfWriter{nextObjNum: 10}
+			page := document.NewPage(0, document.A4)
+
+			pageObj, _, _ := w.createPageWithAllContent(page, 3, 2, nil, tt.ops)
+			data := string(pageObj.Data)
+
+			if tt.want == "" {
+				if strings.Contains(data, "/Group") {
+					t.Errorf("page should not have /Group, got: %s", data)
+				}
+				return
+			}
+			if !strings.Contains(data, tt.want) {
+				t.Errorf("page should contain %q, got: %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestBlendingColorSpace(t *testing.T) {
+	cmyk := GraphicsOp{Type: 1, FillColorCMYK: &CMYK{C: 1}}
+	rgb := GraphicsOp{Type: 1, StrokeColor: &RGB{G: 1}}
+
+	if got := blendingColorSpace(nil, []GraphicsOp{cmyk}); got != "DeviceCMYK" {
+		t.Errorf("CMYK-only page = %s, want DeviceCMYK", got)
+	}
+	if got := blendingColorSpace(nil, []GraphicsOp{cmyk, rgb}); got != "DeviceRGB" {
+		t.Errorf("mixed page = %s, want DeviceRGB", got)
+	}
+	if got := blendingColorSpace([]TextOp{{Text: "x"}}, []GraphicsOp{cmyk}); got != "DeviceRGB" {
+		t.Errorf("page with text = %s, want DeviceRGB", got)
+	}
+}
+
+func TestCreatePageTreeWithAllContent_SharesStandardFonts(t *testing.T) {
+	doc := document.NewDocument()
+	textContents := make(map[int][]TextOp)
+	for i := 0; i < 3; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error: %v", err)
+		}
+		textContents[i] = []TextOp{
+			{Text: "Title", X: 72, Y: 770, Font: "Helvetica-Bold", Size: 14},
+			{Text: "Body", X: 72, Y: 740, Font: "Helvetica", Size: 11},
+		}
+	}
+	// The last page also has graphics, so it takes the full path.
+	graphicsContents := map[int][]GraphicsOp{
+		2: {{Type: 1, X: 72, Y: 72, Width: 100, Height: 50, StrokeColor: &RGB{}}},
+	}
+
+	w := &PdfWriter{nextObjNum: 1}
+	objects, _, err := w.createPageTreeWithAllContent(doc, textContents, graphicsContents)
+	if err != nil {
+		t.Fatalf("createPageTreeWithAllContent() error: %v", err)
+	}
+
+	var fontRefs, pages []string
+	for _, obj := range objects {
+		data := string(obj.Data)
+		switch {
+		case strings.Contains(data, "/Type /Font"):
+			fontRefs = append(fontRefs, fmt.Sprintf("%d 0 R", obj.Number))
+		case strings.Contains(data, "/Type /Page "):
+			pages = append(pages, data)
+		}
+	}
+
+	if len(fontRefs) != 2 {
+		t.Fatalf("expected 2 font objects for 3 pages, got %d", len(fontRefs))
+	}
+	for i, page := range pages {
+		for _, ref := range fontRefs {
+			if !strings.Contains(page, ref) {
+				t.Errorf("page %d should reference shared font %s", i+1, ref)
+			}
+		}
+	}
+}
+
+// goRegularFont returns the Go Regular TrueType font as an embedded font
+// with an empty subset.
+func goRegularFont(tb testing.TB) *EmbeddedFont {
+	tb.Helper()
+	ttf, err := fonts.ParseTTF(goregular.TTF)
+	if err != nil {
+		tb.Fatalf("ParseTTF() error: %v", err)
+	}
+	return &EmbeddedFont{TTF: ttf, Subset: fonts.NewFontSubset(ttf), ID: "GoRegular"}
+}
+
+// embeddedFontDocument returns a document of n pages with a line of text
+// each in font. The font's subset holds the characters of all pages, as
+// it does when the creator writes a document.
+func embeddedFontDocument(tb testing.TB, font *EmbeddedFont, n int) (*document.Document, map[int][]TextOp) {
+	tb.Helper()
+	doc := document.NewDocument()
+	textContents := make(map[int][]TextOp, n)
+	for i := 0; i < n; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			tb.Fatalf("AddPage() error: %v", err)
+		}
+		text := fmt.Sprintf("Page %d of the quarterly report", i+1)
+		font.Subset.UseString(text)
+		textContents[i] = []TextOp{{Text: text, X: 72, Y: 750, Size: 12, CustomFont: font}}
+	}
+	return doc, textContents
+}
+
+func TestCreatePageTreeWithAllContent_SharesEmbeddedFont(t *testing.T) {
+	font := goRegularFont(t)
+	doc, textContents := embeddedFontDocument(t, font, 2)
+
+	w := &PdfWriter{nextObjNum: 1}
+	objects, _, err := w.createPageTreeWithAllContent(doc, textContents, nil)
+	if err != nil {
+		t.Fatalf("createPageTreeWithAllContent() error: %v", err)
+	}
+
+	var fontRefs, pages []string
+	for _, obj := range objects {
+		data := string(obj.Data)
+		switch {
+		case strings.Contains(data, "/Subtype /Type0"):
+			fontRefs = append(fontRefs, fmt.Sprintf("%d 0 R", obj.Number))
+		case strings.Contains(data, "/Type /Page "):
+			pages = append(pages, data)
+		}
+	}
+	if len(fontRefs) != 1 {
+		t.Fatalf("expected 1 font object for 2 pages, got %d", len(fontRefs))
+	}
+	for i, page := range pages {
+		if !strings.Contains(page, fontRefs[0]) {
+			t.Errorf("page %d should reference shared font %s", i+1, fontRefs[0])
+		}
+	}
+
+	// A page using no new characters does not rebuild the subset.
+	marker := []byte("built")
+	font.Subset.SubsetData = marker
+	w.buildFontSubset(font)
+	if !bytes.Equal(font.Subset.SubsetData, marker) {
+		t.Error("subset was rebuilt although no characters were added")
+	}
+	font.Subset.UseString("Z")
+	w.buildFontSubset(font)
+	if bytes.Equal(font.Subset.SubsetData, marker) {
+		t.Error("subset was not rebuilt after characters were added")
+	}
+}
+
+func TestCreatePageTreeWithAllContent_TextOnlyPageWithAnnotations(t *testing.T) {
+	doc := document.NewDocument()
+	page, err := doc.AddPage(document.A4)
+	if err != nil {
+		t.Fatalf("AddPage() error: %v", err)
+	}
+	if err := page.AddLinkAnnotation(document.NewLinkAnnotation([4]float64{72, 700, 200, 720}, "https://example.com")); err != nil {
+		t.Fatalf("AddLinkAnnotation() error: %v", err)
+	}
+	textContents := map[int][]TextOp{0: {{Text: "Link", X: 72, Y: 705, Font: "Helvetica", Size: 12}}}
+
+	if isTextOnlyPage(page, textContents[0], nil) {
+		t.Error("page with annotations should not take the text-only path")
+	}
+
+	w := &PdfWriter{nextObjNum: 1}
+	objects, _, err := w.createPageTreeWithAllContent(doc, textContents, nil)
+	if err != nil {
+		t.Fatalf("createPageTreeWithAllContent() error: %v", err)
+	}
+	if !strings.Contains(string(objects[1].Data), "/Annots [") {
+		t.Errorf("page should have /Annots, got: %s", objects[1].Data)
+	}
+}
+
+func TestIsTextOnlyPage(t *testing.T) {
+	page := document.NewPage(0, document.A4)
+	text := []TextOp{{Text: "x", Font: "Helvetica", Size: 12}}
+
+	tests := []struct {
+		name     string
+		textOps  []TextOp
+		graphics []GraphicsOp
+		want     bool
+	}{
+		{"empty", nil, nil, true},
+		{"standard fonts", text, nil, true},
+		{"graphics", text, []GraphicsOp{{Type: 1}}, false},
+		{"custom font", []TextOp{{Text: "x", CustomFont: &EmbeddedFont{ID: "f"}}}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTextOnlyPage(page, tt.textOps, tt.graphics); got != tt.want {
+				t.Errorf("isTextOnlyPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePage_EmptyContentStreams(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		for name, create := range map[string]func(w *PdfWriter, page *document.Page) (*IndirectObject, *IndirectObject){
+			"createPageWithContent": func(w *PdfWriter, page *document.Page) (*IndirectObject, *IndirectObject) {
+				pageObj, contentObj, _ := w.createPageWithContent(page, 3, 2, nil)
+				return pageObj, contentObj
+			},
+			"createPageWithAllContent": func(w *PdfWriter, page *document.Page) (*IndirectObject, *IndirectObject) {
+				pageObj, contentObj, _ := w.createPageWithAllContent(page, 3, 2, nil, nil)
+				return pageObj, contentObj
+			},
+		} {
+			t.Run(fmt.Sprintf("%s/%v", name, enabled), func(t *testing.T) {
+				w := &PdfWriter{nextObjNum: 10}
+				w.SetEmptyContentStreams(enabled)
+
+				pageObj, contentObj := create(w, document.NewPage(0, document.A4))
+				data := string(pageObj.Data)
+
+				if !strings.Contains(data, "/Resources << >>") {
+					t.Errorf("blank page should have empty resources, got: %s", data)
+				}
+				if !enabled {
+					if contentObj != nil || strings.Contains(data, "/Contents") {
+						t.Errorf("blank page should have no content stream, got: %s", data)
+					}
+					return
+				}
+				if contentObj == nil {
+					t.Fatal("blank page should have a content stream")
+				}
+				if want := fmt.Sprintf("/Contents %d 0 R", contentObj.Number); !strings.Contains(data, want) {
+					t.Errorf("page should contain %q, got: %s", want, data)
+				}
+				if got := string(contentObj.Data); got != "<< /Length 0 >>\nstream\nendstream" {
+					t.Errorf("content stream = %q, want a zero-length stream", got)
+				}
+			})
+		}
+	}
+}
+
+// textOnlyDocument returns a document of n pages with 40 lines of text
+// each, in two Standard 14 fonts.
+func textOnlyDocument(b *testing.B, n int) (*document.Document, map[int][]TextOp) {
+	b.Helper()
+
+	doc := document.NewDocument()
+	textContents := make(map[int][]TextOp, n)
+	for i := 0; i < n; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			b.Fatalf("AddPage() error: %v", err)
+		}
+		ops := make([]TextOp, 0, 40)
+		for line := 0; line < 40; line++ {
+			font := "Helvetica"
+			if line == 0 {
+				font = "Helvetica-Bold"
+			}
+			ops = append(ops, TextOp{
+				Text: fmt.Sprintf("Page %d, line %d: the quick brown fox jumps over the lazy dog", i+1, line+1),
+				X:    72,
+				Y:    770 - float64(line)*18,
+				Font: font,
+				Size: 11,
+			})
+		}
+		textContents[i] = ops
+	}
+	return doc, textContents
+}
+
+func BenchmarkWriteWithAllContent_TextOnly(b *testing.B) {
+	doc, textContents := textOnlyDocument(b, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := NewPdfWriterFromWriter(io.Discard)
+		if err := w.WriteWithAllContent(doc, textContents, nil); err != nil {
+			b.Fatalf("WriteWithAllContent() error: %v", err)
+		}
+	}
+}
+
+// BenchmarkWriteWithAllContent_EmbeddedFont writes documents of 1, 10 and
+// 100 pages in one embedded font. The subset is built once per document,
+// not once per page, so ns/page falls as the page count grows.
+func BenchmarkWriteWithAllContent_EmbeddedFont(b *testing.B) {
+	for _, n := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("pages=%d", n), func(b *testing.B) {
+			doc, textContents := embeddedFontDocument(b, goRegularFont(b), n)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				w := NewPdfWriterFromWriter(io.Discard)
+				if err := w.WriteWithAllContent(doc, textContents, nil); err != nil {
+					b.Fatalf("WriteWithAllContent() error: %v", err)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/page")
+		})
+	}
+}
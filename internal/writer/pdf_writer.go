@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/coregx/gxpdf/internal/document"
@@ -31,11 +32,65 @@ import (
 type PdfWriter struct {
 	file        *os.File          // Output file (nil for io.Writer mode)
 	writer      *bufio.Writer     // Buffered writer
-	countWriter *countingWriter   // Tracks bytes written (for io.Writer mode)
+	countWriter *countingWriter   // Tracks bytes passed to the output (see getCurrentOffset)
 	objects     []*IndirectObject // All objects to write
 	offsets     map[int]int64     // Byte offsets for each object number
 	nextObjNum  int               // Next available object number
 	closed      bool              // Whether Close() has been called
+
+	contentStyle        ContentStyle     // Page content stream layout
+	compression         CompressionLevel // Flate level of content streams and images (see SetCompression)
+	emptyContentStreams bool             // Give blank pages an empty content stream (see SetEmptyContentStreams)
+	globalResourceNames bool             // Name resources after their object numbers (see SetGlobalResourceNames)
+
+	stdFontNums      map[string]int              // Standard 14 font objects shared by all pages, by font name
+	imageNums        map[resourceHash]int        // Image XObjects shared by all pages, by content hash
+	embeddedFontNums map[resourceHash]int        // Embedded font objects shared by all pages, by subset hash
+	formNums         map[string]int              // Form XObjects shared by all pages, by form ID (0 = being written)
+	fontSubsets      map[string]*fontSubsetState // Built embedded font subsets, by font ID
+	importNums       map[importKey]int           // Objects copied from imported pages (see ImportedPage)
+
+	// AcroForm state collected while writing pages.
+	formFieldRefs   []int                               // Top-level /Fields entries
+	formParents     map[*document.FormField]*formParent // Radio group parents
+	formParentOrder []*document.FormField               // Parents in first-seen order
+	formFieldNums   map[string]int                      // Top-level field object numbers by name (for /CO)
+	acroFormFontNum int                                 // Helvetica font for /DR (0 = none)
+
+	// Structure tree state collected while writing pages.
+	structPages       []*structPage // Pages with tagged figures, by /StructParents key
+	structTreeRootNum int           // StructTreeRoot object (0 = none)
+
+	// Limits set with SetLimits (0 = unlimited).
+	maxObjects int64
+	maxBytes   int64
+	limitErr   error // Set once more than maxObjects objects are allocated
+
+	checksum    bool // Reserve a checksum entry in the Info dictionary (see SetChecksum)
+	pageChunked bool // Write document-level objects before the page chunks (see SetPageChunked)
+	xrefStream  bool // Write a cross-reference stream for PDF 1.5+ (see SetXRefStream)
+
+	objectStreams bool                // Pack objects into object streams (see SetObjectStreams)
+	compressed    map[int]objStmEntry // Objects packed into object streams, by object number
+
+	usageRights    *UsageRights // Usage rights signature placeholder (see SetUsageRights)
+	usageRightsNum int          // Usage rights signature object (0 = none)
+
+	acroFormOpts *AcroFormOptions // AcroForm dictionary options (see SetAcroFormOptions)
+
+	xmp         []byte // XMP metadata packet (see SetXMPMetadata)
+	metadataNum int    // Metadata stream object (0 = none)
+
+	pdfa             bool // Write PDF/A-1b output (see SetPDFA)
+	outputProfileNum int  // Output intent ICC profile object (0 = none)
+
+	infoNum int // Info dictionary object (0 = none)
+
+	outline    []*OutlineItem // Document outline (see SetOutline)
+	outlineNum int            // Outline dictionary object (0 = none)
+	pageNums   []int          // Page object numbers, by page index
+
+	streamRootNum int // Pages root of the streamed document (0 = not streaming, see BeginStream)
 }
 
 // countingWriter wraps an io.Writer and tracks bytes written.
@@ -61,13 +116,16 @@ func NewPdfWriter(path string) (*PdfWriter, error) {
 		return nil, fmt.Errorf("failed to create file: %w", err)
 	}
 
+	cw := &countingWriter{w: file}
 	return &PdfWriter{
-		file:       file,
-		writer:     bufio.NewWriter(file),
-		objects:    make([]*IndirectObject, 0),
-		offsets:    make(map[int]int64),
-		nextObjNum: 1, // Object numbering starts at 1
-		closed:     false,
+		file:        file,
+		countWriter: cw,
+		writer:      bufio.NewWriter(cw),
+		objects:     make([]*IndirectObject, 0),
+		offsets:     make(map[int]int64),
+		nextObjNum:  1, // Object numbering starts at 1
+		closed:      false,
+		compression: DefaultCompression,
 	}, nil
 }
 
@@ -94,9 +152,26 @@ func NewPdfWriterFromWriter(w io.Writer) *PdfWriter {
 		offsets:     make(map[int]int64),
 		nextObjNum:  1,
 		closed:      false,
+		compression: DefaultCompression,
 	}
 }
 
+// SetContentStyle sets the operator layout of page content streams.
+//
+// Use ContentStylePretty for readable (uncompressed) debugging output and
+// ContentStyleCompact to minimize file size. Must be called before writing.
+func (w *PdfWriter) SetContentStyle(style ContentStyle) {
+	w.contentStyle = style
+}
+
+// SetEmptyContentStreams sets whether pages without content get an empty
+// content stream (/Contents referencing a zero-length stream) instead of
+// no /Contents entry. Both are valid, but some strict validators warn
+// about pages without a content stream. Must be called before writing.
+func (w *PdfWriter) SetEmptyContentStreams(enabled bool) {
+	w.emptyContentStreams = enabled
+}
+
 // WriteWithPageContent writes a document with page content operations to the PDF file.
 //
 // This is similar to Write() but accepts page-level content operations
@@ -121,6 +196,10 @@ func (w *PdfWriter) WriteWithPageContent(doc *document.Document, pageContents ma
 	w.objects = make([]*IndirectObject, 0)
 	w.offsets = make(map[int]int64)
 	w.nextObjNum = 1
+	w.limitErr = nil
+	w.stdFontNums = nil
+	w.imageNums, w.embeddedFontNums, w.formNums, w.importNums = nil, nil, nil, nil
+	w.fontSubsets = nil
 
 	// Write PDF header
 	if err := w.writeHeader(doc.Version().String()); err != nil {
@@ -136,36 +215,45 @@ func (w *PdfWriter) WriteWithPageContent(doc *document.Document, pageContents ma
 	// Add pages objects to write queue
 	w.objects = append(w.objects, pagesObjs...)
 
+	// Create the outline (referenced from the catalog)
+	w.objects = append(w.objects, w.createOutlineObjects(doc)...)
+
+	// Create the XMP metadata stream (referenced from the catalog)
+	if metaObj := w.createMetadataObject(); metaObj != nil {
+		w.objects = append(w.objects, metaObj)
+	}
+
+	// Create the PDF/A output intent profile (referenced from the catalog)
+	profileObj, err := w.createOutputIntentObject()
+	if err != nil {
+		return err
+	}
+	if profileObj != nil {
+		w.objects = append(w.objects, profileObj)
+	}
+
 	// Create catalog (references pages root)
 	catalogObj := w.createCatalog(pagesRootRef, doc)
 	w.objects = append([]*IndirectObject{catalogObj}, w.objects...)
 
-	// Write all objects and track their offsets
-	for _, obj := range w.objects {
-		// Get current offset
-		pos, err := w.getCurrentOffset()
-		if err != nil {
-			return fmt.Errorf("failed to get file position: %w", err)
-		}
-
-		w.offsets[obj.Number] = pos
+	// Create Info dictionary (referenced from the trailer)
+	infoObj := w.createInfoObject(doc)
+	if infoObj != nil {
+		w.objects = append(w.objects, infoObj)
+	}
 
-		if _, err := obj.WriteTo(w.writer); err != nil {
-			return fmt.Errorf("failed to write object %d: %w", obj.Number, err)
-		}
+	if w.limitErr != nil {
+		return w.limitErr
 	}
 
-	// Write cross-reference table
-	xrefOffset, err := w.writeXRef()
-	if err != nil {
-		return fmt.Errorf("failed to write xref: %w", err)
+	// Write all objects and track their offsets
+	if err := w.writeObjects(doc, catalogObj.Number); err != nil {
+		return err
 	}
 
-	// Write trailer
-	catalogRef := catalogObj.Number
-	size := w.nextObjNum
-	if err := w.writeTrailer(catalogRef, size, xrefOffset, doc); err != nil {
-		return fmt.Errorf("failed to write trailer: %w", err)
+	// Write cross-reference section and trailer
+	if err := w.writeXRefAndTrailer(doc, catalogObj.Number, infoObjNum(infoObj)); err != nil {
+		return err
 	}
 
 	// Flush buffer
@@ -204,6 +292,12 @@ func (w *PdfWriter) WriteWithAllContent(
 	w.objects = make([]*IndirectObject, 0)
 	w.offsets = make(map[int]int64)
 	w.nextObjNum = 1
+	w.limitErr = nil
+	w.stdFontNums = nil
+	w.imageNums, w.embeddedFontNums, w.formNums, w.importNums = nil, nil, nil, nil
+	w.fontSubsets = nil
+	w.resetFormState()
+	w.resetStructState()
 
 	// Write PDF header
 	if err := w.writeHeader(doc.Version().String()); err != nil {
@@ -216,39 +310,30 @@ func (w *PdfWriter) WriteWithAllContent(
 		return fmt.Errorf("failed to create page tree: %w", err)
 	}
 
-	// Add pages objects to write queue
-	w.objects = append(w.objects, pagesObjs...)
+	// Create the document-level objects and the Info dictionary
+	docObjs, infoObj, err := w.createDocumentObjects(doc)
+	if err != nil {
+		return err
+	}
 
 	// Create catalog (references pages root)
 	catalogObj := w.createCatalog(pagesRootRef, doc)
-	w.objects = append([]*IndirectObject{catalogObj}, w.objects...)
 
-	// Write all objects and track their offsets
-	for _, obj := range w.objects {
-		// Get current offset
-		pos, err := w.getCurrentOffset()
-		if err != nil {
-			return fmt.Errorf("failed to get file position: %w", err)
-		}
+	// Queue objects for writing (see SetPageChunked for the order)
+	w.objects = w.orderObjects(catalogObj, pagesObjs, docObjs)
 
-		w.offsets[obj.Number] = pos
-
-		if _, err := obj.WriteTo(w.writer); err != nil {
-			return fmt.Errorf("failed to write object %d: %w", obj.Number, err)
-		}
+	if w.limitErr != nil {
+		return w.limitErr
 	}
 
-	// Write cross-reference table
-	xrefOffset, err := w.writeXRef()
-	if err != nil {
-		return fmt.Errorf("failed to write xref: %w", err)
+	// Write all objects and track their offsets
+	if err := w.writeObjects(doc, catalogObj.Number); err != nil {
+		return err
 	}
 
-	// Write trailer
-	catalogRef := catalogObj.Number
-	size := w.nextObjNum
-	if err := w.writeTrailer(catalogRef, size, xrefOffset, doc); err != nil {
-		return fmt.Errorf("failed to write trailer: %w", err)
+	// Write cross-reference section and trailer
+	if err := w.writeXRefAndTrailer(doc, catalogObj.Number, infoObjNum(infoObj)); err != nil {
+		return err
 	}
 
 	// Flush buffer
@@ -259,6 +344,37 @@ func (w *PdfWriter) WriteWithAllContent(
 	return nil
 }
 
+// createDocumentObjects creates the objects that follow the page tree:
+// AcroForm objects (radio group parents, default font), the structure
+// tree for tagged figures, the outline, the usage rights signature, the
+// XMP metadata stream, the PDF/A output intent profile and the Info
+// dictionary, which is also returned on its own (nil if none).
+func (w *PdfWriter) createDocumentObjects(doc *document.Document) ([]*IndirectObject, *IndirectObject, error) {
+	docObjs := w.createAcroFormObjects()
+	docObjs = append(docObjs, w.createStructTreeObjects()...)
+	docObjs = append(docObjs, w.createOutlineObjects(doc)...)
+	if urObj := w.createUsageRightsObject(); urObj != nil {
+		docObjs = append(docObjs, urObj)
+	}
+	if metaObj := w.createMetadataObject(); metaObj != nil {
+		docObjs = append(docObjs, metaObj)
+	}
+	profileObj, err := w.createOutputIntentObject()
+	if err != nil {
+		return nil, nil, err
+	}
+	if profileObj != nil {
+		docObjs = append(docObjs, profileObj)
+	}
+
+	// Info dictionary (referenced from the trailer)
+	infoObj := w.createInfoObject(doc)
+	if infoObj != nil {
+		docObjs = append(docObjs, infoObj)
+	}
+	return docObjs, infoObj, nil
+}
+
 // Write writes a document to the PDF file.
 //
 // This performs the following steps:
@@ -286,6 +402,10 @@ func (w *PdfWriter) Write(doc *document.Document) error {
 	w.objects = make([]*IndirectObject, 0)
 	w.offsets = make(map[int]int64)
 	w.nextObjNum = 1
+	w.limitErr = nil
+	w.stdFontNums = nil
+	w.imageNums, w.embeddedFontNums, w.formNums, w.importNums = nil, nil, nil, nil
+	w.fontSubsets = nil
 
 	// Write PDF header
 	if err := w.writeHeader(doc.Version().String()); err != nil {
@@ -301,36 +421,45 @@ func (w *PdfWriter) Write(doc *document.Document) error {
 	// Add pages objects to write queue
 	w.objects = append(w.objects, pagesObjs...)
 
+	// Create the outline (referenced from the catalog)
+	w.objects = append(w.objects, w.createOutlineObjects(doc)...)
+
+	// Create the XMP metadata stream (referenced from the catalog)
+	if metaObj := w.createMetadataObject(); metaObj != nil {
+		w.objects = append(w.objects, metaObj)
+	}
+
+	// Create the PDF/A output intent profile (referenced from the catalog)
+	profileObj, err := w.createOutputIntentObject()
+	if err != nil {
+		return err
+	}
+	if profileObj != nil {
+		w.objects = append(w.objects, profileObj)
+	}
+
 	// Create catalog (references pages root)
 	catalogObj := w.createCatalog(pagesRootRef, doc)
 	w.objects = append([]*IndirectObject{catalogObj}, w.objects...)
 
-	// Write all objects and track their offsets
-	for _, obj := range w.objects {
-		// Get current offset
-		pos, err := w.getCurrentOffset()
-		if err != nil {
-			return fmt.Errorf("failed to get file position: %w", err)
-		}
-
-		w.offsets[obj.Number] = pos
+	// Create Info dictionary (referenced from the trailer)
+	infoObj := w.createInfoObject(doc)
+	if infoObj != nil {
+		w.objects = append(w.objects, infoObj)
+	}
 
-		if _, err := obj.WriteTo(w.writer); err != nil {
-			return fmt.Errorf("failed to write object %d: %w", obj.Number, err)
-		}
+	if w.limitErr != nil {
+		return w.limitErr
 	}
 
-	// Write cross-reference table
-	xrefOffset, err := w.writeXRef()
-	if err != nil {
-		return fmt.Errorf("failed to write xref: %w", err)
+	// Write all objects and track their offsets
+	if err := w.writeObjects(doc, catalogObj.Number); err != nil {
+		return err
 	}
 
-	// Write trailer
-	catalogRef := catalogObj.Number
-	size := w.nextObjNum // Total number of objects + 1 (includes object 0)
-	if err := w.writeTrailer(catalogRef, size, xrefOffset, doc); err != nil {
-		return fmt.Errorf("failed to write trailer: %w", err)
+	// Write cross-reference section and trailer
+	if err := w.writeXRefAndTrailer(doc, catalogObj.Number, infoObjNum(infoObj)); err != nil {
+		return err
 	}
 
 	// Flush buffer
@@ -369,30 +498,12 @@ func (w *PdfWriter) Close() error {
 	return nil
 }
 
-// getCurrentOffset returns the current byte offset in the output.
-// For file mode, it uses file.Seek. For io.Writer mode, it uses
-// the counting writer plus buffered bytes.
-func (w *PdfWriter) getCurrentOffset() (int64, error) {
-	// Flush buffered data first to get accurate count
-	if err := w.writer.Flush(); err != nil {
-		return 0, err
-	}
-
-	if w.file != nil {
-		// File mode: use Seek to get position
-		pos, err := w.file.Seek(0, 1)
-		if err != nil {
-			return 0, err
-		}
-		return pos, nil
-	}
-
-	// io.Writer mode: use counting writer
-	if w.countWriter != nil {
-		return w.countWriter.n, nil
-	}
-
-	return 0, fmt.Errorf("no file or counting writer available")
+// getCurrentOffset returns the current byte offset in the output: the
+// bytes already passed to the target plus those still buffered. The
+// offset is tracked without flushing or seeking, so any io.Writer can be
+// the target, including pipes and HTTP responses.
+func (w *PdfWriter) getCurrentOffset() int64 {
+	return w.countWriter.n + int64(w.writer.Buffered())
 }
 
 // writeHeader writes the PDF header with version and binary marker.
@@ -434,9 +545,9 @@ func (w *PdfWriter) writeHeader(version string) error {
 // Returns the byte offset where xref starts.
 func (w *PdfWriter) writeXRef() (int64, error) {
 	// Get current position (where xref starts)
-	xrefOffset, err := w.getCurrentOffset()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get file position: %w", err)
+	xrefOffset := w.getCurrentOffset()
+	if err := w.checkByteLimit(xrefOffset); err != nil {
+		return 0, err
 	}
 
 	// Write xref header
@@ -481,7 +592,10 @@ func (w *PdfWriter) writeXRef() (int64, error) {
 //	startxref
 //	<xref_offset>
 //	%%EOF
-func (w *PdfWriter) writeTrailer(catalogRef int, size int, xrefOffset int64, doc *document.Document) error {
+//
+// infoRef is the object number of the Info dictionary, or 0 for none; id
+// is the /ID array, or "" for none.
+func (w *PdfWriter) writeTrailer(catalogRef, infoRef int, id string, xrefOffset int64) error {
 	// Write trailer keyword
 	if _, err := w.writer.WriteString("trailer\n"); err != nil {
 		return fmt.Errorf("failed to write trailer keyword: %w", err)
@@ -490,26 +604,16 @@ func (w *PdfWriter) writeTrailer(catalogRef int, size int, xrefOffset int64, doc
 	// Build trailer dictionary
 	var trailerDict bytes.Buffer
 	trailerDict.WriteString("<<")
-	trailerDict.WriteString(fmt.Sprintf(" /Size %d", size))
+	trailerDict.WriteString(fmt.Sprintf(" /Size %d", w.nextObjNum))
 	trailerDict.WriteString(fmt.Sprintf(" /Root %d 0 R", catalogRef))
 
 	// Add Info dictionary if metadata exists
-	if doc.Title() != "" || doc.Author() != "" || doc.Subject() != "" {
-		infoRef := w.allocateObjNum()
+	if infoRef > 0 {
 		trailerDict.WriteString(fmt.Sprintf(" /Info %d 0 R", infoRef))
+	}
 
-		// Create Info object
-		infoObj := w.createInfo(infoRef, doc)
-		w.objects = append(w.objects, infoObj)
-
-		// Write Info object immediately (before startxref)
-		offset := xrefOffset // Info comes after xref, so we track it
-		w.offsets[infoRef] = offset
-
-		// We need to write it to a temp buffer to calculate size,
-		// but for simplicity, we'll skip Info in this iteration
-		// TODO: Implement Info object writing in next iteration
-		_ = infoObj // Prevent unused variable error
+	if id != "" {
+		trailerDict.WriteString(" /ID " + id)
 	}
 
 	trailerDict.WriteString(" >>")
@@ -541,32 +645,98 @@ func (w *PdfWriter) writeTrailer(catalogRef int, size int, xrefOffset int64, doc
 	return nil
 }
 
+// writeObjects writes the queued objects and records their offsets.
+//
+// With object streams enabled (see SetObjectStreams), non-stream objects
+// are first packed into object streams, which are written in their place.
+func (w *PdfWriter) writeObjects(doc *document.Document, catalogRef int) error {
+	objects := w.objects
+	w.compressed = nil
+	if w.useObjectStreams(doc) {
+		var err error
+		if objects, err = w.packObjectStreams(objects, catalogRef); err != nil {
+			return err
+		}
+	}
+
+	for _, obj := range objects {
+		if err := w.writeObject(obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeObject writes one object and records its offset.
+func (w *PdfWriter) writeObject(obj *IndirectObject) error {
+	pos := w.getCurrentOffset()
+	if err := w.checkByteLimit(pos); err != nil {
+		return err
+	}
+
+	w.offsets[obj.Number] = pos
+
+	if _, err := obj.WriteTo(w.writer); err != nil {
+		return fmt.Errorf("failed to write object %d: %w", obj.Number, err)
+	}
+	return nil
+}
+
 // allocateObjNum allocates a new object number and returns it.
 func (w *PdfWriter) allocateObjNum() int {
 	num := w.nextObjNum
 	w.nextObjNum++
+	w.checkObjectLimit(num)
 	return num
 }
 
+// createInfoObject allocates an object number and creates the Info
+// dictionary object, or returns nil if the document has no metadata and
+// no checksum is reserved.
+//
+// The object must be queued with the other objects before they are
+// written so that its offset is recorded in the xref table.
+func (w *PdfWriter) createInfoObject(doc *document.Document) *IndirectObject {
+	if !w.checksum && doc.Title() == "" && doc.Author() == "" && doc.Subject() == "" &&
+		FormatKeywords(doc.Keywords()) == "" && doc.Trapped() == document.TrappedUnset {
+		return nil
+	}
+	w.infoNum = w.allocateObjNum()
+	return w.createInfo(w.infoNum, doc)
+}
+
+// infoObjNum returns the object number of the Info dictionary object, or 0
+// if there is none.
+func infoObjNum(infoObj *IndirectObject) int {
+	if infoObj == nil {
+		return 0
+	}
+	return infoObj.Number
+}
+
 // createInfo creates an Info dictionary object with document metadata.
 func (w *PdfWriter) createInfo(objNum int, doc *document.Document) *IndirectObject {
 	var info bytes.Buffer
 	info.WriteString("<<")
 
 	if doc.Title() != "" {
-		info.WriteString(fmt.Sprintf(" /Title (%s)", escapePDFString(doc.Title())))
+		info.WriteString(" /Title " + FormatTextString(doc.Title()))
 	}
 	if doc.Author() != "" {
-		info.WriteString(fmt.Sprintf(" /Author (%s)", escapePDFString(doc.Author())))
+		info.WriteString(" /Author " + FormatTextString(doc.Author()))
 	}
 	if doc.Subject() != "" {
-		info.WriteString(fmt.Sprintf(" /Subject (%s)", escapePDFString(doc.Subject())))
+		info.WriteString(" /Subject " + FormatTextString(doc.Subject()))
+	}
+	if keywords := FormatKeywords(doc.Keywords()); keywords != "" {
+		info.WriteString(" /Keywords " + FormatTextString(keywords))
 	}
 	if doc.Creator() != "" {
-		info.WriteString(fmt.Sprintf(" /Creator (%s)", escapePDFString(doc.Creator())))
+		info.WriteString(" /Creator " + FormatTextString(doc.Creator()))
 	}
 	if doc.Producer() != "" {
-		info.WriteString(fmt.Sprintf(" /Producer (%s)", escapePDFString(doc.Producer())))
+		info.WriteString(" /Producer " + FormatTextString(doc.Producer()))
 	}
 
 	// Creation date
@@ -575,16 +745,29 @@ func (w *PdfWriter) createInfo(objNum int, doc *document.Document) *IndirectObje
 	// Modification date
 	info.WriteString(fmt.Sprintf(" /ModDate (%s)", formatPDFDate(doc.ModificationDate())))
 
+	if trapped := doc.Trapped(); trapped != document.TrappedUnset {
+		info.WriteString(fmt.Sprintf(" /Trapped /%s", trapped))
+	}
+
+	if w.checksum {
+		info.WriteString(checksumPlaceholder())
+	}
+
 	info.WriteString(" >>")
 
 	return NewIndirectObject(objNum, 0, info.Bytes())
 }
 
-// escapePDFString escapes special characters in PDF strings.
-func escapePDFString(s string) string {
-	// For now, simple implementation - just handle basic escaping
-	// TODO: Implement full PDF string escaping (parentheses, backslash, etc.)
-	return s
+// FormatKeywords joins the non-empty keywords with ", " for the Info
+// /Keywords entry. Returns "" if there are none.
+func FormatKeywords(keywords []string) string {
+	nonEmpty := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		if k = strings.TrimSpace(k); k != "" {
+			nonEmpty = append(nonEmpty, k)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
 }
 
 // formatPDFDate formats a time.Time as a PDF date string.
@@ -1,13 +1,20 @@
 package writer
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
 )
 
 func TestNewPdfWriter(t *testing.T) {
@@ -438,6 +445,201 @@ func TestPdfWriter_MetadataInTrailer(t *testing.T) {
 	}
 }
 
+func TestPdfWriter_InfoRoundTrip(t *testing.T) {
+	writes := map[string]func(w *PdfWriter, doc *document.Document) error{
+		"Write": func(w *PdfWriter, doc *document.Document) error {
+			return w.Write(doc)
+		},
+		"WriteWithPageContent": func(w *PdfWriter, doc *document.Document) error {
+			return w.WriteWithPageContent(doc, nil)
+		},
+		"WriteWithAllContent": func(w *PdfWriter, doc *document.Document) error {
+			return w.WriteWithAllContent(doc, nil, nil)
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "info.pdf")
+
+			doc := document.NewDocument()
+			doc.SetMetadata("Quarterly (Q3) Report", "Jane Doe", "Finance")
+			if _, err := doc.AddPage(document.A4); err != nil {
+				t.Fatalf("AddPage() error = %v", err)
+			}
+
+			w, err := NewPdfWriter(path)
+			if err != nil {
+				t.Fatalf("NewPdfWriter() error = %v", err)
+			}
+			if err := write(w, doc); err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			r, err := parser.OpenPDF(path)
+			if err != nil {
+				t.Fatalf("OpenPDF() error = %v", err)
+			}
+			defer func() { _ = r.Close() }()
+
+			info := r.GetDocumentInfo()
+			if info.Title != "Quarterly (Q3) Report" {
+				t.Errorf("Title = %q, want %q", info.Title, "Quarterly (Q3) Report")
+			}
+			if info.Author != "Jane Doe" {
+				t.Errorf("Author = %q, want %q", info.Author, "Jane Doe")
+			}
+		})
+	}
+}
+
+func TestPdfWriter_InfoKeywordsAndTrapped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keywords.pdf")
+
+	doc := document.NewDocument()
+	doc.SetKeywords([]string{"invoice", " ", "2025", "acme corp"})
+	doc.SetTrapped(document.TrappedFalse)
+	if _, err := doc.AddPage(document.A4); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	w, err := NewPdfWriter(path)
+	if err != nil {
+		t.Fatalf("NewPdfWriter() error = %v", err)
+	}
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Contains(data, []byte("/Trapped /False")) {
+		t.Error("Info should contain /Trapped /False")
+	}
+
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if got, want := r.GetDocumentInfo().Keywords, "invoice, 2025, acme corp"; got != want {
+		t.Errorf("Keywords = %q, want %q", got, want)
+	}
+}
+
+func TestPdfWriter_InfoOmitsEmptyKeywords(t *testing.T) {
+	for name, keywords := range map[string][]string{
+		"nil":   nil,
+		"empty": {},
+		"blank": {"", " "},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := document.NewDocument()
+			doc.SetMetadata("Title", "", "")
+			doc.SetKeywords(keywords)
+
+			info := string(NewPdfWriterFromWriter(io.Discard).createInfo(1, doc).Data)
+			if strings.Contains(info, "/Keywords") {
+				t.Errorf("Info should not contain /Keywords, got: %s", info)
+			}
+			if strings.Contains(info, "/Trapped") {
+				t.Errorf("Info should not contain /Trapped by default, got: %s", info)
+			}
+		})
+	}
+
+	// Keywords alone are enough to write an Info dictionary.
+	doc := document.NewDocument()
+	doc.SetKeywords([]string{"draft"})
+	w := NewPdfWriterFromWriter(io.Discard)
+	w.nextObjNum = 1
+	if w.createInfoObject(doc) == nil {
+		t.Error("createInfoObject() should create an Info dictionary for keywords")
+	}
+}
+
+func TestPdfWriter_InfoUTF16Title(t *testing.T) {
+	doc := document.NewDocument()
+	doc.SetMetadata("請求書", "Test Author", "")
+	if _, err := doc.AddPage(document.A4); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+
+	m := regexp.MustCompile(`/Title <([0-9A-F]+)>`).FindSubmatch(buf.Bytes())
+	if m == nil {
+		t.Fatalf("Info should contain a hex /Title, got: %s", buf.String())
+	}
+	raw, err := hex.DecodeString(string(m[1]))
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+
+	want := []byte{0xFE, 0xFF, 0x8A, 0xCB, 0x6C, 0x42, 0x66, 0xF8}
+	if !bytes.Equal(raw, want) {
+		t.Errorf("/Title bytes = % X, want % X", raw, want)
+	}
+
+	if !strings.Contains(buf.String(), "/Author (Test Author)") {
+		t.Error("ASCII /Author should keep the literal string form")
+	}
+}
+
+func TestPdfWriter_TrailerSizeWithMetadata(t *testing.T) {
+	doc := document.NewDocument()
+	doc.SetMetadata("Test Title", "Test Author", "")
+	doc.AddPage(document.A4)
+	doc.AddPage(document.A4)
+
+	var buf bytes.Buffer
+	writer := NewPdfWriterFromWriter(&buf)
+	if err := writer.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	content := buf.String()
+
+	sizeMatch := regexp.MustCompile(`/Size (\d+)`).FindStringSubmatch(content)
+	if sizeMatch == nil {
+		t.Fatal("trailer should contain /Size")
+	}
+	size, _ := strconv.Atoi(sizeMatch[1])
+
+	infoMatch := regexp.MustCompile(`/Info (\d+) 0 R`).FindStringSubmatch(content)
+	if infoMatch == nil {
+		t.Fatal("trailer should reference /Info when metadata is set")
+	}
+	infoRef, _ := strconv.Atoi(infoMatch[1])
+
+	// Highest object number, counting every written object and the Info reference.
+	highest := infoRef
+	for _, m := range regexp.MustCompile(`(?m)^(\d+) 0 obj`).FindAllStringSubmatch(content, -1) {
+		if n, _ := strconv.Atoi(m[1]); n > highest {
+			highest = n
+		}
+	}
+
+	if size != highest+1 {
+		t.Errorf("/Size = %d, want highest object number + 1 = %d", size, highest+1)
+	}
+	if size != writer.nextObjNum {
+		t.Errorf("/Size = %d, want %d (all allocated objects + 1)", size, writer.nextObjNum)
+	}
+}
+
 func TestPdfWriter_DifferentPageSizes(t *testing.T) {
 	tmpDir := t.TempDir()
 	path := filepath.Join(tmpDir, "sizes.pdf")
@@ -569,3 +771,95 @@ func TestFormatPDFDate(t *testing.T) {
 func mustTime(year, month, day, hour, min, sec int) time.Time {
 	return time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC)
 }
+
+func TestPdfWriter_CreateInfoEscapesStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"plain", "Report", `/Title (Report)`},
+		{"parentheses and backslash", `Report (2024) \ Final`, `/Title (Report \(2024\) \\ Final)`},
+		{"nested parentheses", "a (b (c)) d", `/Title (a \(b \(c\)\) d)`},
+		{"unbalanced parenthesis", "smile :)", `/Title (smile :\))`},
+		{"trailing backslash", `C:\dir\`, `/Title (C:\\dir\\)`},
+		{"control characters", "line1\r\nline2\tend", `/Title (line1\r\nline2\tend)`},
+		{"non-ASCII bytes", "Caf\xe9 \xff", "/Title (Caf\xe9 \xff)"},
+		{"Japanese", "日本語", "/Title <FEFF65E5672C8A9E>"},
+		{"Latin-1", "Café", "/Title <FEFF00430061006600E9>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := document.NewDocument()
+			doc.SetMetadata(tt.title, "", "")
+
+			w := NewPdfWriterFromWriter(&bytes.Buffer{})
+			info := string(w.createInfo(1, doc).Data)
+
+			if !strings.Contains(info, tt.want+" ") {
+				t.Errorf("Info = %q, want to contain %q", info, tt.want)
+			}
+		})
+	}
+}
+
+// nonSeekableWriter is an io.Writer that cannot seek, like a pipe or an
+// HTTP response. It counts the Write calls that reach it.
+type nonSeekableWriter struct {
+	buf    bytes.Buffer
+	writes int
+}
+
+func (nw *nonSeekableWriter) Write(p []byte) (int, error) {
+	nw.writes++
+	return nw.buf.Write(p)
+}
+
+func TestPdfWriter_NonSeekableWriter(t *testing.T) {
+	doc := document.NewDocument()
+	textOps := make(map[int][]TextOp)
+	for i := 0; i < 50; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+		textOps[i] = []TextOp{{Text: fmt.Sprintf("Page %d", i+1), X: 72, Y: 770, Font: "Helvetica", Size: 12}}
+	}
+
+	out := &nonSeekableWriter{}
+	w := NewPdfWriterFromWriter(out)
+	if err := w.WriteWithAllContent(doc, textOps, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	pdf := out.buf.String()
+
+	// Every in-use xref entry must point at its object.
+	start := strings.LastIndex(pdf, "\nxref\n") + 1
+	var first, count int
+	if _, err := fmt.Sscanf(pdf[start:], "xref\n%d %d\n", &first, &count); err != nil {
+		t.Fatalf("cannot parse xref header: %v", err)
+	}
+	subsection := fmt.Sprintf("xref\n%d %d\n", first, count)
+	entries := pdf[start+len(subsection):]
+	for num := first; num < first+count; num++ {
+		entry := entries[(num-first)*20 : (num-first+1)*20]
+		if entry[17] != 'n' {
+			continue
+		}
+		offset, err := strconv.Atoi(entry[:10])
+		if err != nil {
+			t.Fatalf("bad xref entry %q", entry)
+		}
+		if want := fmt.Sprintf("%d 0 obj", num); !strings.HasPrefix(pdf[offset:], want) {
+			t.Errorf("xref offset %d of object %d points at %.12q", offset, num, pdf[offset:])
+		}
+	}
+	if want := fmt.Sprintf("startxref\n%d\n", start); !strings.Contains(pdf, want) {
+		t.Errorf("startxref should point at offset %d", start)
+	}
+
+	// Offsets are counted, not flushed: far fewer writes than objects.
+	if out.writes >= count/2 {
+		t.Errorf("%d writes reached the target for %d objects, want output buffered across objects", out.writes, count)
+	}
+}
@@ -0,0 +1,97 @@
+package writer
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // File identifiers are not a security feature
+	"encoding/hex"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// srgbOutputCondition identifies the sRGB output intent.
+const srgbOutputCondition = "sRGB IEC61966-2.1"
+
+// SetPDFA writes the document for PDF/A-1b (ISO 19005-1, level B). Must be
+// called before writing.
+//
+// The catalog gets an sRGB output intent with an embedded ICC profile, the
+// trailer gets a file identifier, and the PDF 1.5 features that PDF/A-1
+// forbids are turned off: cross-reference and object streams are not
+// used, and /NeedAppearances is never set. The writer does not check the
+// page content; callers must only use embedded fonts, opaque painting and
+// RGB or gray colors, and set an XMP packet with the PDF/A identification
+// schema (see SetXMPMetadata).
+//
+// Reference: ISO 19005-1:2005, Sections 6.1.3 (File Trailer), 6.2.2
+// (Output Intent) and 6.7 (Metadata).
+func (w *PdfWriter) SetPDFA(enabled bool) {
+	w.pdfa = enabled
+}
+
+// createOutputIntentObject creates the ICC profile stream referenced by
+// the catalog's output intent, or returns nil if PDF/A output is off.
+//
+// Format:
+//
+//	<< /N 3 /Filter /FlateDecode /Length L >>
+//	stream
+//	...ICC profile...
+//	endstream
+func (w *PdfWriter) createOutputIntentObject() (*IndirectObject, error) {
+	if !w.pdfa {
+		w.outputProfileNum = 0
+		return nil, nil
+	}
+	w.outputProfileNum = w.allocateObjNum()
+
+	data, err := CompressStream(srgbICCProfile(), DefaultCompression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress ICC profile: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("<< /N 3 /Filter /FlateDecode /Length %d >>\n", len(data)))
+	buf.WriteString("stream\n")
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+
+	return NewIndirectObject(w.outputProfileNum, 0, buf.Bytes()), nil
+}
+
+// outputIntents returns the catalog's /OutputIntents array.
+//
+// Format:
+//
+//	[<< /Type /OutputIntent /S /GTS_PDFA1
+//	    /OutputConditionIdentifier (sRGB IEC61966-2.1)
+//	    /RegistryName (http://www.color.org)
+//	    /Info (sRGB IEC61966-2.1) /DestOutputProfile N 0 R >>]
+func (w *PdfWriter) outputIntents() string {
+	return fmt.Sprintf("[<< /Type /OutputIntent /S /GTS_PDFA1"+
+		" /OutputConditionIdentifier (%s) /RegistryName (http://www.color.org)"+
+		" /Info (%s) /DestOutputProfile %d 0 R >>]",
+		srgbOutputCondition, srgbOutputCondition, w.outputProfileNum)
+}
+
+// fileIdentifier returns the trailer /ID array for PDF/A output, or "" if
+// PDF/A output is off.
+//
+// Both halves are the same MD5 digest of the document metadata and the
+// file size, which is what the PDF specification suggests for a newly
+// created file.
+//
+// Reference: PDF 1.7 Specification, Section 14.4 (File Identifiers).
+func (w *PdfWriter) fileIdentifier(doc *document.Document, xrefOffset int64) string {
+	if !w.pdfa {
+		return ""
+	}
+
+	h := md5.New() //nolint:gosec // Not used for security
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%d\x00%d",
+		doc.Title(), doc.Author(), doc.Producer(),
+		formatPDFDate(doc.CreationDate()), xrefOffset, w.nextObjNum)
+	id := hex.EncodeToString(h.Sum(nil))
+
+	return fmt.Sprintf("[<%s> <%s>]", id, id)
+}
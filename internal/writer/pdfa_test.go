@@ -0,0 +1,165 @@
+package writer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+func TestPdfWriter_PDFAOutputIntent(t *testing.T) {
+	doc := document.NewDocument()
+	doc.SetMetadata("Archive", "", "")
+	if _, err := doc.AddPage(document.A4); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewPdfWriterFromWriter(&buf)
+	w.SetPDFA(true)
+	w.SetObjectStreams(true) // Ignored: PDF/A-1 forbids object and xref streams
+	if err := w.WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	for _, unwanted := range []string{"/Type /ObjStm", "/Type /XRef"} {
+		if strings.Contains(buf.String(), unwanted) {
+			t.Errorf("PDF/A output should not contain %q", unwanted)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "pdfa.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	catalog, err := r.GetCatalog()
+	if err != nil {
+		t.Fatalf("GetCatalog() error = %v", err)
+	}
+	intents := catalog.GetArray("OutputIntents")
+	if intents == nil || intents.Len() != 1 {
+		t.Fatalf("/OutputIntents = %v, want one output intent", catalog.Get("OutputIntents"))
+	}
+	intent, ok := intents.Get(0).(*parser.Dictionary)
+	if !ok {
+		t.Fatalf("output intent = %T, want dictionary", intents.Get(0))
+	}
+	if typ := intent.GetName("Type"); typ == nil || typ.Value() != "OutputIntent" {
+		t.Errorf("/Type = %v, want /OutputIntent", intent.Get("Type"))
+	}
+	if s := intent.GetName("S"); s == nil || s.Value() != "GTS_PDFA1" {
+		t.Errorf("/S = %v, want /GTS_PDFA1", intent.Get("S"))
+	}
+	if id := intent.GetString("OutputConditionIdentifier"); id != "sRGB IEC61966-2.1" {
+		t.Errorf("/OutputConditionIdentifier = %q, want %q", id, "sRGB IEC61966-2.1")
+	}
+
+	ref, ok := intent.Get("DestOutputProfile").(*parser.IndirectReference)
+	if !ok {
+		t.Fatalf("/DestOutputProfile = %v, want an indirect reference", intent.Get("DestOutputProfile"))
+	}
+	obj, err := r.GetObject(ref.Number)
+	if err != nil {
+		t.Fatalf("GetObject(%d) error = %v", ref.Number, err)
+	}
+	stream, ok := obj.(*parser.Stream)
+	if !ok {
+		t.Fatalf("profile object = %T, want stream", obj)
+	}
+	if n := stream.Dictionary().GetInteger("N"); n != 3 {
+		t.Errorf("profile /N = %d, want 3", n)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(stream.Content()))
+	if err != nil {
+		t.Fatalf("zlib.NewReader() error = %v", err)
+	}
+	profile, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress profile: %v", err)
+	}
+	if !bytes.Equal(profile, srgbICCProfile()) {
+		t.Error("embedded profile differs from the sRGB profile")
+	}
+
+	id := r.Trailer().GetArray("ID")
+	if id == nil || id.Len() != 2 {
+		t.Errorf("trailer /ID = %v, want a two-element array", r.Trailer().Get("ID"))
+	}
+}
+
+func TestPdfWriter_NoPDFA(t *testing.T) {
+	doc := document.NewDocument()
+	if _, err := doc.AddPage(document.A4); err != nil {
+		t.Fatalf("AddPage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewPdfWriterFromWriter(&buf).WriteWithAllContent(doc, nil, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	for _, unwanted := range []string{"/OutputIntents", "/ID "} {
+		if strings.Contains(buf.String(), unwanted) {
+			t.Errorf("PDF should not contain %q without PDF/A output", unwanted)
+		}
+	}
+}
+
+func TestSRGBICCProfile(t *testing.T) {
+	profile := srgbICCProfile()
+
+	if size := binary.BigEndian.Uint32(profile); int(size) != len(profile) {
+		t.Errorf("header size = %d, want %d", size, len(profile))
+	}
+	for offset, want := range map[int]string{12: "mntr", 16: "RGB ", 20: "XYZ ", 36: "acsp"} {
+		if got := string(profile[offset : offset+4]); got != want {
+			t.Errorf("header[%d:%d] = %q, want %q", offset, offset+4, got, want)
+		}
+	}
+
+	count := int(binary.BigEndian.Uint32(profile[128:]))
+	tags := make(map[string]string, count)
+	for i := 0; i < count; i++ {
+		entry := profile[132+12*i:]
+		sig := string(entry[:4])
+		offset := int(binary.BigEndian.Uint32(entry[4:]))
+		size := int(binary.BigEndian.Uint32(entry[8:]))
+		if offset%4 != 0 || offset+size > len(profile) {
+			t.Fatalf("tag %s at %d+%d is misaligned or out of range", sig, offset, size)
+		}
+		tags[sig] = string(profile[offset : offset+4])
+	}
+
+	want := map[string]string{
+		"desc": "desc", "cprt": "text", "wtpt": "XYZ ",
+		"rXYZ": "XYZ ", "gXYZ": "XYZ ", "bXYZ": "XYZ ",
+		"rTRC": "curv", "gTRC": "curv", "bTRC": "curv",
+	}
+	for sig, typ := range want {
+		if tags[sig] != typ {
+			t.Errorf("tag %s type = %q, want %q", sig, tags[sig], typ)
+		}
+	}
+}
+
+func TestPdfWriter_PDFANeverNeedsAppearances(t *testing.T) {
+	w := NewPdfWriterFromWriter(io.Discard)
+	w.SetPDFA(true)
+	w.SetAcroFormOptions(&AcroFormOptions{NeedAppearances: true})
+	w.formFieldRefs = []int{5}
+
+	if dict := w.acroFormDict(); strings.Contains(dict, "/NeedAppearances") {
+		t.Errorf("AcroForm = %q, want no /NeedAppearances in PDF/A output", dict)
+	}
+}
@@ -0,0 +1,81 @@
+package writer
+
+import (
+	"fmt"
+)
+
+// RawTrailer holds the trailer entries of a file written by WriteObjects.
+type RawTrailer struct {
+	Root    int    // Object number of the catalog
+	Info    int    // Object number of the Info dictionary (0 for none)
+	Encrypt int    // Object number of the encryption dictionary (0 for none)
+	ID      string // The /ID array in PDF syntax ("" for none)
+}
+
+// WriteObjects writes a complete PDF file from already-serialized objects.
+//
+// This is used to save documents loaded by the reader, whose objects are
+// copied rather than built from a document model. Objects must be numbered
+// 1 to len(objects) without gaps, and encrypted already if the trailer has
+// an /Encrypt entry.
+//
+// Reference: PDF 1.7 Specification, Section 7.5 (File Structure).
+func (w *PdfWriter) WriteObjects(version string, objects []*IndirectObject, trailer RawTrailer) error {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+
+	w.objects = objects
+	w.offsets = make(map[int]int64)
+	w.nextObjNum = len(objects) + 1
+	w.limitErr = nil
+
+	for i, obj := range objects {
+		if obj.Number != i+1 {
+			return fmt.Errorf("object %d out of sequence (expected %d)", obj.Number, i+1)
+		}
+	}
+
+	if err := w.writeHeader(version); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, obj := range w.objects {
+		pos := w.getCurrentOffset()
+		if err := w.checkByteLimit(pos); err != nil {
+			return err
+		}
+
+		w.offsets[obj.Number] = pos
+
+		if _, err := obj.WriteTo(w.writer); err != nil {
+			return fmt.Errorf("failed to write object %d: %w", obj.Number, err)
+		}
+	}
+
+	xrefOffset, err := w.writeXRef()
+	if err != nil {
+		return fmt.Errorf("failed to write xref: %w", err)
+	}
+
+	dict := fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R", w.nextObjNum, trailer.Root)
+	if trailer.Info > 0 {
+		dict += fmt.Sprintf(" /Info %d 0 R", trailer.Info)
+	}
+	if trailer.Encrypt > 0 {
+		dict += fmt.Sprintf(" /Encrypt %d 0 R", trailer.Encrypt)
+	}
+	if trailer.ID != "" {
+		dict += " /ID " + trailer.ID
+	}
+	dict += fmt.Sprintf(" >>\nstartxref\n%d\n%%%%EOF\n", xrefOffset)
+	if _, err := w.writer.WriteString(dict); err != nil {
+		return fmt.Errorf("failed to write trailer: %w", err)
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package writer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"slices"
+
+	"github.com/coregx/gxpdf/internal/fonts"
+)
+
+// resourceHash identifies a page resource by its content.
+type resourceHash [sha256.Size]byte
+
+// imageXObject returns the object number of the image XObject for img,
+// and the objects to write on the image's first use in the document (nil
+// afterwards). Images with identical samples and parameters share one
+// XObject, so a logo repeated on every page is embedded once. As with
+// Standard 14 fonts, page-chunked output gives every page its own copy
+// (see SetPageChunked).
+func (w *PdfWriter) imageXObject(img *ImageData) (int, []*IndirectObject) {
+	key := imageHash(img)
+	if objNum, ok := w.imageNums[key]; ok && !w.pageChunked {
+		return objNum, nil
+	}
+
+	img = w.recompressImage(img)
+	imageObjNum := w.allocateObjNum()
+	var objects []*IndirectObject
+
+	// Handle alpha mask (SMask) for PNG with transparency
+	var smaskObjNum int
+	if len(img.AlphaMask) > 0 && !img.ImageMask {
+		smaskObjNum = w.allocateObjNum()
+		objects = append(objects, w.createSMaskObject(smaskObjNum, img))
+	}
+	objects = append(objects, w.createImageXObject(imageObjNum, img, smaskObjNum))
+
+	if w.imageNums == nil {
+		w.imageNums = make(map[resourceHash]int)
+	}
+	w.imageNums[key] = imageObjNum
+	return imageObjNum, objects
+}
+
+// embeddedFontObject returns the object number of the font dictionary for
+// an embedded font, and the font's objects on first use (nil afterwards).
+// Fonts whose built subsets are identical share one set of objects; a
+// subset that gained characters since an earlier page is written anew.
+func (w *PdfWriter) embeddedFontObject(font *EmbeddedFont) (int, []*IndirectObject, error) {
+	state := w.fontSubsets[font.ID]
+	if state != nil && state.subset != font.Subset {
+		state = nil
+	}
+	if state != nil && state.objNum != 0 && !w.pageChunked {
+		return state.objNum, nil, nil
+	}
+
+	key := embeddedFontHash(font)
+	if objNum, ok := w.embeddedFontNums[key]; ok && !w.pageChunked {
+		return objNum, nil, nil
+	}
+
+	fontWriter := NewTrueTypeFontWriter(font.TTF, font.Subset, w.allocateObjNum)
+	objects, refs, err := fontWriter.WriteFont()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if w.embeddedFontNums == nil {
+		w.embeddedFontNums = make(map[resourceHash]int)
+	}
+	w.embeddedFontNums[key] = refs.FontObjNum
+	if state != nil {
+		state.objNum = refs.FontObjNum
+	}
+	return refs.FontObjNum, objects, nil
+}
+
+// fontSubsetState records the last build of an embedded font's subset.
+type fontSubsetState struct {
+	subset *fonts.FontSubset
+	size   int // Characters and glyphs in the subset when it was built
+	objNum int // Font dictionary written for this build (0 = not yet written)
+}
+
+// buildFontSubset builds the subset of an embedded font unless it was
+// already built with the same characters. Pages using a font share its
+// subset, which only grows, so the subset is built once per font rather
+// than once per page, and later pages reuse the font object written for
+// it without hashing the font program again.
+func (w *PdfWriter) buildFontSubset(font *EmbeddedFont) {
+	if font.Subset == nil {
+		return
+	}
+	size := len(font.Subset.UsedChars) + len(font.Subset.GlyphText)
+	if state := w.fontSubsets[font.ID]; state != nil && state.subset == font.Subset && state.size == size {
+		return
+	}
+
+	_ = font.Subset.Build() // Errors surface when the font is written.
+	if w.fontSubsets == nil {
+		w.fontSubsets = make(map[string]*fontSubsetState)
+	}
+	w.fontSubsets[font.ID] = &fontSubsetState{subset: font.Subset, size: size}
+}
+
+// imageHash hashes everything createImageXObject writes for img.
+func imageHash(img *ImageData) resourceHash {
+	h := sha256.New()
+	hashBytes(h, img.Data)
+	hashBytes(h, img.AlphaMask)
+	hashBytes(h, img.Palette)
+	for _, s := range []string{img.ColorSpace, img.Format, img.Filter, img.Decode, img.DecodeParms} {
+		hashBytes(h, []byte(s))
+	}
+	for _, n := range []int{img.Width, img.Height, img.BitsPerComponent} {
+		hashInt(h, n)
+	}
+	for _, b := range []bool{img.DataRaw, img.AlphaMaskRaw, img.ImageMask} {
+		if b {
+			hashInt(h, 1)
+		} else {
+			hashInt(h, 0)
+		}
+	}
+
+	var key resourceHash
+	h.Sum(key[:0])
+	return key
+}
+
+// embeddedFontHash hashes the font program and the subset state that
+// TrueTypeFontWriter writes: the used characters (widths, ToUnicode) and
+// multi-character glyphs.
+func embeddedFontHash(font *EmbeddedFont) resourceHash {
+	h := sha256.New()
+	if font.TTF != nil {
+		hashBytes(h, []byte(font.TTF.PostScriptName))
+	}
+	if font.Subset != nil {
+		program := font.Subset.SubsetData
+		if len(program) == 0 && font.TTF != nil {
+			program = font.TTF.FontData
+		}
+		hashBytes(h, program)
+
+		chars := make([]rune, 0, len(font.Subset.UsedChars))
+		for ch, used := range font.Subset.UsedChars {
+			if used {
+				chars = append(chars, ch)
+			}
+		}
+		slices.Sort(chars)
+		for _, ch := range chars {
+			hashInt(h, int(ch))
+		}
+
+		glyphs := make([]uint16, 0, len(font.Subset.GlyphText))
+		for gid := range font.Subset.GlyphText {
+			glyphs = append(glyphs, gid)
+		}
+		slices.Sort(glyphs)
+		for _, gid := range glyphs {
+			hashInt(h, int(gid))
+			hashBytes(h, []byte(font.Subset.GlyphText[gid]))
+		}
+	}
+
+	var key resourceHash
+	h.Sum(key[:0])
+	return key
+}
+
+// hashBytes writes b to h with a length prefix, so that adjacent fields
+// cannot run into each other.
+func hashBytes(h hash.Hash, b []byte) {
+	hashInt(h, len(b))
+	h.Write(b)
+}
+
+// hashInt writes n to h as a fixed-size value.
+func hashInt(h hash.Hash, n int) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(n)) //nolint:gosec // Bit pattern only
+	h.Write(buf[:])
+}
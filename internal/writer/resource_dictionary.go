@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // ResourceDictionary manages PDF page resources (fonts, images, graphics states, etc.).
@@ -18,17 +19,23 @@ import (
 //	  /Font << /F1 5 0 R /F2 6 0 R >>
 //	  /XObject << /Im1 7 0 R >>
 //	  /ExtGState << /GS1 8 0 R >>
+//	  /Shading << /Sh1 9 0 R >>
 //	  /ProcSet [/PDF /Text /ImageB /ImageC /ImageI]
 //	>>
 //
 // Thread Safety: Not thread-safe. Caller must synchronize if needed.
 type ResourceDictionary struct {
-	fonts           map[string]int     // Font resource name -> object number (e.g., "F1" -> 5)
-	fontIDs         map[string]string  // Font ID -> resource name (e.g., "custom:font_1" -> "F1")
-	xobjects        map[string]int     // XObject resource name -> object number (e.g., "Im1" -> 10)
-	extgstates      map[string]int     // ExtGState resource name -> object number (e.g., "GS1" -> 15)
-	extgstateCache  map[float64]string // Opacity -> ExtGState name (for caching, e.g., 0.5 -> "GS1")
-	extgstateObjMap map[string]int     // ExtGState name -> object number (for later setting)
+	fonts           map[string]int             // Font resource name -> object number (e.g., "F1" -> 5)
+	fontIDs         map[string]string          // Font ID -> resource name (e.g., "custom:font_1" -> "F1")
+	xobjects        map[string]int             // XObject resource name -> object number (e.g., "Im1" -> 10)
+	images          int                        // Image XObjects added (Im1..ImN)
+	formIDs         map[string]string          // Form ID -> resource name (e.g., "tpl1" -> "Fm1")
+	extgstates      map[string]int             // ExtGState resource name -> object number (e.g., "GS1" -> 15)
+	extgstateCache  map[ExtGStateParams]string // Parameters -> ExtGState name (for caching, e.g., 0.5 -> "GS1")
+	extgstateParams map[string]ExtGStateParams // ExtGState name -> parameters (for object creation)
+	extgstateObjMap map[string]int             // ExtGState name -> object number (for later setting)
+	shadings        map[string]int             // Shading resource name -> object number (e.g., "Sh1" -> 16)
+	shadingParams   map[string]*GradientOp     // Shading name -> gradient (for object creation)
 }
 
 // NewResourceDictionary creates a new empty resource dictionary.
@@ -37,9 +44,13 @@ func NewResourceDictionary() *ResourceDictionary {
 		fonts:           make(map[string]int),
 		fontIDs:         make(map[string]string),
 		xobjects:        make(map[string]int),
+		formIDs:         make(map[string]string),
 		extgstates:      make(map[string]int),
-		extgstateCache:  make(map[float64]string),
+		extgstateCache:  make(map[ExtGStateParams]string),
+		extgstateParams: make(map[string]ExtGStateParams),
 		extgstateObjMap: make(map[string]int),
+		shadings:        make(map[string]int),
+		shadingParams:   make(map[string]*GradientOp),
 	}
 }
 
@@ -139,11 +150,38 @@ func (rd *ResourceDictionary) GetFontResourceName(fontID string) string {
 //	name := rd.AddImage(10)  // Returns "Im1"
 //	// In content stream: /Im1 Do (draw image Im1)
 func (rd *ResourceDictionary) AddImage(objNum int) string {
-	name := fmt.Sprintf("Im%d", len(rd.xobjects)+1)
+	rd.images++
+	name := fmt.Sprintf("Im%d", rd.images)
 	rd.xobjects[name] = objNum
 	return name
 }
 
+// AddForm adds a form XObject resource and returns its resource name.
+//
+// Forms are named sequentially: Fm1, Fm2, Fm3, etc. A form drawn several
+// times keeps its first name. The object number is set later with
+// SetFormObjNum.
+func (rd *ResourceDictionary) AddForm(formID string) string {
+	if name, exists := rd.formIDs[formID]; exists {
+		return name
+	}
+	name := fmt.Sprintf("Fm%d", len(rd.formIDs)+1)
+	rd.formIDs[formID] = name
+	rd.xobjects[name] = 0
+	return name
+}
+
+// SetFormObjNum sets the object number of the form XObject with the given
+// ID. Returns false if the form is not in the dictionary.
+func (rd *ResourceDictionary) SetFormObjNum(formID string, objNum int) bool {
+	name, ok := rd.formIDs[formID]
+	if !ok {
+		return false
+	}
+	rd.xobjects[name] = objNum
+	return true
+}
+
 // SetImageObjNum sets the object number for an existing image resource.
 //
 // This is used to update placeholder object numbers (0) with actual values
@@ -208,16 +246,36 @@ func (rd *ResourceDictionary) AddExtGState(objNum int) string {
 //	name3, needsCreate := rd.GetOrCreateExtGState(0.3)
 //	// name3 = "GS2", needsCreate = true (different opacity)
 func (rd *ResourceDictionary) GetOrCreateExtGState(opacity float64) (string, bool) {
-	// Check if ExtGState for this opacity already exists
-	if name, exists := rd.extgstateCache[opacity]; exists {
+	return rd.GetOrCreateExtGStateParams(ExtGStateParams{Opacity: opacity})
+}
+
+// GetOrCreateExtGStateParams returns an existing or creates a new ExtGState
+// for the given parameters.
+//
+// ExtGStates are cached by their full parameter set, so drawing operations
+// with the same opacity, stroke adjustment and overprint settings share one
+// ExtGState object.
+//
+// Returns:
+//   - Resource name (e.g., "GS1")
+//   - needsCreation: true if this is a new ExtGState that needs object creation
+//
+// Example:
+//
+//	name, _ := rd.GetOrCreateExtGStateParams(ExtGStateParams{Opacity: 1, Overprint: true})
+//	csw.SetGraphicsState(name)
+func (rd *ResourceDictionary) GetOrCreateExtGStateParams(params ExtGStateParams) (string, bool) {
+	// Check if ExtGState for these parameters already exists
+	if name, exists := rd.extgstateCache[params]; exists {
 		return name, false // Already exists, no need to create
 	}
 
 	// Create new resource name
 	name := fmt.Sprintf("GS%d", len(rd.extgstates)+1)
 
-	// Cache by opacity
-	rd.extgstateCache[opacity] = name
+	// Cache by parameters
+	rd.extgstateCache[params] = name
+	rd.extgstateParams[name] = params
 
 	// Add to extgstates map with placeholder object number (0)
 	// The actual object number will be set later via SetExtGStateObjNum
@@ -227,6 +285,39 @@ func (rd *ResourceDictionary) GetOrCreateExtGState(opacity float64) (string, boo
 	return name, true // New ExtGState, needs creation
 }
 
+// PendingExtGStates returns the names of cached ExtGStates that have no
+// object number yet, sorted by name.
+//
+// The writer creates an ExtGState object for each and assigns its number
+// with SetExtGStateObjNum.
+func (rd *ResourceDictionary) PendingExtGStates() []string {
+	names := make([]string, 0, len(rd.extgstateParams))
+	for name := range rd.extgstateParams {
+		if rd.extgstates[name] == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UsesTransparency reports whether any cached ExtGState sets an opacity
+// below 1.0.
+func (rd *ResourceDictionary) UsesTransparency() bool {
+	for _, params := range rd.extgstateParams {
+		if params.Opacity < 1.0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtGStateParamsByName returns the parameters of a cached ExtGState.
+func (rd *ResourceDictionary) ExtGStateParamsByName(name string) (ExtGStateParams, bool) {
+	params, ok := rd.extgstateParams[name]
+	return params, ok
+}
+
 // SetExtGStateObjNum sets the object number for an ExtGState resource.
 //
 // This is called after the ExtGState PDF object has been created.
@@ -257,11 +348,122 @@ func (rd *ResourceDictionary) GetExtGStateObjNum(name string) int {
 	return rd.extgstates[name]
 }
 
+// AddShading adds a shading resource for a gradient and returns its
+// resource name.
+//
+// Shadings are named sequentially: Sh1, Sh2, Sh3, etc. The object number
+// is set later via SetShadingObjNum.
+//
+// Example:
+//
+//	name := rd.AddShading(grad) // Returns "Sh1"
+//	// In content stream: /Sh1 sh (paint shading Sh1)
+func (rd *ResourceDictionary) AddShading(grad *GradientOp) string {
+	name := fmt.Sprintf("Sh%d", len(rd.shadings)+1)
+	rd.shadings[name] = 0
+	rd.shadingParams[name] = grad
+	return name
+}
+
+// PendingShadings returns the names of shadings that have no object
+// number yet, sorted by name.
+func (rd *ResourceDictionary) PendingShadings() []string {
+	names := make([]string, 0, len(rd.shadings))
+	for name, objNum := range rd.shadings {
+		if objNum == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ShadingByName returns the gradient of a shading resource.
+func (rd *ResourceDictionary) ShadingByName(name string) (*GradientOp, bool) {
+	grad, ok := rd.shadingParams[name]
+	return grad, ok
+}
+
+// SetShadingObjNum sets the object number for a shading resource.
+//
+// Returns true if the shading was found and updated, false otherwise.
+func (rd *ResourceDictionary) SetShadingObjNum(name string, objNum int) bool {
+	if _, exists := rd.shadings[name]; !exists {
+		return false
+	}
+	rd.shadings[name] = objNum
+	return true
+}
+
+// RenameByObjNum renames every resource that has an object number after
+// that number, keeping its prefix: a font in object 12 becomes F12, an
+// image in object 30 Im30. The same object then has the same name on
+// every page. Resources of the same kind sharing an object are merged.
+//
+// Returns the renamed resources (old name -> new name), which must be
+// applied to the content stream that uses the dictionary.
+func (rd *ResourceDictionary) RenameByObjNum() map[string]string {
+	renames := make(map[string]string)
+	rename := func(names map[string]int) map[string]int {
+		renamed := make(map[string]int, len(names))
+		for name, objNum := range names {
+			newName := name
+			if objNum != 0 {
+				newName = fmt.Sprintf("%s%d", strings.TrimRight(name, "0123456789"), objNum)
+			}
+			if newName != name {
+				renames[name] = newName
+			}
+			renamed[newName] = objNum
+		}
+		return renamed
+	}
+	newName := func(name string) string {
+		if n, ok := renames[name]; ok {
+			return n
+		}
+		return name
+	}
+
+	rd.fonts = rename(rd.fonts)
+	for id, name := range rd.fontIDs {
+		rd.fontIDs[id] = newName(name)
+	}
+
+	rd.xobjects = rename(rd.xobjects)
+	for id, name := range rd.formIDs {
+		rd.formIDs[id] = newName(name)
+	}
+
+	rd.extgstates = rename(rd.extgstates)
+	for params, name := range rd.extgstateCache {
+		rd.extgstateCache[params] = newName(name)
+	}
+	extgstateParams := make(map[string]ExtGStateParams, len(rd.extgstateParams))
+	for name, params := range rd.extgstateParams {
+		extgstateParams[newName(name)] = params
+	}
+	rd.extgstateParams = extgstateParams
+	rd.extgstateObjMap = make(map[string]int, len(rd.extgstates))
+	for name, objNum := range rd.extgstates {
+		rd.extgstateObjMap[name] = objNum
+	}
+
+	rd.shadings = rename(rd.shadings)
+	shadingParams := make(map[string]*GradientOp, len(rd.shadingParams))
+	for name, grad := range rd.shadingParams {
+		shadingParams[newName(name)] = grad
+	}
+	rd.shadingParams = shadingParams
+
+	return renames
+}
+
 // HasResources returns true if any resources are registered.
 //
 // Use this to check if the resource dictionary is empty before writing.
 func (rd *ResourceDictionary) HasResources() bool {
-	return len(rd.fonts) > 0 || len(rd.xobjects) > 0 || len(rd.extgstates) > 0
+	return len(rd.fonts) > 0 || len(rd.xobjects) > 0 || len(rd.extgstates) > 0 || len(rd.shadings) > 0
 }
 
 // Bytes returns the resource dictionary as PDF bytes.
@@ -300,6 +502,13 @@ func (rd *ResourceDictionary) Bytes() []byte {
 		buf.WriteString(" >>")
 	}
 
+	// Shading resources (gradients).
+	if len(rd.shadings) > 0 {
+		buf.WriteString(" /Shading <<")
+		rd.writeSortedResources(&buf, rd.shadings)
+		buf.WriteString(" >>")
+	}
+
 	// ProcSet (procedure set) - required for compatibility with old PDF readers.
 	// Modern readers ignore this, but it's recommended for maximum compatibility.
 	if rd.HasResources() {
@@ -316,3 +316,40 @@ func TestResourceDictionary_ObjectNumbers(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceDictionary_RenameByObjNum(t *testing.T) {
+	rd := NewResourceDictionary()
+	rd.AddFontWithID(0, "std:Helvetica")   // F1
+	rd.AddFontWithID(0, "std:Times-Roman") // F2
+	rd.SetFontObjNumByID("std:Helvetica", 2)
+	rd.SetFontObjNumByID("std:Times-Roman", 1)
+	rd.AddImage(30) // Im1
+	rd.AddImage(30) // Im2: the same image drawn again
+	gs, _ := rd.GetOrCreateExtGState(0.5)
+	rd.SetExtGStateObjNum(gs, 7)
+
+	renames := rd.RenameByObjNum()
+
+	// F1 and F2 swap names; both image names merge into one.
+	want := map[string]string{"F2": "F1", "F1": "F2", "Im1": "Im30", "Im2": "Im30", "GS1": "GS7"}
+	for old, name := range want {
+		if renames[old] != name {
+			t.Errorf("renames[%s] = %q, want %q", old, renames[old], name)
+		}
+	}
+	if got := rd.GetFontResourceName("std:Helvetica"); got != "F2" {
+		t.Errorf("Helvetica resource name = %q, want F2", got)
+	}
+	if name, _ := rd.GetOrCreateExtGState(0.5); name != "GS7" {
+		t.Errorf("cached ExtGState name = %q, want GS7", name)
+	}
+	if got, want := rd.String(), "<< /Font << /F1 1 0 R /F2 2 0 R >> /XObject << /Im30 30 0 R >> /ExtGState << /GS7 7 0 R >>"; !strings.HasPrefix(got, want) {
+		t.Errorf("String() = %q, want prefix %q", got, want)
+	}
+
+	content := "BT /F1 12 Tf (/F1 and /Im1) Tj ET <2F4631> Tj q /GS1 gs /Im1 Do Q /F2 9 Tf\n"
+	got := string(renameContentResources([]byte(content), renames))
+	if wantContent := "BT /F2 12 Tf (/F1 and /Im1) Tj ET <2F4631> Tj q /GS7 gs /Im30 Do Q /F1 9 Tf\n"; got != wantContent {
+		t.Errorf("renamed content = %q, want %q", got, wantContent)
+	}
+}
@@ -0,0 +1,102 @@
+package writer
+
+import "bytes"
+
+// SetGlobalResourceNames sets whether page resources are named after
+// their object numbers (F12 for the font in object 12, Im30 for the image
+// in object 30) instead of sequentially per page (F1, Im1, ...).
+//
+// With global names, an object shared by several pages, such as a logo or
+// an embedded font, is referenced by the same name on every page, which
+// makes the output easier to inspect and compare. Both are valid PDF.
+// Must be called before writing.
+func (w *PdfWriter) SetGlobalResourceNames(enabled bool) {
+	w.globalResourceNames = enabled
+}
+
+// applyGlobalResourceNames renames the resources of a content stream after
+// their object numbers if enabled with SetGlobalResourceNames, and returns
+// the updated content.
+func (w *PdfWriter) applyGlobalResourceNames(content []byte, resources *ResourceDictionary) []byte {
+	if !w.globalResourceNames {
+		return content
+	}
+	return renameContentResources(content, resources.RenameByObjNum())
+}
+
+// renameContentResources replaces the resource names in a content stream
+// (old name -> new name, without the slash). Names inside strings and
+// comments are left alone; renames are applied simultaneously, so names
+// may be swapped.
+func renameContentResources(content []byte, renames map[string]string) []byte {
+	if len(renames) == 0 {
+		return content
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(content))
+	for i := 0; i < len(content); {
+		start := i
+		switch c := content[i]; {
+		case c == '(':
+			i = skipLiteralString(content, i)
+		case c == '<' && (i+1 >= len(content) || content[i+1] != '<'):
+			// Hex string (dictionaries start with "<<").
+			if end := bytes.IndexByte(content[i:], '>'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(content)
+			}
+		case c == '%':
+			if end := bytes.IndexAny(content[i:], "\r\n"); end >= 0 {
+				i += end
+			} else {
+				i = len(content)
+			}
+		case c == '/':
+			i++
+			for i < len(content) && !isContentDelimiter(content[i]) {
+				i++
+			}
+			if name, ok := renames[string(content[start+1:i])]; ok {
+				out.WriteByte('/')
+				out.WriteString(name)
+				continue
+			}
+		default:
+			i++
+		}
+		out.Write(content[start:i])
+	}
+	return out.Bytes()
+}
+
+// skipLiteralString returns the position after the literal string starting
+// at content[i] ("("), honoring escapes and balanced parentheses.
+func skipLiteralString(content []byte, i int) int {
+	depth := 0
+	for ; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(content)
+}
+
+// isContentDelimiter reports whether c ends a name token: white space or
+// a PDF delimiter character.
+func isContentDelimiter(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ', '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
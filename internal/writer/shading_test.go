@@ -0,0 +1,84 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGradientOp_BytesLinear(t *testing.T) {
+	grad := &GradientOp{
+		Type:        GradientTypeLinear,
+		X1:          0,
+		Y1:          0,
+		X2:          100,
+		Y2:          0,
+		ExtendStart: true,
+		ColorStops: []ColorStopOp{
+			{Position: 0, Color: RGB{R: 1}},
+			{Position: 1, Color: RGB{B: 1}},
+		},
+	}
+
+	want := "<< /ShadingType 2 /ColorSpace /DeviceRGB /Coords [0 0 100 0]" +
+		" /Function << /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [0 0 1] /N 1 >> /Extend [true false] >>"
+	if got := string(grad.Bytes()); got != want {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestGradientFunction_Stitching(t *testing.T) {
+	red, yellow, green := RGB{R: 1}, RGB{R: 1, G: 1}, RGB{G: 1}
+
+	got := gradientFunction([]ColorStopOp{
+		{Position: 0, Color: red},
+		{Position: 0.25, Color: yellow},
+		{Position: 1, Color: green},
+	})
+	want := "<< /FunctionType 3 /Domain [0 1] /Functions [" +
+		"<< /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [1 1 0] /N 1 >> " +
+		"<< /FunctionType 2 /Domain [0 1] /C0 [1 1 0] /C1 [0 1 0] /N 1 >>" +
+		"] /Bounds [0.25] /Encode [0 1 0 1] >>"
+	if got != want {
+		t.Errorf("gradientFunction() = %q, want %q", got, want)
+	}
+}
+
+func TestGradientFunction_PadsToDomain(t *testing.T) {
+	stops := []ColorStopOp{
+		{Position: 0.2, Color: RGB{R: 1}},
+		{Position: 0.8, Color: RGB{B: 1}},
+	}
+
+	got := gradientFunction(stops)
+	if !strings.Contains(got, "/Bounds [0.20 0.80] /Encode [0 1 0 1 0 1]") {
+		t.Errorf("stops inside [0 1] should be held to the ends, got %q", got)
+	}
+	if len(stops) != 2 || stops[0].Position != 0.2 {
+		t.Error("gradientFunction() must not modify the stops")
+	}
+}
+
+func TestPaintPath_GradientClipsToShape(t *testing.T) {
+	grad := &GradientOp{
+		Type:       GradientTypeRadial,
+		X1:         50,
+		Y1:         50,
+		R1:         50,
+		ColorStops: []ColorStopOp{{Position: 0, Color: RGB{R: 1, G: 1, B: 1}}, {Position: 1, Color: RGB{}}},
+	}
+
+	content, resources, err := GenerateContentStreamWithGraphics(nil, []GraphicsOp{
+		{Type: 1, X: 0, Y: 0, Width: 100, Height: 100, FillGradient: grad},
+	})
+	if err != nil {
+		t.Fatalf("GenerateContentStreamWithGraphics() error = %v", err)
+	}
+
+	want := "q\n1 w\nq\n0 0 100 100 re\nW\nn\n/Sh1 sh\nQ\nQ\n"
+	if string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+	if names := resources.PendingShadings(); len(names) != 1 || names[0] != "Sh1" {
+		t.Errorf("PendingShadings() = %v, want [Sh1]", names)
+	}
+}
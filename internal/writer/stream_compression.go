@@ -201,3 +201,57 @@ func ShouldCompress(data []byte) bool {
 	const minSizeForCompression = 50
 	return len(data) >= minSizeForCompression
 }
+
+// SetCompression sets the Flate level of page content streams and of the
+// Flate-compressed PNG samples of image XObjects. The default is
+// DefaultCompression. NoCompression writes content streams as plain text
+// and image samples unfiltered, which is useful for inspecting output in a
+// text editor or diffing it. Must be called before writing.
+//
+// Returns an error if level is not -1 (DefaultCompression) or 0-9.
+func (w *PdfWriter) SetCompression(level CompressionLevel) error {
+	if !isValidCompressionLevel(level) {
+		return fmt.Errorf("invalid compression level: %d (must be -1, 0-9)", level)
+	}
+	w.compression = level
+	return nil
+}
+
+// recompressImage returns img with its Flate-compressed samples (PNG data
+// and alpha mask, which are compressed at the default level when loaded)
+// recompressed at the writer's level. At NoCompression the samples are
+// stored raw. As when loading, samples are only kept compressed if that
+// makes them smaller. img is returned unchanged at the default level, for
+// JPEG and custom-filter images, and if the samples cannot be decoded.
+func (w *PdfWriter) recompressImage(img *ImageData) *ImageData {
+	if w.compression == DefaultCompression || img.Format != "png" || img.Filter != "" {
+		return img
+	}
+
+	out := *img
+	out.Data, out.DataRaw = w.recompressSamples(img.Data, img.DataRaw)
+	if len(img.AlphaMask) > 0 {
+		out.AlphaMask, out.AlphaMaskRaw = w.recompressSamples(img.AlphaMask, img.AlphaMaskRaw)
+	}
+	return &out
+}
+
+// recompressSamples recompresses one sample stream for recompressImage.
+func (w *PdfWriter) recompressSamples(data []byte, raw bool) ([]byte, bool) {
+	samples := data
+	if !raw {
+		var err error
+		if samples, err = DecompressStream(data); err != nil {
+			return data, raw
+		}
+	}
+	if w.compression == NoCompression {
+		return samples, true
+	}
+
+	compressed, err := CompressStream(samples, w.compression)
+	if err != nil || len(compressed) >= len(samples) {
+		return samples, true
+	}
+	return compressed, false
+}
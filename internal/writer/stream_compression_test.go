@@ -458,3 +458,26 @@ func BenchmarkCompressionLevels(b *testing.B) {
 		})
 	}
 }
+
+// TestCreateContentStreamObjectWithLevel tests content streams at explicit levels.
+func TestCreateContentStreamObjectWithLevel(t *testing.T) {
+	content := []byte(strings.Repeat("BT /F1 12 Tf 100 700 Td (Hello) Tj ET\n", 20))
+
+	plain := string(CreateContentStreamObjectWithLevel(5, content, NoCompression).Data)
+	if strings.Contains(plain, "/Filter") || !strings.Contains(plain, "(Hello) Tj") {
+		t.Errorf("NoCompression stream should be plain text without /Filter:\n%.80s", plain)
+	}
+
+	fast := CreateContentStreamObjectWithLevel(5, content, BestSpeed).Data
+	if !bytes.Contains(fast, []byte("/Filter /FlateDecode")) || bytes.Contains(fast, []byte("(Hello) Tj")) {
+		t.Error("BestSpeed stream should be Flate-compressed")
+	}
+
+	w := NewPdfWriterFromWriter(&bytes.Buffer{})
+	if err := w.SetCompression(CompressionLevel(10)); err == nil {
+		t.Error("SetCompression(10): expected an error")
+	}
+	if err := w.SetCompression(BestCompression); err != nil || w.compression != BestCompression {
+		t.Errorf("SetCompression(BestCompression) = %v, level %d", err, w.compression)
+	}
+}
@@ -1,7 +1,12 @@
 // Package writer implements PDF writing infrastructure.
 package writer
 
-import "strings"
+import (
+	"encoding/hex"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
 
 // EscapePDFString escapes a string for use in PDF literal strings.
 //
@@ -50,3 +55,42 @@ func EscapePDFString(s string) string {
 
 	return s
 }
+
+// FormatTextString formats s as a PDF text string token, including its
+// delimiters.
+//
+// ASCII-only strings use the compact literal form: (Hello). Other UTF-8
+// strings are written as a hex string holding UTF-16BE with a byte order
+// mark: <FEFF...>, since literal strings are read as PDFDocEncoding, which
+// cannot represent most non-ASCII characters. Strings that are not valid
+// UTF-8 are taken to be pre-encoded bytes and kept in literal form.
+//
+// Example:
+//
+//	FormatTextString("Report (draft)") // "(Report \\(draft\\))"
+//	FormatTextString("日本")            // "<FEFF65E5672C>"
+//
+// Reference: PDF 1.7 Spec, Section 7.9.2.2 (Text String Type).
+func FormatTextString(s string) string {
+	if isASCII(s) || !utf8.ValidString(s) {
+		return "(" + EscapePDFString(s) + ")"
+	}
+
+	units := utf16.Encode([]rune(s))
+	buf := make([]byte, 2, 2+2*len(units))
+	buf[0], buf[1] = 0xFE, 0xFF
+	for _, u := range units {
+		buf = append(buf, byte(u>>8), byte(u))
+	}
+	return "<" + strings.ToUpper(hex.EncodeToString(buf)) + ">"
+}
+
+// isASCII reports whether s contains only 7-bit ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
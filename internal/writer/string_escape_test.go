@@ -227,3 +227,27 @@ func BenchmarkEscapePDFString(b *testing.B) {
 		})
 	}
 }
+
+func TestFormatTextString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"ASCII", "Hello", "(Hello)"},
+		{"ASCII escaped", "a (b) \\", `(a \(b\) \\)`},
+		{"empty", "", "()"},
+		{"Japanese", "日本", "<FEFF65E5672C>"},
+		{"Cyrillic", "Привет", "<FEFF041F04400438043204350442>"},
+		{"emoji surrogate pair", "A😀", "<FEFF0041D83DDE00>"},
+		{"invalid UTF-8 kept literal", "Caf\xe9", "(Caf\xe9)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTextString(tt.input); got != tt.want {
+				t.Errorf("FormatTextString(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// structFigure is a tagged image collected while writing pages.
+type structFigure struct {
+	pageRef int    // Page object containing the figure
+	mcid    int    // Marked-content ID within the page
+	alt     string // Alternate text
+}
+
+// structPage holds the figures of one page, indexed by /StructParents.
+type structPage struct {
+	figures  []structFigure
+	elemNums []int // Structure element object numbers, by MCID
+}
+
+// resetStructState clears structure tree state from a previous write.
+func (w *PdfWriter) resetStructState() {
+	w.structPages = nil
+	w.structTreeRootNum = 0
+}
+
+// tagFigures assigns marked-content IDs to the images with alternate
+// text on a page.
+//
+// Returns a copy of graphicsOps with MCIDs set, and the figures found.
+func tagFigures(graphicsOps []GraphicsOp, pageRef int) ([]GraphicsOp, []structFigure) {
+	var figures []structFigure
+	var tagged []GraphicsOp
+	for i, gop := range graphicsOps {
+		if gop.Type != 3 || gop.AltText == "" {
+			continue
+		}
+		if tagged == nil {
+			tagged = append([]GraphicsOp(nil), graphicsOps...)
+		}
+		tagged[i].MCID = len(figures)
+		figures = append(figures, structFigure{pageRef: pageRef, mcid: len(figures), alt: gop.AltText})
+	}
+	if tagged == nil {
+		return graphicsOps, nil
+	}
+	return tagged, figures
+}
+
+// addStructPage records a page's figures and returns its /StructParents key.
+func (w *PdfWriter) addStructPage(figures []structFigure) int {
+	w.structPages = append(w.structPages, &structPage{figures: figures})
+	return len(w.structPages) - 1
+}
+
+// createStructTreeObjects creates the structure tree for the tagged
+// figures collected while writing pages:
+//
+//	StructTreeRoot
+//	  Document
+//	    Figure (/Alt, /Pg, /K mcid) ...
+//
+// The parent tree maps each page's /StructParents key to the Figure
+// elements of its marked content, indexed by MCID.
+//
+// Returns nil if no figures were written.
+//
+// Reference: PDF 1.7 Specification, Section 14.7 (Logical Structure).
+func (w *PdfWriter) createStructTreeObjects() []*IndirectObject {
+	if len(w.structPages) == 0 {
+		return nil
+	}
+
+	w.structTreeRootNum = w.allocateObjNum()
+	documentNum := w.allocateObjNum()
+
+	var objects []*IndirectObject
+	var figureRefs []int
+	for _, page := range w.structPages {
+		for _, fig := range page.figures {
+			num := w.allocateObjNum()
+			page.elemNums = append(page.elemNums, num)
+			figureRefs = append(figureRefs, num)
+
+			elem := fmt.Sprintf("<< /Type /StructElem /S /Figure /P %d 0 R /Pg %d 0 R /K %d /Alt (%s) >>",
+				documentNum, fig.pageRef, fig.mcid, EscapePDFString(fig.alt))
+			objects = append(objects, NewIndirectObject(num, 0, []byte(elem)))
+		}
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString(fmt.Sprintf("<< /Type /StructElem /S /Document /P %d 0 R /K [", w.structTreeRootNum))
+	doc.WriteString(formatRefs(figureRefs))
+	doc.WriteString("] >>")
+
+	var root bytes.Buffer
+	root.WriteString(fmt.Sprintf("<< /Type /StructTreeRoot /K %d 0 R /ParentTree << /Nums [", documentNum))
+	for key, page := range w.structPages {
+		if key > 0 {
+			root.WriteString(" ")
+		}
+		root.WriteString(fmt.Sprintf("%d [%s]", key, formatRefs(page.elemNums)))
+	}
+	root.WriteString(fmt.Sprintf("] >> /ParentTreeNextKey %d >>", len(w.structPages)))
+
+	return append([]*IndirectObject{
+		NewIndirectObject(w.structTreeRootNum, 0, root.Bytes()),
+		NewIndirectObject(documentNum, 0, doc.Bytes()),
+	}, objects...)
+}
+
+// formatRefs formats object numbers as space-separated references.
+func formatRefs(nums []int) string {
+	var buf bytes.Buffer
+	for i, num := range nums {
+		if i > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%d 0 R", num))
+	}
+	return buf.String()
+}
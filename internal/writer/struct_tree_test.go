@@ -0,0 +1,74 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+func TestCreatePageWithAllContent_TaggedFigures(t *testing.T) {
+	w := &PdfWriter{nextObjNum: 4}
+	doc := document.NewDocument()
+	page, _ := doc.AddPage(document.A4)
+
+	img := &ImageData{Data: []byte{0}, Width: 1, Height: 1, ColorSpace: "DeviceGray", BitsPerComponent: 8, DataRaw: true}
+	ops := []GraphicsOp{
+		{Type: 3, X: 10, Y: 10, Width: 5, Height: 5, Image: img, AltText: "First (chart)"},
+		{Type: 3, X: 20, Y: 10, Width: 5, Height: 5, Image: img},
+		{Type: 3, X: 30, Y: 10, Width: 5, Height: 5, Image: img, AltText: "Second"},
+	}
+
+	pageObj, _, _ := w.createPageWithAllContent(page, 3, 2, nil, ops)
+	if !strings.Contains(string(pageObj.Data), "/StructParents 0") {
+		t.Errorf("page should have /StructParents 0, got: %s", pageObj.Data)
+	}
+	if ops[0].MCID != 0 || ops[2].MCID != 0 {
+		t.Error("tagging figures must not modify the caller's operations")
+	}
+
+	objs := w.createStructTreeObjects()
+	if len(objs) != 4 {
+		t.Fatalf("expected root, document and 2 figures, got %d objects", len(objs))
+	}
+	var all strings.Builder
+	for _, obj := range objs {
+		all.Write(obj.Data)
+		all.WriteString("\n")
+	}
+	out := all.String()
+	for _, want := range []string{
+		"/Type /StructTreeRoot",
+		"/S /Document",
+		"/S /Figure /P ",
+		"/Pg 3 0 R /K 0 /Alt (First \\(chart\\))",
+		"/Pg 3 0 R /K 1 /Alt (Second)",
+		"/ParentTreeNextKey 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("structure tree should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderImage_MarkedContent(t *testing.T) {
+	img := &ImageData{Data: []byte{0}, Width: 1, Height: 1, ColorSpace: "DeviceGray", BitsPerComponent: 8}
+	ops, figures := tagFigures([]GraphicsOp{
+		{Type: 3, Width: 5, Height: 5, Image: img},
+		{Type: 3, Width: 5, Height: 5, Image: img, AltText: "Logo"},
+	}, 3)
+	if len(figures) != 1 || ops[1].MCID != 0 {
+		t.Fatalf("unexpected figures %+v", figures)
+	}
+
+	content, _, err := GenerateContentStreamWithGraphics(nil, ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(content), "BDC"); got != 1 {
+		t.Errorf("expected one marked-content sequence, got %d:\n%s", got, content)
+	}
+	if !strings.Contains(string(content), "/Figure <</MCID 0>> BDC") || !strings.Contains(string(content), "EMC") {
+		t.Errorf("figure should be marked content, got:\n%s", content)
+	}
+}
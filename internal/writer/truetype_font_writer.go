@@ -15,7 +15,7 @@ type EmbeddedFontRefs struct {
 	FontObjNum       int // Font dictionary object number
 	DescriptorObjNum int // FontDescriptor object number
 	ToUnicodeObjNum  int // ToUnicode CMap object number
-	FontFileObjNum   int // FontFile2 stream object number
+	FontFileObjNum   int // FontFile2 or FontFile3 stream object number
 }
 
 // TrueTypeFontWriter generates PDF objects for TrueType/OpenType fonts.
@@ -28,7 +28,12 @@ type EmbeddedFontRefs struct {
 //   - ToUnicode CMap (for text extraction)
 //   - FontFile2 stream (embedded font data)
 //
-// Reference: PDF 1.7, Section 9.7 (Composite Fonts) and 9.8 (FontDescriptor).
+// OpenType fonts with CFF outlines (IsCFF) get a CIDFontType0 descendant
+// font instead, and the font program is embedded as a FontFile3 stream
+// with Subtype /OpenType. In both cases CIDs are glyph IDs.
+//
+// Reference: PDF 1.7, Section 9.7 (Composite Fonts), 9.8 (FontDescriptor)
+// and 9.9 (Embedded Font Programs).
 type TrueTypeFontWriter struct {
 	ttf        *fonts.TTFFont
 	subset     *fonts.FontSubset
@@ -72,7 +77,7 @@ func (w *TrueTypeFontWriter) WriteFont() ([]*IndirectObject, *EmbeddedFontRefs,
 
 	objects := make([]*IndirectObject, 0, 5)
 
-	// 1. Create FontFile2 stream (compressed font data).
+	// 1. Create FontFile2/FontFile3 stream (compressed font data).
 	fontFileObj, err := w.createFontFileObject(fontFileObjNum)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create font file: %w", err)
@@ -109,7 +114,8 @@ func (w *TrueTypeFontWriter) WriteFont() ([]*IndirectObject, *EmbeddedFontRefs,
 	return objects, refs, nil
 }
 
-// createFontFileObject creates the FontFile2 stream with compressed font data.
+// createFontFileObject creates the FontFile2 (TrueType) or FontFile3
+// (OpenType CFF) stream with compressed font data.
 func (w *TrueTypeFontWriter) createFontFileObject(objNum int) (*IndirectObject, error) {
 	// Get compressed font data from subset.
 	compressedData := w.subset.SubsetData
@@ -128,7 +134,11 @@ func (w *TrueTypeFontWriter) createFontFileObject(objNum int) (*IndirectObject,
 	var buf bytes.Buffer
 	buf.WriteString("<<\n")
 	buf.WriteString(fmt.Sprintf("/Length %d\n", len(compressedData)))
-	buf.WriteString(fmt.Sprintf("/Length1 %d\n", originalLength))
+	if w.ttf.IsCFF {
+		buf.WriteString("/Subtype /OpenType\n")
+	} else {
+		buf.WriteString(fmt.Sprintf("/Length1 %d\n", originalLength))
+	}
 	buf.WriteString("/Filter /FlateDecode\n")
 	buf.WriteString(">>\n")
 	buf.WriteString("stream\n")
@@ -170,7 +180,11 @@ func (w *TrueTypeFontWriter) createFontDescriptorObject(objNum, fontFileObjNum i
 	buf.WriteString(fmt.Sprintf("/Descent %d\n", fd.Descent))
 	buf.WriteString(fmt.Sprintf("/CapHeight %d\n", fd.CapHeight))
 	buf.WriteString(fmt.Sprintf("/StemV %d\n", fd.StemV))
-	buf.WriteString(fmt.Sprintf("/FontFile2 %d 0 R\n", fontFileObjNum))
+	if w.ttf.IsCFF {
+		buf.WriteString(fmt.Sprintf("/FontFile3 %d 0 R\n", fontFileObjNum))
+	} else {
+		buf.WriteString(fmt.Sprintf("/FontFile2 %d 0 R\n", fontFileObjNum))
+	}
 	buf.WriteString(">>")
 
 	return &IndirectObject{
@@ -214,9 +228,10 @@ func (w *TrueTypeFontWriter) createToUnicodeObject(objNum int) (*IndirectObject,
 // createFontObject creates the main Font dictionary (Type 0 Composite Font).
 //
 // For full Unicode support, we use Type 0 (Composite) font structure:
-// - Type 0 font with Identity-H encoding
-// - CIDFontType2 descendant font (TrueType-based CID font)
-// - Identity CIDToGIDMap
+//   - Type 0 font with Identity-H encoding
+//   - CIDFontType2 descendant font (TrueType-based CID font) with an
+//     Identity CIDToGIDMap, or CIDFontType0 (CFF-based CID font), which
+//     selects glyphs by CID directly
 //
 // This allows encoding any glyph ID directly in the content stream.
 func (w *TrueTypeFontWriter) createFontObject(objNum, descriptorObjNum, toUnicodeObjNum int) (*IndirectObject, error) {
@@ -238,11 +253,17 @@ func (w *TrueTypeFontWriter) createFontObject(objNum, descriptorObjNum, toUnicod
 	var cidBuf bytes.Buffer
 	cidBuf.WriteString("<<\n")
 	cidBuf.WriteString("/Type /Font\n")
-	cidBuf.WriteString("/Subtype /CIDFontType2\n")
+	if w.ttf.IsCFF {
+		cidBuf.WriteString("/Subtype /CIDFontType0\n")
+	} else {
+		cidBuf.WriteString("/Subtype /CIDFontType2\n")
+	}
 	cidBuf.WriteString(fmt.Sprintf("/BaseFont /%s\n", subsetName))
 	cidBuf.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >>\n")
 	cidBuf.WriteString(fmt.Sprintf("/FontDescriptor %d 0 R\n", descriptorObjNum))
-	cidBuf.WriteString("/CIDToGIDMap /Identity\n")
+	if !w.ttf.IsCFF {
+		cidBuf.WriteString("/CIDToGIDMap /Identity\n")
+	}
 	cidBuf.WriteString(fmt.Sprintf("/DW %d\n", w.getDefaultWidth()))
 	if widthsArray != "" {
 		cidBuf.WriteString(fmt.Sprintf("/W %s\n", widthsArray))
@@ -298,24 +319,30 @@ func (w *TrueTypeFontWriter) getDefaultWidth() int {
 //
 // We use the first format for compactness.
 func (w *TrueTypeFontWriter) generateCIDWidthsArray() string {
-	if len(w.subset.UsedChars) == 0 {
+	if len(w.subset.UsedChars) == 0 && len(w.subset.GlyphText) == 0 {
 		return ""
 	}
 
-	// Collect all used glyph IDs with their widths.
+	// Collect all used glyph IDs (once each) with their widths.
 	type glyphWidth struct {
 		gid   uint16
 		width int
 	}
 
-	glyphs := make([]glyphWidth, 0, len(w.subset.UsedChars))
-	scale := 1000.0 / float64(w.ttf.UnitsPerEm)
-
+	used := make(map[uint16]bool, len(w.subset.UsedChars)+len(w.subset.GlyphText))
 	for ch := range w.subset.UsedChars {
-		gid, ok := w.ttf.CharToGlyph[ch]
-		if !ok {
-			continue
+		if gid, ok := w.ttf.CharToGlyph[ch]; ok {
+			used[gid] = true
 		}
+	}
+	for gid := range w.subset.GlyphText {
+		used[gid] = true
+	}
+
+	glyphs := make([]glyphWidth, 0, len(used))
+	scale := 1000.0 / float64(w.ttf.UnitsPerEm)
+
+	for gid := range used {
 		width, ok := w.ttf.GlyphWidths[gid]
 		if !ok {
 			continue
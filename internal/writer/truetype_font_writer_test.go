@@ -263,3 +263,61 @@ func TestTrueTypeFontWriter_ToUnicode(t *testing.T) {
 		t.Error("Missing stream keyword")
 	}
 }
+
+func TestTrueTypeFontWriter_CFF(t *testing.T) {
+	ttf := &fonts.TTFFont{
+		PostScriptName: "TestFont-CFF",
+		UnitsPerEm:     1000,
+		Ascender:       800,
+		Descender:      -200,
+		Flags:          32,
+		GlyphWidths:    map[uint16]uint16{1: 600},
+		CharToGlyph:    map[rune]uint16{'A': 1},
+		FontData:       []byte("OTTO mock font data"),
+		IsCFF:          true,
+	}
+
+	subset := fonts.NewFontSubset(ttf)
+	subset.UseString("A")
+
+	nextObjNum := 1
+	writer := NewTrueTypeFontWriter(ttf, subset, func() int {
+		num := nextObjNum
+		nextObjNum++
+		return num
+	})
+
+	objects, refs, err := writer.WriteFont()
+	if err != nil {
+		t.Fatalf("WriteFont failed: %v", err)
+	}
+
+	byNum := make(map[int]string, len(objects))
+	for _, obj := range objects {
+		byNum[obj.Number] = string(obj.Data)
+	}
+
+	descriptor := byNum[refs.DescriptorObjNum]
+	if !strings.Contains(descriptor, "/FontFile3 4 0 R") {
+		t.Errorf("descriptor should reference FontFile3:\n%s", descriptor)
+	}
+	if strings.Contains(descriptor, "/FontFile2") {
+		t.Errorf("descriptor should not reference FontFile2:\n%s", descriptor)
+	}
+
+	fontFile := byNum[refs.FontFileObjNum]
+	if !strings.Contains(fontFile, "/Subtype /OpenType") {
+		t.Errorf("font file should have /Subtype /OpenType:\n%s", fontFile)
+	}
+	if strings.Contains(fontFile, "/Length1") {
+		t.Error("FontFile3 should not have /Length1")
+	}
+
+	cidFont := byNum[refs.FontFileObjNum+1]
+	if !strings.Contains(cidFont, "/Subtype /CIDFontType0") {
+		t.Errorf("descendant font should be CIDFontType0:\n%s", cidFont)
+	}
+	if strings.Contains(cidFont, "/CIDToGIDMap") {
+		t.Errorf("CIDFontType0 should not have /CIDToGIDMap:\n%s", cidFont)
+	}
+}
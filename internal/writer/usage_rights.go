@@ -0,0 +1,183 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// UsageRights lists the rights granted by a usage rights (UR3) signature.
+// Each field holds the right names of one TransformParams entry, without
+// the leading slash (e.g. Form: []string{"FillIn", "Export"}).
+//
+// Reference: PDF 1.7 Specification, Section 12.8.2.3 (UR Signatures), Table 255.
+type UsageRights struct {
+	Document  []string // /Document rights (FullSave)
+	Form      []string // /Form rights (FillIn, Import, Export, ...)
+	Annots    []string // /Annots rights (Create, Delete, Modify, ...)
+	Signature []string // /Signature rights (Modify)
+	EF        []string // /EF embedded file rights (Create, Delete, ...)
+	Message   string   // /Msg shown by the viewer
+}
+
+// UsageRightsContentsSize is the number of bytes reserved for the
+// usage rights signature (a DER-encoded PKCS#7 object).
+const UsageRightsContentsSize = 8192
+
+// usageRightsKey identifies the usage rights signature dictionary.
+const usageRightsKey = "/TransformMethod /UR3"
+
+// byteRangeWidth is the width of each zero-padded /ByteRange number.
+const byteRangeWidth = 10
+
+var (
+	// ErrNoUsageRights is returned for documents without a usage rights
+	// signature placeholder.
+	ErrNoUsageRights = errors.New("no usage rights signature placeholder found")
+
+	// ErrSignatureTooLarge is returned when a signature does not fit in
+	// the space reserved for it.
+	ErrSignatureTooLarge = errors.New("signature larger than reserved space")
+)
+
+// SetUsageRights reserves a usage rights signature in the catalog
+// (/Perms /UR3). The signature dictionary is written as a placeholder;
+// call PrepareUsageRights on the complete output to fill in its
+// /ByteRange. Pass nil to remove it. Must be called before writing.
+func (w *PdfWriter) SetUsageRights(rights *UsageRights) {
+	w.usageRights = rights
+}
+
+// createUsageRightsObject creates the usage rights signature dictionary,
+// or returns nil if no usage rights are set.
+//
+// Format:
+//
+//	<< /Type /Sig /Filter /Adobe.PPKLite /SubFilter /adbe.pkcs7.detached
+//	   /ByteRange [0 0000000000 0000000000 0000000000] /Contents <00...>
+//	   /Reference [<< /Type /SigRef /TransformMethod /UR3
+//	     /TransformParams << /Type /TransformParams /V /2.2 /Form [/FillIn] >> >>]
+//	   /M (D:20250101120000+00'00') >>
+func (w *PdfWriter) createUsageRightsObject() *IndirectObject {
+	if w.usageRights == nil {
+		w.usageRightsNum = 0
+		return nil
+	}
+	w.usageRightsNum = w.allocateObjNum()
+
+	zero := string(bytes.Repeat([]byte{'0'}, byteRangeWidth))
+
+	var buf bytes.Buffer
+	buf.WriteString("<< /Type /Sig /Filter /Adobe.PPKLite /SubFilter /adbe.pkcs7.detached")
+	buf.WriteString(fmt.Sprintf(" /ByteRange [0 %s %s %s]", zero, zero, zero))
+	buf.WriteString(" /Contents <")
+	buf.Write(bytes.Repeat([]byte{'0'}, UsageRightsContentsSize*2))
+	buf.WriteString(">")
+	buf.WriteString(" /Reference [<< /Type /SigRef " + usageRightsKey)
+	buf.WriteString(" /TransformParams << /Type /TransformParams /V /2.2")
+	writeRightNames(&buf, "Document", w.usageRights.Document)
+	writeRightNames(&buf, "Form", w.usageRights.Form)
+	writeRightNames(&buf, "Annots", w.usageRights.Annots)
+	writeRightNames(&buf, "Signature", w.usageRights.Signature)
+	writeRightNames(&buf, "EF", w.usageRights.EF)
+	if w.usageRights.Message != "" {
+		buf.WriteString(fmt.Sprintf(" /Msg (%s)", EscapePDFString(w.usageRights.Message)))
+	}
+	buf.WriteString(" >> >>]")
+	buf.WriteString(fmt.Sprintf(" /M (%s) >>", formatPDFDate(time.Now())))
+
+	return NewIndirectObject(w.usageRightsNum, 0, buf.Bytes())
+}
+
+// writeRightNames writes a TransformParams entry as an array of names.
+func writeRightNames(buf *bytes.Buffer, key string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	buf.WriteString(fmt.Sprintf(" /%s [", key))
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("/" + name)
+	}
+	buf.WriteString("]")
+}
+
+// findUsageRights returns the offsets of the /ByteRange numbers and of the
+// /Contents hex string (including its angle brackets) of the usage rights
+// signature in pdf.
+func findUsageRights(pdf []byte) (byteRange, contentsStart, contentsEnd int, err error) {
+	key := bytes.Index(pdf, []byte(usageRightsKey))
+	if key < 0 {
+		return 0, 0, 0, ErrNoUsageRights
+	}
+	br := bytes.LastIndex(pdf[:key], []byte("/ByteRange [0 "))
+	contents := bytes.LastIndex(pdf[:key], []byte("/Contents <"))
+	if br < 0 || contents < br {
+		return 0, 0, 0, ErrNoUsageRights
+	}
+	contentsStart = contents + len("/Contents ")
+	contentsEnd = contentsStart + UsageRightsContentsSize*2 + 2
+	if contentsEnd > key || pdf[contentsEnd-1] != '>' {
+		return 0, 0, 0, ErrNoUsageRights
+	}
+	return br + len("/ByteRange [0 "), contentsStart, contentsEnd, nil
+}
+
+// PrepareUsageRights fills in the /ByteRange of the usage rights signature
+// placeholder of a complete PDF in place.
+//
+// The byte range covers the whole file except the /Contents hex string,
+// which is where the signature is stored (see EmbedUsageRightsSignature).
+func PrepareUsageRights(pdf []byte) error {
+	at, start, end, err := findUsageRights(pdf)
+	if err != nil {
+		return err
+	}
+	values := []int{start, end, len(pdf) - end}
+	for i, v := range values {
+		field := fmt.Sprintf("%0*d", byteRangeWidth, v)
+		if len(field) != byteRangeWidth {
+			return fmt.Errorf("byte range value %d too large", v)
+		}
+		copy(pdf[at+i*(byteRangeWidth+1):], field)
+	}
+	return nil
+}
+
+// UsageRightsSignedData returns the bytes covered by the usage rights
+// signature of a prepared PDF: the file without the /Contents hex string.
+func UsageRightsSignedData(pdf []byte) ([]byte, error) {
+	at, start, end, err := findUsageRights(pdf)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := strconv.Atoi(string(pdf[at : at+byteRangeWidth])); err != nil || n != start {
+		return nil, errors.New("usage rights byte range not prepared")
+	}
+	data := make([]byte, 0, len(pdf)-(end-start))
+	data = append(data, pdf[:start]...)
+	return append(data, pdf[end:]...), nil
+}
+
+// EmbedUsageRightsSignature stores a DER-encoded PKCS#7 signature in the
+// /Contents of the usage rights signature of a prepared PDF, in place.
+func EmbedUsageRightsSignature(pdf, signature []byte) error {
+	_, start, end, err := findUsageRights(pdf)
+	if err != nil {
+		return err
+	}
+	if len(signature) > UsageRightsContentsSize {
+		return fmt.Errorf("%w: %d > %d bytes", ErrSignatureTooLarge, len(signature), UsageRightsContentsSize)
+	}
+	digits := pdf[start+1 : end-1]
+	for i := range digits {
+		digits[i] = '0'
+	}
+	hex.Encode(digits, signature)
+	return nil
+}
@@ -0,0 +1,48 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// SetXMPMetadata embeds an XMP packet as the document metadata stream
+// (/Metadata in the catalog). Pass nil to write no metadata stream. Must
+// be called before writing.
+//
+// The packet is written as is; the Info dictionary is still written, so
+// callers should keep the two consistent.
+//
+// Reference: PDF 1.7 Specification, Section 14.3.2 (Metadata Streams).
+func (w *PdfWriter) SetXMPMetadata(packet []byte) {
+	w.xmp = packet
+}
+
+// createMetadataObject creates the metadata stream, or returns nil if no
+// XMP packet is set.
+//
+// The stream is never compressed, so that tools which do not parse PDF
+// can still find the packet by scanning the file.
+//
+// Format:
+//
+//	<< /Type /Metadata /Subtype /XML /Length N >>
+//	stream
+//	<?xpacket begin="..." id="W5M0MpCehiHzreSzNTczkc9d"?>
+//	...
+//	<?xpacket end="w"?>
+//	endstream
+func (w *PdfWriter) createMetadataObject() *IndirectObject {
+	if len(w.xmp) == 0 {
+		w.metadataNum = 0
+		return nil
+	}
+	w.metadataNum = w.allocateObjNum()
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("<< /Type /Metadata /Subtype /XML /Length %d >>\n", len(w.xmp)))
+	buf.WriteString("stream\n")
+	buf.Write(w.xmp)
+	buf.WriteString("\nendstream")
+
+	return NewIndirectObject(w.metadataNum, 0, buf.Bytes())
+}
@@ -0,0 +1,142 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/document"
+)
+
+// SetXRefStream enables cross-reference streams. Must be called before
+// writing.
+//
+// For documents of PDF 1.5 or later the classic xref table and trailer are
+// replaced by a compressed /Type /XRef stream object, which is smaller and
+// is a prerequisite for object streams. Documents declaring an earlier
+// version are still written with an xref table.
+//
+// Reference: PDF 1.7 Specification, Section 7.5.8 (Cross-Reference Streams).
+func (w *PdfWriter) SetXRefStream(enabled bool) {
+	w.xrefStream = enabled
+}
+
+// writeXRefAndTrailer writes the cross-reference section and trailer,
+// as a cross-reference stream if enabled for the document's version.
+//
+// infoRef is the object number of the Info dictionary, or 0 for none.
+func (w *PdfWriter) writeXRefAndTrailer(doc *document.Document, catalogRef, infoRef int) error {
+	if w.useXRefStream(doc) {
+		if err := w.writeXRefStream(catalogRef, infoRef); err != nil {
+			return fmt.Errorf("failed to write xref stream: %w", err)
+		}
+		return nil
+	}
+
+	// Write cross-reference table
+	xrefOffset, err := w.writeXRef()
+	if err != nil {
+		return fmt.Errorf("failed to write xref: %w", err)
+	}
+
+	// Write trailer
+	if err := w.writeTrailer(catalogRef, infoRef, w.fileIdentifier(doc, xrefOffset), xrefOffset); err != nil {
+		return fmt.Errorf("failed to write trailer: %w", err)
+	}
+
+	return nil
+}
+
+// writeXRefStream writes a cross-reference stream object followed by
+// startxref and the EOF marker.
+//
+// The stream is the last object in the file and lists itself. Each entry
+// is a type byte followed by two fields, using the field widths in /W:
+// 0 (free), 1 (in use) with the byte offset and generation number, or 2
+// (in an object stream) with the object stream number and index:
+//
+//	N 0 obj
+//	<< /Type /XRef /Size N+1 /W [1 4 2] /Index [0 N+1]
+//	   /Root 1 0 R /Info 5 0 R /Filter /FlateDecode /Length L >>
+//	stream
+//	...
+//	endstream
+//	endobj
+//	startxref
+//	<offset of N 0 obj>
+//	%%EOF
+//
+// The trailer keys (/Root, /Size, /Info) move into the stream dictionary.
+func (w *PdfWriter) writeXRefStream(catalogRef, infoRef int) error {
+	xrefOffset := w.getCurrentOffset()
+	if err := w.checkByteLimit(xrefOffset); err != nil {
+		return err
+	}
+
+	objNum := w.allocateObjNum()
+	if w.limitErr != nil {
+		return w.limitErr
+	}
+	w.offsets[objNum] = xrefOffset
+	size := w.nextObjNum
+
+	// The second field needs as many bytes as the largest offset or
+	// object stream number.
+	offsetWidth := 1
+	for v := max(xrefOffset, int64(size)) >> 8; v > 0; v >>= 8 {
+		offsetWidth++
+	}
+
+	var entries bytes.Buffer
+	writeXRefStreamEntry(&entries, 0, 0, offsetWidth, 0xFFFF)
+	for i := 1; i < size; i++ {
+		if entry, ok := w.compressed[i]; ok {
+			writeXRefStreamEntry(&entries, 2, int64(entry.stream), offsetWidth, entry.index)
+			continue
+		}
+		offset, exists := w.offsets[i]
+		if !exists {
+			return fmt.Errorf("missing offset for object %d", i)
+		}
+		writeXRefStreamEntry(&entries, 1, offset, offsetWidth, 0)
+	}
+
+	data, err := CompressStream(entries.Bytes(), DefaultCompression)
+	if err != nil {
+		return err
+	}
+
+	var dict bytes.Buffer
+	dict.WriteString("<< /Type /XRef")
+	dict.WriteString(fmt.Sprintf(" /Size %d", size))
+	dict.WriteString(fmt.Sprintf(" /W [1 %d 2]", offsetWidth))
+	dict.WriteString(fmt.Sprintf(" /Index [0 %d]", size))
+	dict.WriteString(fmt.Sprintf(" /Root %d 0 R", catalogRef))
+	if infoRef > 0 {
+		dict.WriteString(fmt.Sprintf(" /Info %d 0 R", infoRef))
+	}
+	dict.WriteString(fmt.Sprintf(" /Filter /FlateDecode /Length %d >>\n", len(data)))
+	dict.WriteString("stream\n")
+	dict.Write(data)
+	dict.WriteString("\nendstream")
+
+	if _, err := NewIndirectObject(objNum, 0, dict.Bytes()).WriteTo(w.writer); err != nil {
+		return fmt.Errorf("failed to write object %d: %w", objNum, err)
+	}
+
+	if _, err := w.writer.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOffset)); err != nil {
+		return fmt.Errorf("failed to write startxref: %w", err)
+	}
+
+	return nil
+}
+
+// writeXRefStreamEntry appends one big-endian cross-reference stream
+// entry with /W [1 width 2].
+func writeXRefStreamEntry(buf *bytes.Buffer, typ byte, field2 int64, width int, field3 int) {
+	buf.WriteByte(typ)
+	for i := width - 1; i >= 0; i-- {
+		buf.WriteByte(byte(field2 >> (8 * i)))
+	}
+	buf.WriteByte(byte(field3 >> 8))
+	buf.WriteByte(byte(field3))
+}
@@ -0,0 +1,236 @@
+package writer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/models/types"
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+func writeXRefStreamTestDoc(t *testing.T, version types.Version) (string, []byte) {
+	t.Helper()
+
+	doc := document.NewDocument()
+	doc.SetVersion(version)
+	doc.SetMetadata("XRef Stream", "Test Author", "")
+	for i := 0; i < 3; i++ {
+		if _, err := doc.AddPage(document.A4); err != nil {
+			t.Fatalf("AddPage() error = %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "xref.pdf")
+	w, err := NewPdfWriter(path)
+	if err != nil {
+		t.Fatalf("NewPdfWriter() error = %v", err)
+	}
+	w.SetXRefStream(true)
+
+	textOps := map[int][]TextOp{0: {{Text: "Hello", X: 100, Y: 700, Font: "Helvetica", Size: 12}}}
+	if err := w.WriteWithAllContent(doc, textOps, nil); err != nil {
+		t.Fatalf("WriteWithAllContent() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return path, data
+}
+
+func TestPdfWriter_XRefStream(t *testing.T) {
+	path, data := writeXRefStreamTestDoc(t, types.PDF17)
+	content := string(data)
+
+	if strings.Contains(content, "\nxref\n") || strings.Contains(content, "trailer") {
+		t.Error("output should not contain an xref table or trailer")
+	}
+	for _, want := range []string{"/Type /XRef", "/W [1 ", "/Index [0 ", "/Root ", "/Info "} {
+		if !strings.Contains(content, want) {
+			t.Errorf("xref stream dictionary should contain %q", want)
+		}
+	}
+
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	count, err := r.GetPageCount()
+	if err != nil {
+		t.Fatalf("GetPageCount() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("page count = %d, want 3", count)
+	}
+	if info := r.GetDocumentInfo(); info.Title != "XRef Stream" || info.Author != "Test Author" {
+		t.Errorf("Info = %+v, want Title and Author to resolve", info)
+	}
+}
+
+func TestPdfWriter_XRefStreamOffsets(t *testing.T) {
+	_, data := writeXRefStreamTestDoc(t, types.PDF17)
+
+	// startxref must point at the xref stream object.
+	idx := bytes.LastIndex(data, []byte("startxref\n"))
+	if idx < 0 {
+		t.Fatal("startxref not found")
+	}
+	var offset int
+	if _, err := fmt.Sscan(string(data[idx+len("startxref\n"):]), &offset); err != nil {
+		t.Fatalf("parse startxref: %v", err)
+	}
+	if !regexp.MustCompile(`^\d+ 0 obj\n<< /Type /XRef`).Match(data[offset:]) {
+		t.Errorf("startxref %d does not point at the xref stream", offset)
+	}
+}
+
+// TestPdfWriter_XRefStreamEntries decodes the cross-reference stream
+// without the parser, checks it against PDF 1.7 Section 7.5.8 and resolves
+// every listed object with the reader.
+func TestPdfWriter_XRefStreamEntries(t *testing.T) {
+	path, data := writeXRefStreamTestDoc(t, types.PDF17)
+
+	idx := bytes.LastIndex(data, []byte("startxref\n"))
+	if idx < 0 {
+		t.Fatal("startxref not found")
+	}
+	var offset int
+	if _, err := fmt.Sscan(string(data[idx+len("startxref\n"):]), &offset); err != nil {
+		t.Fatalf("parse startxref: %v", err)
+	}
+	m := regexp.MustCompile(`^(\d+) 0 obj\n(<<[^\n]*>>)\nstream\n`).FindSubmatch(data[offset:])
+	if m == nil {
+		t.Fatalf("no xref stream object at startxref %d", offset)
+	}
+	xrefNum, _ := strconv.Atoi(string(m[1]))
+	dict := string(m[2])
+
+	field := func(pattern string) []int {
+		t.Helper()
+		sub := regexp.MustCompile(pattern).FindStringSubmatch(dict)
+		if sub == nil {
+			t.Fatalf("xref stream dictionary %q does not match %s", dict, pattern)
+		}
+		var vals []int
+		for _, f := range strings.Fields(strings.Join(sub[1:], " ")) {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				t.Fatalf("parse %q: %v", f, err)
+			}
+			vals = append(vals, v)
+		}
+		return vals
+	}
+	size := field(`/Size (\d+)`)[0]
+	widths := field(`/W \[(\d+) (\d+) (\d+)\]`)
+	index := field(`/Index \[(\d+) (\d+)\]`)
+	length := field(`/Length (\d+)`)[0]
+	field(`/Root (\d+) 0 R`)
+	if !strings.Contains(dict, "/Filter /FlateDecode") {
+		t.Fatalf("xref stream dictionary %q is not FlateDecode", dict)
+	}
+
+	if index[0] != 0 || index[1] != size {
+		t.Errorf("/Index = %v, want [0 %d]", index, size)
+	}
+	if xrefNum != size-1 {
+		t.Errorf("xref stream is object %d, want the last object %d", xrefNum, size-1)
+	}
+
+	start := offset + len(m[0])
+	if !bytes.HasPrefix(data[start+length:], []byte("\nendstream")) {
+		t.Fatalf("/Length %d does not end at endstream", length)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(data[start : start+length]))
+	if err != nil {
+		t.Fatalf("zlib.NewReader() error = %v", err)
+	}
+	entries, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress xref stream: %v", err)
+	}
+	entryLen := widths[0] + widths[1] + widths[2]
+	if len(entries) != size*entryLen {
+		t.Fatalf("xref stream has %d bytes, want %d entries of %d bytes", len(entries), size, entryLen)
+	}
+
+	r, err := parser.OpenPDF(path)
+	if err != nil {
+		t.Fatalf("OpenPDF() error = %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	readField := func(b []byte) int {
+		v := 0
+		for _, c := range b {
+			v = v<<8 | int(c)
+		}
+		return v
+	}
+	for num := 0; num < size; num++ {
+		e := entries[num*entryLen : (num+1)*entryLen]
+		typ := readField(e[:widths[0]])
+		f2 := readField(e[widths[0] : widths[0]+widths[1]])
+		f3 := readField(e[widths[0]+widths[1]:])
+
+		switch {
+		case num == 0:
+			if typ != 0 || f3 != 0xFFFF {
+				t.Errorf("object 0: type %d generation %d, want free with generation 65535", typ, f3)
+			}
+		case typ == 1:
+			want := fmt.Sprintf("%d %d obj", num, f3)
+			if f2 >= len(data) || !bytes.HasPrefix(data[f2:], []byte(want)) {
+				t.Errorf("object %d: offset %d does not point at %q", num, f2, want)
+			}
+			if _, err := r.GetObject(num); err != nil {
+				t.Errorf("GetObject(%d) error = %v", num, err)
+			}
+		default:
+			t.Errorf("object %d: entry type %d, want 1", num, typ)
+		}
+	}
+}
+
+// TestPdfWriter_XRefStreamQPDF checks the output with qpdf when it is
+// installed.
+func TestPdfWriter_XRefStreamQPDF(t *testing.T) {
+	qpdf, err := exec.LookPath("qpdf")
+	if err != nil {
+		t.Skip("qpdf not installed")
+	}
+	path, _ := writeXRefStreamTestDoc(t, types.PDF17)
+
+	out, err := exec.Command(qpdf, "--check", path).CombinedOutput()
+	if err != nil {
+		t.Errorf("qpdf --check error = %v:\n%s", err, out)
+	}
+}
+
+func TestPdfWriter_XRefStreamPre15(t *testing.T) {
+	_, data := writeXRefStreamTestDoc(t, types.PDF14)
+	content := string(data)
+
+	if !strings.Contains(content, "\nxref\n") || !strings.Contains(content, "trailer") {
+		t.Error("PDF 1.4 output should keep the xref table and trailer")
+	}
+	if strings.Contains(content, "/Type /XRef") {
+		t.Error("PDF 1.4 output should not contain an xref stream")
+	}
+}
@@ -0,0 +1,234 @@
+package gxpdf
+
+import (
+	"fmt"
+
+	"github.com/coregx/gxpdf/internal/parser"
+)
+
+// maxNameTreeDepth limits recursion into /Kids of the named destinations
+// tree so a malformed (cyclic) tree cannot recurse forever.
+const maxNameTreeDepth = 32
+
+// OutlineItem is an entry of the document outline (the bookmarks shown
+// in a viewer's navigation pane).
+type OutlineItem struct {
+	// Title is the text shown for the item.
+	Title string
+
+	// PageIndex is the target page (0-based), or -1 if the item does not
+	// lead to a page of this document: it has no destination, runs
+	// another kind of action (such as opening a URI), names an undefined
+	// destination, or targets a removed page.
+	PageIndex int
+
+	// Children are the nested items, in order.
+	Children []OutlineItem
+}
+
+// Outline returns the document outline (bookmarks) as a tree of items.
+//
+// An item's target page is taken from its /Dest entry or from a GoTo
+// action, and named destinations are looked up in the catalog's /Names
+// tree or the older /Dests dictionary. Page indices follow the current
+// page order, so after RemovePage or MovePage they still point at the
+// right pages.
+//
+// Returns nil if the document has no outline. Malformed items are kept
+// with the parts that could be read rather than reported, since the
+// outline is purely navigational.
+//
+// Example:
+//
+//	items, err := doc.Outline()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range items {
+//	    fmt.Printf("%s (page %d)\n", item.Title, item.PageIndex+1)
+//	}
+//
+// Reference: PDF 1.7 specification, Section 12.3.3 (Document Outline).
+func (d *Document) Outline() ([]OutlineItem, error) {
+	catalog, err := d.reader.GetCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("gxpdf: failed to read outline: %w", err)
+	}
+
+	root, ok := d.resolve(catalog.Get("Outlines")).(*parser.Dictionary)
+	if !ok {
+		return nil, nil
+	}
+
+	pages, _, err := newObjectCopier(d.reader).collectPages(catalog.Get("Pages"))
+	if err != nil {
+		return nil, fmt.Errorf("gxpdf: failed to read outline: %w", err)
+	}
+
+	// Map page objects to their index in the current page order.
+	views := make(map[int]int, len(pages))
+	for view, source := range d.pageOrder() {
+		views[source] = view
+	}
+	o := &outlineReader{
+		doc:     d,
+		catalog: catalog,
+		pages:   make(map[int]int, len(pages)),
+		sources: make([]int, len(pages)),
+		visited: make(map[int]bool),
+	}
+	for source, page := range pages {
+		o.sources[source] = page.num
+		if view, ok := views[source]; ok {
+			o.pages[page.num] = view
+		}
+	}
+
+	items := o.items(root.Get("First"))
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items, nil
+}
+
+// resolve returns the object an indirect reference points to, or obj
+// itself. Unlike parser.Reader.ResolveReferences it leaves nested
+// references alone, so a destination keeps its page reference.
+func (d *Document) resolve(obj parser.PdfObject) parser.PdfObject {
+	ref, ok := obj.(*parser.IndirectReference)
+	if !ok {
+		return obj
+	}
+	resolved, err := d.reader.GetObject(ref.Number)
+	if err != nil {
+		return nil
+	}
+	return resolved
+}
+
+// outlineReader reads the items of an outline.
+type outlineReader struct {
+	doc     *Document
+	catalog *parser.Dictionary
+	pages   map[int]int                 // Page object number -> page index
+	sources []int                       // Page object numbers in file order
+	visited map[int]bool                // Outline items already read
+	names   map[string]parser.PdfObject // Named destinations (loaded on first use)
+}
+
+// items reads the sibling items starting at first, following /Next.
+// Items seen before are skipped, so cyclic links end the list.
+func (o *outlineReader) items(first parser.PdfObject) []OutlineItem {
+	var items []OutlineItem
+	for next := first; next != nil; {
+		if ref, ok := next.(*parser.IndirectReference); ok {
+			if o.visited[ref.Number] {
+				break
+			}
+			o.visited[ref.Number] = true
+		}
+		dict, ok := o.doc.resolve(next).(*parser.Dictionary)
+		if !ok {
+			break
+		}
+
+		item := OutlineItem{PageIndex: -1}
+		if title, ok := o.doc.resolve(dict.Get("Title")).(*parser.String); ok {
+			item.Title = parser.DecodeTextString(title.Bytes())
+		}
+		if dest := dict.Get("Dest"); dest != nil {
+			item.PageIndex = o.destPage(dest, true)
+		} else if action, ok := o.doc.resolve(dict.Get("A")).(*parser.Dictionary); ok {
+			if s := action.GetName("S"); s != nil && s.Value() == "GoTo" {
+				item.PageIndex = o.destPage(action.Get("D"), true)
+			}
+		}
+		item.Children = o.items(dict.Get("First"))
+
+		items = append(items, item)
+		next = dict.Get("Next")
+	}
+	return items
+}
+
+// destPage returns the page index a destination leads to, or -1. A
+// destination is an array starting with the page, a dictionary holding
+// the array in /D, or the name of one of these (followed if named is true).
+func (o *outlineReader) destPage(dest parser.PdfObject, named bool) int {
+	switch v := o.doc.resolve(dest).(type) {
+	case *parser.Array:
+		if v.Len() == 0 {
+			return -1
+		}
+		num := -1
+		switch page := v.Get(0).(type) {
+		case *parser.IndirectReference:
+			num = page.Number
+		case *parser.Integer:
+			// Destinations in other files give page numbers; some
+			// writers use them for local destinations too.
+			if source := page.Int(); source >= 0 && source < len(o.sources) {
+				num = o.sources[source]
+			}
+		}
+		if index, ok := o.pages[num]; ok {
+			return index
+		}
+	case *parser.Dictionary:
+		if named {
+			return o.destPage(v.Get("D"), false)
+		}
+	case *parser.Name:
+		if named {
+			return o.destPage(o.namedDest(v.Value()), false)
+		}
+	case *parser.String:
+		if named {
+			return o.destPage(o.namedDest(string(v.Bytes())), false)
+		}
+	}
+	return -1
+}
+
+// namedDest looks up a named destination. The named destinations are
+// read from the catalog's /Names /Dests tree and its /Dests dictionary
+// on first use.
+func (o *outlineReader) namedDest(name string) parser.PdfObject {
+	if o.names == nil {
+		o.names = make(map[string]parser.PdfObject)
+		if dests, ok := o.doc.resolve(o.catalog.Get("Dests")).(*parser.Dictionary); ok {
+			for _, key := range dests.Keys() {
+				o.names[key] = dests.Get(key)
+			}
+		}
+		if names, ok := o.doc.resolve(o.catalog.Get("Names")).(*parser.Dictionary); ok {
+			o.collectNames(names.Get("Dests"), 0)
+		}
+	}
+
+	value := o.names[name]
+	if dict, ok := o.doc.resolve(value).(*parser.Dictionary); ok {
+		return dict.Get("D")
+	}
+	return value
+}
+
+// collectNames adds the entries of a name tree node and its kids to o.names.
+func (o *outlineReader) collectNames(node parser.PdfObject, depth int) {
+	dict, ok := o.doc.resolve(node).(*parser.Dictionary)
+	if !ok || depth > maxNameTreeDepth {
+		return
+	}
+	if names, ok := o.doc.resolve(dict.Get("Names")).(*parser.Array); ok {
+		for i := 0; i+1 < names.Len(); i += 2 {
+			if key, ok := o.doc.resolve(names.Get(i)).(*parser.String); ok {
+				o.names[string(key.Bytes())] = names.Get(i + 1)
+			}
+		}
+	}
+	if kids, ok := o.doc.resolve(dict.Get("Kids")).(*parser.Array); ok {
+		for _, kid := range kids.Elements() {
+			o.collectNames(kid, depth+1)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gxpdf_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coregx/gxpdf"
+	"github.com/coregx/gxpdf/creator"
+)
+
+func TestDocument_Outline(t *testing.T) {
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "outline.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	got, err := doc.Outline()
+	if err != nil {
+		t.Fatalf("Outline() error = %v", err)
+	}
+	want := []gxpdf.OutlineItem{
+		{Title: "Chapter 1", PageIndex: 0, Children: []gxpdf.OutlineItem{
+			{Title: "Section 1.1", PageIndex: 1}, // GoTo action
+			{Title: "Section 1.2", PageIndex: 2}, // Named in the /Names tree
+		}},
+		{Title: "Résumé", PageIndex: 1, Children: []gxpdf.OutlineItem{ // Named in /Dests
+			{Title: "Appendix — Notes", PageIndex: -1}, // URI action
+			{Title: "Index", PageIndex: -1},            // Undefined name
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Outline() = %+v\nwant %+v", got, want)
+	}
+
+	// Targets follow the page order.
+	if err := doc.RemovePage(0); err != nil {
+		t.Fatalf("RemovePage() error = %v", err)
+	}
+	if err := doc.MovePage(1, 0); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+	got, err = doc.Outline()
+	if err != nil {
+		t.Fatalf("Outline() error = %v", err)
+	}
+	chapter := got[0]
+	if chapter.PageIndex != -1 || chapter.Children[0].PageIndex != 1 || chapter.Children[1].PageIndex != 0 {
+		t.Errorf("Outline() after reordering = %+v, want pages -1, 1, 0", chapter)
+	}
+}
+
+func TestDocument_Outline_Bookmarks(t *testing.T) {
+	c := creator.New()
+	for range 3 {
+		if _, err := c.NewPage(); err != nil {
+			t.Fatalf("NewPage() error = %v", err)
+		}
+	}
+	for _, b := range []creator.Bookmark{
+		{Title: "Chapter 1", PageIndex: 0, Level: 0},
+		{Title: "Section 1.1", PageIndex: 1, Level: 1},
+		{Title: "Chapter 2", PageIndex: 2, Level: 0},
+	} {
+		if err := c.AddBookmarkAtLevel(b.Title, b.PageIndex, b.Level); err != nil {
+			t.Fatalf("AddBookmarkAtLevel() error = %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "bookmarks.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	got, err := doc.Outline()
+	if err != nil {
+		t.Fatalf("Outline() error = %v", err)
+	}
+	want := []gxpdf.OutlineItem{
+		{Title: "Chapter 1", PageIndex: 0, Children: []gxpdf.OutlineItem{
+			{Title: "Section 1.1", PageIndex: 1},
+		}},
+		{Title: "Chapter 2", PageIndex: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Outline() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDocument_Outline_None(t *testing.T) {
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "minimal.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	if got, err := doc.Outline(); got != nil || err != nil {
+		t.Errorf("Outline() = %+v, %v; want nil, nil", got, err)
+	}
+}
@@ -1,7 +1,10 @@
 package gxpdf
 
 import (
+	"image"
+
 	"github.com/coregx/gxpdf/internal/extractor"
+	"github.com/coregx/gxpdf/internal/render"
 	"github.com/coregx/gxpdf/internal/tabledetect"
 )
 
@@ -21,26 +24,72 @@ func (p *Page) Number() int {
 	return p.index + 1
 }
 
-// ExtractText extracts all text from the page.
+// ExtractText extracts all text from the page in reading order.
 //
-// Returns the text content as a single string.
+// Text is decoded with each font's ToUnicode CMap or encoding and laid
+// out by position: lines from top to bottom, separated by "\n", and
+// text within a line from left to right, with a space wherever there is
+// a visible gap between two pieces of text.
 //
 // Example:
 //
-//	text := page.ExtractText()
+//	text, err := page.ExtractText()
+//	if err != nil {
+//	    return err
+//	}
 //	fmt.Println(text)
-func (p *Page) ExtractText() string {
+func (p *Page) ExtractText() (string, error) {
 	textExtractor := extractor.NewTextExtractor(p.doc.reader)
-	elements, err := textExtractor.ExtractFromPage(p.index)
+	elements, err := textExtractor.ExtractFromPage(p.doc.sourcePage(p.index))
 	if err != nil {
-		return ""
+		return "", err
 	}
+	return extractor.LayoutText(elements), nil
+}
 
-	var result string
-	for _, elem := range elements {
-		result += elem.Text + " "
-	}
-	return result
+// TextRun is a positioned piece of text passed to a WalkText visitor.
+//
+// Coordinates are in PDF user space: points from the bottom-left corner
+// of the page, with Y increasing upward. X and Y are the start of the
+// run's baseline.
+type TextRun struct {
+	Text     string  // Decoded text
+	X        float64 // Baseline start X (points)
+	Y        float64 // Baseline Y (points)
+	Width    float64 // Approximate width (points)
+	Height   float64 // Approximate height, the font size (points)
+	FontName string  // Font resource name (e.g., "F1")
+	FontSize float64 // Font size (points)
+}
+
+// WalkText calls visit for each text run on the page, in content stream
+// order, as the page's content is decoded.
+//
+// WalkText is the primitive beneath ExtractText. It does not collect the
+// page's text, and keeps the position of every run, which is useful for
+// building search indexes, mapping search hits back to page coordinates
+// for highlighting, and layout-aware extraction.
+//
+// Example:
+//
+//	err := page.WalkText(func(run gxpdf.TextRun) {
+//	    if strings.Contains(run.Text, "Total") {
+//	        fmt.Printf("found at (%.0f, %.0f)\n", run.X, run.Y)
+//	    }
+//	})
+func (p *Page) WalkText(visit func(run TextRun)) error {
+	textExtractor := extractor.NewTextExtractor(p.doc.reader)
+	return textExtractor.WalkPage(p.doc.sourcePage(p.index), func(elem *extractor.TextElement) {
+		visit(TextRun{
+			Text:     elem.Text,
+			X:        elem.X,
+			Y:        elem.Y,
+			Width:    elem.Width,
+			Height:   elem.Height,
+			FontName: elem.FontName,
+			FontSize: elem.FontSize,
+		})
+	})
 }
 
 // ExtractTables extracts all tables from this page.
@@ -63,7 +112,7 @@ func (p *Page) ExtractTablesWithOptions(opts *ExtractionOptions) ([]*Table, erro
 	}
 
 	textExtractor := extractor.NewTextExtractor(p.doc.reader)
-	textElements, err := textExtractor.ExtractFromPage(p.index)
+	textElements, err := textExtractor.ExtractFromPage(p.doc.sourcePage(p.index))
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +171,7 @@ func (p *Page) GetImages() []*Image {
 // Use this when you need error handling for image extraction.
 func (p *Page) GetImagesWithError() ([]*Image, error) {
 	imageExtractor := extractor.NewImageExtractor(p.doc.reader)
-	internalImages, err := imageExtractor.ExtractFromPage(p.index)
+	internalImages, err := imageExtractor.ExtractFromPage(p.doc.sourcePage(p.index))
 	if err != nil {
 		return nil, err
 	}
@@ -135,3 +184,81 @@ func (p *Page) GetImagesWithError() ([]*Image, error) {
 
 	return images, nil
 }
+
+// Images returns the images drawn on the page, in drawing order, with
+// their placement.
+//
+// Image XObjects (including those drawn inside form XObjects) and inline
+// images are returned, each time they are drawn. Unlike GetImages, which
+// lists the images in the page's resources, only images the page actually
+// draws are returned, and each comes with its transformation matrix.
+// Images that cannot be read are skipped.
+//
+// Example:
+//
+//	images, err := page.Images()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for i, img := range images {
+//	    fmt.Printf("%dx%d at (%.0f, %.0f)\n", img.Width, img.Height, img.CTM[4], img.CTM[5])
+//	    if img.Filter == "DCTDecode" {
+//	        os.WriteFile(fmt.Sprintf("image%d.jpg", i), img.Data, 0o644)
+//	    }
+//	}
+func (p *Page) Images() ([]ExtractedImage, error) {
+	imageExtractor := extractor.NewImageExtractor(p.doc.reader)
+	placed, err := imageExtractor.ExtractPlacedFromPage(p.doc.sourcePage(p.index))
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]ExtractedImage, len(placed))
+	for i, img := range placed {
+		m := img.CTM
+		images[i] = ExtractedImage{
+			Name:             img.Name,
+			Inline:           img.Inline,
+			Width:            img.Width,
+			Height:           img.Height,
+			BitsPerComponent: img.BitsPerComponent,
+			ColorSpace:       img.ColorSpace,
+			Filter:           img.Filter,
+			Data:             img.Data,
+			Encoded:          img.Encoded,
+			CTM:              [6]float64{m.A, m.B, m.C, m.D, m.E, m.F},
+		}
+	}
+	return images, nil
+}
+
+// Render rasterizes the page into an image at the given resolution.
+//
+// The image is sized to the page's media box at dpi dots per inch
+// (72 dpi = one pixel per point) and painted on a white background.
+// Encode the result with image/png or image/jpeg to save it.
+//
+// The renderer covers a practical subset of PDF graphics: filled and
+// stroked paths, clipping, device colors, images and text drawn as
+// anti-aliased glyph outlines. Embedded TrueType fonts use their own
+// outlines; the non-embedded Standard 14 fonts are drawn with the bundled
+// Go fonts (a sans serif stands in for Times), stretched to the standard
+// widths. Text in other non-embedded fonts, Symbol and ZapfDingbats is
+// drawn as glyph-sized boxes; shadings and patterns are skipped.
+//
+// Example:
+//
+//	img, err := page.Render(150)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	f, _ := os.Create("page1.png")
+//	defer f.Close()
+//	png.Encode(f, img)
+func (p *Page) Render(dpi float64) (image.Image, error) {
+	img, err := render.RenderPage(p.doc.reader, p.doc.sourcePage(p.index), dpi)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
@@ -0,0 +1,178 @@
+package gxpdf
+
+import (
+	"github.com/coregx/gxpdf/internal/document"
+	"github.com/coregx/gxpdf/internal/parser"
+	"github.com/coregx/gxpdf/internal/reader"
+)
+
+// maxPageTreeDepth limits /Parent traversal when looking up inherited
+// page attributes, so a malformed (cyclic) page tree cannot loop forever.
+const maxPageTreeDepth = 32
+
+// Rectangle is a page boundary in PDF user space: points from the
+// bottom-left corner of the page, with Y increasing upward.
+type Rectangle struct {
+	LLX, LLY float64 // Lower-left corner
+	URX, URY float64 // Upper-right corner
+}
+
+// Width returns the width of the rectangle in points.
+func (r Rectangle) Width() float64 {
+	return r.URX - r.LLX
+}
+
+// Height returns the height of the rectangle in points.
+func (r Rectangle) Height() float64 {
+	return r.URY - r.LLY
+}
+
+// defaultMediaBox is used for pages without a valid /MediaBox (US Letter).
+var defaultMediaBox = Rectangle{0, 0, 612, 792}
+
+// MediaBox returns the page's media box: the boundaries of the physical
+// medium the page is to be printed on.
+//
+// The media box may be set on the page or inherited from an ancestor in
+// the page tree. Pages without a valid media box report US Letter
+// (612 x 792 points).
+//
+// Reference: PDF 1.7 specification, Section 14.11.2 (Page Boundaries).
+func (p *Page) MediaBox() Rectangle {
+	if box, ok := p.box("MediaBox"); ok {
+		return box
+	}
+	return defaultMediaBox
+}
+
+// CropBox returns the page's crop box: the region of the page that is
+// displayed or printed.
+//
+// The crop box may be set on the page or inherited from an ancestor in
+// the page tree. It defaults to the media box and is clipped to it.
+func (p *Page) CropBox() Rectangle {
+	media := p.MediaBox()
+	crop, ok := p.box("CropBox")
+	if !ok {
+		return media
+	}
+
+	crop = Rectangle{
+		LLX: max(crop.LLX, media.LLX),
+		LLY: max(crop.LLY, media.LLY),
+		URX: min(crop.URX, media.URX),
+		URY: min(crop.URY, media.URY),
+	}
+	if crop.Width() <= 0 || crop.Height() <= 0 {
+		return media
+	}
+	return crop
+}
+
+// Rotation returns the number of degrees the page is rotated clockwise
+// when displayed or printed: 0, 90, 180 or 270.
+//
+// The rotation may be set on the page or inherited from an ancestor in
+// the page tree. Values that are not a multiple of 90 are ignored.
+func (p *Page) Rotation() int {
+	rotate, ok := p.inherited("Rotate").(*parser.Integer)
+	if !ok || rotate.Int()%90 != 0 {
+		return 0
+	}
+	return (rotate.Int()%360 + 360) % 360
+}
+
+// Size returns the width and height of the page in points as displayed:
+// the size of the crop box, swapped if the page is rotated by 90 or 270
+// degrees.
+//
+// Example:
+//
+//	w, h := page.Size()
+//	if w > h {
+//	    fmt.Println("landscape")
+//	}
+func (p *Page) Size() (width, height float64) {
+	crop := p.CropBox()
+	if p.Rotation()%180 != 0 {
+		return crop.Height(), crop.Width()
+	}
+	return crop.Width(), crop.Height()
+}
+
+// Label returns the page label shown by viewers instead of the page
+// number (e.g., "iv" or "A-3"), as defined by the document's /PageLabels.
+//
+// Returns an empty string if the document does not label its pages. The
+// label belongs to the page, so it moves with the page when pages are
+// removed or reordered.
+//
+// Reference: PDF 1.7 specification, Section 12.4.2 (Page Labels).
+func (p *Page) Label() string {
+	labels := reader.NewPdfReaderFromParser(p.doc.reader).PageLabels()
+	if labels == nil {
+		return ""
+	}
+
+	doc := document.NewDocument()
+	if err := doc.SetPageLabels(labels); err != nil {
+		return ""
+	}
+	r, number, ok := doc.PageLabelAt(p.doc.sourcePage(p.index))
+	if !ok {
+		return ""
+	}
+	return document.FormatPageLabel(r.Style, r.Prefix, number)
+}
+
+// box returns a page boundary rectangle, normalized so that the
+// lower-left corner comes first. ok is false if the boundary is missing
+// or invalid.
+func (p *Page) box(key string) (Rectangle, bool) {
+	arr, ok := p.inherited(key).(*parser.Array)
+	if !ok || arr.Len() != 4 {
+		return Rectangle{}, false
+	}
+
+	var v [4]float64
+	for i := range v {
+		switch n := p.doc.resolve(arr.Get(i)).(type) {
+		case *parser.Integer:
+			v[i] = float64(n.Value())
+		case *parser.Real:
+			v[i] = n.Value()
+		default:
+			return Rectangle{}, false
+		}
+	}
+
+	// Any two opposite corners may be given.
+	box := Rectangle{
+		LLX: min(v[0], v[2]),
+		LLY: min(v[1], v[3]),
+		URX: max(v[0], v[2]),
+		URY: max(v[1], v[3]),
+	}
+	if box.Width() <= 0 || box.Height() <= 0 {
+		return Rectangle{}, false
+	}
+	return box, true
+}
+
+// inherited returns a page attribute, resolved, looking it up on the page
+// and then on its ancestors in the page tree.
+//
+// Reference: PDF 1.7 specification, Section 7.7.3.4 (Inheritance of Page Attributes).
+func (p *Page) inherited(key string) parser.PdfObject {
+	node, err := p.doc.reader.GetPage(p.doc.sourcePage(p.index))
+	if err != nil {
+		return nil
+	}
+	for i := 0; node != nil && i < maxPageTreeDepth; i++ {
+		if value := p.doc.resolve(node.Get(key)); value != nil {
+			return value
+		}
+		node, _ = p.doc.resolve(node.Get("Parent")).(*parser.Dictionary)
+	}
+	return nil
+}
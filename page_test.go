@@ -0,0 +1,331 @@
+package gxpdf_test
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coregx/gxpdf"
+	"github.com/coregx/gxpdf/creator"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestPage_WalkText(t *testing.T) {
+	c := creator.New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	if err := page.AddText("Header", 72, 720, creator.HelveticaBold, 18); err != nil {
+		t.Fatalf("AddText() error = %v", err)
+	}
+	if err := page.AddText("Body", 100, 500, creator.Helvetica, 12); err != nil {
+		t.Fatalf("AddText() error = %v", err)
+	}
+
+	path := t.TempDir() + "/walk.pdf"
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	var runs []gxpdf.TextRun
+	if err := doc.Page(0).WalkText(func(run gxpdf.TextRun) {
+		runs = append(runs, run)
+	}); err != nil {
+		t.Fatalf("WalkText() error = %v", err)
+	}
+
+	if len(runs) != 2 {
+		t.Fatalf("WalkText() visited %d runs, want 2: %+v", len(runs), runs)
+	}
+	want := []struct {
+		text string
+		x, y float64
+		size float64
+	}{
+		{"Header", 72, 720, 18},
+		{"Body", 100, 500, 12},
+	}
+	for i, w := range want {
+		r := runs[i]
+		if r.Text != w.text || r.X != w.x || r.Y != w.y || r.FontSize != w.size {
+			t.Errorf("run %d = %+v, want %q at (%v, %v) size %v", i, r, w.text, w.x, w.y, w.size)
+		}
+	}
+
+	if got, err := doc.Page(0).ExtractText(); err != nil || got != "Header\nBody" {
+		t.Errorf("ExtractText() = %q, %v; want %q", got, err, "Header\nBody")
+	}
+}
+
+func TestPage_ExtractText(t *testing.T) {
+	fontPath := filepath.Join(t.TempDir(), "goregular.ttf")
+	if err := os.WriteFile(fontPath, goregular.TTF, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	goFont, err := creator.LoadFont(fontPath)
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	c := creator.New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Body text first: extraction follows the page layout, not the
+	// content stream order.
+	check(page.AddText("Line two of the body", 72, 660, creator.TimesRoman, 12))
+	check(page.AddText("Quarterly Report", 72, 720, creator.HelveticaBold, 18))
+	check(page.AddText("Line one of the body", 72, 680, creator.TimesRoman, 12))
+
+	// Separate runs on one line, with and without a gap between them.
+	label := "Total:"
+	check(page.AddText(label, 72, 640, creator.Helvetica, 12))
+	check(page.AddText("1,024", 72+creator.MeasureText(label+" ", creator.Helvetica, 12), 640, creator.Helvetica, 12))
+	check(page.AddText("Sub", 300, 640, creator.Helvetica, 12))
+	check(page.AddText("total", 300+creator.MeasureText("Sub", creator.Helvetica, 12), 640, creator.Helvetica, 12))
+
+	// Embedded font: glyph IDs decoded through the ToUnicode CMap.
+	check(page.AddTextCustomFont("Grüße, Ελλάδα", 72, 620, goFont, 12))
+
+	path := filepath.Join(t.TempDir(), "text.pdf")
+	check(c.WriteToFile(path))
+
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	want := "Quarterly Report\n" +
+		"Line one of the body\n" +
+		"Line two of the body\n" +
+		"Total: 1,024 Subtotal\n" +
+		"Grüße, Ελλάδα"
+	got, err := doc.Page(0).ExtractText()
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("ExtractText() = %q, want %q", got, want)
+	}
+}
+
+func TestPage_ExtractText_Paragraph(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog, then runs back " +
+		"across the field to jump over the sleeping dog once more."
+
+	c := creator.New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	opts := &creator.ParagraphOptions{Font: creator.TimesRoman, Size: 11, Align: creator.AlignJustify}
+	if _, err := page.AddParagraph(text, 72, 700, 180, opts); err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "paragraph.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	got, err := doc.Page(0).ExtractText()
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if strings.Count(got, "\n") < 2 {
+		t.Errorf("ExtractText() = %q, want the wrapped lines on separate lines", got)
+	}
+	if joined := strings.Join(strings.Fields(got), " "); joined != text {
+		t.Errorf("ExtractText() words = %q, want %q", joined, text)
+	}
+}
+
+func TestDocument_ExtractText(t *testing.T) {
+	doc, err := gxpdf.Open(writeTestPDF(t, "First page", "Second page"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	got, err := doc.ExtractText()
+	if err != nil {
+		t.Fatalf("ExtractText() error = %v", err)
+	}
+	if want := "First page\n\nSecond page"; got != want {
+		t.Errorf("ExtractText() = %q, want %q", got, want)
+	}
+}
+
+func TestPage_ExtractText_WrongStreamLength(t *testing.T) {
+	// The content streams of this fixture declare a /Length that runs
+	// into the endstream keyword.
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "multipage.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	for i, want := range []string{"Page 1", "Page 2", "Page 3"} {
+		got, err := doc.Page(i).ExtractText()
+		if err != nil {
+			t.Fatalf("page %d: ExtractText() error = %v", i+1, err)
+		}
+		if got != want {
+			t.Errorf("page %d: ExtractText() = %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestPage_Images(t *testing.T) {
+	photo := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for i := range photo.Pix {
+		photo.Pix[i] = 0x80
+	}
+	icon := image.NewGray(image.Rect(0, 0, 3, 2))
+	icon.Pix = []byte{0, 50, 100, 150, 200, 250}
+
+	c := creator.New()
+	page, err := c.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage() error = %v", err)
+	}
+	for _, img := range []struct {
+		src        image.Image
+		format     string
+		x, y, w, h float64
+	}{
+		{photo, "jpeg", 72, 500, 200, 150},
+		{icon, "png", 300, 100, 30, 20},
+	} {
+		pdfImg, err := creator.ImageFromGo(img.src, img.format)
+		if err != nil {
+			t.Fatalf("ImageFromGo() error = %v", err)
+		}
+		if err := page.DrawImage(pdfImg, img.x, img.y, img.w, img.h); err != nil {
+			t.Fatalf("DrawImage() error = %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "images.pdf")
+	if err := c.WriteToFile(path); err != nil {
+		t.Fatalf("WriteToFile() error = %v", err)
+	}
+	doc, err := gxpdf.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	images, err := doc.Page(0).Images()
+	if err != nil {
+		t.Fatalf("Images() error = %v", err)
+	}
+	if len(images) != 2 {
+		t.Fatalf("Images() found %d images, want 2", len(images))
+	}
+
+	jpg := images[0]
+	if jpg.Width != 40 || jpg.Height != 30 || jpg.Filter != "DCTDecode" || jpg.ColorSpace != "DeviceRGB" {
+		t.Errorf("JPEG image = %dx%d, filter %q, color space %q; want 40x30 DCTDecode DeviceRGB",
+			jpg.Width, jpg.Height, jpg.Filter, jpg.ColorSpace)
+	}
+	if decoded, err := jpeg.Decode(bytes.NewReader(jpg.Data)); err != nil || decoded.Bounds().Dx() != 40 {
+		t.Errorf("JPEG data does not decode to the image: %v", err)
+	}
+	if want := [6]float64{200, 0, 0, 150, 72, 500}; jpg.CTM != want {
+		t.Errorf("JPEG CTM = %v, want %v", jpg.CTM, want)
+	}
+
+	raw := images[1]
+	if raw.Width != 3 || raw.Height != 2 || raw.Filter != "" || raw.ColorSpace != "DeviceGray" || raw.BitsPerComponent != 8 {
+		t.Errorf("PNG image = %dx%d, filter %q, color space %q, %d bits; want 3x2 raw DeviceGray 8 bits",
+			raw.Width, raw.Height, raw.Filter, raw.ColorSpace, raw.BitsPerComponent)
+	}
+	if !bytes.Equal(raw.Data, icon.Pix) {
+		t.Errorf("PNG data = %v, want %v", raw.Data, icon.Pix)
+	}
+	if want := [6]float64{30, 0, 0, 20, 300, 100}; raw.CTM != want {
+		t.Errorf("PNG CTM = %v, want %v", raw.CTM, want)
+	}
+	if raw.Name == "" || raw.Name == jpg.Name || raw.Inline {
+		t.Errorf("image names = %q, %q; want two XObject names", jpg.Name, raw.Name)
+	}
+}
+
+func TestPage_Geometry(t *testing.T) {
+	// The MediaBox is only on the page tree root; see
+	// testdata/generators/inherited_boxes.go.
+	doc, err := gxpdf.Open(filepath.Join("testdata", "pdfs", "inherited_boxes.pdf"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+
+	a4 := gxpdf.Rectangle{LLX: 0, LLY: 0, URX: 595, URY: 842}
+	letter := gxpdf.Rectangle{LLX: 0, LLY: 0, URX: 612, URY: 792}
+	tests := []struct {
+		media, crop gxpdf.Rectangle
+		rotation    int
+		w, h        float64
+		label       string
+	}{
+		{a4, a4, 0, 595, 842, "i"},
+		{a4, gxpdf.Rectangle{LLX: 10, LLY: 10, URX: 585, URY: 832}, 90, 822, 575, "ii"},
+		{letter, gxpdf.Rectangle{LLX: 10, LLY: 10, URX: 585, URY: 792}, 0, 575, 782, "A-5"},
+		{letter, letter, 90, 792, 612, "A-6"},
+	}
+	if doc.PageCount() != len(tests) {
+		t.Fatalf("PageCount() = %d, want %d", doc.PageCount(), len(tests))
+	}
+	for i, tt := range tests {
+		page := doc.Page(i)
+		if got := page.MediaBox(); got != tt.media {
+			t.Errorf("page %d: MediaBox() = %+v, want %+v", i+1, got, tt.media)
+		}
+		if got := page.CropBox(); got != tt.crop {
+			t.Errorf("page %d: CropBox() = %+v, want %+v", i+1, got, tt.crop)
+		}
+		if got := page.Rotation(); got != tt.rotation {
+			t.Errorf("page %d: Rotation() = %d, want %d", i+1, got, tt.rotation)
+		}
+		if w, h := page.Size(); w != tt.w || h != tt.h {
+			t.Errorf("page %d: Size() = %v x %v, want %v x %v", i+1, w, h, tt.w, tt.h)
+		}
+		if got := page.Label(); got != tt.label {
+			t.Errorf("page %d: Label() = %q, want %q", i+1, got, tt.label)
+		}
+	}
+
+	// Attributes follow the page when pages are reordered.
+	if err := doc.MovePage(3, 0); err != nil {
+		t.Fatalf("MovePage() error = %v", err)
+	}
+	if got := doc.Page(0).Label(); got != "A-6" || doc.Page(0).MediaBox() != letter {
+		t.Errorf("moved page: Label() = %q, MediaBox() = %+v; want A-6, %+v", got, doc.Page(0).MediaBox(), letter)
+	}
+}
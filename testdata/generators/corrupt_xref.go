@@ -0,0 +1,65 @@
+//go:build ignore
+
+// Generator for testdata/pdfs/corrupt_xref.pdf
+//
+// This creates a three-page PDF whose xref table has wrong offsets, as
+// written by buggy tools: the catalog and page tree entries point at the
+// next object, and the page entries point into the middle of the object
+// before them, where nothing parses. Objects are separated by more than
+// 4KB of padding, so they can only be found by scanning the whole file.
+//
+// Run with: go run corrupt_xref.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var pdf bytes.Buffer
+	padding := "%" + strings.Repeat("-", 5000) + "\n"
+
+	// Header
+	pdf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	objects := []string{
+		"<</Type/Catalog/Pages 2 0 R>>",
+		"<</Type/Pages/Kids[3 0 R 4 0 R 5 0 R]/Count 3>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 595 842]>>",
+	}
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		pdf.WriteString(padding)
+		offsets[i] = pdf.Len()
+		pdf.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, obj))
+	}
+
+	// Corrupt the xref: objects 1-2 point at the next object, pages 3-5
+	// point past the "N 0 " of the object before them.
+	wrong := []int{offsets[1], offsets[2], offsets[1] + 4, offsets[2] + 4, offsets[3] + 4}
+
+	xrefOff := pdf.Len()
+	pdf.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, off := range wrong {
+		pdf.WriteString(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	pdf.WriteString(fmt.Sprintf("trailer\n<</Size %d/Root 1 0 R>>\n", len(objects)+1))
+
+	// startxref and EOF
+	pdf.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOff))
+
+	// Write to testdata/pdfs
+	outputPath := filepath.Join("..", "pdfs", "corrupt_xref.pdf")
+	err := os.WriteFile(outputPath, pdf.Bytes(), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing PDF: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s (%d bytes)\n", outputPath, pdf.Len())
+}
@@ -0,0 +1,250 @@
+//go:build ignore
+
+// Generator for testdata/pdfs/encrypted_rc4.pdf, encrypted_aes128.pdf and
+// encrypted_aes256.pdf
+//
+// This creates minimal one-page PDFs encrypted with the Standard Security
+// Handler and an empty user password (owner password "owner"):
+//   - encrypted_rc4.pdf: V 2, R 3, 128-bit RC4
+//   - encrypted_aes128.pdf: V 4, R 4, /StdCF crypt filter with /AESV2
+//   - encrypted_aes256.pdf: V 5, R 6, /StdCF crypt filter with /AESV3
+//
+// All have a FlateDecode content stream showing "Encrypted Hello" and an
+// /Info dictionary with the encrypted title "Secret Report".
+//
+// Run with: go run encrypted.go
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rc4"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const padding = "\x28\xBF\x4E\x5E\x4E\x75\x8A\x41\x64\x00\x4E\x56" +
+	"\xFF\xFA\x01\x08\x2E\x2E\x00\xB6\xD0\x68\x3E\x80\x2F\x0C" +
+	"\xA9\xFE\x64\x53\x69\x7A"
+
+// fileID is the first (and second) element of the trailer /ID.
+var fileID = []byte("gxpdf-encrypted!")
+
+var permissions int32 = -3904 // Print, copy and fill forms
+
+func main() {
+	for _, name := range []string{"rc4", "aes128", "aes256"} {
+		path := filepath.Join("..", "pdfs", "encrypted_"+name+".pdf")
+		if err := os.WriteFile(path, build(name), 0o644); err != nil {
+			panic(err)
+		}
+		fmt.Println("wrote", path)
+	}
+}
+
+func build(method string) []byte {
+	var encrypt string
+	var crypt func(objNum int, data []byte) []byte
+
+	switch method {
+	case "aes256":
+		key := sha256.Sum256([]byte("gxpdf file key")) // Fixed for reproducible output
+		u, ue := r6Hash("", "user", key[:], nil)
+		o, oe := r6Hash("owner", "owner", key[:], u)
+		encrypt = fmt.Sprintf("<</Filter/Standard/V 5/R 6/Length 256"+
+			"/CF<</StdCF<</CFM/AESV3/AuthEvent/DocOpen/Length 32>>>>/StmF/StdCF/StrF/StdCF"+
+			"/O<%x>/U<%x>/OE<%x>/UE<%x>/Perms<%x>/P %d>>", o, u, oe, ue, r6Perms(key[:]), permissions)
+		crypt = func(objNum int, data []byte) []byte {
+			return aesCBC(key[:], objNum, data)
+		}
+
+	default:
+		o := ownerHash("owner")
+		key := fileKey(o)
+		u := userHash(key)
+		encrypt = fmt.Sprintf("<</Filter/Standard/V 2/R 3/Length 128/O<%x>/U<%x>/P %d>>", o, u, permissions)
+		if method == "aes128" {
+			encrypt = fmt.Sprintf("<</Filter/Standard/V 4/R 4/Length 128"+
+				"/CF<</StdCF<</CFM/AESV2/AuthEvent/DocOpen/Length 16>>>>/StmF/StdCF/StrF/StdCF"+
+				"/O<%x>/U<%x>/P %d>>", o, u, permissions)
+		}
+		crypt = func(objNum int, data []byte) []byte {
+			h := md5.New()
+			h.Write(key)
+			h.Write([]byte{byte(objNum), byte(objNum >> 8), byte(objNum >> 16), 0, 0})
+			if method == "rc4" {
+				out := make([]byte, len(data))
+				c, _ := rc4.NewCipher(h.Sum(nil)[:16])
+				c.XORKeyStream(out, data)
+				return out
+			}
+			h.Write([]byte("sAlT"))
+			return aesCBC(h.Sum(nil)[:16], objNum, data)
+		}
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte("BT /F1 24 Tf 72 700 Td (Encrypted Hello) Tj ET"))
+	zw.Close()
+	content := crypt(4, compressed.Bytes())
+
+	objects := []string{
+		"<</Type/Catalog/Pages 2 0 R>>",
+		"<</Type/Pages/Kids[3 0 R]/Count 1>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]/Contents 4 0 R/Resources<</Font<</F1 5 0 R>>>>>>",
+		fmt.Sprintf("<</Length %d/Filter/FlateDecode>>\nstream\n%s\nendstream", len(content), content),
+		"<</Type/Font/Subtype/Type1/BaseFont/Helvetica>>",
+		fmt.Sprintf("<</Title<%x>>>", crypt(6, []byte("Secret Report"))),
+		encrypt,
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.6\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<</Size %d/Root 1 0 R/Info 6 0 R/Encrypt 7 0 R/ID[<%x><%x>]>>\n",
+		len(objects)+1, fileID, fileID)
+	fmt.Fprintf(&pdf, "startxref\n%d\n%%%%EOF\n", xref)
+	return pdf.Bytes()
+}
+
+// aesCBC encrypts data with AES-CBC and PKCS#7 padding, prepending an IV
+// derived from the object number.
+func aesCBC(key []byte, objNum int, data []byte) []byte {
+	block, _ := aes.NewCipher(key)
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	plain := append(append([]byte{}, data...), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	iv := bytes.Repeat([]byte{byte(objNum)}, aes.BlockSize) // Fixed for reproducible output
+	out := append(append([]byte{}, iv...), make([]byte, len(plain))...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out[aes.BlockSize:], plain)
+	return out
+}
+
+// r6Hash computes /U or /O (hash, validation salt, key salt) and /UE or
+// /OE for R 6 (Algorithms 8 and 9). userKey is nil for /U and /U for /O.
+func r6Hash(password, saltSeed string, key, userKey []byte) ([]byte, []byte) {
+	salts := sha256.Sum256([]byte(saltSeed)) // Fixed for reproducible output
+	validation, keySalt := salts[:8], salts[8:16]
+
+	out := append(hash2B([]byte(password), validation, userKey), validation...)
+	out = append(out, keySalt...)
+
+	block, _ := aes.NewCipher(hash2B([]byte(password), keySalt, userKey))
+	encrypted := make([]byte, 32)
+	cipher.NewCBCEncrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(encrypted, key)
+	return out, encrypted
+}
+
+// r6Perms computes /Perms (Algorithm 10) with /EncryptMetadata true.
+func r6Perms(key []byte) []byte {
+	p := uint32(permissions)
+	perms := []byte{byte(p), byte(p >> 8), byte(p >> 16), byte(p >> 24), 0xFF, 0xFF, 0xFF, 0xFF, 'T', 'a', 'd', 'b', 0, 0, 0, 0}
+	block, _ := aes.NewCipher(key)
+	block.Encrypt(perms, perms)
+	return perms
+}
+
+// hash2B computes the R 6 password hash (Algorithm 2.B).
+func hash2B(password, salt, userKey []byte) []byte {
+	h := sha256.New()
+	h.Write(password)
+	h.Write(salt)
+	h.Write(userKey)
+	k := h.Sum(nil)
+
+	var e []byte
+	for round := 0; round < 64 || int(e[len(e)-1]) > round-32; round++ {
+		k1 := bytes.Repeat(append(append(append([]byte{}, password...), k...), userKey...), 64)
+		block, _ := aes.NewCipher(k[:16])
+		e = make([]byte, len(k1))
+		cipher.NewCBCEncrypter(block, k[16:32]).CryptBlocks(e, k1)
+
+		sum := 0
+		for _, b := range e[:16] {
+			sum += int(b)
+		}
+		switch sum % 3 {
+		case 0:
+			s := sha256.Sum256(e)
+			k = s[:]
+		case 1:
+			s := sha512.Sum384(e)
+			k = s[:]
+		default:
+			s := sha512.Sum512(e)
+			k = s[:]
+		}
+	}
+	return k[:32]
+}
+
+// ownerHash computes /O for R 3 and 4 (Algorithm 3) with an empty user password.
+func ownerHash(owner string) []byte {
+	sum := md5.Sum(pad(owner))
+	for i := 0; i < 50; i++ {
+		sum = md5.Sum(sum[:])
+	}
+	out := pad("")
+	for i := 0; i <= 19; i++ {
+		k := make([]byte, 16)
+		for j := range k {
+			k[j] = sum[j] ^ byte(i)
+		}
+		c, _ := rc4.NewCipher(k)
+		c.XORKeyStream(out, out)
+	}
+	return out
+}
+
+// fileKey computes the 128-bit file key for the empty user password (Algorithm 2).
+func fileKey(o []byte) []byte {
+	h := md5.New()
+	h.Write(pad(""))
+	h.Write(o)
+	p := uint32(permissions)
+	h.Write([]byte{byte(p), byte(p >> 8), byte(p >> 16), byte(p >> 24)})
+	h.Write(fileID)
+	sum := h.Sum(nil)
+	for i := 0; i < 50; i++ {
+		s := md5.Sum(sum[:16])
+		sum = s[:]
+	}
+	return sum[:16]
+}
+
+// userHash computes /U for R 3 and 4 (Algorithm 5).
+func userHash(key []byte) []byte {
+	h := md5.New()
+	h.Write([]byte(padding))
+	h.Write(fileID)
+	out := h.Sum(nil)
+	for i := 0; i <= 19; i++ {
+		k := make([]byte, len(key))
+		for j := range k {
+			k[j] = key[j] ^ byte(i)
+		}
+		c, _ := rc4.NewCipher(k)
+		c.XORKeyStream(out, out)
+	}
+	return append(out, bytes.Repeat([]byte{0}, 16)...)
+}
+
+// pad pads a password to 32 bytes with the standard padding string.
+func pad(password string) []byte {
+	return []byte((password + padding)[:32])
+}
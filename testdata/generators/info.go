@@ -0,0 +1,101 @@
+//go:build ignore
+
+// Generator for testdata/pdfs/info.pdf
+//
+// This creates a one-page PDF with a fully populated document Info
+// dictionary and an XMP metadata stream. The Info fields use the string
+// forms found in the wild:
+//
+//	Title:        UTF-16BE hex string with a byte order mark
+//	Author:       literal string with escaped parentheses
+//	Subject:      literal string with a PDFDocEncoding em dash (\204)
+//	Keywords:     hex string in PDFDocEncoding
+//	Creator:      literal string
+//	Producer:     indirect reference to a literal string
+//	CreationDate: positive UT offset (+05'30')
+//	ModDate:      negative UT offset (-08'00')
+//
+// The /Metadata stream of the catalog is FlateDecode-compressed.
+//
+// Run with: go run info.go
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+const xmp = `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title><rdf:Alt><rdf:li xml:lang="x-default">Rapport annuel — 年次報告</rdf:li></rdf:Alt></dc:title>
+</rdf:Description>
+</rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+func main() {
+	var pdf bytes.Buffer
+
+	// Header
+	pdf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// UTF-16BE title with BOM, including a surrogate pair.
+	title := []byte{0xFE, 0xFF}
+	for _, u := range utf16.Encode([]rune("Rapport annuel — 年次報告 📄")) {
+		title = append(title, byte(u>>8), byte(u))
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte(xmp))
+	zw.Close()
+
+	objects := []string{
+		"<</Type/Catalog/Pages 2 0 R/Metadata 4 0 R>>",
+		"<</Type/Pages/Kids[3 0 R]/Count 1>>",
+		"<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]/Resources<<>>>>",
+		fmt.Sprintf("<</Type/Metadata/Subtype/XML/Length %d/Filter/FlateDecode>>\nstream\n%s\nendstream",
+			compressed.Len(), compressed.String()),
+		"<</Title<" + strings.ToUpper(hex.EncodeToString(title)) + ">" +
+			"/Author(Jane \\(J.\\) Doe)" +
+			"/Subject(Annual report \\204 2024)" +
+			"/Keywords<" + hex.EncodeToString([]byte("finance, report")) + ">" +
+			"/Creator(gxpdf test generator)" +
+			"/Producer 6 0 R" +
+			"/CreationDate(D:20240315093000+05'30')" +
+			"/ModDate(D:20241201120000-08'00')>>",
+		"(gxpdf)",
+	}
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = pdf.Len()
+		pdf.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, obj))
+	}
+
+	xrefOff := pdf.Len()
+	pdf.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, off := range offsets {
+		pdf.WriteString(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	pdf.WriteString(fmt.Sprintf("trailer\n<</Size %d/Root 1 0 R/Info 5 0 R>>\n", len(objects)+1))
+
+	// startxref and EOF
+	pdf.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOff))
+
+	// Write to testdata/pdfs
+	outputPath := filepath.Join("..", "pdfs", "info.pdf")
+	err := os.WriteFile(outputPath, pdf.Bytes(), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing PDF: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s (%d bytes)\n", outputPath, pdf.Len())
+}
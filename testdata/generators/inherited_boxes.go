@@ -0,0 +1,67 @@
+//go:build ignore
+
+// Generator for testdata/pdfs/inherited_boxes.pdf
+//
+// This creates a four-page PDF whose page geometry is mostly inherited
+// from the page tree. The MediaBox is only on the Pages root, and an
+// intermediate Pages node sets /Rotate and a CropBox for its kids:
+//
+//	page 1: everything inherited from the root (A4, no rotation)
+//	page 2: root MediaBox, intermediate CropBox and /Rotate 90
+//	page 3: own MediaBox (Letter) and /Rotate 0; the inherited
+//	        CropBox extends past the MediaBox
+//	page 4: own MediaBox with swapped corners, CropBox larger than
+//	        the MediaBox, /Rotate -270
+//
+// Pages are labelled i, ii, A-5, A-6.
+//
+// Run with: go run inherited_boxes.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var pdf bytes.Buffer
+
+	// Header
+	pdf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	objects := []string{
+		"<</Type/Catalog/Pages 2 0 R/PageLabels<</Nums[0<</S/r>>2<</S/D/P(A-)/St 5>>]>>>>",
+		"<</Type/Pages/Kids[3 0 R 4 0 R]/Count 4/MediaBox[0 0 595 842]/Resources<<>>>>",
+		"<</Type/Page/Parent 2 0 R>>",
+		"<</Type/Pages/Parent 2 0 R/Kids[5 0 R 6 0 R 7 0 R]/Count 3/Rotate 90/CropBox[10 10 585 832]>>",
+		"<</Type/Page/Parent 4 0 R>>",
+		"<</Type/Page/Parent 4 0 R/MediaBox[0 0 612 792]/Rotate 0>>",
+		"<</Type/Page/Parent 4 0 R/MediaBox[612 792 0 0]/CropBox[-100 -100 2000 2000]/Rotate -270>>",
+	}
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = pdf.Len()
+		pdf.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, obj))
+	}
+
+	xrefOff := pdf.Len()
+	pdf.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, off := range offsets {
+		pdf.WriteString(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	pdf.WriteString(fmt.Sprintf("trailer\n<</Size %d/Root 1 0 R>>\n", len(objects)+1))
+
+	// startxref and EOF
+	pdf.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOff))
+
+	// Write to testdata/pdfs
+	outputPath := filepath.Join("..", "pdfs", "inherited_boxes.pdf")
+	err := os.WriteFile(outputPath, pdf.Bytes(), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing PDF: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s (%d bytes)\n", outputPath, pdf.Len())
+}
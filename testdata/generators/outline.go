@@ -0,0 +1,85 @@
+//go:build ignore
+
+// Generator for testdata/pdfs/outline.pdf
+//
+// This creates a three-page PDF (the last two pages under an intermediate
+// page tree node) with a two-level outline whose items reach their pages
+// in each of the ways a reader must handle:
+//
+//	Chapter 1             /Dest array                   -> page 1
+//	  Section 1.1         /A GoTo action                -> page 2
+//	  Section 1.2         named (string) destination,   -> page 3
+//	                      via the /Names /Dests tree
+//	Résumé                named (name) destination,     -> page 2
+//	                      via the catalog /Dests dict;
+//	                      UTF-16BE title, closed
+//	  Appendix — Notes    URI action, PDFDocEncoding    -> no page
+//	  Index               undefined named destination   -> no page
+//
+// Run with: go run outline.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var pdf bytes.Buffer
+
+	// Header
+	pdf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	objects := []string{
+		// 1: Catalog
+		"<</Type/Catalog/Pages 2 0 R/Outlines 7 0 R/Names<</Dests 14 0 R>>/Dests 17 0 R/PageMode/UseOutlines>>",
+		// 2-6: Page tree
+		"<</Type/Pages/Kids[3 0 R 4 0 R]/Count 3/MediaBox[0 0 612 792]>>",
+		"<</Type/Page/Parent 2 0 R>>",
+		"<</Type/Pages/Parent 2 0 R/Kids[5 0 R 6 0 R]/Count 2>>",
+		"<</Type/Page/Parent 4 0 R>>",
+		"<</Type/Page/Parent 4 0 R>>",
+		// 7: Outline root
+		"<</Type/Outlines/First 8 0 R/Last 11 0 R/Count 4>>",
+		// 8-10: Chapter 1 and its sections
+		"<</Title(Chapter 1)/Parent 7 0 R/Next 11 0 R/First 9 0 R/Last 10 0 R/Count 2/Dest[3 0 R/XYZ 0 792 0]>>",
+		"<</Title(Section 1.1)/Parent 8 0 R/Next 10 0 R/A<</S/GoTo/D[5 0 R/Fit]>>>>",
+		"<</Title(Section 1.2)/Parent 8 0 R/Prev 9 0 R/Dest(sec12)>>",
+		// 11-13: Résumé (closed) and its children
+		"<</Title<FEFF005200E900730075006D00E9>/Parent 7 0 R/Prev 8 0 R/First 12 0 R/Last 13 0 R/Count -2/Dest/summary>>",
+		"<</Title(Appendix \\204 Notes)/Parent 11 0 R/Next 13 0 R/A<</S/URI/URI(https://example.com/)>>>>",
+		"<</Title(Index)/Parent 11 0 R/Prev 12 0 R/Dest(missing)>>",
+		// 14-16: /Dests name tree
+		"<</Kids[15 0 R]>>",
+		"<</Limits[(intro)(sec12)]/Names[(intro)[3 0 R/Fit](sec12)16 0 R]>>",
+		"<</D[6 0 R/FitH 700]>>",
+		// 17: Catalog /Dests dictionary
+		"<</summary[5 0 R/Fit]>>",
+	}
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = pdf.Len()
+		pdf.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, obj))
+	}
+
+	xrefOff := pdf.Len()
+	pdf.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, off := range offsets {
+		pdf.WriteString(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	pdf.WriteString(fmt.Sprintf("trailer\n<</Size %d/Root 1 0 R>>\n", len(objects)+1))
+
+	// startxref and EOF
+	pdf.WriteString(fmt.Sprintf("startxref\n%d\n%%%%EOF\n", xrefOff))
+
+	// Write to testdata/pdfs
+	outputPath := filepath.Join("..", "pdfs", "outline.pdf")
+	err := os.WriteFile(outputPath, pdf.Bytes(), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing PDF: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s (%d bytes)\n", outputPath, pdf.Len())
+}